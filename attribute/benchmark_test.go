@@ -15,6 +15,7 @@
 package attribute_test
 
 import (
+	"fmt"
 	"testing"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -23,8 +24,8 @@ import (
 type test struct{}
 
 var (
-	arrayVal    = []string{"one", "two"}
-	arrayKeyVal = attribute.Array("array", arrayVal)
+	stringSliceVal    = []string{"one", "two"}
+	stringSliceKeyVal = attribute.StringSlice("string_slice", stringSliceVal)
 
 	boolVal    = true
 	boolKeyVal = attribute.Bool("bool", boolVal)
@@ -51,17 +52,17 @@ var (
 	structVal = test{}
 )
 
-func BenchmarkArrayKey(b *testing.B) {
+func BenchmarkStringSliceKey(b *testing.B) {
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
-		_ = attribute.Array("array", arrayVal)
+		_ = attribute.StringSlice("string_slice", stringSliceVal)
 	}
 }
 
-func BenchmarkArrayKeyAny(b *testing.B) {
+func BenchmarkStringSliceKeyAny(b *testing.B) {
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
-		_ = attribute.Any("array", arrayVal)
+		_ = attribute.Any("string_slice", stringSliceVal)
 	}
 }
 
@@ -163,10 +164,10 @@ func BenchmarkStructKeyAny(b *testing.B) {
 	}
 }
 
-func BenchmarkEmitArray(b *testing.B) {
+func BenchmarkEmitStringSlice(b *testing.B) {
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
-		_ = arrayKeyVal.Value.Emit()
+		_ = stringSliceKeyVal.Value.Emit()
 	}
 }
 
@@ -218,3 +219,107 @@ func BenchmarkEmitString(b *testing.B) {
 		_ = stringKeyVal.Value.Emit()
 	}
 }
+
+func BenchmarkNewSet(b *testing.B) {
+	kvs := []attribute.KeyValue{stringKeyVal, boolKeyVal, intKeyVal}
+	cpy := make([]attribute.KeyValue, len(kvs))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		copy(cpy, kvs)
+		_ = attribute.NewSet(cpy...)
+	}
+}
+
+func BenchmarkNewSetFromSorted(b *testing.B) {
+	kvs := []attribute.KeyValue{boolKeyVal, intKeyVal, stringKeyVal}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = attribute.NewSetFromSorted(kvs...)
+	}
+}
+
+func BenchmarkSetBuilder(b *testing.B) {
+	var builder attribute.SetBuilder
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		builder.Add(stringKeyVal, boolKeyVal, intKeyVal)
+		_ = builder.Build()
+	}
+}
+
+// highCardinalitySets returns n distinct sets of the labels of a typical
+// HTTP server request metric.
+func highCardinalitySets(n int) []attribute.Set {
+	sets := make([]attribute.Set, n)
+	for i := range sets {
+		sets[i] = attribute.NewSet(
+			attribute.String("http.method", "GET"),
+			attribute.String("http.route", fmt.Sprintf("/api/v1/items/%d", i)),
+			attribute.Int("http.status_code", 200+i%5),
+			attribute.String("net.host.name", "frontend.example.com"),
+			attribute.String("user_agent.original", "Mozilla/5.0 (X11; Linux x86_64)"),
+		)
+	}
+	return sets
+}
+
+func BenchmarkSetEquals(b *testing.B) {
+	sets := highCardinalitySets(2)
+	same := attribute.NewSet(sets[0].ToSlice()...)
+	b.Run("Equal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = sets[0].Equals(&same)
+		}
+	})
+	b.Run("Distinct", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = sets[0].Equals(&sets[1])
+		}
+	})
+	b.Run("DistinctEquivalent", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = sets[0].Equivalent() == sets[1].Equivalent()
+		}
+	})
+}
+
+// BenchmarkSetMapLookup compares looking up high-cardinality sets in
+// maps keyed by their Distinct value, by their encoding and by their hash.
+func BenchmarkSetMapLookup(b *testing.B) {
+	const n = 10000
+	sets := highCardinalitySets(n)
+	enc := attribute.DefaultEncoder()
+
+	b.Run("Distinct", func(b *testing.B) {
+		m := make(map[attribute.Distinct]int, n)
+		for i := range sets {
+			m[sets[i].Equivalent()] = i
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m[sets[i%n].Equivalent()]
+		}
+	})
+	b.Run("Encoded", func(b *testing.B) {
+		m := make(map[string]int, n)
+		for i := range sets {
+			m[sets[i].Encoded(enc)] = i
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = m[sets[i%n].Encoded(enc)]
+		}
+	})
+	b.Run("Hash", func(b *testing.B) {
+		m := make(map[uint64]int, n)
+		for i := range sets {
+			m[sets[i].Hash()] = i
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			s := &sets[i%n]
+			j := m[s.Hash()]
+			_ = sets[j].Equals(s)
+		}
+	})
+}
@@ -16,6 +16,7 @@ package attribute // import "go.opentelemetry.io/otel/attribute"
 
 import (
 	"bytes"
+	"container/list"
 	"sync"
 	"sync/atomic"
 )
@@ -53,6 +54,26 @@ type (
 		// allocate new memory.
 		pool sync.Pool // *bytes.Buffer
 	}
+
+	// cachedEncoder wraps an Encoder with a size-bounded, least
+	// recently used cache of encodings keyed by the hash of the
+	// encoded label set.  Unlike the per-Set cache, this one is
+	// shared by all the Sets with equivalent contents, so label
+	// sets rebuilt for every collection are only encoded once.
+	cachedEncoder struct {
+		encoder Encoder
+		size    int
+
+		lock    sync.Mutex
+		entries map[uint64]*list.Element
+		order   *list.List // of *cachedEncoding, most recent first
+	}
+
+	cachedEncoding struct {
+		hash     uint64
+		distinct Distinct
+		encoded  string
+	}
 )
 
 // escapeChar is used to ensure uniqueness of the label encoding where
@@ -64,6 +85,7 @@ const escapeChar = '\\'
 
 var (
 	_ Encoder = &defaultLabelEncoder{}
+	_ Encoder = &cachedEncoder{}
 
 	// encoderIDCounter is for generating IDs for other label
 	// encoders.
@@ -131,6 +153,82 @@ func (*defaultLabelEncoder) ID() EncoderID {
 	return defaultEncoderID
 }
 
+// NewCachedEncoder returns an Encoder that produces the same encodings
+// as encoder, but remembers the encodings of up to size distinct label
+// sets.  Equivalent label sets are encoded only once for as long as
+// they stay in the cache, even when they are distinct Set values, e.g.
+// label sets re-created by a processor for every collection.  The
+// least recently used encoding is evicted when the cache is full.
+//
+// Exporters register their own encoding, e.g. of the label names and
+// values of their wire format, by wrapping their Encoder.  The returned
+// Encoder has the same ID as encoder, so it can be passed anywhere
+// encoder is used.  If size is not positive, encoder is returned
+// unchanged.
+func NewCachedEncoder(encoder Encoder, size int) Encoder {
+	if size <= 0 {
+		return encoder
+	}
+	return &cachedEncoder{
+		encoder: encoder,
+		size:    size,
+		entries: make(map[uint64]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+// Encode is a part of an implementation of the Encoder interface.
+func (c *cachedEncoder) Encode(iter Iterator) string {
+	if iter.storage == nil || iter.idx >= 0 {
+		// Not a full iteration over a Set, nothing to key on.
+		return c.encoder.Encode(iter)
+	}
+	hash := iter.storage.Hash()
+	distinct := iter.storage.Equivalent()
+
+	c.lock.Lock()
+	if elem, ok := c.entries[hash]; ok {
+		if entry := elem.Value.(*cachedEncoding); entry.distinct == distinct {
+			c.order.MoveToFront(elem)
+			c.lock.Unlock()
+			return entry.encoded
+		}
+	}
+	c.lock.Unlock()
+
+	// Encode outside of the lock, concurrent misses for the same
+	// label set produce identical results.
+	encoded := c.encoder.Encode(iter)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if elem, ok := c.entries[hash]; ok {
+		// Either the same set was encoded concurrently or another
+		// set has the same hash, which replaces it.
+		entry := elem.Value.(*cachedEncoding)
+		entry.distinct, entry.encoded = distinct, encoded
+		c.order.MoveToFront(elem)
+		return encoded
+	}
+	c.entries[hash] = c.order.PushFront(&cachedEncoding{
+		hash:     hash,
+		distinct: distinct,
+		encoded:  encoded,
+	})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedEncoding).hash)
+	}
+	return encoded
+}
+
+// ID is a part of an implementation of the Encoder interface.  It
+// returns the ID of the wrapped encoder.
+func (c *cachedEncoder) ID() EncoderID {
+	return c.encoder.ID()
+}
+
 // copyAndEscape escapes `=`, `,` and its own escape character (`\`),
 // making the default encoding unique.
 func copyAndEscape(buf *bytes.Buffer, val string) {
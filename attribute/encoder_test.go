@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attribute_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type countingEncoder struct {
+	id    attribute.EncoderID
+	calls int
+}
+
+func (e *countingEncoder) Encode(iter attribute.Iterator) string {
+	e.calls++
+	return attribute.DefaultEncoder().Encode(iter)
+}
+
+func (e *countingEncoder) ID() attribute.EncoderID {
+	return e.id
+}
+
+func TestCachedEncoder(t *testing.T) {
+	inner := &countingEncoder{id: attribute.NewEncoderID()}
+	enc := attribute.NewCachedEncoder(inner, 2)
+	assert.Equal(t, inner.ID(), enc.ID())
+
+	// Each Set is a new value, only the cache shared by the
+	// encoder avoids re-encoding equivalent sets.
+	setA := func() *attribute.Set {
+		s := attribute.NewSet(attribute.String("A", "1"))
+		return &s
+	}
+	setB := func() *attribute.Set {
+		s := attribute.NewSet(attribute.String("B", "2"))
+		return &s
+	}
+	setC := func() *attribute.Set {
+		s := attribute.NewSet(attribute.String("C", "3"))
+		return &s
+	}
+
+	assert.Equal(t, "A=1", setA().Encoded(enc))
+	assert.Equal(t, "A=1", setA().Encoded(enc))
+	assert.Equal(t, 1, inner.calls)
+
+	assert.Equal(t, "B=2", setB().Encoded(enc))
+	assert.Equal(t, "A=1", setA().Encoded(enc))
+	assert.Equal(t, 2, inner.calls)
+
+	// B is the least recently used and gets evicted.
+	assert.Equal(t, "C=3", setC().Encoded(enc))
+	assert.Equal(t, 3, inner.calls)
+	assert.Equal(t, "A=1", setA().Encoded(enc))
+	assert.Equal(t, 3, inner.calls)
+	assert.Equal(t, "B=2", setB().Encoded(enc))
+	assert.Equal(t, 4, inner.calls)
+}
+
+func TestCachedEncoderNotPositiveSize(t *testing.T) {
+	inner := &countingEncoder{id: attribute.NewEncoderID()}
+	assert.Same(t, inner, attribute.NewCachedEncoder(inner, 0))
+}
+
+func TestCachedEncoderPartialIterator(t *testing.T) {
+	inner := &countingEncoder{id: attribute.NewEncoderID()}
+	enc := attribute.NewCachedEncoder(inner, 2)
+
+	set := attribute.NewSet(attribute.String("A", "1"), attribute.String("B", "2"))
+	iter := set.Iter()
+	iter.Next()
+	enc.Encode(iter)
+	assert.Equal(t, "A=1,B=2", enc.Encode(set.Iter()))
+	assert.Equal(t, 2, inner.calls)
+}
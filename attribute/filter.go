@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attribute // import "go.opentelemetry.io/otel/attribute"
+
+import (
+	"regexp"
+	"strings"
+)
+
+// NewAllowKeysFilter returns a Filter that only keeps the attributes with
+// one of the given keys.
+func NewAllowKeysFilter(keys ...Key) Filter {
+	if len(keys) == 0 {
+		return func(KeyValue) bool { return false }
+	}
+
+	allowed := make(map[Key]struct{}, len(keys))
+	for _, k := range keys {
+		allowed[k] = struct{}{}
+	}
+	return func(kv KeyValue) bool {
+		_, ok := allowed[kv.Key]
+		return ok
+	}
+}
+
+// NewDenyKeysFilter returns a Filter that removes the attributes with one
+// of the given keys and keeps all the others.
+func NewDenyKeysFilter(keys ...Key) Filter {
+	if len(keys) == 0 {
+		return func(KeyValue) bool { return true }
+	}
+
+	denied := make(map[Key]struct{}, len(keys))
+	for _, k := range keys {
+		denied[k] = struct{}{}
+	}
+	return func(kv KeyValue) bool {
+		_, ok := denied[kv.Key]
+		return !ok
+	}
+}
+
+// NewKeyPrefixFilter returns a Filter that only keeps the attributes with a
+// key starting with one of the given prefixes. Combine it with
+// NewNotFilter to remove these attributes instead.
+func NewKeyPrefixFilter(prefixes ...string) Filter {
+	return func(kv KeyValue) bool {
+		for _, p := range prefixes {
+			if strings.HasPrefix(string(kv.Key), p) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NewKeyRegexpFilter returns a Filter that only keeps the attributes with a
+// key matching re. Combine it with NewNotFilter to remove these attributes
+// instead.
+func NewKeyRegexpFilter(re *regexp.Regexp) Filter {
+	return func(kv KeyValue) bool {
+		return re.MatchString(string(kv.Key))
+	}
+}
+
+// NewNotFilter returns a Filter that keeps the attributes removed by filter
+// and removes the attributes it keeps.
+func NewNotFilter(filter Filter) Filter {
+	return func(kv KeyValue) bool {
+		return !filter(kv)
+	}
+}
+
+// NewAllFilter returns a Filter that only keeps the attributes kept by all
+// the given filters. With no filters, all attributes are kept.
+func NewAllFilter(filters ...Filter) Filter {
+	return func(kv KeyValue) bool {
+		for _, f := range filters {
+			if !f(kv) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// NewAnyFilter returns a Filter that keeps the attributes kept by at least
+// one of the given filters. With no filters, all attributes are removed.
+func NewAnyFilter(filters ...Filter) Filter {
+	return func(kv KeyValue) bool {
+		for _, f := range filters {
+			if f(kv) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attribute_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var filterTestKVs = []attribute.KeyValue{
+	attribute.String("http.method", "GET"),
+	attribute.String("http.url", "https://example.com/?token=secret"),
+	attribute.String("user.email", "user@example.com"),
+	attribute.Int("user.id", 1),
+	attribute.Bool("sampled", true),
+}
+
+func filteredKeys(filter attribute.Filter) []attribute.Key {
+	var keys []attribute.Key
+	for _, kv := range filterTestKVs {
+		if filter(kv) {
+			keys = append(keys, kv.Key)
+		}
+	}
+	return keys
+}
+
+func TestFilters(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter attribute.Filter
+		want   []attribute.Key
+	}{
+		{
+			name:   "allow keys",
+			filter: attribute.NewAllowKeysFilter("http.method", "sampled", "missing"),
+			want:   []attribute.Key{"http.method", "sampled"},
+		},
+		{
+			name:   "allow no keys",
+			filter: attribute.NewAllowKeysFilter(),
+			want:   nil,
+		},
+		{
+			name:   "deny keys",
+			filter: attribute.NewDenyKeysFilter("http.url", "user.email"),
+			want:   []attribute.Key{"http.method", "user.id", "sampled"},
+		},
+		{
+			name:   "deny no keys",
+			filter: attribute.NewDenyKeysFilter(),
+			want:   []attribute.Key{"http.method", "http.url", "user.email", "user.id", "sampled"},
+		},
+		{
+			name:   "key prefix",
+			filter: attribute.NewKeyPrefixFilter("http.", "sam"),
+			want:   []attribute.Key{"http.method", "http.url", "sampled"},
+		},
+		{
+			name:   "key regexp",
+			filter: attribute.NewKeyRegexpFilter(regexp.MustCompile(`\.(url|email)$`)),
+			want:   []attribute.Key{"http.url", "user.email"},
+		},
+		{
+			name:   "not",
+			filter: attribute.NewNotFilter(attribute.NewKeyPrefixFilter("user.")),
+			want:   []attribute.Key{"http.method", "http.url", "sampled"},
+		},
+		{
+			name: "all",
+			filter: attribute.NewAllFilter(
+				attribute.NewKeyPrefixFilter("http.", "user."),
+				attribute.NewDenyKeysFilter("http.url", "user.email"),
+			),
+			want: []attribute.Key{"http.method", "user.id"},
+		},
+		{
+			name:   "all of none",
+			filter: attribute.NewAllFilter(),
+			want:   []attribute.Key{"http.method", "http.url", "user.email", "user.id", "sampled"},
+		},
+		{
+			name: "any",
+			filter: attribute.NewAnyFilter(
+				attribute.NewAllowKeysFilter("sampled"),
+				attribute.NewKeyPrefixFilter("user."),
+			),
+			want: []attribute.Key{"user.email", "user.id", "sampled"},
+		},
+		{
+			name:   "any of none",
+			filter: attribute.NewAnyFilter(),
+			want:   nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, filteredKeys(test.filter))
+		})
+	}
+}
+
+func TestFilterSet(t *testing.T) {
+	// NewSet sorts its argument, pass a copy to keep the shared fixture
+	// in order.
+	set := attribute.NewSet(append([]attribute.KeyValue(nil), filterTestKVs...)...)
+	filtered, removed := set.Filter(attribute.NewDenyKeysFilter("http.url", "user.email"))
+	assert.Equal(t, 3, filtered.Len())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("http.url", "https://example.com/?token=secret"),
+		attribute.String("user.email", "user@example.com"),
+	}, removed)
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attribute // import "go.opentelemetry.io/otel/attribute"
+
+// The hash of a Set is the 64-bit FNV-1a hash of its sorted labels.  It
+// is computed inline, rather than with `hash/fnv`, so that building a Set
+// does not allocate a `hash.Hash64`.
+const (
+	fnvOffset64 uint64 = 14695981039346656037
+	fnvPrime64  uint64 = 1099511628211
+)
+
+// HashKeyValues returns a 64-bit hash of kvs, in their order.  It is the
+// hash of the Set of kvs, as returned by `Set.Hash`, when they are
+// sorted and de-duplicated, which lets callers that keep labels in
+// another order look them up without building a Set.
+func HashKeyValues(kvs []KeyValue) uint64 {
+	h := fnvOffset64
+	for _, kv := range kvs {
+		h = hashString(h, string(kv.Key))
+		h = hashByte(h, byte(kv.Value.vtype))
+		h = hashUint64(h, kv.Value.numeric)
+		h = hashString(h, kv.Value.stringly)
+	}
+	return h
+}
+
+func hashByte(h uint64, b byte) uint64 {
+	h ^= uint64(b)
+	h *= fnvPrime64
+	return h
+}
+
+func hashUint64(h uint64, v uint64) uint64 {
+	for i := 0; i < 8; i++ {
+		h = hashByte(h, byte(v>>(8*i)))
+	}
+	return h
+}
+
+// hashString hashes the length of s followed by its bytes, so that
+// adjacent strings cannot be confused with each other.
+func hashString(h uint64, s string) uint64 {
+	h = hashUint64(h, uint64(len(s)))
+	for i := 0; i < len(s); i++ {
+		h = hashByte(h, s[i])
+	}
+	return h
+}
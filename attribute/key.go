@@ -88,15 +88,67 @@ func (k Key) Defined() bool {
 	return len(k) != 0
 }
 
-// Array creates a KeyValue instance with a ARRAY Value.
+// BoolSlice creates a KeyValue instance with a BOOLSLICE Value.
 //
-// If creating both key and a array value at the same time, then
-// instead of calling Key(name).String(value) consider using a
+// If creating both key and a []bool value at the same time, then
+// instead of calling Key(name).BoolSlice(value) consider using a
+// convenience function provided by the api/key package -
+// key.BoolSlice(name, value).
+func (k Key) BoolSlice(v []bool) KeyValue {
+	return KeyValue{
+		Key:   k,
+		Value: BoolSliceValue(v),
+	}
+}
+
+// Int64Slice creates a KeyValue instance with an INT64SLICE Value.
+//
+// If creating both key and a []int64 value at the same time, then
+// instead of calling Key(name).Int64Slice(value) consider using a
+// convenience function provided by the api/key package -
+// key.Int64Slice(name, value).
+func (k Key) Int64Slice(v []int64) KeyValue {
+	return KeyValue{
+		Key:   k,
+		Value: Int64SliceValue(v),
+	}
+}
+
+// IntSlice creates a KeyValue instance with an INT64SLICE Value.
+//
+// If creating both key and a []int value at the same time, then
+// instead of calling Key(name).IntSlice(value) consider using a
+// convenience function provided by the api/key package -
+// key.IntSlice(name, value).
+func (k Key) IntSlice(v []int) KeyValue {
+	return KeyValue{
+		Key:   k,
+		Value: IntSliceValue(v),
+	}
+}
+
+// Float64Slice creates a KeyValue instance with a FLOAT64SLICE Value.
+//
+// If creating both key and a []float64 value at the same time, then
+// instead of calling Key(name).Float64Slice(value) consider using a
+// convenience function provided by the api/key package -
+// key.Float64Slice(name, value).
+func (k Key) Float64Slice(v []float64) KeyValue {
+	return KeyValue{
+		Key:   k,
+		Value: Float64SliceValue(v),
+	}
+}
+
+// StringSlice creates a KeyValue instance with a STRINGSLICE Value.
+//
+// If creating both key and a []string value at the same time, then
+// instead of calling Key(name).StringSlice(value) consider using a
 // convenience function provided by the api/key package -
-// key.Array(name, value).
-func (k Key) Array(v interface{}) KeyValue {
+// key.StringSlice(name, value).
+func (k Key) StringSlice(v []string) KeyValue {
 	return KeyValue{
 		Key:   k,
-		Value: ArrayValue(v),
+		Value: StringSliceValue(v),
 	}
 }
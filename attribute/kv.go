@@ -31,6 +31,12 @@ func (kv KeyValue) Valid() bool {
 	return kv.Key != "" && kv.Value.Type() != INVALID
 }
 
+// String returns the key and emitted value of the KeyValue joined by
+// "=", e.g. "A=1".
+func (kv KeyValue) String() string {
+	return string(kv.Key) + "=" + kv.Value.Emit()
+}
+
 // Bool creates a new key-value pair with a passed name and a bool
 // value.
 func Bool(k string, v bool) KeyValue {
@@ -68,10 +74,34 @@ func Int(k string, v int) KeyValue {
 	return Key(k).Int(v)
 }
 
-// Array creates a new key-value pair with a passed name and a array.
-// Only arrays of primitive type are supported.
-func Array(k string, v interface{}) KeyValue {
-	return Key(k).Array(v)
+// BoolSlice creates a new key-value pair with a passed name and a []bool
+// value.
+func BoolSlice(k string, v []bool) KeyValue {
+	return Key(k).BoolSlice(v)
+}
+
+// Int64Slice creates a new key-value pair with a passed name and an []int64
+// value.
+func Int64Slice(k string, v []int64) KeyValue {
+	return Key(k).Int64Slice(v)
+}
+
+// IntSlice creates a new key-value pair with a passed name and an []int
+// value.
+func IntSlice(k string, v []int) KeyValue {
+	return Key(k).IntSlice(v)
+}
+
+// Float64Slice creates a new key-value pair with a passed name and a []float64
+// value.
+func Float64Slice(k string, v []float64) KeyValue {
+	return Key(k).Float64Slice(v)
+}
+
+// StringSlice creates a new key-value pair with a passed name and a []string
+// value.
+func StringSlice(k string, v []string) KeyValue {
+	return Key(k).StringSlice(v)
 }
 
 // Any creates a new key-value pair instance with a passed name and
@@ -89,7 +119,9 @@ func Any(k string, value interface{}) KeyValue {
 
 	switch rv.Kind() {
 	case reflect.Array, reflect.Slice:
-		return Array(k, value)
+		if kv, ok := anySlice(k, rv); ok {
+			return kv
+		}
 	case reflect.Bool:
 		return Bool(k, rv.Bool())
 	case reflect.Int, reflect.Int8, reflect.Int16:
@@ -106,3 +138,35 @@ func Any(k string, value interface{}) KeyValue {
 	}
 	return String(k, fmt.Sprint(value))
 }
+
+// anySlice returns the key-value pair of k and the slice or array rv, and
+// whether its elements are of a type a slice Value supports.
+func anySlice(k string, rv reflect.Value) (KeyValue, bool) {
+	switch rv.Type().Elem().Kind() {
+	case reflect.Bool:
+		v := make([]bool, rv.Len())
+		for i := range v {
+			v[i] = rv.Index(i).Bool()
+		}
+		return BoolSlice(k, v), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := make([]int64, rv.Len())
+		for i := range v {
+			v[i] = rv.Index(i).Int()
+		}
+		return Int64Slice(k, v), true
+	case reflect.Float64:
+		v := make([]float64, rv.Len())
+		for i := range v {
+			v[i] = rv.Index(i).Float()
+		}
+		return Float64Slice(k, v), true
+	case reflect.String:
+		v := make([]string, rv.Len())
+		for i := range v {
+			v[i] = rv.Index(i).String()
+		}
+		return StringSlice(k, v), true
+	}
+	return KeyValue{}, false
+}
@@ -130,6 +130,36 @@ func TestAny(t *testing.T) {
 			wantType:  attribute.STRING,
 			wantValue: "foo",
 		},
+		{
+			key:       "string slice type inferred",
+			value:     []string{"a", "b"},
+			wantType:  attribute.STRINGSLICE,
+			wantValue: []string{"a", "b"},
+		},
+		{
+			key:       "int array type inferred",
+			value:     [2]int32{1, 2},
+			wantType:  attribute.INT64SLICE,
+			wantValue: []int64{1, 2},
+		},
+		{
+			key:       "bool slice type inferred",
+			value:     []bool{true},
+			wantType:  attribute.BOOLSLICE,
+			wantValue: []bool{true},
+		},
+		{
+			key:       "float64 slice type inferred",
+			value:     []float64{1.5},
+			wantType:  attribute.FLOAT64SLICE,
+			wantValue: []float64{1.5},
+		},
+		{
+			key:       "unsupported slice serialized as JSON",
+			value:     [][]int{{1, 2}, {3}},
+			wantType:  attribute.STRING,
+			wantValue: "[[1,2],[3]]",
+		},
 		{
 			key:       "unknown value serialized as %v",
 			value:     nil,
@@ -207,9 +237,9 @@ func TestKeyValueValid(t *testing.T) {
 			kv:    attribute.String("string", ""),
 		},
 		{
-			desc:  "non-empty key with ARRAY type Value should be valid",
+			desc:  "non-empty key with INT64SLICE type Value should be valid",
 			valid: true,
-			kv:    attribute.Array("array", []int{}),
+			kv:    attribute.IntSlice("int_slice", []int{}),
 		},
 	}
 
@@ -219,3 +249,19 @@ func TestKeyValueValid(t *testing.T) {
 		}
 	}
 }
+
+func TestKeyValueString(t *testing.T) {
+	for _, tc := range []struct {
+		kv   attribute.KeyValue
+		want string
+	}{
+		{attribute.Int("A", 1), "A=1"},
+		{attribute.String("B", "b"), "B=b"},
+		{attribute.IntSlice("C", []int{1, 2}), "C=[1 2]"},
+		{attribute.StringSlice("D", []string{"a", "b"}), "D=[a b]"},
+	} {
+		if got := tc.kv.String(); got != tc.want {
+			t.Errorf("KeyValue.String() = %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -27,7 +27,8 @@ type (
 	// for storing label encodings.
 	//
 	// This type supports the `Equivalent` method of comparison
-	// using values of type `Distinct`.
+	// using values of type `Distinct`, and stores a hash of its
+	// labels, computed once when the set is created, see `Hash`.
 	//
 	// This type is used to implement:
 	// 1. Metric labels
@@ -35,6 +36,7 @@ type (
 	// 3. Correlation map (TODO)
 	Set struct {
 		equivalent Distinct
+		hash       uint64
 
 		lock     sync.Mutex
 		encoders [maxConcurrentEncoders]EncoderID
@@ -73,6 +75,7 @@ var (
 		equivalent: Distinct{
 			iface: [0]KeyValue{},
 		},
+		hash: fnvOffset64,
 	}
 )
 
@@ -176,8 +179,25 @@ func (l *Set) Equivalent() Distinct {
 	return l.equivalent
 }
 
+// Hash returns a 64-bit hash of the labels in this set, computed when
+// the set was created.  Equivalent sets have the same hash, so it can be
+// used to bucket sets without hashing their labels again, but sets with
+// the same hash are not necessarily equivalent: use `Equals` to compare
+// them.
+func (l *Set) Hash() uint64 {
+	if l == nil || !l.equivalent.Valid() {
+		return emptySet.hash
+	}
+	return l.hash
+}
+
 // Equals returns true if the argument set is equivalent to this set.
+// Sets with different hashes are known to differ in constant time,
+// without comparing their labels.
 func (l *Set) Equals(o *Set) bool {
+	if l.Hash() != o.Hash() {
+		return false
+	}
 	return l.Equivalent() == o.Equivalent()
 }
 
@@ -232,6 +252,16 @@ func (l *Set) Encoded(encoder Encoder) string {
 func empty() Set {
 	return Set{
 		equivalent: emptySet.equivalent,
+		hash:       emptySet.hash,
+	}
+}
+
+// newSet returns a `Set` of kvs, which are assumed to already be sorted
+// and de-duplicated.
+func newSet(kvs []KeyValue) Set {
+	return Set{
+		equivalent: computeDistinct(kvs),
+		hash:       HashKeyValues(kvs),
 	}
 }
 
@@ -306,6 +336,81 @@ func NewSetWithSortableFiltered(kvs []KeyValue, tmp *Sortable, filter Filter) (S
 		return empty(), nil
 	}
 
+	kvs = sortAndDedup(kvs, tmp)
+	if filter != nil {
+		return filterSet(kvs, filter)
+	}
+	return newSet(kvs), nil
+}
+
+// NewSetFromSorted returns a new `Set` of kvs, which are expected to be
+// sorted by key without duplicate keys, e.g. a slice of labels built once
+// and reused across calls.  Such input is neither sorted nor copied to a
+// temporary.
+//
+// If kvs are not sorted, or have duplicate keys, this behaves like
+// `NewSet`, and reorders them.
+func NewSetFromSorted(kvs ...KeyValue) Set {
+	if len(kvs) == 0 {
+		return empty()
+	}
+	if !sortedUnique(kvs) {
+		return NewSetWithSortable(kvs, new(Sortable))
+	}
+	return newSet(kvs)
+}
+
+// SetBuilder builds `Set`s reusing its label buffer and its `Sortable`
+// across calls, which spares repeated constructions of sets of the same
+// handful of labels from allocating these temporaries.  The zero value
+// is ready to use.
+//
+// A SetBuilder is not safe for concurrent use.  The Sets it builds do not
+// refer to its buffer.
+type SetBuilder struct {
+	kvs []KeyValue
+	tmp Sortable
+}
+
+// Add adds kvs to the labels of the next Set built.
+func (b *SetBuilder) Add(kvs ...KeyValue) {
+	b.kvs = append(b.kvs, kvs...)
+}
+
+// Len returns the number of labels added since the last Set was built,
+// duplicate keys included.
+func (b *SetBuilder) Len() int {
+	return len(b.kvs)
+}
+
+// Reset removes the labels added since the last Set was built.
+func (b *SetBuilder) Reset() {
+	for i := range b.kvs {
+		// Do not retain the values for the GC.
+		b.kvs[i] = KeyValue{}
+	}
+	b.kvs = b.kvs[:0]
+}
+
+// Build returns the Set of the added labels, with the same
+// last-value-wins semantics for duplicate keys as `NewSet`, and resets
+// the builder.
+func (b *SetBuilder) Build() Set {
+	defer b.Reset()
+	if len(b.kvs) == 0 {
+		return empty()
+	}
+	return newSet(sortAndDedup(b.kvs, &b.tmp))
+}
+
+// sortAndDedup sorts kvs, if needed, with tmp and de-duplicates them.
+// It returns the sub-slice at the end of kvs holding the unique labels,
+// see `NewSetWithSortableFiltered` for the reordering of kvs.
+func sortAndDedup(kvs []KeyValue, tmp *Sortable) []KeyValue {
+	if sortedUnique(kvs) {
+		return kvs
+	}
+
 	*tmp = kvs
 
 	// Stable sort so the following de-duplication can implement
@@ -330,12 +435,17 @@ func NewSetWithSortableFiltered(kvs []KeyValue, tmp *Sortable, filter Filter) (S
 		position--
 		kvs[offset], kvs[position] = kvs[position], kvs[offset]
 	}
-	if filter != nil {
-		return filterSet(kvs[position:], filter)
+	return kvs[position:]
+}
+
+// sortedUnique returns whether the keys of kvs are strictly increasing.
+func sortedUnique(kvs []KeyValue) bool {
+	for i := 1; i < len(kvs); i++ {
+		if kvs[i-1].Key >= kvs[i].Key {
+			return false
+		}
 	}
-	return Set{
-		equivalent: computeDistinct(kvs[position:]),
-	}, nil
+	return true
 }
 
 // filterSet reorders `kvs` so that included keys are contiguous at
@@ -359,9 +469,7 @@ func filterSet(kvs []KeyValue, filter Filter) (Set, []KeyValue) {
 	}
 	excluded = kvs[:distinctPosition]
 
-	return Set{
-		equivalent: computeDistinct(kvs[distinctPosition:]),
-	}, excluded
+	return newSet(kvs[distinctPosition:]), excluded
 }
 
 // Filter returns a filtered copy of this `Set`.  See the
@@ -370,6 +478,7 @@ func (l *Set) Filter(re Filter) (Set, []KeyValue) {
 	if re == nil {
 		return Set{
 			equivalent: l.equivalent,
+			hash:       l.hash,
 		}, nil
 	}
 
@@ -455,6 +564,25 @@ func (l *Set) MarshalJSON() ([]byte, error) {
 	return json.Marshal(l.equivalent.iface)
 }
 
+// String returns the set of labels encoded with the default encoder,
+// e.g. "A=1,B=two".  Labels appear in key order, making the result
+// suitable for log lines, test expectations and cache keys.
+func (l *Set) String() string {
+	return l.Encoded(DefaultEncoder())
+}
+
+// MarshalLog returns the representation of the `*Set` used by structured
+// loggers: a map from label key to the emitted label value.  Loggers
+// and encoders emit maps in sorted key order, so the output is stable.
+func (l *Set) MarshalLog() interface{} {
+	kvs := make(map[string]string, l.Len())
+	for iter := l.Iter(); iter.Next(); {
+		kv := iter.Label()
+		kvs[string(kv.Key)] = kv.Value.Emit()
+	}
+	return kvs
+}
+
 // Len implements `sort.Interface`.
 func (l *Sortable) Len() int {
 	return len(*l)
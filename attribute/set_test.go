@@ -15,6 +15,7 @@
 package attribute_test
 
 import (
+	"fmt"
 	"regexp"
 	"testing"
 
@@ -188,3 +189,146 @@ func TestLookup(t *testing.T) {
 	value, has = set.Value("D")
 	require.False(t, has)
 }
+
+func TestSetString(t *testing.T) {
+	set := attribute.NewSet(
+		attribute.String("C", "a,b"),
+		attribute.Int("A", 1),
+		attribute.Bool("B", true),
+	)
+	require.Equal(t, `A=1,B=true,C=a\,b`, set.String())
+	require.Equal(t, set.String(), fmt.Sprint(&set))
+	require.Equal(t, "", attribute.EmptySet().String())
+}
+
+func TestSetMarshalLog(t *testing.T) {
+	set := attribute.NewSet(
+		attribute.String("C", "c"),
+		attribute.Int("A", 1),
+		attribute.Float64("B", 2.5),
+	)
+	require.Equal(t, map[string]string{
+		"A": "1",
+		"B": "2.5",
+		"C": "c",
+	}, set.MarshalLog())
+	require.Equal(t, map[string]string{}, attribute.EmptySet().MarshalLog())
+}
+
+func TestNewSetFromSorted(t *testing.T) {
+	sorted := []attribute.KeyValue{
+		attribute.String("A", "1"),
+		attribute.Int("B", 2),
+		attribute.Bool("C", true),
+	}
+	s := attribute.NewSetFromSorted(sorted...)
+	require.Equal(t, "A=1,B=2,C=true", s.Encoded(attribute.DefaultEncoder()))
+	want := attribute.NewSet(sorted...)
+	require.Equal(t, want.Equivalent(), s.Equivalent())
+
+	// Unsorted input and duplicates fall back to NewSet.
+	s = attribute.NewSetFromSorted(
+		attribute.String("C", "1"),
+		attribute.String("A", "1"),
+		attribute.String("C", "2"),
+	)
+	require.Equal(t, "A=1,C=2", s.Encoded(attribute.DefaultEncoder()))
+
+	s = attribute.NewSetFromSorted()
+	require.Equal(t, 0, s.Len())
+}
+
+func TestSetBuilder(t *testing.T) {
+	var b attribute.SetBuilder
+	b.Add(attribute.String("C", "1"), attribute.String("A", "1"))
+	b.Add(attribute.String("C", "2"))
+	require.Equal(t, 3, b.Len())
+
+	s1 := b.Build()
+	require.Equal(t, 0, b.Len())
+	require.Equal(t, "A=1,C=2", s1.Encoded(attribute.DefaultEncoder()))
+
+	// The builder is reusable and the built Sets are independent of it.
+	b.Add(attribute.String("B", "1"))
+	s2 := b.Build()
+	require.Equal(t, "B=1", s2.Encoded(attribute.DefaultEncoder()))
+	require.Equal(t, "A=1,C=2", s1.Encoded(attribute.DefaultEncoder()))
+
+	b.Add(attribute.String("B", "1"))
+	b.Reset()
+	s3 := b.Build()
+	require.Equal(t, attribute.EmptySet().Equivalent(), s3.Equivalent())
+}
+
+func TestSetBuilderAllocations(t *testing.T) {
+	kvs := []attribute.KeyValue{
+		attribute.String("C", "1"),
+		attribute.String("A", "1"),
+		attribute.String("B", "1"),
+	}
+	var b attribute.SetBuilder
+	built := testing.AllocsPerRun(10, func() {
+		b.Add(kvs...)
+		_ = b.Build()
+	})
+	cpy := make([]attribute.KeyValue, len(kvs))
+	newSet := testing.AllocsPerRun(10, func() {
+		copy(cpy, kvs)
+		_ = attribute.NewSet(cpy...)
+	})
+	if built >= newSet {
+		t.Errorf("SetBuilder allocations %v, want fewer than the %v of NewSet", built, newSet)
+	}
+}
+
+func TestSetHash(t *testing.T) {
+	kvs := []attribute.KeyValue{
+		attribute.String("A", "1"),
+		attribute.Int("B", 2),
+		attribute.StringSlice("C", []string{"x", "y"}),
+	}
+	s := attribute.NewSet(kvs...)
+
+	// Equivalent sets have the same hash, however they are built.
+	sorted := attribute.NewSetFromSorted(kvs...)
+	require.Equal(t, s.Hash(), sorted.Hash())
+	dup := attribute.NewSet(attribute.String("A", "0"), kvs[2], kvs[1], kvs[0])
+	require.Equal(t, s.Hash(), dup.Hash())
+	var b attribute.SetBuilder
+	b.Add(kvs[2], kvs[0], kvs[1])
+	built := b.Build()
+	require.Equal(t, s.Hash(), built.Hash())
+	withD := attribute.NewSet(append(kvs, attribute.Bool("D", true))...)
+	filtered, _ := withD.Filter(func(kv attribute.KeyValue) bool {
+		return kv.Key != "D"
+	})
+	require.Equal(t, s.Hash(), filtered.Hash())
+	unfiltered, _ := s.Filter(nil)
+	require.Equal(t, s.Hash(), unfiltered.Hash())
+	require.Equal(t, s.Hash(), attribute.HashKeyValues(kvs))
+	require.NotEqual(t, s.Hash(), attribute.HashKeyValues([]attribute.KeyValue{kvs[1], kvs[0], kvs[2]}))
+
+	// Sets differing by a key, a value or a value type have different
+	// hashes.
+	for _, other := range [][]attribute.KeyValue{
+		{kvs[0], kvs[1]},
+		{kvs[0], kvs[1], attribute.StringSlice("C", []string{"xy"})},
+		{kvs[0], attribute.Int("B", 3), kvs[2]},
+		{kvs[0], attribute.String("B", "2"), kvs[2]},
+		{attribute.String("A1", ""), kvs[1], kvs[2]},
+	} {
+		o := attribute.NewSet(other...)
+		require.NotEqual(t, s.Hash(), o.Hash(), o.Encoded(attribute.DefaultEncoder()))
+		require.False(t, s.Equals(&o))
+	}
+	require.True(t, s.Equals(&sorted))
+
+	// Empty sets, however they are built, share a hash.
+	var zero attribute.Set
+	empty := attribute.NewSet()
+	var nilSet *attribute.Set
+	require.Equal(t, attribute.EmptySet().Hash(), zero.Hash())
+	require.Equal(t, attribute.EmptySet().Hash(), empty.Hash())
+	require.Equal(t, attribute.EmptySet().Hash(), nilSet.Hash())
+	require.True(t, zero.Equals(nilSet))
+}
@@ -15,9 +15,9 @@
 package attribute // import "go.opentelemetry.io/otel/attribute"
 
 import (
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
-	"reflect"
 	"strconv"
 
 	"go.opentelemetry.io/otel/internal"
@@ -29,13 +29,14 @@ import (
 type Type int
 
 // Value represents the value part in key-value pairs.
+//
+// A slice Value holds the number of its elements in numeric and their
+// encoding in stringly, keeping Values comparable.
 type Value struct {
 	vtype    Type
 	numeric  uint64
 	stringly string
 	// TODO Lazy value type?
-
-	array interface{}
 }
 
 const (
@@ -49,10 +50,14 @@ const (
 	FLOAT64
 	// STRING is a string Type Value.
 	STRING
-	// ARRAY is an array Type Value used to store 1-dimensional slices or
-	// arrays of bool, int, int32, int64, uint, uint32, uint64, float,
-	// float32, float64, or string types.
-	ARRAY
+	// BOOLSLICE is a slice of booleans Type Value.
+	BOOLSLICE
+	// INT64SLICE is a slice of 64-bit signed integral numbers Type Value.
+	INT64SLICE
+	// FLOAT64SLICE is a slice of 64-bit floating point numbers Type Value.
+	FLOAT64SLICE
+	// STRINGSLICE is a slice of strings Type Value.
+	STRINGSLICE
 )
 
 // BoolValue creates a BOOL Value.
@@ -92,33 +97,81 @@ func IntValue(v int) Value {
 	return Int64Value(int64(v))
 }
 
-// ArrayValue creates an ARRAY value from an array or slice.
-// Only arrays or slices of bool, int, int64, float, float64, or string types are allowed.
-// Specifically, arrays  and slices can not contain other arrays, slices, structs, or non-standard
-// types. If the passed value is not an array or slice of these types an
-// INVALID value is returned.
-func ArrayValue(v interface{}) Value {
-	switch reflect.TypeOf(v).Kind() {
-	case reflect.Array, reflect.Slice:
-		// get array type regardless of dimensions
-		typ := reflect.TypeOf(v).Elem()
-		kind := typ.Kind()
-		switch kind {
-		case reflect.Bool, reflect.Int, reflect.Int64,
-			reflect.Float64, reflect.String:
-			val := reflect.ValueOf(v)
-			length := val.Len()
-			frozen := reflect.Indirect(reflect.New(reflect.ArrayOf(length, typ)))
-			reflect.Copy(frozen, val)
-			return Value{
-				vtype: ARRAY,
-				array: frozen.Interface(),
-			}
-		default:
-			return Value{vtype: INVALID}
+// BoolSliceValue creates a BOOLSLICE Value. The Value holds a copy of v.
+func BoolSliceValue(v []bool) Value {
+	b := make([]byte, len(v))
+	for i, e := range v {
+		if e {
+			b[i] = 1
 		}
 	}
-	return Value{vtype: INVALID}
+	return Value{
+		vtype:    BOOLSLICE,
+		numeric:  uint64(len(v)),
+		stringly: string(b),
+	}
+}
+
+// Int64SliceValue creates an INT64SLICE Value. The Value holds a copy of
+// v.
+func Int64SliceValue(v []int64) Value {
+	b := make([]byte, 8*len(v))
+	for i, e := range v {
+		binary.LittleEndian.PutUint64(b[8*i:], internal.Int64ToRaw(e))
+	}
+	return Value{
+		vtype:    INT64SLICE,
+		numeric:  uint64(len(v)),
+		stringly: string(b),
+	}
+}
+
+// IntSliceValue creates an INT64SLICE Value. The Value holds a copy of v.
+func IntSliceValue(v []int) Value {
+	b := make([]byte, 8*len(v))
+	for i, e := range v {
+		binary.LittleEndian.PutUint64(b[8*i:], internal.Int64ToRaw(int64(e)))
+	}
+	return Value{
+		vtype:    INT64SLICE,
+		numeric:  uint64(len(v)),
+		stringly: string(b),
+	}
+}
+
+// Float64SliceValue creates a FLOAT64SLICE Value. The Value holds a copy
+// of v.
+func Float64SliceValue(v []float64) Value {
+	b := make([]byte, 8*len(v))
+	for i, e := range v {
+		binary.LittleEndian.PutUint64(b[8*i:], internal.Float64ToRaw(e))
+	}
+	return Value{
+		vtype:    FLOAT64SLICE,
+		numeric:  uint64(len(v)),
+		stringly: string(b),
+	}
+}
+
+// StringSliceValue creates a STRINGSLICE Value. The Value holds a copy of
+// v.
+func StringSliceValue(v []string) Value {
+	n := 0
+	for _, e := range v {
+		n += binary.MaxVarintLen64 + len(e)
+	}
+	b := make([]byte, 0, n)
+	for _, e := range v {
+		// Each string is prefixed by its length.
+		var l [binary.MaxVarintLen64]byte
+		b = append(b, l[:binary.PutUvarint(l[:], uint64(len(e)))]...)
+		b = append(b, e...)
+	}
+	return Value{
+		vtype:    STRINGSLICE,
+		numeric:  uint64(len(v)),
+		stringly: string(b),
+	}
 }
 
 // Type returns a type of the Value.
@@ -145,14 +198,90 @@ func (v Value) AsFloat64() float64 {
 }
 
 // AsString returns the string value. Make sure that the Value's type
-// is STRING.
+// is STRING, the empty string is returned otherwise. Use Emit for a
+// string representation of any Value.
 func (v Value) AsString() string {
+	if v.vtype != STRING {
+		return ""
+	}
 	return v.stringly
 }
 
-// AsArray returns the array Value as an interface{}.
-func (v Value) AsArray() interface{} {
-	return v.array
+// AsBoolSlice returns a copy of the []bool value. Make sure that the
+// Value's type is BOOLSLICE.
+func (v Value) AsBoolSlice() []bool {
+	if v.vtype != BOOLSLICE {
+		return nil
+	}
+	s := make([]bool, v.numeric)
+	for i := range s {
+		s[i] = v.stringly[i] != 0
+	}
+	return s
+}
+
+// AsInt64Slice returns a copy of the []int64 value. Make sure that the
+// Value's type is INT64SLICE.
+func (v Value) AsInt64Slice() []int64 {
+	if v.vtype != INT64SLICE {
+		return nil
+	}
+	s := make([]int64, v.numeric)
+	for i := range s {
+		s[i] = internal.RawToInt64(v.raw(i))
+	}
+	return s
+}
+
+// AsFloat64Slice returns a copy of the []float64 value. Make sure that the
+// Value's type is FLOAT64SLICE.
+func (v Value) AsFloat64Slice() []float64 {
+	if v.vtype != FLOAT64SLICE {
+		return nil
+	}
+	s := make([]float64, v.numeric)
+	for i := range s {
+		s[i] = internal.RawToFloat64(v.raw(i))
+	}
+	return s
+}
+
+// AsStringSlice returns a copy of the []string value. Make sure that the
+// Value's type is STRINGSLICE.
+func (v Value) AsStringSlice() []string {
+	if v.vtype != STRINGSLICE {
+		return nil
+	}
+	s := make([]string, v.numeric)
+	data := v.stringly
+	for i := range s {
+		l, n := uvarint(data)
+		s[i], data = data[n:n+int(l)], data[n+int(l):]
+	}
+	return s
+}
+
+// raw returns the raw encoding of the i-th element of a numeric slice.
+func (v Value) raw(i int) uint64 {
+	b := v.stringly[8*i : 8*i+8]
+	return uint64(b[0]) | uint64(b[1])<<8 | uint64(b[2])<<16 | uint64(b[3])<<24 |
+		uint64(b[4])<<32 | uint64(b[5])<<40 | uint64(b[6])<<48 | uint64(b[7])<<56
+}
+
+// uvarint decodes the uvarint at the start of s and returns it with the
+// number of bytes read, as binary.Uvarint does without a []byte.
+func uvarint(s string) (uint64, int) {
+	var x uint64
+	var shift uint
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		if b < 0x80 {
+			return x | uint64(b)<<shift, i + 1
+		}
+		x |= uint64(b&0x7f) << shift
+		shift += 7
+	}
+	return 0, 0
 }
 
 type unknownValueType struct{}
@@ -160,8 +289,14 @@ type unknownValueType struct{}
 // AsInterface returns Value's data as interface{}.
 func (v Value) AsInterface() interface{} {
 	switch v.Type() {
-	case ARRAY:
-		return v.AsArray()
+	case BOOLSLICE:
+		return v.AsBoolSlice()
+	case INT64SLICE:
+		return v.AsInt64Slice()
+	case FLOAT64SLICE:
+		return v.AsFloat64Slice()
+	case STRINGSLICE:
+		return v.AsStringSlice()
 	case BOOL:
 		return v.AsBool()
 	case INT64:
@@ -177,8 +312,8 @@ func (v Value) AsInterface() interface{} {
 // Emit returns a string representation of Value's data.
 func (v Value) Emit() string {
 	switch v.Type() {
-	case ARRAY:
-		return fmt.Sprint(v.array)
+	case BOOLSLICE, INT64SLICE, FLOAT64SLICE, STRINGSLICE:
+		return fmt.Sprint(v.AsInterface())
 	case BOOL:
 		return strconv.FormatBool(v.AsBool())
 	case INT64:
@@ -15,7 +15,8 @@
 package attribute_test
 
 import (
-	"reflect"
+	"math"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -39,10 +40,10 @@ func TestValue(t *testing.T) {
 			wantValue: true,
 		},
 		{
-			name:      "Key.Array([]bool) correctly return key's internal bool values",
-			value:     k.Array([]bool{true, false}).Value,
-			wantType:  attribute.ARRAY,
-			wantValue: [2]bool{true, false},
+			name:      "Key.BoolSlice() correctly returns keys's internal []bool value",
+			value:     k.BoolSlice([]bool{true, false, true}).Value,
+			wantType:  attribute.BOOLSLICE,
+			wantValue: []bool{true, false, true},
 		},
 		{
 			name:      "Key.Int64() correctly returns keys's internal int64 value",
@@ -69,34 +70,34 @@ func TestValue(t *testing.T) {
 			wantValue: bli.signedValue,
 		},
 		{
-			name:      "Key.Array([]int64) correctly returns keys's internal int64 values",
-			value:     k.Array([]int64{42, 43}).Value,
-			wantType:  attribute.ARRAY,
-			wantValue: [2]int64{42, 43},
+			name:      "Key.Int64Slice() correctly returns keys's internal []int64 value",
+			value:     k.Int64Slice([]int64{42, -43, math.MinInt64}).Value,
+			wantType:  attribute.INT64SLICE,
+			wantValue: []int64{42, -43, math.MinInt64},
 		},
 		{
-			name:      "Key.Array([]float64) correctly returns keys's internal float64 values",
-			value:     k.Array([]float64{42, 43}).Value,
-			wantType:  attribute.ARRAY,
-			wantValue: [2]float64{42, 43},
+			name:      "Key.Float64Slice() correctly returns keys's internal []float64 value",
+			value:     k.Float64Slice([]float64{42, -43.5}).Value,
+			wantType:  attribute.FLOAT64SLICE,
+			wantValue: []float64{42, -43.5},
 		},
 		{
-			name:      "Key.Array([]string) correctly return key's internal string values",
-			value:     k.Array([]string{"foo", "bar"}).Value,
-			wantType:  attribute.ARRAY,
-			wantValue: [2]string{"foo", "bar"},
+			name:      "Key.StringSlice() correctly returns keys's internal []string value",
+			value:     k.StringSlice([]string{"foo", "", strings.Repeat("b", 300)}).Value,
+			wantType:  attribute.STRINGSLICE,
+			wantValue: []string{"foo", "", strings.Repeat("b", 300)},
 		},
 		{
-			name:      "Key.Array([]int) correctly returns keys's internal signed integral values",
-			value:     k.Array([]int{42, 43}).Value,
-			wantType:  attribute.ARRAY,
-			wantValue: [2]int{42, 43},
+			name:      "Key.IntSlice() correctly returns keys's internal []int64 value",
+			value:     k.IntSlice([]int{42, 43}).Value,
+			wantType:  attribute.INT64SLICE,
+			wantValue: []int64{42, 43},
 		},
 		{
-			name:      "Key.Array([][]int) refuses to create multi-dimensional array",
-			value:     k.Array([][]int{{1, 2}, {3, 4}}).Value,
-			wantType:  attribute.INVALID,
-			wantValue: nil,
+			name:      "Key.StringSlice() of an empty slice",
+			value:     k.StringSlice(nil).Value,
+			wantType:  attribute.STRINGSLICE,
+			wantValue: []string{},
 		},
 	} {
 		t.Logf("Running test case %s", testcase.name)
@@ -129,10 +130,42 @@ func getBitlessInfo(i int) bitlessInfo {
 	}
 }
 
-func TestAsArrayValue(t *testing.T) {
-	v := attribute.ArrayValue([]int{1, 2, 3}).AsArray()
-	// Ensure the returned dynamic type is stable.
-	if got, want := reflect.TypeOf(v).Kind(), reflect.Array; got != want {
-		t.Errorf("AsArray() returned %T, want %T", got, want)
+func TestSliceValue(t *testing.T) {
+	s := []string{"a", "b"}
+	v := attribute.StringSliceValue(s)
+	// The Value holds a copy of the slice.
+	s[0] = "c"
+	if got := v.AsStringSlice(); got[0] != "a" {
+		t.Errorf("AsStringSlice() = %v, want the slice at creation", got)
+	}
+	got := v.AsStringSlice()
+	got[1] = "c"
+	if again := v.AsStringSlice(); again[1] != "b" {
+		t.Errorf("AsStringSlice() = %v, want a copy", again)
+	}
+
+	// Slice Values are comparable.
+	if v != attribute.StringSliceValue([]string{"a", "b"}) {
+		t.Error("equal slices are different Values")
+	}
+
+	// The internal encoding is not exposed as a string.
+	if got := v.AsString(); got != "" {
+		t.Errorf("AsString() = %q, want the empty string", got)
+	}
+	if v == attribute.StringSliceValue([]string{"ab"}) {
+		t.Error("different slices are the same Value")
+	}
+	if attribute.Int64SliceValue([]int64{1}) != attribute.IntSliceValue([]int{1}) {
+		t.Error("IntSliceValue differs from Int64SliceValue")
+	}
+
+	// Accessors of another type return nil.
+	if got := v.AsInt64Slice(); got != nil {
+		t.Errorf("AsInt64Slice() of a STRINGSLICE = %v, want nil", got)
+	}
+
+	if got, want := attribute.BoolSliceValue([]bool{true, false}).Emit(), "[true false]"; got != want {
+		t.Errorf("Emit() = %q, want %q", got, want)
 	}
 }
@@ -45,6 +45,9 @@ func Value(ctx context.Context, key attribute.Key) attribute.Value {
 }
 
 // ContextWithValues returns a copy of parent with pairs updated in the baggage.
+// The pairs are not validated, the propagation.Baggage propagator does not
+// inject the ones with invalid keys or exceeding the W3C Baggage limits, use
+// ContextWithMembers to validate them.
 func ContextWithValues(parent context.Context, pairs ...attribute.KeyValue) context.Context {
 	m := baggage.MapFromContext(parent).Apply(baggage.MapUpdate{
 		MultiKV: pairs,
@@ -52,6 +55,36 @@ func ContextWithValues(parent context.Context, pairs ...attribute.KeyValue) cont
 	return baggage.ContextWithMap(parent, m)
 }
 
+// ContextWithLocalValues returns a copy of parent with pairs updated in the
+// baggage and marked as local: they are available in-process but never
+// propagated by the propagation.Baggage propagator. Use this for sensitive
+// values that must not leak to other services.
+func ContextWithLocalValues(parent context.Context, pairs ...attribute.KeyValue) context.Context {
+	return ContextWithHopLimitedValues(parent, 0, pairs...)
+}
+
+// ContextWithHopLimitedValues returns a copy of parent with pairs updated in
+// the baggage and limited to be propagated at most hops times: every
+// propagation decrements the limit and pairs with no hop left are not
+// propagated. A hops value of zero or less is equivalent to
+// ContextWithLocalValues.
+//
+// Updating a pair with ContextWithValues removes its limit.
+func ContextWithHopLimitedValues(parent context.Context, hops int, pairs ...attribute.KeyValue) context.Context {
+	m := baggage.MapFromContext(parent).Apply(baggage.MapUpdate{
+		MultiKV:    pairs,
+		HopLimited: true,
+		HopLimit:   hops,
+	})
+	return baggage.ContextWithMap(parent, m)
+}
+
+// HopLimit returns the number of times the baggage value related to key in
+// ctx may still be propagated and whether the value is hop-limited.
+func HopLimit(ctx context.Context, key attribute.Key) (int, bool) {
+	return baggage.MapFromContext(ctx).HopLimit(key)
+}
+
 // ContextWithoutValues returns a copy of parent in which the values related
 // to keys have been removed from the baggage.
 func ContextWithoutValues(parent context.Context, keys ...attribute.Key) context.Context {
@@ -84,3 +84,27 @@ func TestBaggage(t *testing.T) {
 		t.Fatal("WithoutBaggage failed to clear baggage")
 	}
 }
+
+func TestBaggageHopLimitedValues(t *testing.T) {
+	ctx := ContextWithValues(context.Background(), attribute.String("public", "v"))
+	ctx = ContextWithLocalValues(ctx, attribute.String("secret", "s"))
+	ctx = ContextWithHopLimitedValues(ctx, 3, attribute.String("limited", "l"))
+
+	if v := Value(ctx, "secret"); v.AsString() != "s" {
+		t.Errorf("local value %q, want %q", v.AsString(), "s")
+	}
+	if _, ok := HopLimit(ctx, "public"); ok {
+		t.Error("public value is hop-limited")
+	}
+	if hops, ok := HopLimit(ctx, "secret"); !ok || hops != 0 {
+		t.Errorf("local value hop limit %d, %t, want 0, true", hops, ok)
+	}
+	if hops, ok := HopLimit(ctx, "limited"); !ok || hops != 3 {
+		t.Errorf("limited value hop limit %d, %t, want 3, true", hops, ok)
+	}
+
+	ctx = ContextWithValues(ctx, attribute.String("secret", "s2"))
+	if _, ok := HopLimit(ctx, "secret"); ok {
+		t.Error("updated value is still hop-limited")
+	}
+}
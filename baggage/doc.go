@@ -17,6 +17,10 @@ Package baggage provides functionality for storing and retrieving
 baggage items in Go context. For propagating the baggage, see the
 go.opentelemetry.io/otel/propagation package.
 
+Baggage entries are Members: a key, a value, and optional Properties
+carrying metadata about the entry, encoded in the W3C Baggage format as
+`key=value;prop1;prop2=val`.
+
 This package is currently in a pre-GA phase. Backwards incompatible changes
 may be introduced in subsequent minor version releases as we work to track the
 evolving OpenTelemetry specification and user feedback.
@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baggage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal/baggage"
+)
+
+// The limits of the W3C Baggage format
+// (https://w3c.github.io/baggage/#limits).
+const (
+	// MaxMembers is the maximum number of members of a baggage.
+	MaxMembers = 180
+	// MaxMemberBytes is the maximum length of an encoded member, its
+	// properties included.
+	MaxMemberBytes = 4096
+	// MaxBytes is the maximum length of an encoded baggage.
+	MaxBytes = 8192
+)
+
+var (
+	// ErrInvalidMember is returned when a member is invalid or cannot be
+	// decoded.
+	ErrInvalidMember = errors.New("invalid baggage member")
+	// ErrInvalidProperty is returned when a property of a member is
+	// invalid.
+	ErrInvalidProperty = errors.New("invalid baggage property")
+	// ErrMemberTooLong is returned when an encoded member is longer than
+	// MaxMemberBytes.
+	ErrMemberTooLong = errors.New("baggage member too long")
+	// ErrTooManyMembers is returned when a baggage would have more than
+	// MaxMembers members.
+	ErrTooManyMembers = errors.New("too many baggage members")
+	// ErrBaggageTooLong is returned when an encoded baggage would be longer
+	// than MaxBytes.
+	ErrBaggageTooLong = errors.New("baggage too long")
+)
+
+// Property is a metadata entry of a baggage Member: a key, optionally with
+// a value.
+type Property struct {
+	p baggage.Property
+}
+
+// NewKeyProperty returns a Property with only a key. The key must be a
+// non-empty token of the W3C Baggage format, otherwise an error wrapping
+// ErrInvalidProperty is returned.
+func NewKeyProperty(key string) (Property, error) {
+	p := baggage.Property{Key: key}
+	if err := validateProperty(p); err != nil {
+		return Property{}, err
+	}
+	return Property{p: p}, nil
+}
+
+// NewKeyValueProperty returns a Property with a key and a value. The key
+// must be a non-empty token of the W3C Baggage format and the value valid
+// UTF-8, otherwise an error wrapping ErrInvalidProperty is returned. The
+// value is percent-encoded by String.
+func NewKeyValueProperty(key, value string) (Property, error) {
+	p := baggage.Property{Key: key, Value: value, HasValue: true}
+	if err := validateProperty(p); err != nil {
+		return Property{}, err
+	}
+	return Property{p: p}, nil
+}
+
+func validateProperty(p baggage.Property) error {
+	if !baggage.ValidKey(p.Key) {
+		return fmt.Errorf("%w: invalid key: %q", ErrInvalidProperty, p.Key)
+	}
+	if p.HasValue && !utf8.ValidString(p.Value) {
+		return fmt.Errorf("%w: invalid value: %q", ErrInvalidProperty, p.Value)
+	}
+	return nil
+}
+
+// Key returns the key of p.
+func (p Property) Key() string {
+	return p.p.Key
+}
+
+// Value returns the value of p and whether p has a value.
+func (p Property) Value() (string, bool) {
+	return p.p.Value, p.p.HasValue
+}
+
+// String encodes p in the W3C Baggage format.
+func (p Property) String() string {
+	return p.p.String()
+}
+
+// Member is a baggage entry: a key, its value, and optional properties
+// carrying metadata about the entry.
+type Member struct {
+	key        string
+	value      string
+	properties []baggage.Property
+}
+
+// NewMember returns a Member of key and value with props. The key must be
+// a non-empty token of the W3C Baggage format and the value valid UTF-8,
+// otherwise an error wrapping ErrInvalidMember is returned. The value is
+// percent-encoded by String, and an error wrapping ErrMemberTooLong is
+// returned if the encoded member is longer than MaxMemberBytes.
+func NewMember(key, value string, props ...Property) (Member, error) {
+	m := Member{key: key, value: value}
+	for _, p := range props {
+		m.properties = append(m.properties, p.p)
+	}
+	if err := m.validate(); err != nil {
+		return Member{}, err
+	}
+	return m, nil
+}
+
+// ParseMember decodes the Member encoded in the W3C Baggage format in
+// member, e.g. `key=value;prop1;prop2=val`. All the properties are
+// decoded, including the ones unknown to this package, so that they are
+// encoded again by String.
+//
+// An error wrapping ErrMemberTooLong is returned if member is longer than
+// MaxMemberBytes, and one wrapping ErrInvalidMember if it is not a valid
+// member.
+func ParseMember(member string) (Member, error) {
+	if len(member) > MaxMemberBytes {
+		return Member{}, fmt.Errorf("%w: %d bytes", ErrMemberTooLong, len(member))
+	}
+	key, value, props, err := baggage.ParseMember(member)
+	if err != nil {
+		return Member{}, fmt.Errorf("%w: %v", ErrInvalidMember, err)
+	}
+	m := Member{key: key, value: value, properties: props}
+	if err := m.validate(); err != nil {
+		return Member{}, err
+	}
+	return m, nil
+}
+
+func (m Member) validate() error {
+	if !baggage.ValidKey(m.key) {
+		return fmt.Errorf("%w: invalid key: %q", ErrInvalidMember, m.key)
+	}
+	if !utf8.ValidString(m.value) {
+		return fmt.Errorf("%w: invalid value: %q", ErrInvalidMember, m.value)
+	}
+	for _, p := range m.properties {
+		if err := validateProperty(p); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMember, err)
+		}
+	}
+	if n := len(m.String()); n > MaxMemberBytes {
+		return fmt.Errorf("%w: %d bytes", ErrMemberTooLong, n)
+	}
+	return nil
+}
+
+// Key returns the key of m.
+func (m Member) Key() string {
+	return m.key
+}
+
+// Value returns the value of m.
+func (m Member) Value() string {
+	return m.value
+}
+
+// Properties returns a copy of the properties of m, in their order.
+func (m Member) Properties() []Property {
+	if len(m.properties) == 0 {
+		return nil
+	}
+	props := make([]Property, len(m.properties))
+	for i, p := range m.properties {
+		props[i] = Property{p: p}
+	}
+	return props
+}
+
+// String encodes m in the W3C Baggage format.
+func (m Member) String() string {
+	return baggage.EncodeMember(m.key, m.value, m.properties)
+}
+
+// ContextWithMembers returns a copy of parent with members updated in the
+// baggage, their properties included.
+//
+// If the updated baggage would have more than MaxMembers members, or its
+// encoding would be longer than MaxBytes, parent is returned with an error
+// wrapping ErrTooManyMembers or ErrBaggageTooLong.
+//
+// Updating a member with ContextWithMembers removes its hop limit.
+func ContextWithMembers(parent context.Context, members ...Member) (context.Context, error) {
+	m := baggage.MapFromContext(parent)
+	for _, member := range members {
+		m = m.Apply(baggage.MapUpdate{
+			SingleKV:   attribute.String(member.key, member.value),
+			Properties: member.properties,
+		})
+	}
+
+	if m.Len() > MaxMembers {
+		return parent, fmt.Errorf("%w: %d members", ErrTooManyMembers, m.Len())
+	}
+	// The members are encoded separated by commas.
+	n := m.Len() - 1
+	m.Foreach(func(kv attribute.KeyValue) bool {
+		n += len(baggage.EncodeMember(string(kv.Key), kv.Value.Emit(), m.Properties(kv.Key)))
+		return true
+	})
+	if n > MaxBytes {
+		return parent, fmt.Errorf("%w: %d bytes", ErrBaggageTooLong, n)
+	}
+	return baggage.ContextWithMap(parent, m), nil
+}
+
+// Members returns the members of the baggage in ctx, sorted by key.
+// Values that are not strings are encoded with attribute.Value.Emit.
+func Members(ctx context.Context) []Member {
+	m := baggage.MapFromContext(ctx)
+	members := make([]Member, 0, m.Len())
+	m.Foreach(func(kv attribute.KeyValue) bool {
+		members = append(members, Member{
+			key:        string(kv.Key),
+			value:      kv.Value.Emit(),
+			properties: m.Properties(kv.Key),
+		})
+		return true
+	})
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].key < members[j].key
+	})
+	return members
+}
@@ -0,0 +1,225 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baggage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestNewProperty(t *testing.T) {
+	p, err := NewKeyProperty("flag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := p.Value(); p.Key() != "flag" || ok || v != "" {
+		t.Errorf("key property %q, %q, %t", p.Key(), v, ok)
+	}
+	if got := p.String(); got != "flag" {
+		t.Errorf("String() = %q, want %q", got, "flag")
+	}
+
+	p, err = NewKeyValueProperty("prop", "a b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := p.Value(); p.Key() != "prop" || !ok || v != "a b" {
+		t.Errorf("key-value property %q, %q, %t", p.Key(), v, ok)
+	}
+	if got := p.String(); got != "prop=a%20b" {
+		t.Errorf("String() = %q, want %q", got, "prop=a%20b")
+	}
+
+	for _, key := range []string{"", "a b", "a;b", "a=b", "a,b"} {
+		if _, err := NewKeyProperty(key); err == nil {
+			t.Errorf("NewKeyProperty(%q) succeeded", key)
+		}
+		if _, err := NewKeyValueProperty(key, "v"); err == nil {
+			t.Errorf("NewKeyValueProperty(%q) succeeded", key)
+		}
+	}
+}
+
+func TestNewMember(t *testing.T) {
+	flag, _ := NewKeyProperty("flag")
+	prop, _ := NewKeyValueProperty("prop", "1")
+	m, err := NewMember("key", "val,ue", flag, prop)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Key() != "key" || m.Value() != "val,ue" || len(m.Properties()) != 2 {
+		t.Errorf("member %q, %q, %v", m.Key(), m.Value(), m.Properties())
+	}
+	if got, want := m.String(), "key=val%2Cue;flag;prop=1"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	for _, key := range []string{"", " ", "a b", "a=b", "é"} {
+		if _, err := NewMember(key, "value"); !errors.Is(err, ErrInvalidMember) {
+			t.Errorf("NewMember(%q) error %v, want ErrInvalidMember", key, err)
+		}
+	}
+	if _, err := NewMember("key", "\xff"); !errors.Is(err, ErrInvalidMember) {
+		t.Errorf("NewMember with an invalid UTF-8 value error %v, want ErrInvalidMember", err)
+	}
+	if _, err := NewKeyValueProperty("prop", "\xff"); !errors.Is(err, ErrInvalidProperty) {
+		t.Errorf("NewKeyValueProperty with an invalid UTF-8 value error %v, want ErrInvalidProperty", err)
+	}
+
+	// The limit applies to the encoded member.
+	value := strings.Repeat("v", MaxMemberBytes-len("key="))
+	if _, err := NewMember("key", value); err != nil {
+		t.Errorf("NewMember of MaxMemberBytes: %v", err)
+	}
+	if _, err := NewMember("key", value[1:]+","); !errors.Is(err, ErrMemberTooLong) {
+		t.Errorf("NewMember with an encoding too long error %v, want ErrMemberTooLong", err)
+	}
+	if _, err := NewMember("key", value, flag); !errors.Is(err, ErrMemberTooLong) {
+		t.Errorf("NewMember with properties too long error %v, want ErrMemberTooLong", err)
+	}
+}
+
+func TestParseMember(t *testing.T) {
+	m, err := ParseMember(" key = value ;flag; prop = a%20b ;unknown=x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Key() != "key" || m.Value() != "value" {
+		t.Errorf("member %q, %q", m.Key(), m.Value())
+	}
+	want := []struct {
+		key, value string
+		hasValue   bool
+	}{{"flag", "", false}, {"prop", "a b", true}, {"unknown", "x", true}}
+	props := m.Properties()
+	if len(props) != len(want) {
+		t.Fatalf("%d properties, want %d", len(props), len(want))
+	}
+	for i, p := range props {
+		v, ok := p.Value()
+		if p.Key() != want[i].key || v != want[i].value || ok != want[i].hasValue {
+			t.Errorf("property %d: %q, %q, %t, want %v", i, p.Key(), v, ok, want[i])
+		}
+	}
+
+	// Unknown properties round-trip.
+	if got, want := m.String(), "key=value;flag;prop=a%20b;unknown=x"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	again, err := ParseMember(m.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.String() != m.String() {
+		t.Errorf("re-parsed member %q, want %q", again.String(), m.String())
+	}
+
+	for _, invalid := range []string{"", "key", "=value", "key=%zz", "key=value;=x", "key=value;p=%zz", "a%20b=value", "key=%ff", "key=value;p q"} {
+		if _, err := ParseMember(invalid); !errors.Is(err, ErrInvalidMember) {
+			t.Errorf("ParseMember(%q) error %v, want ErrInvalidMember", invalid, err)
+		}
+	}
+	if _, err := ParseMember("key=" + strings.Repeat("v", MaxMemberBytes)); !errors.Is(err, ErrMemberTooLong) {
+		t.Errorf("ParseMember of a long member error %v, want ErrMemberTooLong", err)
+	}
+}
+
+func TestContextWithMembers(t *testing.T) {
+	flag, _ := NewKeyProperty("flag")
+	m1, _ := NewMember("b", "1", flag)
+	m2, _ := NewMember("a", "2")
+
+	ctx := ContextWithHopLimitedValues(context.Background(), 2, attribute.String("b", "0"))
+	ctx = ContextWithValues(ctx, attribute.Int("c", 3))
+	ctx, err := ContextWithMembers(ctx, m1, m2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v := Value(ctx, "b"); v.AsString() != "1" {
+		t.Errorf("value of b %q, want %q", v.AsString(), "1")
+	}
+	if _, ok := HopLimit(ctx, "b"); ok {
+		t.Error("updated member is still hop-limited")
+	}
+
+	members := Members(ctx)
+	var got []string
+	for _, m := range members {
+		got = append(got, m.String())
+	}
+	want := []string{"a=2", "b=1;flag", "c=3"}
+	if len(got) != len(want) {
+		t.Fatalf("Members() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Members() = %v, want %v", got, want)
+			break
+		}
+	}
+
+	// Updating a value removes its properties.
+	ctx = ContextWithValues(ctx, attribute.String("b", "2"))
+	for _, m := range Members(ctx) {
+		if m.Key() == "b" && len(m.Properties()) != 0 {
+			t.Errorf("updated value has properties %v", m.Properties())
+		}
+	}
+}
+
+func TestContextWithMembersLimits(t *testing.T) {
+	members := make([]Member, MaxMembers+1)
+	for i := range members {
+		members[i], _ = NewMember(fmt.Sprintf("key%d", i), "v")
+	}
+	ctx, err := ContextWithMembers(context.Background(), members[:MaxMembers]...)
+	if err != nil {
+		t.Fatalf("ContextWithMembers of MaxMembers: %v", err)
+	}
+	if _, err := ContextWithMembers(ctx, members[MaxMembers]); !errors.Is(err, ErrTooManyMembers) {
+		t.Errorf("ContextWithMembers of too many members error %v, want ErrTooManyMembers", err)
+	}
+	// Updating a member does not add one.
+	if _, err := ContextWithMembers(ctx, members[0]); err != nil {
+		t.Errorf("ContextWithMembers updating a member: %v", err)
+	}
+
+	long := func(key string, n int) Member {
+		m, err := NewMember(key, strings.Repeat("v", n-len(key+"=")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return m
+	}
+	// The members and their separator fit exactly.
+	ctx, err = ContextWithMembers(context.Background(), long("a", MaxMemberBytes), long("b", MaxBytes-MaxMemberBytes-1))
+	if err != nil {
+		t.Fatalf("ContextWithMembers of MaxBytes: %v", err)
+	}
+	c, _ := NewMember("c", "")
+	got, err := ContextWithMembers(ctx, c)
+	if !errors.Is(err, ErrBaggageTooLong) {
+		t.Errorf("ContextWithMembers of a baggage too long error %v, want ErrBaggageTooLong", err)
+	}
+	if got != ctx {
+		t.Error("ContextWithMembers error did not return the parent")
+	}
+}
@@ -65,7 +65,7 @@ func (d *checkpointSet) ForEach(exporter export.ExportKindSelector, f func(expor
 	for _, m := range d.metrics {
 		descriptor, err := convertDescriptor(m.Descriptor)
 		if err != nil {
-			otel.Handle(err)
+			otel.HandleSignal(otel.MetricsSignal, err)
 			continue
 		}
 		res := convertResource(m.Resource)
@@ -75,12 +75,12 @@ func (d *checkpointSet) ForEach(exporter export.ExportKindSelector, f func(expor
 			}
 			ls, err := convertLabels(m.Descriptor.LabelKeys, ts.LabelValues)
 			if err != nil {
-				otel.Handle(err)
+				otel.HandleSignal(otel.MetricsSignal, err)
 				continue
 			}
 			agg, err := newAggregationFromPoints(ts.Points)
 			if err != nil {
-				otel.Handle(err)
+				otel.HandleSignal(otel.MetricsSignal, err)
 				continue
 			}
 			if err := f(export.NewRecord(
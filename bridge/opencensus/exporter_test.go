@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -472,7 +473,7 @@ func TestConvertDescriptor(t *testing.T) {
 			if !errors.Is(err, tc.expectedErr) {
 				t.Errorf("convertDescriptor(%v) = err(%v), want err(%v)", tc.input, err, tc.expectedErr)
 			}
-			if output != tc.expected {
+			if !reflect.DeepEqual(output, tc.expected) {
 				t.Errorf("convertDescriptor(%v) = %+v, want %+v", tc.input, output, tc.expected)
 			}
 		})
@@ -3,6 +3,7 @@ module go.opentelemetry.io/otel/bridge/opencensus
 go 1.14
 
 require (
+	github.com/stretchr/testify v1.7.0
 	go.opencensus.io v0.22.6-0.20201102222123-380f4078db9f
 	go.opentelemetry.io/otel v0.19.0
 	go.opentelemetry.io/otel/metric v0.19.0
@@ -54,4 +55,6 @@ replace go.opentelemetry.io/otel/sdk/export/metric => ../../sdk/export/metric
 
 replace go.opentelemetry.io/otel/sdk/metric => ../../sdk/metric
 
+replace go.opentelemetry.io/otel/sdk/trace/selfmetrics => ../../sdk/trace/selfmetrics
+
 replace go.opentelemetry.io/otel/trace => ../../trace
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus // import "go.opentelemetry.io/otel/bridge/opencensus"
+
+import (
+	"context"
+
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+)
+
+// NewMetricProducer returns an OpenTelemetry Producer of the metric data
+// of the OpenCensus metric producers registered with the OpenCensus
+// global metric producer manager, e.g. the OpenCensus views.  It exports
+// the metric data recorded with the OpenCensus API along with the metric
+// data of an OpenTelemetry controller, see basic.WithProducer.
+func NewMetricProducer() export.Producer {
+	return &producer{manager: metricproducer.GlobalManager()}
+}
+
+// producer implements the OpenTelemetry Producer interface reading the
+// OpenCensus metric producers of manager.
+type producer struct {
+	manager *metricproducer.Manager
+}
+
+// Produce implements the OpenTelemetry Producer interface.
+func (p *producer) Produce(context.Context) ([]export.Record, error) {
+	var metrics []*metricdata.Metric
+	for _, ocProducer := range p.manager.GetAll() {
+		metrics = append(metrics, ocProducer.Read()...)
+	}
+
+	var records []export.Record
+	err := (&checkpointSet{metrics: metrics}).ForEach(nil, func(r export.Record) error {
+		records = append(records, r)
+		return nil
+	})
+	return records, err
+}
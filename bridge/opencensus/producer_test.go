@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencensus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/metric/metricdata"
+	"go.opencensus.io/metric/metricproducer"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+type fakeOCProducer struct {
+	metrics []*metricdata.Metric
+}
+
+func (f *fakeOCProducer) Read() []*metricdata.Metric {
+	return f.metrics
+}
+
+func TestMetricProducer(t *testing.T) {
+	now := time.Now()
+	ocProducer := &fakeOCProducer{
+		metrics: []*metricdata.Metric{
+			{
+				Descriptor: metricdata.Descriptor{
+					Name:      "oc.requests",
+					Type:      metricdata.TypeCumulativeInt64,
+					LabelKeys: []metricdata.LabelKey{{Key: "route"}},
+				},
+				TimeSeries: []*metricdata.TimeSeries{
+					{
+						LabelValues: []metricdata.LabelValue{{Value: "/", Present: true}},
+						Points:      []metricdata.Point{metricdata.NewInt64Point(now, 12)},
+						StartTime:   now.Add(-time.Minute),
+					},
+				},
+			},
+		},
+	}
+	metricproducer.GlobalManager().AddProducer(ocProducer)
+	defer metricproducer.GlobalManager().DeleteProducer(ocProducer)
+
+	records, err := NewMetricProducer().Produce(context.Background())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	r := records[0]
+	assert.Equal(t, "oc.requests", r.Descriptor().Name())
+	labels := attribute.NewSet(attribute.String("route", "/"))
+	assert.Equal(t, labels.Equivalent(), r.Labels().Equivalent())
+	assert.Equal(t, now.Add(-time.Minute), r.StartTime())
+	assert.Equal(t, now, r.EndTime())
+	value, _, err := r.Aggregation().(aggregation.LastValue).LastValue()
+	require.NoError(t, err)
+	assert.EqualValues(t, 12, value.AsInt64())
+}
@@ -18,17 +18,18 @@ import (
 	"fmt"
 
 	octrace "go.opencensus.io/trace"
+	ocpropagation "go.opencensus.io/trace/propagation"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 )
 
 // OTelSpanContextToOC converts from an OpenTelemetry SpanContext to an
-// OpenCensus SpanContext, and handles any incompatibilities with the global
-// error handler.
+// OpenCensus SpanContext, and reports any incompatibilities to the error
+// handler of the traces signal.
 func OTelSpanContextToOC(sc trace.SpanContext) octrace.SpanContext {
 	if sc.IsDebug() || sc.IsDeferred() {
-		otel.Handle(fmt.Errorf("ignoring OpenTelemetry Debug or Deferred trace flags for span %q because they are not supported by OpenCensus", sc.SpanID()))
+		otel.HandleSignal(otel.TracesSignal, fmt.Errorf("ignoring OpenTelemetry Debug or Deferred trace flags for span %q because they are not supported by OpenCensus", sc.SpanID()))
 	}
 	var to octrace.TraceOptions
 	if sc.IsSampled() {
@@ -55,3 +56,30 @@ func OCSpanContextToOTel(sc octrace.SpanContext) trace.SpanContext {
 		TraceFlags: traceFlags,
 	})
 }
+
+// OTelSpanContextToOCBinary encodes an OpenTelemetry SpanContext in the
+// OpenCensus binary format, the format of the gRPC `grpc-trace-bin`
+// metadata. It returns nil if sc is not valid. The incompatibilities with
+// OpenCensus are handled as in OTelSpanContextToOC.
+func OTelSpanContextToOCBinary(sc trace.SpanContext) []byte {
+	if !sc.IsValid() {
+		return nil
+	}
+	return ocpropagation.Binary(OTelSpanContextToOC(sc))
+}
+
+// OCBinaryToOTelSpanContext decodes an OpenTelemetry SpanContext from the
+// OpenCensus binary format, the format of the gRPC `grpc-trace-bin`
+// metadata. The returned SpanContext is remote. If b is not a valid
+// encoding, false is returned.
+func OCBinaryToOTelSpanContext(b []byte) (trace.SpanContext, bool) {
+	sc, ok := ocpropagation.FromBinary(b)
+	if !ok {
+		return trace.SpanContext{}, false
+	}
+	otelSC := OCSpanContextToOTel(sc)
+	if !otelSC.IsValid() {
+		return trace.SpanContext{}, false
+	}
+	return otelSC.WithRemote(true), true
+}
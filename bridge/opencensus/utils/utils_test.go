@@ -15,12 +15,15 @@
 package utils
 
 import (
+	"bytes"
+	"context"
 	"testing"
 
 	"go.opencensus.io/trace/tracestate"
 
 	octrace "go.opencensus.io/trace"
 
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -136,3 +139,40 @@ func TestOCSpanContextToOTel(t *testing.T) {
 		})
 	}
 }
+
+func TestOCBinaryRoundTrip(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID([16]byte{1}),
+		SpanID:     trace.SpanID([8]byte{2}),
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	b := OTelSpanContextToOCBinary(sc)
+	if b == nil {
+		t.Fatal("Got nil binary encoding of a valid spancontext.")
+	}
+	// The OpenCensus encoding is the one of the gRPC binary propagator.
+	expected := propagation.GRPCBinary{}.Inject(trace.ContextWithSpanContext(context.Background(), sc))
+	if !bytes.Equal(b, expected) {
+		t.Fatalf("Got %x binary encoding, expected %x.", b, expected)
+	}
+
+	output, ok := OCBinaryToOTelSpanContext(b)
+	if !ok {
+		t.Fatal("Failed to decode the binary encoding.")
+	}
+	if !output.Equal(sc.WithRemote(true)) {
+		t.Fatalf("Got %+v spancontext, exepected %+v.", output, sc.WithRemote(true))
+	}
+}
+
+func TestOCBinaryInvalid(t *testing.T) {
+	if b := OTelSpanContextToOCBinary(trace.SpanContext{}); b != nil {
+		t.Fatalf("Got %x binary encoding of an invalid spancontext, expected nil.", b)
+	}
+	for _, b := range [][]byte{nil, {1, 2, 3}, make([]byte, 29)} {
+		if sc, ok := OCBinaryToOTelSpanContext(b); ok || sc.IsValid() {
+			t.Fatalf("Decoded %+v spancontext from invalid encoding %x.", sc, b)
+		}
+	}
+}
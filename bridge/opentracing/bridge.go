@@ -18,7 +18,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 	"sync"
 
 	ot "github.com/opentracing/opentracing-go"
@@ -35,6 +34,13 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
+// Keys and values of the OpenTracing log fields semantic conventions.
+const (
+	otLogEventKey       = "event"
+	otLogErrorObjectKey = "error.object"
+	otLogErrorEvent     = "error"
+)
+
 type bridgeSpanContext struct {
 	baggageItems    baggage.Map
 	otelSpanContext trace.SpanContext
@@ -69,7 +75,12 @@ func (c *bridgeSpanContext) setBaggageItem(restrictedKey, value string) {
 
 func (c *bridgeSpanContext) baggageItem(restrictedKey string) string {
 	crk := http.CanonicalHeaderKey(restrictedKey)
-	val, _ := c.baggageItems.Value(attribute.Key(crk))
+	val, ok := c.baggageItems.Value(attribute.Key(crk))
+	if !ok {
+		// The baggage items extracted with the propagator keep the
+		// keys they were propagated with.
+		val, _ = c.baggageItems.Value(attribute.Key(restrictedKey))
+	}
 	return val.Emit()
 }
 
@@ -113,11 +124,44 @@ func (s *bridgeSpan) FinishWithOptions(opts ot.FinishOptions) {
 }
 
 func (s *bridgeSpan) logRecord(record ot.LogRecord) {
-	s.otelSpan.AddEvent(
-		"",
-		trace.WithTimestamp(record.Timestamp),
-		trace.WithAttributes(otLogFieldsToOTelLabels(record.Fields)...),
+	s.logFields(record.Fields, trace.WithTimestamp(record.Timestamp))
+}
+
+// logFields adds fields as an event of the span. Following the OpenTracing
+// semantic conventions, the value of the "event" field names the event, and
+// an "error" event or an error in the "error.object" field, as logged by
+// ext.LogError, sets the span status to Error. The error is recorded with
+// RecordError, the other fields become attributes of its event.
+func (s *bridgeSpan) logFields(fields []otlog.Field, opts ...trace.EventOption) {
+	var (
+		name string
+		err  error
 	)
+	attrFields := make([]otlog.Field, 0, len(fields))
+	for _, field := range fields {
+		switch field.Key() {
+		case otLogEventKey:
+			if v, ok := field.Value().(string); ok {
+				name = v
+			}
+		case otLogErrorObjectKey:
+			if e, ok := field.Value().(error); ok {
+				err = e
+				continue
+			}
+		}
+		attrFields = append(attrFields, field)
+	}
+	opts = append(opts, trace.WithAttributes(otLogFieldsToOTelLabels(attrFields)...))
+
+	if err != nil || name == otLogErrorEvent {
+		s.otelSpan.SetStatus(codes.Error, "")
+	}
+	if err != nil {
+		s.otelSpan.RecordError(err, opts...)
+		return
+	}
+	s.otelSpan.AddEvent(name, opts...)
 }
 
 func (s *bridgeSpan) Context() ot.SpanContext {
@@ -132,12 +176,14 @@ func (s *bridgeSpan) SetOperationName(operationName string) ot.Span {
 // SetTag method adds a tag to the span.
 //
 // Note about the following value conversions:
-// - int -> int64
+// - int, int8, int16, int32 -> int64
 // - uint -> string
-// - int32 -> int64
-// - uint32 -> int64
+// - uint8, uint16, uint32 -> int64
 // - uint64 -> string
 // - float32 -> float64
+// - error -> string, the error message
+// - []bool, []int, []int64, []float64, []string -> the matching slice
+// - anything else -> string, as formatted by fmt.Sprint
 func (s *bridgeSpan) SetTag(key string, value interface{}) ot.Span {
 	switch key {
 	case string(otext.SpanKind):
@@ -153,10 +199,7 @@ func (s *bridgeSpan) SetTag(key string, value interface{}) ot.Span {
 }
 
 func (s *bridgeSpan) LogFields(fields ...otlog.Field) {
-	s.otelSpan.AddEvent(
-		"",
-		trace.WithAttributes(otLogFieldsToOTelLabels(fields)...),
-	)
+	s.logFields(fields)
 }
 
 type bridgeFieldEncoder struct {
@@ -381,13 +424,23 @@ func (t *BridgeTracer) baggageGetHook(ctx context.Context, m baggage.Map) baggag
 		return m
 	}
 	items := bSpan.extraBaggageItems
-	if len(items) == 0 {
+	if len(items) == 0 && bSpan.ctx.baggageItems.Len() == 0 {
 		return m
 	}
-	kv := make([]attribute.KeyValue, 0, len(items))
+	kv := make([]attribute.KeyValue, 0, len(items)+bSpan.ctx.baggageItems.Len())
+	overridden := make(map[attribute.Key]struct{}, len(items))
 	for k, v := range items {
 		kv = append(kv, attribute.String(k, v))
+		overridden[attribute.Key(http.CanonicalHeaderKey(k))] = struct{}{}
 	}
+	// Also expose the baggage items the span inherited from its parent
+	// or extracted span context, not only the ones set on the span.
+	bSpan.ctx.baggageItems.Foreach(func(item attribute.KeyValue) bool {
+		if _, ok := overridden[item.Key]; !ok {
+			kv = append(kv, item)
+		}
+		return true
+	})
 	return m.Apply(baggage.MapUpdate{MultiKV: kv})
 }
 
@@ -480,15 +533,12 @@ func otTagsToOTelAttributesKindAndError(tags map[string]interface{}) ([]attribut
 		switch k {
 		case string(otext.SpanKind):
 			if s, ok := v.(string); ok {
-				switch strings.ToLower(s) {
-				case "client":
-					kind = trace.SpanKindClient
-				case "server":
-					kind = trace.SpanKindServer
-				case "producer":
-					kind = trace.SpanKindProducer
-				case "consumer":
-					kind = trace.SpanKindConsumer
+				switch sk, _ := trace.ParseSpanKind(s); sk {
+				case trace.SpanKindClient,
+					trace.SpanKindServer,
+					trace.SpanKindProducer,
+					trace.SpanKindConsumer:
+					kind = sk
 				}
 			}
 		case string(otext.Error):
@@ -529,10 +579,30 @@ func otTagToOTelLabel(k string, v interface{}) attribute.KeyValue {
 		return key.Float64(float64(val))
 	case int:
 		return key.Int(val)
+	case int8:
+		return key.Int64(int64(val))
+	case int16:
+		return key.Int64(int64(val))
+	case uint8:
+		return key.Int64(int64(val))
+	case uint16:
+		return key.Int64(int64(val))
 	case uint:
 		return key.String(fmt.Sprintf("%d", val))
 	case string:
 		return key.String(val)
+	case error:
+		return key.String(val.Error())
+	case []bool:
+		return key.BoolSlice(val)
+	case []int:
+		return key.IntSlice(val)
+	case []int64:
+		return key.Int64Slice(val)
+	case []float64:
+		return key.Float64Slice(val)
+	case []string:
+		return key.StringSlice(val)
 	default:
 		return key.String(fmt.Sprint(v))
 	}
@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentracing
+
+import (
+	"errors"
+	"testing"
+
+	ot "github.com/opentracing/opentracing-go"
+	otext "github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+
+	"go.opentelemetry.io/otel/bridge/opentracing/internal"
+)
+
+func newBridgeTestSpan(t *testing.T) (ot.Span, *internal.MockTracer) {
+	mockOtelTracer := internal.NewMockTracer()
+	otTracer, _ := NewTracerPair(mockOtelTracer)
+	otTracer.SetWarningHandler(func(msg string) {
+		t.Log(msg)
+	})
+	return otTracer.StartSpan("span"), mockOtelTracer
+}
+
+func finishedMockSpan(t *testing.T, span ot.Span, tracer *internal.MockTracer) *internal.MockSpan {
+	span.Finish()
+	if len(tracer.FinishedSpans) != 1 {
+		t.Fatalf("Expected 1 finished span, got %d", len(tracer.FinishedSpans))
+	}
+	return tracer.FinishedSpans[0]
+}
+
+func mockStatusCode(span *internal.MockSpan) (codes.Code, bool) {
+	v, ok := span.Attributes.Value(internal.StatusCodeKey)
+	return codes.Code(v.AsInt64()), ok
+}
+
+func TestLogFieldsTyped(t *testing.T) {
+	span, tracer := newBridgeTestSpan(t)
+	span.LogFields(
+		otlog.Event("retry"),
+		otlog.Int("attempt", 2),
+		otlog.Bool("backoff", true),
+		otlog.Float64("delay", 1.5),
+		otlog.String("message", "retrying"),
+	)
+	span.LogKV("count", uint32(3))
+	mockSpan := finishedMockSpan(t, span, tracer)
+
+	if len(mockSpan.Events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(mockSpan.Events))
+	}
+	event := mockSpan.Events[0]
+	if event.Name != "retry" {
+		t.Errorf("Expected event name retry, got %q", event.Name)
+	}
+	expected := []attribute.KeyValue{
+		attribute.String("event", "retry"),
+		attribute.Int("attempt", 2),
+		attribute.Bool("backoff", true),
+		attribute.Float64("delay", 1.5),
+		attribute.String("message", "retrying"),
+	}
+	for _, kv := range expected {
+		if got, _ := event.Attributes.Value(kv.Key); got != kv.Value {
+			t.Errorf("Expected event attribute %s = %v, got %v", kv.Key, kv.Value.AsInterface(), got.AsInterface())
+		}
+	}
+	if got, _ := mockSpan.Events[1].Attributes.Value("count"); got != attribute.Int64Value(3) {
+		t.Errorf("Expected event attribute count = 3, got %v", got.AsInterface())
+	}
+	if _, ok := mockStatusCode(mockSpan); ok {
+		t.Errorf("Expected no span status to be set")
+	}
+}
+
+func TestLogError(t *testing.T) {
+	span, tracer := newBridgeTestSpan(t)
+	err := errors.New("connection refused")
+	otext.LogError(span, err, otlog.String("message", "dial failed"))
+	mockSpan := finishedMockSpan(t, span, tracer)
+
+	if code, _ := mockStatusCode(mockSpan); code != codes.Error {
+		t.Errorf("Expected span status %s, got %s", codes.Error, code)
+	}
+	if len(mockSpan.Events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(mockSpan.Events))
+	}
+	event := mockSpan.Events[0]
+	if event.Name != semconv.ExceptionEventName {
+		t.Errorf("Expected event name %s, got %q", semconv.ExceptionEventName, event.Name)
+	}
+	if got, _ := event.Attributes.Value(semconv.ExceptionMessageKey); got.AsString() != err.Error() {
+		t.Errorf("Expected exception message %q, got %q", err.Error(), got.AsString())
+	}
+	if got, _ := event.Attributes.Value("message"); got.AsString() != "dial failed" {
+		t.Errorf("Expected message attribute %q, got %q", "dial failed", got.AsString())
+	}
+	if event.Attributes.HasValue("error.object") {
+		t.Errorf("Expected error.object not to be stringified into an attribute")
+	}
+}
+
+func TestLogErrorEventWithoutObject(t *testing.T) {
+	span, tracer := newBridgeTestSpan(t)
+	span.LogKV("event", "error", "message", "timeout")
+	mockSpan := finishedMockSpan(t, span, tracer)
+
+	if code, _ := mockStatusCode(mockSpan); code != codes.Error {
+		t.Errorf("Expected span status %s, got %s", codes.Error, code)
+	}
+	if len(mockSpan.Events) != 1 || mockSpan.Events[0].Name != "error" {
+		t.Errorf("Expected a single error event, got %v", mockSpan.Events)
+	}
+}
+
+func TestSetTagTyped(t *testing.T) {
+	span, tracer := newBridgeTestSpan(t)
+	span.SetTag("int8", int8(1))
+	span.SetTag("uint16", uint16(2))
+	span.SetTag("err", errors.New("boom"))
+	span.SetTag("strings", []string{"a", "b"})
+	span.SetTag("ints", []int{1, 2})
+	span.SetTag(string(otext.Error), true)
+	mockSpan := finishedMockSpan(t, span, tracer)
+
+	expected := []attribute.KeyValue{
+		attribute.Int64("int8", 1),
+		attribute.Int64("uint16", 2),
+		attribute.String("err", "boom"),
+		attribute.StringSlice("strings", []string{"a", "b"}),
+		attribute.IntSlice("ints", []int{1, 2}),
+	}
+	for _, kv := range expected {
+		if got, _ := mockSpan.Attributes.Value(kv.Key); got != kv.Value {
+			t.Errorf("Expected attribute %s = %v, got %v", kv.Key, kv.Value.AsInterface(), got.AsInterface())
+		}
+	}
+	if code, _ := mockStatusCode(mockSpan); code != codes.Error {
+		t.Errorf("Expected span status %s, got %s", codes.Error, code)
+	}
+}
@@ -28,6 +28,13 @@
 // bridge tracer and then passing the chosen OpenTelemetry tracer to
 // the SetOpenTelemetryTracer() function of the bridge tracer.
 //
+// To bridge to the tracers of an OpenTelemetry TracerProvider instead
+// of a single tracer, use the NewTracerPairWithProvider() function.
+// Passing the global TracerProvider returned by otel.GetTracerProvider()
+// allows OpenTracing to be set up before the OpenTelemetry SDK: spans
+// are forwarded to the TracerProvider registered later with
+// otel.SetTracerProvider().
+//
 // Bridge tracer also allows the user to install a warning handler
 // through the SetWarningHandler() function. The warning handler will
 // be called when there is some misbehavior of the OpenTelemetry
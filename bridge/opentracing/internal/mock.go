@@ -24,7 +24,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/internal/baggage"
-	"go.opentelemetry.io/otel/semconv"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/otel/bridge/opentracing/migration"
@@ -218,6 +218,8 @@ func (s *MockSpan) SetName(name string) {
 	s.SetAttributes(NameKey.String(name))
 }
 
+func (s *MockSpan) AddLink(trace.Link) {}
+
 func (s *MockSpan) SetError(v bool) {
 	s.SetAttributes(ErrorKey.Bool(v))
 }
@@ -228,6 +230,13 @@ func (s *MockSpan) SetAttributes(attributes ...attribute.KeyValue) {
 	})
 }
 
+func (s *MockSpan) SetAttributeSet(set *attribute.Set) {
+	if set == nil {
+		return
+	}
+	s.SetAttributes(set.ToSlice()...)
+}
+
 func (s *MockSpan) applyUpdate(update baggage.MapUpdate) {
 	s.Attributes = s.Attributes.Apply(update)
 }
@@ -17,6 +17,7 @@ package opentracing
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
 	ot "github.com/opentracing/opentracing-go"
@@ -24,6 +25,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	otelbaggage "go.opentelemetry.io/otel/internal/baggage"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/otel/bridge/opentracing/internal"
@@ -611,6 +613,50 @@ func generateBaggageKeys(key string) (otKey, otelKey string) {
 	return
 }
 
+func TestExtractedBaggageInteroperation(t *testing.T) {
+	mockOtelTracer := internal.NewMockTracer()
+	ctx, otTracer, _ := NewTracerPairWithContext(context.Background(), mockOtelTracer)
+	otTracer.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	header := http.Header{}
+	header.Set("traceparent", "00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01")
+	header.Set("baggage", "userid=alice,Tenant=acme")
+	sc, err := otTracer.Extract(ot.HTTPHeaders, ot.HTTPHeadersCarrier(header))
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	span := otTracer.StartSpan("server", ot.ChildOf(sc))
+	defer span.Finish()
+
+	// The extracted baggage items are available to OpenTracing...
+	if got := sc.(*bridgeSpanContext).baggageItem("userid"); got != "alice" {
+		t.Errorf("Expected extracted baggage item userid to be alice, got %q", got)
+	}
+	if got := span.BaggageItem("tenant"); got != "acme" {
+		t.Errorf("Expected inherited baggage item tenant to be acme, got %q", got)
+	}
+
+	// ...and to OpenTelemetry.
+	ctx = ot.ContextWithSpan(ctx, span)
+	otelRecording := make(map[string]string)
+	otelbaggage.MapFromContext(ctx).Foreach(func(kv attribute.KeyValue) bool {
+		otelRecording[string(kv.Key)] = kv.Value.Emit()
+		return true
+	})
+	expected := map[string]string{"Userid": "alice", "Tenant": "acme"}
+	if len(otelRecording) != len(expected) {
+		t.Errorf("Expected OpenTelemetry baggage %v, got %v", expected, otelRecording)
+	}
+	for k, v := range expected {
+		if otelRecording[k] != v {
+			t.Errorf("Expected OpenTelemetry baggage item %s to be %s, got %q", k, v, otelRecording[k])
+		}
+	}
+}
+
 // helpers
 
 func checkTraceAndSpans(t *testing.T, tracer *internal.MockTracer, expectedTraceID trace.TraceID, expectedSpanIDs []trace.SpanID) {
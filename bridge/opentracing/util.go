@@ -33,6 +33,26 @@ func NewTracerPair(tracer trace.Tracer) (*BridgeTracer, *WrapperTracerProvider)
 	return bridgeTracer, wrapperProvider
 }
 
+// NewTracerPairWithProvider is a utility function that creates a
+// BridgeTracer and a WrapperTracerProvider wrapping the passed
+// OpenTelemetry TracerProvider. The BridgeTracer forwards the calls to the
+// WrapperTracer returned by the WrapperTracerProvider for name and opts.
+//
+// Every call creates an independent pair, so multiple BridgeTracers can be
+// bound to different TracerProviders. When provider is the global
+// TracerProvider returned by otel.GetTracerProvider, spans started with the
+// BridgeTracer are forwarded to the TracerProvider registered later with
+// otel.SetTracerProvider, so the pair can be created before the
+// OpenTelemetry SDK is set up. In that case the returned
+// WrapperTracerProvider must not itself be registered with
+// otel.SetTracerProvider.
+func NewTracerPairWithProvider(provider trace.TracerProvider, name string, opts ...trace.TracerOption) (*BridgeTracer, *WrapperTracerProvider) {
+	bridgeTracer := NewBridgeTracer()
+	wrapperProvider := NewWrapperTracerProvider(bridgeTracer, provider)
+	bridgeTracer.SetOpenTelemetryTracer(wrapperProvider.Tracer(name, opts...))
+	return bridgeTracer, wrapperProvider
+}
+
 func NewTracerPairWithContext(ctx context.Context, tracer trace.Tracer) (context.Context, *BridgeTracer, *WrapperTracerProvider) {
 	bridgeTracer, wrapperProvider := NewTracerPair(tracer)
 	ctx = bridgeTracer.NewHookedContext(ctx)
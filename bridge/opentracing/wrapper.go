@@ -16,21 +16,53 @@ package opentracing // import "go.opentelemetry.io/otel/bridge/opentracing"
 
 import (
 	"context"
+	"sync"
 
 	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/otel/bridge/opentracing/migration"
 )
 
+// WrapperTracerProvider is an OpenTelemetry TracerProvider that returns
+// WrapperTracers.
 type WrapperTracerProvider struct {
 	wTracer *WrapperTracer
+
+	bridge   *BridgeTracer
+	provider trace.TracerProvider
+	mu       sync.Mutex
+	tracers  map[wrappedTracerKey]*WrapperTracer
+}
+
+type wrappedTracerKey struct {
+	name      string
+	version   string
+	schemaURL string
 }
 
 var _ trace.TracerProvider = (*WrapperTracerProvider)(nil)
 
 // Tracer returns the WrapperTracer associated with the WrapperTracerProvider.
-func (p *WrapperTracerProvider) Tracer(_ string, _ ...trace.TracerOption) trace.Tracer {
-	return p.wTracer
+// If the WrapperTracerProvider wraps a TracerProvider, the returned
+// WrapperTracer wraps the tracer of that provider with the same name and
+// options, and the same WrapperTracer is returned for subsequent calls with
+// the same name, version, and schema URL.
+func (p *WrapperTracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	if p.provider == nil {
+		return p.wTracer
+	}
+
+	c := trace.NewTracerConfig(opts...)
+	key := wrappedTracerKey{name: name, version: c.InstrumentationVersion, schemaURL: c.SchemaURL}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if t, ok := p.tracers[key]; ok {
+		return t
+	}
+	t := NewWrapperTracer(p.bridge, p.provider.Tracer(name, opts...))
+	p.tracers[key] = t
+	return t
 }
 
 // NewWrappedTracerProvider creates a new trace provider that creates a single
@@ -41,6 +73,24 @@ func NewWrappedTracerProvider(bridge *BridgeTracer, tracer trace.Tracer) *Wrappe
 	}
 }
 
+// NewWrapperTracerProvider creates a new trace provider that returns a
+// WrapperTracer for every tracer of the passed OpenTelemetry
+// TracerProvider.
+//
+// The passed provider can be the global TracerProvider returned by
+// otel.GetTracerProvider. Its tracers delegate to the TracerProvider
+// registered later with otel.SetTracerProvider, so the returned provider
+// can be created before the OpenTelemetry SDK is set up. In that case the
+// returned provider must not itself be registered with
+// otel.SetTracerProvider.
+func NewWrapperTracerProvider(bridge *BridgeTracer, provider trace.TracerProvider) *WrapperTracerProvider {
+	return &WrapperTracerProvider{
+		bridge:   bridge,
+		provider: provider,
+		tracers:  make(map[wrappedTracerKey]*WrapperTracer),
+	}
+}
+
 // WrapperTracer is a wrapper around an OpenTelemetry tracer. It
 // mostly forwards the calls to the wrapped tracer, but also does some
 // extra steps like setting up a context with the active OpenTracing
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opentracing
+
+import (
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/otel/bridge/opentracing/internal"
+)
+
+type mockTracerProvider struct {
+	tracer *internal.MockTracer
+}
+
+var _ trace.TracerProvider = mockTracerProvider{}
+
+func (p mockTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return p.tracer
+}
+
+func TestWrapperTracerProviderCachesTracers(t *testing.T) {
+	provider := NewWrapperTracerProvider(NewBridgeTracer(), mockTracerProvider{internal.NewMockTracer()})
+
+	a := provider.Tracer("a")
+	if _, ok := a.(*WrapperTracer); !ok {
+		t.Fatalf("expected a *WrapperTracer, got %T", a)
+	}
+	if provider.Tracer("a") != a {
+		t.Error("expected the same tracer for the same name")
+	}
+	if provider.Tracer("b") == a {
+		t.Error("expected a different tracer for a different name")
+	}
+	if provider.Tracer("a", trace.WithInstrumentationVersion("v1")) == a {
+		t.Error("expected a different tracer for a different version")
+	}
+}
+
+func TestTracerPairWithDeferredGlobalProvider(t *testing.T) {
+	otel.SwapTracerProvider(nil)
+	defer otel.SwapTracerProvider(nil)
+
+	bridgeTracer, _ := NewTracerPairWithProvider(otel.GetTracerProvider(), "deferred")
+	bridgeTracer.StartSpan("before").Finish()
+
+	mockTracer := internal.NewMockTracer()
+	otel.SetTracerProvider(mockTracerProvider{mockTracer})
+	bridgeTracer.StartSpan("after").Finish()
+
+	checkFinishedSpans(t, mockTracer, 1)
+}
+
+func TestTracerPairsWithDifferentProviders(t *testing.T) {
+	mockTracer1, mockTracer2 := internal.NewMockTracer(), internal.NewMockTracer()
+	bridgeTracer1, _ := NewTracerPairWithProvider(mockTracerProvider{mockTracer1}, "first")
+	bridgeTracer2, _ := NewTracerPairWithProvider(mockTracerProvider{mockTracer2}, "second")
+
+	bridgeTracer1.StartSpan("one").Finish()
+	bridgeTracer2.StartSpan("two").Finish()
+
+	checkFinishedSpans(t, mockTracer1, 1)
+	checkFinishedSpans(t, mockTracer2, 1)
+}
+
+func checkFinishedSpans(t *testing.T, tracer *internal.MockTracer, n int) {
+	t.Helper()
+	if got := len(tracer.FinishedSpans); got != n {
+		t.Errorf("expected %d finished spans, got %d", n, got)
+	}
+}
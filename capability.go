@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel // import "go.opentelemetry.io/otel"
+
+import (
+	"sort"
+	"sync"
+)
+
+// Capability describes an optional feature of OpenTelemetry that has been
+// compiled into the program. Vendor distributions and auto-instrumentation
+// agents embedding the SDK can use the registered capabilities to adapt at
+// runtime instead of relying on build tags.
+type Capability struct {
+	// Name identifies the capability. Packages registering a capability
+	// use their import path, or their import path followed by a "#" and
+	// a feature name when they provide several capabilities.
+	Name string
+	// Version is the version of the package providing the capability,
+	// e.g. the version of the SDK for the capabilities of the SDK and its
+	// exporters.
+	Version string
+	// Enabled reports whether the capability is usable. A capability may
+	// be compiled in and still be disabled by its configuration.
+	Enabled bool
+}
+
+var (
+	capabilitiesMu sync.RWMutex
+	capabilities   = map[string]Capability{}
+)
+
+// RegisterCapability registers c, replacing any capability previously
+// registered with the same name. Packages providing an optional capability
+// register it when they are initialized.
+func RegisterCapability(c Capability) {
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+	capabilities[c.Name] = c
+}
+
+// LookupCapability returns the capability registered with name and true, or
+// an empty Capability and false if none is registered.
+func LookupCapability(name string) (Capability, bool) {
+	capabilitiesMu.RLock()
+	defer capabilitiesMu.RUnlock()
+	c, ok := capabilities[name]
+	return c, ok
+}
+
+// HasCapability reports whether a capability named name is registered and
+// enabled.
+func HasCapability(name string) bool {
+	c, ok := LookupCapability(name)
+	return ok && c.Enabled
+}
+
+// Capabilities returns all registered capabilities sorted by name.
+func Capabilities() []Capability {
+	capabilitiesMu.RLock()
+	defer capabilitiesMu.RUnlock()
+	list := make([]Capability, 0, len(capabilities))
+	for _, c := range capabilities {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func resetCapabilities() func() {
+	capabilitiesMu.Lock()
+	saved := capabilities
+	capabilities = map[string]Capability{}
+	capabilitiesMu.Unlock()
+	return func() {
+		capabilitiesMu.Lock()
+		capabilities = saved
+		capabilitiesMu.Unlock()
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	defer resetCapabilities()()
+
+	assert.Empty(t, Capabilities())
+	_, ok := LookupCapability("example.com/b")
+	assert.False(t, ok)
+	assert.False(t, HasCapability("example.com/b"))
+
+	b := Capability{Name: "example.com/b", Version: "1.0.0", Enabled: true}
+	a := Capability{Name: "example.com/a", Version: "0.1.0"}
+	RegisterCapability(b)
+	RegisterCapability(a)
+
+	got, ok := LookupCapability("example.com/b")
+	assert.True(t, ok)
+	assert.Equal(t, b, got)
+	assert.True(t, HasCapability("example.com/b"))
+	// Registered, but disabled.
+	assert.False(t, HasCapability("example.com/a"))
+	assert.Equal(t, []Capability{a, b}, Capabilities())
+
+	a.Enabled = true
+	RegisterCapability(a)
+	assert.True(t, HasCapability("example.com/a"))
+	assert.Len(t, Capabilities(), 2)
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codes // import "go.opentelemetry.io/otel/codes"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ReasonKey is the attribute Key conforming to the status.reason attribute
+// used to record a more granular status than its Code, e.g. "throttled" or
+// "timeout".
+const ReasonKey = attribute.Key("status.reason")
+
+var (
+	// ErrInvalidReason is returned when registering an empty reason or a
+	// reason for a Code other than Error or Ok.
+	ErrInvalidReason = errors.New("invalid status reason")
+
+	// ErrReasonConflict is returned when registering a reason that is
+	// already registered for a different Code.
+	ErrReasonConflict = errors.New("status reason already registered")
+)
+
+var reasons = struct {
+	sync.RWMutex
+	codes map[string]Code
+}{codes: make(map[string]Code)}
+
+// RegisterReason registers reason as a more granular description of the
+// status Code c. Only the Error and Ok Codes can be refined by a reason.
+//
+// Registering a reason multiple times for the same Code is allowed,
+// registering it for different Codes returns an ErrReasonConflict.
+func RegisterReason(reason string, c Code) error {
+	if reason == "" || (c != Error && c != Ok) {
+		return fmt.Errorf("%w: %q for %s", ErrInvalidReason, reason, c)
+	}
+
+	reasons.Lock()
+	defer reasons.Unlock()
+	if registered, ok := reasons.codes[reason]; ok && registered != c {
+		return fmt.Errorf("%w: %q for %s", ErrReasonConflict, reason, registered)
+	}
+	reasons.codes[reason] = c
+	return nil
+}
+
+// ReasonCode returns the Code reason is registered for and true, or Unset
+// and false if reason is not registered.
+func ReasonCode(reason string) (Code, bool) {
+	reasons.RLock()
+	defer reasons.RUnlock()
+	c, ok := reasons.codes[reason]
+	return c, ok
+}
+
+// reasonError is an error annotated with a status reason.
+type reasonError struct {
+	err    error
+	reason string
+}
+
+func (e *reasonError) Error() string        { return e.err.Error() }
+func (e *reasonError) Unwrap() error        { return e.err }
+func (e *reasonError) StatusReason() string { return e.reason }
+
+// WithReason returns err annotated with the status reason. The reason is
+// reported by ErrorReason for the returned error, and any error wrapping it,
+// if it is registered.
+func WithReason(err error, reason string) error {
+	if err == nil {
+		return nil
+	}
+	return &reasonError{err: err, reason: reason}
+}
+
+// ErrorReason returns the registered status reason of err, and the Code it
+// is registered for, if err or any error it wraps has a StatusReason()
+// string method. Otherwise, false is returned.
+func ErrorReason(err error) (string, Code, bool) {
+	var r interface{ StatusReason() string }
+	if !errors.As(err, &r) {
+		return "", Unset, false
+	}
+	reason := r.StatusReason()
+	c, ok := ReasonCode(reason)
+	if !ok {
+		return "", Unset, false
+	}
+	return reason, c, true
+}
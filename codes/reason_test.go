@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codes
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestRegisterReason(t *testing.T) {
+	tests := []struct {
+		reason string
+		code   Code
+		want   error
+	}{
+		{"throttled", Error, nil},
+		{"throttled", Error, nil},
+		{"throttled", Ok, ErrReasonConflict},
+		{"cached", Ok, nil},
+		{"", Error, ErrInvalidReason},
+		{"unset", Unset, ErrInvalidReason},
+	}
+	for _, test := range tests {
+		err := RegisterReason(test.reason, test.code)
+		if !errors.Is(err, test.want) {
+			t.Errorf("RegisterReason(%q, %s) = %v, want %v", test.reason, test.code, err, test.want)
+		}
+	}
+
+	if c, ok := ReasonCode("throttled"); !ok || c != Error {
+		t.Errorf("ReasonCode(throttled) = %s, %t, want Error, true", c, ok)
+	}
+	if _, ok := ReasonCode("unset"); ok {
+		t.Error("invalid reason registered")
+	}
+}
+
+func TestErrorReason(t *testing.T) {
+	if err := RegisterReason("timeout", Error); err != nil {
+		t.Fatal(err)
+	}
+
+	base := errors.New("deadline exceeded")
+	tests := []struct {
+		name   string
+		err    error
+		reason string
+		ok     bool
+	}{
+		{"plain", base, "", false},
+		{"registered", WithReason(base, "timeout"), "timeout", true},
+		{"wrapped", fmt.Errorf("call: %w", WithReason(base, "timeout")), "timeout", true},
+		{"unregistered", WithReason(base, "unknown"), "", false},
+	}
+	for _, test := range tests {
+		reason, c, ok := ErrorReason(test.err)
+		if reason != test.reason || ok != test.ok {
+			t.Errorf("%s: ErrorReason() = %q, %t, want %q, %t", test.name, reason, ok, test.reason, test.ok)
+		}
+		if ok && c != Error {
+			t.Errorf("%s: ErrorReason() code = %s, want Error", test.name, c)
+		}
+	}
+
+	if WithReason(nil, "timeout") != nil {
+		t.Error("WithReason(nil) is not nil")
+	}
+	if got := WithReason(base, "timeout"); !errors.Is(got, base) || got.Error() != base.Error() {
+		t.Errorf("WithReason does not wrap the error: %v", got)
+	}
+}
@@ -15,7 +15,7 @@ require (
 	go.opentelemetry.io/otel/sdk v0.19.0
 	go.opentelemetry.io/otel/sdk/metric v0.19.0
 	go.opentelemetry.io/otel/trace v0.19.0
-	google.golang.org/grpc v1.36.1
+	google.golang.org/grpc v1.37.1
 )
 
 replace go.opentelemetry.io/otel/bridge/opencensus => ../../bridge/opencensus
@@ -54,4 +54,6 @@ replace go.opentelemetry.io/otel/sdk/export/metric => ../../sdk/export/metric
 
 replace go.opentelemetry.io/otel/sdk/metric => ../../sdk/metric
 
+replace go.opentelemetry.io/otel/sdk/trace/selfmetrics => ../../sdk/trace/selfmetrics
+
 replace go.opentelemetry.io/otel/trace => ../../trace
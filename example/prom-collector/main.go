@@ -41,6 +41,8 @@ func initMeter() {
 
 	res, err := resource.New(
 		ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
 		resource.WithAttributes(attribute.String("R", "V")),
 	)
 	if err != nil {
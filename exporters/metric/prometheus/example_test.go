@@ -37,10 +37,9 @@ import (
 // TODO: Address this issue.
 
 func ExampleNewExportPipeline() {
-	// Create a resource, with builtin attributes plus R=V.
+	// Create a resource with the attribute R=V.
 	res, err := resource.New(
 		context.Background(),
-		resource.WithoutBuiltin(), // Test-only!
 		resource.WithAttributes(attribute.String("R", "V")),
 	)
 	if err != nil {
@@ -86,9 +85,9 @@ func ExampleNewExportPipeline() {
 	fmt.Print(string(data))
 
 	// Output:
-	// # HELP a_counter Counts things
-	// # TYPE a_counter counter
-	// a_counter{R="V",key="value"} 100
+	// # HELP a_counter_total Counts things
+	// # TYPE a_counter_total counter
+	// a_counter_total{R="V",key="value"} 100
 	// # HELP a_valuerecorder Records values
 	// # TYPE a_valuerecorder histogram
 	// a_valuerecorder_bucket{R="V",key="value",le="+Inf"} 1
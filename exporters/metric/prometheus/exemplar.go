@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus // import "go.opentelemetry.io/otel/exporters/metric/prometheus"
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+const (
+	// traceIDLabel is the exemplar label containing the hex encoded
+	// trace ID of the span an exemplar was recorded in.
+	traceIDLabel = "trace_id"
+	// spanIDLabel is the exemplar label containing the hex encoded span
+	// ID of the span an exemplar was recorded in.
+	spanIDLabel = "span_id"
+)
+
+// metricWithExemplars wraps a prometheus.Metric adding the exemplars
+// retained by an OpenTelemetry Aggregator to its counter or histogram
+// buckets when written.
+type metricWithExemplars struct {
+	prometheus.Metric
+
+	kind      number.Kind
+	exemplars []aggregation.Exemplar
+}
+
+// withExemplars returns m wrapped so that it is written with the exemplars
+// of agg, if any are retained. Otherwise, m is returned unchanged.
+func withExemplars(m prometheus.Metric, agg aggregation.Aggregation, kind number.Kind) (prometheus.Metric, error) {
+	ex, ok := agg.(aggregation.Exemplars)
+	if !ok {
+		return m, nil
+	}
+	exemplars, err := ex.Exemplars()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving exemplars: %w", err)
+	}
+	if len(exemplars) == 0 {
+		return m, nil
+	}
+	return &metricWithExemplars{
+		Metric:    m,
+		kind:      kind,
+		exemplars: exemplars,
+	}, nil
+}
+
+// Write implements prometheus.Metric.
+func (m *metricWithExemplars) Write(pb *dto.Metric) error {
+	if err := m.Metric.Write(pb); err != nil {
+		return err
+	}
+
+	switch {
+	case pb.Counter != nil:
+		// A counter holds a single exemplar, use the most recent one.
+		latest := m.exemplars[0]
+		for _, e := range m.exemplars[1:] {
+			if e.Time.After(latest.Time) {
+				latest = e
+			}
+		}
+		e, err := m.toProto(latest)
+		if err != nil {
+			return err
+		}
+		pb.Counter.Exemplar = e
+	case pb.Histogram != nil:
+		for _, ex := range m.exemplars {
+			e, err := m.toProto(ex)
+			if err != nil {
+				return err
+			}
+			m.addToBucket(pb.Histogram, e)
+		}
+	}
+	return nil
+}
+
+// addToBucket sets e as the exemplar of the bucket of h its value falls
+// in. OpenTelemetry bucket boundaries are exclusive upper bounds, values
+// beyond the last boundary are added to an explicit +Inf bucket.
+func (m *metricWithExemplars) addToBucket(h *dto.Histogram, e *dto.Exemplar) {
+	v := e.GetValue()
+	for _, b := range h.Bucket {
+		if v < b.GetUpperBound() {
+			b.Exemplar = e
+			return
+		}
+	}
+	h.Bucket = append(h.Bucket, &dto.Bucket{
+		CumulativeCount: proto.Uint64(h.GetSampleCount()),
+		UpperBound:      proto.Float64(math.Inf(1)),
+		Exemplar:        e,
+	})
+}
+
+func (m *metricWithExemplars) toProto(ex aggregation.Exemplar) (*dto.Exemplar, error) {
+	ts, err := ptypes.TimestampProto(ex.Time)
+	if err != nil {
+		return nil, fmt.Errorf("error converting exemplar time: %w", err)
+	}
+	return &dto.Exemplar{
+		Label: []*dto.LabelPair{
+			{
+				Name:  proto.String(traceIDLabel),
+				Value: proto.String(ex.SpanContext.TraceID().String()),
+			},
+			{
+				Name:  proto.String(spanIDLabel),
+				Value: proto.String(ex.SpanContext.SpanID().String()),
+			},
+		},
+		Value:     proto.Float64(ex.Value.CoerceToFloat64(m.kind)),
+		Timestamp: ts,
+	}, nil
+}
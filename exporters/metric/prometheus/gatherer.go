@@ -0,0 +1,297 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus // import "go.opentelemetry.io/otel/exporters/metric/prometheus"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var errGathererConversion = errors.New("unable to convert from Prometheus to OpenTelemetry")
+
+// GathererBridge exports the metrics collected by a prometheus.Gatherer
+// through an OpenTelemetry exporter.  This allows applications with
+// hand-written Prometheus metrics to send them, e.g., via OTLP while they
+// are migrated to the OpenTelemetry API.
+//
+// Counters are exported as cumulative sums, gauges and untyped metrics as
+// last values and histograms as histograms.  Summaries are not supported
+// and are reported to the global error handler.
+type GathererBridge struct {
+	gatherer prometheus.Gatherer
+	base     export.Exporter
+	resource *resource.Resource
+	start    time.Time
+}
+
+// NewGathererBridge returns a GathererBridge exporting the metrics gathered
+// from g to base.  The resource res, which may be nil, is associated with
+// every exported record.
+func NewGathererBridge(g prometheus.Gatherer, base export.Exporter, res *resource.Resource) *GathererBridge {
+	return &GathererBridge{
+		gatherer: g,
+		base:     base,
+		resource: res,
+		start:    time.Now(),
+	}
+}
+
+// Export gathers the current metric families and exports them using the
+// base exporter.  Families that cannot be converted are reported to the
+// global error handler and skipped.
+func (b *GathererBridge) Export(ctx context.Context) error {
+	families, err := b.gatherer.Gather()
+	if err != nil {
+		// Gather may return partial results along with an error.
+		if len(families) == 0 {
+			return err
+		}
+		otel.HandleSignal(otel.MetricsSignal, err)
+	}
+	return b.base.Export(ctx, &gathererCheckpointSet{
+		families: families,
+		resource: b.resource,
+		start:    b.start,
+		now:      time.Now(),
+	})
+}
+
+type gathererCheckpointSet struct {
+	// RWMutex implements locking for the `CheckpointSet` interface.
+	sync.RWMutex
+	families []*dto.MetricFamily
+	resource *resource.Resource
+	start    time.Time
+	now      time.Time
+}
+
+// ForEach iterates through the gathered metric families, passing an
+// export.Record with the appropriate aggregation to an exporter.
+func (d *gathererCheckpointSet) ForEach(_ export.ExportKindSelector, f func(export.Record) error) error {
+	for _, family := range d.families {
+		descriptor, err := convertFamilyDescriptor(family)
+		if err != nil {
+			otel.HandleSignal(otel.MetricsSignal, err)
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			end := d.now
+			if m.TimestampMs != nil {
+				end = time.Unix(0, m.GetTimestampMs()*int64(time.Millisecond))
+			}
+			agg, err := convertMetricAggregation(family.GetType(), m, end)
+			if err != nil {
+				otel.HandleSignal(otel.MetricsSignal, err)
+				continue
+			}
+			ls := convertLabelPairs(m.GetLabel())
+			if err := f(export.NewRecord(
+				&descriptor,
+				&ls,
+				d.resource,
+				agg,
+				d.start,
+				end,
+			)); err != nil && !errors.Is(err, aggregation.ErrNoData) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// convertFamilyDescriptor converts a Prometheus metric family to an
+// OpenTelemetry Descriptor.
+func convertFamilyDescriptor(family *dto.MetricFamily) (metric.Descriptor, error) {
+	var ikind metric.InstrumentKind
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		ikind = metric.SumObserverInstrumentKind
+	case dto.MetricType_GAUGE, dto.MetricType_UNTYPED:
+		ikind = metric.ValueObserverInstrumentKind
+	case dto.MetricType_HISTOGRAM:
+		ikind = metric.ValueRecorderInstrumentKind
+	default:
+		// Includes MetricType_SUMMARY
+		return metric.Descriptor{}, fmt.Errorf("%w; metric %q type: %v", errGathererConversion, family.GetName(), family.GetType())
+	}
+	return metric.NewDescriptor(
+		family.GetName(),
+		ikind,
+		number.Float64Kind,
+		metric.WithDescription(family.GetHelp()),
+		metric.WithInstrumentationName("Prometheus Bridge"),
+	), nil
+}
+
+// convertLabelPairs converts Prometheus label pairs to an OpenTelemetry
+// label Set.
+func convertLabelPairs(pairs []*dto.LabelPair) attribute.Set {
+	labels := make([]attribute.KeyValue, 0, len(pairs))
+	for _, lp := range pairs {
+		labels = append(labels, attribute.String(lp.GetName(), lp.GetValue()))
+	}
+	return attribute.NewSet(labels...)
+}
+
+// convertMetricAggregation creates an OpenTelemetry aggregation from a
+// Prometheus metric of type t.
+func convertMetricAggregation(t dto.MetricType, m *dto.Metric, end time.Time) (aggregation.Aggregation, error) {
+	switch t {
+	case dto.MetricType_COUNTER:
+		if m.Counter == nil {
+			return nil, fmt.Errorf("%w: missing counter value", errGathererConversion)
+		}
+		return &promSumAggregation{
+			sum: number.NewFloat64Number(m.Counter.GetValue()),
+		}, nil
+	case dto.MetricType_GAUGE:
+		if m.Gauge == nil {
+			return nil, fmt.Errorf("%w: missing gauge value", errGathererConversion)
+		}
+		return &promLastValueAggregation{
+			value: number.NewFloat64Number(m.Gauge.GetValue()),
+			time:  end,
+		}, nil
+	case dto.MetricType_UNTYPED:
+		if m.Untyped == nil {
+			return nil, fmt.Errorf("%w: missing untyped value", errGathererConversion)
+		}
+		return &promLastValueAggregation{
+			value: number.NewFloat64Number(m.Untyped.GetValue()),
+			time:  end,
+		}, nil
+	case dto.MetricType_HISTOGRAM:
+		if m.Histogram == nil {
+			return nil, fmt.Errorf("%w: missing histogram value", errGathererConversion)
+		}
+		return newPromHistogramAggregation(m.Histogram)
+	default:
+		return nil, fmt.Errorf("%w: metric type: %v", errGathererConversion, t)
+	}
+}
+
+var _ aggregation.Sum = &promSumAggregation{}
+
+type promSumAggregation struct {
+	sum number.Number
+}
+
+// Kind returns the kind of aggregation this is.
+func (p *promSumAggregation) Kind() aggregation.Kind {
+	return aggregation.SumKind
+}
+
+// Sum returns the counter value.
+func (p *promSumAggregation) Sum() (number.Number, error) {
+	return p.sum, nil
+}
+
+var _ aggregation.LastValue = &promLastValueAggregation{}
+
+type promLastValueAggregation struct {
+	value number.Number
+	time  time.Time
+}
+
+// Kind returns the kind of aggregation this is.
+func (p *promLastValueAggregation) Kind() aggregation.Kind {
+	return aggregation.LastValueKind
+}
+
+// LastValue returns the gauge value and the time it was gathered.
+func (p *promLastValueAggregation) LastValue() (number.Number, time.Time, error) {
+	return p.value, p.time, nil
+}
+
+var _ aggregation.Histogram = &promHistogramAggregation{}
+
+// newPromHistogramAggregation converts the cumulative buckets of a Prometheus
+// histogram to the per-bucket counts of an OpenTelemetry histogram.
+func newPromHistogramAggregation(h *dto.Histogram) (*promHistogramAggregation, error) {
+	buckets := h.GetBucket()
+	boundaries := make([]float64, 0, len(buckets))
+	counts := make([]uint64, 0, len(buckets)+1)
+	var prev uint64
+	for _, b := range buckets {
+		cumulative := b.GetCumulativeCount()
+		if cumulative < prev {
+			return nil, fmt.Errorf("%w: histogram bucket counts must be cumulative", errGathererConversion)
+		}
+		counts = append(counts, cumulative-prev)
+		prev = cumulative
+		if math.IsInf(b.GetUpperBound(), +1) {
+			// The +Inf bucket is implied by the OpenTelemetry
+			// boundaries, it is not a boundary itself.
+			break
+		}
+		boundaries = append(boundaries, b.GetUpperBound())
+	}
+	if len(counts) == len(boundaries) {
+		if h.GetSampleCount() < prev {
+			return nil, fmt.Errorf("%w: histogram count less than bucket counts", errGathererConversion)
+		}
+		counts = append(counts, h.GetSampleCount()-prev)
+	}
+	return &promHistogramAggregation{
+		sum:   number.NewFloat64Number(h.GetSampleSum()),
+		count: h.GetSampleCount(),
+		buckets: aggregation.Buckets{
+			Boundaries: boundaries,
+			Counts:     counts,
+		},
+	}, nil
+}
+
+type promHistogramAggregation struct {
+	sum     number.Number
+	count   uint64
+	buckets aggregation.Buckets
+}
+
+// Kind returns the kind of aggregation this is.
+func (p *promHistogramAggregation) Kind() aggregation.Kind {
+	return aggregation.HistogramKind
+}
+
+// Sum returns the sum of observations.
+func (p *promHistogramAggregation) Sum() (number.Number, error) {
+	return p.sum, nil
+}
+
+// Count returns the number of observations.
+func (p *promHistogramAggregation) Count() (uint64, error) {
+	return p.count, nil
+}
+
+// Histogram returns the count of observations in each bucket.
+func (p *promHistogramAggregation) Histogram() (aggregation.Buckets, error) {
+	return p.buckets, nil
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus_test
+
+import (
+	"context"
+	"testing"
+
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/metric/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+type recordingExporter struct {
+	export.ExportKindSelector
+	records []export.Record
+}
+
+func (r *recordingExporter) Export(_ context.Context, ckpt export.CheckpointSet) error {
+	return ckpt.ForEach(r, func(rec export.Record) error {
+		r.records = append(r.records, rec)
+		return nil
+	})
+}
+
+func TestGathererBridge(t *testing.T) {
+	registry := prom.NewRegistry()
+	counter := prom.NewCounterVec(prom.CounterOpts{
+		Name: "requests_total",
+		Help: "Number of requests.",
+	}, []string{"code"})
+	gauge := prom.NewGauge(prom.GaugeOpts{
+		Name: "temperature",
+	})
+	hist := prom.NewHistogram(prom.HistogramOpts{
+		Name:    "latency",
+		Buckets: []float64{1, 5},
+	})
+	summary := prom.NewSummary(prom.SummaryOpts{
+		Name: "unsupported",
+	})
+	registry.MustRegister(counter, gauge, hist, summary)
+
+	counter.WithLabelValues("200").Add(3)
+	gauge.Set(21.5)
+	for _, v := range []float64{0.5, 2, 3, 10} {
+		hist.Observe(v)
+	}
+	summary.Observe(1)
+
+	exp := &recordingExporter{ExportKindSelector: export.CumulativeExportKindSelector()}
+	res := resource.NewWithAttributes(attribute.String("R", "V"))
+	bridge := prometheus.NewGathererBridge(registry, exp, res)
+	require.NoError(t, bridge.Export(context.Background()))
+
+	// Families are gathered sorted by name and the summary is skipped.
+	require.Len(t, exp.records, 3)
+
+	lat := exp.records[0]
+	assert.Equal(t, "latency", lat.Descriptor().Name())
+	assert.Equal(t, metric.ValueRecorderInstrumentKind, lat.Descriptor().InstrumentKind())
+	assert.Equal(t, "Prometheus Bridge", lat.Descriptor().InstrumentationName())
+	assert.Equal(t, res, lat.Resource())
+	buckets, err := lat.Aggregation().(aggregation.Histogram).Histogram()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 5}, buckets.Boundaries)
+	assert.Equal(t, []uint64{1, 2, 1}, buckets.Counts)
+	count, err := lat.Aggregation().(aggregation.Histogram).Count()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), count)
+
+	req := exp.records[1]
+	assert.Equal(t, "requests_total", req.Descriptor().Name())
+	assert.Equal(t, "Number of requests.", req.Descriptor().Description())
+	assert.Equal(t, metric.SumObserverInstrumentKind, req.Descriptor().InstrumentKind())
+	assert.Equal(t, "code=200", req.Labels().Encoded(attribute.DefaultEncoder()))
+	sum, err := req.Aggregation().(aggregation.Sum).Sum()
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, sum.AsFloat64())
+
+	temp := exp.records[2]
+	assert.Equal(t, "temperature", temp.Descriptor().Name())
+	assert.Equal(t, metric.ValueObserverInstrumentKind, temp.Descriptor().InstrumentKind())
+	last, _, err := temp.Aggregation().(aggregation.LastValue).LastValue()
+	require.NoError(t, err)
+	assert.Equal(t, 21.5, last.AsFloat64())
+}
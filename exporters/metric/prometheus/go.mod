@@ -8,13 +8,16 @@ replace (
 )
 
 require (
+	github.com/golang/protobuf v1.4.3
 	github.com/prometheus/client_golang v1.10.0
+	github.com/prometheus/client_model v0.2.0
 	github.com/stretchr/testify v1.7.0
 	go.opentelemetry.io/otel v0.19.0
 	go.opentelemetry.io/otel/metric v0.19.0
 	go.opentelemetry.io/otel/sdk v0.19.0
 	go.opentelemetry.io/otel/sdk/export/metric v0.19.0
 	go.opentelemetry.io/otel/sdk/metric v0.19.0
+	go.opentelemetry.io/otel/trace v0.19.0
 )
 
 replace go.opentelemetry.io/otel/bridge/opencensus => ../../../bridge/opencensus
@@ -55,4 +58,6 @@ replace go.opentelemetry.io/otel/sdk/export/metric => ../../../sdk/export/metric
 
 replace go.opentelemetry.io/otel/sdk/metric => ../../../sdk/metric
 
+replace go.opentelemetry.io/otel/sdk/trace/selfmetrics => ../../../sdk/trace/selfmetrics
+
 replace go.opentelemetry.io/otel/trace => ../../../trace
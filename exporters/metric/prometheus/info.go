@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus // import "go.opentelemetry.io/otel/exporters/metric/prometheus"
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const (
+	targetInfoName        = "target_info"
+	targetInfoDescription = "Target metadata"
+
+	scopeInfoName        = "otel_scope_info"
+	scopeInfoDescription = "Instrumentation Scope metadata"
+
+	scopeNameLabel    = "otel_scope_name"
+	scopeVersionLabel = "otel_scope_version"
+)
+
+// scopeLabelKeys are the labels identifying the instrumentation library of
+// a metric.
+var scopeLabelKeys = []string{scopeNameLabel, scopeVersionLabel}
+
+// scopeLabelValues returns the values of the scopeLabelKeys for the
+// instrumentation library of desc.
+func scopeLabelValues(desc *metric.Descriptor) []string {
+	return []string{desc.InstrumentationName(), desc.InstrumentationVersion()}
+}
+
+type scope struct {
+	name, version string
+}
+
+// infoMetrics accumulates the distinct Resources and instrumentation
+// libraries of the exported records to export them as target_info and
+// otel_scope_info metrics.
+type infoMetrics struct {
+	exp *Exporter
+
+	resources map[attribute.Distinct]*resource.Resource
+	scopes    map[scope]struct{}
+}
+
+func newInfoMetrics(exp *Exporter) *infoMetrics {
+	return &infoMetrics{
+		exp:       exp,
+		resources: make(map[attribute.Distinct]*resource.Resource),
+		scopes:    make(map[scope]struct{}),
+	}
+}
+
+// add records the Resource and instrumentation library of record.
+func (i *infoMetrics) add(record export.Record) {
+	if i.exp.targetInfo {
+		if res := record.Resource(); res.Len() > 0 {
+			i.resources[res.Equivalent()] = res
+		}
+	}
+	if i.exp.scopeInfo {
+		desc := record.Descriptor()
+		i.scopes[scope{desc.InstrumentationName(), desc.InstrumentationVersion()}] = struct{}{}
+	}
+}
+
+// targetInfoDesc returns the description of the target_info metric of res
+// and the values of its labels.  The values of the resource keys that are
+// sanitized to the same label name are joined with ";" in the order of
+// the keys, and the keys sanitized to a constant label name are dropped,
+// so the label names are always distinct.
+func (i *infoMetrics) targetInfoDesc(res *resource.Resource) (*prometheus.Desc, []string) {
+	keys := make([]string, 0, res.Len())
+	values := make([]string, 0, res.Len())
+	index := make(map[string]int, res.Len())
+	for iter := res.Iter(); iter.Next(); {
+		kv := iter.Attribute()
+		key := i.exp.sanitize(string(kv.Key))
+		if _, ok := i.exp.constLabels[key]; ok {
+			continue
+		}
+		if idx, ok := index[key]; ok {
+			values[idx] += ";" + kv.Value.Emit()
+			continue
+		}
+		index[key] = len(keys)
+		keys = append(keys, key)
+		values = append(values, kv.Value.Emit())
+	}
+	return prometheus.NewDesc(targetInfoName, targetInfoDescription, keys, i.exp.constLabels), values
+}
+
+func (i *infoMetrics) scopeInfoDesc() *prometheus.Desc {
+	return prometheus.NewDesc(scopeInfoName, scopeInfoDescription, scopeLabelKeys, i.exp.constLabels)
+}
+
+// describe sends the descriptions of the info metrics to ch.
+func (i *infoMetrics) describe(ch chan<- *prometheus.Desc) {
+	for _, res := range i.resources {
+		desc, _ := i.targetInfoDesc(res)
+		ch <- desc
+	}
+	if len(i.scopes) > 0 {
+		ch <- i.scopeInfoDesc()
+	}
+}
+
+// collect sends the info metrics to ch.
+func (i *infoMetrics) collect(ch chan<- prometheus.Metric) error {
+	for _, res := range i.resources {
+		desc, values := i.targetInfoDesc(res)
+		m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, 1, values...)
+		if err != nil {
+			return fmt.Errorf("error creating target info metric: %w", err)
+		}
+		ch <- m
+	}
+	if len(i.scopes) == 0 {
+		return nil
+	}
+	desc := i.scopeInfoDesc()
+	for s := range i.scopes {
+		m, err := prometheus.NewConstMetric(desc, prometheus.GaugeValue, 1, s.name, s.version)
+		if err != nil {
+			return fmt.Errorf("error creating scope info metric: %w", err)
+		}
+		ch <- m
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus // import "go.opentelemetry.io/otel/exporters/metric/prometheus"
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// defaultLabelCacheSize is the number of label sets whose Prometheus
+// labels are cached when Config.LabelCacheSize is zero.
+const defaultLabelCacheSize = 1024
+
+// labelEncoder encodes a label set as its sanitized Prometheus label
+// names and values, so that they are computed once per label set with
+// attribute.NewCachedEncoder instead of at every scrape.
+//
+// Each label is encoded as its sanitized name, an '=', the length of its
+// value, a ':' and the value. Sanitized names only contain letters,
+// digits and underscores, and the length delimits values containing any
+// character.
+type labelEncoder struct {
+	id       attribute.EncoderID
+	sanitize func(string) string
+}
+
+var _ attribute.Encoder = labelEncoder{}
+
+// newLabelEncoder returns the cached labelEncoder of the labels sanitized
+// with sanitize, remembering the labels of up to size label sets.
+func newLabelEncoder(sanitize func(string) string, size int) attribute.Encoder {
+	if size == 0 {
+		size = defaultLabelCacheSize
+	}
+	return attribute.NewCachedEncoder(labelEncoder{
+		id:       attribute.NewEncoderID(),
+		sanitize: sanitize,
+	}, size)
+}
+
+// Encode implements attribute.Encoder.
+func (e labelEncoder) Encode(iter attribute.Iterator) string {
+	var b strings.Builder
+	for iter.Next() {
+		label := iter.Label()
+		value := label.Value.Emit()
+		b.WriteString(e.sanitize(string(label.Key)))
+		b.WriteByte('=')
+		b.WriteString(strconv.Itoa(len(value)))
+		b.WriteByte(':')
+		b.WriteString(value)
+	}
+	return b.String()
+}
+
+// ID implements attribute.Encoder.
+func (e labelEncoder) ID() attribute.EncoderID {
+	return e.id
+}
+
+// labelDecoder iterates over the names and values of labels encoded by a
+// labelEncoder. They are substrings of the encoding, decoding does not
+// allocate.
+type labelDecoder struct {
+	encoded string
+}
+
+// next returns the name and value of the next label, ok is false once
+// all the labels have been returned.
+func (d *labelDecoder) next() (name, value string, ok bool) {
+	i := strings.IndexByte(d.encoded, '=')
+	if i < 0 {
+		return "", "", false
+	}
+	name = d.encoded[:i]
+	rest := d.encoded[i+1:]
+	j := strings.IndexByte(rest, ':')
+	if j < 0 {
+		return "", "", false
+	}
+	n, err := strconv.Atoi(rest[:j])
+	if err != nil || n > len(rest)-j-1 {
+		return "", "", false
+	}
+	value = rest[j+1 : j+1+n]
+	d.encoded = rest[j+1+n:]
+	return name, value, true
+}
+
+// hasLabel returns if encoded contains a label named name.
+func hasLabel(encoded, name string) bool {
+	d := labelDecoder{encoded: encoded}
+	for {
+		n, _, ok := d.next()
+		if !ok {
+			return false
+		}
+		if n == name {
+			return true
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func decodeLabels(encoded string) (names, values []string) {
+	d := labelDecoder{encoded: encoded}
+	for name, value, ok := d.next(); ok; name, value, ok = d.next() {
+		names = append(names, name)
+		values = append(values, value)
+	}
+	return names, values
+}
+
+func TestLabelEncoderRoundTrip(t *testing.T) {
+	enc := newLabelEncoder(sanitize, 0)
+	set := attribute.NewSet(
+		attribute.String("http.method", "GET"),
+		attribute.String("odd", "a=1:b,\x00"),
+		attribute.Int("12", 34),
+		attribute.String("empty", ""),
+	)
+
+	names, values := decodeLabels(set.Encoded(enc))
+	assert.Equal(t, []string{"key_12", "empty", "http_method", "odd"}, names)
+	assert.Equal(t, []string{"34", "", "GET", "a=1:b,\x00"}, values)
+
+	assert.True(t, hasLabel(set.Encoded(enc), "http_method"))
+	assert.False(t, hasLabel(set.Encoded(enc), "http.method"))
+}
+
+func TestLabelEncoderEmpty(t *testing.T) {
+	enc := newLabelEncoder(sanitize, -1)
+	names, values := decodeLabels(attribute.EmptySet().Encoded(enc))
+	assert.Empty(t, names)
+	assert.Empty(t, values)
+}
+
+func TestLabelEncoderCache(t *testing.T) {
+	calls := 0
+	enc := newLabelEncoder(func(s string) string {
+		calls++
+		return sanitize(s)
+	}, 0)
+
+	// Equivalent sets rebuilt for every collection are only sanitized
+	// once.
+	for i := 0; i < 3; i++ {
+		set := attribute.NewSet(attribute.String("A", "1"), attribute.String("B", "2"))
+		names, _ := decodeLabels(set.Encoded(enc))
+		assert.Equal(t, []string{"A", "B"}, names)
+	}
+	assert.Equal(t, 2, calls)
+}
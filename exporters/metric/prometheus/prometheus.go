@@ -14,13 +14,12 @@
 
 package prometheus // import "go.opentelemetry.io/otel/exporters/metric/prometheus"
 
-// Note that this package does not support a way to export Prometheus
-// Summary data points, removed in PR#1412.
-
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -31,6 +30,7 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/sdk"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
@@ -39,6 +39,20 @@ import (
 	selector "go.opentelemetry.io/otel/sdk/metric/selector/simple"
 )
 
+// ExponentialHistogramCapability is the name of the otel.Capability
+// registered by this package: the Exporter exports the exponential
+// histogram aggregation, converted to a histogram with explicit
+// boundaries.
+const ExponentialHistogramCapability = "go.opentelemetry.io/otel/exporters/metric/prometheus#exponential_histogram"
+
+func init() {
+	otel.RegisterCapability(otel.Capability{
+		Name:    ExponentialHistogramCapability,
+		Version: sdk.Version(),
+		Enabled: true,
+	})
+}
+
 // Exporter supports Prometheus pulls.  It does not implement the
 // sdk/export/metric.Exporter interface--instead it creates a pull
 // controller and reads the latest checkpointed data on-scrape.
@@ -56,6 +70,24 @@ type Exporter struct {
 	controller *controller.Controller
 
 	defaultHistogramBoundaries []float64
+
+	targetInfo bool
+	scopeInfo  bool
+
+	namespace   string
+	constLabels prometheus.Labels
+
+	openMetrics bool
+
+	sanitize     func(string) string
+	labelEncoder attribute.Encoder
+	nameMapper   func(*metric.Descriptor) string
+	unitSuffixes bool
+
+	filter func(export.Record) bool
+
+	allowedNames []string
+	deniedNames  []string
 }
 
 // ErrUnsupportedAggregator is returned for unrepresentable aggregator
@@ -75,6 +107,10 @@ type Config struct {
 	// Registerer is the prometheus registerer to register
 	// metrics with.
 	//
+	// Use this together with Gatherer to merge the exported metrics into
+	// an existing registry, e.g. prometheus.DefaultRegisterer and
+	// prometheus.DefaultGatherer.
+	//
 	// If not specified the Registry will be used as default.
 	Registerer prometheus.Registerer
 
@@ -87,6 +123,80 @@ type Config struct {
 	// DefaultHistogramBoundaries defines the default histogram bucket
 	// boundaries.
 	DefaultHistogramBoundaries []float64
+
+	// HistogramBoundaries defines the histogram bucket boundaries of
+	// specific instruments, keyed by instrument name. Instruments not
+	// contained use DefaultHistogramBoundaries.
+	HistogramBoundaries map[string][]float64
+
+	// Namespace, if not empty, is prepended to the name of every metric
+	// exported for an instrument, separated by an underscore. It can be
+	// used to avoid collisions with the metrics of other applications in
+	// a shared Prometheus.
+	Namespace string
+
+	// ConstLabels are added to every exported metric.
+	ConstLabels prometheus.Labels
+
+	// NameSanitization is the strategy used to replace characters not
+	// allowed in Prometheus metric and label names. If not set,
+	// SanitizeNonLetters is used.
+	NameSanitization NameSanitization
+
+	// NameMapper, if set, returns the name of the metric exported for an
+	// instrument instead of the sanitized instrument name. The returned
+	// name is used as is, the Namespace, unit suffix, and counter suffix
+	// are still applied.
+	NameMapper func(*metric.Descriptor) string
+
+	// UnitSuffixes, if true, appends the unit of an instrument to the
+	// name of its metric, e.g. _bytes for instruments measured in bytes.
+	UnitSuffixes bool
+
+	// Filter, if set, limits the exported records to those it returns
+	// true for. Multiple Exporters with different Filters and Registries
+	// can be created with NewExporter for the same Controller, e.g. to
+	// expose the metrics of each tenant of a process on a separate
+	// endpoint. See FilterInstrumentation and FilterLabel.
+	Filter func(export.Record) bool
+
+	// AllowedMetricNames, if not empty, limits the exported records to
+	// those of instruments with a name matching one of the patterns. A
+	// "*" in a pattern matches any sequence of characters, e.g.
+	// "http.server.*". Instrument names are matched before they are
+	// sanitized or a Namespace is applied.
+	AllowedMetricNames []string
+
+	// DeniedMetricNames excludes the records of instruments with a name
+	// matching one of the patterns from the export, even if they match
+	// AllowedMetricNames. Patterns are matched like those of
+	// AllowedMetricNames.
+	DeniedMetricNames []string
+
+	// EnableOpenMetrics, if true, serves the OpenMetrics text format to
+	// scrapers requesting it. This is required to expose exemplars.
+	//
+	// The created timestamps of counters and histograms are not exposed,
+	// the Prometheus client library used does not support them.
+	EnableOpenMetrics bool
+
+	// TargetInfo, if true, exports the attributes of the Resource as the
+	// labels of a target_info gauge instead of adding them to the labels
+	// of every metric. Queries can join metrics with the service
+	// metadata using the job and instance labels Prometheus adds.
+	TargetInfo bool
+
+	// LabelCacheSize is the number of label sets whose sanitized
+	// Prometheus labels are remembered across collections, so that the
+	// labels of the same label sets are not computed again at every
+	// scrape. If zero, the labels of 1024 label sets are remembered. If
+	// negative, no labels are remembered.
+	LabelCacheSize int
+
+	// ScopeInfo, if true, adds the otel_scope_name and otel_scope_version
+	// labels identifying the instrumentation library to every metric, and
+	// exports an otel_scope_info gauge for each instrumentation library.
+	ScopeInfo bool
 }
 
 // NewExporter returns a new Prometheus exporter using the configured
@@ -105,11 +215,25 @@ func NewExporter(config Config, controller *controller.Controller) (*Exporter, e
 	}
 
 	e := &Exporter{
-		handler:                    promhttp.HandlerFor(config.Gatherer, promhttp.HandlerOpts{}),
+		handler: promhttp.HandlerFor(config.Gatherer, promhttp.HandlerOpts{
+			EnableOpenMetrics: config.EnableOpenMetrics,
+		}),
 		registerer:                 config.Registerer,
 		gatherer:                   config.Gatherer,
 		controller:                 controller,
 		defaultHistogramBoundaries: config.DefaultHistogramBoundaries,
+		targetInfo:                 config.TargetInfo,
+		scopeInfo:                  config.ScopeInfo,
+		namespace:                  config.NameSanitization.sanitizer()(config.Namespace),
+		constLabels:                config.ConstLabels,
+		openMetrics:                config.EnableOpenMetrics,
+		sanitize:                   config.NameSanitization.sanitizer(),
+		labelEncoder:               newLabelEncoder(config.NameSanitization.sanitizer(), config.LabelCacheSize),
+		nameMapper:                 config.NameMapper,
+		unitSuffixes:               config.UnitSuffixes,
+		filter:                     config.Filter,
+		allowedNames:               config.AllowedMetricNames,
+		deniedNames:                config.DeniedMetricNames,
 	}
 
 	c := &collector{
@@ -152,9 +276,7 @@ func InstallNewPipeline(config Config, options ...controller.Option) (*Exporter,
 func defaultController(config Config, options ...controller.Option) *controller.Controller {
 	return controller.New(
 		processor.New(
-			selector.NewWithHistogramDistribution(
-				histogram.WithExplicitBoundaries(config.DefaultHistogramBoundaries),
-			),
+			newHistogramSelector(config),
 			export.CumulativeExportKindSelector(),
 			processor.WithMemory(true),
 		),
@@ -162,6 +284,46 @@ func defaultController(config Config, options ...controller.Option) *controller.
 	)
 }
 
+// histogramSelector is an export.AggregatorSelector using histogram
+// aggregators with the boundaries configured for an instrument name.
+type histogramSelector struct {
+	export.AggregatorSelector
+
+	boundaries map[string][]float64
+}
+
+var _ export.AggregatorSelector = histogramSelector{}
+
+func newHistogramSelector(config Config) export.AggregatorSelector {
+	def := selector.NewWithHistogramDistribution(
+		histogram.WithExplicitBoundaries(config.DefaultHistogramBoundaries),
+	)
+	if len(config.HistogramBoundaries) == 0 {
+		return def
+	}
+	boundaries := make(map[string][]float64, len(config.HistogramBoundaries))
+	for name, b := range config.HistogramBoundaries {
+		boundaries[name] = b
+	}
+	return histogramSelector{
+		AggregatorSelector: def,
+		boundaries:         boundaries,
+	}
+}
+
+// AggregatorFor implements export.AggregatorSelector.
+func (s histogramSelector) AggregatorFor(descriptor *metric.Descriptor, aggPtrs ...*export.Aggregator) {
+	b, ok := s.boundaries[descriptor.Name()]
+	if !ok || descriptor.InstrumentKind() != metric.ValueRecorderInstrumentKind {
+		s.AggregatorSelector.AggregatorFor(descriptor, aggPtrs...)
+		return
+	}
+	aggs := histogram.New(len(aggPtrs), descriptor, histogram.WithExplicitBoundaries(b))
+	for i := range aggPtrs {
+		*aggPtrs[i] = &aggs[i]
+	}
+}
+
 // MeterProvider returns the MeterProvider of this exporter.
 func (e *Exporter) MeterProvider() metric.MeterProvider {
 	return e.controller.MeterProvider()
@@ -179,6 +341,41 @@ func (e *Exporter) ExportKindFor(desc *metric.Descriptor, kind aggregation.Kind)
 	return export.CumulativeExportKindSelector().ExportKindFor(desc, kind)
 }
 
+// exports returns if record is exported by e.
+func (e *Exporter) exports(record export.Record) bool {
+	name := record.Descriptor().Name()
+	if len(e.allowedNames) > 0 && !matchesAny(e.allowedNames, name) {
+		return false
+	}
+	if matchesAny(e.deniedNames, name) {
+		return false
+	}
+	return e.filter == nil || e.filter(record)
+}
+
+// FilterInstrumentation returns a Config Filter exporting only the
+// records of instruments created by a Meter with one of the
+// instrumentation names.
+func FilterInstrumentation(names ...string) func(export.Record) bool {
+	set := make(map[string]struct{}, len(names))
+	for _, n := range names {
+		set[n] = struct{}{}
+	}
+	return func(record export.Record) bool {
+		_, ok := set[record.Descriptor().InstrumentationName()]
+		return ok
+	}
+}
+
+// FilterLabel returns a Config Filter exporting only the records with the
+// label kv.
+func FilterLabel(kv attribute.KeyValue) func(export.Record) bool {
+	return func(record export.Record) bool {
+		v, ok := record.Labels().Value(kv.Key)
+		return ok && v == kv.Value
+	}
+}
+
 // ServeHTTP implements http.Handler.
 func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	e.handler.ServeHTTP(w, r)
@@ -196,12 +393,18 @@ func (c *collector) Describe(ch chan<- *prometheus.Desc) {
 	c.exp.lock.RLock()
 	defer c.exp.lock.RUnlock()
 
+	info := newInfoMetrics(c.exp)
 	_ = c.exp.Controller().ForEach(c.exp, func(record export.Record) error {
+		if !c.exp.exports(record) {
+			return nil
+		}
 		var labelKeys []string
-		mergeLabels(record, &labelKeys, nil)
+		c.mergeLabels(record, &labelKeys, nil)
 		ch <- c.toDesc(record, labelKeys)
+		info.add(record)
 		return nil
 	})
+	info.describe(ch)
 }
 
 // Collect exports the last calculated CheckpointSet.
@@ -214,16 +417,22 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 
 	ctrl := c.exp.Controller()
 	if err := ctrl.Collect(context.Background()); err != nil {
-		otel.Handle(err)
+		otel.HandleSignal(otel.MetricsSignal, err)
 	}
 
+	info := newInfoMetrics(c.exp)
 	err := ctrl.ForEach(c.exp, func(record export.Record) error {
+		if !c.exp.exports(record) {
+			return nil
+		}
+		info.add(record)
+
 		agg := record.Aggregation()
 		numberKind := record.Descriptor().NumberKind()
 		instrumentKind := record.Descriptor().InstrumentKind()
 
 		var labelKeys, labels []string
-		mergeLabels(record, &labelKeys, &labels)
+		c.mergeLabels(record, &labelKeys, &labels)
 
 		desc := c.toDesc(record, labelKeys)
 
@@ -231,6 +440,16 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 			if err := c.exportHistogram(ch, hist, numberKind, desc, labels); err != nil {
 				return fmt.Errorf("exporting histogram: %w", err)
 			}
+		} else if hist, ok := agg.(aggregation.ExponentialHistogram); ok {
+			// Prometheus has no exponential histogram, its buckets
+			// are exported with explicit boundaries.
+			if err := c.exportHistogram(ch, aggregation.ExplicitHistogram(hist), numberKind, desc, labels); err != nil {
+				return fmt.Errorf("exporting exponential histogram: %w", err)
+			}
+		} else if summary, ok := agg.(aggregation.Summary); ok {
+			if err := c.exportSummary(ch, summary, numberKind, desc, labels); err != nil {
+				return fmt.Errorf("exporting summary: %w", err)
+			}
 		} else if sum, ok := agg.(aggregation.Sum); ok && instrumentKind.Monotonic() {
 			if err := c.exportMonotonicCounter(ch, sum, numberKind, desc, labels); err != nil {
 				return fmt.Errorf("exporting monotonic counter: %w", err)
@@ -249,7 +468,10 @@ func (c *collector) Collect(ch chan<- prometheus.Metric) {
 		return nil
 	})
 	if err != nil {
-		otel.Handle(err)
+		otel.HandleSignal(otel.MetricsSignal, err)
+	}
+	if err := info.collect(ch); err != nil {
+		otel.HandleSignal(otel.MetricsSignal, err)
 	}
 }
 
@@ -293,6 +515,9 @@ func (c *collector) exportMonotonicCounter(ch chan<- prometheus.Metric, sum aggr
 	if err != nil {
 		return fmt.Errorf("error creating constant metric: %w", err)
 	}
+	if m, err = withExemplars(m, sum, kind); err != nil {
+		return err
+	}
 
 	ch <- m
 	return nil
@@ -324,6 +549,42 @@ func (c *collector) exportHistogram(ch chan<- prometheus.Metric, hist aggregatio
 	if err != nil {
 		return fmt.Errorf("error creating constant histogram: %w", err)
 	}
+	if m, err = withExemplars(m, hist, kind); err != nil {
+		return err
+	}
+
+	ch <- m
+	return nil
+}
+
+func (c *collector) exportSummary(ch chan<- prometheus.Metric, summary aggregation.Summary, kind number.Kind, desc *prometheus.Desc, labels []string) error {
+	count, err := summary.Count()
+	if err != nil {
+		return fmt.Errorf("error retrieving count: %w", err)
+	}
+	sum, err := summary.Sum()
+	if err != nil {
+		return fmt.Errorf("error retrieving sum: %w", err)
+	}
+
+	// quantiles maps from the quantile to its estimated value. The
+	// quantiles of an empty summary are not included.
+	quantiles := make(map[float64]float64, len(summary.Quantiles()))
+	for _, q := range summary.Quantiles() {
+		v, err := summary.Quantile(q)
+		if errors.Is(err, aggregation.ErrNoData) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error retrieving quantile: %w", err)
+		}
+		quantiles[q] = v.CoerceToFloat64(kind)
+	}
+
+	m, err := prometheus.NewConstSummary(desc, count, sum.CoerceToFloat64(kind), quantiles, labels...)
+	if err != nil {
+		return fmt.Errorf("error creating constant summary: %w", err)
+	}
 
 	ch <- m
 	return nil
@@ -331,32 +592,108 @@ func (c *collector) exportHistogram(ch chan<- prometheus.Metric, hist aggregatio
 
 func (c *collector) toDesc(record export.Record, labelKeys []string) *prometheus.Desc {
 	desc := record.Descriptor()
-	return prometheus.NewDesc(sanitize(desc.Name()), desc.Description(), labelKeys, nil)
+	name := c.baseName(record)
+	if isCounter(record) {
+		name += counterSuffix
+	}
+	return prometheus.NewDesc(name, desc.Description(), labelKeys, c.exp.constLabels)
+}
+
+// baseName returns the name of the metric exported for record without any
+// suffix. The _total suffix is only removed from the names of counters,
+// which get it back in toDesc.
+func (c *collector) baseName(record export.Record) string {
+	desc := record.Descriptor()
+	var name string
+	if c.exp.nameMapper != nil {
+		name = c.exp.nameMapper(desc)
+	} else {
+		name = c.exp.sanitize(desc.Name())
+	}
+	if isCounter(record) {
+		name = strings.TrimSuffix(name, counterSuffix)
+	}
+	if c.exp.unitSuffixes {
+		name = withUnitSuffix(name, desc.Unit(), c.exp.sanitize)
+	}
+	return prometheus.BuildFQName(c.exp.namespace, "", name)
+}
+
+// counterSuffix is the suffix of the name of Prometheus counters.
+const counterSuffix = "_total"
+
+// isCounter returns if record is exported as a Prometheus counter, a
+// monotonic sum. Non-monotonic sums are exported as gauges.
+func isCounter(record export.Record) bool {
+	agg := record.Aggregation()
+	switch agg.(type) {
+	case aggregation.Histogram, aggregation.ExponentialHistogram:
+		return false
+	}
+	_, ok := agg.(aggregation.Sum)
+	return ok && record.Descriptor().InstrumentKind().Monotonic()
 }
 
 // mergeLabels merges the export.Record's labels and resources into a
 // single set, giving precedence to the record's labels in case of
-// duplicate keys.  This outputs one or both of the keys and the
+// duplicate label names.  This outputs one or both of the keys and the
 // values as a slice, and either argument may be nil to avoid
 // allocating an unnecessary slice.
-func mergeLabels(record export.Record, keys, values *[]string) {
+//
+// The sanitized labels of the record labels and resources are encoded
+// with the cached labelEncoder of the exporter, they are only computed
+// when a label set is first exported.
+//
+// The resources are not merged if they are exported as target_info, and
+// the instrumentation library labels are appended if otel_scope_info is
+// exported.
+func (c *collector) mergeLabels(record export.Record, keys, values *[]string) {
+	res := record.Resource().Set()
+	if c.exp.targetInfo {
+		res = attribute.EmptySet()
+	}
+	size := record.Labels().Len() + res.Len()
+	if c.exp.scopeInfo {
+		size += len(scopeLabelKeys)
+	}
 	if keys != nil {
-		*keys = make([]string, 0, record.Labels().Len()+record.Resource().Len())
+		*keys = make([]string, 0, size)
 	}
 	if values != nil {
-		*values = make([]string, 0, record.Labels().Len()+record.Resource().Len())
+		*values = make([]string, 0, size)
+	}
+
+	add := func(name, value string) {
+		if keys != nil {
+			*keys = append(*keys, name)
+		}
+		if values != nil {
+			*values = append(*values, value)
+		}
+	}
+
+	labels := record.Labels().Encoded(c.exp.labelEncoder)
+	d := labelDecoder{encoded: labels}
+	for name, value, ok := d.next(); ok; name, value, ok = d.next() {
+		add(name, value)
+	}
+	if res.Len() > 0 {
+		// Duplicate names are resolved by taking the record label
+		// value over the resource value.
+		d = labelDecoder{encoded: res.Encoded(c.exp.labelEncoder)}
+		for name, value, ok := d.next(); ok; name, value, ok = d.next() {
+			if !hasLabel(labels, name) {
+				add(name, value)
+			}
+		}
 	}
 
-	// Duplicate keys are resolved by taking the record label value over
-	// the resource value.
-	mi := attribute.NewMergeIterator(record.Labels(), record.Resource().Set())
-	for mi.Next() {
-		label := mi.Label()
+	if c.exp.scopeInfo {
 		if keys != nil {
-			*keys = append(*keys, sanitize(string(label.Key)))
+			*keys = append(*keys, scopeLabelKeys...)
 		}
 		if values != nil {
-			*values = append(*values, label.Value.Emit())
+			*values = append(*values, scopeLabelValues(record.Descriptor())...)
 		}
 	}
 }
@@ -18,19 +18,32 @@ import (
 	"bytes"
 	"context"
 	"io/ioutil"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"sort"
 	"strings"
 	"testing"
 
+	prom "github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/metric/prometheus"
 	"go.opentelemetry.io/otel/metric"
+	otelsdk "go.opentelemetry.io/otel/sdk"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	sdk "go.opentelemetry.io/otel/sdk/metric"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	selector "go.opentelemetry.io/otel/sdk/metric/selector/simple"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/unit"
 )
 
 func TestPrometheusExporter(t *testing.T) {
@@ -63,7 +76,7 @@ func TestPrometheusExporter(t *testing.T) {
 	counter.Add(ctx, 10, labels...)
 	counter.Add(ctx, 5.3, labels...)
 
-	expected = append(expected, `counter{A="B",C="D",R="V"} 15.3`)
+	expected = append(expected, `counter_total{A="B",C="D",R="V"} 15.3`)
 
 	_ = metric.Must(meter).NewInt64ValueObserver("intobserver", func(_ context.Context, result metric.Int64ObserverResult) {
 		result.Observe(1, labels...)
@@ -146,16 +159,445 @@ func TestPrometheusStatefulness(t *testing.T) {
 
 	counter.Add(ctx, 100, attribute.String("key", "value"))
 
-	require.Equal(t, `# HELP a_counter Counts things
-# TYPE a_counter counter
-a_counter{key="value"} 100
+	require.Equal(t, `# HELP a_counter_total Counts things
+# TYPE a_counter_total counter
+a_counter_total{key="value"} 100
 `, scrape())
 
 	counter.Add(ctx, 100, attribute.String("key", "value"))
 
-	require.Equal(t, `# HELP a_counter Counts things
-# TYPE a_counter counter
-a_counter{key="value"} 200
+	require.Equal(t, `# HELP a_counter_total Counts things
+# TYPE a_counter_total counter
+a_counter_total{key="value"} 200
 `, scrape())
 
 }
+
+func TestPrometheusExemplars(t *testing.T) {
+	registry := prom.NewRegistry()
+	exporter, err := prometheus.NewExportPipeline(
+		prometheus.Config{
+			Registry:                   registry,
+			DefaultHistogramBoundaries: []float64{1, 10},
+		},
+		controller.WithCollectPeriod(0),
+	)
+	require.NoError(t, err)
+
+	meter := exporter.MeterProvider().Meter("test")
+	counter := metric.Must(meter).NewInt64Counter("counter")
+	valuerecorder := metric.Must(meter).NewFloat64ValueRecorder("valuerecorder")
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	sampled := trace.ContextWithSpanContext(context.Background(), sc)
+
+	counter.Add(context.Background(), 1)
+	counter.Add(sampled, 2)
+	valuerecorder.Record(sampled, 5)
+	valuerecorder.Record(sampled, 20)
+	valuerecorder.Record(context.Background(), 0.5)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 2)
+
+	wantLabels := map[string]string{
+		"trace_id": sc.TraceID().String(),
+		"span_id":  sc.SpanID().String(),
+	}
+	labelMap := func(lp []*dto.LabelPair) map[string]string {
+		got := make(map[string]string, len(lp))
+		for _, l := range lp {
+			got[l.GetName()] = l.GetValue()
+		}
+		return got
+	}
+
+	for _, mf := range families {
+		require.Len(t, mf.Metric, 1)
+		m := mf.Metric[0]
+		switch mf.GetName() {
+		case "counter_total":
+			e := m.GetCounter().GetExemplar()
+			require.NotNil(t, e)
+			assert.Equal(t, 2.0, e.GetValue())
+			assert.Equal(t, wantLabels, labelMap(e.Label))
+		case "valuerecorder":
+			buckets := m.GetHistogram().GetBucket()
+			require.Len(t, buckets, 3)
+			assert.Nil(t, buckets[0].GetExemplar())
+			require.NotNil(t, buckets[1].GetExemplar())
+			assert.Equal(t, 5.0, buckets[1].GetExemplar().GetValue())
+			assert.Equal(t, wantLabels, labelMap(buckets[1].GetExemplar().Label))
+			assert.True(t, math.IsInf(buckets[2].GetUpperBound(), 1))
+			assert.Equal(t, uint64(3), buckets[2].GetCumulativeCount())
+			require.NotNil(t, buckets[2].GetExemplar())
+			assert.Equal(t, 20.0, buckets[2].GetExemplar().GetValue())
+		default:
+			t.Errorf("unexpected metric family %q", mf.GetName())
+		}
+	}
+}
+
+func TestPrometheusExternalRegistry(t *testing.T) {
+	registry := prom.NewRegistry()
+	registry.MustRegister(prom.NewGoCollector())
+
+	exporter, err := prometheus.NewExportPipeline(
+		prometheus.Config{
+			Registerer: registry,
+			Gatherer:   registry,
+		},
+		controller.WithCollectPeriod(0),
+	)
+	require.NoError(t, err)
+
+	counter := metric.Must(exporter.MeterProvider().Meter("test")).NewInt64Counter("counter")
+	counter.Add(context.Background(), 1)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	names := make(map[string]bool, len(families))
+	for _, mf := range families {
+		names[mf.GetName()] = true
+	}
+	assert.True(t, names["counter_total"], "exported metric not gathered")
+	assert.True(t, names["go_goroutines"], "existing collector not gathered")
+
+	rec := httptest.NewRecorder()
+	exporter.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	assert.Contains(t, body, "\ncounter_total{")
+	assert.Contains(t, body, "\ngo_goroutines ")
+}
+
+func TestPrometheusHistogramBoundaries(t *testing.T) {
+	exporter, err := prometheus.NewExportPipeline(
+		prometheus.Config{
+			DefaultHistogramBoundaries: []float64{1},
+			HistogramBoundaries: map[string][]float64{
+				"latency": {0.1, 0.5},
+			},
+		},
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+	require.NoError(t, err)
+
+	meter := exporter.MeterProvider().Meter("test")
+	latency := metric.Must(meter).NewFloat64ValueRecorder("latency")
+	size := metric.Must(meter).NewInt64ValueRecorder("size")
+
+	ctx := context.Background()
+	latency.Record(ctx, 0.2)
+	size.Record(ctx, 2)
+
+	compareExport(t, exporter, []string{
+		`latency_bucket{le="+Inf"} 1`,
+		`latency_bucket{le="0.1"} 0`,
+		`latency_bucket{le="0.5"} 1`,
+		`latency_count 1`,
+		`latency_sum 0.2`,
+		`size_bucket{le="+Inf"} 1`,
+		`size_bucket{le="1"} 0`,
+		`size_count 1`,
+		`size_sum 2`,
+	})
+}
+
+func TestPrometheusSummary(t *testing.T) {
+	view := sdk.View{
+		InstrumentName:   "latency",
+		Aggregation:      aggregation.SummaryKind,
+		SummaryQuantiles: []float64{0, 1},
+	}
+	ctrl := controller.New(
+		processor.New(
+			selector.NewWithInexpensiveDistribution(),
+			export.CumulativeExportKindSelector(),
+			processor.WithMemory(true),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+		controller.WithViews(view),
+	)
+	exporter, err := prometheus.NewExporter(prometheus.Config{}, ctrl)
+	require.NoError(t, err)
+
+	latency := metric.Must(exporter.MeterProvider().Meter("test")).NewFloat64ValueRecorder("latency")
+	ctx := context.Background()
+	latency.Record(ctx, 0.5)
+	latency.Record(ctx, 2)
+
+	compareExport(t, exporter, []string{
+		`latency_count 2`,
+		`latency_sum 2.5`,
+		`latency{quantile="0"} 0.5`,
+		`latency{quantile="1"} 2`,
+	})
+}
+
+func TestExponentialHistogramCapability(t *testing.T) {
+	c, ok := otel.LookupCapability(prometheus.ExponentialHistogramCapability)
+	require.True(t, ok)
+	assert.True(t, c.Enabled)
+	assert.Equal(t, otelsdk.Version(), c.Version)
+}
+
+func TestPrometheusExponentialHistogram(t *testing.T) {
+	view := sdk.View{
+		InstrumentName: "latency",
+		Aggregation:    aggregation.ExponentialHistogramKind,
+		// 0.5 and 4 fit in 2 buckets at scale -1, of base 4.
+		ExponentialHistogramMaxSize: 2,
+	}
+	ctrl := controller.New(
+		processor.New(
+			selector.NewWithInexpensiveDistribution(),
+			export.CumulativeExportKindSelector(),
+			processor.WithMemory(true),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+		controller.WithViews(view),
+	)
+	exporter, err := prometheus.NewExporter(prometheus.Config{}, ctrl)
+	require.NoError(t, err)
+
+	latency := metric.Must(exporter.MeterProvider().Meter("test")).NewFloat64ValueRecorder("latency")
+	ctx := context.Background()
+	latency.Record(ctx, 0)
+	latency.Record(ctx, 0.5)
+	latency.Record(ctx, 4)
+
+	compareExport(t, exporter, []string{
+		`latency_bucket{le="+Inf"} 3`,
+		`latency_bucket{le="0"} 1`,
+		`latency_bucket{le="1"} 2`,
+		`latency_count 3`,
+		`latency_sum 4.5`,
+	})
+}
+
+func TestPrometheusInfoMetrics(t *testing.T) {
+	exporter, err := prometheus.NewExportPipeline(
+		prometheus.Config{
+			TargetInfo: true,
+			ScopeInfo:  true,
+		},
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.NewWithAttributes(
+			attribute.String("service.name", "checkout"),
+		)),
+	)
+	require.NoError(t, err)
+
+	meter := exporter.MeterProvider().Meter("test", metric.WithInstrumentationVersion("v0.1.0"))
+	counter := metric.Must(meter).NewInt64Counter("counter")
+	counter.Add(context.Background(), 1, attribute.String("A", "B"))
+
+	compareExport(t, exporter, []string{
+		`counter_total{A="B",otel_scope_name="test",otel_scope_version="v0.1.0"} 1`,
+		`otel_scope_info{otel_scope_name="test",otel_scope_version="v0.1.0"} 1`,
+		`target_info{service_name="checkout"} 1`,
+	})
+}
+
+func TestPrometheusTargetInfoLabelCollisions(t *testing.T) {
+	exporter, err := prometheus.NewExportPipeline(
+		prometheus.Config{
+			ConstLabels: prom.Labels{"team": "payments"},
+			TargetInfo:  true,
+		},
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.NewWithAttributes(
+			attribute.String("service.name", "checkout"),
+			attribute.String("service_name", "cart"),
+			attribute.String("team", "ignored"),
+		)),
+	)
+	require.NoError(t, err)
+
+	meter := exporter.MeterProvider().Meter("test")
+	counter := metric.Must(meter).NewInt64Counter("requests")
+	counter.Add(context.Background(), 2)
+
+	compareExport(t, exporter, []string{
+		`requests_total{team="payments"} 2`,
+		`target_info{service_name="checkout;cart",team="payments"} 1`,
+	})
+}
+
+func TestPrometheusNamespaceAndConstLabels(t *testing.T) {
+	exporter, err := prometheus.NewExportPipeline(
+		prometheus.Config{
+			Namespace:   "myapp",
+			ConstLabels: prom.Labels{"team": "payments"},
+			TargetInfo:  true,
+		},
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.NewWithAttributes(attribute.String("R", "V"))),
+	)
+	require.NoError(t, err)
+
+	meter := exporter.MeterProvider().Meter("test")
+	counter := metric.Must(meter).NewInt64Counter("requests")
+	counter.Add(context.Background(), 2, attribute.String("A", "B"))
+
+	compareExport(t, exporter, []string{
+		`myapp_requests_total{A="B",team="payments"} 2`,
+		`target_info{R="V",team="payments"} 1`,
+	})
+}
+
+func TestPrometheusSumTypes(t *testing.T) {
+	exporter, err := prometheus.NewExportPipeline(
+		prometheus.Config{},
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+	require.NoError(t, err)
+
+	meter := exporter.MeterProvider().Meter("test")
+	ctx := context.Background()
+	metric.Must(meter).NewInt64Counter("requests").Add(ctx, 1)
+	metric.Must(meter).NewInt64Counter("bytes_total").Add(ctx, 2)
+	metric.Must(meter).NewInt64UpDownCounter("queue").Add(ctx, -3)
+	metric.Must(meter).NewInt64UpDownCounter("pending_total").Add(ctx, 5)
+	metric.Must(meter).NewInt64ValueRecorder("retries_total").Record(ctx, 6)
+	_ = metric.Must(meter).NewInt64UpDownSumObserver("connections", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(4)
+	})
+
+	rec := httptest.NewRecorder()
+	exporter.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+
+	assert.Contains(t, body, "# TYPE requests_total counter\nrequests_total 1\n")
+	assert.Contains(t, body, "# TYPE bytes_total counter\nbytes_total 2\n")
+	assert.Contains(t, body, "# TYPE queue gauge\nqueue -3\n")
+	// Only the suffix of counters is normalized.
+	assert.Contains(t, body, "# TYPE pending_total gauge\npending_total 5\n")
+	assert.Contains(t, body, "\nretries_total_count 1\n")
+	assert.Contains(t, body, "# TYPE connections gauge\nconnections 4\n")
+}
+
+func TestPrometheusOpenMetrics(t *testing.T) {
+	exporter, err := prometheus.NewExportPipeline(
+		prometheus.Config{
+			EnableOpenMetrics:          true,
+			DefaultHistogramBoundaries: []float64{1},
+		},
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+	require.NoError(t, err)
+
+	meter := exporter.MeterProvider().Meter("test")
+	ctx := context.Background()
+	metric.Must(meter).NewInt64Counter("requests").Add(ctx, 1)
+	metric.Must(meter).NewFloat64ValueRecorder("latency").Record(ctx, 0.5)
+	metric.Must(meter).NewInt64UpDownCounter("queue").Add(ctx, 1)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=0.0.1")
+	rec := httptest.NewRecorder()
+	exporter.ServeHTTP(rec, req)
+
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/openmetrics-text")
+	body := rec.Body.String()
+	assert.Contains(t, body, "\nrequests_total 1.0\n")
+	assert.NotContains(t, body, "_created")
+	assert.True(t, strings.HasSuffix(body, "# EOF\n"))
+}
+
+func TestPrometheusNameMapping(t *testing.T) {
+	exporter, err := prometheus.NewExportPipeline(
+		prometheus.Config{
+			NameSanitization: prometheus.SanitizeNonASCII,
+			NameMapper: func(desc *metric.Descriptor) string {
+				if desc.Name() == "http.server.duration" {
+					return "legacy_http_latency"
+				}
+				return strings.ReplaceAll(desc.Name(), ".", "_")
+			},
+			UnitSuffixes: true,
+		},
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+	require.NoError(t, err)
+
+	meter := exporter.MeterProvider().Meter("test")
+	ctx := context.Background()
+	metric.Must(meter).NewInt64UpDownCounter("http.server.duration", metric.WithUnit(unit.Milliseconds)).Add(ctx, 1)
+	metric.Must(meter).NewInt64Counter("rpc.payload", metric.WithUnit(unit.Bytes)).Add(ctx, 2, attribute.String("größe", "v"))
+
+	compareExport(t, exporter, []string{
+		`legacy_http_latency_milliseconds 1`,
+		`rpc_payload_bytes_total{gr__e="v"} 2`,
+	})
+}
+
+func TestPrometheusMultipleExporters(t *testing.T) {
+	exporterA, err := prometheus.NewExportPipeline(
+		prometheus.Config{
+			Filter: prometheus.FilterLabel(attribute.String("tenant", "a")),
+		},
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+	require.NoError(t, err)
+	exporterB, err := prometheus.NewExporter(
+		prometheus.Config{
+			Filter: prometheus.FilterLabel(attribute.String("tenant", "b")),
+		},
+		exporterA.Controller(),
+	)
+	require.NoError(t, err)
+	exporterLib, err := prometheus.NewExporter(
+		prometheus.Config{
+			Filter: prometheus.FilterInstrumentation("lib"),
+		},
+		exporterA.Controller(),
+	)
+	require.NoError(t, err)
+
+	provider := exporterA.MeterProvider()
+	ctx := context.Background()
+	counter := metric.Must(provider.Meter("app")).NewInt64Counter("requests")
+	counter.Add(ctx, 1, attribute.String("tenant", "a"))
+	counter.Add(ctx, 2, attribute.String("tenant", "b"))
+	metric.Must(provider.Meter("lib")).NewInt64Counter("lib.calls").Add(ctx, 3)
+
+	compareExport(t, exporterA, []string{`requests_total{tenant="a"} 1`})
+	compareExport(t, exporterB, []string{`requests_total{tenant="b"} 2`})
+	compareExport(t, exporterLib, []string{`lib_calls_total 3`})
+}
+
+func TestPrometheusMetricNameLists(t *testing.T) {
+	exporter, err := prometheus.NewExportPipeline(
+		prometheus.Config{
+			AllowedMetricNames: []string{"http.*", "rpc.calls"},
+			DeniedMetricNames:  []string{"http.*.internal"},
+		},
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+	require.NoError(t, err)
+
+	meter := exporter.MeterProvider().Meter("test")
+	ctx := context.Background()
+	for _, name := range []string{"http.requests", "http.cache.internal", "rpc.calls", "rpc.calls.detail", "db.queries"} {
+		metric.Must(meter).NewInt64Counter(name).Add(ctx, 1)
+	}
+
+	compareExport(t, exporter, []string{
+		`http_requests_total 1`,
+		`rpc_calls_total 1`,
+	})
+}
@@ -17,6 +17,8 @@ package prometheus // import "go.opentelemetry.io/otel/exporters/metric/promethe
 import (
 	"strings"
 	"unicode"
+
+	"go.opentelemetry.io/otel/unit"
 )
 
 // TODO(paivagustavo): we should provide a more uniform and controlled way of sanitizing.
@@ -48,3 +50,59 @@ func sanitizeRune(r rune) rune {
 	// Everything else turns into an underscore
 	return '_'
 }
+
+// NameSanitization is the strategy used to replace characters that are not
+// allowed in Prometheus metric and label names.
+type NameSanitization int
+
+const (
+	// SanitizeNonLetters replaces all characters that are neither a
+	// Unicode letter nor a digit with an underscore. This is the default.
+	SanitizeNonLetters NameSanitization = iota
+	// SanitizeNonASCII replaces all characters that are neither an ASCII
+	// letter nor a digit with an underscore, ensuring names are valid for
+	// all Prometheus versions.
+	SanitizeNonASCII
+)
+
+// sanitizer returns the function sanitizing names with strategy s.
+func (s NameSanitization) sanitizer() func(string) string {
+	if s == SanitizeNonASCII {
+		return sanitizeASCII
+	}
+	return sanitize
+}
+
+// sanitizeASCII is like sanitize, but also replaces non ASCII letters and
+// digits with underscores.
+func sanitizeASCII(s string) string {
+	return sanitize(strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII {
+			return '_'
+		}
+		return r
+	}, s))
+}
+
+// unitSuffixes are the name suffixes of well known units.
+var unitSuffixes = map[unit.Unit]string{
+	unit.Dimensionless: "ratio",
+	unit.Bytes:         "bytes",
+	unit.Milliseconds:  "milliseconds",
+}
+
+// withUnitSuffix returns name with the suffix of u appended unless it is
+// already present.
+func withUnitSuffix(name string, u unit.Unit, sanitize func(string) string) string {
+	if u == "" {
+		return name
+	}
+	suffix, ok := unitSuffixes[u]
+	if !ok {
+		suffix = sanitize(string(u))
+	}
+	if strings.HasSuffix(name, "_"+suffix) {
+		return name
+	}
+	return name + "_" + suffix
+}
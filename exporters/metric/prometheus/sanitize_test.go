@@ -16,6 +16,8 @@ package prometheus
 
 import (
 	"testing"
+
+	"go.opentelemetry.io/otel/unit"
 )
 
 func TestSanitize(t *testing.T) {
@@ -59,3 +61,42 @@ func TestSanitize(t *testing.T) {
 		})
 	}
 }
+
+func TestSanitizeASCII(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"test/key-1", "test_key_1"},
+		{"größe", "gr__e"},
+		{"ü", "key_"},
+		{"valid_name", "valid_name"},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeASCII(tt.input); got != tt.want {
+			t.Errorf("sanitizeASCII(%q) = %q; want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestWithUnitSuffix(t *testing.T) {
+	tests := []struct {
+		name string
+		unit unit.Unit
+		want string
+	}{
+		{"payload", unit.Bytes, "payload_bytes"},
+		{"payload_bytes", unit.Bytes, "payload_bytes"},
+		{"latency", unit.Milliseconds, "latency_milliseconds"},
+		{"utilization", unit.Dimensionless, "utilization_ratio"},
+		{"latency", unit.Unit("s"), "latency_s"},
+		{"requests", unit.Unit(""), "requests"},
+	}
+
+	for _, tt := range tests {
+		if got := withUnitSuffix(tt.name, tt.unit, sanitize); got != tt.want {
+			t.Errorf("withUnitSuffix(%q, %q) = %q; want %q", tt.name, tt.unit, got, tt.want)
+		}
+	}
+}
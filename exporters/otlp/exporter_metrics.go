@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlp // import "go.opentelemetry.io/otel/exporters/otlp"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/unit"
+)
+
+// ExportSignalKey and ExportSuccessKey are the attribute keys of the
+// signal, "traces" or "metrics", and of the outcome of an export recorded
+// by the metrics of an Exporter configured with WithExportMeterProvider.
+const (
+	ExportSignalKey  = attribute.Key("otlp.export.signal")
+	ExportSuccessKey = attribute.Key("otlp.export.success")
+)
+
+var (
+	tracesSignal  = ExportSignalKey.String("traces")
+	metricsSignal = ExportSignalKey.String("metrics")
+)
+
+// exporterMetrics are the instruments recording the exports of an Exporter.
+type exporterMetrics struct {
+	exports  metric.Int64Counter
+	active   metric.Int64UpDownCounter
+	spans    metric.Int64Counter
+	retries  metric.Int64Counter
+	duration metric.Float64ValueRecorder
+}
+
+func newExporterMetrics(mp metric.MeterProvider) *exporterMetrics {
+	m := metric.Must(mp.Meter(instrumentationName))
+	return &exporterMetrics{
+		exports: m.NewInt64Counter(
+			"otlp.exporter.exports",
+			metric.WithDescription("Number of exports sent to the collector"),
+			metric.WithUnit(unit.Dimensionless),
+		),
+		active: m.NewInt64UpDownCounter(
+			"otlp.exporter.exports.active",
+			metric.WithDescription("Number of exports waiting for the collector"),
+			metric.WithUnit(unit.Dimensionless),
+		),
+		spans: m.NewInt64Counter(
+			"otlp.exporter.spans",
+			metric.WithDescription("Number of spans sent to the collector"),
+			metric.WithUnit(unit.Dimensionless),
+		),
+		retries: m.NewInt64Counter(
+			"otlp.exporter.retries",
+			metric.WithDescription("Number of times the protocol driver retried sending an export"),
+			metric.WithUnit(unit.Dimensionless),
+		),
+		duration: m.NewFloat64ValueRecorder(
+			"otlp.exporter.export.duration",
+			metric.WithDescription("Duration of exports, including retries"),
+			metric.WithUnit(unit.Milliseconds),
+		),
+	}
+}
+
+// export calls export with ctx and records it as an export of spanCount
+// spans, or of metrics if spanCount is negative, for signal.
+func (m *exporterMetrics) export(ctx context.Context, signal attribute.KeyValue, spanCount int, export func(context.Context) error) error {
+	m.active.Add(ctx, 1, signal)
+	ctx = context.WithValue(ctx, retryRecorderKey, func() {
+		m.retries.Add(ctx, 1, signal)
+	})
+
+	start := time.Now()
+	err := export(ctx)
+	elapsed := time.Since(start)
+
+	m.active.Add(ctx, -1, signal)
+	attrs := []attribute.KeyValue{signal, ExportSuccessKey.Bool(err == nil)}
+	m.exports.Add(ctx, 1, attrs...)
+	m.duration.Record(ctx, float64(elapsed)/float64(time.Millisecond), attrs...)
+	if spanCount >= 0 {
+		m.spans.Add(ctx, int64(spanCount), attrs...)
+	}
+	return err
+}
+
+type retryRecorderKeyType int
+
+const retryRecorderKey retryRecorderKeyType = iota
+
+// RecordExportRetry records that the export of the data passed with ctx to a
+// ProtocolDriver is retried. ProtocolDriver implementations should call it
+// every time they resend an export. It does nothing unless the Exporter was
+// configured with WithExportMeterProvider.
+func RecordExportRetry(ctx context.Context) {
+	if record, ok := ctx.Value(retryRecorderKey).(func()); ok {
+		record()
+	}
+}
@@ -27,7 +27,6 @@ import (
 	"go.opentelemetry.io/otel/exporters/otlp"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/number"
-	exportmetric "go.opentelemetry.io/otel/sdk/export/metric"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
@@ -75,7 +74,7 @@ func RunEndToEndTest(ctx context.Context, t *testing.T, exp *otlp.Exporter, mcTr
 	}
 
 	selector := simple.NewWithInexpensiveDistribution()
-	processor := processor.New(selector, exportmetric.StatelessExportKindSelector())
+	processor := processor.New(selector, exp)
 	cont := controller.New(processor, controller.WithExporter(exp))
 	require.NoError(t, cont.Start(ctx))
 
@@ -216,8 +215,8 @@ func RunEndToEndTest(ctx context.Context, t *testing.T, exp *otlp.Exporter, mcTr
 					assert.Equal(t, data.val, dp[0].Value, "invalid value for %q", m.Name)
 				}
 			case number.Float64Kind:
-				if dp := m.GetDoubleSum().DataPoints; assert.Len(t, dp, 1) {
-					assert.Equal(t, float64(data.val), dp[0].Value, "invalid value for %q", m.Name)
+				if dp := m.GetSum().DataPoints; assert.Len(t, dp, 1) {
+					assert.Equal(t, float64(data.val), dp[0].GetAsDouble(), "invalid value for %q", m.Name)
 				}
 			default:
 				assert.Failf(t, "invalid number kind", data.nKind.String())
@@ -229,8 +228,8 @@ func RunEndToEndTest(ctx context.Context, t *testing.T, exp *otlp.Exporter, mcTr
 					assert.Equal(t, data.val, dp[0].Value, "invalid value for %q", m.Name)
 				}
 			case number.Float64Kind:
-				if dp := m.GetDoubleGauge().DataPoints; assert.Len(t, dp, 1) {
-					assert.Equal(t, float64(data.val), dp[0].Value, "invalid value for %q", m.Name)
+				if dp := m.GetGauge().DataPoints; assert.Len(t, dp, 1) {
+					assert.Equal(t, float64(data.val), dp[0].GetAsDouble(), "invalid value for %q", m.Name)
 				}
 			default:
 				assert.Failf(t, "invalid number kind", data.nKind.String())
@@ -245,8 +244,8 @@ func RunEndToEndTest(ctx context.Context, t *testing.T, exp *otlp.Exporter, mcTr
 					assert.Equal(t, int64(data.val*int64(count)), dp[0].Sum, "invalid sum for %q (value %d)", m.Name, data.val)
 				}
 			case number.Float64Kind:
-				assert.NotNil(t, m.GetDoubleHistogram())
-				if dp := m.GetDoubleHistogram().DataPoints; assert.Len(t, dp, 1) {
+				assert.NotNil(t, m.GetHistogram())
+				if dp := m.GetHistogram().DataPoints; assert.Len(t, dp, 1) {
 					count := dp[0].Count
 					assert.Equal(t, uint64(1), count, "invalid count for %q", m.Name)
 					assert.Equal(t, float64(data.val*int64(count)), dp[0].Sum, "invalid sum for %q (value %d)", m.Name, data.val)
@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracecontext marks the contexts of the exports whose trace
+// context is sent to the receiver, the exports traced by an Exporter
+// configured with WithExportTracerProvider.
+package tracecontext // import "go.opentelemetry.io/otel/exporters/otlp/internal/tracecontext"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+type propagateKey struct{}
+
+// WithPropagation returns a copy of ctx whose trace context is injected
+// by Inject.
+func WithPropagation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, propagateKey{}, true)
+}
+
+// Inject injects the trace context of ctx into carrier with the global
+// TextMapPropagator if ctx was returned by WithPropagation. It does
+// nothing otherwise, the trace context of the caller of an export is not
+// sent to the receiver.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	if propagate, _ := ctx.Value(propagateKey{}).(bool); propagate {
+		otel.GetTextMapPropagator().Inject(ctx, carrier)
+	}
+}
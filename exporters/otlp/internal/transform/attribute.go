@@ -15,8 +15,6 @@
 package transform
 
 import (
-	"reflect"
-
 	"go.opentelemetry.io/otel/attribute"
 	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 
@@ -72,10 +70,10 @@ func toAttribute(v attribute.KeyValue) *commonpb.KeyValue {
 		result.Value.Value = &commonpb.AnyValue_StringValue{
 			StringValue: v.Value.AsString(),
 		}
-	case attribute.ARRAY:
+	case attribute.BOOLSLICE, attribute.INT64SLICE, attribute.FLOAT64SLICE, attribute.STRINGSLICE:
 		result.Value.Value = &commonpb.AnyValue_ArrayValue{
 			ArrayValue: &commonpb.ArrayValue{
-				Values: arrayValues(v),
+				Values: sliceValues(v.Value),
 			},
 		}
 	default:
@@ -86,56 +84,42 @@ func toAttribute(v attribute.KeyValue) *commonpb.KeyValue {
 	return result
 }
 
-func arrayValues(kv attribute.KeyValue) []*commonpb.AnyValue {
-	a := kv.Value.AsArray()
-	aType := reflect.TypeOf(a)
-	var valueFunc func(reflect.Value) *commonpb.AnyValue
-	switch aType.Elem().Kind() {
-	case reflect.Bool:
-		valueFunc = func(v reflect.Value) *commonpb.AnyValue {
-			return &commonpb.AnyValue{
+// sliceValues transforms the elements of a slice Value into OTLP values.
+func sliceValues(v attribute.Value) []*commonpb.AnyValue {
+	var results []*commonpb.AnyValue
+	switch v.Type() {
+	case attribute.BOOLSLICE:
+		for _, e := range v.AsBoolSlice() {
+			results = append(results, &commonpb.AnyValue{
 				Value: &commonpb.AnyValue_BoolValue{
-					BoolValue: v.Bool(),
-				},
-			}
-		}
-	case reflect.Int, reflect.Int64:
-		valueFunc = func(v reflect.Value) *commonpb.AnyValue {
-			return &commonpb.AnyValue{
-				Value: &commonpb.AnyValue_IntValue{
-					IntValue: v.Int(),
+					BoolValue: e,
 				},
-			}
+			})
 		}
-	case reflect.Uintptr:
-		valueFunc = func(v reflect.Value) *commonpb.AnyValue {
-			return &commonpb.AnyValue{
+	case attribute.INT64SLICE:
+		for _, e := range v.AsInt64Slice() {
+			results = append(results, &commonpb.AnyValue{
 				Value: &commonpb.AnyValue_IntValue{
-					IntValue: int64(v.Uint()),
+					IntValue: e,
 				},
-			}
+			})
 		}
-	case reflect.Float64:
-		valueFunc = func(v reflect.Value) *commonpb.AnyValue {
-			return &commonpb.AnyValue{
+	case attribute.FLOAT64SLICE:
+		for _, e := range v.AsFloat64Slice() {
+			results = append(results, &commonpb.AnyValue{
 				Value: &commonpb.AnyValue_DoubleValue{
-					DoubleValue: v.Float(),
+					DoubleValue: e,
 				},
-			}
+			})
 		}
-	case reflect.String:
-		valueFunc = func(v reflect.Value) *commonpb.AnyValue {
-			return &commonpb.AnyValue{
+	case attribute.STRINGSLICE:
+		for _, e := range v.AsStringSlice() {
+			results = append(results, &commonpb.AnyValue{
 				Value: &commonpb.AnyValue_StringValue{
-					StringValue: v.String(),
+					StringValue: e,
 				},
-			}
+			})
 		}
 	}
-
-	results := make([]*commonpb.AnyValue, aType.Len())
-	for i, aValue := 0, reflect.ValueOf(a); i < aValue.Len(); i++ {
-		results[i] = valueFunc(aValue.Index(i))
-	}
 	return results
 }
@@ -105,14 +105,11 @@ func TestAttributes(t *testing.T) {
 }
 
 func TestArrayAttributes(t *testing.T) {
-	// Array KeyValue supports only arrays of primitive types:
-	// "bool", "int", "int64",
-	// "float64", "string",
 	for _, test := range []attributeTest{
 		{nil, nil},
 		{
 			[]attribute.KeyValue{
-				attribute.Array("invalid", [][]string{{"1", "2"}, {"a"}}),
+				{Key: "invalid"},
 			},
 			[]*commonpb.KeyValue{
 				{
@@ -127,11 +124,11 @@ func TestArrayAttributes(t *testing.T) {
 		},
 		{
 			[]attribute.KeyValue{
-				attribute.Array("bool array to bool array", []bool{true, false}),
-				attribute.Array("int array to int64 array", []int{1, 2, 3}),
-				attribute.Array("int64 array to int64 array", []int64{1, 2, 3}),
-				attribute.Array("float64 array to double array", []float64{1.11, 2.22, 3.33}),
-				attribute.Array("string array to string array", []string{"foo", "bar", "baz"}),
+				attribute.BoolSlice("bool array to bool array", []bool{true, false}),
+				attribute.IntSlice("int array to int64 array", []int{1, 2, 3}),
+				attribute.Int64Slice("int64 array to int64 array", []int64{1, 2, 3}),
+				attribute.Float64Slice("float64 array to double array", []float64{1.11, 2.22, 3.33}),
+				attribute.StringSlice("string array to string array", []string{"foo", "bar", "baz"}),
 			},
 			[]*commonpb.KeyValue{
 				newOTelBoolArray("bool array to bool array", []bool{true, false}),
@@ -21,7 +21,7 @@ import (
 )
 
 func instrumentationLibrary(il instrumentation.Library) *commonpb.InstrumentationLibrary {
-	if il == (instrumentation.Library{}) {
+	if il.Name == "" && il.Version == "" {
 		return nil
 	}
 	return &commonpb.InstrumentationLibrary{
@@ -141,8 +141,9 @@ func transformer(ctx context.Context, exportSelector export.ExportKindSelector,
 		res := result{
 			Resource: r.Resource(),
 			InstrumentationLibrary: instrumentation.Library{
-				Name:    r.Descriptor().InstrumentationName(),
-				Version: r.Descriptor().InstrumentationVersion(),
+				Name:      r.Descriptor().InstrumentationName(),
+				Version:   r.Descriptor().InstrumentationVersion(),
+				SchemaURL: r.Descriptor().InstrumentationSchemaURL(),
 			},
 			Metric: m,
 			Err:    err,
@@ -164,24 +165,26 @@ func sink(ctx context.Context, in <-chan result) ([]*metricpb.ResourceMetrics, e
 	var errStrings []string
 
 	type resourceBatch struct {
-		Resource *resourcepb.Resource
+		Resource  *resourcepb.Resource
+		SchemaURL string
 		// Group by instrumentation library name and then the MetricDescriptor.
 		InstrumentationLibraryBatches map[instrumentation.Library]map[string]*metricpb.Metric
 	}
 
 	// group by unique Resource string.
-	grouped := make(map[attribute.Distinct]resourceBatch)
+	grouped := make(map[resource.Key]resourceBatch)
 	for res := range in {
 		if res.Err != nil {
 			errStrings = append(errStrings, res.Err.Error())
 			continue
 		}
 
-		rID := res.Resource.Equivalent()
+		rID := res.Resource.Key()
 		rb, ok := grouped[rID]
 		if !ok {
 			rb = resourceBatch{
 				Resource:                      Resource(res.Resource),
+				SchemaURL:                     res.Resource.SchemaURL(),
 				InstrumentationLibraryBatches: make(map[instrumentation.Library]map[string]*metricpb.Metric),
 			}
 			grouped[rID] = rb
@@ -206,12 +209,12 @@ func sink(ctx context.Context, in <-chan result) ([]*metricpb.ResourceMetrics, e
 			m.GetIntHistogram().DataPoints = append(m.GetIntHistogram().DataPoints, res.Metric.GetIntHistogram().DataPoints...)
 		case *metricpb.Metric_IntSum:
 			m.GetIntSum().DataPoints = append(m.GetIntSum().DataPoints, res.Metric.GetIntSum().DataPoints...)
-		case *metricpb.Metric_DoubleGauge:
-			m.GetDoubleGauge().DataPoints = append(m.GetDoubleGauge().DataPoints, res.Metric.GetDoubleGauge().DataPoints...)
-		case *metricpb.Metric_DoubleHistogram:
-			m.GetDoubleHistogram().DataPoints = append(m.GetDoubleHistogram().DataPoints, res.Metric.GetDoubleHistogram().DataPoints...)
-		case *metricpb.Metric_DoubleSum:
-			m.GetDoubleSum().DataPoints = append(m.GetDoubleSum().DataPoints, res.Metric.GetDoubleSum().DataPoints...)
+		case *metricpb.Metric_Gauge:
+			m.GetGauge().DataPoints = append(m.GetGauge().DataPoints, res.Metric.GetGauge().DataPoints...)
+		case *metricpb.Metric_Histogram:
+			m.GetHistogram().DataPoints = append(m.GetHistogram().DataPoints, res.Metric.GetHistogram().DataPoints...)
+		case *metricpb.Metric_Sum:
+			m.GetSum().DataPoints = append(m.GetSum().DataPoints, res.Metric.GetSum().DataPoints...)
 		default:
 		}
 	}
@@ -222,12 +225,13 @@ func sink(ctx context.Context, in <-chan result) ([]*metricpb.ResourceMetrics, e
 
 	var rms []*metricpb.ResourceMetrics
 	for _, rb := range grouped {
-		rm := &metricpb.ResourceMetrics{Resource: rb.Resource}
+		rm := &metricpb.ResourceMetrics{Resource: rb.Resource, SchemaUrl: rb.SchemaURL}
 		for il, mb := range rb.InstrumentationLibraryBatches {
 			ilm := &metricpb.InstrumentationLibraryMetrics{
-				Metrics: make([]*metricpb.Metric, 0, len(mb)),
+				Metrics:   make([]*metricpb.Metric, 0, len(mb)),
+				SchemaUrl: il.SchemaURL,
 			}
-			if il != (instrumentation.Library{}) {
+			if il.Name != "" || il.Version != "" {
 				ilm.InstrumentationLibrary = &commonpb.InstrumentationLibrary{
 					Name:    il.Name,
 					Version: il.Version,
@@ -267,6 +271,22 @@ func Record(exportSelector export.ExportKindSelector, r export.Record) (*metricp
 		}
 		return histogramPoint(r, exportSelector.ExportKindFor(r.Descriptor(), aggregation.HistogramKind), h)
 
+	case aggregation.ExponentialHistogramKind:
+		// OTLP has no exponential histogram, its buckets are exported
+		// with explicit boundaries.
+		h, ok := agg.(aggregation.ExponentialHistogram)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrIncompatibleAgg, agg)
+		}
+		return histogramPoint(r, exportSelector.ExportKindFor(r.Descriptor(), aggregation.ExponentialHistogramKind), aggregation.ExplicitHistogram(h))
+
+	case aggregation.SummaryKind:
+		s, ok := agg.(aggregation.Summary)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T", ErrIncompatibleAgg, agg)
+		}
+		return summaryPoint(r, s)
+
 	case aggregation.SumKind:
 		s, ok := agg.(aggregation.Sum)
 		if !ok {
@@ -335,17 +355,17 @@ func gaugeArray(record export.Record, points []aggregation.Point) (*metricpb.Met
 		}
 
 	case number.Float64Kind:
-		var pts []*metricpb.DoubleDataPoint
+		var pts []*metricpb.NumberDataPoint
 		for _, s := range points {
-			pts = append(pts, &metricpb.DoubleDataPoint{
+			pts = append(pts, &metricpb.NumberDataPoint{
 				Labels:            pbLabels,
 				StartTimeUnixNano: toNanos(record.StartTime()),
 				TimeUnixNano:      toNanos(record.EndTime()),
-				Value:             s.Number.CoerceToFloat64(nk),
+				Value:             &metricpb.NumberDataPoint_AsDouble{AsDouble: s.Number.CoerceToFloat64(nk)},
 			})
 		}
-		m.Data = &metricpb.Metric_DoubleGauge{
-			DoubleGauge: &metricpb.DoubleGauge{
+		m.Data = &metricpb.Metric_Gauge{
+			Gauge: &metricpb.Gauge{
 				DataPoints: pts,
 			},
 		}
@@ -382,11 +402,11 @@ func gaugePoint(record export.Record, num number.Number, start, end time.Time) (
 			},
 		}
 	case number.Float64Kind:
-		m.Data = &metricpb.Metric_DoubleGauge{
-			DoubleGauge: &metricpb.DoubleGauge{
-				DataPoints: []*metricpb.DoubleDataPoint{
+		m.Data = &metricpb.Metric_Gauge{
+			Gauge: &metricpb.Gauge{
+				DataPoints: []*metricpb.NumberDataPoint{
 					{
-						Value:             num.CoerceToFloat64(n),
+						Value:             &metricpb.NumberDataPoint_AsDouble{AsDouble: num.CoerceToFloat64(n)},
 						Labels:            stringKeyValues(labels.Iter()),
 						StartTimeUnixNano: toNanos(start),
 						TimeUnixNano:      toNanos(end),
@@ -438,13 +458,13 @@ func sumPoint(record export.Record, num number.Number, start, end time.Time, ek
 			},
 		}
 	case number.Float64Kind:
-		m.Data = &metricpb.Metric_DoubleSum{
-			DoubleSum: &metricpb.DoubleSum{
+		m.Data = &metricpb.Metric_Sum{
+			Sum: &metricpb.Sum{
 				IsMonotonic:            monotonic,
 				AggregationTemporality: exportKindToTemporality(ek),
-				DataPoints: []*metricpb.DoubleDataPoint{
+				DataPoints: []*metricpb.NumberDataPoint{
 					{
-						Value:             num.CoerceToFloat64(n),
+						Value:             &metricpb.NumberDataPoint_AsDouble{AsDouble: num.CoerceToFloat64(n)},
 						Labels:            stringKeyValues(labels.Iter()),
 						StartTimeUnixNano: toNanos(start),
 						TimeUnixNano:      toNanos(end),
@@ -513,9 +533,9 @@ func minMaxSumCount(record export.Record, a aggregation.MinMaxSumCount) (*metric
 			},
 		}
 	case number.Float64Kind:
-		m.Data = &metricpb.Metric_DoubleHistogram{
-			DoubleHistogram: &metricpb.DoubleHistogram{
-				DataPoints: []*metricpb.DoubleHistogramDataPoint{
+		m.Data = &metricpb.Metric_Histogram{
+			Histogram: &metricpb.Histogram{
+				DataPoints: []*metricpb.HistogramDataPoint{
 					{
 						Sum:               sum.CoerceToFloat64(n),
 						Labels:            stringKeyValues(labels.Iter()),
@@ -590,10 +610,10 @@ func histogramPoint(record export.Record, ek export.ExportKind, a aggregation.Hi
 			},
 		}
 	case number.Float64Kind:
-		m.Data = &metricpb.Metric_DoubleHistogram{
-			DoubleHistogram: &metricpb.DoubleHistogram{
+		m.Data = &metricpb.Metric_Histogram{
+			Histogram: &metricpb.Histogram{
 				AggregationTemporality: exportKindToTemporality(ek),
-				DataPoints: []*metricpb.DoubleHistogramDataPoint{
+				DataPoints: []*metricpb.HistogramDataPoint{
 					{
 						Sum:               sum.CoerceToFloat64(n),
 						Labels:            stringKeyValues(labels.Iter()),
@@ -613,6 +633,72 @@ func histogramPoint(record export.Record, ek export.ExportKind, a aggregation.Hi
 	return m, nil
 }
 
+// summaryValues returns the estimated values of the quantiles of the
+// Summary Aggregator. No values are returned if the Aggregator is empty.
+func summaryValues(n number.Kind, a aggregation.Summary) ([]*metricpb.SummaryDataPoint_ValueAtQuantile, error) {
+	quantiles := a.Quantiles()
+	values := make([]*metricpb.SummaryDataPoint_ValueAtQuantile, 0, len(quantiles))
+	for _, q := range quantiles {
+		v, err := a.Quantile(q)
+		if errors.Is(err, aggregation.ErrNoData) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, &metricpb.SummaryDataPoint_ValueAtQuantile{
+			Quantile: q,
+			Value:    v.CoerceToFloat64(n),
+		})
+	}
+	return values, nil
+}
+
+// summaryPoint transforms a Summary Aggregator into an OTLP Metric.
+func summaryPoint(record export.Record, a aggregation.Summary) (*metricpb.Metric, error) {
+	desc := record.Descriptor()
+	labels := record.Labels()
+	n := desc.NumberKind()
+	if n != number.Int64Kind && n != number.Float64Kind {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownValueType, n)
+	}
+
+	count, err := a.Count()
+	if err != nil {
+		return nil, err
+	}
+
+	sum, err := a.Sum()
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := summaryValues(n, a)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metricpb.Metric{
+		Name:        desc.Name(),
+		Description: desc.Description(),
+		Unit:        string(desc.Unit()),
+		Data: &metricpb.Metric_Summary{
+			Summary: &metricpb.Summary{
+				DataPoints: []*metricpb.SummaryDataPoint{
+					{
+						Labels:            stringKeyValues(labels.Iter()),
+						StartTimeUnixNano: toNanos(record.StartTime()),
+						TimeUnixNano:      toNanos(record.EndTime()),
+						Count:             count,
+						Sum:               sum.CoerceToFloat64(n),
+						QuantileValues:    values,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
 // stringKeyValues transforms a label iterator into an OTLP StringKeyValues.
 func stringKeyValues(iter attribute.Iterator) []*commonpb.StringKeyValue {
 	l := iter.Len()
@@ -30,7 +30,9 @@ import (
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/export/metric/metrictest"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/ddsketch"
 	arrAgg "go.opentelemetry.io/otel/sdk/metric/aggregator/exact"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
 	lvAgg "go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/minmaxsumcount"
@@ -151,8 +153,8 @@ func TestMinMaxSumCountDatapoints(t *testing.T) {
 		assert.Nil(t, m.GetIntGauge())
 		assert.Equal(t, expected, m.GetIntHistogram().DataPoints)
 		assert.Nil(t, m.GetIntSum())
-		assert.Nil(t, m.GetDoubleGauge())
-		assert.Nil(t, m.GetDoubleHistogram())
+		assert.Nil(t, m.GetGauge())
+		assert.Nil(t, m.GetHistogram())
 	}
 }
 
@@ -195,8 +197,8 @@ func TestSumIntDataPoints(t *testing.T) {
 					},
 				},
 			}}}, m.GetIntSum())
-		assert.Nil(t, m.GetDoubleGauge())
-		assert.Nil(t, m.GetDoubleHistogram())
+		assert.Nil(t, m.GetGauge())
+		assert.Nil(t, m.GetHistogram())
 	}
 }
 
@@ -216,13 +218,13 @@ func TestSumFloatDataPoints(t *testing.T) {
 		assert.Nil(t, m.GetIntGauge())
 		assert.Nil(t, m.GetIntHistogram())
 		assert.Nil(t, m.GetIntSum())
-		assert.Nil(t, m.GetDoubleGauge())
-		assert.Nil(t, m.GetDoubleHistogram())
-		assert.Equal(t, &metricpb.DoubleSum{
+		assert.Nil(t, m.GetGauge())
+		assert.Nil(t, m.GetHistogram())
+		assert.Equal(t, &metricpb.Sum{
 			IsMonotonic:            false,
 			AggregationTemporality: otelDelta,
-			DataPoints: []*metricpb.DoubleDataPoint{{
-				Value:             1,
+			DataPoints: []*metricpb.NumberDataPoint{{
+				Value:             &metricpb.NumberDataPoint_AsDouble{AsDouble: 1},
 				StartTimeUnixNano: uint64(intervalStart.UnixNano()),
 				TimeUnixNano:      uint64(intervalEnd.UnixNano()),
 				Labels: []*commonpb.StringKeyValue{
@@ -231,7 +233,7 @@ func TestSumFloatDataPoints(t *testing.T) {
 						Value: "1",
 					},
 				},
-			}}}, m.GetDoubleSum())
+			}}}, m.GetSum())
 	}
 }
 
@@ -261,9 +263,9 @@ func TestLastValueIntDataPoints(t *testing.T) {
 		}}, m.GetIntGauge().DataPoints)
 		assert.Nil(t, m.GetIntHistogram())
 		assert.Nil(t, m.GetIntSum())
-		assert.Nil(t, m.GetDoubleGauge())
-		assert.Nil(t, m.GetDoubleHistogram())
-		assert.Nil(t, m.GetDoubleSum())
+		assert.Nil(t, m.GetGauge())
+		assert.Nil(t, m.GetHistogram())
+		assert.Nil(t, m.GetSum())
 	}
 }
 
@@ -293,9 +295,9 @@ func TestExactIntDataPoints(t *testing.T) {
 		}}, m.GetIntGauge().DataPoints)
 		assert.Nil(t, m.GetIntHistogram())
 		assert.Nil(t, m.GetIntSum())
-		assert.Nil(t, m.GetDoubleGauge())
-		assert.Nil(t, m.GetDoubleHistogram())
-		assert.Nil(t, m.GetDoubleSum())
+		assert.Nil(t, m.GetGauge())
+		assert.Nil(t, m.GetHistogram())
+		assert.Nil(t, m.GetSum())
 	}
 }
 
@@ -312,8 +314,8 @@ func TestExactFloatDataPoints(t *testing.T) {
 	require.NoError(t, err)
 
 	if m, err := gaugeArray(record, pts); assert.NoError(t, err) {
-		assert.Equal(t, []*metricpb.DoubleDataPoint{{
-			Value:             100,
+		assert.Equal(t, []*metricpb.NumberDataPoint{{
+			Value:             &metricpb.NumberDataPoint_AsDouble{AsDouble: 100},
 			StartTimeUnixNano: toNanos(intervalStart),
 			TimeUnixNano:      toNanos(intervalEnd),
 			Labels: []*commonpb.StringKeyValue{
@@ -322,15 +324,82 @@ func TestExactFloatDataPoints(t *testing.T) {
 					Value: "1",
 				},
 			},
-		}}, m.GetDoubleGauge().DataPoints)
+		}}, m.GetGauge().DataPoints)
 		assert.Nil(t, m.GetIntHistogram())
 		assert.Nil(t, m.GetIntSum())
 		assert.Nil(t, m.GetIntGauge())
-		assert.Nil(t, m.GetDoubleHistogram())
-		assert.Nil(t, m.GetDoubleSum())
+		assert.Nil(t, m.GetHistogram())
+		assert.Nil(t, m.GetSum())
 	}
 }
 
+func TestSummaryDataPoints(t *testing.T) {
+	desc := metric.NewDescriptor("", metric.ValueRecorderInstrumentKind, number.Int64Kind)
+	labels := attribute.NewSet(attribute.String("one", "1"))
+	s, ckpt := metrictest.Unslice2(ddsketch.New(2, &desc, ddsketch.WithQuantiles([]float64{0, 1})))
+	assert.NoError(t, s.Update(context.Background(), 1, &desc))
+	assert.NoError(t, s.Update(context.Background(), 10, &desc))
+	require.NoError(t, s.SynchronizedMove(ckpt, &desc))
+	record := export.NewRecord(&desc, &labels, nil, ckpt.Aggregation(), intervalStart, intervalEnd)
+
+	m, err := Record(export.CumulativeExportKindSelector(), record)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []*metricpb.SummaryDataPoint{{
+			Count:             2,
+			Sum:               11,
+			StartTimeUnixNano: toNanos(intervalStart),
+			TimeUnixNano:      toNanos(intervalEnd),
+			Labels: []*commonpb.StringKeyValue{
+				{
+					Key:   "one",
+					Value: "1",
+				},
+			},
+			QuantileValues: []*metricpb.SummaryDataPoint_ValueAtQuantile{
+				{Quantile: 0, Value: 1},
+				{Quantile: 1, Value: 10},
+			},
+		}}, m.GetSummary().DataPoints)
+		assert.Nil(t, m.GetIntHistogram())
+		assert.Nil(t, m.GetHistogram())
+	}
+
+	// An empty Summary has no quantile values.
+	require.NoError(t, s.SynchronizedMove(ckpt, &desc))
+	record = export.NewRecord(&desc, &labels, nil, ckpt.Aggregation(), intervalStart, intervalEnd)
+	m, err = Record(export.CumulativeExportKindSelector(), record)
+	if assert.NoError(t, err) {
+		require.Len(t, m.GetSummary().DataPoints, 1)
+		assert.Equal(t, uint64(0), m.GetSummary().DataPoints[0].Count)
+		assert.Empty(t, m.GetSummary().DataPoints[0].QuantileValues)
+	}
+}
+
+func TestExponentialHistogramDataPoints(t *testing.T) {
+	desc := metric.NewDescriptor("", metric.ValueRecorderInstrumentKind, number.Float64Kind)
+	labels := attribute.NewSet()
+	h, ckpt := metrictest.Unslice2(exponential.New(2, &desc, exponential.WithMaxScale(0)))
+	for _, v := range []float64{-4, 0, 1, 2, 8} {
+		assert.NoError(t, h.Update(context.Background(), number.NewFloat64Number(v), &desc))
+	}
+	require.NoError(t, h.SynchronizedMove(ckpt, &desc))
+	record := export.NewRecord(&desc, &labels, nil, ckpt.Aggregation(), intervalStart, intervalEnd)
+
+	m, err := Record(export.CumulativeExportKindSelector(), record)
+	require.NoError(t, err)
+	assert.Equal(t, otelCumulative, m.GetHistogram().AggregationTemporality)
+	assert.Equal(t, []*metricpb.HistogramDataPoint{{
+		Count:             5,
+		Sum:               7,
+		StartTimeUnixNano: toNanos(intervalStart),
+		TimeUnixNano:      toNanos(intervalEnd),
+		// The buckets with index i hold (-2^(i+1), -2^i] and
+		// (2^i, 2^(i+1)] at scale 0.
+		ExplicitBounds: []float64{-2, 0, 1, 2, 4},
+		BucketCounts:   []uint64{1, 1, 1, 1, 0, 1},
+	}}, m.GetHistogram().DataPoints)
+}
+
 func TestSumErrUnknownValueType(t *testing.T) {
 	desc := metric.NewDescriptor("", metric.ValueRecorderInstrumentKind, number.Kind(-1))
 	labels := attribute.NewSet()
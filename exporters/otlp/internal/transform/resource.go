@@ -20,7 +20,9 @@ import (
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
-// Resource transforms a Resource into an OTLP Resource.
+// Resource transforms a Resource into an OTLP Resource. The schema URL of
+// r is not part of the OTLP Resource, it is set on the ResourceSpans or
+// ResourceMetrics of r.
 func Resource(r *resource.Resource) *resourcepb.Resource {
 	if r == nil {
 		return nil
@@ -15,12 +15,14 @@
 package transform
 
 import (
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 
-	export "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -28,17 +30,24 @@ const (
 	maxMessageEventsPerSpan = 128
 )
 
-// SpanData transforms a slice of SpanSnapshot into a slice of OTLP
+// SpanData transforms a slice of ReadOnlySpan into a slice of OTLP
 // ResourceSpans.
-func SpanData(sdl []*export.SpanSnapshot) []*tracepb.ResourceSpans {
+func SpanData(sdl []tracesdk.ReadOnlySpan) []*tracepb.ResourceSpans {
+	return SpanDataWithCache(sdl, nil)
+}
+
+// SpanDataWithCache transforms a slice of ReadOnlySpan into a slice of
+// OTLP ResourceSpans. The OTLP Resource and InstrumentationLibrary are
+// memoized in cache, which may be nil to not cache them.
+func SpanDataWithCache(sdl []tracesdk.ReadOnlySpan, cache tracesdk.TranslationCache) []*tracepb.ResourceSpans {
 	if len(sdl) == 0 {
 		return nil
 	}
 
-	rsm := make(map[attribute.Distinct]*tracepb.ResourceSpans)
+	rsm := make(map[resource.Key]*tracepb.ResourceSpans)
 
 	type ilsKey struct {
-		r  attribute.Distinct
+		r  resource.Key
 		il instrumentation.Library
 	}
 	ilsm := make(map[ilsKey]*tracepb.InstrumentationLibrarySpans)
@@ -49,17 +58,18 @@ func SpanData(sdl []*export.SpanSnapshot) []*tracepb.ResourceSpans {
 			continue
 		}
 
-		rKey := sd.Resource.Equivalent()
+		rKey := sd.Resource().Key()
 		iKey := ilsKey{
 			r:  rKey,
-			il: sd.InstrumentationLibrary,
+			il: sd.InstrumentationLibrary(),
 		}
 		ils, iOk := ilsm[iKey]
 		if !iOk {
 			// Either the resource or instrumentation library were unknown.
 			ils = &tracepb.InstrumentationLibrarySpans{
-				InstrumentationLibrary: instrumentationLibrary(sd.InstrumentationLibrary),
+				InstrumentationLibrary: cachedInstrumentationLibrary(cache, sd.InstrumentationLibrary()),
 				Spans:                  []*tracepb.Span{},
+				SchemaUrl:              sd.InstrumentationLibrary().SchemaURL,
 			}
 		}
 		ils.Spans = append(ils.Spans, span(sd))
@@ -70,8 +80,9 @@ func SpanData(sdl []*export.SpanSnapshot) []*tracepb.ResourceSpans {
 			resources++
 			// The resource was unknown.
 			rs = &tracepb.ResourceSpans{
-				Resource:                    Resource(sd.Resource),
+				Resource:                    cachedResource(cache, sd.Resource()),
 				InstrumentationLibrarySpans: []*tracepb.InstrumentationLibrarySpans{ils},
+				SchemaUrl:                   sd.Resource().SchemaURL(),
 			}
 			rsm[rKey] = rs
 			continue
@@ -95,33 +106,55 @@ func SpanData(sdl []*export.SpanSnapshot) []*tracepb.ResourceSpans {
 	return rss
 }
 
+// cachedResource returns the OTLP Resource of r, memoized in cache if it is
+// not nil.
+func cachedResource(cache tracesdk.TranslationCache, r *resource.Resource) *resourcepb.Resource {
+	if cache == nil {
+		return Resource(r)
+	}
+	return cache.Resource(r, func(r *resource.Resource) interface{} {
+		return Resource(r)
+	}).(*resourcepb.Resource)
+}
+
+// cachedInstrumentationLibrary returns the OTLP InstrumentationLibrary of
+// il, memoized in cache if it is not nil.
+func cachedInstrumentationLibrary(cache tracesdk.TranslationCache, il instrumentation.Library) *commonpb.InstrumentationLibrary {
+	if cache == nil {
+		return instrumentationLibrary(il)
+	}
+	return cache.InstrumentationLibrary(il, func(il instrumentation.Library) interface{} {
+		return instrumentationLibrary(il)
+	}).(*commonpb.InstrumentationLibrary)
+}
+
 // span transforms a Span into an OTLP span.
-func span(sd *export.SpanSnapshot) *tracepb.Span {
+func span(sd tracesdk.ReadOnlySpan) *tracepb.Span {
 	if sd == nil {
 		return nil
 	}
 
-	tid := sd.SpanContext.TraceID()
-	sid := sd.SpanContext.SpanID()
+	tid := sd.SpanContext().TraceID()
+	sid := sd.SpanContext().SpanID()
 
 	s := &tracepb.Span{
 		TraceId:                tid[:],
 		SpanId:                 sid[:],
-		TraceState:             sd.SpanContext.TraceState().String(),
-		Status:                 status(sd.StatusCode, sd.StatusMessage),
-		StartTimeUnixNano:      uint64(sd.StartTime.UnixNano()),
-		EndTimeUnixNano:        uint64(sd.EndTime.UnixNano()),
-		Links:                  links(sd.Links),
-		Kind:                   spanKind(sd.SpanKind),
-		Name:                   sd.Name,
-		Attributes:             Attributes(sd.Attributes),
-		Events:                 spanEvents(sd.MessageEvents),
-		DroppedAttributesCount: uint32(sd.DroppedAttributeCount),
-		DroppedEventsCount:     uint32(sd.DroppedMessageEventCount),
-		DroppedLinksCount:      uint32(sd.DroppedLinkCount),
-	}
-
-	if psid := sd.Parent.SpanID(); psid.IsValid() {
+		TraceState:             sd.SpanContext().TraceState().String(),
+		Status:                 status(sd.StatusCode(), sd.StatusMessage()),
+		StartTimeUnixNano:      uint64(sd.StartTime().UnixNano()),
+		EndTimeUnixNano:        uint64(sd.EndTime().UnixNano()),
+		Links:                  links(sd.Links()),
+		Kind:                   spanKind(sd.SpanKind()),
+		Name:                   sd.Name(),
+		Attributes:             Attributes(sd.Attributes()),
+		Events:                 spanEvents(sd.Events()),
+		DroppedAttributesCount: uint32(sd.DroppedAttributes()),
+		DroppedEventsCount:     uint32(sd.DroppedEvents()),
+		DroppedLinksCount:      uint32(sd.DroppedLinks()),
+	}
+
+	if psid := sd.Parent().SpanID(); psid.IsValid() {
 		s.ParentSpanId = psid[:]
 	}
 
@@ -29,9 +29,10 @@ import (
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 
 	"go.opentelemetry.io/otel/codes"
-	export "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestSpanKind(t *testing.T) {
@@ -199,7 +200,7 @@ func TestSpanData(t *testing.T) {
 	startTime := time.Unix(1585674086, 1234)
 	endTime := startTime.Add(10 * time.Second)
 	traceState, _ := trace.TraceStateFromKeyValues(attribute.String("key1", "val1"), attribute.String("key2", "val2"))
-	spanData := &export.SpanSnapshot{
+	spanData := tracetest.SpanStub{
 		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    trace.TraceID{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F},
 			SpanID:     trace.SpanID{0xFF, 0xFE, 0xFD, 0xFC, 0xFB, 0xFA, 0xF9, 0xF8},
@@ -257,12 +258,13 @@ func TestSpanData(t *testing.T) {
 		DroppedAttributeCount:    1,
 		DroppedMessageEventCount: 2,
 		DroppedLinkCount:         3,
-		Resource:                 resource.NewWithAttributes(attribute.String("rk1", "rv1"), attribute.Int64("rk2", 5)),
+		Resource:                 resource.NewWithSchemaURL("https://opentelemetry.io/schemas/1.4.0", attribute.String("rk1", "rv1"), attribute.Int64("rk2", 5)),
 		InstrumentationLibrary: instrumentation.Library{
-			Name:    "go.opentelemetry.io/test/otel",
-			Version: "v0.0.1",
+			Name:      "go.opentelemetry.io/test/otel",
+			Version:   "v0.0.1",
+			SchemaURL: "https://opentelemetry.io/schemas/1.3.0",
 		},
-	}
+	}.Snapshot()
 
 	// Not checking resource as the underlying map of our Resource makes
 	// ordering impossible to guarantee on the output. The Resource
@@ -272,26 +274,28 @@ func TestSpanData(t *testing.T) {
 		SpanId:                 []byte{0xFF, 0xFE, 0xFD, 0xFC, 0xFB, 0xFA, 0xF9, 0xF8},
 		ParentSpanId:           []byte{0xEF, 0xEE, 0xED, 0xEC, 0xEB, 0xEA, 0xE9, 0xE8},
 		TraceState:             "key1=val1,key2=val2",
-		Name:                   spanData.Name,
+		Name:                   spanData.Name(),
 		Kind:                   tracepb.Span_SPAN_KIND_SERVER,
 		StartTimeUnixNano:      uint64(startTime.UnixNano()),
 		EndTimeUnixNano:        uint64(endTime.UnixNano()),
-		Status:                 status(spanData.StatusCode, spanData.StatusMessage),
-		Events:                 spanEvents(spanData.MessageEvents),
-		Links:                  links(spanData.Links),
-		Attributes:             Attributes(spanData.Attributes),
+		Status:                 status(spanData.StatusCode(), spanData.StatusMessage()),
+		Events:                 spanEvents(spanData.Events()),
+		Links:                  links(spanData.Links()),
+		Attributes:             Attributes(spanData.Attributes()),
 		DroppedAttributesCount: 1,
 		DroppedEventsCount:     2,
 		DroppedLinksCount:      3,
 	}
 
-	got := SpanData([]*export.SpanSnapshot{spanData})
+	got := SpanData([]tracesdk.ReadOnlySpan{spanData})
 	require.Len(t, got, 1)
 
-	assert.Equal(t, got[0].GetResource(), Resource(spanData.Resource))
+	assert.Equal(t, got[0].GetResource(), Resource(spanData.Resource()))
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.4.0", got[0].GetSchemaUrl())
 	ilSpans := got[0].GetInstrumentationLibrarySpans()
 	require.Len(t, ilSpans, 1)
-	assert.Equal(t, ilSpans[0].GetInstrumentationLibrary(), instrumentationLibrary(spanData.InstrumentationLibrary))
+	assert.Equal(t, ilSpans[0].GetInstrumentationLibrary(), instrumentationLibrary(spanData.InstrumentationLibrary()))
+	assert.Equal(t, "https://opentelemetry.io/schemas/1.3.0", ilSpans[0].GetSchemaUrl())
 	require.Len(t, ilSpans[0].Spans, 1)
 	actualSpan := ilSpans[0].Spans[0]
 
@@ -302,7 +306,7 @@ func TestSpanData(t *testing.T) {
 
 // Empty parent span ID should be treated as root span.
 func TestRootSpanData(t *testing.T) {
-	sd := SpanData([]*export.SpanSnapshot{{}})
+	sd := SpanData(tracetest.SpanStubs{{}}.Snapshots())
 	require.Len(t, sd, 1)
 	rs := sd[0]
 	got := rs.GetInstrumentationLibrarySpans()[0].GetSpans()[0].GetParentSpanId()
@@ -312,5 +316,60 @@ func TestRootSpanData(t *testing.T) {
 }
 
 func TestSpanDataNilResource(t *testing.T) {
-	assert.NotPanics(t, func() { SpanData([]*export.SpanSnapshot{{}}) })
+	assert.NotPanics(t, func() { SpanData(tracetest.SpanStubs{{}}.Snapshots()) })
+}
+
+func cacheTestSpans(n int) []tracesdk.ReadOnlySpan {
+	attrs := make([]attribute.KeyValue, 0, 20)
+	for i := 0; i < cap(attrs); i++ {
+		attrs = append(attrs, attribute.String("key"+strconv.Itoa(i), "value"))
+	}
+	stubs := make(tracetest.SpanStubs, 0, n)
+	for i := 0; i < n; i++ {
+		stubs = append(stubs, tracetest.SpanStub{
+			Name:     "span" + strconv.Itoa(i),
+			Resource: resource.NewWithAttributes(attrs...),
+			InstrumentationLibrary: instrumentation.Library{
+				Name:    "lib",
+				Version: "v1",
+			},
+		})
+	}
+	return stubs.Snapshots()
+}
+
+func TestSpanDataWithCache(t *testing.T) {
+	cache := tracesdk.NewTranslationCache(0)
+	spans := cacheTestSpans(3)
+
+	first := SpanDataWithCache(spans, cache)
+	second := SpanDataWithCache(spans, cache)
+	require.Len(t, first, 1)
+	require.Len(t, second, 1)
+	assert.True(t, proto.Equal(SpanData(spans)[0], first[0]))
+
+	// The translated resource and instrumentation library are shared by
+	// both batches.
+	assert.Same(t, first[0].Resource, second[0].Resource)
+	assert.Same(t,
+		first[0].InstrumentationLibrarySpans[0].InstrumentationLibrary,
+		second[0].InstrumentationLibrarySpans[0].InstrumentationLibrary,
+	)
+}
+
+func BenchmarkSpanData(b *testing.B) {
+	spans := cacheTestSpans(10)
+	b.Run("NoCache", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			SpanData(spans)
+		}
+	})
+	b.Run("Cache", func(b *testing.B) {
+		cache := tracesdk.NewTranslationCache(0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			SpanDataWithCache(spans, cache)
+		}
+	})
 }
@@ -15,7 +15,9 @@
 package otlp // import "go.opentelemetry.io/otel/exporters/otlp"
 
 import (
+	"go.opentelemetry.io/otel/metric"
 	metricsdk "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -32,6 +34,8 @@ type ExporterOption func(*config)
 
 type config struct {
 	exportKindSelector metricsdk.ExportKindSelector
+	tracerProvider     trace.TracerProvider
+	meterProvider      metric.MeterProvider
 }
 
 // WithMetricExportKindSelector defines the ExportKindSelector used
@@ -43,3 +47,34 @@ func WithMetricExportKindSelector(selector metricsdk.ExportKindSelector) Exporte
 		cfg.exportKindSelector = selector
 	}
 }
+
+// WithExportTracerProvider configures the Exporter to create a span, using
+// a Tracer from tp, around every export of spans or metrics. The span
+// context is sent to the collector using the global TextMapPropagator,
+// allowing the telemetry pipeline itself to be traced.
+//
+// The TracerProvider should export to a different backend than this
+// Exporter, otherwise every export creates a span that will be exported
+// by a subsequent export.
+func WithExportTracerProvider(tp trace.TracerProvider) ExporterOption {
+	return func(cfg *config) {
+		cfg.tracerProvider = tp
+	}
+}
+
+// WithExportMeterProvider configures the Exporter to record metrics about
+// its exports using a Meter from mp: the number of exports, of exports
+// waiting for the collector, of exported spans, and of retries, and the
+// duration of exports. The metrics are recorded by the
+// "go.opentelemetry.io/otel/exporters/otlp" instrumentation library.
+//
+// Retries are only counted for ProtocolDrivers calling RecordExportRetry,
+// e.g. the otlphttp driver. The retries the gRPC library performs for the
+// otlpgrpc driver are not visible to the Exporter. The number of spans
+// queued by a batch span processor is reported by the processor, see
+// "go.opentelemetry.io/otel/sdk/trace".WithBatchExportCallback.
+func WithExportMeterProvider(mp metric.MeterProvider) ExporterOption {
+	return func(cfg *config) {
+		cfg.meterProvider = mp
+	}
+}
@@ -19,10 +19,44 @@ import (
 	"errors"
 	"sync"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/tracecontext"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk"
 	metricsdk "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
-	tracesdk "go.opentelemetry.io/otel/sdk/export/trace"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "go.opentelemetry.io/otel/exporters/otlp"
+
+// ExponentialHistogramCapability is the name of the otel.Capability
+// registered by this package: the Exporter exports the exponential
+// histogram aggregation, converted to a histogram with explicit
+// boundaries.
+const ExponentialHistogramCapability = instrumentationName + "#exponential_histogram"
+
+func init() {
+	otel.RegisterCapability(otel.Capability{
+		Name:    ExponentialHistogramCapability,
+		Version: sdk.Version(),
+		Enabled: true,
+	})
+}
+
+// ExportSpanCountKey is the attribute key of the number of spans sent in an
+// export when the Exporter is configured with WithExportTracerProvider.
+const ExportSpanCountKey = attribute.Key("otlp.export.span_count")
+
+// ExportBatchIDKey and ExportFlushReasonKey are the attribute keys of the
+// ID and flush reason of the exported batch, when known, of spans sent in an
+// export when the Exporter is configured with WithExportTracerProvider.
+const (
+	ExportBatchIDKey     = attribute.Key("otlp.export.batch_id")
+	ExportFlushReasonKey = attribute.Key("otlp.export.flush_reason")
 )
 
 // Exporter is an OpenTelemetry exporter. It exports both traces and metrics
@@ -31,6 +65,8 @@ import (
 type Exporter struct {
 	cfg    config
 	driver ProtocolDriver
+	tracer trace.Tracer
+	meter  *exporterMetrics
 
 	mu      sync.RWMutex
 	started bool
@@ -62,10 +98,17 @@ func NewUnstartedExporter(driver ProtocolDriver, opts ...ExporterOption) *Export
 	for _, opt := range opts {
 		opt(&cfg)
 	}
-	return &Exporter{
+	exp := &Exporter{
 		cfg:    cfg,
 		driver: driver,
 	}
+	if cfg.tracerProvider != nil {
+		exp.tracer = cfg.tracerProvider.Tracer(instrumentationName)
+	}
+	if cfg.meterProvider != nil {
+		exp.meter = newExporterMetrics(cfg.meterProvider)
+	}
+	return exp
 }
 
 var (
@@ -115,7 +158,11 @@ func (e *Exporter) Shutdown(ctx context.Context) error {
 // interface. It transforms and batches metric Records into OTLP Metrics and
 // transmits them to the configured collector.
 func (e *Exporter) Export(parent context.Context, cps metricsdk.CheckpointSet) error {
-	return e.driver.ExportMetrics(parent, cps, e.cfg.exportKindSelector)
+	return e.traceExport(parent, "otlp.ExportMetrics", func(ctx context.Context) error {
+		return e.meterExport(ctx, metricsSignal, -1, func(ctx context.Context) error {
+			return e.driver.ExportMetrics(ctx, cps, e.cfg.exportKindSelector)
+		})
+	})
 }
 
 // ExportKindFor reports back to the OpenTelemetry SDK sending this Exporter
@@ -125,9 +172,51 @@ func (e *Exporter) ExportKindFor(desc *metric.Descriptor, kind aggregation.Kind)
 }
 
 // ExportSpans implements the
-// "go.opentelemetry.io/otel/sdk/export/trace".SpanExporter interface. It
-// transforms and batches trace SpanSnapshots into OTLP Trace and transmits them
+// "go.opentelemetry.io/otel/sdk/trace".SpanExporter interface. It
+// transforms and batches trace spans into OTLP Trace and transmits them
 // to the configured collector.
-func (e *Exporter) ExportSpans(ctx context.Context, ss []*tracesdk.SpanSnapshot) error {
-	return e.driver.ExportTraces(ctx, ss)
+func (e *Exporter) ExportSpans(ctx context.Context, ss []tracesdk.ReadOnlySpan) error {
+	attrs := []attribute.KeyValue{ExportSpanCountKey.Int(len(ss))}
+	if info, ok := tracesdk.ExportBatchInfoFromContext(ctx); ok {
+		attrs = append(attrs,
+			ExportBatchIDKey.Int64(int64(info.ID)),
+			ExportFlushReasonKey.String(info.Reason.String()),
+		)
+	}
+	return e.traceExport(ctx, "otlp.ExportSpans", func(ctx context.Context) error {
+		return e.meterExport(ctx, tracesSignal, len(ss), func(ctx context.Context) error {
+			return e.driver.ExportTraces(ctx, ss)
+		})
+	}, attrs...)
+}
+
+// meterExport calls export with ctx, recording the export if the Exporter
+// was configured with a MeterProvider.
+func (e *Exporter) meterExport(ctx context.Context, signal attribute.KeyValue, spanCount int, export func(context.Context) error) error {
+	if e.meter == nil {
+		return export(ctx)
+	}
+	return e.meter.export(ctx, signal, spanCount, export)
+}
+
+// traceExport calls export with ctx, or if the Exporter was configured with
+// a TracerProvider, a child context of ctx containing a span named name
+// that describes the export, whose trace context is sent to the receiver.
+func (e *Exporter) traceExport(ctx context.Context, name string, export func(context.Context) error, attrs ...attribute.KeyValue) error {
+	if e.tracer == nil {
+		return export(ctx)
+	}
+	ctx, span := e.tracer.Start(
+		ctx,
+		name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	defer span.End()
+	err := export(tracecontext.WithPropagation(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
 }
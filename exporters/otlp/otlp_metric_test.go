@@ -80,8 +80,10 @@ var (
 	baseKeyValues = []attribute.KeyValue{attribute.String("host", "test.com")}
 	cpuKey        = attribute.Key("CPU")
 
+	testSchemaURL = "https://opentelemetry.io/schemas/1.4.0"
+
 	testInstA = resource.NewWithAttributes(attribute.String("instance", "tester-a"))
-	testInstB = resource.NewWithAttributes(attribute.String("instance", "tester-b"))
+	testInstB = resource.NewWithSchemaURL(testSchemaURL, attribute.String("instance", "tester-b"))
 
 	testHistogramBoundaries = []float64{2.0, 4.0, 8.0}
 
@@ -331,13 +333,13 @@ func TestCountFloat64MetricGroupingExport(t *testing.T) {
 						Metrics: []*metricpb.Metric{
 							{
 								Name: "float64-count",
-								Data: &metricpb.Metric_DoubleSum{
-									DoubleSum: &metricpb.DoubleSum{
+								Data: &metricpb.Metric_Sum{
+									Sum: &metricpb.Sum{
 										IsMonotonic:            true,
 										AggregationTemporality: metricpb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
-										DataPoints: []*metricpb.DoubleDataPoint{
+										DataPoints: []*metricpb.NumberDataPoint{
 											{
-												Value: 11,
+												Value: &metricpb.NumberDataPoint_AsDouble{AsDouble: 11},
 												Labels: []*commonpb.StringKeyValue{
 													{
 														Key:   "CPU",
@@ -352,7 +354,7 @@ func TestCountFloat64MetricGroupingExport(t *testing.T) {
 												TimeUnixNano:      pointTime(),
 											},
 											{
-												Value: 11,
+												Value: &metricpb.NumberDataPoint_AsDouble{AsDouble: 11},
 												Labels: []*commonpb.StringKeyValue{
 													{
 														Key:   "CPU",
@@ -456,7 +458,8 @@ func TestResourceMetricGroupingExport(t *testing.T) {
 				},
 			},
 			{
-				Resource: testerBResource,
+				Resource:  testerBResource,
+				SchemaUrl: testSchemaURL,
 				InstrumentationLibraryMetrics: []*metricpb.InstrumentationLibraryMetrics{
 					{
 						Metrics: []*metricpb.Metric{
@@ -493,6 +496,7 @@ func TestResourceInstLibMetricGroupingExport(t *testing.T) {
 	countingLib2 := []metric.InstrumentOption{
 		metric.WithInstrumentationName("counting-lib"),
 		metric.WithInstrumentationVersion("v2"),
+		metric.WithSchemaURL(testSchemaURL),
 	}
 	summingLib := []metric.InstrumentOption{
 		metric.WithInstrumentationName("summing-lib"),
@@ -596,6 +600,7 @@ func TestResourceInstLibMetricGroupingExport(t *testing.T) {
 							Name:    "counting-lib",
 							Version: "v2",
 						},
+						SchemaUrl: testSchemaURL,
 						Metrics: []*metricpb.Metric{
 							{
 								Name: "int64-count",
@@ -643,7 +648,8 @@ func TestResourceInstLibMetricGroupingExport(t *testing.T) {
 				},
 			},
 			{
-				Resource: testerBResource,
+				Resource:  testerBResource,
+				SchemaUrl: testSchemaURL,
 				InstrumentationLibraryMetrics: []*metricpb.InstrumentationLibraryMetrics{
 					{
 						InstrumentationLibrary: &commonpb.InstrumentationLibrary{
@@ -799,14 +805,16 @@ func runMetricExportTests(t *testing.T, opts []otlp.ExporterOption, rs []record,
 	// that validate the metric elements match for all expected pairs. Finally,
 	// make we saw all expected pairs.
 	type key struct {
-		resource, instrumentationLibrary string
+		resource, resourceSchemaURL, instrumentationLibrary, instrumentationLibrarySchemaURL string
 	}
 	got := map[key][]*metricpb.Metric{}
 	for _, rm := range driver.rm {
 		for _, ilm := range rm.InstrumentationLibraryMetrics {
 			k := key{
-				resource:               rm.GetResource().String(),
-				instrumentationLibrary: ilm.GetInstrumentationLibrary().String(),
+				resource:                        rm.GetResource().String(),
+				resourceSchemaURL:               rm.GetSchemaUrl(),
+				instrumentationLibrary:          ilm.GetInstrumentationLibrary().String(),
+				instrumentationLibrarySchemaURL: ilm.GetSchemaUrl(),
 			}
 			got[k] = ilm.GetMetrics()
 		}
@@ -815,8 +823,10 @@ func runMetricExportTests(t *testing.T, opts []otlp.ExporterOption, rs []record,
 	for _, rm := range expected {
 		for _, ilm := range rm.InstrumentationLibraryMetrics {
 			k := key{
-				resource:               rm.GetResource().String(),
-				instrumentationLibrary: ilm.GetInstrumentationLibrary().String(),
+				resource:                        rm.GetResource().String(),
+				resourceSchemaURL:               rm.GetSchemaUrl(),
+				instrumentationLibrary:          ilm.GetInstrumentationLibrary().String(),
+				instrumentationLibrarySchemaURL: ilm.GetSchemaUrl(),
 			}
 			seen[k] = struct{}{}
 			g, ok := got[k]
@@ -850,24 +860,24 @@ func runMetricExportTests(t *testing.T, opts []otlp.ExporterOption, rs []record,
 						g[i].GetIntSum().GetIsMonotonic(),
 					)
 					assert.ElementsMatch(t, expected.GetIntSum().DataPoints, g[i].GetIntSum().DataPoints)
-				case *metricpb.Metric_DoubleGauge:
-					assert.ElementsMatch(t, expected.GetDoubleGauge().DataPoints, g[i].GetDoubleGauge().DataPoints)
-				case *metricpb.Metric_DoubleHistogram:
+				case *metricpb.Metric_Gauge:
+					assert.ElementsMatch(t, expected.GetGauge().DataPoints, g[i].GetGauge().DataPoints)
+				case *metricpb.Metric_Histogram:
 					assert.Equal(t,
-						expected.GetDoubleHistogram().GetAggregationTemporality(),
-						g[i].GetDoubleHistogram().GetAggregationTemporality(),
+						expected.GetHistogram().GetAggregationTemporality(),
+						g[i].GetHistogram().GetAggregationTemporality(),
 					)
-					assert.ElementsMatch(t, expected.GetDoubleHistogram().DataPoints, g[i].GetDoubleHistogram().DataPoints)
-				case *metricpb.Metric_DoubleSum:
+					assert.ElementsMatch(t, expected.GetHistogram().DataPoints, g[i].GetHistogram().DataPoints)
+				case *metricpb.Metric_Sum:
 					assert.Equal(t,
-						expected.GetDoubleSum().GetAggregationTemporality(),
-						g[i].GetDoubleSum().GetAggregationTemporality(),
+						expected.GetSum().GetAggregationTemporality(),
+						g[i].GetSum().GetAggregationTemporality(),
 					)
 					assert.Equal(t,
-						expected.GetDoubleSum().GetIsMonotonic(),
-						g[i].GetDoubleSum().GetIsMonotonic(),
+						expected.GetSum().GetIsMonotonic(),
+						g[i].GetSum().GetIsMonotonic(),
 					)
-					assert.ElementsMatch(t, expected.GetDoubleSum().DataPoints, g[i].GetDoubleSum().DataPoints)
+					assert.ElementsMatch(t, expected.GetSum().DataPoints, g[i].GetSum().DataPoints)
 				default:
 					assert.Failf(t, "unknown data type", g[i].Name)
 				}
@@ -28,9 +28,10 @@ import (
 	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 
-	tracesdk "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestExportSpans(t *testing.T) {
@@ -41,19 +42,19 @@ func TestExportSpans(t *testing.T) {
 	endTime := startTime.Add(10 * time.Second)
 
 	for _, test := range []struct {
-		sd   []*tracesdk.SpanSnapshot
+		sd   []tracesdk.ReadOnlySpan
 		want []*tracepb.ResourceSpans
 	}{
 		{
-			[]*tracesdk.SpanSnapshot(nil),
+			[]tracesdk.ReadOnlySpan(nil),
 			[]*tracepb.ResourceSpans(nil),
 		},
 		{
-			[]*tracesdk.SpanSnapshot{},
+			tracetest.SpanStubs{}.Snapshots(),
 			[]*tracepb.ResourceSpans(nil),
 		},
 		{
-			[]*tracesdk.SpanSnapshot{
+			tracetest.SpanStubs{
 				{
 					SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
 						TraceID:    trace.TraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}),
@@ -147,7 +148,7 @@ func TestExportSpans(t *testing.T) {
 						Version: "v1.1.0",
 					},
 				},
-			},
+			}.Snapshots(),
 			[]*tracepb.ResourceSpans{
 				{
 					Resource: &resourcepb.Resource{
@@ -24,18 +24,25 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/otlp"
 	"go.opentelemetry.io/otel/exporters/otlp/internal/transform"
+	"go.opentelemetry.io/otel/oteltest"
+	"go.opentelemetry.io/otel/sdk"
 	metricsdk "go.opentelemetry.io/otel/sdk/export/metric"
-	tracesdk "go.opentelemetry.io/otel/sdk/export/trace"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
 	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
 )
 
-func stubSpanSnapshot(count int) []*tracesdk.SpanSnapshot {
-	spans := make([]*tracesdk.SpanSnapshot, 0, count)
+func stubSpans(count int) []tracesdk.ReadOnlySpan {
+	spans := make([]tracesdk.ReadOnlySpan, 0, count)
 	for i := 0; i < count; i++ {
-		spans = append(spans, new(tracesdk.SpanSnapshot))
+		spans = append(spans, tracetest.SpanStub{}.Snapshot())
 	}
 	return spans
 }
@@ -70,7 +77,7 @@ type stubProtocolDriver struct {
 	injectedStopError  error
 
 	rm []metricsdk.Record
-	rs []tracesdk.SpanSnapshot
+	rs tracetest.SpanStubs
 }
 
 var _ otlp.ProtocolDriver = (*stubProtocolDriver)(nil)
@@ -103,13 +110,13 @@ func (m *stubProtocolDriver) ExportMetrics(parent context.Context, cps metricsdk
 	})
 }
 
-func (m *stubProtocolDriver) ExportTraces(ctx context.Context, ss []*tracesdk.SpanSnapshot) error {
+func (m *stubProtocolDriver) ExportTraces(ctx context.Context, ss []tracesdk.ReadOnlySpan) error {
 	m.tracesExported++
 	for _, rs := range ss {
 		if rs == nil {
 			continue
 		}
-		m.rs = append(m.rs, *rs)
+		m.rs = append(m.rs, tracetest.SpanStubFromReadOnlySpan(rs))
 	}
 	return nil
 }
@@ -143,7 +150,7 @@ func (m *stubTransformingProtocolDriver) ExportMetrics(parent context.Context, c
 	return nil
 }
 
-func (m *stubTransformingProtocolDriver) ExportTraces(ctx context.Context, ss []*tracesdk.SpanSnapshot) error {
+func (m *stubTransformingProtocolDriver) ExportTraces(ctx context.Context, ss []tracesdk.ReadOnlySpan) error {
 	for _, rs := range transform.SpanData(ss) {
 		if rs == nil {
 			continue
@@ -165,6 +172,13 @@ func newExporter(t *testing.T, opts ...otlp.ExporterOption) (*otlp.Exporter, *st
 	return exp, driver
 }
 
+func TestExponentialHistogramCapability(t *testing.T) {
+	c, ok := otel.LookupCapability(otlp.ExponentialHistogramCapability)
+	require.True(t, ok)
+	assert.True(t, c.Enabled)
+	assert.Equal(t, sdk.Version(), c.Version)
+}
+
 func TestExporterShutdownHonorsTimeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
 	defer cancel()
@@ -266,7 +280,7 @@ func TestSplitDriver(t *testing.T) {
 	recordCount := 5
 	spanCount := 7
 	assert.NoError(t, driver.ExportMetrics(ctx, stubCheckpointSet{recordCount}, metricsdk.StatelessExportKindSelector()))
-	assert.NoError(t, driver.ExportTraces(ctx, stubSpanSnapshot(spanCount)))
+	assert.NoError(t, driver.ExportTraces(ctx, stubSpans(spanCount)))
 	assert.Len(t, driverTraces.rm, 0)
 	assert.Len(t, driverTraces.rs, spanCount)
 	assert.Len(t, driverMetrics.rm, recordCount)
@@ -338,3 +352,141 @@ func TestSplitDriverFail(t *testing.T) {
 		}
 	}
 }
+
+type contextRecordingDriver struct {
+	stubProtocolDriver
+
+	ctxs []context.Context
+	err  error
+}
+
+func (d *contextRecordingDriver) ExportMetrics(ctx context.Context, cps metricsdk.CheckpointSet, selector metricsdk.ExportKindSelector) error {
+	d.ctxs = append(d.ctxs, ctx)
+	return d.err
+}
+
+func (d *contextRecordingDriver) ExportTraces(ctx context.Context, ss []tracesdk.ReadOnlySpan) error {
+	d.ctxs = append(d.ctxs, ctx)
+	return d.err
+}
+
+func TestExporterExportTracing(t *testing.T) {
+	recorder := tracetest.NewInMemoryExporter()
+	tp := tracesdk.NewTracerProvider(tracesdk.WithSyncer(recorder))
+	driver := &contextRecordingDriver{}
+	e := otlp.NewUnstartedExporter(driver, otlp.WithExportTracerProvider(tp))
+	ctx := context.Background()
+
+	require.NoError(t, e.ExportSpans(ctx, stubSpans(3)))
+	require.NoError(t, e.Export(ctx, stubCheckpointSet{}))
+
+	spans := recorder.GetSpans()
+	require.Len(t, spans, 2)
+	require.Len(t, driver.ctxs, 2)
+
+	assert.Equal(t, "otlp.ExportSpans", spans[0].Name)
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+	assert.Contains(t, spans[0].Attributes, otlp.ExportSpanCountKey.Int(3))
+	assert.Equal(t, spans[0].SpanContext, trace.SpanContextFromContext(driver.ctxs[0]))
+
+	assert.Equal(t, "otlp.ExportMetrics", spans[1].Name)
+	assert.Equal(t, spans[1].SpanContext, trace.SpanContextFromContext(driver.ctxs[1]))
+
+	recorder.Reset()
+	driver.err = errors.New("export failed")
+	assert.Error(t, e.ExportSpans(ctx, stubSpans(1)))
+	spans = recorder.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].StatusCode)
+	assert.Equal(t, "export failed", spans[0].StatusMessage)
+}
+
+func TestExporterExportTracingBatchInfo(t *testing.T) {
+	recorder := tracetest.NewInMemoryExporter()
+	tp := tracesdk.NewTracerProvider(tracesdk.WithSyncer(recorder))
+	e := otlp.NewUnstartedExporter(&contextRecordingDriver{}, otlp.WithExportTracerProvider(tp))
+	ctx := tracesdk.ContextWithExportBatchInfo(context.Background(), tracesdk.ExportBatchInfo{
+		ID:     7,
+		Reason: tracesdk.FlushReasonTimeout,
+	})
+
+	require.NoError(t, e.ExportSpans(ctx, stubSpans(1)))
+
+	spans := recorder.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes, otlp.ExportBatchIDKey.Int64(7))
+	assert.Contains(t, spans[0].Attributes, otlp.ExportFlushReasonKey.String("timeout"))
+}
+
+func TestExporterWithoutExportTracing(t *testing.T) {
+	driver := &contextRecordingDriver{}
+	e := otlp.NewUnstartedExporter(driver)
+	ctx := context.Background()
+
+	require.NoError(t, e.ExportSpans(ctx, stubSpans(1)))
+	require.Len(t, driver.ctxs, 1)
+	assert.Equal(t, ctx, driver.ctxs[0])
+}
+
+// retryingDriver is a contextRecordingDriver retrying every export twice.
+type retryingDriver struct {
+	contextRecordingDriver
+}
+
+func (d *retryingDriver) ExportTraces(ctx context.Context, ss []tracesdk.ReadOnlySpan) error {
+	otlp.RecordExportRetry(ctx)
+	otlp.RecordExportRetry(ctx)
+	return d.contextRecordingDriver.ExportTraces(ctx, ss)
+}
+
+func TestExporterExportMetrics(t *testing.T) {
+	meter, mp := oteltest.NewMeterProvider()
+	driver := &retryingDriver{}
+	e := otlp.NewUnstartedExporter(driver, otlp.WithExportMeterProvider(mp))
+	ctx := context.Background()
+
+	require.NoError(t, e.ExportSpans(ctx, stubSpans(3)))
+	driver.err = errors.New("export failed")
+	require.Error(t, e.Export(ctx, stubCheckpointSet{}))
+
+	got := map[string][]oteltest.Measured{}
+	for _, m := range oteltest.AsStructs(meter.MeasurementBatches) {
+		assert.Equal(t, "go.opentelemetry.io/otel/exporters/otlp", m.InstrumentationName)
+		got[m.Name] = append(got[m.Name], m)
+	}
+
+	traces := attribute.StringValue("traces")
+	metrics := attribute.StringValue("metrics")
+	exports := got["otlp.exporter.exports"]
+	require.Len(t, exports, 2)
+	assert.Equal(t, traces, exports[0].Labels[otlp.ExportSignalKey])
+	assert.Equal(t, attribute.BoolValue(true), exports[0].Labels[otlp.ExportSuccessKey])
+	assert.Equal(t, metrics, exports[1].Labels[otlp.ExportSignalKey])
+	assert.Equal(t, attribute.BoolValue(false), exports[1].Labels[otlp.ExportSuccessKey])
+
+	spans := got["otlp.exporter.spans"]
+	require.Len(t, spans, 1)
+	assert.Equal(t, int64(3), spans[0].Number.AsInt64())
+
+	retries := got["otlp.exporter.retries"]
+	require.Len(t, retries, 2)
+	for _, r := range retries {
+		assert.Equal(t, traces, r.Labels[otlp.ExportSignalKey])
+	}
+
+	var active int64
+	for _, m := range got["otlp.exporter.exports.active"] {
+		active += m.Number.AsInt64()
+	}
+	assert.Len(t, got["otlp.exporter.exports.active"], 4)
+	assert.Equal(t, int64(0), active)
+	assert.Len(t, got["otlp.exporter.export.duration"], 2)
+}
+
+func TestRecordExportRetryWithoutMetrics(t *testing.T) {
+	driver := &retryingDriver{}
+	e := otlp.NewUnstartedExporter(driver)
+	assert.NotPanics(t, func() {
+		require.NoError(t, e.ExportSpans(context.Background(), stubSpans(1)))
+	})
+}
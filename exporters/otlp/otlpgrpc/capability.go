@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpgrpc
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk"
+)
+
+// TraceStreamCapability is the name of the otel.Capability reserved for
+// the export of spans over a single long-lived gRPC stream instead of one
+// unary Export request per batch. The OTLP specification only defines
+// unary export requests, so the driver does not implement the stream and
+// the capability is registered disabled. It is only documented so that
+// users can discover that streaming is not available yet. Registering it
+// again with Enabled set has no effect on the driver.
+const TraceStreamCapability = "go.opentelemetry.io/otel/exporters/otlp/otlpgrpc#trace_stream"
+
+func init() {
+	otel.RegisterCapability(otel.Capability{
+		Name:    TraceStreamCapability,
+		Version: sdk.Version(),
+	})
+}
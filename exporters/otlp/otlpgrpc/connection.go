@@ -23,7 +23,11 @@ import (
 	"unsafe"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/metadata"
+
+	"go.opentelemetry.io/otel/exporters/otlp/internal/tracecontext"
+	"go.opentelemetry.io/otel/internal/global"
 )
 
 type connection struct {
@@ -110,6 +114,15 @@ func (c *connection) connected() bool {
 	return c.lastConnectError() == nil
 }
 
+// ready returns true if the client connection is ready to send RPCs. An
+// RPC failing while it is not ready failed to reach the collector, the
+// background connection takes over reconnecting to it.
+func (c *connection) ready() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cc != nil && c.cc.GetState() == connectivity.Ready
+}
+
 const defaultConnReattemptPeriod = 10 * time.Second
 
 func (c *connection) indefiniteBackgroundConnection() {
@@ -157,6 +170,7 @@ func (c *connection) indefiniteBackgroundConnection() {
 		} else {
 			// this code is unreachable in most cases
 			// c.connect does not establish connection
+			global.Warn("failed to reconnect to the collector", "endpoint", c.cfg.collectorEndpoint, "error", err)
 			c.setStateDisconnected(err)
 		}
 
@@ -228,13 +242,42 @@ func (c *connection) dialToCollector(ctx context.Context) (*grpc.ClientConn, err
 	return grpc.DialContext(ctx, endpoint, dialOpts...)
 }
 
+// contextWithMetadata returns a copy of ctx that sends the configured
+// headers and, for the exports traced by the Exporter, the trace context of
+// ctx, as encoded by the global TextMapPropagator, as metadata of outgoing
+// RPCs.
 func (c *connection) contextWithMetadata(ctx context.Context) context.Context {
-	if c.metadata.Len() > 0 {
-		return metadata.NewOutgoingContext(ctx, c.metadata)
+	md := c.metadata.Copy()
+	tracecontext.Inject(ctx, metadataCarrier(md))
+	if md.Len() > 0 {
+		return metadata.NewOutgoingContext(ctx, md)
 	}
 	return ctx
 }
 
+// metadataCarrier adapts metadata.MD to satisfy the
+// propagation.TextMapCarrier interface.
+type metadataCarrier metadata.MD
+
+func (mc metadataCarrier) Get(key string) string {
+	if v := metadata.MD(mc).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (mc metadataCarrier) Set(key string, value string) {
+	metadata.MD(mc).Set(key, value)
+}
+
+func (mc metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(mc))
+	for k := range mc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
 func (c *connection) shutdown(ctx context.Context) error {
 	close(c.stopCh)
 	// Ensure that the backgroundConnector returns
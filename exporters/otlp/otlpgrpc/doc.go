@@ -17,6 +17,16 @@ Package otlpgrpc provides an implementation of otlp.ProtocolDriver
 that connects to the collector and sends traces and metrics using
 gRPC.
 
+All export requests are sent over a single long-lived gRPC connection
+that is established when the driver is started and re-established in the
+background if it is lost. Each export is an individual request
+multiplexed on this connection, the per-request overhead is that of an
+HTTP/2 stream, not of a new connection.
+
+The OTLP protocol only defines unary export requests, spans are not
+exported over a long-lived stream. The disabled TraceStreamCapability
+documents this until a streaming export service is specified.
+
 This package is currently in a pre-GA phase. Backwards incompatible
 changes may be introduced in subsequent minor version releases as we
 work to track the evolving OpenTelemetry specification and user
@@ -24,8 +24,9 @@ import (
 
 	"go.opentelemetry.io/otel/exporters/otlp"
 	"go.opentelemetry.io/otel/exporters/otlp/internal/transform"
+	"go.opentelemetry.io/otel/internal/retry"
 	metricsdk "go.opentelemetry.io/otel/sdk/export/metric"
-	tracesdk "go.opentelemetry.io/otel/sdk/export/trace"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	colmetricpb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 	metricpb "go.opentelemetry.io/proto/otlp/metrics/v1"
@@ -34,6 +35,8 @@ import (
 
 type driver struct {
 	connection *connection
+	cache      tracesdk.TranslationCache
+	retry      retry.Config
 
 	lock          sync.Mutex
 	metricsClient colmetricpb.MetricsServiceClient
@@ -48,12 +51,15 @@ var (
 func NewDriver(opts ...Option) otlp.ProtocolDriver {
 	cfg := config{
 		collectorEndpoint: fmt.Sprintf("%s:%d", otlp.DefaultCollectorHost, otlp.DefaultCollectorPort),
-		serviceConfig:     DefaultServiceConfig,
+		retry:             retry.DefaultConfig,
 	}
 	for _, opt := range opts {
 		opt(&cfg)
 	}
-	d := &driver{}
+	d := &driver{
+		cache: tracesdk.NewTranslationCache(tracesdk.DefaultTranslationCacheSize),
+		retry: cfg.retry,
+	}
 	d.connection = newConnection(cfg, d.handleNewConnection)
 	return d
 }
@@ -105,7 +111,7 @@ func (d *driver) ExportMetrics(ctx context.Context, cps metricsdk.CheckpointSet,
 
 func (d *driver) uploadMetrics(ctx context.Context, protoMetrics []*metricpb.ResourceMetrics) error {
 	ctx = d.connection.contextWithMetadata(ctx)
-	err := func() error {
+	err := d.retry.Do(ctx, func(ctx context.Context) error {
 		d.lock.Lock()
 		defer d.lock.Unlock()
 		if d.metricsClient == nil {
@@ -114,24 +120,36 @@ func (d *driver) uploadMetrics(ctx context.Context, protoMetrics []*metricpb.Res
 		_, err := d.metricsClient.Export(ctx, &colmetricpb.ExportMetricsServiceRequest{
 			ResourceMetrics: protoMetrics,
 		})
-		return err
-	}()
+		return d.exportError(err)
+	})
 	if err != nil {
 		d.connection.setStateDisconnected(err)
 	}
 	return err
 }
 
+// exportError returns the error of an export attempt, marked as retryable
+// if its status code is retryable and the connection to the collector is
+// still ready. Retrying an export that failed to reach the collector is
+// left to the background connection, which drops the exports while it
+// reconnects.
+func (d *driver) exportError(err error) error {
+	if err == nil || !d.connection.ready() {
+		return err
+	}
+	return retryableError(err)
+}
+
 // ExportTraces implements otlp.ProtocolDriver. It transforms spans to
 // protobuf binary format and sends the result to the collector.
-func (d *driver) ExportTraces(ctx context.Context, ss []*tracesdk.SpanSnapshot) error {
+func (d *driver) ExportTraces(ctx context.Context, ss []tracesdk.ReadOnlySpan) error {
 	if !d.connection.connected() {
 		return fmt.Errorf("exporter disconnected: %w", d.connection.lastConnectError())
 	}
 	ctx, cancel := d.connection.contextWithStop(ctx)
 	defer cancel()
 
-	protoSpans := transform.SpanData(ss)
+	protoSpans := transform.SpanDataWithCache(ss, d.cache)
 	if len(protoSpans) == 0 {
 		return nil
 	}
@@ -140,18 +158,19 @@ func (d *driver) ExportTraces(ctx context.Context, ss []*tracesdk.SpanSnapshot)
 }
 
 func (d *driver) uploadTraces(ctx context.Context, protoSpans []*tracepb.ResourceSpans) error {
+	req := &coltracepb.ExportTraceServiceRequest{
+		ResourceSpans: protoSpans,
+	}
 	ctx = d.connection.contextWithMetadata(ctx)
-	err := func() error {
+	err := d.retry.Do(ctx, func(ctx context.Context) error {
 		d.lock.Lock()
 		defer d.lock.Unlock()
 		if d.tracesClient == nil {
 			return errNoClient
 		}
-		_, err := d.tracesClient.Export(ctx, &coltracepb.ExportTraceServiceRequest{
-			ResourceSpans: protoSpans,
-		})
-		return err
-	}()
+		_, err := d.tracesClient.Export(ctx, req)
+		return d.exportError(err)
+	})
 	if err != nil {
 		d.connection.setStateDisconnected(err)
 	}
@@ -19,11 +19,18 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+
+	"go.opentelemetry.io/otel/internal/retry"
 )
 
 const (
-	// DefaultServiceConfig is the gRPC service config used if none is
-	// provided by the user.
+	// DefaultServiceConfig is a gRPC service config retrying the
+	// export RPCs in gRPC.
+	//
+	// Deprecated: the driver retries failed exports with the retry policy
+	// shared by the exporters, see WithMaxRetryElapsedTime. It no longer
+	// uses this service config by default, using it in addition multiplies
+	// the attempts.
 	//
 	// For more info on gRPC service configs:
 	// https://github.com/grpc/proposal/blob/master/A6-client-retries.md
@@ -68,6 +75,7 @@ type config struct {
 	dialOptions        []grpc.DialOption
 	headers            map[string]string
 	clientCredentials  credentials.TransportCredentials
+	retry              retry.Config
 }
 
 // Option applies an option to the gRPC driver.
@@ -128,6 +136,24 @@ func WithTLSCredentials(creds credentials.TransportCredentials) Option {
 	}
 }
 
+// WithMaxRetryElapsedTime sets the time budget of the retries of an export
+// failing with a retryable status code, such as UNAVAILABLE or
+// RESOURCE_EXHAUSTED. The retries back off exponentially and honor the
+// RetryInfo details of the status. The budget is one minute by default,
+// a non-positive value disables the retries.
+func WithMaxRetryElapsedTime(d time.Duration) Option {
+	return func(cfg *config) {
+		if d <= 0 {
+			cfg.retry = retry.Config{}
+			return
+		}
+		if cfg.retry.InitialInterval <= 0 {
+			cfg.retry = retry.DefaultConfig
+		}
+		cfg.retry.MaxElapsedTime = d
+	}
+}
+
 // WithServiceConfig defines the default gRPC service config used.
 func WithServiceConfig(serviceConfig string) Option {
 	return func(cfg *config) {
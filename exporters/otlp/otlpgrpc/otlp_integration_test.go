@@ -28,12 +28,14 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/encoding/gzip"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp"
 	"go.opentelemetry.io/otel/exporters/otlp/internal/otlptest"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
-	exporttrace "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
 )
 
@@ -161,7 +163,7 @@ func TestNewExporter_collectorConnectionDiesThenReconnectsWhenInRestMode(t *test
 	// trigger almost immediate reconnection
 	require.Error(
 		t,
-		exp.ExportSpans(ctx, []*exporttrace.SpanSnapshot{{Name: "in the midst"}}),
+		exp.ExportSpans(ctx, tracetest.SpanStubs{{Name: "in the midst"}}.Snapshots()),
 		"transport: Error while dialing dial tcp %s: connect: connection refused",
 		mc.endpoint,
 	)
@@ -173,7 +175,7 @@ func TestNewExporter_collectorConnectionDiesThenReconnectsWhenInRestMode(t *test
 	// send message to disconnected channel but this time reconnection gouroutine will be in (rest mode, not listening to the disconnected channel)
 	require.Error(
 		t,
-		exp.ExportSpans(ctx, []*exporttrace.SpanSnapshot{{Name: "in the midst"}}),
+		exp.ExportSpans(ctx, tracetest.SpanStubs{{Name: "in the midst"}}.Snapshots()),
 		"transport: Error while dialing dial tcp %s: connect: connection refused2",
 		mc.endpoint,
 	)
@@ -191,12 +193,12 @@ func TestNewExporter_collectorConnectionDiesThenReconnectsWhenInRestMode(t *test
 	for i := 0; i < n; i++ {
 		// when disconnected exp.ExportSpans doesnt send disconnected messages again
 		// it just quits and return last connection error
-		require.NoError(t, exp.ExportSpans(ctx, []*exporttrace.SpanSnapshot{{Name: "Resurrected"}}))
+		require.NoError(t, exp.ExportSpans(ctx, tracetest.SpanStubs{{Name: "Resurrected"}}.Snapshots()))
 	}
 
 	nmaSpans := nmc.getSpans()
 
-	// Expecting 10 SpanSnapshots that were sampled, given that
+	// Expecting 10 spans that were sampled, given that
 	if g, w := len(nmaSpans), n; g != w {
 		t.Fatalf("Connected collector: spans: got %d want %d", g, w)
 	}
@@ -231,7 +233,7 @@ func TestNewExporter_collectorConnectionDiesThenReconnects(t *testing.T) {
 		// No endpoint up.
 		require.Error(
 			t,
-			exp.ExportSpans(ctx, []*exporttrace.SpanSnapshot{{Name: "in the midst"}}),
+			exp.ExportSpans(ctx, tracetest.SpanStubs{{Name: "in the midst"}}.Snapshots()),
 			"transport: Error while dialing dial tcp %s: connect: connection refused",
 			mc.endpoint,
 		)
@@ -245,11 +247,11 @@ func TestNewExporter_collectorConnectionDiesThenReconnects(t *testing.T) {
 
 		n := 10
 		for i := 0; i < n; i++ {
-			require.NoError(t, exp.ExportSpans(ctx, []*exporttrace.SpanSnapshot{{Name: "Resurrected"}}))
+			require.NoError(t, exp.ExportSpans(ctx, tracetest.SpanStubs{{Name: "Resurrected"}}.Snapshots()))
 		}
 
 		nmaSpans := nmc.getSpans()
-		// Expecting 10 SpanSnapshots that were sampled, given that
+		// Expecting 10 spans that were sampled, given that
 		if g, w := len(nmaSpans), n; g != w {
 			t.Fatalf("Round #%d: Connected collector: spans: got %d want %d", j, g, w)
 		}
@@ -305,7 +307,7 @@ func TestNewExporter_withHeaders(t *testing.T) {
 	ctx := context.Background()
 	exp := newGRPCExporter(t, ctx, mc.endpoint,
 		otlpgrpc.WithHeaders(map[string]string{"header1": "value1"}))
-	require.NoError(t, exp.ExportSpans(ctx, []*exporttrace.SpanSnapshot{{Name: "in the midst"}}))
+	require.NoError(t, exp.ExportSpans(ctx, tracetest.SpanStubs{{Name: "in the midst"}}.Snapshots()))
 
 	defer func() {
 		_ = exp.Shutdown(ctx)
@@ -316,6 +318,34 @@ func TestNewExporter_withHeaders(t *testing.T) {
 	assert.Equal(t, "value1", headers.Get("header1")[0])
 }
 
+func TestTraceStreamCapabilityDisabled(t *testing.T) {
+	c, ok := otel.LookupCapability(otlpgrpc.TraceStreamCapability)
+	require.True(t, ok)
+	assert.False(t, c.Enabled)
+	assert.Equal(t, sdk.Version(), c.Version)
+
+	// Enabling the capability does not make the driver use a stream, the
+	// mock collector only implements the unary Export.
+	enabled := c
+	enabled.Enabled = true
+	otel.RegisterCapability(enabled)
+	defer otel.RegisterCapability(c)
+
+	mc := runMockCollector(t)
+	defer func() {
+		_ = mc.stop()
+	}()
+
+	ctx := context.Background()
+	exp := newGRPCExporter(t, ctx, mc.endpoint)
+	defer func() {
+		_ = exp.Shutdown(ctx)
+	}()
+
+	require.NoError(t, exp.ExportSpans(ctx, tracetest.SpanStubs{{Name: "in the midst"}}.Snapshots()))
+	assert.Len(t, mc.getSpans(), 1)
+}
+
 func TestNewExporter_withInvalidSecurityConfiguration(t *testing.T) {
 	mc := runMockCollector(t)
 	defer func() {
@@ -329,7 +359,7 @@ func TestNewExporter_withInvalidSecurityConfiguration(t *testing.T) {
 		t.Fatalf("failed to create a new collector exporter: %v", err)
 	}
 
-	err = exp.ExportSpans(ctx, []*exporttrace.SpanSnapshot{{Name: "misconfiguration"}})
+	err = exp.ExportSpans(ctx, tracetest.SpanStubs{{Name: "misconfiguration"}}.Snapshots())
 	require.Equal(t, err.Error(), "exporter disconnected: grpc: no transport security set (use grpc.WithInsecure() explicitly or set credentials)")
 
 	defer func() {
@@ -485,7 +515,7 @@ func TestDisconnected(t *testing.T) {
 	}()
 
 	assert.Error(t, exp.Export(ctx, otlptest.OneRecordCheckpointSet{}))
-	assert.Error(t, exp.ExportSpans(ctx, otlptest.SingleSpanSnapshot()))
+	assert.Error(t, exp.ExportSpans(ctx, otlptest.SingleReadOnlySpan()))
 }
 
 func TestEmptyData(t *testing.T) {
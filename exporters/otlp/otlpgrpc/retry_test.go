@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpgrpc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"go.opentelemetry.io/otel/internal/retry"
+)
+
+func TestRetryableError(t *testing.T) {
+	assert.NoError(t, retryableError(nil))
+
+	plain := errors.New("not a status")
+	assert.Same(t, plain, retryableError(plain))
+
+	for _, c := range []codes.Code{
+		codes.Canceled,
+		codes.DeadlineExceeded,
+		codes.ResourceExhausted,
+		codes.Aborted,
+		codes.OutOfRange,
+		codes.Unavailable,
+		codes.DataLoss,
+	} {
+		err := status.Error(c, "retry")
+		got := retryableError(err)
+		assert.True(t, errors.Is(got, err), c.String())
+		ok, _ := retry.IsRetryable(got)
+		assert.True(t, ok, c.String())
+	}
+
+	for _, c := range []codes.Code{
+		codes.Unknown,
+		codes.InvalidArgument,
+		codes.NotFound,
+		codes.PermissionDenied,
+		codes.Unimplemented,
+		codes.Unauthenticated,
+	} {
+		ok, _ := retry.IsRetryable(retryableError(status.Error(c, "fail")))
+		assert.False(t, ok, c.String())
+	}
+}
+
+func TestRetryableErrorRetryInfo(t *testing.T) {
+	s, err := status.New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(3 * time.Second),
+	})
+	require.NoError(t, err)
+	ok, after := retry.IsRetryable(retryableError(s.Err()))
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, after)
+}
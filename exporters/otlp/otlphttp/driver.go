@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
-	"math/rand"
 	"net"
 	"net/http"
 	"path"
@@ -33,9 +32,13 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/internal/tracecontext"
 	"go.opentelemetry.io/otel/exporters/otlp/internal/transform"
+	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/internal/retry"
+	"go.opentelemetry.io/otel/propagation"
 	metricsdk "go.opentelemetry.io/otel/sdk/export/metric"
-	tracesdk "go.opentelemetry.io/otel/sdk/export/trace"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
 	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
 )
@@ -65,11 +68,13 @@ type driver struct {
 	metricsDriver signalDriver
 	tracesDriver  signalDriver
 	cfg           config
+	cache         tracesdk.TranslationCache
 
 	stopCh chan struct{}
 }
 
 type signalDriver struct {
+	signal     otel.Signal
 	cfg        signalConfig
 	generalCfg config
 	client     *http.Client
@@ -134,18 +139,21 @@ func NewDriver(opts ...Option) otlp.ProtocolDriver {
 	stopCh := make(chan struct{})
 	return &driver{
 		tracesDriver: signalDriver{
+			signal:     otel.TracesSignal,
 			cfg:        cfg.traces,
 			generalCfg: cfg,
 			stopCh:     stopCh,
 			client:     tracesClient,
 		},
 		metricsDriver: signalDriver{
+			signal:     otel.MetricsSignal,
 			cfg:        cfg.metrics,
 			generalCfg: cfg,
 			stopCh:     stopCh,
 			client:     metricsClient,
 		},
 		cfg:    cfg,
+		cache:  tracesdk.NewTranslationCache(tracesdk.DefaultTranslationCacheSize),
 		stopCh: stopCh,
 	}
 }
@@ -182,8 +190,8 @@ func (d *driver) ExportMetrics(ctx context.Context, cps metricsdk.CheckpointSet,
 }
 
 // ExportTraces implements otlp.ProtocolDriver.
-func (d *driver) ExportTraces(ctx context.Context, ss []*tracesdk.SpanSnapshot) error {
-	protoSpans := transform.SpanData(ss)
+func (d *driver) ExportTraces(ctx context.Context, ss []tracesdk.ReadOnlySpan) error {
+	protoSpans := transform.SpanDataWithCache(ss, d.cache)
 	if len(protoSpans) == 0 {
 		return nil
 	}
@@ -209,33 +217,28 @@ func (d *signalDriver) send(ctx context.Context, rawRequest []byte) error {
 	var cancel context.CancelFunc
 	ctx, cancel = d.contextWithStop(ctx)
 	defer cancel()
-	for i := 0; i < d.generalCfg.maxAttempts; i++ {
+	policy := retry.DefaultConfig
+	policy.InitialInterval = d.generalCfg.backoff
+	policy.MaxAttempts = d.generalCfg.maxAttempts
+	policy.OnRetry = func(attempt int, err error, delay time.Duration) {
+		otlp.RecordExportRetry(ctx)
+		global.Info("retrying export", "endpoint", address, "attempt", attempt, "error", err, "backoff", delay)
+	}
+	return policy.Do(ctx, func(ctx context.Context) error {
 		response, err := d.singleSend(ctx, rawRequest, address)
 		if err != nil {
-			return err
+			return retry.TransportError(err)
 		}
 		// We don't care about the body, so try to read it
 		// into /dev/null and close it immediately. The
 		// reading part is to facilitate connection reuse.
 		_, _ = io.Copy(ioutil.Discard, response.Body)
 		_ = response.Body.Close()
-		switch response.StatusCode {
-		case http.StatusOK:
-			return nil
-		case http.StatusTooManyRequests:
-			fallthrough
-		case http.StatusServiceUnavailable:
-			select {
-			case <-time.After(getWaitDuration(d.generalCfg.backoff, i)):
-				continue
-			case <-ctx.Done():
-				return ctx.Err()
-			}
-		default:
-			return fmt.Errorf("failed with HTTP status %s", response.Status)
+		if err := retry.HTTPResponseError(response); err != nil {
+			return fmt.Errorf("failed to send data to %s: %w", address, err)
 		}
-	}
-	return fmt.Errorf("failed to send data to %s after %d tries", address, d.generalCfg.maxAttempts)
+		return nil
+	})
 }
 
 func (d *signalDriver) getScheme() string {
@@ -245,26 +248,6 @@ func (d *signalDriver) getScheme() string {
 	return "https"
 }
 
-func getWaitDuration(backoff time.Duration, i int) time.Duration {
-	// Strategy: after nth failed attempt, attempt resending after
-	// k * initialBackoff + jitter, where k is a random number in
-	// range [0, 2^n-1), and jitter is a random percentage of
-	// initialBackoff from [-5%, 5%).
-	//
-	// Based on
-	// https://en.wikipedia.org/wiki/Exponential_backoff#Example_exponential_backoff_algorithm
-	//
-	// Jitter is our addition.
-
-	// There won't be an overflow, since i is capped to
-	// DefaultMaxAttempts (5).
-	upperK := (int64)(1) << (i + 1)
-	jitterPercent := (rand.Float64() - 0.5) / 10.
-	jitter := jitterPercent * (float64)(backoff)
-	k := rand.Int63n(upperK)
-	return (time.Duration)(k)*backoff + (time.Duration)(jitter)
-}
-
 func (d *signalDriver) contextWithStop(ctx context.Context) (context.Context, context.CancelFunc) {
 	// Unify the parent context Done signal with the driver's stop
 	// channel.
@@ -296,6 +279,7 @@ func (d *signalDriver) singleSend(ctx context.Context, rawRequest []byte, addres
 			request.Header.Add(key, value)
 		}
 	}
+	tracecontext.Inject(ctx, propagation.HeaderCarrier(request.Header))
 	return d.client.Do(request)
 }
 
@@ -323,7 +307,7 @@ func (d *signalDriver) prepareBody(rawRequest []byte) (io.ReadCloser, int64, htt
 			defer gzipper.Close()
 			_, err := io.Copy(gzipper, requestReader)
 			if err != nil {
-				otel.Handle(fmt.Errorf("otlphttp: failed to gzip request: %v", err))
+				otel.HandleSignal(d.signal, fmt.Errorf("otlphttp: failed to gzip request: %v", err))
 			}
 		}()
 		headers.Set("Content-Encoding", "gzip")
@@ -16,17 +16,22 @@ package otlphttp_test
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
-	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp"
 	"go.opentelemetry.io/otel/exporters/otlp/internal/otlptest"
 	"go.opentelemetry.io/otel/exporters/otlp/otlphttp"
+	"go.opentelemetry.io/otel/oteltest"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -145,7 +150,9 @@ func TestEndToEnd(t *testing.T) {
 func TestRetry(t *testing.T) {
 	statuses := []int{
 		http.StatusTooManyRequests,
+		http.StatusBadGateway,
 		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout,
 	}
 	mcCfg := mockCollectorConfig{
 		InjectHTTPStatus: statuses,
@@ -156,6 +163,7 @@ func TestRetry(t *testing.T) {
 		otlphttp.WithEndpoint(mc.Endpoint()),
 		otlphttp.WithInsecure(),
 		otlphttp.WithMaxAttempts(len(statuses)+1),
+		otlphttp.WithBackoff(time.Millisecond),
 	)
 	ctx := context.Background()
 	exporter, err := otlp.NewExporter(ctx, driver)
@@ -163,11 +171,44 @@ func TestRetry(t *testing.T) {
 	defer func() {
 		assert.NoError(t, exporter.Shutdown(ctx))
 	}()
-	err = exporter.ExportSpans(ctx, otlptest.SingleSpanSnapshot())
+	err = exporter.ExportSpans(ctx, otlptest.SingleReadOnlySpan())
 	assert.NoError(t, err)
 	assert.Len(t, mc.GetSpans(), 1)
 }
 
+func TestRetryMetrics(t *testing.T) {
+	statuses := []int{
+		http.StatusTooManyRequests,
+		http.StatusServiceUnavailable,
+	}
+	mcCfg := mockCollectorConfig{
+		InjectHTTPStatus: statuses,
+	}
+	mc := runMockCollector(t, mcCfg)
+	defer mc.MustStop(t)
+	driver := otlphttp.NewDriver(
+		otlphttp.WithEndpoint(mc.Endpoint()),
+		otlphttp.WithInsecure(),
+		otlphttp.WithMaxAttempts(len(statuses)+1),
+	)
+	meter, mp := oteltest.NewMeterProvider()
+	ctx := context.Background()
+	exporter, err := otlp.NewExporter(ctx, driver, otlp.WithExportMeterProvider(mp))
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	require.NoError(t, exporter.ExportSpans(ctx, otlptest.SingleReadOnlySpan()))
+
+	var retries int64
+	for _, m := range oteltest.AsStructs(meter.MeasurementBatches) {
+		if m.Name == "otlp.exporter.retries" {
+			retries += m.Number.AsInt64()
+		}
+	}
+	assert.Equal(t, int64(len(statuses)), retries)
+}
+
 func TestTimeout(t *testing.T) {
 	mcCfg := mockCollectorConfig{
 		InjectDelay: 100 * time.Millisecond,
@@ -178,6 +219,32 @@ func TestTimeout(t *testing.T) {
 		otlphttp.WithEndpoint(mc.Endpoint()),
 		otlphttp.WithInsecure(),
 		otlphttp.WithTimeout(50*time.Millisecond),
+		otlphttp.WithMaxAttempts(1),
+	)
+	ctx := context.Background()
+	exporter, err := otlp.NewExporter(ctx, driver)
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}()
+	err = exporter.ExportSpans(ctx, otlptest.SingleReadOnlySpan())
+	var netErr net.Error
+	require.True(t, errors.As(err, &netErr))
+	assert.True(t, netErr.Timeout())
+}
+
+func TestRetryTimeout(t *testing.T) {
+	mcCfg := mockCollectorConfig{
+		InjectDelay: 100 * time.Millisecond,
+	}
+	mc := runMockCollector(t, mcCfg)
+	defer mc.MustStop(t)
+	driver := otlphttp.NewDriver(
+		otlphttp.WithEndpoint(mc.Endpoint()),
+		otlphttp.WithInsecure(),
+		otlphttp.WithTimeout(50*time.Millisecond),
+		otlphttp.WithMaxAttempts(2),
+		otlphttp.WithBackoff(time.Millisecond),
 	)
 	ctx := context.Background()
 	exporter, err := otlp.NewExporter(ctx, driver)
@@ -185,8 +252,8 @@ func TestTimeout(t *testing.T) {
 	defer func() {
 		assert.NoError(t, exporter.Shutdown(ctx))
 	}()
-	err = exporter.ExportSpans(ctx, otlptest.SingleSpanSnapshot())
-	assert.Equal(t, true, os.IsTimeout(err))
+	err = exporter.ExportSpans(ctx, otlptest.SingleReadOnlySpan())
+	assert.Contains(t, err.Error(), "failed after 2 attempts")
 }
 
 func TestRetryFailed(t *testing.T) {
@@ -210,7 +277,7 @@ func TestRetryFailed(t *testing.T) {
 	defer func() {
 		assert.NoError(t, exporter.Shutdown(ctx))
 	}()
-	err = exporter.ExportSpans(ctx, otlptest.SingleSpanSnapshot())
+	err = exporter.ExportSpans(ctx, otlptest.SingleReadOnlySpan())
 	assert.Error(t, err)
 	assert.Empty(t, mc.GetSpans())
 }
@@ -235,7 +302,7 @@ func TestNoRetry(t *testing.T) {
 	defer func() {
 		assert.NoError(t, exporter.Shutdown(ctx))
 	}()
-	err = exporter.ExportSpans(ctx, otlptest.SingleSpanSnapshot())
+	err = exporter.ExportSpans(ctx, otlptest.SingleReadOnlySpan())
 	assert.Error(t, err)
 	assert.Empty(t, mc.GetSpans())
 }
@@ -322,7 +389,7 @@ func TestUnreasonableMaxAttempts(t *testing.T) {
 			defer func() {
 				assert.NoError(t, exporter.Shutdown(ctx))
 			}()
-			err = exporter.ExportSpans(ctx, otlptest.SingleSpanSnapshot())
+			err = exporter.ExportSpans(ctx, otlptest.SingleReadOnlySpan())
 			assert.Error(t, err)
 			assert.Empty(t, mc.GetSpans())
 		})
@@ -358,7 +425,7 @@ func TestUnreasonableBackoff(t *testing.T) {
 	defer func() {
 		assert.NoError(t, exporter.Shutdown(ctx))
 	}()
-	err = exporter.ExportSpans(ctx, otlptest.SingleSpanSnapshot())
+	err = exporter.ExportSpans(ctx, otlptest.SingleReadOnlySpan())
 	assert.Error(t, err)
 	assert.Empty(t, mc.GetSpans())
 }
@@ -378,7 +445,7 @@ func TestCancelledContext(t *testing.T) {
 		assert.NoError(t, exporter.Shutdown(ctx))
 	}()
 	cancel()
-	err = exporter.ExportSpans(ctx, otlptest.SingleSpanSnapshot())
+	err = exporter.ExportSpans(ctx, otlptest.SingleReadOnlySpan())
 	assert.Error(t, err)
 	assert.Empty(t, mc.GetSpans())
 }
@@ -406,7 +473,7 @@ func TestDeadlineContext(t *testing.T) {
 	}()
 	ctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
-	err = exporter.ExportSpans(ctx, otlptest.SingleSpanSnapshot())
+	err = exporter.ExportSpans(ctx, otlptest.SingleReadOnlySpan())
 	assert.Error(t, err)
 	assert.Empty(t, mc.GetSpans())
 }
@@ -434,7 +501,7 @@ func TestStopWhileExporting(t *testing.T) {
 	}()
 	doneCh := make(chan struct{})
 	go func() {
-		err := exporter.ExportSpans(ctx, otlptest.SingleSpanSnapshot())
+		err := exporter.ExportSpans(ctx, otlptest.SingleReadOnlySpan())
 		assert.Error(t, err)
 		assert.Empty(t, mc.GetSpans())
 		close(doneCh)
@@ -444,3 +511,52 @@ func TestStopWhileExporting(t *testing.T) {
 	assert.NoError(t, err)
 	<-doneCh
 }
+
+func TestTraceContextPropagation(t *testing.T) {
+	prev := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(prev)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	for _, tc := range []struct {
+		name        string
+		opts        []otlp.ExporterOption
+		traceparent string
+	}{
+		{
+			name: "untraced exports",
+		},
+		{
+			name: "traced exports",
+			// The noop Tracer propagates the span context of its parent.
+			opts:        []otlp.ExporterOption{otlp.WithExportTracerProvider(trace.NewNoopTracerProvider())},
+			traceparent: "00-01000000000000000000000000000000-0200000000000000-01",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mcCfg := mockCollectorConfig{
+				ExpectedHeaders: map[string]string{"traceparent": tc.traceparent},
+			}
+			mc := runMockCollector(t, mcCfg)
+			defer mc.MustStop(t)
+			driver := otlphttp.NewDriver(
+				otlphttp.WithEndpoint(mc.Endpoint()),
+				otlphttp.WithInsecure(),
+				otlphttp.WithMaxAttempts(1),
+			)
+			ctx := context.Background()
+			exporter, err := otlp.NewExporter(ctx, driver, tc.opts...)
+			require.NoError(t, err)
+			defer func() {
+				assert.NoError(t, exporter.Shutdown(ctx))
+			}()
+			err = exporter.ExportSpans(trace.ContextWithSpanContext(ctx, sc), otlptest.SingleReadOnlySpan())
+			assert.NoError(t, err)
+			assert.Len(t, mc.GetSpans(), 1)
+		})
+	}
+}
@@ -72,21 +72,21 @@ func (e *envOptionsReader) getOptionsFromEnv() []Option {
 		if tls, err := e.readTLSConfig(path); err == nil {
 			opts = append(opts, WithTLSClientConfig(tls))
 		} else {
-			otel.Handle(fmt.Errorf("failed to configure otlp exporter certificate '%s': %w", path, err))
+			otel.HandleSignals(fmt.Errorf("failed to configure otlp exporter certificate '%s': %w", path, err), otel.TracesSignal, otel.MetricsSignal)
 		}
 	}
 	if path, ok := e.getEnvValue("TRACES_CERTIFICATE"); ok {
 		if tls, err := e.readTLSConfig(path); err == nil {
 			opts = append(opts, WithTracesTLSClientConfig(tls))
 		} else {
-			otel.Handle(fmt.Errorf("failed to configure otlp traces exporter certificate '%s': %w", path, err))
+			otel.HandleSignal(otel.TracesSignal, fmt.Errorf("failed to configure otlp traces exporter certificate '%s': %w", path, err))
 		}
 	}
 	if path, ok := e.getEnvValue("METRICS_CERTIFICATE"); ok {
 		if tls, err := e.readTLSConfig(path); err == nil {
 			opts = append(opts, WithMetricsTLSClientConfig(tls))
 		} else {
-			otel.Handle(fmt.Errorf("failed to configure otlp metrics exporter certificate '%s': %w", path, err))
+			otel.HandleSignal(otel.MetricsSignal, fmt.Errorf("failed to configure otlp metrics exporter certificate '%s': %w", path, err))
 		}
 	}
 
@@ -201,6 +201,12 @@ func WithMetricsURLPath(urlPath string) Option {
 // WithMaxAttempts allows one to override how many times the driver
 // will try to send the payload in case of retryable errors. If unset,
 // DefaultMaxAttempts will be used.
+//
+// The retryable errors are the transient network failures and the
+// responses with the 429 Too Many Requests, 502 Bad Gateway, 503 Service
+// Unavailable, or 504 Gateway Timeout status, whose Retry-After header is
+// honored. All the attempts of an export are bounded by a one minute
+// budget.
 func WithMaxAttempts(maxAttempts int) Option {
 	return newGenericOption(func(cfg *config) {
 		cfg.maxAttempts = maxAttempts
@@ -19,7 +19,7 @@ import (
 	"sync"
 
 	metricsdk "go.opentelemetry.io/otel/sdk/export/metric"
-	tracesdk "go.opentelemetry.io/otel/sdk/export/trace"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
 )
 
 // ProtocolDriver is an interface used by OTLP exporter. It's
@@ -48,7 +48,7 @@ type ProtocolDriver interface {
 	// format and send it to the collector. May be called
 	// concurrently with ExportMetrics, so the manager needs to
 	// take this into account by doing proper locking.
-	ExportTraces(ctx context.Context, ss []*tracesdk.SpanSnapshot) error
+	ExportTraces(ctx context.Context, ss []tracesdk.ReadOnlySpan) error
 }
 
 // SplitConfig is used to configure a split driver.
@@ -140,6 +140,6 @@ func (d *splitDriver) ExportMetrics(ctx context.Context, cps metricsdk.Checkpoin
 
 // ExportTraces implements ProtocolDriver. It forwards the call to the
 // driver used for sending spans.
-func (d *splitDriver) ExportTraces(ctx context.Context, ss []*tracesdk.SpanSnapshot) error {
+func (d *splitDriver) ExportTraces(ctx context.Context, ss []tracesdk.ReadOnlySpan) error {
 	return d.trace.ExportTraces(ctx, ss)
 }
@@ -51,6 +51,24 @@ type Config struct {
 
 	// DisableMetricExport prevents any export of metric telemetry.
 	DisableMetricExport bool
+
+	// MetricDeltas exports the sums of adding instruments cumulatively and
+	// prints the change of each sum since the previous export alongside
+	// it. Default is false.
+	MetricDeltas bool
+
+	// FilePath is the path of a file the output is appended to. If set, it
+	// takes precedence over Writer. The file is opened by NewExporter.
+	FilePath string
+
+	// MaxFileSize is the size in bytes at which the file at FilePath is
+	// rotated. If zero or negative, the file is never rotated.
+	MaxFileSize int64
+
+	// MaxFileBackups is the number of rotated files to retain. Rotated
+	// files are named by appending ".1", ".2", ... to FilePath, ".1" being
+	// the most recent. If zero, the file is truncated when rotated.
+	MaxFileBackups int
 }
 
 // NewConfig creates a validated Config configured with options.
@@ -162,3 +180,54 @@ func (o disableMetricExportOption) Apply(config *Config) {
 }
 
 func (disableMetricExportOption) private() {}
+
+// WithMetricDeltas prints, for the sums of adding instruments, the change of
+// the sum since the previous export in a "Delta" field next to the
+// cumulative "Sum". This makes growing sums readable while debugging.
+func WithMetricDeltas() Option {
+	return metricDeltasOption(true)
+}
+
+type metricDeltasOption bool
+
+func (o metricDeltasOption) Apply(config *Config) {
+	config.MetricDeltas = bool(o)
+}
+
+func (metricDeltasOption) private() {}
+
+// WithFile sets the export stream destination to be the file at path. The
+// file is created if it does not exist, otherwise output is appended to it.
+//
+// The file is shared by the span and metric exports, it is synced to stable
+// storage and closed when the Exporter is shut down, see Exporter.Shutdown.
+// Use WithFileRotation to limit its size.
+func WithFile(path string) Option {
+	return fileOption(path)
+}
+
+type fileOption string
+
+func (o fileOption) Apply(config *Config) {
+	config.FilePath = string(o)
+}
+
+func (fileOption) private() {}
+
+// WithFileRotation sets the size in bytes at which the file configured with
+// WithFile is rotated, and the number of rotated files to retain.
+func WithFileRotation(maxSize int64, maxBackups int) Option {
+	return fileRotationOption{maxSize: maxSize, maxBackups: maxBackups}
+}
+
+type fileRotationOption struct {
+	maxSize    int64
+	maxBackups int
+}
+
+func (o fileRotationOption) Apply(config *Config) {
+	config.MaxFileSize = o.maxSize
+	config.MaxFileBackups = o.maxBackups
+}
+
+func (fileRotationOption) private() {}
@@ -20,7 +20,6 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric/global"
 	"go.opentelemetry.io/otel/sdk/export/metric"
-	exporttrace "go.opentelemetry.io/otel/sdk/export/trace"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
@@ -34,25 +33,57 @@ type Exporter struct {
 }
 
 var (
-	_ metric.Exporter          = &Exporter{}
-	_ exporttrace.SpanExporter = &Exporter{}
+	_ metric.Exporter       = &Exporter{}
+	_ sdktrace.SpanExporter = &Exporter{}
 )
 
-// NewExporter creates an Exporter with the passed options.
+// NewExporter creates an Exporter with the passed options. An error is
+// returned if the file configured with WithFile cannot be opened.
 func NewExporter(options ...Option) (*Exporter, error) {
 	config, err := NewConfig(options...)
 	if err != nil {
 		return nil, err
 	}
+	if config.FilePath != "" {
+		f, err := openRotatingFile(config.FilePath, config.MaxFileSize, config.MaxFileBackups)
+		if err != nil {
+			return nil, err
+		}
+		config.Writer = f
+	}
 	return &Exporter{
 		traceExporter:  traceExporter{config: config},
-		metricExporter: metricExporter{config},
+		metricExporter: metricExporter{config: config},
 	}, nil
 }
 
+// Shutdown stops the export of both spans and metrics: subsequent exports
+// are dropped. If the Exporter writes to a file configured with WithFile,
+// the file is synced and closed.
+//
+// The Exporter owns the file for both signals, so when it is shared by a
+// TracerProvider, which shuts down its exporters, and a Controller, stop
+// the Controller before shutting down the TracerProvider. An Exporter only
+// used for metrics needs to be shut down explicitly to close its file.
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.traceExporter.stop()
+	e.metricExporter.stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+	if f, ok := e.traceExporter.config.Writer.(*rotatingFile); ok {
+		return f.Close()
+	}
+	return nil
+}
+
 // NewExportPipeline creates a complete export pipeline with the default
 // selectors, processors, and trace registration. It is the responsibility
-// of the caller to stop the returned push Controller.
+// of the caller to stop the returned push Controller, before shutting down
+// the returned TracerProvider which shuts down the Exporter.
 func NewExportPipeline(exportOpts []Option, pushOpts []controller.Option) (trace.TracerProvider, *controller.Controller, error) {
 	exporter, err := NewExporter(exportOpts...)
 	if err != nil {
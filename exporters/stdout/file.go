@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout // import "go.opentelemetry.io/otel/exporters/stdout"
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+)
+
+// rotatingFile is an io.WriteCloser that appends to a file and rotates it
+// once it reaches a maximum size.
+type rotatingFile struct {
+	mu sync.Mutex
+
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	size int64
+}
+
+func openRotatingFile(path string, maxSize int64, maxBackups int) (*rotatingFile, error) {
+	f := &rotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// open opens the file at f.path for appending.
+func (f *rotatingFile) open() error {
+	file, size, err := openFile(f.path, 0)
+	if err != nil {
+		return err
+	}
+	f.file = file
+	f.size = size
+	return nil
+}
+
+// openFile opens the file at path for appending, with the additional flag,
+// and returns its size.
+func openFile(path string, flag int) (*os.File, int64, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND|flag, 0644)
+	if err != nil {
+		return nil, 0, fmt.Errorf("opening export file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, 0, fmt.Errorf("opening export file: %w", err)
+	}
+	return file, info.Size(), nil
+}
+
+// Write appends p to the file, rotating it first if p would cause the file
+// to exceed its maximum size. A write larger than the maximum size is
+// written to an empty file instead of being split. Rotation errors are
+// reported to the error handlers, p is still written to the current file.
+func (f *rotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(p)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			otel.HandleSignals(err, otel.TracesSignal, otel.MetricsSignal)
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// rotate replaces the current file with a new empty file at f.path and
+// closes the previous one. Without backups the file is truncated, otherwise
+// the new file is opened next to the current one before the retained
+// backups are shifted and the current file becomes the first backup. The
+// files are only changed once the new file is opened, the previous file
+// stays in use if rotating fails. It must be called while holding f.mu.
+func (f *rotatingFile) rotate() error {
+	var (
+		file *os.File
+		err  error
+	)
+	if f.maxBackups <= 0 {
+		file, _, err = openFile(f.path, os.O_TRUNC)
+		if err != nil {
+			return fmt.Errorf("rotating export file: %w", err)
+		}
+	} else if file, err = f.openNext(); err != nil {
+		return fmt.Errorf("rotating export file: %w", err)
+	}
+
+	previous := f.file
+	f.file = file
+	f.size = 0
+	if err := syncAndClose(previous); err != nil {
+		return fmt.Errorf("rotating export file: %w", err)
+	}
+	return nil
+}
+
+// openNext opens an empty file, shifts the retained backups, moves the
+// current file to the first backup and the new file to f.path. The new file
+// is removed if the files cannot be moved.
+func (f *rotatingFile) openNext() (*os.File, error) {
+	next := f.path + ".next"
+	file, _, err := openFile(next, os.O_TRUNC)
+	if err != nil {
+		return nil, err
+	}
+	discard := func(err error) (*os.File, error) {
+		_ = file.Close()
+		_ = os.Remove(next)
+		return nil, err
+	}
+
+	for i := f.maxBackups - 1; i > 0; i-- {
+		err := os.Rename(f.backupPath(i), f.backupPath(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return discard(err)
+		}
+	}
+	if err := os.Rename(f.path, f.backupPath(1)); err != nil {
+		return discard(err)
+	}
+	if err := os.Rename(next, f.path); err != nil {
+		// Restore the current file, it is still written to.
+		_ = os.Rename(f.backupPath(1), f.path)
+		return discard(err)
+	}
+	return file, nil
+}
+
+func (f *rotatingFile) backupPath(n int) string {
+	return fmt.Sprintf("%s.%d", f.path, n)
+}
+
+// Close syncs the file to stable storage and closes it.
+func (f *rotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closeFile()
+}
+
+func (f *rotatingFile) closeFile() error {
+	if f.file == nil {
+		return nil
+	}
+	file := f.file
+	f.file = nil
+	return syncAndClose(file)
+}
+
+// syncAndClose syncs file to stable storage and closes it.
+func syncAndClose(file *os.File) error {
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("syncing export file: %w", err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package stdout_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/stdout"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/sdk/export/metric/metrictest"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "stdout-exporter")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func exportNamed(t *testing.T, ex *stdout.Exporter, name string) {
+	err := ex.ExportSpans(context.Background(), tracetest.SpanStubs{{Name: name}}.Snapshots())
+	require.NoError(t, err)
+}
+
+func TestExporterWithFile(t *testing.T) {
+	path := filepath.Join(tempDir(t), "spans.json")
+	ex, err := stdout.NewExporter(stdout.WithFile(path))
+	require.NoError(t, err)
+
+	exportNamed(t, ex, "span-1")
+	exportNamed(t, ex, "span-2")
+	require.NoError(t, ex.Shutdown(context.Background()))
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"Name":"span-1"`)
+	assert.Contains(t, lines[1], `"Name":"span-2"`)
+}
+
+func TestExporterWithFileSharedBySignals(t *testing.T) {
+	path := filepath.Join(tempDir(t), "telemetry.json")
+	ex, err := stdout.NewExporter(stdout.WithFile(path), stdout.WithoutTimestamps())
+	require.NoError(t, err)
+
+	desc := metric.NewDescriptor("test.sum", metric.CounterInstrumentKind, number.Int64Kind)
+	agg, ckpt := metrictest.Unslice2(sum.New(2))
+	aggregatortest.CheckedUpdate(t, agg, number.NewInt64Number(1), &desc)
+	require.NoError(t, agg.SynchronizedMove(ckpt, &desc))
+	checkpointSet := metrictest.NewCheckpointSet(testResource)
+	checkpointSet.Add(&desc, ckpt)
+
+	exportNamed(t, ex, "span")
+	require.NoError(t, ex.Export(context.Background(), checkpointSet))
+	require.NoError(t, ex.Shutdown(context.Background()))
+
+	// Exports of both signals are dropped once the file is closed.
+	exportNamed(t, ex, "late-span")
+	require.NoError(t, ex.Export(context.Background(), checkpointSet))
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"Name":"span"`)
+	assert.Contains(t, lines[1], `"Name":"test.sum{R=V}"`)
+}
+
+func TestExporterWithFileAppends(t *testing.T) {
+	path := filepath.Join(tempDir(t), "spans.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte("existing\n"), 0644))
+
+	ex, err := stdout.NewExporter(stdout.WithFile(path))
+	require.NoError(t, err)
+	exportNamed(t, ex, "span")
+	require.NoError(t, ex.Shutdown(context.Background()))
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "existing\n"))
+	assert.Contains(t, string(data), `"Name":"span"`)
+}
+
+func TestExporterWithFileRotation(t *testing.T) {
+	path := filepath.Join(tempDir(t), "spans.json")
+	// Every exported batch is larger than half of the maximum size so each
+	// write after the first rotates the file.
+	ex, err := stdout.NewExporter(
+		stdout.WithFile(path),
+		stdout.WithFileRotation(300, 2),
+		stdout.WithoutTimestamps(),
+	)
+	require.NoError(t, err)
+
+	for _, name := range []string{"span-1", "span-2", "span-3", "span-4"} {
+		exportNamed(t, ex, name)
+	}
+	require.NoError(t, ex.Shutdown(context.Background()))
+
+	for path, want := range map[string]string{
+		path:        "span-4",
+		path + ".1": "span-3",
+		path + ".2": "span-2",
+	} {
+		data, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"Name":"`+want+`"`, path)
+	}
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err), "unexpected backup beyond the retained count")
+}
+
+func TestExporterWithFileRotationWithoutBackups(t *testing.T) {
+	path := filepath.Join(tempDir(t), "spans.json")
+	ex, err := stdout.NewExporter(stdout.WithFile(path), stdout.WithFileRotation(300, 0))
+	require.NoError(t, err)
+
+	exportNamed(t, ex, "span-1")
+	exportNamed(t, ex, "span-2")
+	require.NoError(t, ex.Shutdown(context.Background()))
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"Name":"span-1"`)
+	assert.Contains(t, string(data), `"Name":"span-2"`)
+	_, err = os.Stat(path + ".1")
+	assert.True(t, os.IsNotExist(err))
+}
+
+type errorRecorder struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+func (r *errorRecorder) Handle(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = append(r.errs, err)
+}
+
+func (r *errorRecorder) recorded() []error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.errs
+}
+
+func TestExporterWithFileRotationFailure(t *testing.T) {
+	handler := &errorRecorder{}
+	otel.SetSignalErrorHandler(otel.TracesSignal, handler)
+	defer otel.SetSignalErrorHandler(otel.TracesSignal, nil)
+
+	path := filepath.Join(tempDir(t), "spans.json")
+	// The file cannot be moved to a non-empty directory.
+	require.NoError(t, os.MkdirAll(filepath.Join(path+".1", "blocked"), 0755))
+	ex, err := stdout.NewExporter(
+		stdout.WithFile(path),
+		stdout.WithFileRotation(300, 1),
+		stdout.WithoutTimestamps(),
+	)
+	require.NoError(t, err)
+
+	exportNamed(t, ex, "span-1")
+	exportNamed(t, ex, "span-2")
+	require.NoError(t, ex.Shutdown(context.Background()))
+
+	// The spans are still written to the current file.
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"span-1"`)
+	assert.Contains(t, string(data), `"Name":"span-2"`)
+	assert.Len(t, handler.recorded(), 1)
+	_, err = os.Stat(path + ".next")
+	assert.True(t, os.IsNotExist(err), "new file not removed")
+}
+
+func TestExporterWithFileInvalidPath(t *testing.T) {
+	path := filepath.Join(tempDir(t), "missing", "spans.json")
+	_, err := stdout.NewExporter(stdout.WithFile(path))
+	assert.Error(t, err)
+}
+
+func TestNewConfigWithFile(t *testing.T) {
+	path := filepath.Join(tempDir(t), "spans.json")
+	config, err := stdout.NewConfig(stdout.WithFile(path))
+	require.NoError(t, err)
+	assert.Equal(t, path, config.FilePath)
+
+	// The file is only opened by NewExporter.
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
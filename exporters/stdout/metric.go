@@ -19,16 +19,28 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
 	exportmetric "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 )
 
 type metricExporter struct {
 	config Config
+
+	// sumsMu protects sums.
+	sumsMu sync.Mutex
+	// sums are the cumulative sums of the previous export, by line name,
+	// used to print deltas when MetricDeltas is configured. The lines that
+	// are not exported again are dropped.
+	sums map[string]number.Number
+
+	stoppedMu sync.RWMutex
+	stopped   bool
 }
 
 var _ exportmetric.Exporter = &metricExporter{}
@@ -38,6 +50,7 @@ type line struct {
 	Min       interface{} `json:"Min,omitempty"`
 	Max       interface{} `json:"Max,omitempty"`
 	Sum       interface{} `json:"Sum,omitempty"`
+	Delta     interface{} `json:"Delta,omitempty"`
 	Count     interface{} `json:"Count,omitempty"`
 	LastValue interface{} `json:"Last,omitempty"`
 
@@ -46,13 +59,23 @@ type line struct {
 }
 
 func (e *metricExporter) ExportKindFor(desc *metric.Descriptor, kind aggregation.Kind) exportmetric.ExportKind {
+	if e.config.MetricDeltas && kind == aggregation.SumKind {
+		return exportmetric.CumulativeExportKind
+	}
 	return exportmetric.StatelessExportKindSelector().ExportKindFor(desc, kind)
 }
 
 func (e *metricExporter) Export(_ context.Context, checkpointSet exportmetric.CheckpointSet) error {
-	if e.config.DisableMetricExport {
+	e.stoppedMu.RLock()
+	stopped := e.stopped
+	e.stoppedMu.RUnlock()
+	if stopped || e.config.DisableMetricExport {
 		return nil
 	}
+	e.sumsMu.Lock()
+	defer e.sumsMu.Unlock()
+	sums := make(map[string]number.Number, len(e.sums))
+
 	var aggError error
 	var batch []line
 	aggError = checkpointSet.ForEach(e, func(record exportmetric.Record) error {
@@ -137,9 +160,27 @@ func (e *metricExporter) Export(_ context.Context, checkpointSet exportmetric.Ch
 
 		expose.Name = sb.String()
 
+		if e.config.MetricDeltas && agg.Kind() == aggregation.SumKind {
+			value, err := agg.(aggregation.Sum).Sum()
+			if err != nil {
+				return err
+			}
+			delta := e.delta(sums, expose.Name, kind, value)
+			expose.Delta = delta.AsInterface(kind)
+		}
+
 		batch = append(batch, expose)
 		return nil
 	})
+	if aggError != nil {
+		// Keep the sums of the lines that may not have been visited.
+		for name, sum := range e.sums {
+			if _, ok := sums[name]; !ok {
+				sums[name] = sum
+			}
+		}
+	}
+	e.sums = sums
 	if len(batch) == 0 {
 		return aggError
 	}
@@ -153,6 +194,25 @@ func (e *metricExporter) Export(_ context.Context, checkpointSet exportmetric.Ch
 	return aggError
 }
 
+// stop makes subsequent calls to Export no-ops.
+func (e *metricExporter) stop() {
+	e.stoppedMu.Lock()
+	e.stopped = true
+	e.stoppedMu.Unlock()
+}
+
+// delta returns the change of the cumulative sum of the line name since the
+// previous export and records sum in next for the next export. The sumsMu
+// lock needs to be held by the caller.
+func (e *metricExporter) delta(next map[string]number.Number, name string, kind number.Kind, sum number.Number) number.Number {
+	prev := e.sums[name]
+	next[name] = sum
+	if kind == number.Int64Kind {
+		return number.NewInt64Number(sum.AsInt64() - prev.AsInt64())
+	}
+	return number.NewFloat64Number(sum.AsFloat64() - prev.AsFloat64())
+}
+
 // marshal v with approriate indentation.
 func (e *metricExporter) marshal(v interface{}) ([]byte, error) {
 	if e.config.PrettyPrint {
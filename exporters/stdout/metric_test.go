@@ -31,6 +31,7 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/number"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/export/metric/metrictest"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
@@ -146,6 +147,74 @@ func TestStdoutCounterFormat(t *testing.T) {
 	require.Equal(t, `[{"Name":"test.name{R=V,A=B,C=D}","Sum":123}]`, fix.Output())
 }
 
+func TestStdoutCounterDeltas(t *testing.T) {
+	fix := newFixture(t, stdout.WithMetricDeltas())
+
+	desc := metric.NewDescriptor("test.name", metric.CounterInstrumentKind, number.Int64Kind)
+	assert.Equal(t, export.CumulativeExportKind, fix.exporter.ExportKindFor(&desc, aggregation.SumKind))
+
+	for _, cumulative := range []int64{123, 150, 150} {
+		checkpointSet := metrictest.NewCheckpointSet(testResource)
+		cagg, ckpt := metrictest.Unslice2(sum.New(2))
+		aggregatortest.CheckedUpdate(fix.t, cagg, number.NewInt64Number(cumulative), &desc)
+		require.NoError(t, cagg.SynchronizedMove(ckpt, &desc))
+		checkpointSet.Add(&desc, ckpt, attribute.String("A", "B"))
+		fix.Export(checkpointSet)
+	}
+
+	require.Equal(t, strings.Join([]string{
+		`[{"Name":"test.name{R=V,A=B}","Sum":123,"Delta":123}]`,
+		`[{"Name":"test.name{R=V,A=B}","Sum":150,"Delta":27}]`,
+		`[{"Name":"test.name{R=V,A=B}","Sum":150,"Delta":0}]`,
+	}, "\n"), fix.Output())
+}
+
+func TestStdoutCounterDeltasDropStaleLines(t *testing.T) {
+	fix := newFixture(t, stdout.WithMetricDeltas())
+
+	desc := metric.NewDescriptor("test.name", metric.CounterInstrumentKind, number.Int64Kind)
+	for _, export := range []struct {
+		label      attribute.KeyValue
+		cumulative int64
+	}{
+		{attribute.String("A", "B"), 100},
+		{attribute.String("C", "D"), 5},
+		{attribute.String("A", "B"), 130},
+	} {
+		checkpointSet := metrictest.NewCheckpointSet(testResource)
+		cagg, ckpt := metrictest.Unslice2(sum.New(2))
+		aggregatortest.CheckedUpdate(fix.t, cagg, number.NewInt64Number(export.cumulative), &desc)
+		require.NoError(t, cagg.SynchronizedMove(ckpt, &desc))
+		checkpointSet.Add(&desc, ckpt, export.label)
+		fix.Export(checkpointSet)
+	}
+
+	// The sum of A=B is dropped once it is not exported, so it starts
+	// over.
+	require.Equal(t, strings.Join([]string{
+		`[{"Name":"test.name{R=V,A=B}","Sum":100,"Delta":100}]`,
+		`[{"Name":"test.name{R=V,C=D}","Sum":5,"Delta":5}]`,
+		`[{"Name":"test.name{R=V,A=B}","Sum":130,"Delta":130}]`,
+	}, "\n"), fix.Output())
+}
+
+func TestStdoutMinMaxSumCountWithDeltas(t *testing.T) {
+	fix := newFixture(t, stdout.WithMetricDeltas())
+
+	desc := metric.NewDescriptor("test.name", metric.ValueRecorderInstrumentKind, number.Float64Kind)
+	assert.Equal(t, export.DeltaExportKind, fix.exporter.ExportKindFor(&desc, aggregation.MinMaxSumCountKind))
+
+	checkpointSet := metrictest.NewCheckpointSet(testResource)
+	magg, ckpt := metrictest.Unslice2(minmaxsumcount.New(2, &desc))
+	aggregatortest.CheckedUpdate(fix.t, magg, number.NewFloat64Number(123.456), &desc)
+	require.NoError(t, magg.SynchronizedMove(ckpt, &desc))
+	checkpointSet.Add(&desc, ckpt, attribute.String("A", "B"))
+	fix.Export(checkpointSet)
+
+	// Only sums of adding instruments have a delta.
+	assert.NotContains(t, fix.Output(), "Delta")
+}
+
 func TestStdoutLastValueFormat(t *testing.T) {
 	fix := newFixture(t)
 
@@ -20,7 +20,8 @@ import (
 	"fmt"
 	"sync"
 
-	"go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 // Exporter is an implementation of trace.SpanSyncer that writes spans to stdout.
@@ -31,8 +32,8 @@ type traceExporter struct {
 	stopped   bool
 }
 
-// ExportSpans writes SpanSnapshots in json format to stdout.
-func (e *traceExporter) ExportSpans(ctx context.Context, ss []*trace.SpanSnapshot) error {
+// ExportSpans writes spans in json format to stdout.
+func (e *traceExporter) ExportSpans(ctx context.Context, spans []trace.ReadOnlySpan) error {
 	e.stoppedMu.RLock()
 	stopped := e.stopped
 	e.stoppedMu.RUnlock()
@@ -40,10 +41,10 @@ func (e *traceExporter) ExportSpans(ctx context.Context, ss []*trace.SpanSnapsho
 		return nil
 	}
 
-	if e.config.DisableTraceExport || len(ss) == 0 {
+	if e.config.DisableTraceExport || len(spans) == 0 {
 		return nil
 	}
-	out, err := e.marshal(ss)
+	out, err := e.marshal(tracetest.SpanStubsFromReadOnlySpans(spans))
 	if err != nil {
 		return err
 	}
@@ -51,18 +52,11 @@ func (e *traceExporter) ExportSpans(ctx context.Context, ss []*trace.SpanSnapsho
 	return err
 }
 
-// Shutdown is called to stop the exporter, it preforms no action.
-func (e *traceExporter) Shutdown(ctx context.Context) error {
+// stop makes subsequent calls to ExportSpans no-ops.
+func (e *traceExporter) stop() {
 	e.stoppedMu.Lock()
 	e.stopped = true
 	e.stoppedMu.Unlock()
-
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	default:
-	}
-	return nil
 }
 
 // marshal v with approriate indentation.
@@ -25,8 +25,9 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/stdout"
-	export "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -47,7 +48,7 @@ func TestExporter_ExportSpan(t *testing.T) {
 	doubleValue := 123.456
 	resource := resource.NewWithAttributes(attribute.String("rk1", "rv11"))
 
-	testSpan := &export.SpanSnapshot{
+	testSpan := tracetest.SpanStub{
 		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    traceID,
 			SpanID:     spanID,
@@ -68,8 +69,8 @@ func TestExporter_ExportSpan(t *testing.T) {
 		StatusCode:    codes.Error,
 		StatusMessage: "interesting",
 		Resource:      resource,
-	}
-	if err := ex.ExportSpans(context.Background(), []*export.SpanSnapshot{testSpan}); err != nil {
+	}.Snapshot()
+	if err := ex.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{testSpan}); err != nil {
 		t.Fatal(err)
 	}
 
@@ -140,7 +141,8 @@ func TestExporter_ExportSpan(t *testing.T) {
 		`}],` +
 		`"InstrumentationLibrary":{` +
 		`"Name":"",` +
-		`"Version":""` +
+		`"Version":"",` +
+		`"SchemaURL":""` +
 		`}}]` + "\n"
 
 	if got != expectedOutput {
@@ -81,7 +81,7 @@ func ProcessFromEnv() Process {
 	if e := os.Getenv(envTags); e != "" {
 		tags, err := parseTags(e)
 		if err != nil {
-			otel.Handle(err)
+			otel.HandleSignal(otel.TracesSignal, err)
 		} else {
 			p.Tags = tags
 		}
@@ -27,10 +27,9 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	gen "go.opentelemetry.io/otel/exporters/trace/jaeger/internal/gen-go/jaeger"
-	export "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	"go.opentelemetry.io/otel/semconv"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -44,6 +43,12 @@ const (
 	keyEventName                     = "event"
 )
 
+// Jaeger span flags as defined by the Jaeger client libraries.
+const (
+	jaegerFlagSampled int32 = 0x01
+	jaegerFlagDebug   int32 = 0x02
+)
+
 type Option func(*options)
 
 // options are the options to be used when initializing a Jaeger export.
@@ -112,21 +117,32 @@ func NewRawExporter(endpointOption EndpointOption, opts ...Option) (*Exporter, e
 	var defaultServiceName string
 	defaultResource := resource.Default()
 	if value, exists := defaultResource.Set().Value(semconv.ServiceNameKey); exists {
-		defaultServiceName = value.AsString()
+		defaultServiceName = value.Emit()
 	}
 	if defaultServiceName == "" {
 		return nil, fmt.Errorf("failed to get service name from default resource")
 	}
 
+	uploadCtx, cancelUploads := context.WithCancel(context.Background())
 	e := &Exporter{
 		uploader:            uploader,
+		uploadCtx:           uploadCtx,
+		cancelUploads:       cancelUploads,
 		o:                   o,
 		defaultServiceName:  defaultServiceName,
 		resourceFromProcess: processToResource(o.Process),
-	}
-	bundler := bundler.NewBundler((*export.SpanSnapshot)(nil), func(bundle interface{}) {
-		if err := e.upload(bundle.([]*export.SpanSnapshot)); err != nil {
-			otel.Handle(err)
+		cache:               sdktrace.NewTranslationCache(sdktrace.DefaultTranslationCacheSize),
+	}
+	// The bundler creates bundles of the type of its example item, which
+	// cannot be an interface, so spans are bundled by reference.
+	bundler := bundler.NewBundler((*sdktrace.ReadOnlySpan)(nil), func(bundle interface{}) {
+		refs := bundle.([]*sdktrace.ReadOnlySpan)
+		spans := make([]sdktrace.ReadOnlySpan, len(refs))
+		for i, ref := range refs {
+			spans[i] = *ref
+		}
+		if err := e.upload(e.uploadCtx, spans); err != nil {
+			otel.HandleSignal(otel.TracesSignal, err)
 		}
 	})
 
@@ -200,14 +216,21 @@ type Exporter struct {
 	stoppedMu sync.RWMutex
 	stopped   bool
 
+	// uploadCtx is the context of the uploads of the bundler, it is
+	// canceled by cancelUploads when Shutdown returns, stopping the
+	// retries of failing uploads.
+	uploadCtx     context.Context
+	cancelUploads context.CancelFunc
+
 	defaultServiceName  string
 	resourceFromProcess *resource.Resource
+	cache               sdktrace.TranslationCache
 }
 
-var _ export.SpanExporter = (*Exporter)(nil)
+var _ sdktrace.SpanExporter = (*Exporter)(nil)
 
-// ExportSpans exports SpanSnapshots to Jaeger.
-func (e *Exporter) ExportSpans(ctx context.Context, ss []*export.SpanSnapshot) error {
+// ExportSpans exports spans to Jaeger.
+func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
 	e.stoppedMu.RLock()
 	stopped := e.stopped
 	e.stoppedMu.RUnlock()
@@ -215,11 +238,14 @@ func (e *Exporter) ExportSpans(ctx context.Context, ss []*export.SpanSnapshot) e
 		return nil
 	}
 
-	for _, span := range ss {
+	for _, span := range spans {
+		// The passed slice may be reused by the caller once this returns,
+		// bundle a reference to a copy of the span.
+		span := span
 		// TODO(jbd): Handle oversized bundlers.
-		err := e.bundler.Add(span, 1)
+		err := e.bundler.Add(&span, 1)
 		if err != nil {
-			return fmt.Errorf("failed to bundle %q: %w", span.Name, err)
+			return fmt.Errorf("failed to bundle %q: %w", span.Name(), err)
 		}
 	}
 	return nil
@@ -248,6 +274,7 @@ func (e *Exporter) Shutdown(ctx context.Context) error {
 		FlushFunc(e)
 		done <- struct{}{}
 	}(flush)
+	defer e.cancelUploads()
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
@@ -256,41 +283,41 @@ func (e *Exporter) Shutdown(ctx context.Context) error {
 	return nil
 }
 
-func spanSnapshotToThrift(ss *export.SpanSnapshot) *gen.Span {
-	tags := make([]*gen.Tag, 0, len(ss.Attributes))
-	for _, kv := range ss.Attributes {
+func spanToThrift(ss sdktrace.ReadOnlySpan) *gen.Span {
+	tags := make([]*gen.Tag, 0, len(ss.Attributes()))
+	for _, kv := range ss.Attributes() {
 		tag := keyValueToTag(kv)
 		if tag != nil {
 			tags = append(tags, tag)
 		}
 	}
 
-	if il := ss.InstrumentationLibrary; il.Name != "" {
+	if il := ss.InstrumentationLibrary(); il.Name != "" {
 		tags = append(tags, getStringTag(keyInstrumentationLibraryName, il.Name))
 		if il.Version != "" {
 			tags = append(tags, getStringTag(keyInstrumentationLibraryVersion, il.Version))
 		}
 	}
 
-	if ss.SpanKind != trace.SpanKindInternal {
+	if ss.SpanKind() != trace.SpanKindInternal {
 		tags = append(tags,
-			getStringTag(keySpanKind, ss.SpanKind.String()),
+			getStringTag(keySpanKind, ss.SpanKind().String()),
 		)
 	}
 
-	if ss.StatusCode != codes.Unset {
-		tags = append(tags, getInt64Tag(keyStatusCode, int64(ss.StatusCode)))
-		if ss.StatusMessage != "" {
-			tags = append(tags, getStringTag(keyStatusMessage, ss.StatusMessage))
+	if ss.StatusCode() != codes.Unset {
+		tags = append(tags, getInt64Tag(keyStatusCode, int64(ss.StatusCode())))
+		if ss.StatusMessage() != "" {
+			tags = append(tags, getStringTag(keyStatusMessage, ss.StatusMessage()))
 		}
 
-		if ss.StatusCode == codes.Error {
+		if ss.StatusCode() == codes.Error {
 			tags = append(tags, getBoolTag(keyError, true))
 		}
 	}
 
 	var logs []*gen.Log
-	for _, a := range ss.MessageEvents {
+	for _, a := range ss.Events() {
 		nTags := len(a.Attributes)
 		if a.Name != "" {
 			nTags++
@@ -314,7 +341,7 @@ func spanSnapshotToThrift(ss *export.SpanSnapshot) *gen.Span {
 	}
 
 	var refs []*gen.SpanRef
-	for _, link := range ss.Links {
+	for _, link := range ss.Links() {
 		tid := link.TraceID()
 		sid := link.SpanID()
 		refs = append(refs, &gen.SpanRef{
@@ -325,24 +352,38 @@ func spanSnapshotToThrift(ss *export.SpanSnapshot) *gen.Span {
 		})
 	}
 
-	tid := ss.SpanContext.TraceID()
-	sid := ss.SpanContext.SpanID()
-	psid := ss.Parent.SpanID()
+	tid := ss.SpanContext().TraceID()
+	sid := ss.SpanContext().SpanID()
+	psid := ss.Parent().SpanID()
 	return &gen.Span{
 		TraceIdHigh:   int64(binary.BigEndian.Uint64(tid[0:8])),
 		TraceIdLow:    int64(binary.BigEndian.Uint64(tid[8:16])),
 		SpanId:        int64(binary.BigEndian.Uint64(sid[:])),
 		ParentSpanId:  int64(binary.BigEndian.Uint64(psid[:])),
-		OperationName: ss.Name, // TODO: if span kind is added then add prefix "Sent"/"Recv"
-		Flags:         int32(ss.SpanContext.TraceFlags()),
-		StartTime:     ss.StartTime.UnixNano() / 1000,
-		Duration:      ss.EndTime.Sub(ss.StartTime).Nanoseconds() / 1000,
+		OperationName: ss.Name(), // TODO: if span kind is added then add prefix "Sent"/"Recv"
+		Flags:         spanFlags(ss.SpanContext()),
+		StartTime:     ss.StartTime().UnixNano() / 1000,
+		Duration:      ss.EndTime().Sub(ss.StartTime()).Nanoseconds() / 1000,
 		Tags:          tags,
 		Logs:          logs,
 		References:    refs,
 	}
 }
 
+// spanFlags returns the Jaeger span flags for the trace flags of sc. The bit
+// layout of the Jaeger flags differs from the OpenTelemetry trace flags, they
+// cannot be converted directly.
+func spanFlags(sc trace.SpanContext) int32 {
+	var flags int32
+	if sc.IsSampled() {
+		flags |= jaegerFlagSampled
+	}
+	if sc.IsDebug() {
+		flags |= jaegerFlagDebug
+	}
+	return flags
+}
+
 func keyValueToTag(keyValue attribute.KeyValue) *gen.Tag {
 	var tag *gen.Tag
 	switch keyValue.Value.Type() {
@@ -374,8 +415,8 @@ func keyValueToTag(keyValue attribute.KeyValue) *gen.Tag {
 			VDouble: &f,
 			VType:   gen.TagType_DOUBLE,
 		}
-	case attribute.ARRAY:
-		json, _ := json.Marshal(keyValue.Value.AsArray())
+	case attribute.BOOLSLICE, attribute.INT64SLICE, attribute.FLOAT64SLICE, attribute.STRINGSLICE:
+		json, _ := json.Marshal(keyValue.Value.AsInterface())
 		a := (string)(json)
 		tag = &gen.Tag{
 			Key:   string(keyValue.Key),
@@ -417,10 +458,10 @@ func (e *Exporter) Flush() {
 	flush(e)
 }
 
-func (e *Exporter) upload(spans []*export.SpanSnapshot) error {
-	batchList := jaegerBatchList(spans, e.defaultServiceName, e.resourceFromProcess)
+func (e *Exporter) upload(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	batchList := jaegerBatchList(spans, e.defaultServiceName, e.resourceFromProcess, e.cache)
 	for _, batch := range batchList {
-		err := e.uploader.upload(batch)
+		err := e.uploader.upload(ctx, batch)
 		if err != nil {
 			return err
 		}
@@ -429,34 +470,47 @@ func (e *Exporter) upload(spans []*export.SpanSnapshot) error {
 	return nil
 }
 
-// jaegerBatchList transforms a slice of SpanSnapshot into a slice of jaeger
-// Batch.
-func jaegerBatchList(ssl []*export.SpanSnapshot, defaultServiceName string, resourceFromProcess *resource.Resource) []*gen.Batch {
+// jaegerBatchList transforms a slice of spans into a slice of jaeger
+// Batch. The jaeger Process of each span Resource is memoized in cache,
+// which may be nil to not cache them.
+func jaegerBatchList(ssl []sdktrace.ReadOnlySpan, defaultServiceName string, resourceFromProcess *resource.Resource, cache sdktrace.TranslationCache) []*gen.Batch {
 	if len(ssl) == 0 {
 		return nil
 	}
 
-	batchDict := make(map[attribute.Distinct]*gen.Batch)
+	batchDict := make(map[resource.Key]*gen.Batch)
 
 	for _, ss := range ssl {
 		if ss == nil {
 			continue
 		}
 
-		newResource := ss.Resource
-		if resourceFromProcess != nil {
-			// The value from process will overwrite the value from span's resources
-			newResource = resource.Merge(ss.Resource, resourceFromProcess)
+		translate := func(r *resource.Resource) interface{} {
+			if resourceFromProcess != nil {
+				// The value from process will overwrite the value from span's resources.
+				// The schema URL is not exported, its conflicts are ignored.
+				r, _ = resource.Merge(r, resourceFromProcess)
+			}
+			return batchProcess{
+				key:     r.Key(),
+				process: process(r, defaultServiceName),
+			}
 		}
-		resourceKey := newResource.Equivalent()
+		var bp batchProcess
+		if cache != nil {
+			bp = cache.Resource(ss.Resource(), translate).(batchProcess)
+		} else {
+			bp = translate(ss.Resource()).(batchProcess)
+		}
+		resourceKey := bp.key
 		batch, bOK := batchDict[resourceKey]
 		if !bOK {
 			batch = &gen.Batch{
-				Process: process(newResource, defaultServiceName),
+				Process: bp.process,
 				Spans:   []*gen.Span{},
 			}
 		}
-		batch.Spans = append(batch.Spans, spanSnapshotToThrift(ss))
+		batch.Spans = append(batch.Spans, spanToThrift(ss))
 		batchDict[resourceKey] = batch
 	}
 
@@ -468,6 +522,13 @@ func jaegerBatchList(ssl []*export.SpanSnapshot, defaultServiceName string, reso
 	return batchList
 }
 
+// batchProcess is the jaeger Process of the spans of a Batch along with the
+// equivalence key of the Resource it was translated from.
+type batchProcess struct {
+	key     resource.Key
+	process *gen.Process
+}
+
 // process transforms an OTel Resource into a jaeger Process.
 func process(res *resource.Resource, defaultServiceName string) *gen.Process {
 	var process gen.Process
@@ -488,10 +549,12 @@ func process(res *resource.Resource, defaultServiceName string) *gen.Process {
 
 	// If no service.name is contained in a Span's Resource,
 	// that field MUST be populated from the default Resource.
-	if serviceName.Value.AsString() == "" {
-		serviceName = semconv.ServiceVersionKey.String(defaultServiceName)
+	if serviceName.Value.Type() != attribute.INVALID {
+		process.ServiceName = serviceName.Value.Emit()
+	}
+	if process.ServiceName == "" {
+		process.ServiceName = defaultServiceName
 	}
-	process.ServiceName = serviceName.Value.AsString()
 
 	return &process
 }
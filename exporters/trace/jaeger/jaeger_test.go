@@ -34,11 +34,11 @@ import (
 	"go.opentelemetry.io/otel/codes"
 	gen "go.opentelemetry.io/otel/exporters/trace/jaeger/internal/gen-go/jaeger"
 	ottest "go.opentelemetry.io/otel/internal/internaltest"
-	export "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -112,8 +112,10 @@ func TestNewExportPipeline(t *testing.T) {
 			expectedProviderType: trace.NewNoopTracerProvider(),
 		},
 		{
-			name:     "always on",
-			endpoint: WithCollectorEndpoint(collectorEndpoint),
+			name: "always on",
+			// The sampled span is uploaded on shutdown, don't retry
+			// uploading it to the collector that isn't running.
+			endpoint: WithCollectorEndpoint(collectorEndpoint, WithMaxRetryElapsedTime(0)),
 			options: []Option{
 				WithSDKOptions(sdktrace.WithSampler(sdktrace.AlwaysSample())),
 			},
@@ -281,7 +283,7 @@ type testCollectorEndpoint struct {
 	batchesUploaded []*gen.Batch
 }
 
-func (c *testCollectorEndpoint) upload(batch *gen.Batch) error {
+func (c *testCollectorEndpoint) upload(_ context.Context, batch *gen.Batch) error {
 	c.batchesUploaded = append(c.batchesUploaded, batch)
 	return nil
 }
@@ -346,7 +348,7 @@ func TestExporter_ExportSpan(t *testing.T) {
 	assert.Equal(t, tagVal, uploadedBatch.GetProcess().GetTags()[0].GetVStr())
 }
 
-func Test_spanSnapshotToThrift(t *testing.T) {
+func Test_spanToThrift(t *testing.T) {
 	now := time.Now()
 	traceID, _ := trace.TraceIDFromHex("0102030405060708090a0b0c0d0e0f10")
 	spanID, _ := trace.SpanIDFromHex("0102030405060708")
@@ -371,12 +373,12 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 
 	tests := []struct {
 		name string
-		data *export.SpanSnapshot
+		data sdktrace.ReadOnlySpan
 		want *gen.Span
 	}{
 		{
 			name: "no status description",
-			data: &export.SpanSnapshot{
+			data: tracetest.SpanStub{
 				SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
 					TraceID: traceID,
 					SpanID:  spanID,
@@ -390,7 +392,7 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 					Name:    instrLibName,
 					Version: instrLibVersion,
 				},
-			},
+			}.Snapshot(),
 			want: &gen.Span{
 				TraceIdLow:    651345242494996240,
 				TraceIdHigh:   72623859790382856,
@@ -410,7 +412,7 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 		},
 		{
 			name: "no parent",
-			data: &export.SpanSnapshot{
+			data: tracetest.SpanStub{
 				SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
 					TraceID: traceID,
 					SpanID:  spanID,
@@ -441,7 +443,7 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 					Name:    instrLibName,
 					Version: instrLibVersion,
 				},
-			},
+			}.Snapshot(),
 			want: &gen.Span{
 				TraceIdLow:    651345242494996240,
 				TraceIdHigh:   72623859790382856,
@@ -489,7 +491,7 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 		},
 		{
 			name: "with parent",
-			data: &export.SpanSnapshot{
+			data: tracetest.SpanStub{
 				SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
 					TraceID: traceID,
 					SpanID:  spanID,
@@ -510,7 +512,7 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 				StartTime: now,
 				EndTime:   now,
 				Attributes: []attribute.KeyValue{
-					attribute.Array("arr", []int{0, 1, 2, 3}),
+					attribute.IntSlice("arr", []int{0, 1, 2, 3}),
 				},
 				StatusCode:    codes.Unset,
 				StatusMessage: statusMessage,
@@ -519,7 +521,7 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 					Name:    instrLibName,
 					Version: instrLibVersion,
 				},
-			},
+			}.Snapshot(),
 			want: &gen.Span{
 				TraceIdLow:    651345242494996240,
 				TraceIdHigh:   72623859790382856,
@@ -546,7 +548,7 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 		},
 		{
 			name: "resources do not affect the tags",
-			data: &export.SpanSnapshot{
+			data: tracetest.SpanStub{
 				SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
 					TraceID: traceID,
 					SpanID:  spanID,
@@ -570,7 +572,7 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 					Name:    instrLibName,
 					Version: instrLibVersion,
 				},
-			},
+			}.Snapshot(),
 			want: &gen.Span{
 				TraceIdLow:    651345242494996240,
 				TraceIdHigh:   72623859790382856,
@@ -588,7 +590,7 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := spanSnapshotToThrift(tt.data)
+			got := spanToThrift(tt.data)
 			sort.Slice(got.Tags, func(i, j int) bool {
 				return got.Tags[i].Key < got.Tags[j].Key
 			})
@@ -602,6 +604,29 @@ func Test_spanSnapshotToThrift(t *testing.T) {
 	}
 }
 
+func TestSpanFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags byte
+		want  int32
+	}{
+		{name: "none", flags: 0, want: 0},
+		{name: "sampled", flags: trace.FlagsSampled, want: jaegerFlagSampled},
+		{name: "debug", flags: trace.FlagsDebug, want: jaegerFlagDebug},
+		{name: "sampled and debug", flags: trace.FlagsSampled | trace.FlagsDebug, want: jaegerFlagSampled | jaegerFlagDebug},
+		{name: "deferred is not propagated", flags: trace.FlagsSampled | trace.FlagsDeferred, want: jaegerFlagSampled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := trace.NewSpanContext(trace.SpanContextConfig{TraceFlags: tt.flags})
+			assert.Equal(t, tt.want, spanFlags(sc))
+
+			got := spanToThrift(tracetest.SpanStub{SpanContext: sc}.Snapshot())
+			assert.Equal(t, tt.want, got.Flags)
+		})
+	}
+}
+
 func TestExporterShutdownHonorsCancel(t *testing.T) {
 	orig := flush
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
@@ -642,6 +667,14 @@ func TestExporterShutdownHonorsTimeout(t *testing.T) {
 	innerCancel()
 }
 
+func TestExporterShutdownCancelsUploads(t *testing.T) {
+	e, err := NewRawExporter(withTestCollectorEndpoint())
+	require.NoError(t, err)
+	assert.NoError(t, e.uploadCtx.Err())
+	assert.NoError(t, e.Shutdown(context.Background()))
+	assert.Equal(t, context.Canceled, e.uploadCtx.Err())
+}
+
 func TestErrorOnExportShutdownExporter(t *testing.T) {
 	e, err := NewRawExporter(withTestCollectorEndpoint())
 	require.NoError(t, err)
@@ -658,20 +691,20 @@ func TestJaegerBatchList(t *testing.T) {
 
 	testCases := []struct {
 		name                string
-		spanSnapshotList    []*export.SpanSnapshot
+		spanList            []sdktrace.ReadOnlySpan
 		defaultServiceName  string
 		resourceFromProcess *resource.Resource
 		expectedBatchList   []*gen.Batch
 	}{
 		{
 			name:              "no span shots",
-			spanSnapshotList:  nil,
+			spanList:          nil,
 			expectedBatchList: nil,
 		},
 		{
 			name: "span's snapshot contains nil span",
-			spanSnapshotList: []*export.SpanSnapshot{
-				{
+			spanList: []sdktrace.ReadOnlySpan{
+				tracetest.SpanStub{
 					Name: "s1",
 					Resource: resource.NewWithAttributes(
 						semconv.ServiceNameKey.String("name"),
@@ -679,7 +712,7 @@ func TestJaegerBatchList(t *testing.T) {
 					),
 					StartTime: now,
 					EndTime:   now,
-				},
+				}.Snapshot(),
 				nil,
 			},
 			expectedBatchList: []*gen.Batch{
@@ -704,7 +737,7 @@ func TestJaegerBatchList(t *testing.T) {
 		},
 		{
 			name: "merge spans that have the same resources",
-			spanSnapshotList: []*export.SpanSnapshot{
+			spanList: tracetest.SpanStubs{
 				{
 					Name: "s1",
 					Resource: resource.NewWithAttributes(
@@ -732,7 +765,7 @@ func TestJaegerBatchList(t *testing.T) {
 					StartTime: now,
 					EndTime:   now,
 				},
-			},
+			}.Snapshots(),
 			expectedBatchList: []*gen.Batch{
 				{
 					Process: &gen.Process{
@@ -779,7 +812,7 @@ func TestJaegerBatchList(t *testing.T) {
 		},
 		{
 			name: "merge resources that come from process",
-			spanSnapshotList: []*export.SpanSnapshot{
+			spanList: tracetest.SpanStubs{
 				{
 					Name: "s1",
 					Resource: resource.NewWithAttributes(
@@ -790,7 +823,7 @@ func TestJaegerBatchList(t *testing.T) {
 					StartTime: now,
 					EndTime:   now,
 				},
-			},
+			}.Snapshots(),
 			resourceFromProcess: resource.NewWithAttributes(
 				semconv.ServiceNameKey.String("new-name"),
 				attribute.Key("r1").String("v2"),
@@ -818,15 +851,15 @@ func TestJaegerBatchList(t *testing.T) {
 		},
 		{
 			name: "span's snapshot contains no service name but resourceFromProcess does",
-			spanSnapshotList: []*export.SpanSnapshot{
-				{
+			spanList: []sdktrace.ReadOnlySpan{
+				tracetest.SpanStub{
 					Name: "s1",
 					Resource: resource.NewWithAttributes(
 						attribute.Key("r1").String("v1"),
 					),
 					StartTime: now,
 					EndTime:   now,
-				},
+				}.Snapshot(),
 				nil,
 			},
 			resourceFromProcess: resource.NewWithAttributes(
@@ -854,15 +887,15 @@ func TestJaegerBatchList(t *testing.T) {
 		},
 		{
 			name: "no service name in spans and resourceFromProcess",
-			spanSnapshotList: []*export.SpanSnapshot{
-				{
+			spanList: []sdktrace.ReadOnlySpan{
+				tracetest.SpanStub{
 					Name: "s1",
 					Resource: resource.NewWithAttributes(
 						attribute.Key("r1").String("v1"),
 					),
 					StartTime: now,
 					EndTime:   now,
-				},
+				}.Snapshot(),
 				nil,
 			},
 			defaultServiceName: "default service name",
@@ -890,13 +923,50 @@ func TestJaegerBatchList(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			batchList := jaegerBatchList(tc.spanSnapshotList, tc.defaultServiceName, tc.resourceFromProcess)
+			batchList := jaegerBatchList(tc.spanList, tc.defaultServiceName, tc.resourceFromProcess, nil)
 
 			assert.ElementsMatch(t, tc.expectedBatchList, batchList)
+
+			cache := sdktrace.NewTranslationCache(0)
+			for i := 0; i < 2; i++ {
+				batchList = jaegerBatchList(tc.spanList, tc.defaultServiceName, tc.resourceFromProcess, cache)
+				assert.ElementsMatch(t, tc.expectedBatchList, batchList)
+			}
 		})
 	}
 }
 
+func BenchmarkJaegerBatchList(b *testing.B) {
+	attrs := make([]attribute.KeyValue, 0, 20)
+	for i := 0; i < cap(attrs); i++ {
+		attrs = append(attrs, attribute.String(fmt.Sprintf("key%d", i), "value"))
+	}
+	res := resource.NewWithAttributes(attrs...)
+	stubs := make(tracetest.SpanStubs, 0, 10)
+	for i := 0; i < cap(stubs); i++ {
+		stubs = append(stubs, tracetest.SpanStub{
+			Name:     fmt.Sprintf("span%d", i),
+			Resource: res,
+		})
+	}
+	spans := stubs.Snapshots()
+	fromProcess := resource.NewWithAttributes(attribute.String("process", "tag"))
+
+	b.Run("NoCache", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			jaegerBatchList(spans, "service", fromProcess, nil)
+		}
+	})
+	b.Run("Cache", func(b *testing.B) {
+		cache := sdktrace.NewTranslationCache(0)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			jaegerBatchList(spans, "service", fromProcess, cache)
+		}
+	})
+}
+
 func TestProcess(t *testing.T) {
 	v1 := "v1"
 
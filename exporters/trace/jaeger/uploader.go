@@ -26,13 +26,14 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel/exporters/trace/jaeger/internal/third_party/thrift/lib/go/thrift"
+	"go.opentelemetry.io/otel/internal/retry"
 
 	gen "go.opentelemetry.io/otel/exporters/trace/jaeger/internal/gen-go/jaeger"
 )
 
 // batchUploader send a batch of spans to Jaeger
 type batchUploader interface {
-	upload(batch *gen.Batch) error
+	upload(ctx context.Context, batch *gen.Batch) error
 }
 
 type EndpointOption func() (batchUploader, error)
@@ -107,6 +108,7 @@ func WithCollectorEndpoint(collectorEndpoint string, options ...CollectorEndpoin
 
 		o := &CollectorEndpointOptions{
 			httpClient: http.DefaultClient,
+			retry:      retry.DefaultConfig,
 		}
 
 		options = append(options, WithCollectorEndpointOptionFromEnv())
@@ -119,6 +121,7 @@ func WithCollectorEndpoint(collectorEndpoint string, options ...CollectorEndpoin
 			username:   o.username,
 			password:   o.password,
 			httpClient: o.httpClient,
+			retry:      o.retry,
 		}, nil
 	}
 }
@@ -134,6 +137,9 @@ type CollectorEndpointOptions struct {
 
 	// httpClient to be used to make requests to the collector endpoint.
 	httpClient *http.Client
+
+	// retry is the policy of the retries of failed uploads.
+	retry retry.Config
 }
 
 // WithUsername sets the username to be used if basic auth is required.
@@ -157,6 +163,24 @@ func WithHTTPClient(client *http.Client) CollectorEndpointOption {
 	}
 }
 
+// WithMaxRetryElapsedTime sets the time budget of the retries of an upload
+// failing with a retryable HTTP status, such as 503 Service Unavailable or
+// 429 Too Many Requests. The retries back off exponentially and honor the
+// Retry-After header of the collector. The budget is one minute by default,
+// a non-positive value disables the retries.
+func WithMaxRetryElapsedTime(d time.Duration) CollectorEndpointOption {
+	return func(o *CollectorEndpointOptions) {
+		if d <= 0 {
+			o.retry = retry.Config{}
+			return
+		}
+		if o.retry.InitialInterval <= 0 {
+			o.retry = retry.DefaultConfig
+		}
+		o.retry.MaxElapsedTime = d
+	}
+}
+
 // agentUploader implements batchUploader interface sending batches to
 // Jaeger through the UDP agent.
 type agentUploader struct {
@@ -165,7 +189,7 @@ type agentUploader struct {
 
 var _ batchUploader = (*agentUploader)(nil)
 
-func (a *agentUploader) upload(batch *gen.Batch) error {
+func (a *agentUploader) upload(ctx context.Context, batch *gen.Batch) error {
 	return a.client.EmitBatch(batch)
 }
 
@@ -176,36 +200,39 @@ type collectorUploader struct {
 	username   string
 	password   string
 	httpClient *http.Client
+	retry      retry.Config
 }
 
 var _ batchUploader = (*collectorUploader)(nil)
 
-func (c *collectorUploader) upload(batch *gen.Batch) error {
+func (c *collectorUploader) upload(ctx context.Context, batch *gen.Batch) error {
 	body, err := serialize(batch)
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest("POST", c.endpoint, body)
-	if err != nil {
-		return err
-	}
-	if c.username != "" && c.password != "" {
-		req.SetBasicAuth(c.username, c.password)
-	}
-	req.Header.Set("Content-Type", "application/x-thrift")
+	return c.retry.Do(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(body.Bytes()))
+		if err != nil {
+			return err
+		}
+		if c.username != "" && c.password != "" {
+			req.SetBasicAuth(c.username, c.password)
+		}
+		req.Header.Set("Content-Type", "application/x-thrift")
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return retry.TransportError(err)
+		}
 
-	_, _ = io.Copy(ioutil.Discard, resp.Body)
-	resp.Body.Close()
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("failed to upload traces; HTTP status code: %d", resp.StatusCode)
-	}
-	return nil
+		if err := retry.HTTPResponseError(resp); err != nil {
+			return fmt.Errorf("failed to upload traces: %w", err)
+		}
+		return nil
+	})
 }
 
 func serialize(obj thrift.TStruct) (*bytes.Buffer, error) {
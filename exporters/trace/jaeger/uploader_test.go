@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaeger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gen "go.opentelemetry.io/otel/exporters/trace/jaeger/internal/gen-go/jaeger"
+	"go.opentelemetry.io/otel/internal/retry"
+)
+
+// newFlakyCollector returns a collector responding with the statuses in
+// order, and then with 202 Accepted, and the number of its requests.
+func newFlakyCollector(statuses ...int) (*httptest.Server, *int32) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := atomic.AddInt32(&requests, 1)
+		if int(i) <= len(statuses) {
+			w.WriteHeader(statuses[i-1])
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	return srv, &requests
+}
+
+func TestCollectorUploaderRetry(t *testing.T) {
+	srv, requests := newFlakyCollector(http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	defer srv.Close()
+
+	c := &collectorUploader{
+		endpoint:   srv.URL,
+		httpClient: srv.Client(),
+		retry:      retry.Config{InitialInterval: time.Millisecond},
+	}
+	require.NoError(t, c.upload(context.Background(), &gen.Batch{Process: &gen.Process{ServiceName: "test"}}))
+	assert.Equal(t, int32(3), atomic.LoadInt32(requests))
+}
+
+func TestCollectorUploaderNoRetry(t *testing.T) {
+	srv, requests := newFlakyCollector(http.StatusBadRequest)
+	defer srv.Close()
+
+	c := &collectorUploader{
+		endpoint:   srv.URL,
+		httpClient: srv.Client(),
+		retry:      retry.Config{InitialInterval: time.Millisecond},
+	}
+	assert.Error(t, c.upload(context.Background(), &gen.Batch{Process: &gen.Process{ServiceName: "test"}}))
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+func TestCollectorUploaderCanceled(t *testing.T) {
+	srv, requests := newFlakyCollector(http.StatusServiceUnavailable)
+	defer srv.Close()
+
+	c := &collectorUploader{
+		endpoint:   srv.URL,
+		httpClient: srv.Client(),
+		retry:      retry.Config{InitialInterval: time.Hour},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+	err := c.upload(ctx, &gen.Batch{Process: &gen.Process{ServiceName: "test"}})
+	assert.True(t, errors.Is(err, context.Canceled), err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(requests))
+}
+
+func TestWithMaxRetryElapsedTime(t *testing.T) {
+	o := &CollectorEndpointOptions{retry: retry.DefaultConfig}
+	WithMaxRetryElapsedTime(0)(o)
+	assert.Equal(t, retry.Config{}, o.retry)
+
+	WithMaxRetryElapsedTime(time.Second)(o)
+	expected := retry.DefaultConfig
+	expected.MaxElapsedTime = time.Second
+	assert.Equal(t, expected, o.retry)
+}
@@ -54,4 +54,6 @@ replace go.opentelemetry.io/otel/sdk/export/metric => ../../../sdk/export/metric
 
 replace go.opentelemetry.io/otel/sdk/metric => ../../../sdk/metric
 
+replace go.opentelemetry.io/otel/sdk/trace/selfmetrics => ../../../sdk/trace/selfmetrics
+
 replace go.opentelemetry.io/otel/trace => ../../../trace
@@ -22,8 +22,8 @@ import (
 	zkmodel "github.com/openzipkin/zipkin-go/model"
 
 	"go.opentelemetry.io/otel/attribute"
-	export "go.opentelemetry.io/otel/sdk/export/trace"
-	"go.opentelemetry.io/otel/semconv"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -32,7 +32,7 @@ const (
 	keyInstrumentationLibraryVersion = "otel.instrumentation_library.version"
 )
 
-func toZipkinSpanModels(batch []*export.SpanSnapshot) []zkmodel.SpanModel {
+func toZipkinSpanModels(batch []sdktrace.ReadOnlySpan) []zkmodel.SpanModel {
 	models := make([]zkmodel.SpanModel, 0, len(batch))
 	for _, data := range batch {
 		models = append(models, toZipkinSpanModel(data))
@@ -43,7 +43,7 @@ func toZipkinSpanModels(batch []*export.SpanSnapshot) []zkmodel.SpanModel {
 func getServiceName(attrs []attribute.KeyValue) string {
 	for _, kv := range attrs {
 		if kv.Key == semconv.ServiceNameKey {
-			return kv.Value.AsString()
+			return kv.Value.Emit()
 		}
 	}
 
@@ -51,28 +51,28 @@ func getServiceName(attrs []attribute.KeyValue) string {
 	return ""
 }
 
-func toZipkinSpanModel(data *export.SpanSnapshot) zkmodel.SpanModel {
+func toZipkinSpanModel(data sdktrace.ReadOnlySpan) zkmodel.SpanModel {
 	return zkmodel.SpanModel{
 		SpanContext: toZipkinSpanContext(data),
-		Name:        data.Name,
-		Kind:        toZipkinKind(data.SpanKind),
-		Timestamp:   data.StartTime,
-		Duration:    data.EndTime.Sub(data.StartTime),
+		Name:        data.Name(),
+		Kind:        toZipkinKind(data.SpanKind()),
+		Timestamp:   data.StartTime(),
+		Duration:    data.EndTime().Sub(data.StartTime()),
 		Shared:      false,
 		LocalEndpoint: &zkmodel.Endpoint{
-			ServiceName: getServiceName(data.Resource.Attributes()),
+			ServiceName: getServiceName(data.Resource().Attributes()),
 		},
 		RemoteEndpoint: nil, // *Endpoint
-		Annotations:    toZipkinAnnotations(data.MessageEvents),
+		Annotations:    toZipkinAnnotations(data.Events()),
 		Tags:           toZipkinTags(data),
 	}
 }
 
-func toZipkinSpanContext(data *export.SpanSnapshot) zkmodel.SpanContext {
+func toZipkinSpanContext(data sdktrace.ReadOnlySpan) zkmodel.SpanContext {
 	return zkmodel.SpanContext{
-		TraceID:  toZipkinTraceID(data.SpanContext.TraceID()),
-		ID:       toZipkinID(data.SpanContext.SpanID()),
-		ParentID: toZipkinParentID(data.Parent.SpanID()),
+		TraceID:  toZipkinTraceID(data.SpanContext().TraceID()),
+		ID:       toZipkinID(data.SpanContext().SpanID()),
+		ParentID: toZipkinParentID(data.Parent().SpanID()),
 		Debug:    false,
 		Sampled:  nil,
 		Err:      nil,
@@ -103,8 +103,9 @@ func toZipkinKind(kind trace.SpanKind) zkmodel.Kind {
 	case trace.SpanKindUnspecified:
 		return zkmodel.Undetermined
 	case trace.SpanKindInternal:
-		// The spec says we should set the kind to nil, but
-		// the model does not allow that.
+		// The spec says internal spans have no kind. Undetermined
+		// is the empty Kind, which is omitted when the span model
+		// is serialized to JSON.
 		return zkmodel.Undetermined
 	case trace.SpanKindServer:
 		return zkmodel.Server
@@ -157,18 +158,18 @@ var extraZipkinTags = []string{
 	keyInstrumentationLibraryVersion,
 }
 
-func toZipkinTags(data *export.SpanSnapshot) map[string]string {
-	m := make(map[string]string, len(data.Attributes)+len(extraZipkinTags))
-	for _, kv := range data.Attributes {
-		m[(string)(kv.Key)] = kv.Value.Emit()
+func toZipkinTags(data sdktrace.ReadOnlySpan) map[string]string {
+	m := make(map[string]string, len(data.Attributes())+len(extraZipkinTags))
+	for _, kv := range data.Attributes() {
+		m[(string)(kv.Key)] = zipkinTagValue(kv.Value)
 	}
 	if v, ok := m["error"]; ok && v == "false" {
 		delete(m, "error")
 	}
-	m["otel.status_code"] = data.StatusCode.String()
-	m["otel.status_description"] = data.StatusMessage
+	m["otel.status_code"] = data.StatusCode().String()
+	m["otel.status_description"] = data.StatusMessage()
 
-	if il := data.InstrumentationLibrary; il.Name != "" {
+	if il := data.InstrumentationLibrary(); il.Name != "" {
 		m[keyInstrumentationLibraryName] = il.Name
 		if il.Version != "" {
 			m[keyInstrumentationLibraryVersion] = il.Version
@@ -176,3 +177,37 @@ func toZipkinTags(data *export.SpanSnapshot) map[string]string {
 	}
 	return m
 }
+
+// zipkinTagValue returns the Zipkin tag value of v: slices are encoded as
+// JSON lists, other values are emitted.
+func zipkinTagValue(v attribute.Value) string {
+	switch v.Type() {
+	case attribute.BOOLSLICE, attribute.INT64SLICE, attribute.FLOAT64SLICE, attribute.STRINGSLICE:
+		encoded, _ := json.Marshal(v.AsInterface())
+		return string(encoded)
+	default:
+		return v.Emit()
+	}
+}
+
+// addResourceTags adds the resource attributes of each span in batch, with
+// their keys prefixed by prefix, to the tags of the corresponding model.
+// Existing tags are not overwritten.
+func addResourceTags(models []zkmodel.SpanModel, batch []sdktrace.ReadOnlySpan, prefix string) {
+	for i, data := range batch {
+		res := data.Resource()
+		if res == nil {
+			continue
+		}
+		if models[i].Tags == nil {
+			models[i].Tags = make(map[string]string, res.Len())
+		}
+		for iter := res.Iter(); iter.Next(); {
+			kv := iter.Attribute()
+			k := prefix + string(kv.Key)
+			if _, ok := models[i].Tags[k]; !ok {
+				models[i].Tags[k] = zipkinTagValue(kv.Value)
+			}
+		}
+	}
+}
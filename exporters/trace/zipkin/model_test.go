@@ -15,6 +15,7 @@
 package zipkin
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"testing"
@@ -27,10 +28,11 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
-	export "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
-	"go.opentelemetry.io/otel/semconv"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -39,7 +41,7 @@ func TestModelConversion(t *testing.T) {
 		semconv.ServiceNameKey.String("model-test"),
 	)
 
-	inputBatch := []*export.SpanSnapshot{
+	inputBatch := tracetest.SpanStubs{
 		// typical span data
 		{
 			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
@@ -347,7 +349,7 @@ func TestModelConversion(t *testing.T) {
 			StatusMessage: "404, file not found",
 			Resource:      resource,
 		},
-	}
+	}.Snapshots()
 
 	expectedOutputBatch := []zkmodel.SpanModel{
 		// model for typical span data
@@ -695,6 +697,26 @@ func TestModelConversion(t *testing.T) {
 	require.Equal(t, expectedOutputBatch, gottenOutputBatch)
 }
 
+func TestInternalSpanKindOmitted(t *testing.T) {
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	})
+	start := time.Date(2020, time.March, 11, 19, 24, 0, 0, time.UTC)
+	models := toZipkinSpanModels(tracetest.SpanStubs{
+		{SpanContext: spanContext, SpanKind: trace.SpanKindInternal, Name: "internal", StartTime: start, EndTime: start.Add(time.Second)},
+		{SpanContext: spanContext, SpanKind: trace.SpanKindServer, Name: "server", StartTime: start, EndTime: start.Add(time.Second)},
+	}.Snapshots())
+	body, err := json.Marshal(models)
+	require.NoError(t, err)
+
+	var spans []map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &spans))
+	require.Len(t, spans, 2)
+	assert.NotContains(t, spans[0], "kind")
+	assert.Equal(t, "SERVER", spans[1]["kind"])
+}
+
 func zkmodelIDPtr(n uint64) *zkmodel.ID {
 	id := zkmodel.ID(n)
 	return &id
@@ -710,19 +732,19 @@ func Test_toZipkinTags(t *testing.T) {
 
 	tests := []struct {
 		name string
-		data *export.SpanSnapshot
+		data sdktrace.ReadOnlySpan
 		want map[string]string
 	}{
 		{
 			name: "attributes",
-			data: &export.SpanSnapshot{
+			data: tracetest.SpanStub{
 				Attributes: []attribute.KeyValue{
 					attribute.String("key", keyValue),
 					attribute.Float64("double", doubleValue),
 					attribute.Int64("uint", uintValue),
 					attribute.Bool("ok", true),
 				},
-			},
+			}.Snapshot(),
 			want: map[string]string{
 				"double":                  fmt.Sprint(doubleValue),
 				"key":                     keyValue,
@@ -732,9 +754,28 @@ func Test_toZipkinTags(t *testing.T) {
 				"otel.status_description": "",
 			},
 		},
+		{
+			name: "slice attributes",
+			data: tracetest.SpanStub{
+				Attributes: []attribute.KeyValue{
+					attribute.BoolSlice("bools", []bool{true, false}),
+					attribute.Int64Slice("ints", []int64{1, 2}),
+					attribute.Float64Slice("doubles", []float64{1.5, 2}),
+					attribute.StringSlice("strings", []string{"a", "b c"}),
+				},
+			}.Snapshot(),
+			want: map[string]string{
+				"bools":                   "[true,false]",
+				"ints":                    "[1,2]",
+				"doubles":                 "[1.5,2]",
+				"strings":                 `["a","b c"]`,
+				"otel.status_code":        codes.Unset.String(),
+				"otel.status_description": "",
+			},
+		},
 		{
 			name: "no attributes",
-			data: &export.SpanSnapshot{},
+			data: tracetest.SpanStub{}.Snapshot(),
 			want: map[string]string{
 				"otel.status_code":        codes.Unset.String(),
 				"otel.status_description": "",
@@ -742,11 +783,11 @@ func Test_toZipkinTags(t *testing.T) {
 		},
 		{
 			name: "omit-noerror",
-			data: &export.SpanSnapshot{
+			data: tracetest.SpanStub{
 				Attributes: []attribute.KeyValue{
 					attribute.Bool("error", false),
 				},
-			},
+			}.Snapshot(),
 			want: map[string]string{
 				"otel.status_code":        codes.Unset.String(),
 				"otel.status_description": "",
@@ -754,14 +795,14 @@ func Test_toZipkinTags(t *testing.T) {
 		},
 		{
 			name: "statusCode",
-			data: &export.SpanSnapshot{
+			data: tracetest.SpanStub{
 				Attributes: []attribute.KeyValue{
 					attribute.String("key", keyValue),
 					attribute.Bool("error", true),
 				},
 				StatusCode:    codes.Error,
 				StatusMessage: statusMessage,
-			},
+			}.Snapshot(),
 			want: map[string]string{
 				"error":                   "true",
 				"key":                     keyValue,
@@ -771,9 +812,9 @@ func Test_toZipkinTags(t *testing.T) {
 		},
 		{
 			name: "instrLib-empty",
-			data: &export.SpanSnapshot{
+			data: tracetest.SpanStub{
 				InstrumentationLibrary: instrumentation.Library{},
-			},
+			}.Snapshot(),
 			want: map[string]string{
 				"otel.status_code":        codes.Unset.String(),
 				"otel.status_description": "",
@@ -781,12 +822,12 @@ func Test_toZipkinTags(t *testing.T) {
 		},
 		{
 			name: "instrLib-noversion",
-			data: &export.SpanSnapshot{
+			data: tracetest.SpanStub{
 				Attributes: []attribute.KeyValue{},
 				InstrumentationLibrary: instrumentation.Library{
 					Name: instrLibName,
 				},
-			},
+			}.Snapshot(),
 			want: map[string]string{
 				"otel.instrumentation_library.name": instrLibName,
 				"otel.status_code":                  codes.Unset.String(),
@@ -795,13 +836,13 @@ func Test_toZipkinTags(t *testing.T) {
 		},
 		{
 			name: "instrLib-with-version",
-			data: &export.SpanSnapshot{
+			data: tracetest.SpanStub{
 				Attributes: []attribute.KeyValue{},
 				InstrumentationLibrary: instrumentation.Library{
 					Name:    instrLibName,
 					Version: instrLibVersion,
 				},
-			},
+			}.Snapshot(),
 			want: map[string]string{
 				"otel.instrumentation_library.name":    instrLibName,
 				"otel.instrumentation_library.version": instrLibVersion,
@@ -830,3 +871,24 @@ func TestServiceName(t *testing.T) {
 	attrs = append(attrs, semconv.ServiceNameKey.String("my_service"))
 	assert.Equal(t, "my_service", getServiceName(attrs))
 }
+
+func TestAddResourceTags(t *testing.T) {
+	batch := tracetest.SpanStubs{
+		{
+			Attributes: []attribute.KeyValue{
+				attribute.String("resource.host.name", "span"),
+			},
+			Resource: resource.NewWithAttributes(
+				attribute.String("k8s.pod.name", "pod-1"),
+				attribute.String("host.name", "host-1"),
+			),
+		},
+		{},
+	}.Snapshots()
+	models := toZipkinSpanModels(batch)
+	addResourceTags(models, batch, "resource.")
+
+	assert.Equal(t, "pod-1", models[0].Tags["resource.k8s.pod.name"])
+	assert.Equal(t, "span", models[0].Tags["resource.host.name"], "span attributes must take precedence")
+	assert.Equal(t, toZipkinTags(batch[1]), models[1].Tags)
+}
@@ -26,13 +26,15 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel"
-	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/internal/retry"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
-// Exporter exports SpanSnapshots to the zipkin collector. It implements
+// Exporter exports spans to the zipkin collector. It implements
 // the SpanBatcher interface, so it needs to be used together with the
 // WithBatcher option when setting up the exporter pipeline.
 type Exporter struct {
@@ -46,7 +48,7 @@ type Exporter struct {
 }
 
 var (
-	_ export.SpanExporter = &Exporter{}
+	_ sdktrace.SpanExporter = &Exporter{}
 )
 
 // Options contains configuration for the exporter.
@@ -54,12 +56,26 @@ type options struct {
 	client *http.Client
 	logger *log.Logger
 	tpOpts []sdktrace.TracerProviderOption
+
+	// resourceTags is true if resource attributes are added to span tags.
+	resourceTags bool
+	// resourceTagPrefix is prepended to the keys of resource attributes
+	// added to span tags.
+	resourceTagPrefix string
+
+	// retry is the policy of the retries of failed exports.
+	retry retry.Config
 }
 
 // Option defines a function that configures the exporter.
 type Option func(*options)
 
-// WithLogger configures the exporter to use the passed logger.
+// WithLogger configures the exporter to use the passed logger instead of
+// the Logger of OpenTelemetry.
+//
+// Deprecated: the exporter logs its diagnostics to the Logger set with
+// otel.SetLogger at the debug level, this option will be removed in a
+// future release.
 func WithLogger(logger *log.Logger) Option {
 	return func(opts *options) {
 		opts.logger = logger
@@ -80,6 +96,36 @@ func WithSDKOptions(tpOpts ...sdktrace.TracerProviderOption) Option {
 	}
 }
 
+// WithResourceTags configures the exporter to add the attributes of the
+// Resource associated with a span to its tags. The key of each tag is the
+// attribute key prefixed with prefix, e.g. a prefix of "resource." exports
+// the k8s.pod.name attribute as the resource.k8s.pod.name tag. Span
+// attributes take precedence over resource attributes with the same key.
+func WithResourceTags(prefix string) Option {
+	return func(opts *options) {
+		opts.resourceTags = true
+		opts.resourceTagPrefix = prefix
+	}
+}
+
+// WithMaxRetryElapsedTime sets the time budget of the retries of an export
+// failing with a retryable HTTP status, such as 503 Service Unavailable or
+// 429 Too Many Requests. The retries back off exponentially and honor the
+// Retry-After header of the collector. The budget is one minute by default,
+// a non-positive value disables the retries.
+func WithMaxRetryElapsedTime(d time.Duration) Option {
+	return func(opts *options) {
+		if d <= 0 {
+			opts.retry = retry.Config{}
+			return
+		}
+		if opts.retry.InitialInterval <= 0 {
+			opts.retry = retry.DefaultConfig
+		}
+		opts.retry.MaxElapsedTime = d
+	}
+}
+
 // NewRawExporter creates a new Zipkin exporter.
 func NewRawExporter(collectorURL string, opts ...Option) (*Exporter, error) {
 	if collectorURL == "" {
@@ -93,7 +139,7 @@ func NewRawExporter(collectorURL string, opts ...Option) (*Exporter, error) {
 		return nil, errors.New("invalid collector URL")
 	}
 
-	o := options{}
+	o := options{retry: retry.DefaultConfig}
 	for _, opt := range opts {
 		opt(&o)
 	}
@@ -134,8 +180,8 @@ func InstallNewPipeline(collectorURL string, opts ...Option) error {
 	return nil
 }
 
-// ExportSpans exports SpanSnapshots to a Zipkin receiver.
-func (e *Exporter) ExportSpans(ctx context.Context, ss []*export.SpanSnapshot) error {
+// ExportSpans exports spans to a Zipkin receiver.
+func (e *Exporter) ExportSpans(ctx context.Context, ss []sdktrace.ReadOnlySpan) error {
 	e.stoppedMu.RLock()
 	stopped := e.stopped
 	e.stoppedMu.RUnlock()
@@ -149,36 +195,49 @@ func (e *Exporter) ExportSpans(ctx context.Context, ss []*export.SpanSnapshot) e
 		return nil
 	}
 	models := toZipkinSpanModels(ss)
+	if e.o.resourceTags {
+		addResourceTags(models, ss, e.o.resourceTagPrefix)
+	}
 	body, err := json.Marshal(models)
 	if err != nil {
 		return e.errf("failed to serialize zipkin models to JSON: %v", err)
 	}
 	e.logf("about to send a POST request to %s with body %s", e.url, body)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewBuffer(body))
-	if err != nil {
-		return e.errf("failed to create request to %s: %v", e.url, err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := e.client.Do(req)
-	if err != nil {
-		return e.errf("request to %s failed: %v", e.url, err)
-	}
-	defer resp.Body.Close()
+	return e.o.retry.Do(ctx, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+		if err != nil {
+			return e.errf("failed to create request to %s: %v", e.url, err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := e.client.Do(req)
+		if err != nil {
+			rerr := e.errf("request to %s failed: %v", e.url, err)
+			if ok, _ := retry.IsRetryable(retry.TransportError(err)); ok {
+				return retry.Retryable(rerr, 0)
+			}
+			return rerr
+		}
+		defer resp.Body.Close()
 
-	// Zipkin API returns a 202 on success and the content of the body isn't interesting
-	// but it is still being read because according to https://golang.org/pkg/net/http/#Response
-	// > The default HTTP client's Transport may not reuse HTTP/1.x "keep-alive" TCP connections
-	// > if the Body is not read to completion and closed.
-	_, err = io.Copy(ioutil.Discard, resp.Body)
-	if err != nil {
-		return e.errf("failed to read response body: %v", err)
-	}
+		// Zipkin API returns a 202 on success and the content of the body isn't interesting
+		// but it is still being read because according to https://golang.org/pkg/net/http/#Response
+		// > The default HTTP client's Transport may not reuse HTTP/1.x "keep-alive" TCP connections
+		// > if the Body is not read to completion and closed.
+		_, err = io.Copy(ioutil.Discard, resp.Body)
+		if err != nil {
+			return e.errf("failed to read response body: %v", err)
+		}
 
-	if resp.StatusCode != http.StatusAccepted {
-		return e.errf("failed to send spans to zipkin server with status %d", resp.StatusCode)
-	}
-
-	return nil
+		if resp.StatusCode != http.StatusAccepted {
+			err := e.errf("failed to send spans to zipkin server with status %d", resp.StatusCode)
+			if retry.RetryableHTTPStatus(resp.StatusCode) {
+				after, _ := retry.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+				return retry.Retryable(err, after)
+			}
+			return err
+		}
+		return nil
+	})
 }
 
 // Shutdown stops the exporter flushing any pending exports.
@@ -195,9 +254,15 @@ func (e *Exporter) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// logf logs a diagnostic message at the debug level of the Logger of
+// OpenTelemetry, or to the deprecated logger of the exporter if it is set.
 func (e *Exporter) logf(format string, args ...interface{}) {
 	if e.logger != nil {
 		e.logger.Printf(format, args...)
+		return
+	}
+	if l := global.GetLogger(); l.Enabled(global.DebugLevel) {
+		l.Info(global.DebugLevel, fmt.Sprintf(format, args...))
 	}
 }
 
@@ -22,6 +22,7 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"sync"
 	"testing"
 	"time"
@@ -32,10 +33,11 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/codes"
-	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/internal/retry"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -229,12 +231,24 @@ func logStoreLogger(s *logStore) *log.Logger {
 	return log.New(s, "", 0)
 }
 
+// logStore also implements otel.Logger, storing the messages up to the
+// debug level.
+func (s *logStore) Enabled(level int) bool { return level <= 8 }
+
+func (s *logStore) Info(_ int, msg string, _ ...interface{}) {
+	_, _ = s.Write([]byte(msg))
+}
+
+func (s *logStore) Error(err error, msg string, _ ...interface{}) {
+	_, _ = s.Write([]byte(fmt.Sprintf("%s: %v", msg, err)))
+}
+
 func TestExportSpans(t *testing.T) {
 	resource := resource.NewWithAttributes(
 		semconv.ServiceNameKey.String("exporter-test"),
 	)
 
-	spans := []*export.SpanSnapshot{
+	spans := tracetest.SpanStubs{
 		// parent
 		{
 			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
@@ -271,7 +285,7 @@ func TestExportSpans(t *testing.T) {
 			StatusMessage: "403, forbidden",
 			Resource:      resource,
 		},
-	}
+	}.Snapshots()
 	models := []zkmodel.SpanModel{
 		// model of parent
 		{
@@ -334,8 +348,9 @@ func TestExportSpans(t *testing.T) {
 	collector := startMockZipkinCollector(t)
 	defer collector.Close()
 	ls := &logStore{T: t}
-	logger := logStoreLogger(ls)
-	exporter, err := NewRawExporter(collector.url, WithLogger(logger))
+	otel.SetLogger(ls)
+	defer otel.SetLogger(nil)
+	exporter, err := NewRawExporter(collector.url)
 	require.NoError(t, err)
 	ctx := context.Background()
 	require.Len(t, ls.Messages, 0)
@@ -356,6 +371,15 @@ func TestExportSpans(t *testing.T) {
 	require.Equal(t, models, collector.StealModels())
 }
 
+func TestWithLogger(t *testing.T) {
+	ls := &logStore{T: t}
+	exporter, err := NewRawExporter(collectorURL, WithLogger(logStoreLogger(ls)))
+	require.NoError(t, err)
+	require.NoError(t, exporter.ExportSpans(context.Background(), nil))
+	require.Len(t, ls.Messages, 1)
+	require.Contains(t, ls.Messages[0], "no spans to export")
+}
+
 func TestExporterShutdownHonorsTimeout(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
 	defer cancel()
@@ -418,3 +442,45 @@ func TestNewExportPipelineWithOptions(t *testing.T) {
 	model := collector.StealModels()[0]
 	require.Equal(t, len(model.Annotations), eventCountLimit)
 }
+
+func TestExportSpansRetry(t *testing.T) {
+	var requests int
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		n := requests
+		mu.Unlock()
+		switch n {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer srv.Close()
+
+	exp, err := NewRawExporter(srv.URL)
+	require.NoError(t, err)
+	exp.o.retry = retry.Config{InitialInterval: time.Millisecond}
+
+	spans := tracetest.SpanStubs{{Name: "foo"}}.Snapshots()
+	// The 503 is retried, the 400 is not.
+	assert.EqualError(t, exp.ExportSpans(context.Background(), spans), "failed to send spans to zipkin server with status 400")
+	assert.Equal(t, 2, requests)
+
+	require.NoError(t, exp.ExportSpans(context.Background(), spans))
+	assert.Equal(t, 3, requests)
+}
+
+func TestWithMaxRetryElapsedTime(t *testing.T) {
+	exp, err := NewRawExporter(collectorURL)
+	require.NoError(t, err)
+	assert.Equal(t, retry.DefaultConfig, exp.o.retry)
+
+	exp, err = NewRawExporter(collectorURL, WithMaxRetryElapsedTime(0))
+	require.NoError(t, err)
+	assert.Equal(t, retry.Config{}, exp.o.retry)
+}
@@ -15,10 +15,10 @@
 package otel // import "go.opentelemetry.io/otel"
 
 import (
-	"log"
-	"os"
 	"sync"
 	"sync/atomic"
+
+	"go.opentelemetry.io/otel/internal/global"
 )
 
 var (
@@ -26,9 +26,7 @@ var (
 	// throughout an OpenTelemetry instrumented project. When a user
 	// specified ErrorHandler is registered (`SetErrorHandler`) all calls to
 	// `Handle` and will be delegated to the registered ErrorHandler.
-	globalErrorHandler = &loggingErrorHandler{
-		l: log.New(os.Stderr, "", log.LstdFlags),
-	}
+	globalErrorHandler = &loggingErrorHandler{}
 
 	// delegateErrorHandlerOnce ensures that a user provided ErrorHandler is
 	// only ever registered once.
@@ -38,11 +36,10 @@ var (
 	_ ErrorHandler = (*loggingErrorHandler)(nil)
 )
 
-// loggingErrorHandler logs all errors to STDERR.
+// loggingErrorHandler logs all errors to the Logger, which writes them to
+// STDERR by default.
 type loggingErrorHandler struct {
 	delegate atomic.Value
-
-	l *log.Logger
 }
 
 // setDelegate sets the ErrorHandler delegate if one is not already set.
@@ -60,12 +57,12 @@ func (h *loggingErrorHandler) Handle(err error) {
 		d.(ErrorHandler).Handle(err)
 		return
 	}
-	h.l.Print(err)
+	global.Error(err, "")
 }
 
 // GetErrorHandler returns the global ErrorHandler instance. If no ErrorHandler
 // instance has been set (`SetErrorHandler`), the default ErrorHandler which
-// logs errors to STDERR is returned.
+// logs errors to the Logger (`SetLogger`), STDERR by default, is returned.
 func GetErrorHandler() ErrorHandler {
 	return globalErrorHandler
 }
@@ -87,3 +84,66 @@ func SetErrorHandler(h ErrorHandler) {
 func Handle(err error) {
 	GetErrorHandler().Handle(err)
 }
+
+// Signal identifies the telemetry signal of the OpenTelemetry component
+// reporting an error.
+type Signal string
+
+const (
+	// TracesSignal is the signal of tracing components, e.g. the trace
+	// SDK and the span exporters.
+	TracesSignal Signal = "traces"
+	// MetricsSignal is the signal of metric components, e.g. the metric
+	// SDK and the metric exporters.
+	MetricsSignal Signal = "metrics"
+)
+
+// signalErrorHandlers holds the ErrorHandler registered for each Signal.
+var signalErrorHandlers sync.Map // Signal -> ErrorHandler
+
+// GetSignalErrorHandler returns the ErrorHandler for the errors of
+// signal. If no ErrorHandler has been set for signal
+// (`SetSignalErrorHandler`), the global ErrorHandler is returned.
+func GetSignalErrorHandler(signal Signal) ErrorHandler {
+	if h, ok := signalErrorHandlers.Load(signal); ok {
+		return h.(ErrorHandler)
+	}
+	return GetErrorHandler()
+}
+
+// SetSignalErrorHandler sets h as the ErrorHandler for the errors of
+// signal, in place of the global ErrorHandler. Contrary to
+// SetErrorHandler, it can be called multiple times. A nil h removes the
+// ErrorHandler of signal, its errors are sent to the global ErrorHandler
+// again.
+func SetSignalErrorHandler(signal Signal, h ErrorHandler) {
+	if h == nil {
+		signalErrorHandlers.Delete(signal)
+		return
+	}
+	signalErrorHandlers.Store(signal, h)
+}
+
+// HandleSignal is a convenience function for
+// GetSignalErrorHandler(signal).Handle(err)
+func HandleSignal(signal Signal, err error) {
+	GetSignalErrorHandler(signal).Handle(err)
+}
+
+// HandleSignals sends err to the ErrorHandler of each of signals, for the
+// errors of components shared by several signals, e.g. resource
+// detection. The global ErrorHandler receives err at most once, for all
+// the signals without an ErrorHandler of their own.
+func HandleSignals(err error, signals ...Signal) {
+	var global bool
+	for _, signal := range signals {
+		if h, ok := signalErrorHandlers.Load(signal); ok {
+			h.(ErrorHandler).Handle(err)
+		} else {
+			global = true
+		}
+	}
+	if global {
+		GetErrorHandler().Handle(err)
+	}
+}
@@ -15,22 +15,29 @@
 package otel
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
-	"log"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
 type errLogger []string
 
-func (l *errLogger) Write(p []byte) (int, error) {
-	msg := bytes.TrimRight(p, "\n")
-	(*l) = append(*l, string(msg))
-	return len(msg), nil
+// errLogger is a Logger, and an ErrorHandler, recording the logged
+// errors.
+func (l *errLogger) Enabled(int) bool { return true }
+
+func (l *errLogger) Info(int, string, ...interface{}) {}
+
+func (l *errLogger) Error(err error, _ string, _ ...interface{}) {
+	(*l) = append(*l, err.Error())
+}
+
+func (l *errLogger) Handle(err error) {
+	l.Error(err, "")
 }
 
 func (l *errLogger) Reset() {
@@ -51,13 +58,13 @@ type HandlerTestSuite struct {
 func (s *HandlerTestSuite) SetupSuite() {
 	s.errLogger = new(errLogger)
 	s.origHandler = globalErrorHandler
-	globalErrorHandler = &loggingErrorHandler{
-		l: log.New(s.errLogger, "", 0),
-	}
+	globalErrorHandler = &loggingErrorHandler{}
+	SetLogger(s.errLogger)
 }
 
 func (s *HandlerTestSuite) TearDownSuite() {
 	globalErrorHandler = s.origHandler
+	SetLogger(nil)
 }
 
 func (s *HandlerTestSuite) SetupTest() {
@@ -71,6 +78,19 @@ func (s *HandlerTestSuite) TestGlobalHandler() {
 	s.Assert().Equal(errs, s.errLogger.Got())
 }
 
+func (s *HandlerTestSuite) TestHandleSignals() {
+	defer SetSignalErrorHandler(TracesSignal, nil)
+
+	HandleSignals(errors.New("one"), TracesSignal, MetricsSignal)
+	s.Assert().Equal([]string{"one"}, s.errLogger.Got(), "sent to the global handler once")
+
+	tracesLogger := new(errLogger)
+	SetSignalErrorHandler(TracesSignal, tracesLogger)
+	HandleSignals(errors.New("two"), TracesSignal, MetricsSignal)
+	s.Assert().Equal([]string{"two"}, tracesLogger.Got())
+	s.Assert().Equal([]string{"one", "two"}, s.errLogger.Got())
+}
+
 func (s *HandlerTestSuite) TestNoDropsOnDelegate() {
 	// max time to wait for goroutine to Handle an error.
 	pause := 10 * time.Millisecond
@@ -122,10 +142,7 @@ func (s *HandlerTestSuite) TestNoDropsOnDelegate() {
 
 	// Change to another Handler. We are testing this is loss-less.
 	newErrLogger := new(errLogger)
-	secondary := &loggingErrorHandler{
-		l: log.New(newErrLogger, "", 0),
-	}
-	SetErrorHandler(secondary)
+	SetErrorHandler(newErrLogger)
 	s.Require().NoError(wait(pause), "switched to new Handler")
 
 	// Testing done, stop sending errors.
@@ -138,6 +155,23 @@ func (s *HandlerTestSuite) TestNoDropsOnDelegate() {
 	s.Assert().Len(got, sent)
 }
 
+func TestSignalErrorHandler(t *testing.T) {
+	defer SetSignalErrorHandler(TracesSignal, nil)
+
+	// Without a signal handler, errors go to the global handler.
+	assert.Same(t, GetErrorHandler(), GetSignalErrorHandler(TracesSignal))
+
+	tracesLogger := new(errLogger)
+	SetSignalErrorHandler(TracesSignal, tracesLogger)
+	assert.Same(t, tracesLogger, GetSignalErrorHandler(TracesSignal))
+	assert.Same(t, GetErrorHandler(), GetSignalErrorHandler(MetricsSignal))
+	HandleSignal(TracesSignal, errors.New("one"))
+	assert.Equal(t, []string{"one"}, tracesLogger.Got())
+
+	SetSignalErrorHandler(TracesSignal, nil)
+	assert.Same(t, GetErrorHandler(), GetSignalErrorHandler(TracesSignal))
+}
+
 func TestHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(HandlerTestSuite))
 }
@@ -27,6 +27,11 @@ type keySet map[attribute.Key]struct{}
 // Map is an immutable storage for correlations.
 type Map struct {
 	m rawMap
+	// hops holds the remaining number of times the values of hop-limited
+	// keys may be propagated. Keys without a limit are not in hops.
+	hops map[attribute.Key]int
+	// props holds the properties of the keys having some.
+	props map[attribute.Key][]Property
 }
 
 // MapUpdate contains information about correlation changes to be
@@ -47,17 +52,31 @@ type MapUpdate struct {
 	// MultiKV contains all the key-value pairs to be added to
 	// correlations.
 	MultiKV []attribute.KeyValue
+
+	// HopLimited marks the key-value pairs of SingleKV and MultiKV
+	// as hop-limited, they are propagated at most HopLimit times.
+	// A HopLimit of zero or less means they are not propagated.
+	HopLimited bool
+	// HopLimit is the number of times the key-value pairs of
+	// SingleKV and MultiKV may be propagated if HopLimited is true.
+	HopLimit int
+
+	// Properties are the properties of the key-value pairs of
+	// SingleKV and MultiKV.
+	Properties []Property
 }
 
-func newMap(raw rawMap) Map {
+func newMap(raw rawMap, hops map[attribute.Key]int, props map[attribute.Key][]Property) Map {
 	return Map{
-		m: raw,
+		m:     raw,
+		hops:  hops,
+		props: props,
 	}
 }
 
 // NewEmptyMap creates an empty correlations map.
 func NewEmptyMap() Map {
-	return newMap(nil)
+	return newMap(nil, nil, nil)
 }
 
 // NewMap creates a map with the contents of the update applied. In
@@ -75,6 +94,8 @@ func (m Map) Apply(update MapUpdate) Map {
 	mapSize := getNewMapSize(m.m, delSet, addSet)
 
 	r := make(rawMap, mapSize)
+	var hops map[attribute.Key]int
+	var props map[attribute.Key][]Property
 	for k, v := range m.m {
 		// do not copy items we want to drop
 		if _, ok := delSet[k]; ok {
@@ -85,17 +106,56 @@ func (m Map) Apply(update MapUpdate) Map {
 			continue
 		}
 		r[k] = v
+		if h, ok := m.hops[k]; ok {
+			hops = setHops(hops, k, h)
+		}
+		if p, ok := m.props[k]; ok {
+			props = setProperties(props, k, p)
+		}
 	}
 	if update.SingleKV.Key.Defined() {
 		r[update.SingleKV.Key] = update.SingleKV.Value
+		if update.HopLimited {
+			hops = setHops(hops, update.SingleKV.Key, update.HopLimit)
+		}
+		props = setProperties(props, update.SingleKV.Key, update.Properties)
 	}
 	for _, kv := range update.MultiKV {
 		r[kv.Key] = kv.Value
+		if update.HopLimited {
+			hops = setHops(hops, kv.Key, update.HopLimit)
+		}
+		props = setProperties(props, kv.Key, update.Properties)
 	}
 	if len(r) == 0 {
 		r = nil
 	}
-	return newMap(r)
+	return newMap(r, hops, props)
+}
+
+// setProperties sets the properties of k in props, allocating props if
+// needed. Nothing is set if p is empty.
+func setProperties(props map[attribute.Key][]Property, k attribute.Key, p []Property) map[attribute.Key][]Property {
+	if len(p) == 0 {
+		return props
+	}
+	if props == nil {
+		props = make(map[attribute.Key][]Property)
+	}
+	props[k] = p
+	return props
+}
+
+// setHops sets the hop limit of k in hops, allocating hops if needed.
+func setHops(hops map[attribute.Key]int, k attribute.Key, limit int) map[attribute.Key]int {
+	if hops == nil {
+		hops = make(map[attribute.Key]int)
+	}
+	if limit < 0 {
+		limit = 0
+	}
+	hops[k] = limit
+	return hops
 }
 
 func getModificationSets(update MapUpdate) (delSet, addSet keySet) {
@@ -154,6 +214,20 @@ func (m Map) Value(k attribute.Key) (attribute.Value, bool) {
 	return value, ok
 }
 
+// HopLimit returns the number of times the value of k may still be
+// propagated and a boolean value indicating whether the value of k is
+// hop-limited. Values that are not hop-limited are always propagated.
+func (m Map) HopLimit(k attribute.Key) (int, bool) {
+	h, ok := m.hops[k]
+	return h, ok
+}
+
+// Properties returns the properties of the value of k, in their
+// order. The returned slice must not be modified.
+func (m Map) Properties(k attribute.Key) []Property {
+	return m.props[k]
+}
+
 // HasValue returns a boolean value indicating whether the key exist
 // in the map.
 func (m Map) HasValue(k attribute.Key) bool {
@@ -281,5 +281,71 @@ func makeTestMap(ints []int) Map {
 	for _, v := range ints {
 		r[attribute.Key(fmt.Sprintf("key%d", v))] = attribute.IntValue(v)
 	}
-	return newMap(r)
+	return newMap(r, nil, nil)
+}
+
+func TestMapHopLimit(t *testing.T) {
+	m := NewMap(MapUpdate{
+		MultiKV: []attribute.KeyValue{attribute.String("unlimited", "v")},
+	}).Apply(MapUpdate{
+		MultiKV:    []attribute.KeyValue{attribute.String("local", "v")},
+		HopLimited: true,
+	}).Apply(MapUpdate{
+		SingleKV:   attribute.String("limited", "v"),
+		HopLimited: true,
+		HopLimit:   2,
+	})
+
+	for key, want := range map[attribute.Key]int{"local": 0, "limited": 2} {
+		if got, ok := m.HopLimit(key); !ok || got != want {
+			t.Errorf("HopLimit(%q) = %d, %t; want %d, true", key, got, ok, want)
+		}
+	}
+	if _, ok := m.HopLimit("unlimited"); ok {
+		t.Errorf("HopLimit(%q) limited", "unlimited")
+	}
+
+	// Overwriting or dropping a value drops its limit.
+	m = m.Apply(MapUpdate{
+		SingleKV:    attribute.String("local", "v2"),
+		DropSingleK: "limited",
+	})
+	for _, key := range []attribute.Key{"local", "limited"} {
+		if _, ok := m.HopLimit(key); ok {
+			t.Errorf("HopLimit(%q) limited after update", key)
+		}
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+}
+
+func TestMapProperties(t *testing.T) {
+	props := []Property{{Key: "flag"}, {Key: "prop", Value: "1", HasValue: true}}
+	m := NewMap(MapUpdate{
+		MultiKV:    []attribute.KeyValue{attribute.String("k1", "v"), attribute.String("k2", "v")},
+		Properties: props,
+	}).Apply(MapUpdate{
+		SingleKV: attribute.String("k3", "v"),
+	})
+
+	for _, key := range []attribute.Key{"k1", "k2"} {
+		if got := m.Properties(key); len(got) != 2 || got[0] != props[0] || got[1] != props[1] {
+			t.Errorf("Properties(%q) = %v; want %v", key, got, props)
+		}
+	}
+	if got := m.Properties("k3"); len(got) != 0 {
+		t.Errorf("Properties(%q) = %v; want none", "k3", got)
+	}
+
+	// Overwriting or dropping a value drops its properties.
+	m = m.Apply(MapUpdate{
+		SingleKV:    attribute.String("k1", "v2"),
+		DropSingleK: "k2",
+	})
+	for _, key := range []attribute.Key{"k1", "k2"} {
+		if got := m.Properties(key); len(got) != 0 {
+			t.Errorf("Properties(%q) = %v after update; want none", key, got)
+		}
+	}
 }
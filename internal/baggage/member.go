@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package baggage
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	propertyDelimiter = ";"
+	keyValueDelimiter = "="
+)
+
+var errInvalidMember = errors.New("invalid baggage member")
+
+// Property is a metadata entry of a baggage member: a key, optionally
+// with a value.
+type Property struct {
+	Key      string
+	Value    string
+	HasValue bool
+}
+
+// String encodes p in the W3C Baggage format.
+func (p Property) String() string {
+	if !p.HasValue {
+		return p.Key
+	}
+	return p.Key + keyValueDelimiter + escape(p.Value)
+}
+
+// EncodeMember encodes the member of key, value and props in the W3C
+// Baggage format.
+func EncodeMember(key, value string, props []Property) string {
+	var b strings.Builder
+	b.WriteString(escape(strings.TrimSpace(key)))
+	b.WriteString(keyValueDelimiter)
+	b.WriteString(escape(strings.TrimSpace(value)))
+	for _, p := range props {
+		b.WriteString(propertyDelimiter)
+		b.WriteString(p.String())
+	}
+	return b.String()
+}
+
+// ParseMember decodes the key, value and properties of the member s
+// encoded in the W3C Baggage format. The properties are returned in
+// their order in s, unknown ones included.
+func ParseMember(s string) (key, value string, props []Property, err error) {
+	parts := strings.Split(s, propertyDelimiter)
+	kv := strings.SplitN(parts[0], keyValueDelimiter, 2)
+	if len(kv) != 2 {
+		return "", "", nil, fmt.Errorf("%w: missing value: %q", errInvalidMember, s)
+	}
+	if key, err = url.PathUnescape(kv[0]); err != nil {
+		return "", "", nil, fmt.Errorf("%w: %v", errInvalidMember, err)
+	}
+	if key = strings.TrimSpace(key); key == "" {
+		return "", "", nil, fmt.Errorf("%w: missing key: %q", errInvalidMember, s)
+	}
+	if value, err = unescape(kv[1]); err != nil {
+		return "", "", nil, fmt.Errorf("%w: %v", errInvalidMember, err)
+	}
+	value = strings.TrimSpace(value)
+
+	for _, part := range parts[1:] {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		p, err := parseProperty(part)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("%w: %v", errInvalidMember, err)
+		}
+		props = append(props, p)
+	}
+	return key, value, props, nil
+}
+
+// parseProperty decodes the property s encoded in the W3C Baggage format.
+func parseProperty(s string) (Property, error) {
+	kv := strings.SplitN(s, keyValueDelimiter, 2)
+	p := Property{Key: strings.TrimSpace(kv[0])}
+	if p.Key == "" {
+		return Property{}, fmt.Errorf("missing property key: %q", s)
+	}
+	if len(kv) == 2 {
+		value, err := unescape(kv[1])
+		if err != nil {
+			return Property{}, err
+		}
+		p.Value, p.HasValue = strings.TrimSpace(value), true
+	}
+	return p, nil
+}
+
+// ValidKey returns whether key is a token as defined by RFC 7230, the keys
+// of the W3C Baggage format.
+func ValidKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, c := range key {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// unescape decodes the percent-encoded value s. A '+' is decoded as a
+// space, as encoded by the URL encoders of other implementations.
+func unescape(s string) (string, error) {
+	return url.QueryUnescape(s)
+}
+
+// escape percent-encodes the bytes of s that are not baggage-octets of the
+// W3C Baggage format, and the '%' of the encoding itself and '+', which
+// is decoded as a space.
+func escape(s string) string {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if shouldEscape(s[i]) {
+			n++
+		}
+	}
+	if n == 0 {
+		return s
+	}
+
+	const hex = "0123456789ABCDEF"
+	b := make([]byte, 0, len(s)+2*n)
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; shouldEscape(c) {
+			b = append(b, '%', hex[c>>4], hex[c&0xf])
+		} else {
+			b = append(b, c)
+		}
+	}
+	return string(b)
+}
+
+// shouldEscape returns whether c is '%', '+', or not a baggage-octet, i.e.
+// one of %x21 / %x23-2B / %x2D-3A / %x3C-5B / %x5D-7E.
+func shouldEscape(c byte) bool {
+	switch {
+	case c == '%', c == '+':
+		return true
+	case c == 0x21, c >= 0x23 && c <= 0x2b, c >= 0x2d && c <= 0x3a,
+		c >= 0x3c && c <= 0x5b, c >= 0x5d && c <= 0x7e:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package glob provides the name patterns shared by the SDKs and exporters
+// to select instruments, tracers and metrics by name: a "*" matches any
+// sequence of characters, including an empty one and one containing "/",
+// all other characters only match themselves.
+package glob // import "go.opentelemetry.io/otel/internal/glob"
+
+import "strings"
+
+// Wildcard matches any sequence of characters in a pattern.
+const Wildcard = "*"
+
+// Match returns if name matches pattern.
+func Match(pattern, name string) bool {
+	parts := strings.Split(pattern, Wildcard)
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return len(name) >= len(last) && strings.HasSuffix(name, last)
+}
+
+// IsLiteral returns if pattern has no wildcard, i.e. if it only matches
+// the name equal to it.
+func IsLiteral(pattern string) bool {
+	return !strings.Contains(pattern, Wildcard)
+}
+
+// Specificity returns how specific a match of pattern is, to select the
+// most specific of several patterns matching the same name. A literal
+// pattern is more specific than all the wildcard patterns matching the same
+// name, and a wildcard pattern with more literal characters is more
+// specific than one with fewer.
+func Specificity(pattern string) int {
+	if IsLiteral(pattern) {
+		return len(pattern) + 1
+	}
+	return len(pattern) - strings.Count(pattern, Wildcard)
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package glob
+
+import "testing"
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"http.requests", "http.requests", true},
+		{"http.requests", "http.requests.total", false},
+		{"*", "anything", true},
+		{"*", "", true},
+		{"http.*", "http.requests", true},
+		{"http.*", "rpc.http.requests", false},
+		{"*.internal", "cache.internal", true},
+		{"*.internal", "cache.internal.hits", false},
+		{"http.*.internal", "http.cache.internal", true},
+		{"http.*.internal", "http.internal", false},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "axxcyyb", false},
+		{"ab*ba", "aba", false},
+		{"github.com/example/*", "github.com/example/a/b", true},
+		{"http.[", "http.[", true},
+		{"http.?", "http.a", false},
+	}
+	for _, test := range tests {
+		if got := Match(test.pattern, test.name); got != test.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", test.pattern, test.name, got, test.want)
+		}
+	}
+}
+
+func TestSpecificity(t *testing.T) {
+	ordered := []string{"*", "github.com/*", "github.com/example/*", "github.com/example/lib"}
+	for i := 1; i < len(ordered); i++ {
+		if Specificity(ordered[i-1]) >= Specificity(ordered[i]) {
+			t.Errorf("Specificity(%q) >= Specificity(%q)", ordered[i-1], ordered[i])
+		}
+	}
+	// An exact match is more specific than a wildcard with all the
+	// other characters of the name.
+	if Specificity("ab*") >= Specificity("ab") {
+		t.Error("wildcard pattern more specific than the literal one")
+	}
+}
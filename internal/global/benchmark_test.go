@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/internal/global"
 	metricglobal "go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func BenchmarkGlobalInt64CounterAddNoSDK(b *testing.B) {
@@ -53,3 +54,18 @@ func BenchmarkStartEndSpanNoSDK(b *testing.B) {
 		span.End()
 	}
 }
+
+func BenchmarkGlobalTracerDelegating(b *testing.B) {
+	// Compare with BenchmarkGlobalTracerWithSDK() in
+	// ../../sdk/trace/benchmark_test.go to see the cost of the Tracers of
+	// the SDK behind the global TracerProvider once delegating.
+	global.ResetForTest()
+	otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = otel.Tracer("Benchmark Tracer")
+		}
+	})
+}
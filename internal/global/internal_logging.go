@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package global
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Verbosity levels used for the internal diagnostics of OpenTelemetry. A
+// Logger reports whether it is enabled at a given level, higher levels
+// being more verbose.
+const (
+	// WarnLevel is used for conditions the user should be aware of, such as
+	// a lost connection to a collector.
+	WarnLevel = 1
+	// InfoLevel is used for notable operational events, such as retries.
+	InfoLevel = 4
+	// DebugLevel is used for detailed diagnostics, such as every dropped
+	// span.
+	DebugLevel = 8
+)
+
+// Logger is the structured logger OpenTelemetry reports its internal
+// diagnostics to.
+type Logger interface {
+	Enabled(level int) bool
+	Info(level int, msg string, keysAndValues ...interface{})
+	Error(err error, msg string, keysAndValues ...interface{})
+}
+
+type loggerHolder struct {
+	l Logger
+}
+
+var globalLogger atomic.Value
+
+func init() {
+	globalLogger.Store(loggerHolder{l: newStdLogger(WarnLevel)})
+}
+
+// SetLogger sets the Logger used for internal diagnostics. Passing nil
+// restores the default Logger, which writes warnings to STDERR.
+func SetLogger(l Logger) {
+	if l == nil {
+		l = newStdLogger(WarnLevel)
+	}
+	globalLogger.Store(loggerHolder{l: l})
+}
+
+// GetLogger returns the Logger used for internal diagnostics.
+func GetLogger() Logger {
+	return globalLogger.Load().(loggerHolder).l
+}
+
+// Warn logs msg and keysAndValues at WarnLevel.
+func Warn(msg string, keysAndValues ...interface{}) {
+	logAt(WarnLevel, msg, keysAndValues)
+}
+
+// Info logs msg and keysAndValues at InfoLevel.
+func Info(msg string, keysAndValues ...interface{}) {
+	logAt(InfoLevel, msg, keysAndValues)
+}
+
+// Debug logs msg and keysAndValues at DebugLevel.
+func Debug(msg string, keysAndValues ...interface{}) {
+	logAt(DebugLevel, msg, keysAndValues)
+}
+
+// Error logs err along with msg and keysAndValues.
+func Error(err error, msg string, keysAndValues ...interface{}) {
+	GetLogger().Error(err, msg, keysAndValues...)
+}
+
+func logAt(level int, msg string, keysAndValues []interface{}) {
+	l := GetLogger()
+	if l.Enabled(level) {
+		l.Info(level, msg, keysAndValues...)
+	}
+}
+
+// stdLogger is the default Logger. It writes every message up to its
+// verbosity to STDERR as a message followed by key=value pairs.
+type stdLogger struct {
+	verbosity int
+	l         *log.Logger
+}
+
+func newStdLogger(verbosity int) *stdLogger {
+	return &stdLogger{
+		verbosity: verbosity,
+		l:         log.New(os.Stderr, "", log.LstdFlags),
+	}
+}
+
+func (s *stdLogger) Enabled(level int) bool {
+	return level <= s.verbosity
+}
+
+func (s *stdLogger) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.l.Print(formatEntry(msg, keysAndValues))
+}
+
+// Error writes err as the message if msg is empty, e.g. for the errors of
+// the default ErrorHandler.
+func (s *stdLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	if msg == "" {
+		s.l.Print(formatEntry(fmt.Sprint(err), keysAndValues))
+		return
+	}
+	s.l.Print(formatEntry(msg, append([]interface{}{"error", err}, keysAndValues...)))
+}
+
+// formatEntry renders msg and keysAndValues as `msg key=value ...`. A
+// trailing key without a value is rendered with a "<missing>" value.
+func formatEntry(msg string, keysAndValues []interface{}) string {
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		var v interface{} = "<missing>"
+		if i+1 < len(keysAndValues) {
+			v = keysAndValues[i+1]
+		}
+		fmt.Fprintf(&b, " %v=%v", keysAndValues[i], v)
+	}
+	return b.String()
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package global
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type entry struct {
+	level int
+	err   error
+	msg   string
+	kv    []interface{}
+}
+
+type recordingLogger struct {
+	verbosity int
+	entries   []entry
+}
+
+func (r *recordingLogger) Enabled(level int) bool { return level <= r.verbosity }
+
+func (r *recordingLogger) Info(level int, msg string, kv ...interface{}) {
+	r.entries = append(r.entries, entry{level: level, msg: msg, kv: kv})
+}
+
+func (r *recordingLogger) Error(err error, msg string, kv ...interface{}) {
+	r.entries = append(r.entries, entry{err: err, msg: msg, kv: kv})
+}
+
+func TestLoggerVerbosity(t *testing.T) {
+	defer SetLogger(nil)
+
+	r := &recordingLogger{verbosity: InfoLevel}
+	SetLogger(r)
+	assert.Same(t, r, GetLogger())
+
+	err := errors.New("test")
+	Warn("warn", "k", 1)
+	Info("info")
+	Debug("debug")
+	Error(err, "error", "k", 2)
+
+	assert.Equal(t, []entry{
+		{level: WarnLevel, msg: "warn", kv: []interface{}{"k", 1}},
+		{level: InfoLevel, msg: "info"},
+		{err: err, msg: "error", kv: []interface{}{"k", 2}},
+	}, r.entries)
+}
+
+func TestSetLoggerNilRestoresDefault(t *testing.T) {
+	SetLogger(&recordingLogger{})
+	SetLogger(nil)
+	l, ok := GetLogger().(*stdLogger)
+	if assert.True(t, ok) {
+		assert.True(t, l.Enabled(WarnLevel))
+		assert.False(t, l.Enabled(InfoLevel))
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := &stdLogger{verbosity: DebugLevel, l: log.New(&buf, "", 0)}
+
+	l.Info(DebugLevel, "dropping span", "span", "foo", "queue_size", 2048)
+	l.Error(errors.New("boom"), "export failed", "dangling")
+	l.Error(errors.New("handled"), "")
+
+	assert.Equal(t,
+		"dropping span span=foo queue_size=2048\n"+
+			"export failed error=boom dangling=<missing>\n"+
+			"handled\n",
+		buf.String())
+}
@@ -48,7 +48,7 @@ import (
 // methods of the api/metric/registry package.
 
 type meterKey struct {
-	Name, Version string
+	Name, Version, SchemaURL string
 }
 
 type meterProvider struct {
@@ -68,6 +68,7 @@ type meterImpl struct {
 	lock       sync.Mutex
 	syncInsts  []*syncImpl
 	asyncInsts []*asyncImpl
+	callbacks  []*asyncCallback
 }
 
 type meterEntry struct {
@@ -93,6 +94,13 @@ type asyncImpl struct {
 	runner metric.AsyncRunner
 }
 
+// asyncCallback is a callback registered for existing asynchronous
+// instruments.
+type asyncCallback struct {
+	runner      metric.AsyncBatchRunner
+	instruments []metric.AsyncImpl
+}
+
 // SyncImpler is implemented by all of the sync metric
 // instruments.
 type SyncImpler interface {
@@ -116,6 +124,8 @@ type syncHandle struct {
 
 var _ metric.MeterProvider = &meterProvider{}
 var _ metric.MeterImpl = &meterImpl{}
+var _ metric.AsyncRegisterer = &meterImpl{}
+var _ metric.AsyncUnregisterer = &meterImpl{}
 var _ metric.InstrumentImpl = &syncImpl{}
 var _ metric.BoundSyncImpl = &syncHandle{}
 var _ metric.AsyncImpl = &asyncImpl{}
@@ -138,7 +148,7 @@ func (p *meterProvider) setDelegate(provider metric.MeterProvider) {
 
 	p.delegate = provider
 	for key, entry := range p.meters {
-		entry.impl.setDelegate(key.Name, key.Version, provider)
+		entry.impl.setDelegate(key, provider)
 	}
 	p.meters = nil
 }
@@ -151,9 +161,11 @@ func (p *meterProvider) Meter(instrumentationName string, opts ...metric.MeterOp
 		return p.delegate.Meter(instrumentationName, opts...)
 	}
 
+	c := metric.NewMeterConfig(opts...)
 	key := meterKey{
-		Name:    instrumentationName,
-		Version: metric.NewMeterConfig(opts...).InstrumentationVersion,
+		Name:      instrumentationName,
+		Version:   c.InstrumentationVersion,
+		SchemaURL: c.SchemaURL,
 	}
 	entry, ok := p.meters[key]
 	if !ok {
@@ -162,17 +174,17 @@ func (p *meterProvider) Meter(instrumentationName string, opts ...metric.MeterOp
 		p.meters[key] = entry
 
 	}
-	return metric.WrapMeterImpl(entry.unique, key.Name, metric.WithInstrumentationVersion(key.Version))
+	return metric.WrapMeterImpl(entry.unique, key.Name, metric.WithInstrumentationVersion(key.Version), metric.WithSchemaURL(key.SchemaURL))
 }
 
 // Meter interface and delegation
 
-func (m *meterImpl) setDelegate(name, version string, provider metric.MeterProvider) {
+func (m *meterImpl) setDelegate(key meterKey, provider metric.MeterProvider) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
 	d := new(metric.MeterImpl)
-	*d = provider.Meter(name, metric.WithInstrumentationVersion(version)).MeterImpl()
+	*d = provider.Meter(key.Name, metric.WithInstrumentationVersion(key.Version), metric.WithSchemaURL(key.SchemaURL)).MeterImpl()
 	m.delegate = unsafe.Pointer(d)
 
 	for _, inst := range m.syncInsts {
@@ -183,6 +195,18 @@ func (m *meterImpl) setDelegate(name, version string, provider metric.MeterProvi
 		obs.setDelegate(*d)
 	}
 	m.asyncInsts = nil
+	if registerer, ok := (*d).(metric.AsyncRegisterer); ok {
+		for _, cb := range m.callbacks {
+			if err := registerer.RegisterAsync(cb.runner, delegateAsyncs(cb.instruments)...); err != nil {
+				// TODO: There is no standard way to deliver this error to the user.
+				// See https://github.com/open-telemetry/opentelemetry-go/issues/514
+				panic(err)
+			}
+		}
+	}
+	// The callbacks are dropped if the delegate does not support
+	// registering callbacks.
+	m.callbacks = nil
 }
 
 func (m *meterImpl) NewSyncInstrument(desc metric.Descriptor) (metric.SyncImpl, error) {
@@ -274,6 +298,74 @@ func (m *meterImpl) NewAsyncInstrument(
 	return inst, nil
 }
 
+// RegisterAsync implements metric.AsyncRegisterer. The callback is
+// forwarded to the delegate if it has been set, and otherwise
+// registered with it when it is set.
+func (m *meterImpl) RegisterAsync(runner metric.AsyncBatchRunner, instruments ...metric.AsyncImpl) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if meterPtr := (*metric.MeterImpl)(atomic.LoadPointer(&m.delegate)); meterPtr != nil {
+		if r, ok := (*meterPtr).(metric.AsyncRegisterer); ok {
+			return r.RegisterAsync(runner, delegateAsyncs(instruments)...)
+		}
+		return metric.ErrRegisterNotSupported
+	}
+
+	m.callbacks = append(m.callbacks, &asyncCallback{
+		runner:      runner,
+		instruments: instruments,
+	})
+	return nil
+}
+
+// delegateAsyncs returns the delegates of the instruments created
+// before the delegate was set.
+func delegateAsyncs(instruments []metric.AsyncImpl) []metric.AsyncImpl {
+	delegates := make([]metric.AsyncImpl, len(instruments))
+	for i, inst := range instruments {
+		delegates[i] = inst
+		if obs, ok := inst.(*asyncImpl); ok {
+			if implPtr := (*metric.AsyncImpl)(atomic.LoadPointer(&obs.delegate)); implPtr != nil {
+				delegates[i] = *implPtr
+			}
+		}
+	}
+	return delegates
+}
+
+// UnregisterAsync implements metric.AsyncUnregisterer. The callback
+// is forwarded to the delegate if it has been set, and otherwise not
+// registered with it when it is set.
+func (m *meterImpl) UnregisterAsync(runner metric.AsyncRunner) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if meterPtr := (*metric.MeterImpl)(atomic.LoadPointer(&m.delegate)); meterPtr != nil {
+		if u, ok := (*meterPtr).(metric.AsyncUnregisterer); ok {
+			return u.UnregisterAsync(runner)
+		}
+		return metric.ErrUnregisterNotSupported
+	}
+
+	var insts []*asyncImpl
+	for _, inst := range m.asyncInsts {
+		if inst.runner != runner {
+			insts = append(insts, inst)
+		}
+	}
+	m.asyncInsts = insts
+
+	var callbacks []*asyncCallback
+	for _, cb := range m.callbacks {
+		if cb.runner != runner {
+			callbacks = append(callbacks, cb)
+		}
+	}
+	m.callbacks = callbacks
+	return nil
+}
+
 func (obs *asyncImpl) Implementation() interface{} {
 	if implPtr := (*metric.AsyncImpl)(atomic.LoadPointer(&obs.delegate)); implPtr != nil {
 		return (*implPtr).Implementation()
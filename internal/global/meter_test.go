@@ -132,6 +132,35 @@ func TestDirect(t *testing.T) {
 	)
 }
 
+func TestSchemaURL(t *testing.T) {
+	global.ResetForTest()
+
+	ctx := context.Background()
+	meter1 := metricglobal.Meter("test", metric.WithSchemaURL("https://opentelemetry.io/schemas/1.3.0"))
+	meter2 := metricglobal.Meter("test", metric.WithSchemaURL("https://opentelemetry.io/schemas/1.4.0"))
+
+	// Meters of different schema URLs are distinct, their instruments
+	// of the same name do not conflict.
+	counter1 := Must(meter1).NewInt64Counter("test.counter")
+	counter2 := Must(meter2).NewInt64Counter("test.counter")
+
+	mock, provider := oteltest.NewMeterProvider()
+	metricglobal.SetMeterProvider(provider)
+
+	counter1.Add(ctx, 1)
+	counter2.Add(ctx, 2)
+
+	require.Len(t, mock.MeasurementBatches, 2)
+	require.Equal(t,
+		"https://opentelemetry.io/schemas/1.3.0",
+		mock.MeasurementBatches[0].Measurements[0].Instrument.Descriptor().InstrumentationSchemaURL(),
+	)
+	require.Equal(t,
+		"https://opentelemetry.io/schemas/1.4.0",
+		mock.MeasurementBatches[1].Measurements[0].Instrument.Descriptor().InstrumentationSchemaURL(),
+	)
+}
+
 func TestBound(t *testing.T) {
 	global.ResetForTest()
 
@@ -300,6 +329,68 @@ func TestImplementationIndirection(t *testing.T) {
 	require.True(t, ok)
 }
 
+func TestUnregisterAsync(t *testing.T) {
+	global.ResetForTest()
+
+	meter := metricglobal.Meter("test")
+	callback := func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1)
+	}
+
+	// Unregistered before the SDK is set.
+	before := Must(meter).NewInt64ValueObserver("before.valueobserver", callback)
+	require.NoError(t, before.Unregister())
+	after := Must(meter).NewInt64ValueObserver("after.valueobserver", callback)
+
+	mock, provider := oteltest.NewMeterProvider()
+	metricglobal.SetMeterProvider(provider)
+
+	mock.RunAsyncInstruments()
+	require.Len(t, mock.MeasurementBatches, 1)
+	require.Equal(t, "after.valueobserver", mock.MeasurementBatches[0].Measurements[0].Instrument.Descriptor().Name())
+
+	// Unregistered after the SDK is set.
+	require.NoError(t, after.Unregister())
+	mock.RunAsyncInstruments()
+	require.Len(t, mock.MeasurementBatches, 1)
+}
+
+func TestRegisterCallback(t *testing.T) {
+	global.ResetForTest()
+
+	meter := metricglobal.Meter("test")
+	batch := Must(meter).NewBatchObserver(nil)
+	before := batch.NewInt64ValueObserver("before.valueobserver")
+	callback := func(_ context.Context, result metric.BatchObserverResult) {
+		result.Observe(nil, before.Observation(1))
+	}
+
+	// Registered before the SDK is set.
+	reg, err := meter.RegisterCallback([]metric.Asynchronous{before}, callback)
+	require.NoError(t, err)
+	removed, err := meter.RegisterCallback([]metric.Asynchronous{before}, callback)
+	require.NoError(t, err)
+	require.NoError(t, removed.Unregister())
+
+	mock, provider := oteltest.NewMeterProvider()
+	metricglobal.SetMeterProvider(provider)
+
+	mock.RunAsyncInstruments()
+	require.Len(t, mock.MeasurementBatches, 1)
+	require.Equal(t, "before.valueobserver", mock.MeasurementBatches[0].Measurements[0].Instrument.Descriptor().Name())
+
+	// Registered after the SDK is set.
+	after, err := meter.RegisterCallback([]metric.Asynchronous{before}, callback)
+	require.NoError(t, err)
+	mock.RunAsyncInstruments()
+	require.Len(t, mock.MeasurementBatches, 3)
+
+	require.NoError(t, reg.Unregister())
+	require.NoError(t, after.Unregister())
+	mock.RunAsyncInstruments()
+	require.Len(t, mock.MeasurementBatches, 3)
+}
+
 func TestRecordBatchMock(t *testing.T) {
 	global.ResetForTest()
 
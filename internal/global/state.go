@@ -45,6 +45,9 @@ var (
 	delegateMeterOnce             sync.Once
 	delegateTraceOnce             sync.Once
 	delegateTextMapPropagatorOnce sync.Once
+
+	// swapMu serializes the swaps of the global values.
+	swapMu sync.Mutex
 )
 
 // TracerProvider is the internal implementation for global.TracerProvider.
@@ -114,6 +117,57 @@ func SetTextMapPropagator(p propagation.TextMapPropagator) {
 	globalPropagators.Store(propagatorsHolder{tm: p})
 }
 
+// SwapTracerProvider is the internal implementation for
+// global.SwapTracerProvider.
+func SwapTracerProvider(tp trace.TracerProvider) trace.TracerProvider {
+	swapMu.Lock()
+	defer swapMu.Unlock()
+
+	if tp == nil {
+		// Install a new delegating default that the next call to
+		// SetTracerProvider delegates to the registered provider.
+		tp = &tracerProvider{}
+		delegateTraceOnce = sync.Once{}
+	}
+	prev := globalTracer.Load().(tracerProviderHolder).tp
+	globalTracer.Store(tracerProviderHolder{tp: tp})
+	return prev
+}
+
+// SwapMeterProvider is the internal implementation for
+// global.SwapMeterProvider.
+func SwapMeterProvider(mp metric.MeterProvider) metric.MeterProvider {
+	swapMu.Lock()
+	defer swapMu.Unlock()
+
+	if mp == nil {
+		// Install a new delegating default that the next call to
+		// SetMeterProvider delegates to the registered provider.
+		mp = newMeterProvider()
+		delegateMeterOnce = sync.Once{}
+	}
+	prev := globalMeter.Load().(meterProviderHolder).mp
+	globalMeter.Store(meterProviderHolder{mp: mp})
+	return prev
+}
+
+// SwapTextMapPropagator is the internal implementation for
+// global.SwapTextMapPropagator.
+func SwapTextMapPropagator(p propagation.TextMapPropagator) propagation.TextMapPropagator {
+	swapMu.Lock()
+	defer swapMu.Unlock()
+
+	if p == nil {
+		// Install a new delegating default that the next call to
+		// SetTextMapPropagator delegates to the registered propagator.
+		p = newTextMapPropagator()
+		delegateTextMapPropagatorOnce = sync.Once{}
+	}
+	prev := globalPropagators.Load().(propagatorsHolder).tm
+	globalPropagators.Store(propagatorsHolder{tm: p})
+	return prev
+}
+
 func defaultTracerValue() *atomic.Value {
 	v := &atomic.Value{}
 	v.Store(tracerProviderHolder{tp: &tracerProvider{}})
@@ -17,7 +17,12 @@ package global_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestResetsOfGlobalsPanic(t *testing.T) {
@@ -39,6 +44,74 @@ func TestResetsOfGlobalsPanic(t *testing.T) {
 	}
 }
 
+func TestSwapTracerProvider(t *testing.T) {
+	global.ResetForTest()
+	def := global.TracerProvider()
+
+	fixture := trace.NewNoopTracerProvider()
+	assert.Same(t, def, global.SwapTracerProvider(fixture))
+	assert.Equal(t, fixture, global.TracerProvider())
+
+	// Restoring the default must not have made it delegate to the
+	// fixture, it still delegates to the next registered provider.
+	assert.Equal(t, fixture, global.SwapTracerProvider(def))
+	called := false
+	global.SetTracerProvider(fnTracerProvider{
+		tracer: func(string, ...trace.TracerOption) trace.Tracer {
+			called = true
+			return trace.NewNoopTracerProvider().Tracer("")
+		},
+	})
+	def.Tracer("abc")
+	assert.True(t, called, "expected restored default to delegate")
+
+	// Resetting installs a new default with delegation re-armed.
+	global.SwapTracerProvider(nil)
+	reset := global.TracerProvider()
+	assert.NotSame(t, def, reset)
+	called = false
+	global.SetTracerProvider(fnTracerProvider{
+		tracer: func(string, ...trace.TracerOption) trace.Tracer {
+			called = true
+			return trace.NewNoopTracerProvider().Tracer("")
+		},
+	})
+	reset.Tracer("abc")
+	assert.True(t, called, "expected reset default to delegate")
+}
+
+func TestSwapMeterProvider(t *testing.T) {
+	global.ResetForTest()
+	def := global.MeterProvider()
+
+	fixture := metric.NoopMeterProvider{}
+	assert.Same(t, def, global.SwapMeterProvider(fixture))
+	assert.Equal(t, fixture, global.MeterProvider())
+	assert.Equal(t, fixture, global.SwapMeterProvider(nil))
+
+	reset := global.MeterProvider()
+	assert.NotSame(t, def, reset)
+	shouldPanic(t, "SetMeterProvider", func() {
+		global.SetMeterProvider(reset)
+	})
+}
+
+func TestSwapTextMapPropagator(t *testing.T) {
+	global.ResetForTest()
+	def := global.TextMapPropagator()
+
+	fixture := propagation.TraceContext{}
+	assert.Same(t, def, global.SwapTextMapPropagator(fixture))
+	assert.Equal(t, fixture, global.TextMapPropagator())
+	assert.Equal(t, fixture, global.SwapTextMapPropagator(nil))
+
+	reset := global.TextMapPropagator()
+	assert.NotSame(t, def, reset)
+	global.SetTextMapPropagator(propagation.Baggage{})
+	assert.Equal(t, []string{"baggage"}, reset.Fields())
+	assert.Empty(t, def.Fields(), "expected swapped out default not to delegate")
+}
+
 func shouldPanic(t *testing.T, name string, f func()) {
 	defer func() {
 		if r := recover(); r == nil {
@@ -28,7 +28,8 @@ remains a no-op Span.
 The implementation to track and swap Tracers locks all new Tracer creation
 until the swap is complete. This assumes that this operation is not
 performance-critical. If that assumption is incorrect, be sure to configure an
-SDK prior to any Tracer creation.
+SDK prior to any Tracer creation. Once the swap is complete, Tracer creation
+is forwarded to the SDK without acquiring any lock.
 */
 
 import (
@@ -45,9 +46,12 @@ import (
 // All TracerProvider functionality is forwarded to a delegate once
 // configured.
 type tracerProvider struct {
-	mtx      sync.Mutex
-	tracers  map[il]*tracer
-	delegate trace.TracerProvider
+	mtx     sync.Mutex
+	tracers map[il]*tracer
+
+	// delegate holds the trace.TracerProvider once set, it is only stored
+	// while mtx is held but can be loaded without it.
+	delegate atomic.Value
 }
 
 // Compile-time guarantee that tracerProvider implements the TracerProvider
@@ -65,7 +69,7 @@ func (p *tracerProvider) setDelegate(provider trace.TracerProvider) {
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
-	p.delegate = provider
+	p.delegate.Store(tracerProviderHolder{tp: provider})
 
 	if len(p.tracers) == 0 {
 		return
@@ -80,18 +84,25 @@ func (p *tracerProvider) setDelegate(provider trace.TracerProvider) {
 
 // Tracer implements TracerProvider.
 func (p *tracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	if d, ok := p.delegate.Load().(tracerProviderHolder); ok {
+		return d.tp.Tracer(name, opts...)
+	}
+
 	p.mtx.Lock()
 	defer p.mtx.Unlock()
 
-	if p.delegate != nil {
-		return p.delegate.Tracer(name, opts...)
+	// The delegate may have been set while waiting for the lock.
+	if d, ok := p.delegate.Load().(tracerProviderHolder); ok {
+		return d.tp.Tracer(name, opts...)
 	}
 
 	// At this moment it is guaranteed that no sdk is installed, save the tracer in the tracers map.
 
+	c := trace.NewTracerConfig(opts...)
 	key := il{
-		name:    name,
-		version: trace.NewTracerConfig(opts...).InstrumentationVersion,
+		name:      name,
+		version:   c.InstrumentationVersion,
+		schemaURL: c.SchemaURL,
 	}
 
 	if p.tracers == nil {
@@ -108,8 +119,9 @@ func (p *tracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.T
 }
 
 type il struct {
-	name    string
-	version string
+	name      string
+	version   string
+	schemaURL string
 }
 
 // tracer is a placeholder for a trace.Tracer.
@@ -61,6 +61,14 @@ type AsyncInstrumentState struct {
 	// instruments maintains the set of instruments in the order
 	// they were registered.
 	instruments []metric.AsyncImpl
+
+	// instrumentRefs counts the runners registered with each
+	// entry of instruments.
+	instrumentRefs map[metric.AsyncImpl]int
+
+	// runnerInstruments maps each runner to the instruments
+	// registered with it.
+	runnerInstruments map[metric.AsyncRunner][]metric.AsyncImpl
 }
 
 // asyncRunnerPair is a map entry for Observer callback runners.
@@ -79,7 +87,9 @@ type asyncRunnerPair struct {
 // the correct order.
 func NewAsyncInstrumentState() *AsyncInstrumentState {
 	return &AsyncInstrumentState{
-		runnerMap: map[asyncRunnerPair]struct{}{},
+		runnerMap:         map[asyncRunnerPair]struct{}{},
+		instrumentRefs:    map[metric.AsyncImpl]int{},
+		runnerInstruments: map[metric.AsyncRunner][]metric.AsyncImpl{},
 	}
 }
 
@@ -100,7 +110,27 @@ func (a *AsyncInstrumentState) Register(inst metric.AsyncImpl, runner metric.Asy
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
-	a.instruments = append(a.instruments, inst)
+	a.register(inst, runner)
+}
+
+// RegisterBatch adds a batch runner observing instruments that are
+// already managed by this object, in addition to the runners they
+// were registered with.
+func (a *AsyncInstrumentState) RegisterBatch(runner metric.AsyncBatchRunner, instruments ...metric.AsyncImpl) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	for _, inst := range instruments {
+		a.register(inst, runner)
+	}
+}
+
+func (a *AsyncInstrumentState) register(inst metric.AsyncImpl, runner metric.AsyncRunner) {
+	if a.instrumentRefs[inst] == 0 {
+		a.instruments = append(a.instruments, inst)
+	}
+	a.instrumentRefs[inst]++
+	a.runnerInstruments[runner] = append(a.runnerInstruments[runner], inst)
 
 	// asyncRunnerPair reflects this callback in the asyncRunners
 	// list.  If this is a batch runner, the instrument is nil.
@@ -120,6 +150,50 @@ func (a *AsyncInstrumentState) Register(inst metric.AsyncImpl, runner metric.Asy
 	}
 }
 
+// Unregister removes runner from the set managed by this object, as
+// well as the asynchronous instruments no other runner is registered
+// with.  Unregistering a runner that is not registered has no effect.
+func (a *AsyncInstrumentState) Unregister(runner metric.AsyncRunner) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	registered, ok := a.runnerInstruments[runner]
+	if !ok {
+		return
+	}
+	delete(a.runnerInstruments, runner)
+
+	// The slices returned by Instruments() and read by Run() are
+	// not modified, new slices replace them.
+	var runners []asyncRunnerPair
+	for _, rp := range a.runners {
+		if rp.runner == runner {
+			delete(a.runnerMap, rp)
+			continue
+		}
+		runners = append(runners, rp)
+	}
+	a.runners = runners
+
+	removed := false
+	for _, inst := range registered {
+		if a.instrumentRefs[inst]--; a.instrumentRefs[inst] == 0 {
+			delete(a.instrumentRefs, inst)
+			removed = true
+		}
+	}
+	if !removed {
+		return
+	}
+	var instruments []metric.AsyncImpl
+	for _, inst := range a.instruments {
+		if _, ok := a.instrumentRefs[inst]; ok {
+			instruments = append(instruments, inst)
+		}
+	}
+	a.instruments = instruments
+}
+
 // Run executes the complete set of observer callbacks.
 func (a *AsyncInstrumentState) Run(ctx context.Context, collector AsyncCollector) {
 	a.lock.Lock()
@@ -142,7 +216,7 @@ func (a *AsyncInstrumentState) Run(ctx context.Context, collector AsyncCollector
 		}
 
 		a.errorOnce.Do(func() {
-			otel.Handle(fmt.Errorf("%w: type %T (reported once)", ErrInvalidAsyncRunner, rp))
+			otel.HandleSignal(otel.MetricsSignal, fmt.Errorf("%w: type %T (reported once)", ErrInvalidAsyncRunner, rp))
 		})
 	}
 }
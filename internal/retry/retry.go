@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retry provides the retry policy shared by the exporters: a
+// jittered exponential backoff, bounded by a number of attempts and an
+// elapsed time budget, that honors the delays requested by servers and
+// the cancellation of contexts.
+package retry // import "go.opentelemetry.io/otel/internal/retry"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultConfig is the default retry policy of the exporters.
+var DefaultConfig = Config{
+	InitialInterval: 5 * time.Second,
+	MaxInterval:     30 * time.Second,
+	MaxElapsedTime:  time.Minute,
+}
+
+// Config is a retry policy.
+type Config struct {
+	// InitialInterval is the delay after the first failed attempt. Each
+	// subsequent delay doubles, randomized by ±50%. If it is not
+	// positive, the failed attempts are not retried.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between two attempts, before the
+	// randomization. It is not capped if MaxInterval is not positive.
+	MaxInterval time.Duration
+	// MaxElapsedTime is the time budget of all the attempts. No attempt
+	// is made if its delay would exceed the budget. There is no budget if
+	// MaxElapsedTime is not positive.
+	MaxElapsedTime time.Duration
+	// MaxAttempts is the maximum number of attempts, including the first
+	// one. The number of attempts is not limited if MaxAttempts is not
+	// positive.
+	MaxAttempts int
+	// OnRetry, if set, is called before every retried attempt with the
+	// number of the failed attempt, starting at 1, its error, and the
+	// delay until the next attempt.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// retryableError is an error that marks the failed attempt as retryable.
+type retryableError struct {
+	err   error
+	after time.Duration
+}
+
+func (e retryableError) Error() string { return e.err.Error() }
+
+func (e retryableError) Unwrap() error { return e.err }
+
+// Retryable returns err marked as the retryable error of an attempt. If
+// after is positive, the next attempt is delayed for at least after, e.g.
+// the delay requested by the Retry-After header of an HTTP response.
+func Retryable(err error, after time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return retryableError{err: err, after: after}
+}
+
+// IsRetryable returns whether err is marked as retryable, and the delay
+// requested for the next attempt.
+func IsRetryable(err error) (bool, time.Duration) {
+	var r retryableError
+	if errors.As(err, &r) {
+		return true, r.after
+	}
+	return false, 0
+}
+
+// Do calls fn until it succeeds, returns an error that is not Retryable,
+// or the policy or ctx stops the attempts. The returned error is the one
+// of the last attempt, or the error of ctx if it is done before the next
+// attempt.
+func (c Config) Do(ctx context.Context, fn func(context.Context) error) error {
+	start := time.Now()
+	interval := c.InitialInterval
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		ok, after := IsRetryable(err)
+		if !ok || c.InitialInterval <= 0 {
+			return err
+		}
+		if c.MaxAttempts > 0 && attempt >= c.MaxAttempts {
+			return fmt.Errorf("failed after %d attempts: %w", attempt, err)
+		}
+
+		delay := jitter(interval)
+		if after > delay {
+			delay = after
+		}
+		if c.MaxElapsedTime > 0 && time.Since(start)+delay > c.MaxElapsedTime {
+			return fmt.Errorf("retry time budget of %s exceeded: %w", c.MaxElapsedTime, err)
+		}
+		if c.OnRetry != nil {
+			c.OnRetry(attempt, err, delay)
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		}
+
+		interval *= 2
+		if c.MaxInterval > 0 && interval > c.MaxInterval {
+			interval = c.MaxInterval
+		}
+	}
+}
+
+var (
+	rngMu sync.Mutex
+	rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// jitter returns d randomized in [d/2, 3d/2).
+func jitter(d time.Duration) time.Duration {
+	rngMu.Lock()
+	f := rng.Float64()
+	rngMu.Unlock()
+	return d/2 + time.Duration(f*float64(d))
+}
+
+// RetryableHTTPStatus returns whether an HTTP response with the status
+// code is worth retrying: the server is throttling or temporarily
+// unavailable.
+func RetryableHTTPStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// TransportError returns the error of a request that failed to get a
+// response, marked as Retryable if it is a transient network failure: a
+// timeout, or a failure to connect or of the connection. The other errors,
+// such as the cancellation of the request, are returned unchanged.
+func TransportError(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return err
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && (netErr.Timeout() || netErr.Temporary()) {
+		return Retryable(err, 0)
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return Retryable(err, 0)
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return Retryable(err, 0)
+	}
+	return err
+}
+
+// HTTPResponseError returns nil if resp succeeded with a 2xx status code,
+// or else an error describing it. The error is Retryable if the status
+// code is, with the delay requested by the Retry-After header of resp.
+func HTTPResponseError(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	err := fmt.Errorf("failed with HTTP status %s", resp.Status)
+	if !RetryableHTTPStatus(resp.StatusCode) {
+		return err
+	}
+	after, _ := ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	return Retryable(err, after)
+}
+
+// ParseRetryAfter returns the delay requested by the value of a
+// Retry-After HTTP header, either a number of seconds or an HTTP date,
+// relative to now. It returns false if v is empty or invalid.
+func ParseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseUint(v, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retry
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTest = errors.New("test")
+
+// failing returns a function failing with err the first n times it is
+// called, and the number of calls.
+func failing(n int, err error) (func(context.Context) error, *int) {
+	calls := 0
+	return func(context.Context) error {
+		calls++
+		if calls <= n {
+			return err
+		}
+		return nil
+	}, &calls
+}
+
+func TestDoRetriesUntilSuccess(t *testing.T) {
+	var retries []int
+	c := Config{
+		InitialInterval: time.Millisecond,
+		OnRetry: func(attempt int, err error, delay time.Duration) {
+			assert.Equal(t, errTest, errors.Unwrap(err))
+			assert.True(t, delay >= time.Millisecond/2)
+			retries = append(retries, attempt)
+		},
+	}
+	fn, calls := failing(3, Retryable(errTest, 0))
+	require.NoError(t, c.Do(context.Background(), fn))
+	assert.Equal(t, 4, *calls)
+	assert.Equal(t, []int{1, 2, 3}, retries)
+}
+
+func TestDoNotRetryable(t *testing.T) {
+	c := Config{InitialInterval: time.Millisecond}
+	fn, calls := failing(3, errTest)
+	assert.Equal(t, errTest, c.Do(context.Background(), fn))
+	assert.Equal(t, 1, *calls)
+}
+
+func TestDoDisabled(t *testing.T) {
+	fn, calls := failing(3, Retryable(errTest, 0))
+	assert.True(t, errors.Is(Config{}.Do(context.Background(), fn), errTest))
+	assert.Equal(t, 1, *calls)
+}
+
+func TestDoMaxAttempts(t *testing.T) {
+	c := Config{InitialInterval: time.Millisecond, MaxAttempts: 2}
+	fn, calls := failing(3, Retryable(errTest, 0))
+	err := c.Do(context.Background(), fn)
+	assert.True(t, errors.Is(err, errTest))
+	assert.Equal(t, 2, *calls)
+}
+
+func TestDoMaxElapsedTime(t *testing.T) {
+	c := Config{InitialInterval: time.Hour, MaxElapsedTime: time.Minute}
+	fn, calls := failing(3, Retryable(errTest, 0))
+	err := c.Do(context.Background(), fn)
+	assert.True(t, errors.Is(err, errTest))
+	assert.Equal(t, 1, *calls)
+}
+
+func TestDoRetryAfter(t *testing.T) {
+	var delays []time.Duration
+	c := Config{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+		OnRetry: func(_ int, _ error, delay time.Duration) {
+			delays = append(delays, delay)
+		},
+	}
+	fn, _ := failing(1, Retryable(errTest, 20*time.Millisecond))
+	require.NoError(t, c.Do(context.Background(), fn))
+	assert.Equal(t, []time.Duration{20 * time.Millisecond}, delays)
+
+	// A requested delay exceeding the budget stops the attempts.
+	c.MaxElapsedTime = 10 * time.Millisecond
+	fn, calls := failing(1, Retryable(errTest, 20*time.Millisecond))
+	assert.Error(t, c.Do(context.Background(), fn))
+	assert.Equal(t, 1, *calls)
+}
+
+func TestDoContextDone(t *testing.T) {
+	c := Config{InitialInterval: time.Hour}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	fn, calls := failing(3, Retryable(errTest, 0))
+	assert.Equal(t, context.DeadlineExceeded, c.Do(ctx, fn))
+	assert.Equal(t, 1, *calls)
+}
+
+func TestDoBackoffGrowth(t *testing.T) {
+	var delays []time.Duration
+	c := Config{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     4 * time.Millisecond,
+		OnRetry: func(_ int, _ error, delay time.Duration) {
+			delays = append(delays, delay)
+		},
+	}
+	fn, _ := failing(5, Retryable(errTest, 0))
+	require.NoError(t, c.Do(context.Background(), fn))
+	require.Len(t, delays, 5)
+	for i, base := range []time.Duration{1, 2, 4, 4, 4} {
+		base *= time.Millisecond
+		assert.True(t, delays[i] >= base/2 && delays[i] < base*3/2, "delay %d: %s", i, delays[i])
+	}
+}
+
+func TestRetryableNil(t *testing.T) {
+	assert.NoError(t, Retryable(nil, time.Second))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		value string
+		delay time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"Tue, 01 Jun 2021 12:00:30 GMT", 30 * time.Second, true},
+		{"Tue, 01 Jun 2021 11:00:00 GMT", 0, true},
+		{"soon", 0, false},
+		{"-1", 0, false},
+	} {
+		delay, ok := ParseRetryAfter(tc.value, now)
+		assert.Equal(t, tc.delay, delay, tc.value)
+		assert.Equal(t, tc.ok, ok, tc.value)
+	}
+}
+
+func TestHTTPResponseError(t *testing.T) {
+	assert.NoError(t, HTTPResponseError(&http.Response{StatusCode: http.StatusAccepted}))
+
+	err := HTTPResponseError(&http.Response{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"})
+	require.Error(t, err)
+	ok, _ := IsRetryable(err)
+	assert.False(t, ok)
+
+	header := http.Header{}
+	header.Set("Retry-After", "3")
+	err = HTTPResponseError(&http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Status:     "429 Too Many Requests",
+		Header:     header,
+	})
+	ok, after := IsRetryable(err)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, after)
+	assert.EqualError(t, err, "failed with HTTP status 429 Too Many Requests")
+}
+
+func TestTransportError(t *testing.T) {
+	assert.NoError(t, TransportError(nil))
+
+	_, err := http.Get("http://127.0.0.1:1")
+	require.Error(t, err)
+	ok, _ := IsRetryable(TransportError(err))
+	assert.True(t, ok, "connection refused is retryable")
+
+	ok, _ = IsRetryable(TransportError(&url.Error{Op: "Post", URL: "http://localhost", Err: io.ErrUnexpectedEOF}))
+	assert.True(t, ok, "a broken connection is retryable")
+
+	ok, _ = IsRetryable(TransportError(&url.Error{Op: "Post", URL: "http://localhost", Err: context.Canceled}))
+	assert.False(t, ok, "a canceled request is not retryable")
+
+	ok, _ = IsRetryable(TransportError(errTest))
+	assert.False(t, ok)
+}
@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"strings"
+	"testing"
+)
+
+func recurse(depth int) string {
+	if depth == 0 {
+		return StackTrace()
+	}
+	return recurse(depth - 1)
+}
+
+func TestStackTrace(t *testing.T) {
+	// A deep stack does not fit in the initial buffer.
+	stack := recurse(100)
+	if !strings.Contains(stack, "TestStackTrace") {
+		t.Errorf("stack trace is truncated: %q", stack)
+	}
+	if len(stack) <= 2048 {
+		t.Errorf("stack trace of %d bytes, want more than 2048", len(stack))
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel // import "go.opentelemetry.io/otel"
+
+import "go.opentelemetry.io/otel/internal/global"
+
+// Logger is the structured logger the OpenTelemetry SDK and exporters report
+// their internal diagnostics to: warnings, retries, dropped data, and debug
+// output.
+//
+// Messages are logged with Info at a verbosity level, higher levels being
+// more verbose: 1 for warnings, 4 for informational messages, and 8 for
+// debug output. Enabled is called before a message is built so disabled
+// levels cost next to nothing.
+//
+// The method set is a subset of the logr.LogSink interface
+// (github.com/go-logr/logr), so a logr.Logger can be used with
+// SetLogger(logger.GetSink()).
+type Logger interface {
+	// Enabled returns whether messages at level are logged.
+	Enabled(level int) bool
+	// Info logs a non-error message at level with the key/value pairs in
+	// keysAndValues.
+	Info(level int, msg string, keysAndValues ...interface{})
+	// Error logs err along with msg and the key/value pairs in
+	// keysAndValues.
+	Error(err error, msg string, keysAndValues ...interface{})
+}
+
+// SetLogger sets the Logger used for the internal diagnostics of
+// OpenTelemetry. Unlike SetErrorHandler, it can be called any number of
+// times. Passing nil restores the default Logger, which writes warnings to
+// STDERR.
+func SetLogger(l Logger) {
+	global.SetLogger(l)
+}
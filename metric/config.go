@@ -30,6 +30,14 @@ type InstrumentConfig struct {
 	// InstrumentationVersion is the version of the library providing
 	// instrumentation.
 	InstrumentationVersion string
+	// InstrumentationSchemaURL is the schema URL of the telemetry
+	// emitted by the library providing instrumentation.
+	InstrumentationSchemaURL string
+	// HistogramBoundaries are the bucket boundaries advised for the
+	// histogram aggregating the measurements of the instrument.  The
+	// SDK may ignore them, e.g. if it is configured with other
+	// boundaries.
+	HistogramBoundaries []float64
 }
 
 // InstrumentOption is an interface for applying metric instrument options.
@@ -82,11 +90,26 @@ func (i instrumentationNameOption) ApplyInstrument(config *InstrumentConfig) {
 	config.InstrumentationName = string(i)
 }
 
+// WithHistogramBoundaries advises the bucket boundaries of the histogram
+// aggregating the measurements of the instrument, for instrumentation
+// libraries to suggest boundaries suiting the values they measure.
+func WithHistogramBoundaries(boundaries ...float64) InstrumentOption {
+	return histogramBoundariesOption(boundaries)
+}
+
+type histogramBoundariesOption []float64
+
+func (h histogramBoundariesOption) ApplyInstrument(config *InstrumentConfig) {
+	config.HistogramBoundaries = append([]float64(nil), h...)
+}
+
 // MeterConfig contains options for Meters.
 type MeterConfig struct {
 	// InstrumentationVersion is the version of the library providing
 	// instrumentation.
 	InstrumentationVersion string
+	// SchemaURL is the schema URL of the telemetry emitted by the Meter.
+	SchemaURL string
 }
 
 // MeterOption is an interface for applying Meter options.
@@ -126,3 +149,20 @@ func (i instrumentationVersionOption) ApplyMeter(config *MeterConfig) {
 func (i instrumentationVersionOption) ApplyInstrument(config *InstrumentConfig) {
 	config.InstrumentationVersion = string(i)
 }
+
+// WithSchemaURL sets the schema URL of the telemetry emitted by the Meter
+// or instrument, i.e. the version of the semantic conventions its names
+// and labels follow.
+func WithSchemaURL(schemaURL string) InstrumentationOption {
+	return schemaURLOption(schemaURL)
+}
+
+type schemaURLOption string
+
+func (o schemaURLOption) ApplyMeter(config *MeterConfig) {
+	config.SchemaURL = string(o)
+}
+
+func (o schemaURLOption) ApplyInstrument(config *InstrumentConfig) {
+	config.InstrumentationSchemaURL = string(o)
+}
@@ -42,6 +42,7 @@ The synchronous instrument names are:
   Counter:           additive, monotonic
   UpDownCounter:     additive
   ValueRecorder:     grouping
+  Gauge:             grouping, the last value set is kept
 
 and the asynchronous instruments are:
 
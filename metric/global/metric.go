@@ -47,3 +47,18 @@ func GetMeterProvider() metric.MeterProvider {
 func SetMeterProvider(mp metric.MeterProvider) {
 	global.SetMeterProvider(mp)
 }
+
+// SwapMeterProvider registers `mp` as the global meter provider and
+// returns the previously registered one. Unlike SetMeterProvider, the
+// previous provider is not set to delegate to `mp`, which makes it
+// suitable to install, and later restore, test fixtures:
+//     prev := global.SwapMeterProvider(mp)
+//     defer global.SwapMeterProvider(prev)
+// Passing nil installs a new default delegating provider that the next
+// call to SetMeterProvider delegates to.
+//
+// SwapMeterProvider must not be called concurrently with
+// SetMeterProvider.
+func SwapMeterProvider(mp metric.MeterProvider) metric.MeterProvider {
+	return global.SwapMeterProvider(mp)
+}
@@ -37,8 +37,8 @@ type MeterProvider interface {
 //
 // An uninitialized Meter is a no-op implementation.
 type Meter struct {
-	impl          MeterImpl
-	name, version string
+	impl                     MeterImpl
+	name, version, schemaURL string
 }
 
 // RecordBatch atomically records a batch of measurements.
@@ -50,7 +50,9 @@ func (m Meter) RecordBatch(ctx context.Context, ls []attribute.KeyValue, ms ...M
 }
 
 // NewBatchObserver creates a new BatchObserver that supports
-// making batches of observations for multiple instruments.
+// making batches of observations for multiple instruments. The
+// instruments of a BatchObserver created with a nil callback are
+// observed by the callbacks registered with RegisterCallback.
 func (m Meter) NewBatchObserver(callback BatchObserverFunc) BatchObserver {
 	return BatchObserver{
 		meter:  m,
@@ -58,6 +60,61 @@ func (m Meter) NewBatchObserver(callback BatchObserverFunc) BatchObserver {
 	}
 }
 
+// RegisterCallback registers callback to observe instruments, so that
+// the observations of several instruments can be made from a single
+// snapshot. The callback observes the instruments using the
+// Observation method of each instrument. It runs in addition to the
+// callbacks the instruments were created with, the instruments of a
+// BatchObserver created with a nil callback have none, e.g.
+//
+//	batch := metric.Must(meter).NewBatchObserver(nil)
+//	cpu := batch.NewFloat64ValueObserver("process.cpu")
+//	memory := batch.NewInt64ValueObserver("process.memory")
+//	reg, err := meter.RegisterCallback(
+//		[]metric.Asynchronous{cpu, memory},
+//		func(ctx context.Context, result metric.BatchObserverResult) {
+//			stat := readProcStat()
+//			result.Observe(nil,
+//				cpu.Observation(stat.cpu),
+//				memory.Observation(stat.memory),
+//			)
+//		},
+//	)
+//
+// The callback stops running when the returned Registration is
+// unregistered. ErrRegisterNotSupported is returned if the SDK cannot
+// register callbacks.
+func (m Meter) RegisterCallback(instruments []Asynchronous, callback BatchObserverFunc) (Registration, error) {
+	if m.impl == nil || callback == nil {
+		return registration{}, nil
+	}
+	impls := make([]AsyncImpl, 0, len(instruments))
+	for _, inst := range instruments {
+		impl := inst.AsyncImpl()
+		if _, ok := impl.(NoopAsync); ok {
+			continue
+		}
+		impls = append(impls, impl)
+	}
+	registerer, ok := m.impl.(AsyncRegisterer)
+	if !ok {
+		return registration{}, ErrRegisterNotSupported
+	}
+	runner := newBatchAsyncRunner(callback)
+	if err := registerer.RegisterAsync(runner, impls...); err != nil {
+		return registration{}, err
+	}
+	return registration{impl: m.impl, runner: runner}, nil
+}
+
+// Unregister stops running the callback of b. The instruments created
+// by b stop reporting observations, the same instruments can then be
+// created again with a new callback. Unregister returns
+// ErrUnregisterNotSupported if the SDK cannot unregister callbacks.
+func (b BatchObserver) Unregister() error {
+	return registration{impl: b.meter.impl, runner: b.runner}.Unregister()
+}
+
 // NewInt64Counter creates a new integer Counter instrument with the
 // given name, customized with options.  May return an error if the
 // name is invalid (e.g., empty) or improperly registered (e.g.,
@@ -112,13 +169,31 @@ func (m Meter) NewFloat64ValueRecorder(name string, opts ...InstrumentOption) (F
 		m.newSync(name, ValueRecorderInstrumentKind, number.Float64Kind, opts))
 }
 
+// NewInt64Gauge creates a new integer Gauge instrument with the given
+// name, customized with options.  May return an error if the name is
+// invalid (e.g., empty) or improperly registered (e.g., duplicate
+// registration).
+func (m Meter) NewInt64Gauge(name string, opts ...InstrumentOption) (Int64Gauge, error) {
+	return wrapInt64GaugeInstrument(
+		m.newSync(name, GaugeInstrumentKind, number.Int64Kind, opts))
+}
+
+// NewFloat64Gauge creates a new floating point Gauge instrument with the
+// given name, customized with options.  May return an error if the
+// name is invalid (e.g., empty) or improperly registered (e.g.,
+// duplicate registration).
+func (m Meter) NewFloat64Gauge(name string, opts ...InstrumentOption) (Float64Gauge, error) {
+	return wrapFloat64GaugeInstrument(
+		m.newSync(name, GaugeInstrumentKind, number.Float64Kind, opts))
+}
+
 // NewInt64ValueObserver creates a new integer ValueObserver instrument
 // with the given name, running a given callback, and customized with
 // options.  May return an error if the name is invalid (e.g., empty)
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewInt64ValueObserver(name string, callback Int64ObserverFunc, opts ...InstrumentOption) (Int64ValueObserver, error) {
 	if callback == nil {
-		return wrapInt64ValueObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64ValueObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapInt64ValueObserverInstrument(
 		m.newAsync(name, ValueObserverInstrumentKind, number.Int64Kind, opts,
@@ -131,7 +206,7 @@ func (m Meter) NewInt64ValueObserver(name string, callback Int64ObserverFunc, op
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewFloat64ValueObserver(name string, callback Float64ObserverFunc, opts ...InstrumentOption) (Float64ValueObserver, error) {
 	if callback == nil {
-		return wrapFloat64ValueObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64ValueObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapFloat64ValueObserverInstrument(
 		m.newAsync(name, ValueObserverInstrumentKind, number.Float64Kind, opts,
@@ -144,7 +219,7 @@ func (m Meter) NewFloat64ValueObserver(name string, callback Float64ObserverFunc
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewInt64SumObserver(name string, callback Int64ObserverFunc, opts ...InstrumentOption) (Int64SumObserver, error) {
 	if callback == nil {
-		return wrapInt64SumObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64SumObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapInt64SumObserverInstrument(
 		m.newAsync(name, SumObserverInstrumentKind, number.Int64Kind, opts,
@@ -157,7 +232,7 @@ func (m Meter) NewInt64SumObserver(name string, callback Int64ObserverFunc, opts
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewFloat64SumObserver(name string, callback Float64ObserverFunc, opts ...InstrumentOption) (Float64SumObserver, error) {
 	if callback == nil {
-		return wrapFloat64SumObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64SumObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapFloat64SumObserverInstrument(
 		m.newAsync(name, SumObserverInstrumentKind, number.Float64Kind, opts,
@@ -170,7 +245,7 @@ func (m Meter) NewFloat64SumObserver(name string, callback Float64ObserverFunc,
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewInt64UpDownSumObserver(name string, callback Int64ObserverFunc, opts ...InstrumentOption) (Int64UpDownSumObserver, error) {
 	if callback == nil {
-		return wrapInt64UpDownSumObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64UpDownSumObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapInt64UpDownSumObserverInstrument(
 		m.newAsync(name, UpDownSumObserverInstrumentKind, number.Int64Kind, opts,
@@ -183,7 +258,7 @@ func (m Meter) NewInt64UpDownSumObserver(name string, callback Int64ObserverFunc
 // or improperly registered (e.g., duplicate registration).
 func (m Meter) NewFloat64UpDownSumObserver(name string, callback Float64ObserverFunc, opts ...InstrumentOption) (Float64UpDownSumObserver, error) {
 	if callback == nil {
-		return wrapFloat64UpDownSumObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64UpDownSumObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapFloat64UpDownSumObserverInstrument(
 		m.newAsync(name, UpDownSumObserverInstrumentKind, number.Float64Kind, opts,
@@ -196,7 +271,7 @@ func (m Meter) NewFloat64UpDownSumObserver(name string, callback Float64Observer
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewInt64ValueObserver(name string, opts ...InstrumentOption) (Int64ValueObserver, error) {
 	if b.runner == nil {
-		return wrapInt64ValueObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64ValueObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapInt64ValueObserverInstrument(
 		b.meter.newAsync(name, ValueObserverInstrumentKind, number.Int64Kind, opts, b.runner))
@@ -208,7 +283,7 @@ func (b BatchObserver) NewInt64ValueObserver(name string, opts ...InstrumentOpti
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewFloat64ValueObserver(name string, opts ...InstrumentOption) (Float64ValueObserver, error) {
 	if b.runner == nil {
-		return wrapFloat64ValueObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64ValueObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapFloat64ValueObserverInstrument(
 		b.meter.newAsync(name, ValueObserverInstrumentKind, number.Float64Kind, opts,
@@ -221,7 +296,7 @@ func (b BatchObserver) NewFloat64ValueObserver(name string, opts ...InstrumentOp
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewInt64SumObserver(name string, opts ...InstrumentOption) (Int64SumObserver, error) {
 	if b.runner == nil {
-		return wrapInt64SumObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64SumObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapInt64SumObserverInstrument(
 		b.meter.newAsync(name, SumObserverInstrumentKind, number.Int64Kind, opts, b.runner))
@@ -233,7 +308,7 @@ func (b BatchObserver) NewInt64SumObserver(name string, opts ...InstrumentOption
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewFloat64SumObserver(name string, opts ...InstrumentOption) (Float64SumObserver, error) {
 	if b.runner == nil {
-		return wrapFloat64SumObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64SumObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapFloat64SumObserverInstrument(
 		b.meter.newAsync(name, SumObserverInstrumentKind, number.Float64Kind, opts,
@@ -246,7 +321,7 @@ func (b BatchObserver) NewFloat64SumObserver(name string, opts ...InstrumentOpti
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewInt64UpDownSumObserver(name string, opts ...InstrumentOption) (Int64UpDownSumObserver, error) {
 	if b.runner == nil {
-		return wrapInt64UpDownSumObserverInstrument(NoopAsync{}, nil)
+		return wrapInt64UpDownSumObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapInt64UpDownSumObserverInstrument(
 		b.meter.newAsync(name, UpDownSumObserverInstrumentKind, number.Int64Kind, opts, b.runner))
@@ -258,7 +333,7 @@ func (b BatchObserver) NewInt64UpDownSumObserver(name string, opts ...Instrument
 // or improperly registered (e.g., duplicate registration).
 func (b BatchObserver) NewFloat64UpDownSumObserver(name string, opts ...InstrumentOption) (Float64UpDownSumObserver, error) {
 	if b.runner == nil {
-		return wrapFloat64UpDownSumObserverInstrument(NoopAsync{}, nil)
+		return wrapFloat64UpDownSumObserverInstrument(NoopAsync{}, registration{}, nil)
 	}
 	return wrapFloat64UpDownSumObserverInstrument(
 		b.meter.newAsync(name, UpDownSumObserverInstrumentKind, number.Float64Kind, opts,
@@ -279,15 +354,18 @@ func (m Meter) newAsync(
 	runner AsyncRunner,
 ) (
 	AsyncImpl,
+	registration,
 	error,
 ) {
 	if m.impl == nil {
-		return NoopAsync{}, nil
+		return NoopAsync{}, registration{}, nil
 	}
 	desc := NewDescriptor(name, mkind, nkind, opts...)
 	desc.config.InstrumentationName = m.name
 	desc.config.InstrumentationVersion = m.version
-	return m.impl.NewAsyncInstrument(desc, runner)
+	desc.config.InstrumentationSchemaURL = m.schemaURL
+	inst, err := m.impl.NewAsyncInstrument(desc, runner)
+	return inst, registration{impl: m.impl, runner: runner}, err
 }
 
 // newSync constructs one new synchronous instrument.
@@ -306,6 +384,7 @@ func (m Meter) newSync(
 	desc := NewDescriptor(name, metricKind, numberKind, opts...)
 	desc.config.InstrumentationName = m.name
 	desc.config.InstrumentationVersion = m.version
+	desc.config.InstrumentationSchemaURL = m.schemaURL
 	return m.impl.NewSyncInstrument(desc)
 }
 
@@ -388,6 +467,26 @@ func (mm MeterMust) NewFloat64ValueRecorder(name string, mos ...InstrumentOption
 	}
 }
 
+// NewInt64Gauge calls `Meter.NewInt64Gauge` and returns the
+// instrument, panicking if it encounters an error.
+func (mm MeterMust) NewInt64Gauge(name string, gos ...InstrumentOption) Int64Gauge {
+	if inst, err := mm.meter.NewInt64Gauge(name, gos...); err != nil {
+		panic(err)
+	} else {
+		return inst
+	}
+}
+
+// NewFloat64Gauge calls `Meter.NewFloat64Gauge` and returns the
+// instrument, panicking if it encounters an error.
+func (mm MeterMust) NewFloat64Gauge(name string, gos ...InstrumentOption) Float64Gauge {
+	if inst, err := mm.meter.NewFloat64Gauge(name, gos...); err != nil {
+		panic(err)
+	} else {
+		return inst
+	}
+}
+
 // NewInt64ValueObserver calls `Meter.NewInt64ValueObserver` and
 // returns the instrument, panicking if it encounters an error.
 func (mm MeterMust) NewInt64ValueObserver(name string, callback Int64ObserverFunc, oos ...InstrumentOption) Int64ValueObserver {
@@ -456,6 +555,11 @@ func (mm MeterMust) NewBatchObserver(callback BatchObserverFunc) BatchObserverMu
 	}
 }
 
+// Unregister calls `BatchObserver.Unregister`.
+func (bm BatchObserverMust) Unregister() error {
+	return bm.batch.Unregister()
+}
+
 // NewInt64ValueObserver calls `BatchObserver.NewInt64ValueObserver` and
 // returns the instrument, panicking if it encounters an error.
 func (bm BatchObserverMust) NewInt64ValueObserver(name string, oos ...InstrumentOption) Int64ValueObserver {
@@ -575,3 +679,15 @@ func (d Descriptor) InstrumentationName() string {
 func (d Descriptor) InstrumentationVersion() string {
 	return d.config.InstrumentationVersion
 }
+
+// InstrumentationSchemaURL returns the schema URL of the telemetry emitted
+// by the library that provided instrumentation for this instrument.
+func (d Descriptor) InstrumentationSchemaURL() string {
+	return d.config.InstrumentationSchemaURL
+}
+
+// HistogramBoundaries returns the bucket boundaries advised for the
+// histogram aggregating the measurements of the instrument, if any.
+func (d Descriptor) HistogramBoundaries() []float64 {
+	return d.config.HistogramBoundaries
+}
@@ -27,6 +27,14 @@ import (
 // ErrSDKReturnedNilImpl is returned when a new `MeterImpl` returns nil.
 var ErrSDKReturnedNilImpl = errors.New("SDK returned a nil implementation")
 
+// ErrRegisterNotSupported is returned when registering a callback with
+// a `MeterImpl` that does not implement AsyncRegisterer.
+var ErrRegisterNotSupported = errors.New("SDK does not support registering callbacks")
+
+// ErrUnregisterNotSupported is returned when unregistering a callback
+// with a `MeterImpl` that does not implement AsyncUnregisterer.
+var ErrUnregisterNotSupported = errors.New("SDK does not support unregistering callbacks")
+
 // InstrumentKind describes the kind of instrument.
 type InstrumentKind int8
 
@@ -46,12 +54,15 @@ const (
 	// UpDownSumObserverInstrumentKind indicates a UpDownSumObserver
 	// instrument.
 	UpDownSumObserverInstrumentKind
+
+	// GaugeInstrumentKind indicates a Gauge instrument.
+	GaugeInstrumentKind
 )
 
 // Synchronous returns whether this is a synchronous kind of instrument.
 func (k InstrumentKind) Synchronous() bool {
 	switch k {
-	case CounterInstrumentKind, UpDownCounterInstrumentKind, ValueRecorderInstrumentKind:
+	case CounterInstrumentKind, UpDownCounterInstrumentKind, ValueRecorderInstrumentKind, GaugeInstrumentKind:
 		return true
 	}
 	return false
@@ -188,6 +199,19 @@ type AsyncBatchRunner interface {
 	AsyncRunner
 }
 
+// Asynchronous is implemented by all of the asynchronous instruments.
+type Asynchronous interface {
+	// AsyncImpl returns the implementation of the instrument.
+	AsyncImpl() AsyncImpl
+}
+
+// Registration is the handle of a registered observer callback.
+type Registration interface {
+	// Unregister stops running the callback. It is safe to call
+	// Unregister more than once.
+	Unregister() error
+}
+
 var _ AsyncSingleRunner = (*Int64ObserverFunc)(nil)
 var _ AsyncSingleRunner = (*Float64ObserverFunc)(nil)
 var _ AsyncBatchRunner = (*BatchObserverFunc)(nil)
@@ -234,44 +258,47 @@ func (f *Float64ObserverFunc) Run(ctx context.Context, impl AsyncImpl, function
 
 // Run implements AsyncBatchRunner.
 func (b *BatchObserverFunc) Run(ctx context.Context, function func([]attribute.KeyValue, ...Observation)) {
+	if *b == nil {
+		return
+	}
 	(*b)(ctx, BatchObserverResult{
 		function: function,
 	})
 }
 
 // wrapInt64ValueObserverInstrument converts an AsyncImpl into Int64ValueObserver.
-func wrapInt64ValueObserverInstrument(asyncInst AsyncImpl, err error) (Int64ValueObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+func wrapInt64ValueObserverInstrument(asyncInst AsyncImpl, reg registration, err error) (Int64ValueObserver, error) {
+	common, err := checkNewAsync(asyncInst, reg, err)
 	return Int64ValueObserver{asyncInstrument: common}, err
 }
 
 // wrapFloat64ValueObserverInstrument converts an AsyncImpl into Float64ValueObserver.
-func wrapFloat64ValueObserverInstrument(asyncInst AsyncImpl, err error) (Float64ValueObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+func wrapFloat64ValueObserverInstrument(asyncInst AsyncImpl, reg registration, err error) (Float64ValueObserver, error) {
+	common, err := checkNewAsync(asyncInst, reg, err)
 	return Float64ValueObserver{asyncInstrument: common}, err
 }
 
 // wrapInt64SumObserverInstrument converts an AsyncImpl into Int64SumObserver.
-func wrapInt64SumObserverInstrument(asyncInst AsyncImpl, err error) (Int64SumObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+func wrapInt64SumObserverInstrument(asyncInst AsyncImpl, reg registration, err error) (Int64SumObserver, error) {
+	common, err := checkNewAsync(asyncInst, reg, err)
 	return Int64SumObserver{asyncInstrument: common}, err
 }
 
 // wrapFloat64SumObserverInstrument converts an AsyncImpl into Float64SumObserver.
-func wrapFloat64SumObserverInstrument(asyncInst AsyncImpl, err error) (Float64SumObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+func wrapFloat64SumObserverInstrument(asyncInst AsyncImpl, reg registration, err error) (Float64SumObserver, error) {
+	common, err := checkNewAsync(asyncInst, reg, err)
 	return Float64SumObserver{asyncInstrument: common}, err
 }
 
 // wrapInt64UpDownSumObserverInstrument converts an AsyncImpl into Int64UpDownSumObserver.
-func wrapInt64UpDownSumObserverInstrument(asyncInst AsyncImpl, err error) (Int64UpDownSumObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+func wrapInt64UpDownSumObserverInstrument(asyncInst AsyncImpl, reg registration, err error) (Int64UpDownSumObserver, error) {
+	common, err := checkNewAsync(asyncInst, reg, err)
 	return Int64UpDownSumObserver{asyncInstrument: common}, err
 }
 
 // wrapFloat64UpDownSumObserverInstrument converts an AsyncImpl into Float64UpDownSumObserver.
-func wrapFloat64UpDownSumObserverInstrument(asyncInst AsyncImpl, err error) (Float64UpDownSumObserver, error) {
-	common, err := checkNewAsync(asyncInst, err)
+func wrapFloat64UpDownSumObserverInstrument(asyncInst AsyncImpl, reg registration, err error) (Float64UpDownSumObserver, error) {
+	common, err := checkNewAsync(asyncInst, reg, err)
 	return Float64UpDownSumObserver{asyncInstrument: common}, err
 }
 
@@ -405,7 +432,29 @@ type syncBoundInstrument struct {
 
 // asyncInstrument contains a AsyncImpl.
 type asyncInstrument struct {
-	instrument AsyncImpl
+	instrument   AsyncImpl
+	registration registration
+}
+
+// registration is the Registration of the callback runner of
+// asynchronous instruments.
+type registration struct {
+	impl   MeterImpl
+	runner AsyncRunner
+}
+
+var _ Registration = registration{}
+
+// Unregister implements Registration.
+func (r registration) Unregister() error {
+	if r.impl == nil || r.runner == nil {
+		return nil
+	}
+	u, ok := r.impl.(AsyncUnregisterer)
+	if !ok {
+		return ErrUnregisterNotSupported
+	}
+	return u.UnregisterAsync(r.runner)
 }
 
 // SyncImpl returns the instrument that created this measurement.
@@ -437,6 +486,15 @@ func (a asyncInstrument) AsyncImpl() AsyncImpl {
 	return a.instrument
 }
 
+// Unregister stops running the callback of the instrument. The callback
+// of an instrument created by a BatchObserver is the callback of the
+// BatchObserver, it stops for all of its instruments. Unregister
+// returns ErrUnregisterNotSupported if the SDK cannot unregister
+// callbacks.
+func (a asyncInstrument) Unregister() error {
+	return a.registration.Unregister()
+}
+
 // SyncImpl returns the implementation object for synchronous instruments.
 func (s syncInstrument) SyncImpl() SyncImpl {
 	return s.instrument
@@ -470,15 +528,17 @@ func (h syncBoundInstrument) Unbind() {
 // checkNewAsync receives an AsyncImpl and potential
 // error, and returns the same types, checking for and ensuring that
 // the returned interface is not nil.
-func checkNewAsync(instrument AsyncImpl, err error) (asyncInstrument, error) {
+func checkNewAsync(instrument AsyncImpl, reg registration, err error) (asyncInstrument, error) {
 	if instrument == nil {
 		if err == nil {
 			err = ErrSDKReturnedNilImpl
 		}
 		instrument = NoopAsync{}
+		reg = registration{}
 	}
 	return asyncInstrument{
-		instrument: instrument,
+		instrument:   instrument,
+		registration: reg,
 	}, err
 }
 
@@ -551,6 +611,18 @@ func wrapFloat64ValueRecorderInstrument(syncInst SyncImpl, err error) (Float64Va
 	return Float64ValueRecorder{syncInstrument: common}, err
 }
 
+// wrapInt64GaugeInstrument converts a SyncImpl into Int64Gauge.
+func wrapInt64GaugeInstrument(syncInst SyncImpl, err error) (Int64Gauge, error) {
+	common, err := checkNewSync(syncInst, err)
+	return Int64Gauge{syncInstrument: common}, err
+}
+
+// wrapFloat64GaugeInstrument converts a SyncImpl into Float64Gauge.
+func wrapFloat64GaugeInstrument(syncInst SyncImpl, err error) (Float64Gauge, error) {
+	common, err := checkNewSync(syncInst, err)
+	return Float64Gauge{syncInstrument: common}, err
+}
+
 // Float64Counter is a metric that accumulates float64 values.
 type Float64Counter struct {
 	syncInstrument
@@ -775,3 +847,81 @@ func (b BoundFloat64ValueRecorder) Record(ctx context.Context, value float64) {
 func (b BoundInt64ValueRecorder) Record(ctx context.Context, value int64) {
 	b.directRecord(ctx, number.NewInt64Number(value))
 }
+
+// Float64Gauge is a metric that sets the current float64 value of
+// something, e.g. the depth of a queue reported by an event.
+type Float64Gauge struct {
+	syncInstrument
+}
+
+// Int64Gauge is a metric that sets the current int64 value of
+// something, e.g. the depth of a queue reported by an event.
+type Int64Gauge struct {
+	syncInstrument
+}
+
+// BoundFloat64Gauge is a bound instrument for Float64Gauge.
+//
+// It inherits the Unbind function from syncBoundInstrument.
+type BoundFloat64Gauge struct {
+	syncBoundInstrument
+}
+
+// BoundInt64Gauge is a bound instrument for Int64Gauge.
+//
+// It inherits the Unbind function from syncBoundInstrument.
+type BoundInt64Gauge struct {
+	syncBoundInstrument
+}
+
+// Bind creates a bound instrument for this Gauge. The labels are
+// associated with values set via subsequent calls to Set.
+func (g Float64Gauge) Bind(labels ...attribute.KeyValue) (h BoundFloat64Gauge) {
+	h.syncBoundInstrument = g.bind(labels)
+	return
+}
+
+// Bind creates a bound instrument for this Gauge. The labels are
+// associated with values set via subsequent calls to Set.
+func (g Int64Gauge) Bind(labels ...attribute.KeyValue) (h BoundInt64Gauge) {
+	h.syncBoundInstrument = g.bind(labels)
+	return
+}
+
+// Measurement creates a Measurement object to use with batch
+// recording.
+func (g Float64Gauge) Measurement(value float64) Measurement {
+	return g.float64Measurement(value)
+}
+
+// Measurement creates a Measurement object to use with batch
+// recording.
+func (g Int64Gauge) Measurement(value int64) Measurement {
+	return g.int64Measurement(value)
+}
+
+// Set sets the current value of the Gauge, replacing the value
+// previously set with the same labels. The labels should contain the
+// keys and values to be associated with this value.
+func (g Float64Gauge) Set(ctx context.Context, value float64, labels ...attribute.KeyValue) {
+	g.directRecord(ctx, number.NewFloat64Number(value), labels)
+}
+
+// Set sets the current value of the Gauge, replacing the value
+// previously set with the same labels. The labels should contain the
+// keys and values to be associated with this value.
+func (g Int64Gauge) Set(ctx context.Context, value int64, labels ...attribute.KeyValue) {
+	g.directRecord(ctx, number.NewInt64Number(value), labels)
+}
+
+// Set sets the current value of the Gauge for the labels previously
+// bound to the Gauge via Bind().
+func (b BoundFloat64Gauge) Set(ctx context.Context, value float64) {
+	b.directRecord(ctx, number.NewFloat64Number(value))
+}
+
+// Set sets the current value of the Gauge for the labels previously
+// bound to the Gauge via Bind().
+func (b BoundInt64Gauge) Set(ctx context.Context, value int64) {
+	b.directRecord(ctx, number.NewInt64Number(value))
+}
@@ -41,6 +41,24 @@ type MeterImpl interface {
 	) (AsyncImpl, error)
 }
 
+// AsyncRegisterer is an optional interface of a MeterImpl supporting
+// callbacks registered for existing asynchronous instruments.
+type AsyncRegisterer interface {
+	// RegisterAsync runs runner to observe instruments, in
+	// addition to the runners they were created with. The
+	// registration is removed with AsyncUnregisterer.
+	RegisterAsync(runner AsyncBatchRunner, instruments ...AsyncImpl) error
+}
+
+// AsyncUnregisterer is an optional interface of a MeterImpl supporting
+// unregistering the callbacks of asynchronous instruments.
+type AsyncUnregisterer interface {
+	// UnregisterAsync stops running runner and releases the
+	// asynchronous instruments registered with it. Unregistering a
+	// runner that is not registered has no effect.
+	UnregisterAsync(runner AsyncRunner) error
+}
+
 // InstrumentImpl is a common interface for synchronous and
 // asynchronous instruments.
 type InstrumentImpl interface {
@@ -87,9 +105,11 @@ type AsyncImpl interface {
 // WrapMeterImpl constructs a `Meter` implementation from a
 // `MeterImpl` implementation.
 func WrapMeterImpl(impl MeterImpl, instrumentationName string, opts ...MeterOption) Meter {
+	config := NewMeterConfig(opts...)
 	return Meter{
-		impl:    impl,
-		name:    instrumentationName,
-		version: NewMeterConfig(opts...).InstrumentationVersion,
+		impl:      impl,
+		name:      instrumentationName,
+		version:   config.InstrumentationVersion,
+		schemaURL: config.SchemaURL,
 	}
 }
@@ -37,6 +37,7 @@ var (
 		metric.ValueRecorderInstrumentKind,
 		metric.CounterInstrumentKind,
 		metric.UpDownCounterInstrumentKind,
+		metric.GaugeInstrumentKind,
 	}
 	asyncKinds = []metric.InstrumentKind{
 		metric.ValueObserverInstrumentKind,
@@ -52,6 +53,7 @@ var (
 	groupingKinds = []metric.InstrumentKind{
 		metric.ValueRecorderInstrumentKind,
 		metric.ValueObserverInstrumentKind,
+		metric.GaugeInstrumentKind,
 	}
 
 	monotonicKinds = []metric.InstrumentKind{
@@ -64,6 +66,7 @@ var (
 		metric.UpDownSumObserverInstrumentKind,
 		metric.ValueRecorderInstrumentKind,
 		metric.ValueObserverInstrumentKind,
+		metric.GaugeInstrumentKind,
 	}
 
 	precomputedSumKinds = []metric.InstrumentKind{
@@ -76,6 +79,7 @@ var (
 		metric.UpDownCounterInstrumentKind,
 		metric.ValueRecorderInstrumentKind,
 		metric.ValueObserverInstrumentKind,
+		metric.GaugeInstrumentKind,
 	}
 )
 
@@ -293,6 +297,35 @@ func TestValueRecorder(t *testing.T) {
 	})
 }
 
+func TestGauge(t *testing.T) {
+	t.Run("float64 gauge", func(t *testing.T) {
+		mockSDK, meter := oteltest.NewMeter()
+		g := Must(meter).NewFloat64Gauge("test.gauge.float")
+		ctx := context.Background()
+		labels := []attribute.KeyValue{}
+		g.Set(ctx, 42, labels...)
+		boundInstrument := g.Bind(labels...)
+		boundInstrument.Set(ctx, 0)
+		meter.RecordBatch(ctx, labels, g.Measurement(-100.5))
+		checkSyncBatches(ctx, t, labels, mockSDK, number.Float64Kind, metric.GaugeInstrumentKind, g.SyncImpl(),
+			42, 0, -100.5,
+		)
+	})
+	t.Run("int64 gauge", func(t *testing.T) {
+		mockSDK, meter := oteltest.NewMeter()
+		g := Must(meter).NewInt64Gauge("test.gauge.int")
+		ctx := context.Background()
+		labels := []attribute.KeyValue{attribute.Int("I", 1)}
+		g.Set(ctx, 173, labels...)
+		boundInstrument := g.Bind(labels...)
+		boundInstrument.Set(ctx, 80)
+		meter.RecordBatch(ctx, labels, g.Measurement(0))
+		checkSyncBatches(ctx, t, labels, mockSDK, number.Int64Kind, metric.GaugeInstrumentKind, g.SyncImpl(),
+			173, 80, 0,
+		)
+	})
+}
+
 func TestObserverInstruments(t *testing.T) {
 	t.Run("float valueobserver", func(t *testing.T) {
 		labels := []attribute.KeyValue{attribute.String("O", "P")}
@@ -407,6 +440,105 @@ func TestBatchObserverInstruments(t *testing.T) {
 	require.Equal(t, 0, m2.Number.CompareNumber(number.Float64Kind, oteltest.ResolveNumberByKind(t, number.Float64Kind, 42)))
 }
 
+func TestUnregisterObserver(t *testing.T) {
+	mockSDK, meter := oteltest.NewMeter()
+
+	single := Must(meter).NewInt64ValueObserver("test.observer.single", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1)
+	})
+	var batchObs metric.Float64SumObserver
+	batch := Must(meter).NewBatchObserver(func(_ context.Context, result metric.BatchObserverResult) {
+		result.Observe(nil, batchObs.Observation(2))
+	})
+	batchObs = batch.NewFloat64SumObserver("test.observer.batch")
+
+	mockSDK.RunAsyncInstruments()
+	require.Len(t, mockSDK.MeasurementBatches, 2)
+
+	require.NoError(t, single.Unregister())
+	mockSDK.RunAsyncInstruments()
+	require.Len(t, mockSDK.MeasurementBatches, 3)
+	require.Equal(t, "test.observer.batch", mockSDK.MeasurementBatches[2].Measurements[0].Instrument.Descriptor().Name())
+
+	require.NoError(t, batch.Unregister())
+	// Unregistering again has no effect.
+	require.NoError(t, batchObs.Unregister())
+	mockSDK.RunAsyncInstruments()
+	require.Len(t, mockSDK.MeasurementBatches, 3)
+
+	// The instrument can be created again with a new callback.
+	again, err := meter.NewInt64SumObserver("test.observer.single", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(3)
+	})
+	require.NoError(t, err)
+	mockSDK.RunAsyncInstruments()
+	require.Len(t, mockSDK.MeasurementBatches, 4)
+	require.Equal(t, again.AsyncImpl(), mockSDK.MeasurementBatches[3].Measurements[0].Instrument)
+}
+
+func TestRegisterCallback(t *testing.T) {
+	mockSDK, meter := oteltest.NewMeter()
+
+	batch := Must(meter).NewBatchObserver(nil)
+	cpu := batch.NewFloat64ValueObserver("test.cpu")
+	memory := batch.NewInt64ValueObserver("test.memory")
+	own := Must(meter).NewInt64SumObserver("test.own", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1)
+	})
+
+	snapshots := 0
+	reg, err := meter.RegisterCallback(
+		[]metric.Asynchronous{cpu, memory, own},
+		func(_ context.Context, result metric.BatchObserverResult) {
+			snapshots++
+			result.Observe(nil,
+				cpu.Observation(0.5),
+				memory.Observation(1024),
+				own.Observation(2),
+			)
+		},
+	)
+	require.NoError(t, err)
+
+	mockSDK.RunAsyncInstruments()
+	require.Equal(t, 1, snapshots)
+	require.Len(t, mockSDK.MeasurementBatches, 2)
+	require.Len(t, mockSDK.MeasurementBatches[1].Measurements, 3)
+
+	require.NoError(t, reg.Unregister())
+	mockSDK.MeasurementBatches = nil
+	mockSDK.RunAsyncInstruments()
+	require.Equal(t, 1, snapshots)
+	// The callback of the instrument keeps running.
+	require.Len(t, mockSDK.MeasurementBatches, 1)
+	require.Equal(t, own.AsyncImpl(), mockSDK.MeasurementBatches[0].Measurements[0].Instrument)
+}
+
+func TestUnregisterNotSupported(t *testing.T) {
+	meter := metric.WrapMeterImpl(testUnregisterMeter{}, "test")
+	observer, err := meter.NewInt64ValueObserver("test.observer", func(context.Context, metric.Int64ObserverResult) {})
+	require.NoError(t, err)
+	assert.ErrorIs(t, observer.Unregister(), metric.ErrUnregisterNotSupported)
+
+	_, err = meter.RegisterCallback([]metric.Asynchronous{observer}, func(context.Context, metric.BatchObserverResult) {})
+	assert.ErrorIs(t, err, metric.ErrRegisterNotSupported)
+
+	// No-op instruments have no callback to unregister.
+	noop := Must(metric.Meter{}).NewInt64ValueObserver("test.observer", func(context.Context, metric.Int64ObserverResult) {})
+	assert.NoError(t, noop.Unregister())
+	reg, err := metric.Meter{}.RegisterCallback([]metric.Asynchronous{noop}, func(context.Context, metric.BatchObserverResult) {})
+	assert.NoError(t, err)
+	assert.NoError(t, reg.Unregister())
+}
+
+type testUnregisterMeter struct {
+	testWrappedMeter
+}
+
+func (testUnregisterMeter) NewAsyncInstrument(_ metric.Descriptor, _ metric.AsyncRunner) (metric.AsyncImpl, error) {
+	return metric.NoopAsync{}, nil
+}
+
 func checkObserverBatch(t *testing.T, labels []attribute.KeyValue, mock *oteltest.MeterImpl, nkind number.Kind, mkind metric.InstrumentKind, observer metric.AsyncImpl, expected float64) {
 	t.Helper()
 	assert.Len(t, mock.MeasurementBatches, 1)
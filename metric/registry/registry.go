@@ -37,14 +37,21 @@ type uniqueInstrumentMeterImpl struct {
 	lock  sync.Mutex
 	impl  metric.MeterImpl
 	state map[key]metric.InstrumentImpl
+
+	// asyncKeys maps the runner of asynchronous instruments to the
+	// keys of the instruments registered with it.
+	asyncKeys map[metric.AsyncRunner][]key
 }
 
 var _ metric.MeterImpl = (*uniqueInstrumentMeterImpl)(nil)
+var _ metric.AsyncRegisterer = (*uniqueInstrumentMeterImpl)(nil)
+var _ metric.AsyncUnregisterer = (*uniqueInstrumentMeterImpl)(nil)
 
 type key struct {
-	instrumentName         string
-	instrumentationName    string
-	InstrumentationVersion string
+	instrumentName           string
+	instrumentationName      string
+	InstrumentationVersion   string
+	InstrumentationSchemaURL string
 }
 
 // NewMeterProvider returns a new provider that implements instrument
@@ -69,8 +76,9 @@ var ErrMetricKindMismatch = fmt.Errorf(
 // the addition of uniqueness checking.
 func NewUniqueInstrumentMeterImpl(impl metric.MeterImpl) metric.MeterImpl {
 	return &uniqueInstrumentMeterImpl{
-		impl:  impl,
-		state: map[key]metric.InstrumentImpl{},
+		impl:      impl,
+		state:     map[key]metric.InstrumentImpl{},
+		asyncKeys: map[metric.AsyncRunner][]key{},
 	}
 }
 
@@ -84,6 +92,7 @@ func keyOf(descriptor metric.Descriptor) key {
 		descriptor.Name(),
 		descriptor.InstrumentationName(),
 		descriptor.InstrumentationVersion(),
+		descriptor.InstrumentationSchemaURL(),
 	}
 }
 
@@ -166,5 +175,37 @@ func (u *uniqueInstrumentMeterImpl) NewAsyncInstrument(
 		return nil, err
 	}
 	u.state[keyOf(descriptor)] = asyncInst
+	u.asyncKeys[runner] = append(u.asyncKeys[runner], keyOf(descriptor))
 	return asyncInst, nil
 }
+
+// RegisterAsync implements metric.AsyncRegisterer.
+func (u *uniqueInstrumentMeterImpl) RegisterAsync(runner metric.AsyncBatchRunner, instruments ...metric.AsyncImpl) error {
+	registerer, ok := u.impl.(metric.AsyncRegisterer)
+	if !ok {
+		return metric.ErrRegisterNotSupported
+	}
+	return registerer.RegisterAsync(runner, instruments...)
+}
+
+// UnregisterAsync implements metric.AsyncUnregisterer. The instruments
+// registered with runner can be registered again once it is
+// unregistered.
+func (u *uniqueInstrumentMeterImpl) UnregisterAsync(runner metric.AsyncRunner) error {
+	unregisterer, ok := u.impl.(metric.AsyncUnregisterer)
+	if !ok {
+		return metric.ErrUnregisterNotSupported
+	}
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if err := unregisterer.UnregisterAsync(runner); err != nil {
+		return err
+	}
+	for _, k := range u.asyncKeys[runner] {
+		delete(u.state, k)
+	}
+	delete(u.asyncKeys, runner)
+	return nil
+}
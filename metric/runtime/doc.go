@@ -0,0 +1,33 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package runtime provides observable instruments reporting statistics of the
+// Go runtime.
+//
+// A single call to Start registers the instruments against a MeterProvider:
+//
+//	if err := runtime.Start(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// The statistics are read from the runtime/metrics package, thus this package
+// requires Go 1.16 or later. Statistics not supported by the running Go
+// version (for example scheduler latencies before Go 1.17) are not reported.
+//
+// Distributions recorded by the runtime, GC pause times and scheduler
+// latencies, are reported as the quantiles of the values observed since the
+// previous collection, each by its own instrument named after the
+// distribution and the quantile, for example "runtime.go.gc.pause.p99" and
+// "runtime.go.gc.pause.max".
+package runtime // import "go.opentelemetry.io/otel/metric/runtime"
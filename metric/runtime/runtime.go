@@ -0,0 +1,335 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.16
+// +build go1.16
+
+package runtime // import "go.opentelemetry.io/otel/metric/runtime"
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime/metrics"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/unit"
+)
+
+// instrumentationName is the name of the Meter used by this package.
+const instrumentationName = "go.opentelemetry.io/otel/metric/runtime"
+
+// distributionQuantile is a quantile reported for each runtime distribution,
+// by an instrument named after the distribution followed by suffix.
+type distributionQuantile struct {
+	q           float64
+	suffix      string
+	description string
+}
+
+// quantiles are the quantiles reported for each runtime distribution.
+var quantiles = []distributionQuantile{
+	{q: 0.5, suffix: ".p50", description: "Median"},
+	{q: 0.9, suffix: ".p90", description: "90th percentile"},
+	{q: 0.99, suffix: ".p99", description: "99th percentile"},
+	{q: 1, suffix: ".max", description: "Maximum"},
+}
+
+type instrumentKind int
+
+const (
+	gaugeKind instrumentKind = iota
+	cumulativeKind
+	distributionKind
+)
+
+// runtimeMetric maps a runtime/metrics sample onto an instrument.
+type runtimeMetric struct {
+	name        string
+	key         string
+	kind        instrumentKind
+	unit        unit.Unit
+	description string
+}
+
+// runtimeMetrics are all the statistics reported by this package.
+var runtimeMetrics = []runtimeMetric{
+	{
+		name:        "runtime.go.goroutines",
+		key:         "/sched/goroutines:goroutines",
+		kind:        gaugeKind,
+		unit:        unit.Dimensionless,
+		description: "Number of live goroutines",
+	},
+	{
+		name:        "runtime.go.mem.heap_objects",
+		key:         "/memory/classes/heap/objects:bytes",
+		kind:        gaugeKind,
+		unit:        unit.Bytes,
+		description: "Memory occupied by live and not yet freed heap objects",
+	},
+	{
+		name:        "runtime.go.mem.stack",
+		key:         "/memory/classes/heap/stacks:bytes",
+		kind:        gaugeKind,
+		unit:        unit.Bytes,
+		description: "Memory reserved for goroutine stacks",
+	},
+	{
+		name:        "runtime.go.mem.total",
+		key:         "/memory/classes/total:bytes",
+		kind:        gaugeKind,
+		unit:        unit.Bytes,
+		description: "Memory mapped by the Go runtime into the process",
+	},
+	{
+		name:        "runtime.go.gc.heap_goal",
+		key:         "/gc/heap/goal:bytes",
+		kind:        gaugeKind,
+		unit:        unit.Bytes,
+		description: "Heap size target for the end of the GC cycle",
+	},
+	{
+		name:        "runtime.go.gc.count",
+		key:         "/gc/cycles/total:gc-cycles",
+		kind:        cumulativeKind,
+		unit:        unit.Dimensionless,
+		description: "Number of completed GC cycles",
+	},
+	{
+		name:        "runtime.go.gc.heap_allocs",
+		key:         "/gc/heap/allocs:bytes",
+		kind:        cumulativeKind,
+		unit:        unit.Bytes,
+		description: "Cumulative memory allocated to the heap",
+	},
+	{
+		name:        "runtime.go.gc.pause",
+		key:         "/gc/pauses:seconds",
+		kind:        distributionKind,
+		unit:        unit.Unit("s"),
+		description: "stop-the-world GC pauses since the last collection",
+	},
+	{
+		name:        "runtime.go.sched.latency",
+		key:         "/sched/latencies:seconds",
+		kind:        distributionKind,
+		unit:        unit.Unit("s"),
+		description: "time goroutines spent runnable before running since the last collection",
+	},
+}
+
+// config contains configuration options for the runtime instrumentation.
+type config struct {
+	meterProvider metric.MeterProvider
+}
+
+// Option configures the runtime instrumentation.
+type Option interface {
+	apply(*config)
+}
+
+type meterProviderOption struct {
+	mp metric.MeterProvider
+}
+
+func (o meterProviderOption) apply(c *config) {
+	c.meterProvider = o.mp
+}
+
+// WithMeterProvider sets the MeterProvider used to create the runtime
+// instruments. If this option is not provided the global MeterProvider is
+// used.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return meterProviderOption{mp: mp}
+}
+
+// instruments holds the state needed to observe the runtime statistics.
+type instruments struct {
+	meter metric.Meter
+
+	mu      sync.Mutex
+	samples []metrics.Sample
+	// observers are indexed in the same order as samples.
+	observers []observer
+}
+
+// observer converts a single runtime sample into observations.
+type observer struct {
+	int64Observer metric.Int64UpDownSumObserver
+	int64Sum      metric.Int64SumObserver
+	// quantileObservers are indexed in the same order as quantiles.
+	quantileObservers []metric.Float64ValueObserver
+	kind              instrumentKind
+
+	// previous is the last distribution read for distributionKind, used to
+	// compute the observations made since the last collection.
+	previous []uint64
+}
+
+// Start registers observable instruments reporting the Go runtime statistics.
+func Start(opts ...Option) error {
+	c := config{}
+	for _, o := range opts {
+		o.apply(&c)
+	}
+	if c.meterProvider == nil {
+		c.meterProvider = global.GetMeterProvider()
+	}
+	r := &instruments{
+		meter: c.meterProvider.Meter(
+			instrumentationName,
+			metric.WithInstrumentationVersion(otel.Version()),
+		),
+	}
+	return r.register()
+}
+
+func (r *instruments) register() error {
+	supported := map[string]metrics.ValueKind{}
+	for _, d := range metrics.All() {
+		supported[d.Name] = d.Kind
+	}
+
+	batch := r.meter.NewBatchObserver(r.observe)
+	for _, m := range runtimeMetrics {
+		valueKind, ok := supported[m.key]
+		if !ok {
+			continue
+		}
+		if err := checkValueKind(m, valueKind); err != nil {
+			return err
+		}
+
+		opts := []metric.InstrumentOption{
+			metric.WithUnit(m.unit),
+			metric.WithDescription(m.description),
+		}
+		o := observer{kind: m.kind}
+		var err error
+		switch m.kind {
+		case gaugeKind:
+			o.int64Observer, err = batch.NewInt64UpDownSumObserver(m.name, opts...)
+		case cumulativeKind:
+			o.int64Sum, err = batch.NewInt64SumObserver(m.name, opts...)
+		case distributionKind:
+			for _, q := range quantiles {
+				var qo metric.Float64ValueObserver
+				qo, err = batch.NewFloat64ValueObserver(
+					m.name+q.suffix,
+					metric.WithUnit(m.unit),
+					metric.WithDescription(q.description+" of the "+m.description),
+				)
+				if err != nil {
+					break
+				}
+				o.quantileObservers = append(o.quantileObservers, qo)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		r.samples = append(r.samples, metrics.Sample{Name: m.key})
+		r.observers = append(r.observers, o)
+	}
+	return nil
+}
+
+func checkValueKind(m runtimeMetric, k metrics.ValueKind) error {
+	want := metrics.KindUint64
+	if m.kind == distributionKind {
+		want = metrics.KindFloat64Histogram
+	}
+	if k != want {
+		return fmt.Errorf("runtime metric %q has unexpected value kind %d", m.key, k)
+	}
+	return nil
+}
+
+func (r *instruments) observe(_ context.Context, result metric.BatchObserverResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	metrics.Read(r.samples)
+
+	var obs []metric.Observation
+	for i, s := range r.samples {
+		o := &r.observers[i]
+		switch o.kind {
+		case gaugeKind:
+			obs = append(obs, o.int64Observer.Observation(clampInt64(s.Value.Uint64())))
+		case cumulativeKind:
+			obs = append(obs, o.int64Sum.Observation(clampInt64(s.Value.Uint64())))
+		case distributionKind:
+			obs = o.appendDistribution(obs, s.Value.Float64Histogram())
+		}
+	}
+	result.Observe(nil, obs...)
+}
+
+// appendDistribution appends to obs the observations of the quantiles of the
+// values added to h since the previous call.
+func (o *observer) appendDistribution(obs []metric.Observation, h *metrics.Float64Histogram) []metric.Observation {
+	delta := make([]uint64, len(h.Counts))
+	var total uint64
+	for i, c := range h.Counts {
+		if i < len(o.previous) {
+			c -= o.previous[i]
+		}
+		delta[i] = c
+		total += c
+	}
+	o.previous = append(o.previous[:0], h.Counts...)
+	if total == 0 {
+		return obs
+	}
+
+	for i, q := range quantiles {
+		obs = append(obs, o.quantileObservers[i].Observation(quantile(q.q, total, delta, h.Buckets)))
+	}
+	return obs
+}
+
+// quantile returns an estimate of the q quantile of a distribution of total
+// values, where counts[i] values fall within [buckets[i], buckets[i+1]). The
+// upper boundary of the bucket containing the quantile is returned, making
+// the estimate an upper bound of the actual value.
+func quantile(q float64, total uint64, counts []uint64, buckets []float64) float64 {
+	rank := uint64(math.Ceil(q * float64(total)))
+	if rank == 0 {
+		rank = 1
+	}
+	var seen uint64
+	for i, c := range counts {
+		seen += c
+		if seen < rank {
+			continue
+		}
+		if upper := buckets[i+1]; !math.IsInf(upper, 1) {
+			return upper
+		}
+		return buckets[i]
+	}
+	return buckets[len(buckets)-1]
+}
+
+func clampInt64(v uint64) int64 {
+	if v > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(v)
+}
@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.16
+// +build go1.16
+
+package runtime
+
+import (
+	"math"
+	goruntime "runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/oteltest"
+)
+
+func TestStart(t *testing.T) {
+	impl, provider := oteltest.NewMeterProvider()
+	require.NoError(t, Start(WithMeterProvider(provider)))
+
+	goruntime.GC()
+	impl.RunAsyncInstruments()
+
+	values := map[string]number.Number{}
+	for _, m := range oteltest.AsStructs(impl.MeasurementBatches) {
+		assert.Equal(t, instrumentationName, m.InstrumentationName)
+		assert.Empty(t, m.Labels, m.Name)
+		values[m.Name] = m.Number
+	}
+
+	for _, name := range []string{
+		"runtime.go.goroutines",
+		"runtime.go.mem.heap_objects",
+		"runtime.go.mem.total",
+		"runtime.go.gc.count",
+	} {
+		value := values[name]
+		assert.Greater(t, value.AsInt64(), int64(0), name)
+	}
+	for _, q := range quantiles {
+		assert.Contains(t, values, "runtime.go.gc.pause"+q.suffix)
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	buckets := []float64{0, 1, 2, 4, 8}
+	counts := []uint64{5, 3, 0, 2}
+
+	assert.Equal(t, 1.0, quantile(0.5, 10, counts, buckets))
+	assert.Equal(t, 2.0, quantile(0.8, 10, counts, buckets))
+	assert.Equal(t, 8.0, quantile(0.9, 10, counts, buckets))
+	assert.Equal(t, 8.0, quantile(1, 10, counts, buckets))
+	assert.Equal(t, 1.0, quantile(0, 10, counts, buckets))
+}
+
+func TestQuantileInfiniteBucket(t *testing.T) {
+	buckets := []float64{0, 1, math.Inf(1)}
+	counts := []uint64{1, 1}
+
+	assert.Equal(t, 1.0, quantile(1, 2, counts, buckets))
+}
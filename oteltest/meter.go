@@ -154,6 +154,18 @@ func (m *MeterImpl) NewAsyncInstrument(descriptor metric.Descriptor, runner metr
 	return a, nil
 }
 
+// RegisterAsync implements metric.AsyncRegisterer.
+func (m *MeterImpl) RegisterAsync(runner metric.AsyncBatchRunner, instruments ...metric.AsyncImpl) error {
+	m.asyncInstruments.RegisterBatch(runner, instruments...)
+	return nil
+}
+
+// UnregisterAsync implements metric.AsyncUnregisterer.
+func (m *MeterImpl) UnregisterAsync(runner metric.AsyncRunner) error {
+	m.asyncInstruments.Unregister(runner)
+	return nil
+}
+
 func (m *MeterImpl) RecordBatch(ctx context.Context, labels []attribute.KeyValue, measurements ...metric.Measurement) {
 	mm := make([]Measurement, len(measurements))
 	for i := 0; i < len(measurements); i++ {
@@ -22,12 +22,18 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/internal"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
 var _ trace.Span = (*Span)(nil)
 
+// codeStacktraceKey is the attribute key of the stack trace recorded for
+// events other than exceptions. It is not defined by the semantic
+// conventions.
+const codeStacktraceKey = attribute.Key("code.stacktrace")
+
 // Span is an OpenTelemetry Span used for testing.
 type Span struct {
 	lock          sync.RWMutex
@@ -94,7 +100,10 @@ func (s *Span) RecordError(err error, opts ...trace.EventOption) {
 	s.AddEvent(semconv.ExceptionEventName, opts...)
 }
 
-// AddEvent adds an event to s.
+// AddEvent adds an event to s. With the WithStackTrace option, the stack
+// trace of the calling goroutine is recorded as the code.stacktrace
+// attribute of the event, or as its exception.stacktrace attribute for
+// exception events.
 func (s *Span) AddEvent(name string, o ...trace.EventOption) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -104,6 +113,13 @@ func (s *Span) AddEvent(name string, o ...trace.EventOption) {
 	}
 
 	c := trace.NewEventConfig(o...)
+	if c.StackTrace {
+		key := codeStacktraceKey
+		if name == semconv.ExceptionEventName {
+			key = semconv.ExceptionStacktraceKey
+		}
+		c.Attributes = append(c.Attributes, key.String(internal.StackTrace()))
+	}
 
 	var attributes map[attribute.Key]attribute.Value
 	if l := len(c.Attributes); l > 0 {
@@ -143,6 +159,18 @@ func (s *Span) SetStatus(code codes.Code, msg string) {
 	s.statusMessage = msg
 }
 
+// AddLink adds link to the links of s.
+func (s *Span) AddLink(link trace.Link) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.ended {
+		return
+	}
+
+	s.links = append(s.links, link)
+}
+
 // SetName sets the name of s.
 func (s *Span) SetName(name string) {
 	s.lock.Lock()
@@ -155,6 +183,14 @@ func (s *Span) SetName(name string) {
 	s.name = name
 }
 
+// SetAttributeSet sets the attributes contained in set as attributes of s.
+func (s *Span) SetAttributeSet(set *attribute.Set) {
+	if set == nil {
+		return
+	}
+	s.SetAttributes(set.ToSlice()...)
+}
+
 // SetAttributes sets attrs as attributes of s.
 func (s *Span) SetAttributes(attrs ...attribute.KeyValue) {
 	s.lock.Lock()
@@ -198,8 +234,9 @@ func (s *Span) Attributes() map[attribute.Key]attribute.Value {
 // been called on s.
 func (s *Span) Events() []Event { return s.events }
 
-// Links returns the links set on s at creation time. If multiple links for
-// the same SpanContext were set, the last link will be used.
+// Links returns the links set on s at creation time, followed by the ones
+// added with AddLink. If multiple links for the same SpanContext were set
+// at creation time, the last link will be used.
 func (s *Span) Links() []trace.Link { return s.links }
 
 // StartTime returns the time at which s was started. This will be the
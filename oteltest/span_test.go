@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -27,7 +28,7 @@ import (
 	ottest "go.opentelemetry.io/otel/internal/internaltest"
 	"go.opentelemetry.io/otel/internal/matchers"
 	"go.opentelemetry.io/otel/oteltest"
-	"go.opentelemetry.io/otel/semconv"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -190,6 +191,25 @@ func TestSpan(t *testing.T) {
 			e.Expect(len(subject.Events())).ToEqual(0)
 		})
 
+		t.Run("records a stack trace when requested", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+
+			tracer := tp.Tracer(t.Name())
+			_, span := tracer.Start(context.Background(), "test")
+
+			subject, ok := span.(*oteltest.Span)
+			e.Expect(ok).ToBeTrue()
+
+			subject.RecordError(errors.New("test error"), trace.WithStackTrace(true))
+
+			events := subject.Events()
+			e.Expect(len(events)).ToEqual(1)
+			stack := events[0].Attributes[semconv.ExceptionStacktraceKey].AsString()
+			e.Expect(strings.Contains(stack, "TestSpan")).ToBeTrue()
+		})
+
 		t.Run("has no effect with nil error", func(t *testing.T) {
 			t.Parallel()
 
@@ -332,6 +352,29 @@ func TestSpan(t *testing.T) {
 			e.Expect(attributes[attr3.Key]).ToEqual(attr3.Value)
 		})
 
+		t.Run("includes the attributes of a set", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+
+			tracer := tp.Tracer(t.Name())
+			_, span := tracer.Start(context.Background(), "test")
+
+			subject, ok := span.(*oteltest.Span)
+			e.Expect(ok).ToBeTrue()
+
+			attr1 := attribute.String("key1", "value1")
+			attr2 := attribute.String("key2", "value2")
+			set := attribute.NewSet(attr1, attr2)
+
+			subject.SetAttributeSet(&set)
+
+			attributes := subject.Attributes()
+
+			e.Expect(attributes[attr1.Key]).ToEqual(attr1.Value)
+			e.Expect(attributes[attr2.Key]).ToEqual(attr2.Value)
+		})
+
 		t.Run("cannot be changed after the span has been ended", func(t *testing.T) {
 			t.Parallel()
 
@@ -401,6 +444,30 @@ func TestSpan(t *testing.T) {
 
 			e.Expect(len(subject.Links())).ToEqual(0)
 		})
+
+		t.Run("includes the links added before End", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+
+			tracer := tp.Tracer(t.Name())
+			_, span := tracer.Start(context.Background(), "test")
+
+			subject, ok := span.(*oteltest.Span)
+			e.Expect(ok).ToBeTrue()
+
+			link := trace.Link{
+				SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+					TraceID: trace.TraceID{1},
+					SpanID:  trace.SpanID{1},
+				}),
+			}
+			subject.AddLink(link)
+			subject.End()
+			subject.AddLink(link)
+
+			e.Expect(subject.Links()).ToEqual([]trace.Link{link})
+		})
 	})
 
 	t.Run("#Events", func(t *testing.T) {
@@ -419,6 +486,25 @@ func TestSpan(t *testing.T) {
 			e.Expect(len(subject.Events())).ToEqual(0)
 		})
 
+		t.Run("records a stack trace with WithStackTrace", func(t *testing.T) {
+			t.Parallel()
+
+			e := matchers.NewExpecter(t)
+
+			tracer := tp.Tracer(t.Name())
+			_, span := tracer.Start(context.Background(), "test")
+
+			subject, ok := span.(*oteltest.Span)
+			e.Expect(ok).ToBeTrue()
+
+			subject.AddEvent("event", trace.WithStackTrace(true))
+
+			events := subject.Events()
+			e.Expect(len(events)).ToEqual(1)
+			stack := events[0].Attributes[attribute.Key("code.stacktrace")].AsString()
+			e.Expect(strings.Contains(stack, "TestSpan")).ToBeTrue()
+		})
+
 		t.Run("returns all of the added events", func(t *testing.T) {
 			t.Parallel()
 
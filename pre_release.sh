@@ -69,9 +69,11 @@ if ! git diff --quiet; then \
 fi
 
 # Update version.go
-cp ./version.go ./version.go.bak
-sed "s/\(return \"\)[0-9]*\.[0-9]*\.[0-9]*\"/\1${OTEL_VERSION}\"/" ./version.go.bak >./version.go
-rm -f ./version.go.bak
+for file in ./version.go ./sdk/version.go; do
+	cp "${file}" "${file}.bak"
+	sed "s/\(return \"\)[0-9]*\.[0-9]*\.[0-9]*\"/\1${OTEL_VERSION}\"/" "${file}.bak" >"${file}"
+	rm -f "${file}.bak"
+done
 
 # Update go.mod
 git checkout -b pre_release_${TAG} main
@@ -29,3 +29,16 @@ func GetTextMapPropagator() propagation.TextMapPropagator {
 func SetTextMapPropagator(propagator propagation.TextMapPropagator) {
 	global.SetTextMapPropagator(propagator)
 }
+
+// SwapTextMapPropagator sets propagator as the global TextMapPropagator
+// and returns the previously set one. Unlike SetTextMapPropagator, the
+// previous TextMapPropagator is not set to delegate to propagator, which
+// makes it suitable to install, and later restore, test fixtures. Passing
+// nil installs a new default delegating TextMapPropagator that the next
+// call to SetTextMapPropagator delegates to.
+//
+// SwapTextMapPropagator must not be called concurrently with
+// SetTextMapPropagator.
+func SwapTextMapPropagator(propagator propagation.TextMapPropagator) propagation.TextMapPropagator {
+	return global.SwapTextMapPropagator(propagator)
+}
@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestExtractB3(t *testing.T) {
+	traceID64 := mustTraceIDFromHex("000000000000000000f067aa0ba902b7")
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wantSc  trace.SpanContext
+	}{
+		{
+			name: "multiple headers, sampled",
+			headers: map[string]string{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "1",
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "multiple headers, lenient sampled and 64-bit trace ID",
+			headers: map[string]string{
+				"x-b3-traceid":      "00F067AA0BA902B7",
+				"x-b3-spanid":       spanIDStr,
+				"x-b3-parentspanid": spanIDStr,
+				"x-b3-sampled":      "false",
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID64,
+				SpanID:  spanID,
+				Remote:  true,
+			}),
+		},
+		{
+			name: "multiple headers, deferred",
+			headers: map[string]string{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsDeferred,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "multiple headers, debug",
+			headers: map[string]string{
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-flags":   "1",
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsDebug | trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "single header",
+			headers: map[string]string{
+				"b3": traceIDStr + "-" + spanIDStr + "-1",
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "single header, 64-bit trace ID, debug and parent",
+			headers: map[string]string{
+				"b3": "00f067aa0ba902b7-" + spanIDStr + "-d-" + spanIDStr,
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID64,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsDebug | trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "single header, deferred",
+			headers: map[string]string{
+				"b3": traceIDStr + "-" + spanIDStr,
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsDeferred,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "single header takes precedence",
+			headers: map[string]string{
+				"b3":           traceIDStr + "-" + spanIDStr + "-0",
+				"x-b3-traceid": "00f067aa0ba902b7",
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "1",
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+				Remote:  true,
+			}),
+		},
+		{
+			name: "invalid single header falls back to multiple headers",
+			headers: map[string]string{
+				"b3":           traceIDStr + "-" + spanIDStr + "-x",
+				"x-b3-traceid": traceIDStr,
+				"x-b3-spanid":  spanIDStr,
+				"x-b3-sampled": "1",
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+	}
+
+	prop := propagation.B3{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.headers {
+				header.Set(k, v)
+			}
+			ctx := prop.Extract(context.Background(), propagation.HeaderCarrier(header))
+			gotSc := trace.SpanContextFromContext(ctx)
+			if diff := cmp.Diff(gotSc, tt.wantSc, cmp.AllowUnexported(trace.TraceState{})); diff != "" {
+				t.Errorf("Extract B3: %s: -got +want %s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestExtractInvalidB3(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+	}{
+		{"no headers", map[string]string{}},
+		{"sampling only", map[string]string{"b3": "1", "x-b3-sampled": "1"}},
+		{"trace ID only", map[string]string{"b3": traceIDStr, "x-b3-traceid": traceIDStr}},
+		{"invalid trace ID", map[string]string{"x-b3-traceid": "qw" + traceIDStr[2:], "x-b3-spanid": spanIDStr}},
+		{"invalid span ID", map[string]string{"x-b3-traceid": traceIDStr, "x-b3-spanid": "qw" + spanIDStr[2:]}},
+		{"invalid sampled", map[string]string{"x-b3-traceid": traceIDStr, "x-b3-spanid": spanIDStr, "x-b3-sampled": "2"}},
+		{"invalid parent", map[string]string{"x-b3-traceid": traceIDStr, "x-b3-spanid": spanIDStr, "x-b3-parentspanid": "qw"}},
+		{"parent without IDs", map[string]string{"x-b3-parentspanid": spanIDStr}},
+		{"single header missing sampling", map[string]string{"b3": traceIDStr + "-" + spanIDStr + "-"}},
+		{"single header parent without sampling", map[string]string{"b3": traceIDStr + "-" + spanIDStr + "-" + spanIDStr}},
+		{"single header short span ID", map[string]string{"b3": traceIDStr + "-" + spanIDStr[:8]}},
+		{"single header trailing data", map[string]string{"b3": traceIDStr + "-" + spanIDStr + "-1-" + spanIDStr + "-1"}},
+	}
+
+	prop := propagation.B3{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.headers {
+				header.Set(k, v)
+			}
+			ctx := prop.Extract(context.Background(), propagation.HeaderCarrier(header))
+			if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+				t.Errorf("Extract B3: %s: extracted %v", tt.name, sc)
+			}
+		})
+	}
+}
+
+func TestInjectB3(t *testing.T) {
+	sampled := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	tests := []struct {
+		name     string
+		encoding propagation.B3Encoding
+		sc       trace.SpanContext
+		want     map[string]string
+	}{
+		{
+			name:     "default encoding",
+			encoding: propagation.B3Unspecified,
+			sc:       sampled,
+			want: map[string]string{
+				"X-B3-Traceid": traceIDStr,
+				"X-B3-Spanid":  spanIDStr,
+				"X-B3-Sampled": "1",
+			},
+		},
+		{
+			name:     "single header",
+			encoding: propagation.B3SingleHeader,
+			sc:       sampled,
+			want: map[string]string{
+				"B3": traceIDStr + "-" + spanIDStr + "-1",
+			},
+		},
+		{
+			name:     "both encodings, not sampled",
+			encoding: propagation.B3SingleHeader | propagation.B3MultipleHeader,
+			sc:       sampled.WithTraceFlags(0),
+			want: map[string]string{
+				"B3":           traceIDStr + "-" + spanIDStr + "-0",
+				"X-B3-Traceid": traceIDStr,
+				"X-B3-Spanid":  spanIDStr,
+				"X-B3-Sampled": "0",
+			},
+		},
+		{
+			name:     "both encodings, debug",
+			encoding: propagation.B3SingleHeader | propagation.B3MultipleHeader,
+			sc:       sampled.WithTraceFlags(trace.FlagsDebug | trace.FlagsSampled),
+			want: map[string]string{
+				"B3":           traceIDStr + "-" + spanIDStr + "-d",
+				"X-B3-Traceid": traceIDStr,
+				"X-B3-Spanid":  spanIDStr,
+				"X-B3-Flags":   "1",
+			},
+		},
+		{
+			name:     "both encodings, deferred",
+			encoding: propagation.B3SingleHeader | propagation.B3MultipleHeader,
+			sc:       sampled.WithTraceFlags(trace.FlagsDeferred),
+			want: map[string]string{
+				"B3":           traceIDStr + "-" + spanIDStr,
+				"X-B3-Traceid": traceIDStr,
+				"X-B3-Spanid":  spanIDStr,
+			},
+		},
+		{
+			name:     "invalid span context",
+			encoding: propagation.B3SingleHeader | propagation.B3MultipleHeader,
+			sc:       trace.SpanContext{},
+			want:     map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			ctx := trace.ContextWithRemoteSpanContext(context.Background(), tt.sc)
+			propagation.B3{InjectEncoding: tt.encoding}.Inject(ctx, propagation.HeaderCarrier(header))
+
+			got := map[string]string{}
+			for k := range header {
+				got[k] = header.Get(k)
+			}
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("Inject B3: %s: -got +want %s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestB3RoundTrip(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	for _, encoding := range []propagation.B3Encoding{propagation.B3SingleHeader, propagation.B3MultipleHeader} {
+		prop := propagation.B3{InjectEncoding: encoding}
+		header := http.Header{}
+		prop.Inject(trace.ContextWithRemoteSpanContext(context.Background(), sc), propagation.HeaderCarrier(header))
+		got := trace.SpanContextFromContext(prop.Extract(context.Background(), propagation.HeaderCarrier(header)))
+		if !got.Equal(sc) {
+			t.Errorf("B3 encoding %d: round trip %v, want %v", encoding, got, sc)
+		}
+	}
+}
+
+func TestB3PropagatorFields(t *testing.T) {
+	tests := []struct {
+		encoding propagation.B3Encoding
+		want     []string
+	}{
+		{propagation.B3Unspecified, []string{"x-b3-traceid", "x-b3-spanid", "x-b3-sampled", "x-b3-flags"}},
+		{propagation.B3SingleHeader, []string{"b3"}},
+		{propagation.B3SingleHeader | propagation.B3MultipleHeader, []string{"b3", "x-b3-traceid", "x-b3-spanid", "x-b3-sampled", "x-b3-flags"}},
+	}
+	for _, tt := range tests {
+		got := propagation.B3{InjectEncoding: tt.encoding}.Fields()
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("Fields: encoding %d: -got +want %s", tt.encoding, diff)
+		}
+	}
+}
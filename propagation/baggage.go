@@ -17,95 +17,245 @@ package propagation // import "go.opentelemetry.io/otel/propagation"
 import (
 	"context"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/internal/baggage"
 )
 
-const baggageHeader = "baggage"
+const (
+	baggageHeader = "baggage"
+
+	// hopsProperty is the baggage member property holding the number of
+	// times a hop-limited member may still be propagated.
+	hopsProperty = "hops"
+
+	// DefaultBaggageMaxMembers is the default maximum number of baggage
+	// members extracted or injected by the Baggage propagator, the limit
+	// of the W3C Baggage specification.
+	DefaultBaggageMaxMembers = 180
+	// DefaultBaggageMaxBytes is the default maximum number of bytes of
+	// the baggage header read or written by the Baggage propagator, the
+	// limit of the W3C Baggage specification.
+	DefaultBaggageMaxBytes = 8192
+)
 
 // Baggage is a propagator that supports the W3C Baggage format.
 //
 // This propagates user-defined baggage associated with a trace. The complete
 // specification is defined at https://w3c.github.io/baggage/.
-type Baggage struct{}
+//
+// The properties of the baggage members are propagated with them. Baggage
+// values set with baggage.ContextWithLocalValues are not injected.
+// Values set with baggage.ContextWithHopLimitedValues are injected with their
+// remaining hop limit, decremented, in the "hops" member property, and are
+// extracted as hop-limited values again.
+//
+// The extraction is bounded: the members past the first MaxMembers valid
+// ones and past the first MaxBytes bytes of the header are dropped, and
+// only the members of AllowedKeys are extracted if it is not empty. The
+// injection is bounded by the same limits: the members whose key is not a
+// valid W3C Baggage key are not injected, and the members, in the order of
+// their encoding, that would exceed MaxMembers or MaxBytes are dropped.
+type Baggage struct {
+	// MaxMembers is the maximum number of members extracted or injected.
+	// DefaultBaggageMaxMembers is used if it is not positive.
+	MaxMembers int
+
+	// MaxBytes is the maximum number of bytes of the baggage header
+	// read or written, a member that does not fit entirely is dropped.
+	// DefaultBaggageMaxBytes is used if it is not positive.
+	MaxBytes int
+
+	// AllowedKeys are the keys of the members extracted, all the keys
+	// are extracted if it is empty.
+	AllowedKeys []string
+}
 
 var _ TextMapPropagator = Baggage{}
 
 // Inject sets baggage key-values from ctx into the carrier.
 func (b Baggage) Inject(ctx context.Context, carrier TextMapCarrier) {
 	baggageMap := baggage.MapFromContext(ctx)
-	firstIter := true
-	var headerValueBuilder strings.Builder
+	var members []string
 	baggageMap.Foreach(func(kv attribute.KeyValue) bool {
-		if !firstIter {
-			headerValueBuilder.WriteRune(',')
+		if !baggage.ValidKey(string(kv.Key)) {
+			return true
+		}
+		props := baggageMap.Properties(kv.Key)
+		hops, limited := baggageMap.HopLimit(kv.Key)
+		if limited {
+			if hops <= 0 {
+				return true
+			}
+			props = append(props[:len(props):len(props)], baggage.Property{
+				Key:      hopsProperty,
+				Value:    strconv.Itoa(hops - 1),
+				HasValue: true,
+			})
 		}
-		firstIter = false
-		headerValueBuilder.WriteString(url.QueryEscape(strings.TrimSpace((string)(kv.Key))))
-		headerValueBuilder.WriteRune('=')
-		headerValueBuilder.WriteString(url.QueryEscape(strings.TrimSpace(kv.Value.Emit())))
+		members = append(members, baggage.EncodeMember(string(kv.Key), kv.Value.Emit(), props))
 		return true
 	})
-	if headerValueBuilder.Len() > 0 {
-		headerString := headerValueBuilder.String()
-		carrier.Set(baggageHeader, headerString)
+	// The members are sorted so that the same ones are dropped for the
+	// same baggage.
+	sort.Strings(members)
+	maxMembers, maxBytes := b.limits()
+	kept, n := members[:0], -1
+	for _, m := range members {
+		if len(kept) == maxMembers {
+			break
+		}
+		// The members are separated by commas.
+		if n+1+len(m) > maxBytes {
+			continue
+		}
+		kept = append(kept, m)
+		n += 1 + len(m)
+	}
+	members = kept
+	if len(members) > 0 {
+		carrier.Set(baggageHeader, strings.Join(members, ","))
 	}
 }
 
 // Extract returns a copy of parent with the baggage from the carrier added.
+// The properties of the baggage members are extracted with them, the
+// invalid members are ignored.
 func (b Baggage) Extract(parent context.Context, carrier TextMapCarrier) context.Context {
 	bVal := carrier.Get(baggageHeader)
 	if bVal == "" {
 		return parent
 	}
 
-	baggageValues := strings.Split(bVal, ",")
-	keyValues := make([]attribute.KeyValue, 0, len(baggageValues))
-	for _, baggageValue := range baggageValues {
-		valueAndProps := strings.Split(baggageValue, ";")
-		if len(valueAndProps) < 1 {
-			continue
-		}
-		nameValue := strings.Split(valueAndProps[0], "=")
-		if len(nameValue) < 2 {
-			continue
+	maxMembers, maxBytes := b.limits()
+	if len(bVal) > maxBytes {
+		// Only the members entirely within the limit are extracted.
+		cut := bVal[maxBytes] == ','
+		bVal = bVal[:maxBytes]
+		if !cut {
+			i := strings.LastIndexByte(bVal, ',')
+			if i < 0 {
+				return parent
+			}
+			bVal = bVal[:i]
 		}
-		name, err := url.QueryUnescape(nameValue[0])
-		if err != nil {
-			continue
+	}
+
+	var updates []baggage.MapUpdate
+	for len(bVal) > 0 && len(updates) < maxMembers {
+		var member string
+		if i := strings.IndexByte(bVal, ','); i >= 0 {
+			member, bVal = bVal[:i], bVal[i+1:]
+		} else {
+			member, bVal = bVal, ""
 		}
-		trimmedName := strings.TrimSpace(name)
-		value, err := url.QueryUnescape(nameValue[1])
-		if err != nil {
+		key, value, props, err := baggage.ParseMember(member)
+		if err != nil || !b.allowed(key) {
 			continue
 		}
-		trimmedValue := strings.TrimSpace(value)
-
-		// TODO (skaris): properties defiend https://w3c.github.io/correlation-context/, are currently
-		// just put as part of the value.
-		var trimmedValueWithProps strings.Builder
-		trimmedValueWithProps.WriteString(trimmedValue)
-		for _, prop := range valueAndProps[1:] {
-			trimmedValueWithProps.WriteRune(';')
-			trimmedValueWithProps.WriteString(prop)
+		update := baggage.MapUpdate{SingleKV: attribute.String(key, value)}
+		// The hops property is not a property of the value.
+		for i, prop := range props {
+			if h, ok := parseHopsProperty(prop); ok {
+				update.HopLimited, update.HopLimit = true, h
+				props = append(props[:i:i], props[i+1:]...)
+				break
+			}
 		}
+		update.Properties = props
+		updates = append(updates, update)
+	}
 
-		keyValues = append(keyValues, attribute.String(trimmedName, trimmedValueWithProps.String()))
+	if len(updates) == 0 {
+		return parent
+	}
+	// Only update the context if valid values were found
+	m := baggage.NewEmptyMap()
+	for _, u := range updates {
+		m = m.Apply(u)
 	}
+	return baggage.ContextWithMap(parent, m)
+}
 
-	if len(keyValues) > 0 {
-		// Only update the context if valid values were found
-		return baggage.ContextWithMap(parent, baggage.NewMap(baggage.MapUpdate{
-			MultiKV: keyValues,
-		}))
+// limits returns the maximum number of members and bytes of a baggage
+// header.
+func (b Baggage) limits() (maxMembers, maxBytes int) {
+	maxMembers, maxBytes = b.MaxMembers, b.MaxBytes
+	if maxMembers <= 0 {
+		maxMembers = DefaultBaggageMaxMembers
 	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultBaggageMaxBytes
+	}
+	return maxMembers, maxBytes
+}
+
+// allowed returns whether the members of key are extracted.
+func (b Baggage) allowed(key string) bool {
+	if len(b.AllowedKeys) == 0 {
+		return true
+	}
+	for _, k := range b.AllowedKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
 
-	return parent
+// parseHopsProperty returns the hop limit held by the baggage member
+// property prop and whether prop is a valid hops property.
+func parseHopsProperty(prop baggage.Property) (int, bool) {
+	if prop.Key != hopsProperty || !prop.HasValue {
+		return 0, false
+	}
+	hops, err := strconv.Atoi(prop.Value)
+	if err != nil || hops < 0 {
+		return 0, false
+	}
+	return hops, true
 }
 
 // Fields returns the keys who's values are set with Inject.
 func (b Baggage) Fields() []string {
 	return []string{baggageHeader}
 }
+
+// injectPrefixedBaggage sets the baggage values of ctx into the carrier,
+// each one in the key prefixed with prefix. The hop-limited values are not
+// set, the limit cannot be carried.
+func injectPrefixedBaggage(ctx context.Context, carrier TextMapCarrier, prefix string) {
+	m := baggage.MapFromContext(ctx)
+	m.Foreach(func(kv attribute.KeyValue) bool {
+		if _, limited := m.HopLimit(kv.Key); !limited {
+			carrier.Set(prefix+string(kv.Key), url.QueryEscape(kv.Value.Emit()))
+		}
+		return true
+	})
+}
+
+// extractPrefixedBaggage returns a copy of ctx with the values of the keys
+// of the carrier prefixed with prefix added to its baggage.
+func extractPrefixedBaggage(ctx context.Context, carrier TextMapCarrier, prefix string) context.Context {
+	var kvs []attribute.KeyValue
+	for _, key := range carrier.Keys() {
+		// Carriers like HeaderCarrier canonicalize the keys.
+		lower := strings.ToLower(key)
+		if !strings.HasPrefix(lower, prefix) || lower == prefix {
+			continue
+		}
+		value, err := url.QueryUnescape(carrier.Get(key))
+		if err != nil {
+			continue
+		}
+		kvs = append(kvs, attribute.String(lower[len(prefix):], value))
+	}
+	if len(kvs) == 0 {
+		return ctx
+	}
+	m := baggage.MapFromContext(ctx).Apply(baggage.MapUpdate{MultiKV: kvs})
+	return baggage.ContextWithMap(ctx, m)
+}
@@ -16,7 +16,10 @@ package propagation_test
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -55,7 +58,7 @@ func TestExtractValidBaggageFromHTTPReq(t *testing.T) {
 			header: "key1=val1,key2=val2;prop=1",
 			wantKVs: []attribute.KeyValue{
 				attribute.String("key1", "val1"),
-				attribute.String("key2", "val2;prop=1"),
+				attribute.String("key2", "val2"),
 			},
 		},
 		{
@@ -66,6 +69,13 @@ func TestExtractValidBaggageFromHTTPReq(t *testing.T) {
 				attribute.String("key2", "val2,val3"),
 			},
 		},
+		{
+			name:   "valid header with a plus sign",
+			header: "key1=a+b%20c%2B",
+			wantKVs: []attribute.KeyValue{
+				attribute.String("key1", "a b c+"),
+			},
+		},
 		{
 			name:   "valid header with an invalid header",
 			header: "key1=val1,key2=val2,a,val3",
@@ -196,7 +206,15 @@ func TestInjectBaggageToHTTPReq(t *testing.T) {
 				attribute.String("key1", "val1,val2"),
 				attribute.String("key2", "val3=4"),
 			},
-			wantInHeader: []string{"key1=val1%2Cval2", "key2=val3%3D4"},
+			wantInHeader: []string{"key1=val1%2Cval2", "key2=val3=4"},
+		},
+		{
+			name: "percent-encoded values",
+			kvs: []attribute.KeyValue{
+				attribute.String("key1", "a b+c%"),
+				attribute.String("key2", `"\;é`),
+			},
+			wantInHeader: []string{"key1=a%20b%2Bc%25", "key2=%22%5C%3B%C3%A9"},
 		},
 		{
 			name: "values of non-string types",
@@ -246,3 +264,239 @@ func TestBaggagePropagatorGetAllKeys(t *testing.T) {
 		t.Errorf("GetAllKeys: -got +want %s", diff)
 	}
 }
+
+func TestBaggagePropagatorHopLimitedValues(t *testing.T) {
+	propagator := propagation.Baggage{}
+	ctx := baggage.ContextWithMap(context.Background(), baggage.NewMap(baggage.MapUpdate{
+		SingleKV: attribute.String("public", "v"),
+	}).Apply(baggage.MapUpdate{
+		SingleKV:   attribute.String("secret", "s"),
+		HopLimited: true,
+	}).Apply(baggage.MapUpdate{
+		SingleKV:   attribute.String("limited", "l"),
+		HopLimited: true,
+		HopLimit:   2,
+	}))
+
+	// First hop.
+	header := http.Header{}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+	got := header.Get("baggage")
+	if strings.Contains(got, "secret") {
+		t.Errorf("local value injected: %s", got)
+	}
+	for _, want := range []string{"public=v", "limited=l;hops=1"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Inject baggage missing %s in %s", want, got)
+		}
+	}
+
+	ctx = propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+	m := baggage.MapFromContext(ctx)
+	if v, _ := m.Value("limited"); v.AsString() != "l" {
+		t.Errorf("extracted limited value %q, want %q", v.AsString(), "l")
+	}
+	if hops, ok := m.HopLimit("limited"); !ok || hops != 1 {
+		t.Errorf("extracted hop limit %d, %t, want 1, true", hops, ok)
+	}
+
+	// Second hop: the value is propagated, but no further.
+	header = http.Header{}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+	if got, want := header.Get("baggage"), "limited=l;hops=0"; !strings.Contains(got, want) {
+		t.Errorf("Inject baggage missing %s in %s", want, got)
+	}
+	ctx = propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+
+	header = http.Header{}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+	if got := header.Get("baggage"); strings.Contains(got, "limited") {
+		t.Errorf("exhausted value injected: %s", got)
+	}
+}
+
+func TestBaggagePropagatorProperties(t *testing.T) {
+	propagator := propagation.Baggage{}
+	header := http.Header{}
+	header.Set("baggage", "key1=val1;flag;prop=a%20b;hops=1,key2=val2")
+
+	ctx := propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+	m := baggage.MapFromContext(ctx)
+	want := []baggage.Property{
+		{Key: "flag"},
+		{Key: "prop", Value: "a b", HasValue: true},
+	}
+	if diff := cmp.Diff(want, m.Properties("key1")); diff != "" {
+		t.Errorf("extracted properties: -want +got %s", diff)
+	}
+	if hops, ok := m.HopLimit("key1"); !ok || hops != 1 {
+		t.Errorf("extracted hop limit %d, %t, want 1, true", hops, ok)
+	}
+	if props := m.Properties("key2"); len(props) != 0 {
+		t.Errorf("extracted properties of key2: %v", props)
+	}
+
+	// Unknown properties are injected again.
+	header = http.Header{}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+	got := header.Get("baggage")
+	for _, want := range []string{"key1=val1;flag;prop=a%20b;hops=0", "key2=val2"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Inject baggage missing %s in %s", want, got)
+		}
+	}
+}
+
+func TestBaggagePropagatorExtractLimits(t *testing.T) {
+	members := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		members = append(members, "key"+strconv.Itoa(i)+"=v")
+	}
+	tests := []struct {
+		name       string
+		propagator propagation.Baggage
+		header     string
+		wantKeys   []string
+	}{
+		{
+			name:       "default members limit",
+			propagator: propagation.Baggage{},
+			header:     strings.Join(members, ","),
+			wantKeys:   keysN(propagation.DefaultBaggageMaxMembers),
+		},
+		{
+			name:       "members limit",
+			propagator: propagation.Baggage{MaxMembers: 2},
+			header:     "key0=v,invalid,key1=v,key2=v",
+			wantKeys:   []string{"key0", "key1"},
+		},
+		{
+			name:       "bytes limit cutting a member",
+			propagator: propagation.Baggage{MaxBytes: 16},
+			header:     "key0=v,key1=v,key2=v",
+			wantKeys:   []string{"key0", "key1"},
+		},
+		{
+			name:       "bytes limit between members",
+			propagator: propagation.Baggage{MaxBytes: 13},
+			header:     "key0=v,key1=v,key2=v",
+			wantKeys:   []string{"key0", "key1"},
+		},
+		{
+			name:       "bytes limit cutting the first member",
+			propagator: propagation.Baggage{MaxBytes: 3},
+			header:     "key0=v,key1=v",
+		},
+		{
+			name:       "default bytes limit",
+			propagator: propagation.Baggage{},
+			header:     "key0=" + strings.Repeat("v", propagation.DefaultBaggageMaxBytes),
+		},
+		{
+			name:       "allowed keys",
+			propagator: propagation.Baggage{AllowedKeys: []string{"key1", "key3"}},
+			header:     "key0=v,key1=v,key2=v,key3=v",
+			wantKeys:   []string{"key1", "key3"},
+		},
+		{
+			name:       "allowed keys within the members limit",
+			propagator: propagation.Baggage{MaxMembers: 1, AllowedKeys: []string{"key1", "key3"}},
+			header:     "key0=v,key1=v,key2=v,key3=v",
+			wantKeys:   []string{"key1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("baggage", tt.header)
+			ctx := tt.propagator.Extract(context.Background(), propagation.HeaderCarrier(header))
+
+			var got []string
+			baggage.MapFromContext(ctx).Foreach(func(kv attribute.KeyValue) bool {
+				got = append(got, string(kv.Key))
+				return true
+			})
+			sort.Strings(got)
+			want := append([]string(nil), tt.wantKeys...)
+			sort.Strings(want)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("extracted keys: -want +got %s", diff)
+			}
+		})
+	}
+}
+
+func TestBaggagePropagatorInjectLimits(t *testing.T) {
+	kvs := make([]attribute.KeyValue, 0, 200)
+	for i := 0; i < 200; i++ {
+		kvs = append(kvs, attribute.String(fmt.Sprintf("key%03d", i), "v"))
+	}
+	tests := []struct {
+		name       string
+		propagator propagation.Baggage
+		kvs        []attribute.KeyValue
+		wantHeader string
+	}{
+		{
+			name:       "default members limit",
+			propagator: propagation.Baggage{},
+			kvs:        kvs,
+			wantHeader: strings.Join(membersN(propagation.DefaultBaggageMaxMembers), ","),
+		},
+		{
+			name:       "members limit",
+			propagator: propagation.Baggage{MaxMembers: 2},
+			kvs:        kvs[:3],
+			wantHeader: "key000=v,key001=v",
+		},
+		{
+			name:       "bytes limit",
+			propagator: propagation.Baggage{MaxBytes: 20},
+			kvs: []attribute.KeyValue{
+				attribute.String("key0", "v"),
+				attribute.String("key1", strings.Repeat("v", 10)),
+				attribute.String("key2", "v"),
+			},
+			wantHeader: "key0=v,key2=v",
+		},
+		{
+			name:       "invalid keys",
+			propagator: propagation.Baggage{},
+			kvs: []attribute.KeyValue{
+				attribute.String("key0", "v"),
+				attribute.String("key 1", "v"),
+				attribute.String("kéy2", "v"),
+			},
+			wantHeader: "key0=v",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := baggage.ContextWithMap(context.Background(), baggage.NewMap(baggage.MapUpdate{MultiKV: tt.kvs}))
+			header := http.Header{}
+			tt.propagator.Inject(ctx, propagation.HeaderCarrier(header))
+			if got := header.Get("baggage"); got != tt.wantHeader {
+				t.Errorf("got header %q, want %q", got, tt.wantHeader)
+			}
+		})
+	}
+}
+
+// membersN returns the n first members of TestBaggagePropagatorInjectLimits.
+func membersN(n int) []string {
+	members := make([]string, n)
+	for i := range members {
+		members[i] = fmt.Sprintf("key%03d=v", i)
+	}
+	return members
+}
+
+func keysN(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i)
+	}
+	return keys
+}
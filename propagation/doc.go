@@ -20,9 +20,18 @@ may be introduced in subsequent minor version releases as we work to track the
 evolving OpenTelemetry specification and user feedback.
 
 OpenTelemetry propagators are used to extract and inject context data from and
-into messages exchanged by applications. The propagator supported by this
-package is the W3C Trace Context encoding
-(https://www.w3.org/TR/trace-context/), and W3C Baggage
-(https://w3c.github.io/baggage/).
+into messages exchanged by applications. The propagators supported by this
+package are the W3C Trace Context encoding
+(https://www.w3.org/TR/trace-context/), W3C Baggage
+(https://w3c.github.io/baggage/), the B3 encoding of Zipkin
+(https://github.com/openzipkin/b3-propagation), the native format of
+the Jaeger clients
+(https://www.jaegertracing.io/docs/client-libraries/#propagation-format),
+the `ot-tracer-*` headers of the OpenTracing Basictracer, and the AWS X-Ray
+trace header.
+
+For transports that carry byte headers, the BinaryPropagator interface
+propagates context as a binary value. The GRPCBinary propagator implements
+the `grpc-trace-bin` format of gRPC and OpenCensus.
 */
 package propagation // import "go.opentelemetry.io/otel/propagation"
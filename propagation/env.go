@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "go.opentelemetry.io/otel/propagation"
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvPropagators is the environment variable listing the comma-separated
+// names of the TextMapPropagators to use: "tracecontext", "baggage", "b3",
+// "b3multi", "jaeger", "xray", "ottrace", or "none". It defaults to
+// "tracecontext,baggage".
+const EnvPropagators = "OTEL_PROPAGATORS"
+
+// propagatorsByName are the TextMapPropagators of the names accepted in
+// EnvPropagators.
+var propagatorsByName = map[string]TextMapPropagator{
+	"tracecontext": TraceContext{},
+	"baggage":      Baggage{},
+	"b3":           B3{InjectEncoding: B3SingleHeader},
+	"b3multi":      B3{InjectEncoding: B3MultipleHeader},
+	"jaeger":       Jaeger{},
+	"xray":         XRay{},
+	"ottrace":      OT{},
+}
+
+// FromEnv returns the composite TextMapPropagator of the propagators
+// listed by the EnvPropagators environment variable, in order. It
+// returns the composite of TraceContext and Baggage if the variable is
+// unset, and a TextMapPropagator propagating nothing if it is "none".
+//
+// Unknown names are ignored and reported by the returned error, along
+// with the composite of the known propagators. The result is typically
+// set as the global TextMapPropagator with otel.SetTextMapPropagator.
+func FromEnv() (TextMapPropagator, error) {
+	v, ok := os.LookupEnv(EnvPropagators)
+	if !ok || strings.TrimSpace(v) == "" {
+		return NewCompositeTextMapPropagator(TraceContext{}, Baggage{}), nil
+	}
+
+	var (
+		props   []TextMapPropagator
+		unknown []string
+	)
+	for _, name := range strings.Split(v, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "none" {
+			return NewCompositeTextMapPropagator(), nil
+		}
+		if p, ok := propagatorsByName[name]; ok {
+			props = append(props, p)
+		} else if name != "" {
+			unknown = append(unknown, name)
+		}
+	}
+	var err error
+	if len(unknown) > 0 {
+		err = fmt.Errorf("unsupported %s propagators: %s", EnvPropagators, strings.Join(unknown, ", "))
+	}
+	return NewCompositeTextMapPropagator(props...), err
+}
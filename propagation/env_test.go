@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func setPropagatorsEnv(t *testing.T, value string, set bool) {
+	orig, ok := os.LookupEnv(propagation.EnvPropagators)
+	if set {
+		require.NoError(t, os.Setenv(propagation.EnvPropagators, value))
+	} else {
+		require.NoError(t, os.Unsetenv(propagation.EnvPropagators))
+	}
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(propagation.EnvPropagators, orig)
+		} else {
+			_ = os.Unsetenv(propagation.EnvPropagators)
+		}
+	})
+}
+
+func TestFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		unset   bool
+		fields  []string
+		wantErr bool
+	}{
+		{
+			name:   "unset",
+			unset:  true,
+			fields: []string{"traceparent", "tracestate", "baggage"},
+		},
+		{
+			name:   "ordered",
+			value:  " B3 , jaeger",
+			fields: []string{"b3", "uber-trace-id"},
+		},
+		{
+			name:   "b3multi",
+			value:  "b3multi",
+			fields: propagation.B3{}.Fields(),
+		},
+		{
+			name:  "none",
+			value: "tracecontext,none",
+		},
+		{
+			name:    "unknown",
+			value:   "xray,unknown",
+			fields:  []string{"x-amzn-trace-id"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setPropagatorsEnv(t, tt.value, !tt.unset)
+			p, err := propagation.FromEnv()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.ElementsMatch(t, tt.fields, p.Fields())
+		})
+	}
+}
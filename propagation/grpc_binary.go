@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "go.opentelemetry.io/otel/propagation"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// GRPCBinaryHeader is the gRPC metadata key the GRPCBinary encoding
+	// is conventionally sent with.
+	GRPCBinaryHeader = "grpc-trace-bin"
+
+	grpcBinaryVersion      = 0
+	grpcBinaryTraceIDField = 0
+	grpcBinarySpanIDField  = 1
+	grpcBinaryOptionsField = 2
+
+	// grpcBinaryLen is the length of a version 0 encoding: the version,
+	// then the ID and value of each field.
+	grpcBinaryLen = 1 + 1 + len(trace.TraceID{}) + 1 + len(trace.SpanID{}) + 1 + 1
+)
+
+// GRPCBinary is a propagator that supports the binary format of the
+// `grpc-trace-bin` header used by gRPC and OpenCensus
+// (https://github.com/census-instrumentation/opencensus-specs/blob/master/encodings/BinaryEncoding.md).
+//
+// The encoding is a version byte followed by the trace ID, span ID and
+// trace options fields, each prefixed by its field ID. Only the sampled
+// flag of the trace options is propagated.
+type GRPCBinary struct{}
+
+var _ BinaryPropagator = GRPCBinary{}
+
+// Inject returns the binary encoding of the SpanContext of ctx, or nil if
+// the SpanContext is invalid.
+func (GRPCBinary) Inject(ctx context.Context) []byte {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+
+	traceID, spanID := sc.TraceID(), sc.SpanID()
+	b := make([]byte, 0, grpcBinaryLen)
+	b = append(b, grpcBinaryVersion, grpcBinaryTraceIDField)
+	b = append(b, traceID[:]...)
+	b = append(b, grpcBinarySpanIDField)
+	b = append(b, spanID[:]...)
+	return append(b, grpcBinaryOptionsField, byte(sc.TraceFlags()&trace.FlagsSampled))
+}
+
+// Extract reads the binary encoding of a SpanContext from data into a
+// returned Context.
+//
+// The returned Context will be a copy of ctx and contain the extracted
+// SpanContext as the remote SpanContext. If the data is not a valid
+// encoding, the passed ctx will be returned directly instead.
+func (GRPCBinary) Extract(ctx context.Context, data []byte) context.Context {
+	sc := extractGRPCBinary(data)
+	if !sc.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+func extractGRPCBinary(data []byte) trace.SpanContext {
+	if len(data) == 0 || data[0] != grpcBinaryVersion {
+		return trace.SpanContext{}
+	}
+	data = data[1:]
+
+	scc := trace.SpanContextConfig{Remote: true}
+	// The fields are in order and all optional. Parsing stops at an
+	// unknown field ID so later versions can append fields.
+	if len(data) > 0 && data[0] == grpcBinaryTraceIDField {
+		if len(data) < 1+len(scc.TraceID) {
+			return trace.SpanContext{}
+		}
+		copy(scc.TraceID[:], data[1:])
+		data = data[1+len(scc.TraceID):]
+	}
+	if len(data) > 0 && data[0] == grpcBinarySpanIDField {
+		if len(data) < 1+len(scc.SpanID) {
+			return trace.SpanContext{}
+		}
+		copy(scc.SpanID[:], data[1:])
+		data = data[1+len(scc.SpanID):]
+	}
+	if len(data) > 0 && data[0] == grpcBinaryOptionsField {
+		if len(data) < 2 {
+			return trace.SpanContext{}
+		}
+		scc.TraceFlags = data[1] & trace.FlagsSampled
+	}
+
+	return trace.NewSpanContext(scc)
+}
@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func grpcBinary(traceID trace.TraceID, spanID trace.SpanID, options byte) []byte {
+	b := append([]byte{0, 0}, traceID[:]...)
+	b = append(b, 1)
+	b = append(b, spanID[:]...)
+	return append(b, 2, options)
+}
+
+func TestExtractGRPCBinary(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		wantSc trace.SpanContext
+	}{
+		{
+			name: "sampled",
+			data: grpcBinary(traceID, spanID, 1),
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "not sampled",
+			data: grpcBinary(traceID, spanID, 0),
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+				Remote:  true,
+			}),
+		},
+		{
+			name: "unknown options are dropped",
+			data: grpcBinary(traceID, spanID, 0xff),
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "without options",
+			data: grpcBinary(traceID, spanID, 1)[:27],
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+				Remote:  true,
+			}),
+		},
+		{
+			name: "unknown trailing field",
+			data: append(grpcBinary(traceID, spanID, 1), 3, 0xaa),
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "empty",
+		},
+		{
+			name: "unsupported version",
+			data: append([]byte{1}, grpcBinary(traceID, spanID, 1)[1:]...),
+		},
+		{
+			name: "truncated trace ID",
+			data: grpcBinary(traceID, spanID, 1)[:10],
+		},
+		{
+			name: "truncated span ID",
+			data: grpcBinary(traceID, spanID, 1)[:22],
+		},
+		{
+			name: "truncated options",
+			data: grpcBinary(traceID, spanID, 1)[:28],
+		},
+		{
+			name: "missing span ID",
+			data: append(grpcBinary(traceID, spanID, 1)[:18], 2, 1),
+		},
+		{
+			name: "invalid trace ID",
+			data: grpcBinary(trace.TraceID{}, spanID, 1),
+		},
+	}
+
+	prop := propagation.GRPCBinary{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := prop.Extract(context.Background(), tt.data)
+			gotSc := trace.SpanContextFromContext(ctx)
+			if diff := cmp.Diff(gotSc, tt.wantSc, cmp.AllowUnexported(trace.TraceState{})); diff != "" {
+				t.Errorf("Extract Tracecontext: %s: -got +want %s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestInjectGRPCBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		scc  trace.SpanContextConfig
+		want []byte
+	}{
+		{
+			name: "sampled",
+			scc: trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+			},
+			want: grpcBinary(traceID, spanID, 1),
+		},
+		{
+			name: "debug is not propagated",
+			scc: trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsDebug,
+			},
+			want: grpcBinary(traceID, spanID, 0),
+		},
+		{
+			name: "invalid",
+		},
+	}
+
+	prop := propagation.GRPCBinary{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(tt.scc))
+			got := prop.Inject(ctx)
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Errorf("Inject: -got +want %s", diff)
+			}
+		})
+	}
+}
+
+func TestGRPCBinaryRoundTrip(t *testing.T) {
+	prop := propagation.GRPCBinary{}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	data := prop.Inject(trace.ContextWithSpanContext(context.Background(), sc))
+	got := trace.SpanContextFromContext(prop.Extract(context.Background(), data))
+	if !got.IsRemote() || !got.Equal(sc.WithRemote(true)) {
+		t.Errorf("round trip: got %v, want %v", got, sc)
+	}
+}
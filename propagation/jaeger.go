@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "go.opentelemetry.io/otel/propagation"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	jaegerHeader        = "uber-trace-id"
+	jaegerBaggagePrefix = "uberctx-"
+
+	jaegerFlagsSampled = 0x01
+	jaegerFlagsDebug   = 0x02
+
+	jaegerTraceIDWidth = 32
+	jaegerSpanIDWidth  = 16
+)
+
+var (
+	errEmptyJaegerHeader         = errors.New("empty Jaeger header")
+	errInvalidJaegerTraceID      = errors.New("invalid Jaeger trace ID")
+	errInvalidJaegerSpanID       = errors.New("invalid Jaeger span ID")
+	errInvalidJaegerParentSpanID = errors.New("invalid Jaeger parent span ID")
+	errInvalidJaegerFlags        = errors.New("invalid Jaeger flags")
+)
+
+// Jaeger is a propagator that supports the native propagation format of
+// the Jaeger clients (https://www.jaegertracing.io/docs/client-libraries/#propagation-format):
+// the `uber-trace-id` header, `{trace-id}:{span-id}:{parent-span-id}:{flags}`,
+// and the baggage values in the headers prefixed with `uberctx-`.
+//
+// The trace and span IDs are extracted with their leading zeros omitted,
+// as the Jaeger clients encode them, and 64-bit trace IDs are padded to
+// 128 bits. The parent span ID is not propagated, it is injected as 0.
+//
+// The extracted baggage values are added to the baggage of the context.
+// Only the baggage values that are not hop-limited are injected, see
+// baggage.ContextWithHopLimitedValues, the format cannot carry the limit.
+type Jaeger struct{}
+
+var _ TextMapPropagator = Jaeger{}
+
+// Inject sets the Jaeger trace context and baggage from ctx into the
+// carrier.
+func (jaeger Jaeger) Inject(ctx context.Context, carrier TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		var flags int
+		if sc.IsSampled() {
+			flags |= jaegerFlagsSampled
+		}
+		if sc.IsDebug() {
+			flags |= jaegerFlagsDebug | jaegerFlagsSampled
+		}
+		carrier.Set(jaegerHeader, fmt.Sprintf("%s:%s:0:%x", sc.TraceID(), sc.SpanID(), flags))
+	}
+
+	injectPrefixedBaggage(ctx, carrier, jaegerBaggagePrefix)
+}
+
+// Extract returns a copy of ctx with the Jaeger trace context and baggage
+// from the carrier added. An invalid trace context is not extracted, the
+// baggage is extracted regardless.
+func (jaeger Jaeger) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	if sc, err := extractJaeger(carrier.Get(jaegerHeader)); err == nil && sc.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, sc)
+	}
+
+	return extractPrefixedBaggage(ctx, carrier, jaegerBaggagePrefix)
+}
+
+// Fields returns the keys who's values are set with Inject. The keys of
+// the baggage values, prefixed with `uberctx-`, are not known in advance.
+func (jaeger Jaeger) Fields() []string {
+	return []string{jaegerHeader}
+}
+
+// extractJaeger decodes the SpanContext of the uber-trace-id header h.
+func extractJaeger(h string) (trace.SpanContext, error) {
+	if h == "" {
+		return trace.SpanContext{}, errEmptyJaegerHeader
+	}
+	// Some clients URL encode the header.
+	if strings.Contains(h, "%") {
+		var err error
+		if h, err = url.QueryUnescape(h); err != nil {
+			return trace.SpanContext{}, err
+		}
+	}
+
+	parts := strings.Split(h, ":")
+	if len(parts) != 4 {
+		return trace.SpanContext{}, fmt.Errorf("invalid Jaeger header: %q", h)
+	}
+	scc := trace.SpanContextConfig{Remote: true}
+
+	traceID, err := jaegerPad(parts[0], jaegerTraceIDWidth)
+	if err != nil {
+		return trace.SpanContext{}, errInvalidJaegerTraceID
+	}
+	if scc.TraceID, err = trace.TraceIDFromHex(traceID); err != nil {
+		return trace.SpanContext{}, errInvalidJaegerTraceID
+	}
+
+	spanID, err := jaegerPad(parts[1], jaegerSpanIDWidth)
+	if err != nil {
+		return trace.SpanContext{}, errInvalidJaegerSpanID
+	}
+	if scc.SpanID, err = trace.SpanIDFromHex(spanID); err != nil {
+		return trace.SpanContext{}, errInvalidJaegerSpanID
+	}
+
+	// The parent span ID is deprecated, it is validated but not used.
+	if _, err := strconv.ParseUint(parts[2], 16, 64); err != nil {
+		return trace.SpanContext{}, errInvalidJaegerParentSpanID
+	}
+
+	flags, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		return trace.SpanContext{}, errInvalidJaegerFlags
+	}
+	if flags&jaegerFlagsSampled != 0 {
+		scc.TraceFlags = trace.FlagsSampled
+	}
+	if flags&jaegerFlagsDebug != 0 {
+		// Debug implies an accept sampling decision.
+		scc.TraceFlags = trace.FlagsDebug | trace.FlagsSampled
+	}
+
+	return trace.NewSpanContext(scc), nil
+}
+
+// jaegerPad returns the lowercase hexadecimal id padded to width with
+// leading zeros.
+func jaegerPad(id string, width int) (string, error) {
+	if id == "" || len(id) > width {
+		return "", fmt.Errorf("invalid ID: %q", id)
+	}
+	return strings.Repeat("0", width-len(id)) + strings.ToLower(id), nil
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestExtractJaeger(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		wantSc trace.SpanContext
+	}{
+		{
+			name:   "sampled",
+			header: traceIDStr + ":" + spanIDStr + ":0:1",
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name:   "not sampled, with parent",
+			header: traceIDStr + ":" + spanIDStr + ":" + spanIDStr + ":0",
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+				Remote:  true,
+			}),
+		},
+		{
+			name:   "debug",
+			header: traceIDStr + ":" + spanIDStr + ":0:2",
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsDebug | trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name:   "leading zeros omitted",
+			header: "F067AA0BA902B7:f067aa0ba902b7:0:1",
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    mustTraceIDFromHex("000000000000000000f067aa0ba902b7"),
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name:   "URL encoded",
+			header: traceIDStr + "%3A" + spanIDStr + "%3A0%3A1",
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{name: "empty", header: ""},
+		{name: "missing fields", header: traceIDStr + ":" + spanIDStr + ":1"},
+		{name: "trace ID too long", header: "0" + traceIDStr + ":" + spanIDStr + ":0:1"},
+		{name: "invalid span ID", header: traceIDStr + ":qw:0:1"},
+		{name: "zero span ID", header: traceIDStr + ":0:0:1"},
+		{name: "invalid parent", header: traceIDStr + ":" + spanIDStr + ":qw:1"},
+		{name: "invalid flags", header: traceIDStr + ":" + spanIDStr + ":0:100"},
+	}
+
+	prop := propagation.Jaeger{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("uber-trace-id", tt.header)
+			ctx := prop.Extract(context.Background(), propagation.HeaderCarrier(header))
+			gotSc := trace.SpanContextFromContext(ctx)
+			if diff := cmp.Diff(gotSc, tt.wantSc, cmp.AllowUnexported(trace.TraceState{})); diff != "" {
+				t.Errorf("Extract Jaeger: %s: -got +want %s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestInjectJaeger(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags byte
+		want  string
+	}{
+		{"sampled", trace.FlagsSampled, traceIDStr + ":" + spanIDStr + ":0:1"},
+		{"not sampled", 0, traceIDStr + ":" + spanIDStr + ":0:0"},
+		{"deferred", trace.FlagsDeferred, traceIDStr + ":" + spanIDStr + ":0:0"},
+		{"debug", trace.FlagsDebug, traceIDStr + ":" + spanIDStr + ":0:3"},
+	}
+	prop := propagation.Jaeger{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: tt.flags,
+			})
+			header := http.Header{}
+			prop.Inject(trace.ContextWithRemoteSpanContext(context.Background(), sc), propagation.HeaderCarrier(header))
+			if got := header.Get("uber-trace-id"); got != tt.want {
+				t.Errorf("Inject Jaeger: %s: got %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	header := http.Header{}
+	prop.Inject(context.Background(), propagation.HeaderCarrier(header))
+	if len(header) != 0 {
+		t.Errorf("Inject Jaeger: invalid span context injected %v", header)
+	}
+}
+
+func TestJaegerBaggage(t *testing.T) {
+	prop := propagation.Jaeger{}
+	ctx := baggage.ContextWithMap(context.Background(), baggage.NewMap(baggage.MapUpdate{
+		MultiKV: []attribute.KeyValue{attribute.String("user", "a b,c"), attribute.Int("n", 1)},
+	}).Apply(baggage.MapUpdate{
+		SingleKV:   attribute.String("limited", "l"),
+		HopLimited: true,
+		HopLimit:   1,
+	}))
+
+	header := http.Header{}
+	prop.Inject(ctx, propagation.HeaderCarrier(header))
+	want := http.Header{
+		"Uberctx-User": []string{"a+b%2Cc"},
+		"Uberctx-N":    []string{"1"},
+	}
+	if diff := cmp.Diff(header, want); diff != "" {
+		t.Errorf("Inject Jaeger baggage: -got +want %s", diff)
+	}
+
+	// Extracted values are added to the baggage of the context.
+	ctx = baggage.ContextWithMap(context.Background(), baggage.NewMap(baggage.MapUpdate{
+		SingleKV: attribute.String("existing", "e"),
+	}))
+	ctx = prop.Extract(ctx, propagation.HeaderCarrier(header))
+	m := baggage.MapFromContext(ctx)
+	for k, v := range map[attribute.Key]string{"user": "a b,c", "n": "1", "existing": "e"} {
+		if got, _ := m.Value(k); got.AsString() != v {
+			t.Errorf("Extract Jaeger baggage: %s = %q, want %q", k, got.AsString(), v)
+		}
+	}
+	if m.Len() != 3 {
+		t.Errorf("Extract Jaeger baggage: %d values, want 3", m.Len())
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		t.Errorf("Extract Jaeger baggage: extracted span context %v", sc)
+	}
+}
+
+func TestJaegerRoundTrip(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	prop := propagation.Jaeger{}
+	header := http.Header{}
+	prop.Inject(trace.ContextWithRemoteSpanContext(context.Background(), sc), propagation.HeaderCarrier(header))
+	got := trace.SpanContextFromContext(prop.Extract(context.Background(), propagation.HeaderCarrier(header)))
+	if !got.Equal(sc) {
+		t.Errorf("Jaeger round trip %v, want %v", got, sc)
+	}
+}
+
+func TestJaegerPropagatorFields(t *testing.T) {
+	if diff := cmp.Diff(propagation.Jaeger{}.Fields(), []string{"uber-trace-id"}); diff != "" {
+		t.Errorf("Fields: -got +want %s", diff)
+	}
+}
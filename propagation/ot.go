@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "go.opentelemetry.io/otel/propagation"
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	otTraceIDHeader     = "ot-tracer-traceid"
+	otSpanIDHeader      = "ot-tracer-spanid"
+	otSampledHeader     = "ot-tracer-sampled"
+	otBaggagePrefix     = "ot-baggage-"
+	otTraceID64BitWidth = 64 / 4
+	otTraceIDPadding    = "0000000000000000"
+)
+
+var (
+	errInvalidOTTraceID = errors.New("invalid OpenTracing trace ID")
+	errInvalidOTSpanID  = errors.New("invalid OpenTracing span ID")
+	errInvalidOTSampled = errors.New("invalid OpenTracing sampled value")
+)
+
+// OT is a propagator that supports the headers of the OpenTracing
+// Basictracer and LightStep tracers: `ot-tracer-traceid`,
+// `ot-tracer-spanid` and `ot-tracer-sampled`, and the baggage values in the
+// headers prefixed with `ot-baggage-`.
+//
+// The trace IDs are injected truncated to their lower 64 bits, the width
+// the legacy tracers support, 64-bit trace IDs are padded to 128 bits when
+// extracted. The sampled value is injected as "true" or "false", "1" and
+// "0" are also extracted.
+//
+// The extracted baggage values are added to the baggage of the context.
+// Only the baggage values that are not hop-limited are injected, see
+// baggage.ContextWithHopLimitedValues, the format cannot carry the limit.
+type OT struct{}
+
+var _ TextMapPropagator = OT{}
+
+// Inject sets the OpenTracing trace context and baggage from ctx into the
+// carrier.
+func (ot OT) Inject(ctx context.Context, carrier TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		traceID := sc.TraceID().String()
+		carrier.Set(otTraceIDHeader, traceID[len(traceID)-otTraceID64BitWidth:])
+		carrier.Set(otSpanIDHeader, sc.SpanID().String())
+		if sc.IsSampled() {
+			carrier.Set(otSampledHeader, "true")
+		} else {
+			carrier.Set(otSampledHeader, "false")
+		}
+	}
+
+	injectPrefixedBaggage(ctx, carrier, otBaggagePrefix)
+}
+
+// Extract returns a copy of ctx with the OpenTracing trace context and
+// baggage from the carrier added. An invalid trace context is not
+// extracted, the baggage is extracted regardless.
+func (ot OT) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	sc, err := extractOT(
+		carrier.Get(otTraceIDHeader),
+		carrier.Get(otSpanIDHeader),
+		carrier.Get(otSampledHeader),
+	)
+	if err == nil && sc.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, sc)
+	}
+
+	return extractPrefixedBaggage(ctx, carrier, otBaggagePrefix)
+}
+
+// Fields returns the keys who's values are set with Inject. The keys of
+// the baggage values, prefixed with `ot-baggage-`, are not known in
+// advance.
+func (ot OT) Fields() []string {
+	return []string{otTraceIDHeader, otSpanIDHeader, otSampledHeader}
+}
+
+// extractOT decodes the SpanContext of the OpenTracing header values.
+func extractOT(traceID, spanID, sampled string) (trace.SpanContext, error) {
+	scc := trace.SpanContextConfig{Remote: true}
+
+	traceID = strings.ToLower(traceID)
+	if len(traceID) == otTraceID64BitWidth {
+		traceID = otTraceIDPadding + traceID
+	}
+	var err error
+	if scc.TraceID, err = trace.TraceIDFromHex(traceID); err != nil {
+		return trace.SpanContext{}, errInvalidOTTraceID
+	}
+	if scc.SpanID, err = trace.SpanIDFromHex(strings.ToLower(spanID)); err != nil {
+		return trace.SpanContext{}, errInvalidOTSpanID
+	}
+
+	switch strings.ToLower(sampled) {
+	case "true", "1":
+		scc.TraceFlags = trace.FlagsSampled
+	case "false", "0":
+	case "":
+		scc.TraceFlags = trace.FlagsDeferred
+	default:
+		return trace.SpanContext{}, errInvalidOTSampled
+	}
+
+	return trace.NewSpanContext(scc), nil
+}
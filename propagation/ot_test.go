@@ -0,0 +1,182 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestExtractOT(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		wantSc  trace.SpanContext
+	}{
+		{
+			name: "128-bit trace ID, sampled",
+			headers: map[string]string{
+				"ot-tracer-traceid": traceIDStr,
+				"ot-tracer-spanid":  spanIDStr,
+				"ot-tracer-sampled": "true",
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name: "64-bit trace ID, not sampled",
+			headers: map[string]string{
+				"ot-tracer-traceid": "A3CE929D0E0E4736",
+				"ot-tracer-spanid":  spanIDStr,
+				"ot-tracer-sampled": "0",
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: mustTraceIDFromHex("0000000000000000a3ce929d0e0e4736"),
+				SpanID:  spanID,
+				Remote:  true,
+			}),
+		},
+		{
+			name: "no sampled value",
+			headers: map[string]string{
+				"ot-tracer-traceid": traceIDStr,
+				"ot-tracer-spanid":  spanIDStr,
+			},
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsDeferred,
+				Remote:     true,
+			}),
+		},
+		{
+			name:    "no headers",
+			headers: map[string]string{},
+		},
+		{
+			name: "missing span ID",
+			headers: map[string]string{
+				"ot-tracer-traceid": traceIDStr,
+				"ot-tracer-sampled": "true",
+			},
+		},
+		{
+			name: "invalid trace ID",
+			headers: map[string]string{
+				"ot-tracer-traceid": "qw" + traceIDStr[2:],
+				"ot-tracer-spanid":  spanIDStr,
+			},
+		},
+		{
+			name: "invalid sampled value",
+			headers: map[string]string{
+				"ot-tracer-traceid": traceIDStr,
+				"ot-tracer-spanid":  spanIDStr,
+				"ot-tracer-sampled": "yes",
+			},
+		},
+	}
+
+	prop := propagation.OT{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.headers {
+				header.Set(k, v)
+			}
+			ctx := prop.Extract(context.Background(), propagation.HeaderCarrier(header))
+			gotSc := trace.SpanContextFromContext(ctx)
+			if diff := cmp.Diff(gotSc, tt.wantSc, cmp.AllowUnexported(trace.TraceState{})); diff != "" {
+				t.Errorf("Extract OT: %s: -got +want %s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestInjectOT(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithRemoteSpanContext(context.Background(), sc)
+	ctx = baggage.ContextWithMap(ctx, baggage.NewMap(baggage.MapUpdate{
+		SingleKV: attribute.String("user", "a b"),
+	}).Apply(baggage.MapUpdate{
+		SingleKV:   attribute.String("secret", "s"),
+		HopLimited: true,
+	}))
+
+	header := http.Header{}
+	propagation.OT{}.Inject(ctx, propagation.HeaderCarrier(header))
+	want := http.Header{
+		"Ot-Tracer-Traceid": []string{"a3ce929d0e0e4736"},
+		"Ot-Tracer-Spanid":  []string{spanIDStr},
+		"Ot-Tracer-Sampled": []string{"true"},
+		"Ot-Baggage-User":   []string{"a+b"},
+	}
+	if diff := cmp.Diff(header, want); diff != "" {
+		t.Errorf("Inject OT: -got +want %s", diff)
+	}
+
+	header = http.Header{}
+	propagation.OT{}.Inject(trace.ContextWithRemoteSpanContext(context.Background(), sc.WithTraceFlags(0)), propagation.HeaderCarrier(header))
+	if got := header.Get("ot-tracer-sampled"); got != "false" {
+		t.Errorf("Inject OT: sampled %q, want %q", got, "false")
+	}
+
+	header = http.Header{}
+	propagation.OT{}.Inject(context.Background(), propagation.HeaderCarrier(header))
+	if len(header) != 0 {
+		t.Errorf("Inject OT: invalid span context injected %v", header)
+	}
+}
+
+func TestOTBaggage(t *testing.T) {
+	header := http.Header{}
+	header.Set("ot-baggage-user", "a+b")
+	header.Set("ot-baggage-", "ignored")
+	ctx := baggage.ContextWithMap(context.Background(), baggage.NewMap(baggage.MapUpdate{
+		SingleKV: attribute.String("existing", "e"),
+	}))
+	ctx = propagation.OT{}.Extract(ctx, propagation.HeaderCarrier(header))
+
+	m := baggage.MapFromContext(ctx)
+	if v, _ := m.Value("user"); v.AsString() != "a b" {
+		t.Errorf("Extract OT baggage: user = %q, want %q", v.AsString(), "a b")
+	}
+	if m.Len() != 2 {
+		t.Errorf("Extract OT baggage: %d values, want 2", m.Len())
+	}
+}
+
+func TestOTPropagatorFields(t *testing.T) {
+	want := []string{"ot-tracer-traceid", "ot-tracer-spanid", "ot-tracer-sampled"}
+	if diff := cmp.Diff(propagation.OT{}.Fields(), want); diff != "" {
+		t.Errorf("Fields: -got +want %s", diff)
+	}
+}
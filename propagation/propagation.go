@@ -17,6 +17,7 @@ package propagation // import "go.opentelemetry.io/otel/propagation"
 import (
 	"context"
 	"net/http"
+	"strings"
 )
 
 // TextMapCarrier is the storage medium used by a TextMapPropagator.
@@ -51,6 +52,34 @@ func (hc HeaderCarrier) Keys() []string {
 	return keys
 }
 
+// MetadataCarrier adapts the metadata of gRPC, google.golang.org/grpc/metadata.MD,
+// to satisfy the TextMapCarrier interface without depending on gRPC: the MD
+// of a request is converted with MetadataCarrier(md).
+//
+// The keys are case-insensitive, they are lowercased like the ones of the
+// MD. The multiple values of a key are retrieved as a comma separated list.
+type MetadataCarrier map[string][]string
+
+// Get returns the values associated with the passed key, separated by
+// commas.
+func (mc MetadataCarrier) Get(key string) string {
+	return strings.Join(mc[strings.ToLower(key)], ",")
+}
+
+// Set stores the key-value pair, replacing the values of the key.
+func (mc MetadataCarrier) Set(key string, value string) {
+	mc[strings.ToLower(key)] = []string{value}
+}
+
+// Keys lists the keys stored in this carrier.
+func (mc MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(mc))
+	for k := range mc {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
 // TextMapPropagator propagates cross-cutting concerns as key-value text
 // pairs within a carrier that travels in-band across process boundaries.
 type TextMapPropagator interface {
@@ -79,16 +108,16 @@ func (p compositeTextMapPropagator) Extract(ctx context.Context, carrier TextMap
 
 func (p compositeTextMapPropagator) Fields() []string {
 	unique := make(map[string]struct{})
+	var fields []string
 	for _, i := range p {
 		for _, k := range i.Fields() {
+			if _, ok := unique[k]; ok {
+				continue
+			}
 			unique[k] = struct{}{}
+			fields = append(fields, k)
 		}
 	}
-
-	fields := make([]string, 0, len(unique))
-	for k := range unique {
-		fields = append(fields, k)
-	}
 	return fields
 }
 
@@ -97,9 +126,37 @@ func (p compositeTextMapPropagator) Fields() []string {
 // concerns to be propagates in a unified manner.
 //
 // The returned TextMapPropagator will inject and extract cross-cutting
-// concerns in the order the TextMapPropagators were provided. Additionally,
-// the Fields method will return a de-duplicated slice of the keys that are
-// set with the Inject method.
+// concerns in the order the TextMapPropagators were provided: when several
+// of them set the same key of a carrier, the value of the last one is
+// injected, and the context extracted by one is passed to the next one.
+// Additionally, the Fields method will return a de-duplicated slice of the
+// keys that are set with the Inject method, in the order of the
+// TextMapPropagators and of their Fields.
 func NewCompositeTextMapPropagator(p ...TextMapPropagator) TextMapPropagator {
 	return compositeTextMapPropagator(p)
 }
+
+// ComposedPropagators returns a copy of the TextMapPropagators composed by
+// p, in their order, if p was returned by NewCompositeTextMapPropagator.
+// Otherwise it returns p itself, or nil if p is nil.
+func ComposedPropagators(p TextMapPropagator) []TextMapPropagator {
+	switch v := p.(type) {
+	case nil:
+		return nil
+	case compositeTextMapPropagator:
+		return append([]TextMapPropagator(nil), v...)
+	default:
+		return []TextMapPropagator{p}
+	}
+}
+
+// BinaryPropagator propagates cross-cutting concerns as a binary value that
+// travels in-band across process boundaries, for transports that carry byte
+// headers instead of text ones.
+type BinaryPropagator interface {
+	// Inject returns the binary encoding of the cross-cutting concerns of
+	// the Context, or nil if there is nothing to propagate.
+	Inject(ctx context.Context) []byte
+	// Extract reads cross-cutting concerns from data into a Context.
+	Extract(ctx context.Context, data []byte) context.Context
+}
@@ -16,6 +16,7 @@ package propagation_test
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"testing"
 
@@ -77,6 +78,69 @@ func TestCompositeTextMapPropagatorFields(t *testing.T) {
 	}
 }
 
+func TestCompositeTextMapPropagatorFieldsOrder(t *testing.T) {
+	p := propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.B3{InjectEncoding: propagation.B3SingleHeader},
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	)
+	want := "traceparent,tracestate,b3,baggage"
+	for i := 0; i < 10; i++ {
+		if got := strings.Join(p.Fields(), ","); got != want {
+			t.Fatalf("fields from composite: %s, want %s", got, want)
+		}
+	}
+}
+
+func TestCompositeTextMapPropagatorInjectConflict(t *testing.T) {
+	c := propagation.MetadataCarrier{}
+	propagation.NewCompositeTextMapPropagator(
+		valuePropagator{"first"},
+		valuePropagator{"second"},
+	).Inject(context.Background(), c)
+	if got := c.Get("key"); got != "second" {
+		t.Errorf("injected %q, want the value of the last propagator", got)
+	}
+}
+
+// valuePropagator injects its value in the "key" key.
+type valuePropagator struct {
+	value string
+}
+
+func (p valuePropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	carrier.Set("key", p.value)
+}
+
+func (p valuePropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return ctx
+}
+
+func (p valuePropagator) Fields() []string { return []string{"key"} }
+
+func TestComposedPropagators(t *testing.T) {
+	a, b := propagator{"a"}, propagator{"b"}
+	composite := propagation.NewCompositeTextMapPropagator(a, b)
+
+	got := propagation.ComposedPropagators(composite)
+	if len(got) != 2 || got[0] != a || got[1] != b {
+		t.Errorf("composed propagators: %v", got)
+	}
+	// The returned slice is a copy.
+	got[0] = b
+	if again := propagation.ComposedPropagators(composite); again[0] != a {
+		t.Errorf("composed propagators modified: %v", again)
+	}
+
+	if got := propagation.ComposedPropagators(a); len(got) != 1 || got[0] != a {
+		t.Errorf("composed propagators of a single propagator: %v", got)
+	}
+	if got := propagation.ComposedPropagators(nil); got != nil {
+		t.Errorf("composed propagators of nil: %v", got)
+	}
+}
+
 func TestCompositeTextMapPropagatorInject(t *testing.T) {
 	a, b := propagator{"a"}, propagator{"b"}
 
@@ -102,3 +166,32 @@ func TestCompositeTextMapPropagatorExtract(t *testing.T) {
 		t.Errorf("invalid extract order: %s", got)
 	}
 }
+
+func TestMetadataCarrier(t *testing.T) {
+	md := map[string][]string{
+		"tracestate": {"a=1", "b=2"},
+	}
+	c := propagation.MetadataCarrier(md)
+
+	if got := c.Get("TraceState"); got != "a=1,b=2" {
+		t.Errorf("Get() = %q, want %q", got, "a=1,b=2")
+	}
+	if got := c.Get("missing"); got != "" {
+		t.Errorf("Get() of a missing key = %q", got)
+	}
+
+	c.Set("TraceParent", "v1")
+	c.Set("tracestate", "c=3")
+	if got := md["traceparent"]; len(got) != 1 || got[0] != "v1" {
+		t.Errorf("Set() stored %v, want [v1]", got)
+	}
+	if got := md["tracestate"]; len(got) != 1 || got[0] != "c=3" {
+		t.Errorf("Set() stored %v, want [c=3]", got)
+	}
+
+	keys := c.Keys()
+	sort.Strings(keys)
+	if got := strings.Join(keys, ","); got != "traceparent,tracestate" {
+		t.Errorf("Keys() = %s", got)
+	}
+}
@@ -30,6 +30,14 @@ const (
 	maxVersion        = 254
 	traceparentHeader = "traceparent"
 	tracestateHeader  = "tracestate"
+
+	// traceparentRandomFlag is the random trace ID flag of a traceparent
+	// header, defined by W3C Trace Context Level 2. It is
+	// trace.FlagsRandom in a SpanContext.
+	traceparentRandomFlag = byte(0x02)
+	// traceparentFlags are the flags of a traceparent header: sampled and
+	// random trace ID.
+	traceparentFlags = trace.FlagsSampled | traceparentRandomFlag
 )
 
 // TraceContext is a propagator that supports the W3C Trace Context format
@@ -58,10 +66,30 @@ func (tc TraceContext) Inject(ctx context.Context, carrier TextMapCarrier) {
 		supportedVersion,
 		sc.TraceID(),
 		sc.SpanID(),
-		sc.TraceFlags()&trace.FlagsSampled)
+		toTraceparentFlags(sc.TraceFlags()))
 	carrier.Set(traceparentHeader, h)
 }
 
+// toTraceparentFlags returns the traceparent header flags of the trace
+// flags of a SpanContext.
+func toTraceparentFlags(flags byte) byte {
+	opts := flags & trace.FlagsSampled
+	if flags&trace.FlagsRandom != 0 {
+		opts |= traceparentRandomFlag
+	}
+	return opts
+}
+
+// fromTraceparentFlags returns the trace flags of a SpanContext of the
+// traceparent header flags opts, ignoring unknown flags.
+func fromTraceparentFlags(opts byte) byte {
+	flags := opts & trace.FlagsSampled
+	if opts&traceparentRandomFlag != 0 {
+		flags |= trace.FlagsRandom
+	}
+	return flags
+}
+
 // Extract reads tracecontext from the carrier into a returned Context.
 //
 // The returned Context will be a copy of ctx and contain the extracted
@@ -130,11 +158,12 @@ func (tc TraceContext) extract(carrier TextMapCarrier) trace.SpanContext {
 		return trace.SpanContext{}
 	}
 	opts, err := hex.DecodeString(matches[4])
-	if err != nil || len(opts) < 1 || (version == 0 && opts[0] > 2) {
+	if err != nil || len(opts) < 1 || (version == 0 && opts[0]&^traceparentFlags != 0) {
 		return trace.SpanContext{}
 	}
-	// Clear all flags other than the trace-context supported sampling bit.
-	scc.TraceFlags = opts[0] & trace.FlagsSampled
+	// Clear all flags other than the trace-context supported sampled and
+	// random bits.
+	scc.TraceFlags = fromTraceparentFlags(opts[0])
 
 	scc.TraceState = parseTraceState(carrier.Get(tracestateHeader))
 	scc.Remote = true
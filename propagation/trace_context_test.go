@@ -53,6 +53,26 @@ func TestExtractValidTraceContextFromHTTPReq(t *testing.T) {
 				Remote:     true,
 			}),
 		},
+		{
+			name:   "valid w3cHeader and random",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-02",
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsRandom,
+				Remote:     true,
+			}),
+		},
+		{
+			name:   "valid w3cHeader, sampled and random",
+			header: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-03",
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled | trace.FlagsRandom,
+				Remote:     true,
+			}),
+		},
 		{
 			name:   "future version",
 			header: "02-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
@@ -249,7 +269,25 @@ func TestInjectTraceContextToHTTPReq(t *testing.T) {
 				SpanID:     spanID,
 				TraceFlags: 0xff,
 			}),
-			wantHeader: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000004-01",
+			wantHeader: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000004-03",
+		},
+		{
+			name: "valid spancontext, random",
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsRandom,
+			}),
+			wantHeader: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000005-02",
+		},
+		{
+			name: "valid spancontext, deferred is not propagated",
+			sc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled | trace.FlagsDeferred,
+			}),
+			wantHeader: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000006-01",
 		},
 		{
 			name:       "invalid spancontext",
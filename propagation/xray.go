@@ -0,0 +1,154 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation // import "go.opentelemetry.io/otel/propagation"
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	xrayHeader = "x-amzn-trace-id"
+
+	xrayRootKey    = "Root"
+	xrayParentKey  = "Parent"
+	xraySampledKey = "Sampled"
+
+	xrayTraceIDVersion        = "1"
+	xrayTraceIDEpochWidth     = 8
+	xrayTraceIDUniqueWidth    = 24
+	xrayTraceIDDelimiter      = "-"
+	xrayFieldDelimiter        = ";"
+	xrayKeyValueDelimiter     = "="
+	xraySampled               = "1"
+	xrayNotSampled            = "0"
+	xrayRequestSampleDecision = "?"
+)
+
+var (
+	errInvalidXRayTraceID = errors.New("invalid X-Ray trace ID")
+	errInvalidXRaySpanID  = errors.New("invalid X-Ray parent ID")
+	errInvalidXRaySampled = errors.New("invalid X-Ray sampling decision")
+)
+
+// XRay is a propagator that supports the AWS X-Ray trace header,
+// `X-Amzn-Trace-Id`
+// (https://docs.aws.amazon.com/xray/latest/devguide/xray-concepts.html#xray-concepts-tracingheader),
+// e.g. `Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1`.
+//
+// The X-Ray trace IDs are made of a version, the epoch of their creation
+// and a unique part, the 32 hexadecimal digits of the trace ID of a
+// SpanContext are the epoch and unique part. The deferred sampling
+// decision is propagated as `Sampled=?`. The headers without a Parent
+// field, e.g. the ones added by Application Load Balancers, are not
+// extracted as they have no parent span. The other fields of the header
+// are not propagated.
+type XRay struct{}
+
+var _ TextMapPropagator = XRay{}
+
+// Inject sets the X-Ray trace header from ctx into the carrier.
+func (xray XRay) Inject(ctx context.Context, carrier TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+
+	traceID := sc.TraceID().String()
+	sampled := xrayNotSampled
+	switch {
+	case sc.IsSampled():
+		sampled = xraySampled
+	case sc.IsDeferred():
+		sampled = xrayRequestSampleDecision
+	}
+	carrier.Set(xrayHeader, strings.Join([]string{
+		xrayRootKey + xrayKeyValueDelimiter + xrayTraceIDVersion + xrayTraceIDDelimiter +
+			traceID[:xrayTraceIDEpochWidth] + xrayTraceIDDelimiter + traceID[xrayTraceIDEpochWidth:],
+		xrayParentKey + xrayKeyValueDelimiter + sc.SpanID().String(),
+		xraySampledKey + xrayKeyValueDelimiter + sampled,
+	}, xrayFieldDelimiter))
+}
+
+// Extract returns a copy of ctx with the trace context of the X-Ray trace
+// header of the carrier added. An invalid trace context is not extracted.
+func (xray XRay) Extract(ctx context.Context, carrier TextMapCarrier) context.Context {
+	sc, err := extractXRay(carrier.Get(xrayHeader))
+	if err != nil || !sc.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// Fields returns the keys who's values are set with Inject.
+func (xray XRay) Fields() []string {
+	return []string{xrayHeader}
+}
+
+// extractXRay decodes the SpanContext of the X-Ray trace header h.
+func extractXRay(h string) (trace.SpanContext, error) {
+	scc := trace.SpanContextConfig{
+		TraceFlags: trace.FlagsDeferred,
+		Remote:     true,
+	}
+	var err error
+	for _, field := range strings.Split(h, xrayFieldDelimiter) {
+		kv := strings.SplitN(strings.TrimSpace(field), xrayKeyValueDelimiter, 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		switch {
+		case strings.EqualFold(key, xrayRootKey):
+			if scc.TraceID, err = parseXRayTraceID(value); err != nil {
+				return trace.SpanContext{}, err
+			}
+		case strings.EqualFold(key, xrayParentKey):
+			if scc.SpanID, err = trace.SpanIDFromHex(strings.ToLower(value)); err != nil {
+				return trace.SpanContext{}, errInvalidXRaySpanID
+			}
+		case strings.EqualFold(key, xraySampledKey):
+			switch value {
+			case xraySampled:
+				scc.TraceFlags = trace.FlagsSampled
+			case xrayNotSampled:
+				scc.TraceFlags = 0
+			case xrayRequestSampleDecision:
+				scc.TraceFlags = trace.FlagsDeferred
+			default:
+				return trace.SpanContext{}, errInvalidXRaySampled
+			}
+		}
+	}
+	return trace.NewSpanContext(scc), nil
+}
+
+// parseXRayTraceID decodes the X-Ray trace ID id,
+// `{version}-{epoch}-{unique}`.
+func parseXRayTraceID(id string) (trace.TraceID, error) {
+	parts := strings.Split(id, xrayTraceIDDelimiter)
+	if len(parts) != 3 || parts[0] != xrayTraceIDVersion ||
+		len(parts[1]) != xrayTraceIDEpochWidth || len(parts[2]) != xrayTraceIDUniqueWidth {
+		return trace.TraceID{}, errInvalidXRayTraceID
+	}
+	traceID, err := trace.TraceIDFromHex(strings.ToLower(parts[1] + parts[2]))
+	if err != nil {
+		return trace.TraceID{}, errInvalidXRayTraceID
+	}
+	return traceID, nil
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package propagation_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const xrayRoot = "Root=1-4bf92f35-77b34da6a3ce929d0e0e4736"
+
+func TestExtractXRay(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		wantSc trace.SpanContext
+	}{
+		{
+			name:   "sampled",
+			header: xrayRoot + ";Parent=" + spanIDStr + ";Sampled=1",
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsSampled,
+				Remote:     true,
+			}),
+		},
+		{
+			name:   "not sampled, unordered with spaces and other fields",
+			header: "Self=1-67891233-abcdef012345678912345678; Sampled=0 ;Parent=" + spanIDStr + "; " + xrayRoot + ";Lineage=a87bd80c:0",
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: traceID,
+				SpanID:  spanID,
+				Remote:  true,
+			}),
+		},
+		{
+			name:   "requested sampling decision",
+			header: xrayRoot + ";Parent=" + spanIDStr + ";Sampled=?",
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsDeferred,
+				Remote:     true,
+			}),
+		},
+		{
+			name:   "no sampling decision",
+			header: xrayRoot + ";Parent=" + spanIDStr,
+			wantSc: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: trace.FlagsDeferred,
+				Remote:     true,
+			}),
+		},
+		{name: "empty", header: ""},
+		{name: "no parent", header: xrayRoot + ";Sampled=1"},
+		{name: "no root", header: "Parent=" + spanIDStr + ";Sampled=1"},
+		{name: "unsupported version", header: "Root=2-4bf92f35-77b34da6a3ce929d0e0e4736;Parent=" + spanIDStr},
+		{name: "short epoch", header: "Root=1-4bf92f3-577b34da6a3ce929d0e0e4736;Parent=" + spanIDStr},
+		{name: "invalid trace ID", header: "Root=1-4bf92f35-77b34da6a3ce929d0e0e47qw;Parent=" + spanIDStr},
+		{name: "invalid parent", header: xrayRoot + ";Parent=qw;Sampled=1"},
+		{name: "invalid sampled", header: xrayRoot + ";Parent=" + spanIDStr + ";Sampled=2"},
+	}
+
+	prop := propagation.XRay{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("X-Amzn-Trace-Id", tt.header)
+			ctx := prop.Extract(context.Background(), propagation.HeaderCarrier(header))
+			gotSc := trace.SpanContextFromContext(ctx)
+			if diff := cmp.Diff(gotSc, tt.wantSc, cmp.AllowUnexported(trace.TraceState{})); diff != "" {
+				t.Errorf("Extract X-Ray: %s: -got +want %s", tt.name, diff)
+			}
+		})
+	}
+}
+
+func TestInjectXRay(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags byte
+		want  string
+	}{
+		{"sampled", trace.FlagsSampled, xrayRoot + ";Parent=" + spanIDStr + ";Sampled=1"},
+		{"not sampled", 0, xrayRoot + ";Parent=" + spanIDStr + ";Sampled=0"},
+		{"deferred", trace.FlagsDeferred, xrayRoot + ";Parent=" + spanIDStr + ";Sampled=?"},
+	}
+	prop := propagation.XRay{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID:    traceID,
+				SpanID:     spanID,
+				TraceFlags: tt.flags,
+			})
+			header := http.Header{}
+			prop.Inject(trace.ContextWithRemoteSpanContext(context.Background(), sc), propagation.HeaderCarrier(header))
+			if got := header.Get("X-Amzn-Trace-Id"); got != tt.want {
+				t.Errorf("Inject X-Ray: %s: got %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	header := http.Header{}
+	prop.Inject(context.Background(), propagation.HeaderCarrier(header))
+	if len(header) != 0 {
+		t.Errorf("Inject X-Ray: invalid span context injected %v", header)
+	}
+}
+
+func TestXRayRoundTrip(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	prop := propagation.XRay{}
+	header := http.Header{}
+	prop.Inject(trace.ContextWithRemoteSpanContext(context.Background(), sc), propagation.HeaderCarrier(header))
+	got := trace.SpanContextFromContext(prop.Extract(context.Background(), propagation.HeaderCarrier(header)))
+	if !got.Equal(sc) {
+		t.Errorf("X-Ray round trip %v, want %v", got, sc)
+	}
+}
+
+func TestXRayPropagatorFields(t *testing.T) {
+	if diff := cmp.Diff(propagation.XRay{}.Fields(), []string{"x-amzn-trace-id"}); diff != "" {
+		t.Errorf("Fields: -got +want %s", diff)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel // import "go.opentelemetry.io/otel"
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// rateLimitedErrorHandler forwards errors to a delegate ErrorHandler,
+// dropping the errors identical to one forwarded less than an interval
+// ago.
+type rateLimitedErrorHandler struct {
+	delegate ErrorHandler
+	interval time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	seen      map[string]*errorOccurrences
+	lastPurge time.Time
+}
+
+// errorOccurrences tracks the occurrences of identical errors.
+type errorOccurrences struct {
+	// err is the last error forwarded.
+	err error
+	// reported is the last time the error was forwarded.
+	reported time.Time
+	// suppressed is the number of occurrences dropped since.
+	suppressed int
+}
+
+// Compile time check that rateLimitedErrorHandler implements ErrorHandler.
+var _ ErrorHandler = (*rateLimitedErrorHandler)(nil)
+
+// NewRateLimitedErrorHandler returns an ErrorHandler that forwards errors
+// to h, but drops the errors identical, as determined by their message,
+// to an error already forwarded during the last interval. The number of
+// dropped errors is added to the message of the next identical error
+// forwarded to h, or forwarded with the last error once the interval
+// expired, when another error is handled.
+//
+// It is meant to keep a repeatedly failing component, e.g. an exporter
+// which cannot reach its endpoint, from flooding h:
+//     otel.SetSignalErrorHandler(otel.TracesSignal,
+//         otel.NewRateLimitedErrorHandler(otel.GetErrorHandler(), time.Minute))
+func NewRateLimitedErrorHandler(h ErrorHandler, interval time.Duration) ErrorHandler {
+	return &rateLimitedErrorHandler{
+		delegate: h,
+		interval: interval,
+		now:      time.Now,
+		seen:     make(map[string]*errorOccurrences),
+	}
+}
+
+// maxRateLimitedErrors is the maximum number of distinct errors a
+// rateLimitedErrorHandler tracks. Errors beyond it, e.g. carrying unique
+// IDs, are forwarded without rate limiting until tracked errors expire.
+const maxRateLimitedErrors = 1000
+
+// Handle implements ErrorHandler.
+func (h *rateLimitedErrorHandler) Handle(err error) {
+	if err == nil {
+		h.delegate.Handle(err)
+		return
+	}
+
+	msg := err.Error()
+	now := h.now()
+
+	h.mu.Lock()
+	occ, ok := h.seen[msg]
+	if ok && now.Sub(occ.reported) < h.interval {
+		occ.suppressed++
+		flushed := h.purge(now)
+		h.mu.Unlock()
+		h.forward(flushed)
+		return
+	}
+	var suppressed int
+	if ok {
+		suppressed = occ.suppressed
+		delete(h.seen, msg)
+	}
+	flushed := h.purge(now)
+	if len(h.seen) < maxRateLimitedErrors {
+		h.seen[msg] = &errorOccurrences{err: err, reported: now}
+	}
+	h.mu.Unlock()
+
+	h.forward(flushed)
+	h.delegate.Handle(withSuppressed(err, suppressed))
+}
+
+// forward passes errs to the delegate. It must be called without h.mu
+// held.
+func (h *rateLimitedErrorHandler) forward(errs []error) {
+	for _, err := range errs {
+		h.delegate.Handle(err)
+	}
+}
+
+// withSuppressed returns err annotated with the number of identical errors
+// suppressed before it, if any.
+func withSuppressed(err error, suppressed int) error {
+	if suppressed == 0 {
+		return err
+	}
+	return fmt.Errorf("%w (%d identical errors suppressed)", err, suppressed)
+}
+
+// purge removes the errors that are no longer rate limited, at most once
+// per interval. The errors with dropped occurrences are returned, with
+// their count, to be forwarded. It needs to be called with h.mu held.
+func (h *rateLimitedErrorHandler) purge(now time.Time) []error {
+	if now.Sub(h.lastPurge) < h.interval {
+		return nil
+	}
+	h.lastPurge = now
+	var flushed []error
+	for msg, occ := range h.seen {
+		if now.Sub(occ.reported) < h.interval {
+			continue
+		}
+		if occ.suppressed > 0 {
+			flushed = append(flushed, withSuppressed(occ.err, occ.suppressed))
+		}
+		delete(h.seen, msg)
+	}
+	return flushed
+}
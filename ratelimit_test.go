@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otel
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitedErrorHandler(t *testing.T) {
+	logger := new(errLogger)
+	h := NewRateLimitedErrorHandler(logger, time.Minute).(*rateLimitedErrorHandler)
+	now := time.Unix(0, 0)
+	h.now = func() time.Time { return now }
+
+	errA := errors.New("a")
+	h.Handle(errA)
+	h.Handle(errors.New("a"))
+	h.Handle(errors.New("b"))
+	now = now.Add(30 * time.Second)
+	h.Handle(errA)
+	assert.Equal(t, []string{"a", "b"}, logger.Got())
+
+	now = now.Add(31 * time.Second)
+	h.Handle(errA)
+	h.Handle(errors.New("b"))
+	assert.Equal(t, []string{"a", "b", "a (2 identical errors suppressed)", "b"}, logger.Got())
+
+	// Errors without suppressed occurrences are eventually forgotten.
+	now = now.Add(2 * time.Minute)
+	h.Handle(errors.New("c"))
+	assert.Len(t, h.seen, 1)
+}
+
+func TestRateLimitedErrorHandlerFlushesUniqueErrors(t *testing.T) {
+	logger := new(errLogger)
+	h := NewRateLimitedErrorHandler(logger, time.Minute).(*rateLimitedErrorHandler)
+	now := time.Unix(0, 0)
+	h.now = func() time.Time { return now }
+
+	h.Handle(errors.New("request 1 failed"))
+	h.Handle(errors.New("request 1 failed"))
+	assert.Equal(t, []string{"request 1 failed"}, logger.Got())
+
+	// The suppressed occurrences of an error that does not come back are
+	// reported once it expires.
+	now = now.Add(time.Minute)
+	h.Handle(errors.New("request 2 failed"))
+	assert.Equal(t, []string{
+		"request 1 failed",
+		"request 1 failed (1 identical errors suppressed)",
+		"request 2 failed",
+	}, logger.Got())
+	assert.Len(t, h.seen, 1)
+}
+
+func TestRateLimitedErrorHandlerBoundsTrackedErrors(t *testing.T) {
+	var got int
+	h := NewRateLimitedErrorHandler(errorHandlerFunc(func(err error) {
+		got++
+	}), time.Minute).(*rateLimitedErrorHandler)
+	now := time.Unix(0, 0)
+	h.now = func() time.Time { return now }
+
+	for i := 0; i < 2*maxRateLimitedErrors; i++ {
+		h.Handle(fmt.Errorf("request %d failed", i))
+	}
+	assert.Equal(t, 2*maxRateLimitedErrors, got)
+	assert.Len(t, h.seen, maxRateLimitedErrors)
+}
+
+func TestRateLimitedErrorHandlerWrapsError(t *testing.T) {
+	var got []error
+	h := NewRateLimitedErrorHandler(errorHandlerFunc(func(err error) {
+		got = append(got, err)
+	}), time.Minute).(*rateLimitedErrorHandler)
+	now := time.Unix(0, 0)
+	h.now = func() time.Time { return now }
+
+	errA := errors.New("a")
+	h.Handle(errA)
+	h.Handle(errA)
+	now = now.Add(time.Minute)
+	h.Handle(errA)
+
+	if assert.Len(t, got, 2) {
+		assert.Same(t, errA, got[0])
+		assert.True(t, errors.Is(got[1], errA))
+	}
+}
+
+type errorHandlerFunc func(error)
+
+func (f errorHandlerFunc) Handle(err error) { f(err) }
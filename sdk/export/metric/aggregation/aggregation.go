@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // These interfaces describe the various ways to access state from an
@@ -101,6 +103,87 @@ type (
 		Histogram() (Buckets, error)
 	}
 
+	// Exemplar is a single measurement retained by an Aggregator along
+	// with the sampled span that was active in the context the measurement
+	// was made in.
+	Exemplar struct {
+		// Value is the measured value.
+		Value number.Number
+
+		// Time is the time the measurement was made.
+		Time time.Time
+
+		// SpanContext identifies the sampled span the measurement was
+		// made in.
+		SpanContext trace.SpanContext
+
+		// FilteredAttributes are the labels of the measurement
+		// removed before it was aggregated, by denied attribute
+		// keys or by a View.
+		FilteredAttributes []attribute.KeyValue
+	}
+
+	// Exemplars returns the exemplars retained by an Aggregator.
+	//
+	// Exporters of a Histogram can locate the bucket of an Exemplar by
+	// comparing its value to the bucket boundaries.
+	Exemplars interface {
+		Aggregation
+		Exemplars() ([]Exemplar, error)
+	}
+
+	// ExponentialBuckets are the buckets of one range, either the
+	// positive or the negative values, of an ExponentialHistogram.
+	//
+	// With base = 2^(2^-scale), the bucket with index i counts the
+	// values whose absolute value is in (base^i, base^(i+1)].
+	ExponentialBuckets struct {
+		// Offset is the index of the first bucket in Counts.
+		Offset int32
+
+		// Counts holds the counts of consecutive buckets, starting
+		// with the bucket with index Offset.
+		Counts []uint64
+	}
+
+	// ExponentialHistogram returns the count of events in buckets whose
+	// boundaries are the powers of a base chosen to fit the range of
+	// the values that were aggregated.
+	ExponentialHistogram interface {
+		Aggregation
+		Count() (uint64, error)
+		Sum() (number.Number, error)
+
+		// Scale determines the base of the buckets:
+		// base = 2^(2^-scale).
+		Scale() (int32, error)
+
+		// ZeroCount is the number of zero values.
+		ZeroCount() (uint64, error)
+
+		// Positive returns the buckets of the positive values.
+		Positive() (ExponentialBuckets, error)
+
+		// Negative returns the buckets of the negative values, by
+		// absolute value.
+		Negative() (ExponentialBuckets, error)
+	}
+
+	// Summary returns quantiles estimated from the values that were
+	// aggregated.
+	Summary interface {
+		Aggregation
+		Count() (uint64, error)
+		Sum() (number.Number, error)
+
+		// Quantile returns the estimated value at quantile q, which
+		// must be in [0, 1].
+		Quantile(q float64) (number.Number, error)
+
+		// Quantiles returns the quantiles to report.
+		Quantiles() []float64
+	}
+
 	// MinMaxSumCount supports the Min, Max, Sum, and Count interfaces.
 	MinMaxSumCount interface {
 		Aggregation
@@ -134,6 +217,9 @@ const (
 	HistogramKind      Kind = "Histogram"
 	LastValueKind      Kind = "Lastvalue"
 	ExactKind          Kind = "Exact"
+
+	ExponentialHistogramKind Kind = "ExponentialHistogram"
+	SummaryKind              Kind = "Summary"
 )
 
 var (
@@ -141,6 +227,7 @@ var (
 	ErrNaNInput         = fmt.Errorf("NaN value is an invalid input")
 	ErrInconsistentType = fmt.Errorf("inconsistent aggregator types")
 	ErrNoSubtraction    = fmt.Errorf("aggregator does not subtract")
+	ErrInvalidQuantile  = fmt.Errorf("the requested quantile is out of range")
 
 	// ErrNoData is returned when (due to a race with collection)
 	// the Aggregator is check-pointed before the first value is set.
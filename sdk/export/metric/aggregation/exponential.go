@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation // import "go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/otel/metric/number"
+)
+
+// ExplicitHistogram returns the Histogram of the values aggregated by h, for
+// exporters that don't support exponential histograms. Its boundaries are
+// the upper boundaries of the buckets of h, the powers of its base, and
+// zero if h counted zero values. A value equal to a boundary is counted in
+// the bucket below it.
+func ExplicitHistogram(h ExponentialHistogram) Histogram {
+	return explicitHistogram{h: h}
+}
+
+type explicitHistogram struct {
+	h ExponentialHistogram
+}
+
+var _ Histogram = explicitHistogram{}
+
+// Kind returns ExponentialHistogramKind.
+func (e explicitHistogram) Kind() Kind {
+	return e.h.Kind()
+}
+
+// Count returns the number of values aggregated by the histogram.
+func (e explicitHistogram) Count() (uint64, error) {
+	return e.h.Count()
+}
+
+// Sum returns the sum of the values aggregated by the histogram.
+func (e explicitHistogram) Sum() (number.Number, error) {
+	return e.h.Sum()
+}
+
+// Histogram returns the buckets of the exponential histogram, in
+// increasing order of their boundaries.
+func (e explicitHistogram) Histogram() (Buckets, error) {
+	scale, err := e.h.Scale()
+	if err != nil {
+		return Buckets{}, err
+	}
+	zeroCount, err := e.h.ZeroCount()
+	if err != nil {
+		return Buckets{}, err
+	}
+	positive, err := e.h.Positive()
+	if err != nil {
+		return Buckets{}, err
+	}
+	negative, err := e.h.Negative()
+	if err != nil {
+		return Buckets{}, err
+	}
+
+	// The boundary of a bucket with index i is base^i, with
+	// base = 2^(2^-scale).
+	exponent := math.Exp2(-float64(scale))
+	power := func(i int32) float64 {
+		return math.Exp2(float64(i) * exponent)
+	}
+
+	size := len(negative.Counts) + len(positive.Counts) + 1
+	b := Buckets{
+		Boundaries: make([]float64, 0, size),
+		Counts:     make([]uint64, 0, size),
+	}
+	// The negative buckets, of decreasing absolute values, end at
+	// -base^i.
+	for i := len(negative.Counts) - 1; i >= 0; i-- {
+		b.Boundaries = append(b.Boundaries, -power(negative.Offset+int32(i)))
+		b.Counts = append(b.Counts, negative.Counts[i])
+	}
+	if zeroCount > 0 {
+		b.Boundaries = append(b.Boundaries, 0)
+		b.Counts = append(b.Counts, zeroCount)
+	}
+	// The positive buckets end at base^(i+1).
+	for i, c := range positive.Counts {
+		b.Boundaries = append(b.Boundaries, power(positive.Offset+int32(i)+1))
+		b.Counts = append(b.Counts, c)
+	}
+	if len(b.Counts) == 0 {
+		b.Counts = append(b.Counts, 0)
+		return b, nil
+	}
+	// The last bucket is unbounded.
+	b.Boundaries = b.Boundaries[:len(b.Boundaries)-1]
+	return b, nil
+}
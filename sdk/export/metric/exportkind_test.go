@@ -39,6 +39,7 @@ var cumulativeMemoryKinds = []metric.InstrumentKind{
 	metric.ValueObserverInstrumentKind,
 	metric.CounterInstrumentKind,
 	metric.UpDownCounterInstrumentKind,
+	metric.GaugeInstrumentKind,
 }
 
 func TestExportKindMemoryRequired(t *testing.T) {
@@ -72,3 +73,17 @@ func TestExportKindSelectors(t *testing.T) {
 		require.False(t, seks.ExportKindFor(&desc, akind).MemoryRequired(ikind))
 	}
 }
+
+func TestCombinedExportKindSelector(t *testing.T) {
+	desc := metric.NewDescriptor("instrument", metric.SumObserverInstrumentKind, number.Int64Kind)
+
+	combined := CombinedExportKindSelector(CumulativeExportKindSelector(), DeltaExportKindSelector())
+	ekind := combined.ExportKindFor(&desc, aggregation.SumKind)
+	require.Equal(t, CumulativeExportKind|DeltaExportKind, ekind)
+	require.True(t, ekind.MemoryRequired(metric.SumObserverInstrumentKind))
+	require.True(t, ekind.MemoryRequired(metric.CounterInstrumentKind))
+
+	stateless := CombinedExportKindSelector(StatelessExportKindSelector())
+	require.Equal(t, CumulativeExportKind, stateless.ExportKindFor(&desc, aggregation.SumKind))
+	require.Equal(t, ExportKind(0), CombinedExportKindSelector().ExportKindFor(&desc, aggregation.SumKind))
+}
@@ -228,6 +228,20 @@ type ExportKindSelector interface {
 	ExportKindFor(descriptor *metric.Descriptor, aggregatorKind aggregation.Kind) ExportKind
 }
 
+// Producer produces metric data from a source external to the SDK,
+// e.g. another metric library, to be exported along with the metric
+// data of the SDK.  Producers are called by the controller during each
+// collection.
+type Producer interface {
+	// Produce returns the Records of the current metric data of the
+	// source.  The aggregations of the Records are exported as
+	// produced, they are not converted to the ExportKind of the
+	// exporter.  Their labels and descriptors go through the denied
+	// attribute keys and the views of the SDK, and their Resource is
+	// replaced by the Resource of the SDK.
+	Produce(ctx context.Context) ([]Record, error)
+}
+
 // CheckpointSet allows a controller to access a complete checkpoint of
 // aggregated metrics from the Processor.  This is passed to the
 // Exporter which may then use ForEach to iterate over the collection
@@ -276,6 +290,7 @@ type Metadata struct {
 type Accumulation struct {
 	Metadata
 	aggregator Aggregator
+	selector   AggregatorSelector
 }
 
 // Record contains the exported data for a single metric instrument
@@ -319,12 +334,30 @@ func NewAccumulation(descriptor *metric.Descriptor, labels *attribute.Set, resou
 	}
 }
 
+// NewAccumulationWithSelector is like NewAccumulation for an Aggregator
+// that was selected by selector instead of the AggregatorSelector of the
+// Processor, e.g. by a View of the Accumulator. A nil selector is the
+// same as calling NewAccumulation.
+func NewAccumulationWithSelector(descriptor *metric.Descriptor, labels *attribute.Set, resource *resource.Resource, aggregator Aggregator, selector AggregatorSelector) Accumulation {
+	a := NewAccumulation(descriptor, labels, resource, aggregator)
+	a.selector = selector
+	return a
+}
+
 // Aggregator returns the checkpointed aggregator. It is safe to
 // access the checkpointed state without locking.
 func (r Accumulation) Aggregator() Aggregator {
 	return r.aggregator
 }
 
+// AggregatorSelector returns the AggregatorSelector that selected the
+// Aggregator of the Accumulation, or nil if it was selected by the
+// AggregatorSelector of the Processor. Processors allocating Aggregators
+// to hold copies of the Aggregator have to use it when it is not nil.
+func (r Accumulation) AggregatorSelector() AggregatorSelector {
+	return r.selector
+}
+
 // NewRecord allows Processor implementations to construct export
 // records.  The Descriptor, Labels, and Aggregator represent
 // aggregate metric events received over a single collection period.
@@ -382,7 +415,8 @@ func (kind ExportKind) Includes(has ExportKind) bool {
 func (kind ExportKind) MemoryRequired(mkind metric.InstrumentKind) bool {
 	switch mkind {
 	case metric.ValueRecorderInstrumentKind, metric.ValueObserverInstrumentKind,
-		metric.CounterInstrumentKind, metric.UpDownCounterInstrumentKind:
+		metric.CounterInstrumentKind, metric.UpDownCounterInstrumentKind,
+		metric.GaugeInstrumentKind:
 		// Delta-oriented instruments:
 		return kind.Includes(CumulativeExportKind)
 
@@ -399,11 +433,13 @@ func (kind ExportKind) MemoryRequired(mkind metric.InstrumentKind) bool {
 type (
 	constantExportKindSelector  ExportKind
 	statelessExportKindSelector struct{}
+	combinedExportKindSelector  []ExportKindSelector
 )
 
 var (
 	_ ExportKindSelector = constantExportKindSelector(0)
 	_ ExportKindSelector = statelessExportKindSelector{}
+	_ ExportKindSelector = combinedExportKindSelector(nil)
 )
 
 // ConstantExportKindSelector returns an ExportKindSelector that returns
@@ -431,6 +467,15 @@ func StatelessExportKindSelector() ExportKindSelector {
 	return statelessExportKindSelector{}
 }
 
+// CombinedExportKindSelector returns an ExportKindSelector that returns
+// the union of the ExportKinds returned by selectors.  It is used to
+// configure a Processor whose checkpoints are exported by several
+// exporters preferring different kinds, e.g. a delta and a cumulative
+// one, so that it keeps the memory required by all of them.
+func CombinedExportKindSelector(selectors ...ExportKindSelector) ExportKindSelector {
+	return combinedExportKindSelector(selectors)
+}
+
 // ExportKindFor implements ExportKindSelector.
 func (c constantExportKindSelector) ExportKindFor(_ *metric.Descriptor, _ aggregation.Kind) ExportKind {
 	return ExportKind(c)
@@ -443,3 +488,12 @@ func (s statelessExportKindSelector) ExportKindFor(desc *metric.Descriptor, kind
 	}
 	return DeltaExportKind
 }
+
+// ExportKindFor implements ExportKindSelector.
+func (c combinedExportKindSelector) ExportKindFor(desc *metric.Descriptor, kind aggregation.Kind) ExportKind {
+	var ekind ExportKind
+	for _, s := range c {
+		ekind |= s.ExportKindFor(desc, kind)
+	}
+	return ekind
+}
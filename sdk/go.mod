@@ -52,4 +52,6 @@ replace go.opentelemetry.io/otel/sdk/export/metric => ./export/metric
 
 replace go.opentelemetry.io/otel/sdk/metric => ./metric
 
+replace go.opentelemetry.io/otel/sdk/trace/selfmetrics => ./trace/selfmetrics
+
 replace go.opentelemetry.io/otel/trace => ../trace
@@ -32,4 +32,7 @@ type Library struct {
 	Name string
 	// Version is the version of the instrumentation library.
 	Version string
+	// SchemaURL is the schema URL of the telemetry emitted by the
+	// instrumentation library.
+	SchemaURL string
 }
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package env provides the parsing of the environment variables shared by
+// the trace and metric SDKs.
+package env // import "go.opentelemetry.io/otel/sdk/internal/env"
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// Duration returns the positive number of milliseconds of the environment
+// variable key, or defaultValue if it is unset. Invalid values are reported
+// to the error handler of signal and defaultValue is returned.
+func Duration(signal otel.Signal, key string, defaultValue time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return defaultValue
+	}
+	ms, err := strconv.Atoi(v)
+	if err != nil || ms <= 0 {
+		otel.HandleSignal(signal, fmt.Errorf("invalid %s value %q: must be a positive number of milliseconds", key, v))
+		return defaultValue
+	}
+	return time.Duration(ms) * time.Millisecond
+}
+
+// Int returns the positive integer value of the environment variable key,
+// or defaultValue if it is unset. Invalid values are reported to the error
+// handler of signal and defaultValue is returned.
+func Int(signal otel.Signal, key string, defaultValue int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		otel.HandleSignal(signal, fmt.Errorf("invalid %s value %q: must be a positive integer", key, v))
+		return defaultValue
+	}
+	return n
+}
+
+// Float64s returns the comma-separated list of numbers of the environment
+// variable key, e.g. "0.1, 0.5, 1", or defaultValue if it is unset.
+// Invalid values are reported to the error handler of signal and
+// defaultValue is returned.
+func Float64s(signal otel.Signal, key string, defaultValue []float64) []float64 {
+	v, ok := os.LookupEnv(key)
+	if !ok || strings.TrimSpace(v) == "" {
+		return defaultValue
+	}
+	var values []float64
+	for _, field := range strings.Split(v, ",") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			otel.HandleSignal(signal, fmt.Errorf("invalid %s value %q: must be a comma-separated list of numbers", key, v))
+			return defaultValue
+		}
+		values = append(values, f)
+	}
+	return values
+}
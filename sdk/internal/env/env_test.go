@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel"
+)
+
+const testKey = "OTEL_SDK_INTERNAL_ENV_TEST"
+
+type errorRecorder struct {
+	errs []error
+}
+
+func (r *errorRecorder) Handle(err error) {
+	r.errs = append(r.errs, err)
+}
+
+func setenv(t *testing.T, value string) {
+	if err := os.Setenv(testKey, value); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDuration(t *testing.T) {
+	defer os.Unsetenv(testKey)
+	r := &errorRecorder{}
+	otel.SetSignalErrorHandler(otel.TracesSignal, r)
+	defer otel.SetSignalErrorHandler(otel.TracesSignal, nil)
+
+	os.Unsetenv(testKey)
+	assert.Equal(t, time.Second, Duration(otel.TracesSignal, testKey, time.Second))
+
+	setenv(t, "250")
+	assert.Equal(t, 250*time.Millisecond, Duration(otel.TracesSignal, testKey, time.Second))
+
+	for _, invalid := range []string{"0", "-1", "1s"} {
+		setenv(t, invalid)
+		assert.Equal(t, time.Second, Duration(otel.TracesSignal, testKey, time.Second), invalid)
+	}
+	assert.Len(t, r.errs, 3)
+}
+
+func TestInt(t *testing.T) {
+	defer os.Unsetenv(testKey)
+	r := &errorRecorder{}
+	otel.SetSignalErrorHandler(otel.MetricsSignal, r)
+	defer otel.SetSignalErrorHandler(otel.MetricsSignal, nil)
+
+	os.Unsetenv(testKey)
+	assert.Equal(t, 10, Int(otel.MetricsSignal, testKey, 10))
+
+	setenv(t, "42")
+	assert.Equal(t, 42, Int(otel.MetricsSignal, testKey, 10))
+
+	for _, invalid := range []string{"0", "-1", "ten"} {
+		setenv(t, invalid)
+		assert.Equal(t, 10, Int(otel.MetricsSignal, testKey, 10), invalid)
+	}
+	assert.Len(t, r.errs, 3)
+}
+
+func TestFloat64s(t *testing.T) {
+	defer os.Unsetenv(testKey)
+	r := &errorRecorder{}
+	otel.SetSignalErrorHandler(otel.MetricsSignal, r)
+	defer otel.SetSignalErrorHandler(otel.MetricsSignal, nil)
+
+	def := []float64{1}
+	os.Unsetenv(testKey)
+	assert.Equal(t, def, Float64s(otel.MetricsSignal, testKey, def))
+
+	setenv(t, " ")
+	assert.Equal(t, def, Float64s(otel.MetricsSignal, testKey, def))
+
+	setenv(t, "2, 0.5,1")
+	assert.Equal(t, []float64{2, 0.5, 1}, Float64s(otel.MetricsSignal, testKey, def))
+
+	for _, invalid := range []string{"1,x", "1,,2", ","} {
+		setenv(t, invalid)
+		assert.Equal(t, def, Float64s(otel.MetricsSignal, testKey, def), invalid)
+	}
+	assert.Len(t, r.errs, 3)
+}
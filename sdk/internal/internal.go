@@ -16,7 +16,6 @@ package internal // import "go.opentelemetry.io/otel/sdk/internal"
 
 import (
 	"fmt"
-	"time"
 
 	"go.opentelemetry.io/otel"
 )
@@ -24,14 +23,3 @@ import (
 // UserAgent is the user agent to be added to the outgoing
 // requests from the exporters.
 var UserAgent = fmt.Sprintf("opentelemetry-go/%s", otel.Version())
-
-// MonotonicEndTime returns the end time at present
-// but offset from start, monotonically.
-//
-// The monotonic clock is used in subtractions hence
-// the duration since start added back to start gives
-// end as a monotonic time.
-// See https://golang.org/pkg/time/#hdr-Monotonic_Clocks
-func MonotonicEndTime(start time.Time) time.Time {
-	return start.Add(time.Since(start))
-}
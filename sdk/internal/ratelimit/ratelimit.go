@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ratelimit provides the token bucket used by the rate limiting
+// parts of the SDK.
+package ratelimit // import "go.opentelemetry.io/otel/sdk/internal/ratelimit"
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Bucket is a token bucket that holds up to burst tokens and is refilled at
+// rate tokens per second.
+//
+// A Bucket is not safe for concurrent use, see Limiter.
+type Bucket struct {
+	rate     float64
+	burst    float64
+	balance  float64
+	lastTick time.Time
+}
+
+// NewBucket returns a full Bucket refilled at rate tokens per second from
+// now on. A burst < 1 is treated as 1. A rate <= 0 never refills the
+// bucket.
+func NewBucket(rate, burst float64, now time.Time) Bucket {
+	burst = math.Max(burst, 1)
+	return Bucket{
+		rate:     math.Max(rate, 0),
+		burst:    burst,
+		balance:  burst,
+		lastTick: now,
+	}
+}
+
+// Take refills b with the tokens accrued until now and reports whether a
+// token is available, consuming it if so.
+func (b *Bucket) Take(now time.Time) bool {
+	elapsed := now.Sub(b.lastTick).Seconds()
+	b.lastTick = now
+	b.balance = math.Min(b.balance+elapsed*b.rate, b.burst)
+	if b.balance < 1 {
+		return false
+	}
+	b.balance--
+	return true
+}
+
+// Limiter is a Bucket that reads the time from a clock and is safe for
+// concurrent use.
+type Limiter struct {
+	now func() time.Time
+
+	mu     sync.Mutex
+	bucket Bucket
+}
+
+// NewLimiter returns a Limiter of a full Bucket holding up to burst tokens
+// and refilled at rate tokens per second, as read from now.
+func NewLimiter(rate, burst float64, now func() time.Time) *Limiter {
+	return &Limiter{
+		now:    now,
+		bucket: NewBucket(rate, burst, now()),
+	}
+}
+
+// Take reports whether a token is available, consuming it if so.
+func (l *Limiter) Take() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.bucket.Take(l.now())
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucket(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewBucket(2, 3, now)
+
+	// Starts full.
+	for i := 0; i < 3; i++ {
+		assert.True(t, b.Take(now), i)
+	}
+	assert.False(t, b.Take(now))
+
+	// Refills at rate.
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, b.Take(now))
+	assert.False(t, b.Take(now))
+
+	// Never holds more than burst.
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.True(t, b.Take(now), i)
+	}
+	assert.False(t, b.Take(now))
+}
+
+func TestBucketMinimumBurst(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewBucket(0.5, 0, now)
+	assert.True(t, b.Take(now))
+	assert.False(t, b.Take(now))
+	assert.True(t, b.Take(now.Add(2*time.Second)))
+}
+
+func TestBucketNoRefill(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewBucket(-1, 1, now)
+	assert.True(t, b.Take(now))
+	assert.False(t, b.Take(now.Add(time.Hour)))
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewLimiter(1, 1, func() time.Time { return now })
+	assert.True(t, l.Take())
+	assert.False(t, l.Take())
+	now = now.Add(time.Second)
+	assert.True(t, l.Take())
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "go.opentelemetry.io/otel/sdk/internal"
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// ErrResourceMismatch is returned when SDK providers in the same process are
+// configured with Resources that identify different service instances.
+var ErrResourceMismatch = errors.New("resource mismatch between providers")
+
+// resourceRegistry records the service instances identified by the
+// Resources of the providers that are not shut down.
+type resourceRegistry struct {
+	mu sync.Mutex
+
+	// live holds the registrations of these providers, by
+	// service.instance.id value.
+	live map[string]*registration
+}
+
+// registration counts the providers registered with a service.instance.id.
+type registration struct {
+	// signal is the name of the signal of the first of these providers.
+	signal string
+	count  int
+}
+
+var globalResourceRegistry = &resourceRegistry{}
+
+// RegisterResource registers the Resource r used by a provider of the
+// named signal (e.g. "trace" or "metric") with the process wide registry.
+// The returned release function unregisters it, it is called when the
+// provider is shut down or changes its Resource. It can be called more
+// than once.
+//
+// Telemetry from different providers can only be correlated if they
+// describe the same entity. If r contains a service.instance.id that differs
+// from the one of a Resource registered and not released, an
+// ErrResourceMismatch is returned. The Resource is registered regardless.
+// Resources without a service.instance.id are ignored.
+func RegisterResource(signal string, r *resource.Resource) (release func(), err error) {
+	return globalResourceRegistry.register(signal, r)
+}
+
+func (reg *resourceRegistry) register(signal string, r *resource.Resource) (func(), error) {
+	if r.Len() == 0 {
+		return func() {}, nil
+	}
+	v, ok := r.Set().Value(semconv.ServiceInstanceIDKey)
+	if !ok {
+		return func() {}, nil
+	}
+	id := v.Emit()
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	var err error
+	for other, oreg := range reg.live {
+		if other != id {
+			err = fmt.Errorf(
+				"%w: %s provider uses %s %q, but a %s provider that is not shut down uses %q",
+				ErrResourceMismatch,
+				signal,
+				semconv.ServiceInstanceIDKey,
+				id,
+				oreg.signal,
+				other,
+			)
+			break
+		}
+	}
+
+	if reg.live == nil {
+		reg.live = map[string]*registration{}
+	}
+	lreg, ok := reg.live[id]
+	if !ok {
+		lreg = &registration{signal: signal}
+		reg.live[id] = lreg
+	}
+	lreg.count++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { reg.release(id) })
+	}, err
+}
+
+func (reg *resourceRegistry) release(id string) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	lreg, ok := reg.live[id]
+	if !ok {
+		return
+	}
+	lreg.count--
+	if lreg.count == 0 {
+		delete(reg.live, id)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"errors"
+	"testing"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestResourceRegistryCheck(t *testing.T) {
+	instance := func(id string) *resource.Resource {
+		return resource.NewWithAttributes(
+			semconv.ServiceNameKey.String("test"),
+			semconv.ServiceInstanceIDKey.String(id),
+		)
+	}
+
+	reg := &resourceRegistry{}
+	check := func(signal string, r *resource.Resource) (func(), error) {
+		return reg.register(signal, r)
+	}
+	if _, err := check("trace", resource.Empty()); err != nil {
+		t.Fatalf("unexpected error for resource without instance ID: %v", err)
+	}
+	releaseA, err := check("trace", instance("a"))
+	if err != nil {
+		t.Fatalf("unexpected error registering first resource: %v", err)
+	}
+	releaseA2, err := check("metric", instance("a"))
+	if err != nil {
+		t.Errorf("unexpected error for matching resource: %v", err)
+	}
+	if _, err := check("metric", resource.Empty()); err != nil {
+		t.Errorf("unexpected error for resource without instance ID: %v", err)
+	}
+	releaseB, err := check("metric", instance("b"))
+	if !errors.Is(err, ErrResourceMismatch) {
+		t.Errorf("expected ErrResourceMismatch, got %v", err)
+	}
+	releaseB()
+
+	// A released resource no longer conflicts, once all its providers
+	// released it.
+	releaseA()
+	releaseA() // No effect, the metric provider still uses "a".
+	releaseC, err := check("trace", instance("c"))
+	if !errors.Is(err, ErrResourceMismatch) {
+		t.Errorf("expected ErrResourceMismatch, got %v", err)
+	}
+	releaseC()
+	releaseA2()
+	if _, err := check("trace", instance("d")); err != nil {
+		t.Errorf("unexpected error after release: %v", err)
+	}
+}
@@ -15,13 +15,17 @@
 package aggregator // import "go.opentelemetry.io/otel/sdk/metric/aggregator"
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/number"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // NewInconsistentAggregatorError formats an error describing an attempt to
@@ -50,3 +54,42 @@ func RangeTest(num number.Number, descriptor *metric.Descriptor) error {
 	}
 	return nil
 }
+
+type filteredAttributesKeyType int
+
+const filteredAttributesKey filteredAttributesKeyType = 0
+
+// ContextWithFilteredAttributes returns a copy of ctx in which the labels
+// of a measurement removed before it is aggregated are stored, to be
+// retained by the exemplar of the measurement.
+func ContextWithFilteredAttributes(ctx context.Context, kvs []attribute.KeyValue) context.Context {
+	return context.WithValue(ctx, filteredAttributesKey, kvs)
+}
+
+// NewExemplar returns an Exemplar for num if it is measured in a context
+// containing a sampled span. Otherwise, false is returned.
+func NewExemplar(ctx context.Context, num number.Number) (aggregation.Exemplar, bool) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() || !sc.IsSampled() {
+		return aggregation.Exemplar{}, false
+	}
+	filtered, _ := ctx.Value(filteredAttributesKey).([]attribute.KeyValue)
+	return aggregation.Exemplar{
+		Value:              num,
+		Time:               time.Now(),
+		SpanContext:        sc,
+		FilteredAttributes: filtered,
+	}, true
+}
+
+// LatestExemplar returns the most recent of the Exemplars a and b, ignoring
+// either if it is the zero value.
+func LatestExemplar(a, b aggregation.Exemplar) aggregation.Exemplar {
+	if !b.SpanContext.IsValid() {
+		return a
+	}
+	if !a.SpanContext.IsValid() || b.Time.After(a.Time) {
+		return b
+	}
+	return a
+}
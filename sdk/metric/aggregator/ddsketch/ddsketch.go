@@ -0,0 +1,450 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddsketch // import "go.opentelemetry.io/otel/sdk/metric/aggregator/ddsketch"
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+)
+
+const (
+	// DefaultRelativeAccuracy is the default relative accuracy of the
+	// estimated quantiles.
+	DefaultRelativeAccuracy = 0.01
+
+	// DefaultMaxBins is the default maximum number of bins of each of
+	// the positive and negative ranges.
+	DefaultMaxBins = 2048
+
+	// MinMaxBins is the smallest supported maximum number of bins.
+	MinMaxBins = 2
+
+	// maxIndex bounds the absolute value of the index of a bin.
+	maxIndex = 1 << 30
+)
+
+// DefaultQuantiles are the quantiles reported by default: the minimum,
+// the median, the 90th and 99th percentiles and the maximum.
+var DefaultQuantiles = []float64{0, 0.5, 0.9, 0.99, 1}
+
+type (
+	// Aggregator estimates quantiles of the observed values with a
+	// DDSketch: the values are counted in bins whose boundaries are the
+	// powers of gamma = (1+accuracy)/(1-accuracy), so that any quantile
+	// is estimated within the relative accuracy.  When the range of the
+	// values exceeds the maximum number of bins, the bins of the values
+	// closest to zero are collapsed.  It also calculates the sum, count,
+	// minimum and maximum of all events.
+	Aggregator struct {
+		lock      sync.Mutex
+		kind      number.Kind
+		gamma     float64
+		logGamma  float64
+		maxBins   int32
+		quantiles []float64
+		state     *state
+	}
+
+	// config describes how the sketch is aggregated.
+	config struct {
+		relativeAccuracy float64
+		maxBins          int32
+		quantiles        []float64
+	}
+
+	// Option configures a sketch config.
+	Option interface {
+		// apply sets one or more config fields.
+		apply(*config)
+	}
+
+	// state represents the state of a sketch.
+	state struct {
+		sum       number.Number
+		count     uint64
+		zeroCount uint64
+		min       float64
+		max       float64
+		positive  bins
+		negative  bins
+	}
+
+	// bins are the counts of consecutive bins, starting with the bin
+	// with index offset.
+	bins struct {
+		offset int32
+		counts []uint64
+	}
+)
+
+// WithRelativeAccuracy sets the relative accuracy of the estimated
+// quantiles, in (0, 1).  DefaultRelativeAccuracy is used for values out of
+// range.
+func WithRelativeAccuracy(accuracy float64) Option {
+	return relativeAccuracyOption(accuracy)
+}
+
+type relativeAccuracyOption float64
+
+func (o relativeAccuracyOption) apply(config *config) {
+	config.relativeAccuracy = float64(o)
+}
+
+// WithMaxBins sets the maximum number of bins of each of the positive and
+// negative ranges.  Sizes smaller than MinMaxBins are raised to MinMaxBins.
+func WithMaxBins(bins int32) Option {
+	return maxBinsOption(bins)
+}
+
+type maxBinsOption int32
+
+func (o maxBinsOption) apply(config *config) {
+	config.maxBins = int32(o)
+}
+
+// WithQuantiles sets the quantiles reported by the sketch.
+func WithQuantiles(quantiles []float64) Option {
+	return quantilesOption(quantiles)
+}
+
+type quantilesOption []float64
+
+func (o quantilesOption) apply(config *config) {
+	config.quantiles = o
+}
+
+var _ export.Aggregator = &Aggregator{}
+var _ aggregation.Sum = &Aggregator{}
+var _ aggregation.Count = &Aggregator{}
+var _ aggregation.Min = &Aggregator{}
+var _ aggregation.Max = &Aggregator{}
+var _ aggregation.Summary = &Aggregator{}
+
+// New returns a new aggregator for estimating quantiles.
+//
+// Unlike the histogram aggregator, no bucket boundaries need to be chosen:
+// quantiles are estimated within a relative accuracy whatever the
+// distribution of the observed values.
+func New(cnt int, desc *metric.Descriptor, opts ...Option) []Aggregator {
+	cfg := config{
+		relativeAccuracy: DefaultRelativeAccuracy,
+		maxBins:          DefaultMaxBins,
+		quantiles:        DefaultQuantiles,
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	if !(cfg.relativeAccuracy > 0 && cfg.relativeAccuracy < 1) {
+		cfg.relativeAccuracy = DefaultRelativeAccuracy
+	}
+	if cfg.maxBins < MinMaxBins {
+		cfg.maxBins = MinMaxBins
+	}
+
+	gamma := (1 + cfg.relativeAccuracy) / (1 - cfg.relativeAccuracy)
+	aggs := make([]Aggregator, cnt)
+	for i := range aggs {
+		aggs[i] = Aggregator{
+			kind:      desc.NumberKind(),
+			gamma:     gamma,
+			logGamma:  math.Log(gamma),
+			maxBins:   cfg.maxBins,
+			quantiles: cfg.quantiles,
+			state:     newState(),
+		}
+	}
+	return aggs
+}
+
+func newState() *state {
+	return &state{
+		min: math.Inf(1),
+		max: math.Inf(-1),
+	}
+}
+
+// Aggregation returns an interface for reading the state of this aggregator.
+func (c *Aggregator) Aggregation() aggregation.Aggregation {
+	return c
+}
+
+// Kind returns aggregation.SummaryKind.
+func (c *Aggregator) Kind() aggregation.Kind {
+	return aggregation.SummaryKind
+}
+
+// Sum returns the sum of all values in the checkpoint.
+func (c *Aggregator) Sum() (number.Number, error) {
+	return c.state.sum, nil
+}
+
+// Count returns the number of values in the checkpoint.
+func (c *Aggregator) Count() (uint64, error) {
+	return c.state.count, nil
+}
+
+// Min returns the minimum value in the checkpoint.  The error value
+// aggregation.ErrNoData will be returned if there were no measurements.
+func (c *Aggregator) Min() (number.Number, error) {
+	if c.state.count == 0 {
+		return 0, aggregation.ErrNoData
+	}
+	return c.toNumber(c.state.min), nil
+}
+
+// Max returns the maximum value in the checkpoint.  The error value
+// aggregation.ErrNoData will be returned if there were no measurements.
+func (c *Aggregator) Max() (number.Number, error) {
+	if c.state.count == 0 {
+		return 0, aggregation.ErrNoData
+	}
+	return c.toNumber(c.state.max), nil
+}
+
+// Quantiles returns the quantiles reported by the sketch.
+func (c *Aggregator) Quantiles() []float64 {
+	return c.quantiles
+}
+
+// Quantile returns the estimated value at quantile q of the checkpoint.
+// The error value aggregation.ErrNoData will be returned if there were no
+// measurements, aggregation.ErrInvalidQuantile if q is not in [0, 1].
+func (c *Aggregator) Quantile(q float64) (number.Number, error) {
+	if !(q >= 0 && q <= 1) {
+		return 0, aggregation.ErrInvalidQuantile
+	}
+	s := c.state
+	if s.count == 0 {
+		return 0, aggregation.ErrNoData
+	}
+	switch q {
+	case 0:
+		return c.toNumber(s.min), nil
+	case 1:
+		return c.toNumber(s.max), nil
+	}
+
+	// The estimate is the value of the element with rank
+	// q*(count-1), in ascending order.
+	rank := uint64(q * float64(s.count-1))
+	var seen uint64
+	value := s.max
+	found := false
+	for i := len(s.negative.counts) - 1; i >= 0 && !found; i-- {
+		if seen += s.negative.counts[i]; seen > rank {
+			value = -c.binValue(s.negative.offset + int32(i))
+			found = true
+		}
+	}
+	if !found {
+		if seen += s.zeroCount; seen > rank {
+			value = 0
+			found = true
+		}
+	}
+	for i := 0; i < len(s.positive.counts) && !found; i++ {
+		if seen += s.positive.counts[i]; seen > rank {
+			value = c.binValue(s.positive.offset + int32(i))
+			found = true
+		}
+	}
+	return c.toNumber(math.Max(s.min, math.Min(s.max, value))), nil
+}
+
+// toNumber converts value, a float64, to the number kind of the sketch.
+func (c *Aggregator) toNumber(value float64) number.Number {
+	if c.kind == number.Int64Kind {
+		return number.NewInt64Number(int64(math.Round(value)))
+	}
+	return number.NewFloat64Number(value)
+}
+
+// SynchronizedMove saves the current state into oa and resets the current
+// state to the empty set.
+func (c *Aggregator) SynchronizedMove(oa export.Aggregator, desc *metric.Descriptor) error {
+	o, _ := oa.(*Aggregator)
+
+	if oa != nil && o == nil {
+		return aggregator.NewInconsistentAggregatorError(c, oa)
+	}
+
+	if o != nil {
+		// Reset the target state before swapping it under the
+		// lock below.
+		o.clearState()
+	}
+
+	c.lock.Lock()
+	if o != nil {
+		c.state, o.state = o.state, c.state
+	} else {
+		c.clearState()
+	}
+	c.lock.Unlock()
+
+	return nil
+}
+
+func (c *Aggregator) clearState() {
+	c.state.sum = 0
+	c.state.count = 0
+	c.state.zeroCount = 0
+	c.state.min = math.Inf(1)
+	c.state.max = math.Inf(-1)
+	c.state.positive.clear()
+	c.state.negative.clear()
+}
+
+// Update adds the recorded measurement to the current data set.
+func (c *Aggregator) Update(_ context.Context, number number.Number, desc *metric.Descriptor) error {
+	kind := desc.NumberKind()
+	value := number.CoerceToFloat64(kind)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.state.count++
+	c.state.sum.AddNumber(kind, number)
+	c.state.min = math.Min(c.state.min, value)
+	c.state.max = math.Max(c.state.max, value)
+
+	switch {
+	case value == 0:
+		c.state.zeroCount++
+	case value < 0:
+		c.state.negative.add(c.binIndex(-value), 1, c.maxBins)
+	default:
+		c.state.positive.add(c.binIndex(value), 1, c.maxBins)
+	}
+	return nil
+}
+
+// Merge combines two sketches with the same configuration into a single one.
+func (c *Aggregator) Merge(oa export.Aggregator, desc *metric.Descriptor) error {
+	o, _ := oa.(*Aggregator)
+	if o == nil {
+		return aggregator.NewInconsistentAggregatorError(c, oa)
+	}
+
+	c.state.sum.AddNumber(desc.NumberKind(), o.state.sum)
+	c.state.count += o.state.count
+	c.state.zeroCount += o.state.zeroCount
+	c.state.min = math.Min(c.state.min, o.state.min)
+	c.state.max = math.Max(c.state.max, o.state.max)
+	c.state.positive.merge(&o.state.positive, c.maxBins)
+	c.state.negative.merge(&o.state.negative, c.maxBins)
+	return nil
+}
+
+// binIndex returns the index of the bin of value, a positive number: the
+// bin with index i holds the values in (gamma^(i-1), gamma^i].
+func (c *Aggregator) binIndex(value float64) int32 {
+	index := math.Ceil(math.Log(value) / c.logGamma)
+	// Bound the index of infinite values, keeping the difference of
+	// two indexes in range.
+	return int32(math.Max(-maxIndex, math.Min(maxIndex, index)))
+}
+
+// binValue returns the value estimating the values of the bin with index
+// i, within the relative accuracy of any of them.
+func (c *Aggregator) binValue(i int32) float64 {
+	return 2 * math.Pow(c.gamma, float64(i)) / (c.gamma + 1)
+}
+
+// high returns the index of the last bin.
+func (b *bins) high() int32 {
+	return b.offset + int32(len(b.counts)) - 1
+}
+
+// add adds n to the bin with index, collapsing the lowest bins so that
+// there are no more than maxBins bins.
+func (b *bins) add(index int32, n uint64, maxBins int32) {
+	if len(b.counts) == 0 {
+		b.offset = index
+		b.counts = append(b.counts[:0], n)
+		return
+	}
+	low, high := b.offset, b.high()
+	switch {
+	case index < low:
+		if high-index >= maxBins {
+			// Collapse index into the lowest bin kept.
+			index = high - maxBins + 1
+			if index >= low {
+				b.counts[index-low] += n
+				return
+			}
+		}
+		grown := make([]uint64, high-index+1)
+		copy(grown[low-index:], b.counts)
+		b.counts = grown
+		b.offset = index
+	case index > high:
+		if index-low >= maxBins {
+			b.collapse(index - maxBins + 1)
+		}
+		for b.high() < index {
+			b.counts = append(b.counts, 0)
+		}
+	}
+	b.counts[index-b.offset] += n
+}
+
+// collapse merges the bins with an index lower than low into the bin with
+// index low.
+func (b *bins) collapse(low int32) {
+	if low <= b.offset {
+		return
+	}
+	if low > b.high() {
+		var total uint64
+		for _, n := range b.counts {
+			total += n
+		}
+		b.offset = low
+		b.counts = append(b.counts[:0], total)
+		return
+	}
+	shift := low - b.offset
+	var collapsed uint64
+	for _, n := range b.counts[:shift+1] {
+		collapsed += n
+	}
+	kept := copy(b.counts, b.counts[shift:])
+	b.counts = b.counts[:kept]
+	b.counts[0] = collapsed
+	b.offset = low
+}
+
+// merge adds the counts of o.
+func (b *bins) merge(o *bins, maxBins int32) {
+	for i, n := range o.counts {
+		if n != 0 {
+			b.add(o.offset+int32(i), n, maxBins)
+		}
+	}
+}
+
+func (b *bins) clear() {
+	b.offset = 0
+	b.counts = b.counts[:0]
+}
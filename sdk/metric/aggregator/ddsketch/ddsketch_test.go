@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddsketch
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
+)
+
+func newAggregator(nkind number.Kind, opts ...Option) (*Aggregator, *metric.Descriptor) {
+	desc := metric.NewDescriptor("summary", metric.ValueRecorderInstrumentKind, nkind)
+	return &New(1, &desc, opts...)[0], &desc
+}
+
+func update(t *testing.T, agg *Aggregator, desc *metric.Descriptor, values ...float64) {
+	for _, v := range values {
+		require.NoError(t, agg.Update(context.Background(), number.NewFloat64Number(v), desc))
+	}
+}
+
+// exactQuantile returns the element with rank q*(len-1) of sorted.
+func exactQuantile(sorted []float64, q float64) float64 {
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
+func checkQuantiles(t *testing.T, agg *Aggregator, values []float64, accuracy float64) {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	for _, q := range []float64{0, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999, 1} {
+		got, err := agg.Quantile(q)
+		require.NoError(t, err)
+		want := exactQuantile(sorted, q)
+		assert.InDelta(t, want, got.AsFloat64(), accuracy*math.Abs(want)+1e-12, "quantile %v", q)
+	}
+}
+
+func TestSketchAccuracy(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	agg, desc := newAggregator(number.Float64Kind)
+
+	var values []float64
+	var sum float64
+	for i := 0; i < 10000; i++ {
+		v := math.Exp(r.NormFloat64() * 3)
+		if i%4 == 0 {
+			v = -v
+		}
+		if i%100 == 0 {
+			v = 0
+		}
+		values = append(values, v)
+		sum += v
+	}
+	update(t, agg, desc, values...)
+
+	checkQuantiles(t, agg, values, DefaultRelativeAccuracy)
+
+	count, err := agg.Count()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(len(values)), count)
+	s, err := agg.Sum()
+	require.NoError(t, err)
+	assert.InDelta(t, sum, s.AsFloat64(), 1e-6*math.Abs(sum))
+	assert.Equal(t, DefaultQuantiles, agg.Quantiles())
+}
+
+func TestSketchMaxBins(t *testing.T) {
+	agg, desc := newAggregator(number.Float64Kind, WithMaxBins(10), WithRelativeAccuracy(0.1))
+
+	var values []float64
+	for v := 1e-3; v < 1e6; v *= 1.5 {
+		values = append(values, v)
+	}
+	update(t, agg, desc, values...)
+
+	assert.LessOrEqual(t, len(agg.state.positive.counts), 10)
+	var total uint64
+	for _, n := range agg.state.positive.counts {
+		total += n
+	}
+	assert.Equal(t, uint64(len(values)), total)
+
+	// The lowest bins are collapsed, the highest quantiles are kept
+	// accurate.
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	for _, q := range []float64{0.96, 0.99} {
+		got, err := agg.Quantile(q)
+		require.NoError(t, err)
+		want := exactQuantile(sorted, q)
+		assert.InDelta(t, want, got.AsFloat64(), 0.1*want, "quantile %v", q)
+	}
+	min, err := agg.Quantile(0)
+	require.NoError(t, err)
+	assert.Equal(t, 1e-3, min.AsFloat64())
+}
+
+func TestSketchBinsAdd(t *testing.T) {
+	var b bins
+	b.add(5, 1, 4)
+	b.add(3, 1, 4)
+	assert.Equal(t, int32(3), b.offset)
+	assert.Equal(t, []uint64{1, 0, 1}, b.counts)
+
+	// Index 7 collapses 3 into 4.
+	b.add(7, 1, 4)
+	assert.Equal(t, int32(4), b.offset)
+	assert.Equal(t, []uint64{1, 1, 0, 1}, b.counts)
+
+	// Index 0 is collapsed in the lowest bin kept.
+	b.add(0, 2, 4)
+	assert.Equal(t, int32(4), b.offset)
+	assert.Equal(t, []uint64{3, 1, 0, 1}, b.counts)
+
+	// Index 20 collapses all bins.
+	b.add(20, 1, 4)
+	assert.Equal(t, int32(17), b.offset)
+	assert.Equal(t, []uint64{5, 0, 0, 1}, b.counts)
+}
+
+func TestSketchMerge(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	a1, desc := newAggregator(number.Float64Kind)
+	a2, _ := newAggregator(number.Float64Kind)
+
+	var values []float64
+	for i := 0; i < 1000; i++ {
+		v1 := r.Float64() * 10
+		v2 := -r.Float64()*1000 - 5
+		values = append(values, v1, v2)
+		update(t, a1, desc, v1)
+		update(t, a2, desc, v2)
+	}
+	aggregatortest.CheckedMerge(t, a1, a2, desc)
+	checkQuantiles(t, a1, values, DefaultRelativeAccuracy)
+
+	empty, _ := newAggregator(number.Float64Kind)
+	aggregatortest.CheckedMerge(t, a1, empty, desc)
+	checkQuantiles(t, a1, values, DefaultRelativeAccuracy)
+}
+
+func TestSketchInt64(t *testing.T) {
+	agg, desc := newAggregator(number.Int64Kind)
+	for i := int64(1); i <= 100; i++ {
+		require.NoError(t, agg.Update(context.Background(), number.NewInt64Number(i), desc))
+	}
+	median, err := agg.Quantile(0.5)
+	require.NoError(t, err)
+	assert.InDelta(t, 50, median.AsInt64(), 1)
+	max, err := agg.Max()
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), max.AsInt64())
+	sum, err := agg.Sum()
+	require.NoError(t, err)
+	assert.Equal(t, int64(5050), sum.AsInt64())
+}
+
+func TestSketchErrors(t *testing.T) {
+	agg, desc := newAggregator(number.Float64Kind, WithQuantiles([]float64{0.5}))
+	assert.Equal(t, []float64{0.5}, agg.Quantiles())
+
+	_, err := agg.Quantile(0.5)
+	assert.ErrorIs(t, err, aggregation.ErrNoData)
+	_, err = agg.Min()
+	assert.ErrorIs(t, err, aggregation.ErrNoData)
+
+	update(t, agg, desc, 1)
+	for _, q := range []float64{-0.1, 1.1, math.NaN()} {
+		_, err = agg.Quantile(q)
+		assert.ErrorIs(t, err, aggregation.ErrInvalidQuantile)
+	}
+}
+
+func TestSynchronizedMoveReset(t *testing.T) {
+	aggregatortest.SynchronizedMoveResetTest(
+		t,
+		metric.ValueRecorderInstrumentKind,
+		func(desc *metric.Descriptor) export.Aggregator {
+			return &New(1, desc)[0]
+		},
+	)
+}
+
+func TestSynchronizedMoveSwapsState(t *testing.T) {
+	agg, desc := newAggregator(number.Float64Kind)
+	ckpt, _ := newAggregator(number.Float64Kind)
+	update(t, agg, desc, 1, 1000, -5)
+
+	require.NoError(t, agg.SynchronizedMove(ckpt, desc))
+	count, _ := ckpt.Count()
+	assert.Equal(t, uint64(3), count)
+	min, _ := ckpt.Min()
+	assert.Equal(t, -5.0, min.AsFloat64())
+
+	count, _ = agg.Count()
+	assert.Equal(t, uint64(0), count)
+	assert.Empty(t, agg.state.positive.counts)
+	assert.Empty(t, agg.state.negative.counts)
+}
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package exemplar provides the reservoirs retaining the exemplars of
+// Aggregators.
+//
+// An exemplar is a measurement made in the context of a sampled span,
+// retained along with the span to correlate metrics and traces.  The
+// Strategy of an Aggregator creates its Reservoirs, which select the
+// exemplars retained among the measurements.
+package exemplar // import "go.opentelemetry.io/otel/sdk/metric/aggregator/exemplar"
+
+import (
+	"math/rand"
+	"sync"
+
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+)
+
+// Reservoir retains exemplars selected among the exemplars offered to it.
+// A Reservoir is not safe for concurrent use, Aggregators synchronize
+// their access to it.
+type Reservoir interface {
+	// Offer offers ex, an exemplar of a measurement aggregated in the
+	// bucket with index bucket of the Aggregator.
+	Offer(ex aggregation.Exemplar, bucket int)
+
+	// Merge offers the exemplars retained by o, a Reservoir created by
+	// the same Strategy.
+	Merge(o Reservoir)
+
+	// Exemplars returns the retained exemplars.  The returned slice
+	// must not be modified and is only valid until the next call to
+	// Offer, Merge or Reset.
+	Exemplars() []aggregation.Exemplar
+
+	// Reset discards the retained exemplars.
+	Reset()
+}
+
+// Strategy creates the Reservoir of an Aggregator with buckets buckets,
+// buckets is 1 for Aggregators without buckets.
+type Strategy func(buckets int) Reservoir
+
+// FixedSize returns a Strategy of Reservoirs retaining a uniformly random
+// sample of at most size of the exemplars offered.
+func FixedSize(size int) Strategy {
+	if size < 1 {
+		size = 1
+	}
+	return func(int) Reservoir {
+		return &fixedSize{
+			exemplars: make([]aggregation.Exemplar, 0, size),
+		}
+	}
+}
+
+// AlignedHistogram returns a Strategy of Reservoirs retaining the latest of
+// the exemplars offered for each bucket.
+func AlignedHistogram() Strategy {
+	return func(buckets int) Reservoir {
+		if buckets < 1 {
+			buckets = 1
+		}
+		return &alignedHistogram{
+			exemplars: make([]aggregation.Exemplar, buckets),
+		}
+	}
+}
+
+var (
+	randLock sync.Mutex
+	random   = rand.New(rand.NewSource(rand.Int63()))
+)
+
+// randInt63n returns a random int64 in [0, n).
+func randInt63n(n int64) int64 {
+	randLock.Lock()
+	defer randLock.Unlock()
+	return random.Int63n(n)
+}
+
+// fixedSize implements the reservoir sampling of Algorithm R.
+type fixedSize struct {
+	exemplars []aggregation.Exemplar
+	// seen is the number of exemplars offered since the last Reset.
+	seen int64
+}
+
+var _ Reservoir = &fixedSize{}
+
+func (r *fixedSize) Offer(ex aggregation.Exemplar, _ int) {
+	r.seen++
+	if len(r.exemplars) < cap(r.exemplars) {
+		r.exemplars = append(r.exemplars, ex)
+		return
+	}
+	if i := randInt63n(r.seen); i < int64(len(r.exemplars)) {
+		r.exemplars[i] = ex
+	}
+}
+
+// Merge draws the merged sample from both samples, each with a probability
+// proportional to the number of exemplars it was sampled from.
+func (r *fixedSize) Merge(o Reservoir) {
+	other, _ := o.(*fixedSize)
+	if other == nil || other.seen == 0 {
+		return
+	}
+	if r.seen+other.seen <= int64(cap(r.exemplars)) {
+		r.exemplars = append(r.exemplars, other.exemplars...)
+		r.seen += other.seen
+		return
+	}
+
+	mine := append([]aggregation.Exemplar(nil), r.exemplars...)
+	theirs := append([]aggregation.Exemplar(nil), other.exemplars...)
+	mySeen, theirSeen := r.seen, other.seen
+	r.exemplars = r.exemplars[:0]
+	for len(r.exemplars) < cap(r.exemplars) && len(mine)+len(theirs) > 0 {
+		from := &theirs
+		if len(theirs) == 0 || (len(mine) > 0 && randInt63n(mySeen+theirSeen) < mySeen) {
+			from = &mine
+			mySeen--
+		} else {
+			theirSeen--
+		}
+		i := randInt63n(int64(len(*from)))
+		r.exemplars = append(r.exemplars, (*from)[i])
+		(*from)[i] = (*from)[len(*from)-1]
+		*from = (*from)[:len(*from)-1]
+	}
+	r.seen += other.seen
+}
+
+func (r *fixedSize) Exemplars() []aggregation.Exemplar {
+	return r.exemplars
+}
+
+func (r *fixedSize) Reset() {
+	r.exemplars = r.exemplars[:0]
+	r.seen = 0
+}
+
+// alignedHistogram retains the latest exemplar of each bucket.
+type alignedHistogram struct {
+	exemplars []aggregation.Exemplar
+}
+
+var _ Reservoir = &alignedHistogram{}
+
+func (r *alignedHistogram) Offer(ex aggregation.Exemplar, bucket int) {
+	if bucket < 0 || bucket >= len(r.exemplars) {
+		return
+	}
+	r.exemplars[bucket] = aggregator.LatestExemplar(r.exemplars[bucket], ex)
+}
+
+func (r *alignedHistogram) Merge(o Reservoir) {
+	other, _ := o.(*alignedHistogram)
+	if other == nil {
+		return
+	}
+	for i, ex := range other.exemplars {
+		if i < len(r.exemplars) {
+			r.exemplars[i] = aggregator.LatestExemplar(r.exemplars[i], ex)
+		}
+	}
+}
+
+// Exemplars returns the retained exemplars in bucket order.
+func (r *alignedHistogram) Exemplars() []aggregation.Exemplar {
+	var retained []aggregation.Exemplar
+	for _, ex := range r.exemplars {
+		if ex.SpanContext.IsValid() {
+			retained = append(retained, ex)
+		}
+	}
+	return retained
+}
+
+func (r *alignedHistogram) Reset() {
+	for i := range r.exemplars {
+		r.exemplars[i] = aggregation.Exemplar{}
+	}
+}
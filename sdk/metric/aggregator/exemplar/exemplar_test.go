@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exemplar_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/metric/number"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exemplar"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var start = time.Unix(1000, 0)
+
+func newExemplar(i int64) aggregation.Exemplar {
+	return aggregation.Exemplar{
+		Value: number.NewInt64Number(i),
+		Time:  start.Add(time.Duration(i) * time.Second),
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{byte(i + 1)},
+			SpanID:     trace.SpanID{byte(i + 1)},
+			TraceFlags: trace.FlagsSampled,
+		}),
+	}
+}
+
+func values(exemplars []aggregation.Exemplar) []int64 {
+	var vals []int64
+	for _, ex := range exemplars {
+		vals = append(vals, ex.Value.AsInt64())
+	}
+	return vals
+}
+
+func TestFixedSize(t *testing.T) {
+	r := exemplar.FixedSize(3)(1)
+	assert.Empty(t, r.Exemplars())
+
+	for i := int64(0); i < 3; i++ {
+		r.Offer(newExemplar(i), 0)
+	}
+	assert.Equal(t, []int64{0, 1, 2}, values(r.Exemplars()))
+
+	for i := int64(3); i < 100; i++ {
+		r.Offer(newExemplar(i), 0)
+	}
+	require.Len(t, r.Exemplars(), 3)
+	for _, v := range values(r.Exemplars()) {
+		assert.True(t, v >= 0 && v < 100)
+	}
+
+	r.Reset()
+	assert.Empty(t, r.Exemplars())
+}
+
+func TestFixedSizeUniform(t *testing.T) {
+	// Every offered exemplar is about as likely to be retained.
+	const offered, rounds = 10, 2000
+	retained := make([]int, offered)
+	r := exemplar.FixedSize(2)(1)
+	for round := 0; round < rounds; round++ {
+		r.Reset()
+		for i := int64(0); i < offered; i++ {
+			r.Offer(newExemplar(i), 0)
+		}
+		for _, v := range values(r.Exemplars()) {
+			retained[v]++
+		}
+	}
+	for i, n := range retained {
+		// The expected count is rounds*2/offered = 400.
+		assert.InDelta(t, 400, n, 100, "exemplar %d", i)
+	}
+}
+
+func TestFixedSizeMerge(t *testing.T) {
+	strategy := exemplar.FixedSize(4)
+	a, b := strategy(1), strategy(1)
+	a.Offer(newExemplar(0), 0)
+	b.Offer(newExemplar(1), 0)
+	b.Offer(newExemplar(2), 0)
+	a.Merge(b)
+	assert.Equal(t, []int64{0, 1, 2}, values(a.Exemplars()))
+
+	c := strategy(1)
+	for i := int64(10); i < 20; i++ {
+		c.Offer(newExemplar(i), 0)
+	}
+	a.Merge(c)
+	require.Len(t, a.Exemplars(), 4)
+	seen := map[int64]bool{}
+	for _, v := range values(a.Exemplars()) {
+		assert.False(t, seen[v], "duplicate exemplar %d", v)
+		seen[v] = true
+		assert.True(t, v < 3 || (v >= 10 && v < 20))
+	}
+
+	// Merging an empty reservoir changes nothing.
+	before := values(a.Exemplars())
+	a.Merge(strategy(1))
+	assert.Equal(t, before, values(a.Exemplars()))
+}
+
+func TestAlignedHistogram(t *testing.T) {
+	r := exemplar.AlignedHistogram()(3)
+	r.Offer(newExemplar(1), 2)
+	r.Offer(newExemplar(2), 0)
+	r.Offer(newExemplar(0), 0)
+	r.Offer(newExemplar(3), 3)
+	r.Offer(newExemplar(4), -1)
+	// The buckets are in order, each with its latest exemplar.
+	assert.Equal(t, []int64{2, 1}, values(r.Exemplars()))
+
+	o := exemplar.AlignedHistogram()(3)
+	o.Offer(newExemplar(5), 1)
+	o.Offer(newExemplar(6), 2)
+	o.Offer(aggregation.Exemplar{}, 0)
+	r.Merge(o)
+	assert.Equal(t, []int64{2, 5, 6}, values(r.Exemplars()))
+
+	r.Reset()
+	assert.Empty(t, r.Exemplars())
+}
@@ -0,0 +1,425 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exponential // import "go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+)
+
+const (
+	// DefaultMaxSize is the default maximum number of buckets of each
+	// of the positive and negative ranges.
+	DefaultMaxSize = 160
+
+	// MinMaxSize is the smallest supported maximum number of buckets.
+	MinMaxSize = 2
+
+	// DefaultMaxScale is the default scale of an empty histogram, the
+	// scale decreases as values are aggregated until their range fits
+	// in the maximum number of buckets.
+	DefaultMaxScale = 20
+)
+
+type (
+	// Aggregator observes events and counts them in buckets whose
+	// boundaries are powers of 2^(2^-scale), decreasing the scale so that
+	// the range of the observed values fits in a maximum number of
+	// buckets. It also calculates the sum and count of all events.
+	Aggregator struct {
+		lock     sync.Mutex
+		maxSize  int32
+		maxScale int32
+		state    *state
+	}
+
+	// config describes how the histogram is aggregated.
+	config struct {
+		maxSize  int32
+		maxScale int32
+	}
+
+	// Option configures an exponential histogram config.
+	Option interface {
+		// apply sets one or more config fields.
+		apply(*config)
+	}
+
+	// state represents the state of an exponential histogram.
+	state struct {
+		sum       number.Number
+		count     uint64
+		zeroCount uint64
+		scale     int32
+		positive  buckets
+		negative  buckets
+	}
+
+	// buckets are the counts of consecutive buckets, starting with the
+	// bucket with index offset.
+	buckets struct {
+		offset int32
+		counts []uint64
+	}
+)
+
+// WithMaxSize sets the maximum number of buckets of each of the positive and
+// negative ranges. Sizes smaller than MinMaxSize are raised to MinMaxSize.
+func WithMaxSize(size int32) Option {
+	return maxSizeOption(size)
+}
+
+type maxSizeOption int32
+
+func (o maxSizeOption) apply(config *config) {
+	config.maxSize = int32(o)
+}
+
+// WithMaxScale sets the scale of an empty histogram, i.e. the highest
+// resolution it starts with. Scales above DefaultMaxScale are lowered to
+// DefaultMaxScale.
+func WithMaxScale(scale int32) Option {
+	return maxScaleOption(scale)
+}
+
+type maxScaleOption int32
+
+func (o maxScaleOption) apply(config *config) {
+	config.maxScale = int32(o)
+}
+
+var _ export.Aggregator = &Aggregator{}
+var _ aggregation.Sum = &Aggregator{}
+var _ aggregation.Count = &Aggregator{}
+var _ aggregation.ExponentialHistogram = &Aggregator{}
+
+// New returns a new aggregator for computing exponential histograms.
+//
+// Unlike the histogram aggregator, the bucket boundaries do not need to be
+// chosen ahead of time: the resolution automatically adapts to the range of
+// the observed values, making it suitable for high-dynamic-range
+// distributions such as latencies.
+func New(cnt int, desc *metric.Descriptor, opts ...Option) []Aggregator {
+	cfg := config{
+		maxSize:  DefaultMaxSize,
+		maxScale: DefaultMaxScale,
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	if cfg.maxSize < MinMaxSize {
+		cfg.maxSize = MinMaxSize
+	}
+	if cfg.maxScale > DefaultMaxScale {
+		cfg.maxScale = DefaultMaxScale
+	}
+
+	aggs := make([]Aggregator, cnt)
+	for i := range aggs {
+		aggs[i] = Aggregator{
+			maxSize:  cfg.maxSize,
+			maxScale: cfg.maxScale,
+			state:    &state{scale: cfg.maxScale},
+		}
+	}
+	return aggs
+}
+
+// Aggregation returns an interface for reading the state of this aggregator.
+func (c *Aggregator) Aggregation() aggregation.Aggregation {
+	return c
+}
+
+// Kind returns aggregation.ExponentialHistogramKind.
+func (c *Aggregator) Kind() aggregation.Kind {
+	return aggregation.ExponentialHistogramKind
+}
+
+// Sum returns the sum of all values in the checkpoint.
+func (c *Aggregator) Sum() (number.Number, error) {
+	return c.state.sum, nil
+}
+
+// Count returns the number of values in the checkpoint.
+func (c *Aggregator) Count() (uint64, error) {
+	return c.state.count, nil
+}
+
+// Scale returns the scale of the buckets in the checkpoint.
+func (c *Aggregator) Scale() (int32, error) {
+	return c.state.scale, nil
+}
+
+// ZeroCount returns the number of zero values in the checkpoint.
+func (c *Aggregator) ZeroCount() (uint64, error) {
+	return c.state.zeroCount, nil
+}
+
+// Positive returns the buckets of the positive values in the checkpoint.
+func (c *Aggregator) Positive() (aggregation.ExponentialBuckets, error) {
+	return c.state.positive.export(), nil
+}
+
+// Negative returns the buckets of the negative values in the checkpoint.
+func (c *Aggregator) Negative() (aggregation.ExponentialBuckets, error) {
+	return c.state.negative.export(), nil
+}
+
+// SynchronizedMove saves the current state into oa and resets the current
+// state to the empty set.
+func (c *Aggregator) SynchronizedMove(oa export.Aggregator, desc *metric.Descriptor) error {
+	o, _ := oa.(*Aggregator)
+
+	if oa != nil && o == nil {
+		return aggregator.NewInconsistentAggregatorError(c, oa)
+	}
+
+	if o != nil {
+		// Reset the target state before swapping it under the
+		// lock below.
+		o.clearState()
+	}
+
+	c.lock.Lock()
+	if o != nil {
+		c.state, o.state = o.state, c.state
+	} else {
+		c.clearState()
+	}
+	c.lock.Unlock()
+
+	return nil
+}
+
+func (c *Aggregator) clearState() {
+	c.state.sum = 0
+	c.state.count = 0
+	c.state.zeroCount = 0
+	c.state.scale = c.maxScale
+	c.state.positive.clear()
+	c.state.negative.clear()
+}
+
+// Update adds the recorded measurement to the current data set.
+func (c *Aggregator) Update(_ context.Context, number number.Number, desc *metric.Descriptor) error {
+	kind := desc.NumberKind()
+	value := number.CoerceToFloat64(kind)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.state.count++
+	c.state.sum.AddNumber(kind, number)
+
+	b := &c.state.positive
+	switch {
+	case value == 0:
+		c.state.zeroCount++
+		return nil
+	case value < 0:
+		b = &c.state.negative
+		value = -value
+	}
+
+	index := mapToIndex(value, c.state.scale)
+	if change := b.scaleChange(index, index, c.maxSize); change > 0 {
+		c.state.downscale(change)
+		index >>= change
+	}
+	b.add(index, 1)
+	return nil
+}
+
+// Merge combines the state of the exponential histogram oa into c,
+// lowering the scale of c if needed.
+func (c *Aggregator) Merge(oa export.Aggregator, desc *metric.Descriptor) error {
+	o, _ := oa.(*Aggregator)
+	if o == nil {
+		return aggregator.NewInconsistentAggregatorError(c, oa)
+	}
+
+	c.state.sum.AddNumber(desc.NumberKind(), o.state.sum)
+	c.state.count += o.state.count
+	c.state.zeroCount += o.state.zeroCount
+
+	// The merged histogram has the lowest of both scales, lowered
+	// further until the merged ranges fit.
+	scale := c.state.scale
+	if o.state.scale < scale {
+		scale = o.state.scale
+	}
+	for _, r := range [...][2]*buckets{
+		{&c.state.positive, &o.state.positive},
+		{&c.state.negative, &o.state.negative},
+	} {
+		cb, ob := r[0], r[1]
+		if len(ob.counts) == 0 {
+			continue
+		}
+		for {
+			oShift, cShift := o.state.scale-scale, c.state.scale-scale
+			low, high := ob.offset>>oShift, ob.high()>>oShift
+			if len(cb.counts) > 0 {
+				if l := cb.offset >> cShift; l < low {
+					low = l
+				}
+				if h := cb.high() >> cShift; h > high {
+					high = h
+				}
+			}
+			if high-low+1 <= c.maxSize {
+				break
+			}
+			scale--
+		}
+	}
+	c.state.downscale(c.state.scale - scale)
+
+	oShift := o.state.scale - scale
+	for _, r := range [...][2]*buckets{
+		{&c.state.positive, &o.state.positive},
+		{&c.state.negative, &o.state.negative},
+	} {
+		cb, ob := r[0], r[1]
+		for i, n := range ob.counts {
+			if n != 0 {
+				cb.add((ob.offset+int32(i))>>oShift, n)
+			}
+		}
+	}
+	return nil
+}
+
+// downscale lowers the scale of s by change, merging the buckets that
+// are combined at the new scale.
+func (s *state) downscale(change int32) {
+	s.positive.downscale(change)
+	s.negative.downscale(change)
+	s.scale -= change
+}
+
+// high returns the index of the last bucket, b must not be empty.
+func (b *buckets) high() int32 {
+	return b.offset + int32(len(b.counts)) - 1
+}
+
+// scaleChange returns by how much the scale must be lowered for b and the
+// buckets from low to high to fit in maxSize buckets.
+func (b *buckets) scaleChange(low, high, maxSize int32) int32 {
+	if len(b.counts) > 0 {
+		if b.offset < low {
+			low = b.offset
+		}
+		if h := b.high(); h > high {
+			high = h
+		}
+	}
+	var change int32
+	for (high>>change)-(low>>change)+1 > maxSize {
+		change++
+	}
+	return change
+}
+
+// add adds n to the count of the bucket with index, growing b as needed.
+func (b *buckets) add(index int32, n uint64) {
+	switch {
+	case len(b.counts) == 0:
+		b.offset = index
+		b.counts = append(b.counts[:0], n)
+		return
+	case index < b.offset:
+		grown := make([]uint64, int(b.high()-index)+1)
+		copy(grown[b.offset-index:], b.counts)
+		b.counts = grown
+		b.offset = index
+	case index > b.high():
+		for i := b.high(); i < index; i++ {
+			b.counts = append(b.counts, 0)
+		}
+	}
+	b.counts[index-b.offset] += n
+}
+
+// downscale merges the buckets of b that are combined when the scale is
+// lowered by change.
+func (b *buckets) downscale(change int32) {
+	if len(b.counts) == 0 || change == 0 {
+		return
+	}
+	offset := b.offset >> change
+	size := (b.high() >> change) - offset + 1
+	// The buckets are merged in place: the position of a merged bucket
+	// is never after the positions of the buckets merged into it, and
+	// the buckets before i have already been moved.
+	for i := range b.counts {
+		j := ((b.offset + int32(i)) >> change) - offset
+		if j != int32(i) {
+			b.counts[j] += b.counts[i]
+			b.counts[i] = 0
+		}
+	}
+	b.counts = b.counts[:size]
+	b.offset = offset
+}
+
+func (b *buckets) clear() {
+	b.offset = 0
+	b.counts = b.counts[:0]
+}
+
+func (b *buckets) export() aggregation.ExponentialBuckets {
+	return aggregation.ExponentialBuckets{
+		Offset: b.offset,
+		Counts: b.counts,
+	}
+}
+
+// scaleFactors are the factors converting the natural logarithm of a value
+// to its bucket index for each positive scale: 2^scale / ln(2).
+var scaleFactors = func() (factors [DefaultMaxScale + 1]float64) {
+	for i := range factors {
+		factors[i] = math.Ldexp(math.Log2E, i)
+	}
+	return
+}()
+
+// mapToIndex returns the index of the bucket of the positive value at
+// scale, the bucket (base^index, base^(index+1)] with base = 2^(2^-scale).
+func mapToIndex(value float64, scale int32) int32 {
+	frac, exp := math.Frexp(value)
+	if scale <= 0 {
+		// value is in (2^(exp-1), 2^exp], exact powers of two
+		// (frac == 0.5) are the upper boundary of their bucket.
+		index := exp - 1
+		if frac == 0.5 {
+			index--
+		}
+		return int32(index >> uint(-scale))
+	}
+	if frac == 0.5 {
+		// Exact powers of two are computed exactly to avoid
+		// rounding errors of the logarithm.
+		return int32((exp-1)<<uint(scale)) - 1
+	}
+	return int32(math.Ceil(math.Log(value)*scaleFactors[scale])) - 1
+}
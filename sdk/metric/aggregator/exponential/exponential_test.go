@@ -0,0 +1,259 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exponential
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
+)
+
+func TestMapToIndex(t *testing.T) {
+	for _, tc := range []struct {
+		value float64
+		scale int32
+		want  int32
+	}{
+		// Exact powers of two are the upper boundary of their bucket.
+		{1, 0, -1},
+		{2, 0, 0},
+		{3, 0, 1},
+		{4, 0, 1},
+		{0.5, 0, -2},
+		{0.75, 0, -1},
+		{2, 1, 1},
+		{1.5, 1, 1},
+		{1.4, 1, 0},
+		{4, 3, 15},
+		{4, -1, 0},
+		{5, -1, 1},
+		{1, -1, -1},
+		{16, -1, 1},
+		{17, -1, 2},
+		{math.MaxFloat64, 0, 1023},
+		{math.SmallestNonzeroFloat64, 0, -1075},
+	} {
+		assert.Equal(t, tc.want, mapToIndex(tc.value, tc.scale), "value %v at scale %d", tc.value, tc.scale)
+	}
+}
+
+func TestMapToIndexBoundaries(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for scale := int32(-4); scale <= DefaultMaxScale; scale++ {
+		width := math.Exp2(-float64(scale))
+		for i := 0; i < 1000; i++ {
+			value := math.Exp(r.NormFloat64() * 20)
+			index := mapToIndex(value, scale)
+			lower := math.Exp2(float64(index) * width)
+			upper := math.Exp2(float64(index+1) * width)
+			// Allow the rounding error of the logarithm.
+			assert.True(t, lower < value*(1+1e-12) && value <= upper*(1+1e-12),
+				"value %v at scale %d in bucket %d (%v, %v]", value, scale, index, lower, upper)
+		}
+	}
+}
+
+func newAggregator(nkind number.Kind, opts ...Option) (*Aggregator, *metric.Descriptor) {
+	desc := metric.NewDescriptor("histogram", metric.ValueRecorderInstrumentKind, nkind)
+	return &New(1, &desc, opts...)[0], &desc
+}
+
+func update(t *testing.T, agg *Aggregator, desc *metric.Descriptor, values ...float64) {
+	for _, v := range values {
+		require.NoError(t, agg.Update(context.Background(), number.NewFloat64Number(v), desc))
+	}
+}
+
+// expectedCounts returns the counts of the buckets of values at scale.
+func expectedCounts(values []float64, scale int32) map[int32]uint64 {
+	counts := map[int32]uint64{}
+	for _, v := range values {
+		counts[mapToIndex(v, scale)]++
+	}
+	return counts
+}
+
+func countsOf(b buckets) map[int32]uint64 {
+	counts := map[int32]uint64{}
+	for i, n := range b.counts {
+		if n != 0 {
+			counts[b.offset+int32(i)] = n
+		}
+	}
+	return counts
+}
+
+func TestAggregatorUpdate(t *testing.T) {
+	agg, desc := newAggregator(number.Float64Kind, WithMaxSize(20))
+
+	var positive, negative []float64
+	var sum float64
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 1000; i++ {
+		v := math.Exp(r.Float64() * 14)
+		if i%3 == 0 {
+			negative = append(negative, v)
+			v = -v
+		} else {
+			positive = append(positive, v)
+		}
+		sum += v
+		update(t, agg, desc, v)
+	}
+	update(t, agg, desc, 0, 0)
+
+	scale, err := agg.Scale()
+	require.NoError(t, err)
+	assert.Less(t, scale, int32(DefaultMaxScale))
+	// The scale is the highest at which the values fit.
+	for _, values := range [][]float64{positive, negative} {
+		assert.LessOrEqual(t, len(expectedCounts(values, scale)), 20)
+	}
+	wider := len(expectedCounts(positive, scale+1))
+	if n := len(expectedCounts(negative, scale+1)); n > wider {
+		wider = n
+	}
+	assert.Greater(t, wider, 0)
+
+	pos, err := agg.Positive()
+	require.NoError(t, err)
+	neg, err := agg.Negative()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(pos.Counts), 20)
+	assert.LessOrEqual(t, len(neg.Counts), 20)
+	assert.Equal(t, expectedCounts(positive, scale), countsOf(agg.state.positive))
+	assert.Equal(t, expectedCounts(negative, scale), countsOf(agg.state.negative))
+
+	zeros, err := agg.ZeroCount()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), zeros)
+	count, err := agg.Count()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1002), count)
+	s, err := agg.Sum()
+	require.NoError(t, err)
+	assert.InDelta(t, sum, s.AsFloat64(), 1e-6*math.Abs(sum))
+}
+
+func TestAggregatorSingleValue(t *testing.T) {
+	agg, desc := newAggregator(number.Int64Kind)
+	require.NoError(t, agg.Update(context.Background(), number.NewInt64Number(3), desc))
+
+	scale, _ := agg.Scale()
+	assert.Equal(t, int32(DefaultMaxScale), scale)
+	pos, _ := agg.Positive()
+	assert.Equal(t, []uint64{1}, pos.Counts)
+	assert.Equal(t, mapToIndex(3, DefaultMaxScale), pos.Offset)
+}
+
+func TestAggregatorMerge(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	var all, first, second []float64
+	for i := 0; i < 500; i++ {
+		// The two aggregators see ranges of different widths.
+		v1 := math.Exp(r.Float64() * 3)
+		v2 := -math.Exp(r.Float64()*10 - 5)
+		v3 := math.Exp(r.Float64()*10 + 3)
+		first = append(first, v1, v2)
+		second = append(second, v3)
+		all = append(all, v1, v2, v3)
+	}
+
+	a1, desc := newAggregator(number.Float64Kind, WithMaxSize(32))
+	a2, _ := newAggregator(number.Float64Kind, WithMaxSize(32))
+	direct, _ := newAggregator(number.Float64Kind, WithMaxSize(32))
+	update(t, a1, desc, first...)
+	update(t, a2, desc, second...)
+	update(t, direct, desc, all...)
+
+	require.NoError(t, a1.Merge(a2, desc))
+	assert.Equal(t, direct.state.scale, a1.state.scale)
+	assert.Equal(t, countsOf(direct.state.positive), countsOf(a1.state.positive))
+	assert.Equal(t, countsOf(direct.state.negative), countsOf(a1.state.negative))
+	assert.Equal(t, direct.state.count, a1.state.count)
+	assert.InDelta(t, direct.state.sum.AsFloat64(), a1.state.sum.AsFloat64(), 1e-6)
+}
+
+func TestAggregatorMergeEmpty(t *testing.T) {
+	a1, desc := newAggregator(number.Float64Kind)
+	a2, _ := newAggregator(number.Float64Kind)
+	update(t, a2, desc, 1, 2, 3)
+
+	require.NoError(t, a1.Merge(a2, desc))
+	assert.Equal(t, countsOf(a2.state.positive), countsOf(a1.state.positive))
+
+	empty, _ := newAggregator(number.Float64Kind)
+	require.NoError(t, a1.Merge(empty, desc))
+	assert.Equal(t, countsOf(a2.state.positive), countsOf(a1.state.positive))
+	assert.Equal(t, uint64(3), a1.state.count)
+}
+
+func TestAggregatorDownscale(t *testing.T) {
+	b := buckets{offset: -3, counts: []uint64{1, 2, 3, 4, 5, 6}}
+	b.downscale(1)
+	// Indexes -3..2 become -2, -1, -1, 0, 0, 1.
+	assert.Equal(t, int32(-2), b.offset)
+	assert.Equal(t, []uint64{1, 5, 9, 6}, b.counts)
+
+	b.downscale(2)
+	// Indexes -2..1 become -1, -1, 0, 0.
+	assert.Equal(t, int32(-1), b.offset)
+	assert.Equal(t, []uint64{6, 15}, b.counts)
+}
+
+func TestAggregatorMinMaxSize(t *testing.T) {
+	agg, desc := newAggregator(number.Float64Kind, WithMaxSize(0))
+	update(t, agg, desc, math.SmallestNonzeroFloat64, 1, math.MaxFloat64)
+
+	pos, _ := agg.Positive()
+	assert.LessOrEqual(t, len(pos.Counts), MinMaxSize)
+	var total uint64
+	for _, n := range pos.Counts {
+		total += n
+	}
+	assert.Equal(t, uint64(3), total)
+}
+
+func TestSynchronizedMoveReset(t *testing.T) {
+	aggregatortest.SynchronizedMoveResetTest(
+		t,
+		metric.ValueRecorderInstrumentKind,
+		func(desc *metric.Descriptor) export.Aggregator {
+			return &New(1, desc)[0]
+		},
+	)
+}
+
+func TestSynchronizedMoveSwapsState(t *testing.T) {
+	agg, desc := newAggregator(number.Float64Kind)
+	ckpt, _ := newAggregator(number.Float64Kind)
+	update(t, agg, desc, 1, 1000, -5)
+
+	require.NoError(t, agg.SynchronizedMove(ckpt, desc))
+	assert.Equal(t, uint64(3), ckpt.state.count)
+	assert.Equal(t, uint64(0), agg.state.count)
+	assert.Equal(t, int32(DefaultMaxScale), agg.state.scale)
+	assert.Empty(t, agg.state.positive.counts)
+	assert.Empty(t, agg.state.negative.counts)
+}
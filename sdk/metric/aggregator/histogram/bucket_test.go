@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package histogram
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// linearBucketFor is the linear search bucketFor replaced, kept to
+// compare them.
+func linearBucketFor(boundaries []float64, value float64) int {
+	for i, boundary := range boundaries {
+		if value < boundary {
+			return i
+		}
+	}
+	return len(boundaries)
+}
+
+func TestBucketForMatchesLinearSearch(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 7, 64} {
+		boundaries := make([]float64, size)
+		for i := range boundaries {
+			boundaries[i] = float64(i * 10)
+		}
+		c := &Aggregator{boundaries: boundaries}
+		for v := -5.0; v < float64(size*10+5); v += 2.5 {
+			if got, want := c.bucketFor(v), linearBucketFor(boundaries, v); got != want {
+				t.Errorf("size %d, value %v: bucketFor = %d, want %d", size, v, got, want)
+			}
+		}
+	}
+}
+
+// BenchmarkBucketFor compares the binary search of bucketFor with a linear
+// search, for increasing boundary set sizes.
+func BenchmarkBucketFor(b *testing.B) {
+	const inputRange = 1e6
+	for _, size := range []int{1, 8, 16, 32, 64, 128, 256, 512, 1024} {
+		boundaries := make([]float64, size)
+		for i := range boundaries {
+			boundaries[i] = rand.Float64() * inputRange
+		}
+		sort.Float64s(boundaries)
+		values := make([]float64, 1024)
+		for i := range values {
+			values[i] = rand.Float64() * inputRange
+		}
+		c := &Aggregator{boundaries: boundaries}
+
+		b.Run(fmt.Sprintf("Binary/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = c.bucketFor(values[i%len(values)])
+			}
+		})
+		b.Run(fmt.Sprintf("Linear/%d", size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_ = linearBucketFor(boundaries, values[i%len(values)])
+			}
+		})
+	}
+}
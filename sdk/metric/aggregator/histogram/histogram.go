@@ -16,14 +16,18 @@ package histogram // import "go.opentelemetry.io/otel/sdk/metric/aggregator/hist
 
 import (
 	"context"
+	"os"
 	"sort"
 	"sync"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/number"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/internal/env"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exemplar"
 )
 
 // Note: This code uses a Mutex to govern access to the exclusive
@@ -38,6 +42,7 @@ type (
 		lock       sync.Mutex
 		boundaries []float64
 		kind       number.Kind
+		exemplars  exemplar.Strategy
 		state      *state
 	}
 
@@ -46,6 +51,16 @@ type (
 		// explicitBoundaries support arbitrary bucketing schemes.  This
 		// is the general case.
 		explicitBoundaries []float64
+		// hasExplicitBoundaries is true if explicitBoundaries is set.
+		hasExplicitBoundaries bool
+
+		// defaultBoundaries replace the default boundaries of
+		// histograms that are not advised any, if not nil.
+		defaultBoundaries []float64
+
+		// exemplars creates the Reservoirs of exemplars, exemplars
+		// are disabled if nil.
+		exemplars exemplar.Strategy
 	}
 
 	// Option configures a histogram config.
@@ -61,6 +76,10 @@ type (
 		bucketCounts []uint64
 		sum          number.Number
 		count        uint64
+
+		// exemplars retains measurements made in the context of a
+		// sampled span, it is nil if exemplars are disabled.
+		exemplars exemplar.Reservoir
 	}
 )
 
@@ -75,6 +94,38 @@ type explicitBoundariesOption struct {
 
 func (o explicitBoundariesOption) apply(config *config) {
 	config.explicitBoundaries = o.boundaries
+	config.hasExplicitBoundaries = true
+}
+
+// WithDefaultBoundaries replaces the default boundaries of both int64 and
+// float64 histograms.  Unlike WithExplicitBoundaries, they are only used
+// when the instrument does not advise boundaries.  They take precedence
+// over the EnvDefaultBoundaries environment variable.
+func WithDefaultBoundaries(defaultBoundaries []float64) Option {
+	return defaultBoundariesOption{defaultBoundaries}
+}
+
+type defaultBoundariesOption struct {
+	boundaries []float64
+}
+
+func (o defaultBoundariesOption) apply(config *config) {
+	config.defaultBoundaries = o.boundaries
+}
+
+// WithExemplarStrategy sets the Strategy of the Reservoirs retaining the
+// exemplars of the histogram, nil disables exemplars.  By default the
+// latest exemplar of each bucket is retained, see exemplar.AlignedHistogram.
+func WithExemplarStrategy(strategy exemplar.Strategy) Option {
+	return exemplarStrategyOption{strategy}
+}
+
+type exemplarStrategyOption struct {
+	strategy exemplar.Strategy
+}
+
+func (o exemplarStrategyOption) apply(config *config) {
+	config.exemplars = o.strategy
 }
 
 // defaultExplicitBoundaries have been copied from prometheus.DefBuckets.
@@ -97,32 +148,82 @@ var defaultInt64ExplicitBoundaries = func(bounds []float64) (asint []float64) {
 	return
 }(defaultFloat64ExplicitBoundaries)
 
+// EnvDefaultBoundaries is the environment variable replacing the default
+// boundaries of both int64 and float64 histograms, as a comma-separated
+// list of numbers, e.g. "0.1,0.5,1,5".
+const EnvDefaultBoundaries = "OTEL_METRIC_HISTOGRAM_BOUNDARIES"
+
+// envBoundaries caches the boundaries parsed from the value of the
+// EnvDefaultBoundaries environment variable, so that an invalid value is
+// only reported once.
+var envBoundaries struct {
+	sync.Mutex
+	value      string
+	boundaries []float64
+}
+
+// defaultBoundaries returns the default boundaries of the histograms of
+// kind, set by the EnvDefaultBoundaries environment variable if valid.
+func defaultBoundaries(kind number.Kind) []float64 {
+	if b := boundariesFromEnv(); b != nil {
+		return b
+	}
+	if kind == number.Int64Kind {
+		return defaultInt64ExplicitBoundaries
+	}
+	return defaultFloat64ExplicitBoundaries
+}
+
+// boundariesFromEnv returns the boundaries of the EnvDefaultBoundaries
+// environment variable, or nil if it is unset or invalid.
+func boundariesFromEnv() []float64 {
+	v := os.Getenv(EnvDefaultBoundaries)
+
+	envBoundaries.Lock()
+	defer envBoundaries.Unlock()
+	if v != envBoundaries.value {
+		envBoundaries.value = v
+		envBoundaries.boundaries = env.Float64s(otel.MetricsSignal, EnvDefaultBoundaries, nil)
+	}
+	return envBoundaries.boundaries
+}
+
 var _ export.Aggregator = &Aggregator{}
 var _ aggregation.Sum = &Aggregator{}
 var _ aggregation.Count = &Aggregator{}
 var _ aggregation.Histogram = &Aggregator{}
+var _ aggregation.Exemplars = &Aggregator{}
 
 // New returns a new aggregator for computing Histograms.
 //
 // A Histogram observe events and counts them in pre-defined buckets.
 // And also provides the total sum and count of all observations.
 //
+// The boundaries of the buckets are, in order of precedence, the ones of
+// the WithExplicitBoundaries option, the ones advised by the instrument
+// (see metric.WithHistogramBoundaries), or the defaults, which can be
+// replaced with the WithDefaultBoundaries option or, if it is not used,
+// the EnvDefaultBoundaries environment variable.
+//
 // Note that this aggregator maintains each value using independent
 // atomic operations, which introduces the possibility that
 // checkpoints are inconsistent.
 func New(cnt int, desc *metric.Descriptor, opts ...Option) []Aggregator {
-	var cfg config
-
-	if desc.NumberKind() == number.Int64Kind {
-		cfg.explicitBoundaries = defaultInt64ExplicitBoundaries
-	} else {
-		cfg.explicitBoundaries = defaultFloat64ExplicitBoundaries
-	}
-
+	cfg := config{exemplars: exemplar.AlignedHistogram()}
 	for _, opt := range opts {
 		opt.apply(&cfg)
 	}
 
+	if !cfg.hasExplicitBoundaries {
+		if advice := desc.HistogramBoundaries(); len(advice) > 0 {
+			cfg.explicitBoundaries = advice
+		} else if cfg.defaultBoundaries != nil {
+			cfg.explicitBoundaries = cfg.defaultBoundaries
+		} else {
+			cfg.explicitBoundaries = defaultBoundaries(desc.NumberKind())
+		}
+	}
+
 	aggs := make([]Aggregator, cnt)
 
 	// Boundaries MUST be ordered otherwise the histogram could not
@@ -136,6 +237,7 @@ func New(cnt int, desc *metric.Descriptor, opts ...Option) []Aggregator {
 		aggs[i] = Aggregator{
 			kind:       desc.NumberKind(),
 			boundaries: sortedBoundaries,
+			exemplars:  cfg.exemplars,
 		}
 		aggs[i].state = aggs[i].newState()
 	}
@@ -170,6 +272,16 @@ func (c *Aggregator) Histogram() (aggregation.Buckets, error) {
 	}, nil
 }
 
+// Exemplars returns the measurements made in the context of a sampled
+// span retained in the checkpoint.  With the default exemplar.Strategy
+// the exemplars are returned in bucket order.
+func (c *Aggregator) Exemplars() ([]aggregation.Exemplar, error) {
+	if c.state.exemplars == nil {
+		return nil, nil
+	}
+	return c.state.exemplars.Exemplars(), nil
+}
+
 // SynchronizedMove saves the current state into oa and resets the current state to
 // the empty set.  Since no locks are taken, there is a chance that
 // the independent Sum, Count and Bucket Count are not consistent with each
@@ -206,9 +318,13 @@ func (c *Aggregator) SynchronizedMove(oa export.Aggregator, desc *metric.Descrip
 }
 
 func (c *Aggregator) newState() *state {
-	return &state{
+	s := &state{
 		bucketCounts: make([]uint64, len(c.boundaries)+1),
 	}
+	if c.exemplars != nil {
+		s.exemplars = c.exemplars(len(s.bucketCounts))
+	}
+	return s
 }
 
 func (c *Aggregator) clearState() {
@@ -217,42 +333,66 @@ func (c *Aggregator) clearState() {
 	}
 	c.state.sum = 0
 	c.state.count = 0
+	if c.state.exemplars != nil {
+		c.state.exemplars.Reset()
+	}
 }
 
-// Update adds the recorded measurement to the current data set.
-func (c *Aggregator) Update(_ context.Context, number number.Number, desc *metric.Descriptor) error {
+// Update adds the recorded measurement to the current data set. If the
+// context contains a sampled span the measurement is offered as an exemplar
+// of its bucket.
+func (c *Aggregator) Update(ctx context.Context, number number.Number, desc *metric.Descriptor) error {
 	kind := desc.NumberKind()
 	asFloat := number.CoerceToFloat64(kind)
 
-	bucketID := len(c.boundaries)
-	for i, boundary := range c.boundaries {
-		if asFloat < boundary {
-			bucketID = i
-			break
-		}
-	}
-	// Note: Binary-search was compared using the benchmarks. The following
-	// code is equivalent to the linear search above:
-	//
-	//     bucketID := sort.Search(len(c.boundaries), func(i int) bool {
-	//         return asFloat < c.boundaries[i]
-	//     })
-	//
-	// The binary search wins for very large boundary sets, but
-	// the linear search performs better up through arrays between
-	// 256 and 512 elements, which is a relatively large histogram, so we
-	// continue to prefer linear search.
+	bucketID := c.bucketFor(asFloat)
 
-	c.lock.Lock()
-	defer c.lock.Unlock()
+	ex, sampled := aggregator.NewExemplar(ctx, number)
 
+	c.lock.Lock()
 	c.state.count++
 	c.state.sum.AddNumber(kind, number)
 	c.state.bucketCounts[bucketID]++
+	if sampled && c.state.exemplars != nil {
+		c.state.exemplars.Offer(ex, bucketID)
+	}
+	c.lock.Unlock()
 
 	return nil
 }
 
+// bucketFor returns the index of the bucket containing value, the index of
+// the first boundary greater than value or len(c.boundaries) if there is
+// none.
+//
+// This is equivalent to
+//
+//     sort.Search(len(c.boundaries), func(i int) bool {
+//         return value < c.boundaries[i]
+//     })
+//
+// but avoids the indirect call made for every step of the search.
+//
+// A linear search used to be preferred: compared using the benchmarks,
+// sort.Search only won for very large boundary sets, the linear search
+// performing better up through arrays between 256 and 512 elements,
+// because of the cost of the indirect calls. Without them, BenchmarkBucketFor
+// measured the binary search within about a nanosecond of the linear search
+// for 1 to 16 boundaries (slower for a single boundary), then twice as fast
+// for 32 boundaries and 6 times as fast for 256 or more.
+func (c *Aggregator) bucketFor(value float64) int {
+	lo, hi := 0, len(c.boundaries)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if value < c.boundaries[mid] {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
 // Merge combines two histograms that have the same buckets into a single one.
 func (c *Aggregator) Merge(oa export.Aggregator, desc *metric.Descriptor) error {
 	o, _ := oa.(*Aggregator)
@@ -266,5 +406,9 @@ func (c *Aggregator) Merge(oa export.Aggregator, desc *metric.Descriptor) error
 	for i := 0; i < len(c.state.bucketCounts); i++ {
 		c.state.bucketCounts[i] += o.state.bucketCounts[i]
 	}
+
+	if c.state.exemplars != nil && o.state.exemplars != nil {
+		c.state.exemplars.Merge(o.state.exemplars)
+	}
 	return nil
 }
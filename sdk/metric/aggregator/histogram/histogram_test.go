@@ -23,11 +23,14 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/number"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exemplar"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const count = 100
@@ -294,3 +297,164 @@ func TestHistogramDefaultBoundaries(t *testing.T) {
 		require.EqualValues(t, expect, bucks.Counts)
 	})
 }
+
+func TestHistogramAdvisedBoundaries(t *testing.T) {
+	advised := []float64{1, 10, 100}
+	desc := metric.NewDescriptor("advised", metric.ValueRecorderInstrumentKind, number.Float64Kind,
+		metric.WithHistogramBoundaries(10, 1, 100))
+
+	agg := &histogram.New(1, &desc)[0]
+	bucks, err := agg.Histogram()
+	require.NoError(t, err)
+	require.Equal(t, advised, bucks.Boundaries)
+
+	// Explicit boundaries take precedence.
+	agg = &histogram.New(1, &desc, histogram.WithExplicitBoundaries([]float64{5}))[0]
+	bucks, err = agg.Histogram()
+	require.NoError(t, err)
+	require.Equal(t, []float64{5}, bucks.Boundaries)
+}
+
+func TestHistogramWithDefaultBoundaries(t *testing.T) {
+	opt := histogram.WithDefaultBoundaries([]float64{2, 0.5, 1})
+	for _, kind := range []number.Kind{number.Int64Kind, number.Float64Kind} {
+		desc := metric.NewDescriptor("default", metric.ValueRecorderInstrumentKind, kind)
+		agg := &histogram.New(1, &desc, opt)[0]
+		bucks, err := agg.Histogram()
+		require.NoError(t, err)
+		require.Equal(t, []float64{0.5, 1, 2}, bucks.Boundaries)
+	}
+
+	// Advised boundaries take precedence.
+	desc := metric.NewDescriptor("advised", metric.ValueRecorderInstrumentKind, number.Float64Kind,
+		metric.WithHistogramBoundaries(3))
+	agg := &histogram.New(1, &desc, opt)[0]
+	bucks, err := agg.Histogram()
+	require.NoError(t, err)
+	require.Equal(t, []float64{3}, bucks.Boundaries)
+}
+
+func TestHistogramEnvDefaultBoundaries(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		histogram.EnvDefaultBoundaries: "2, 0.5,1",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	for _, kind := range []number.Kind{number.Int64Kind, number.Float64Kind} {
+		desc := metric.NewDescriptor("env", metric.ValueRecorderInstrumentKind, kind)
+		agg := &histogram.New(1, &desc)[0]
+		bucks, err := agg.Histogram()
+		require.NoError(t, err)
+		require.Equal(t, []float64{0.5, 1, 2}, bucks.Boundaries)
+	}
+
+	// Advised boundaries and the WithDefaultBoundaries option take
+	// precedence.
+	desc := metric.NewDescriptor("advised", metric.ValueRecorderInstrumentKind, number.Float64Kind,
+		metric.WithHistogramBoundaries(3))
+	agg := &histogram.New(1, &desc)[0]
+	bucks, err := agg.Histogram()
+	require.NoError(t, err)
+	require.Equal(t, []float64{3}, bucks.Boundaries)
+
+	desc = metric.NewDescriptor("default", metric.ValueRecorderInstrumentKind, number.Float64Kind)
+	agg = &histogram.New(1, &desc, histogram.WithDefaultBoundaries([]float64{4}))[0]
+	bucks, err = agg.Histogram()
+	require.NoError(t, err)
+	require.Equal(t, []float64{4}, bucks.Boundaries)
+}
+
+func TestHistogramInvalidEnvDefaultBoundaries(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		histogram.EnvDefaultBoundaries: "1,x",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	desc := metric.NewDescriptor("env", metric.ValueRecorderInstrumentKind, number.Float64Kind)
+	agg := &histogram.New(1, &desc)[0]
+	bucks, err := agg.Histogram()
+	require.NoError(t, err)
+	require.Len(t, bucks.Boundaries, 11)
+}
+
+func TestHistogramExemplars(t *testing.T) {
+	descriptor := aggregatortest.NewAggregatorTest(metric.ValueRecorderInstrumentKind, number.Float64Kind)
+	agg, ckpt, other, merged := new4(descriptor, histogram.WithExplicitBoundaries(testBoundaries))
+
+	ctxFor := func(b byte) context.Context {
+		return trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{b},
+			SpanID:     trace.SpanID{b},
+			TraceFlags: trace.FlagsSampled,
+		}))
+	}
+
+	require.NoError(t, agg.Update(ctxFor(1), number.NewFloat64Number(100), descriptor))
+	require.NoError(t, agg.Update(ctxFor(2), number.NewFloat64Number(200), descriptor))
+	require.NoError(t, agg.Update(context.Background(), number.NewFloat64Number(300), descriptor))
+	require.NoError(t, agg.Update(ctxFor(3), number.NewFloat64Number(1000), descriptor))
+	require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+
+	exemplars, err := ckpt.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 2)
+	require.Equal(t, number.NewFloat64Number(200), exemplars[0].Value, "latest exemplar of the first bucket")
+	require.Equal(t, trace.TraceID{2}, exemplars[0].SpanContext.TraceID())
+	require.Equal(t, number.NewFloat64Number(1000), exemplars[1].Value)
+
+	exemplars, err = agg.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 0, "exemplars not reset by SynchronizedMove")
+
+	require.NoError(t, other.Update(ctxFor(4), number.NewFloat64Number(600), descriptor))
+	require.NoError(t, other.SynchronizedMove(merged, descriptor))
+	aggregatortest.CheckedMerge(t, ckpt, merged, descriptor)
+
+	exemplars, err = ckpt.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 3)
+	require.Equal(t, number.NewFloat64Number(600), exemplars[1].Value)
+}
+
+func TestHistogramExemplarStrategy(t *testing.T) {
+	descriptor := aggregatortest.NewAggregatorTest(metric.ValueRecorderInstrumentKind, number.Float64Kind)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	agg, ckpt := new2(descriptor, histogram.WithExemplarStrategy(nil))
+	require.NoError(t, agg.Update(ctx, number.NewFloat64Number(100), descriptor))
+	require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+	exemplars, err := ckpt.Exemplars()
+	require.NoError(t, err)
+	require.Empty(t, exemplars, "exemplars disabled")
+
+	agg, ckpt = new2(descriptor, histogram.WithExemplarStrategy(exemplar.FixedSize(2)))
+	for _, v := range []float64{1, 100, 200, 1000} {
+		require.NoError(t, agg.Update(ctx, number.NewFloat64Number(v), descriptor))
+	}
+	require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+	exemplars, err = ckpt.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 2, "fixed size exemplars regardless of buckets")
+}
+
+func TestHistogramBucketFor(t *testing.T) {
+	boundaries := []float64{1, 2, 5, 10}
+	descriptor := aggregatortest.NewAggregatorTest(metric.ValueRecorderInstrumentKind, number.Float64Kind)
+	agg := &histogram.New(1, descriptor, histogram.WithExplicitBoundaries(boundaries))[0]
+	ckpt := &histogram.New(1, descriptor, histogram.WithExplicitBoundaries(boundaries))[0]
+
+	for _, v := range []float64{-1, 0.5, 1, 1.5, 2, 4.9, 5, 9.99, 10, 100} {
+		aggregatortest.CheckedUpdate(t, agg, number.NewFloat64Number(v), descriptor)
+	}
+	require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+
+	buckets, err := ckpt.Histogram()
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2, 2, 2, 2, 2}, buckets.Counts)
+}
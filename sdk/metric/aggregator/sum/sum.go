@@ -16,12 +16,14 @@ package sum // import "go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
 
 import (
 	"context"
+	"sync"
 
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/number"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exemplar"
 )
 
 // Aggregator aggregates counter events.
@@ -29,17 +31,60 @@ type Aggregator struct {
 	// current holds current increments to this counter record
 	// current needs to be aligned for 64-bit atomic operations.
 	value number.Number
+
+	// lock protects exemplars.
+	lock sync.Mutex
+	// exemplars retains measurements made in the context of a
+	// sampled span, it is nil if exemplars are disabled.
+	exemplars exemplar.Reservoir
+}
+
+// config describes how the sum is aggregated.
+type config struct {
+	exemplars exemplar.Strategy
+}
+
+// Option configures a sum config.
+type Option interface {
+	// apply sets one or more config fields.
+	apply(*config)
+}
+
+// WithExemplarStrategy sets the Strategy of the Reservoirs retaining the
+// exemplars of the sum, nil disables exemplars.  By default the latest
+// exemplar is retained, as by exemplar.AlignedHistogram.
+func WithExemplarStrategy(strategy exemplar.Strategy) Option {
+	return exemplarStrategyOption{strategy}
+}
+
+type exemplarStrategyOption struct {
+	strategy exemplar.Strategy
+}
+
+func (o exemplarStrategyOption) apply(config *config) {
+	config.exemplars = o.strategy
 }
 
 var _ export.Aggregator = &Aggregator{}
 var _ export.Subtractor = &Aggregator{}
 var _ aggregation.Sum = &Aggregator{}
+var _ aggregation.Exemplars = &Aggregator{}
 
 // New returns a new counter aggregator implemented by atomic
 // operations.  This aggregator implements the aggregation.Sum
 // export interface.
-func New(cnt int) []Aggregator {
-	return make([]Aggregator, cnt)
+func New(cnt int, opts ...Option) []Aggregator {
+	cfg := config{exemplars: exemplar.AlignedHistogram()}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	aggs := make([]Aggregator, cnt)
+	if cfg.exemplars != nil {
+		for i := range aggs {
+			aggs[i].exemplars = cfg.exemplars(1)
+		}
+	}
+	return aggs
 }
 
 // Aggregation returns an interface for reading the state of this aggregator.
@@ -58,11 +103,25 @@ func (c *Aggregator) Sum() (number.Number, error) {
 	return c.value, nil
 }
 
+// Exemplars returns the measurements made in the context of a sampled
+// span retained from the last checkpoint.
+func (c *Aggregator) Exemplars() ([]aggregation.Exemplar, error) {
+	if c.exemplars == nil {
+		return nil, nil
+	}
+	return c.exemplars.Exemplars(), nil
+}
+
 // SynchronizedMove atomically saves the current value into oa and resets the
 // current sum to zero.
 func (c *Aggregator) SynchronizedMove(oa export.Aggregator, _ *metric.Descriptor) error {
 	if oa == nil {
 		c.value.SetRawAtomic(0)
+		c.lock.Lock()
+		if c.exemplars != nil {
+			c.exemplars.Reset()
+		}
+		c.lock.Unlock()
 		return nil
 	}
 	o, _ := oa.(*Aggregator)
@@ -70,12 +129,26 @@ func (c *Aggregator) SynchronizedMove(oa export.Aggregator, _ *metric.Descriptor
 		return aggregator.NewInconsistentAggregatorError(c, oa)
 	}
 	o.value = c.value.SwapNumberAtomic(number.Number(0))
+	if o.exemplars != nil {
+		o.exemplars.Reset()
+	}
+	c.lock.Lock()
+	o.exemplars, c.exemplars = c.exemplars, o.exemplars
+	c.lock.Unlock()
 	return nil
 }
 
-// Update atomically adds to the current value.
-func (c *Aggregator) Update(_ context.Context, num number.Number, desc *metric.Descriptor) error {
+// Update atomically adds to the current value. If the context contains a
+// sampled span the measurement is offered as an exemplar.
+func (c *Aggregator) Update(ctx context.Context, num number.Number, desc *metric.Descriptor) error {
 	c.value.AddNumberAtomic(desc.NumberKind(), num)
+	if ex, ok := aggregator.NewExemplar(ctx, num); ok {
+		c.lock.Lock()
+		if c.exemplars != nil {
+			c.exemplars.Offer(ex, 0)
+		}
+		c.lock.Unlock()
+	}
 	return nil
 }
 
@@ -86,6 +159,9 @@ func (c *Aggregator) Merge(oa export.Aggregator, desc *metric.Descriptor) error
 		return aggregator.NewInconsistentAggregatorError(c, oa)
 	}
 	c.value.AddNumber(desc.NumberKind(), o.value)
+	if c.exemplars != nil && o.exemplars != nil {
+		c.exemplars.Merge(o.exemplars)
+	}
 	return nil
 }
 
@@ -101,6 +177,12 @@ func (c *Aggregator) Subtract(opAgg, resAgg export.Aggregator, descriptor *metri
 	}
 
 	res.value = c.value
+	if res.exemplars != nil {
+		res.exemplars.Reset()
+		if c.exemplars != nil {
+			res.exemplars.Merge(c.exemplars)
+		}
+	}
 	res.value.AddNumber(descriptor.NumberKind(), number.NewNumberSignChange(descriptor.NumberKind(), op.value))
 	return nil
 }
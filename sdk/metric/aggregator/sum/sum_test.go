@@ -15,6 +15,7 @@
 package sum
 
 import (
+	"context"
 	"os"
 	"testing"
 	"unsafe"
@@ -26,6 +27,8 @@ import (
 	"go.opentelemetry.io/otel/metric/number"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator/aggregatortest"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exemplar"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const count = 100
@@ -152,3 +155,67 @@ func TestSynchronizedMoveReset(t *testing.T) {
 		},
 	)
 }
+
+func TestCounterExemplar(t *testing.T) {
+	agg, ckpt := new2()
+	descriptor := aggregatortest.NewAggregatorTest(metric.CounterInstrumentKind, number.Int64Kind)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	unsampled := sc.WithTraceFlags(0)
+
+	require.NoError(t, agg.Update(context.Background(), number.NewInt64Number(1), descriptor))
+	require.NoError(t, agg.Update(trace.ContextWithSpanContext(context.Background(), sc), number.NewInt64Number(2), descriptor))
+	require.NoError(t, agg.Update(trace.ContextWithSpanContext(context.Background(), unsampled), number.NewInt64Number(3), descriptor))
+	require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+
+	exemplars, err := ckpt.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 1)
+	require.Equal(t, number.NewInt64Number(2), exemplars[0].Value)
+	require.Equal(t, sc, exemplars[0].SpanContext)
+
+	exemplars, err = agg.Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 0, "exemplar not reset by SynchronizedMove")
+}
+
+func TestCounterExemplarStrategy(t *testing.T) {
+	descriptor := aggregatortest.NewAggregatorTest(metric.CounterInstrumentKind, number.Int64Kind)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	for _, tc := range []struct {
+		name     string
+		strategy exemplar.Strategy
+		want     int
+	}{
+		{"disabled", nil, 0},
+		{"fixed size", exemplar.FixedSize(3), 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			alloc := New(3, WithExemplarStrategy(tc.strategy))
+			agg, ckpt, other := &alloc[0], &alloc[1], &alloc[2]
+			for i := int64(0); i < 5; i++ {
+				require.NoError(t, agg.Update(ctx, number.NewInt64Number(i), descriptor))
+			}
+			require.NoError(t, agg.SynchronizedMove(ckpt, descriptor))
+
+			exemplars, err := ckpt.Exemplars()
+			require.NoError(t, err)
+			require.Len(t, exemplars, tc.want)
+
+			require.NoError(t, other.Update(ctx, number.NewInt64Number(10), descriptor))
+			aggregatortest.CheckedMerge(t, ckpt, other, descriptor)
+			exemplars, err = ckpt.Exemplars()
+			require.NoError(t, err)
+			require.Len(t, exemplars, tc.want)
+		})
+	}
+}
@@ -18,7 +18,8 @@ import "unsafe"
 
 func AtomicFieldOffsets() map[string]uintptr {
 	return map[string]uintptr{
-		"record.refMapped.value": unsafe.Offsetof(record{}.refMapped.value),
-		"record.updateCount":     unsafe.Offsetof(record{}.updateCount),
+		"record.refMapped.value":     unsafe.Offsetof(record{}.refMapped.value),
+		"record.updateCount":         unsafe.Offsetof(record{}.updateCount),
+		"syncInstrument.cardinality": unsafe.Offsetof(syncInstrument{}.cardinality),
 	}
 }
@@ -476,6 +476,24 @@ func BenchmarkFloat64ExactHandleAdd(b *testing.B) {
 	benchmarkFloat64ValueRecorderHandleAdd(b, "float64.exact")
 }
 
+// Histogram
+
+func BenchmarkInt64HistogramAdd(b *testing.B) {
+	benchmarkInt64ValueRecorderAdd(b, "int64.histogram")
+}
+
+func BenchmarkInt64HistogramHandleAdd(b *testing.B) {
+	benchmarkInt64ValueRecorderHandleAdd(b, "int64.histogram")
+}
+
+func BenchmarkFloat64HistogramAdd(b *testing.B) {
+	benchmarkFloat64ValueRecorderAdd(b, "float64.histogram")
+}
+
+func BenchmarkFloat64HistogramHandleAdd(b *testing.B) {
+	benchmarkFloat64ValueRecorderHandleAdd(b, "float64.histogram")
+}
+
 // BatchRecord
 
 func benchmarkBatchRecord8Labels(b *testing.B, numInst int) {
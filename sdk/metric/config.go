@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric // import "go.opentelemetry.io/otel/sdk/metric"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Config contains configuration for an Accumulator.
+type Config struct {
+	// DeniedAttributeKeys are the attribute keys removed from the labels
+	// of all measurements recorded by the Accumulator, regardless of the
+	// instrumentation that recorded them.
+	DeniedAttributeKeys []attribute.Key
+
+	// Views customize the data produced for the instruments they
+	// select, see View.
+	Views []View
+
+	// CardinalityLimit is the maximum number of label sets of each
+	// instrument, see WithCardinalityLimit. There is no limit if it is
+	// not positive.
+	CardinalityLimit int
+
+	// MinimumObserveInterval is the minimum interval between two runs
+	// of the asynchronous instrument callbacks, see
+	// WithMinimumObserveInterval. The callbacks run on every collection
+	// if it is not positive.
+	MinimumObserveInterval time.Duration
+}
+
+// Option is the interface that applies the value to a configuration option.
+type Option interface {
+	// Apply sets the Option value of a Config.
+	Apply(*Config)
+}
+
+// WithDeniedAttributeKeys sets the DeniedAttributeKeys configuration option
+// of a Config. Keys passed by successive calls are added to the keys passed
+// by previous ones.
+func WithDeniedAttributeKeys(keys ...attribute.Key) Option {
+	return deniedAttributeKeysOption(keys)
+}
+
+type deniedAttributeKeysOption []attribute.Key
+
+func (o deniedAttributeKeysOption) Apply(config *Config) {
+	config.DeniedAttributeKeys = append(config.DeniedAttributeKeys, o...)
+}
+
+// WithViews sets the Views configuration option of a Config. Views passed
+// by successive calls are added after the views passed by previous ones.
+//
+// The Aggregation of a View is used instead of the AggregatorSelector of
+// the processor for the instruments it selects. Processors allocating
+// Aggregators for these instruments use the AggregatorSelector of the
+// Accumulation they process.
+func WithViews(views ...View) Option {
+	return viewsOption(views)
+}
+
+type viewsOption []View
+
+func (o viewsOption) Apply(config *Config) {
+	config.Views = append(config.Views, o...)
+}
+
+// WithCardinalityLimit sets the CardinalityLimit configuration option of
+// a Config. Once an instrument has limit label sets, the measurements
+// with other label sets are aggregated into a single series with the
+// otel.metric.overflow=true label, instead of growing memory without
+// bound. The label sets of the synchronous instruments are released
+// when they are collected without being updated. A View can set the
+// limit of the instruments it selects.
+func WithCardinalityLimit(limit int) Option {
+	return cardinalityLimitOption(limit)
+}
+
+type cardinalityLimitOption int
+
+func (o cardinalityLimitOption) Apply(config *Config) {
+	config.CardinalityLimit = int(o)
+}
+
+// WithMinimumObserveInterval sets the MinimumObserveInterval configuration
+// option of a Config. The collections happening less than interval after
+// the asynchronous instrument callbacks last ran do not run them again,
+// they export the observations of that run instead. This keeps expensive
+// callbacks from running more often than they can afford when the
+// Accumulator is collected by several readers.
+func WithMinimumObserveInterval(interval time.Duration) Option {
+	return minimumObserveIntervalOption(interval)
+}
+
+type minimumObserveIntervalOption time.Duration
+
+func (o minimumObserveIntervalOption) Apply(config *Config) {
+	config.MinimumObserveInterval = time.Duration(o)
+}
@@ -17,7 +17,10 @@ package basic // import "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 import (
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
+	sdk "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -37,9 +40,19 @@ type Config struct {
 	//
 	// When exporting metrics, this must be > 0.
 	//
-	// Default value is 10s.
+	// Default value is 10s, or the value of the EnvExportInterval
+	// environment variable.
 	CollectPeriod time.Duration
 
+	// CollectJitter is the upper bound of the random delay added to
+	// each periodic collection and export, of the Controller and of
+	// its PeriodicReaders, so that many processes started at once do
+	// not export at the same time.  It should be smaller than the
+	// collection periods.
+	//
+	// Default value is 0, no delay is added.
+	CollectJitter time.Duration
+
 	// CollectTimeout is the timeout of the Context passed to
 	// Collect() and subsequently to Observer instrument callbacks.
 	//
@@ -54,8 +67,36 @@ type Config struct {
 
 	// PushTimeout is the timeout of the Context when a exporter is configured.
 	//
-	// Default value is 10s.  If zero, no Export timeout is applied.
+	// Default value is 10s, or the value of the EnvExportTimeout
+	// environment variable.  If zero, no Export timeout is applied.
 	PushTimeout time.Duration
+
+	// DeniedAttributeKeys are the attribute keys removed from the labels
+	// of all measurements recorded by Meters created by the Controller.
+	DeniedAttributeKeys []attribute.Key
+
+	// Views customize the data produced for the instruments they
+	// select, see sdk.View.
+	Views []sdk.View
+
+	// MinimumObserveInterval is the minimum interval between two runs
+	// of the asynchronous instrument callbacks, see
+	// sdk.WithMinimumObserveInterval. The callbacks run on every
+	// collection if it is not positive.
+	MinimumObserveInterval time.Duration
+
+	// Readers read the metric data of the Controller in addition to
+	// its checkpointer and Exporter, see Reader.
+	Readers []Reader
+
+	// Producers produce metric data from sources external to the
+	// Controller, exported along with the metric data of the
+	// Controller by its Exporter and Readers.
+	Producers []export.Producer
+
+	// SelfMeterProvider, if set, provides the Meter the Controller
+	// records the number and duration of its collections with.
+	SelfMeterProvider metric.MeterProvider
 }
 
 // Option is the interface that applies the value to a configuration option.
@@ -86,6 +127,17 @@ func (o collectPeriodOption) Apply(config *Config) {
 	config.CollectPeriod = time.Duration(o)
 }
 
+// WithCollectJitter sets the CollectJitter configuration option of a Config.
+func WithCollectJitter(jitter time.Duration) Option {
+	return collectJitterOption(jitter)
+}
+
+type collectJitterOption time.Duration
+
+func (o collectJitterOption) Apply(config *Config) {
+	config.CollectJitter = time.Duration(o)
+}
+
 // WithCollectTimeout sets the CollectTimeout configuration option of a Config.
 func WithCollectTimeout(timeout time.Duration) Option {
 	return collectTimeoutOption(timeout)
@@ -118,3 +170,84 @@ type pushTimeoutOption time.Duration
 func (o pushTimeoutOption) Apply(config *Config) {
 	config.PushTimeout = time.Duration(o)
 }
+
+// WithDeniedAttributeKeys sets the DeniedAttributeKeys configuration option
+// of a Config. Keys passed by successive calls are added to the keys passed
+// by previous ones.
+func WithDeniedAttributeKeys(keys ...attribute.Key) Option {
+	return deniedAttributeKeysOption(keys)
+}
+
+type deniedAttributeKeysOption []attribute.Key
+
+func (o deniedAttributeKeysOption) Apply(config *Config) {
+	config.DeniedAttributeKeys = append(config.DeniedAttributeKeys, o...)
+}
+
+// WithViews sets the Views configuration option of a Config. Views passed
+// by successive calls are added after the views passed by previous ones.
+//
+// The Aggregation of a View is used instead of the AggregatorSelector of
+// the checkpointer for the instruments it selects.
+func WithViews(views ...sdk.View) Option {
+	return viewsOption(views)
+}
+
+type viewsOption []sdk.View
+
+func (o viewsOption) Apply(config *Config) {
+	config.Views = append(config.Views, o...)
+}
+
+// WithMinimumObserveInterval sets the MinimumObserveInterval
+// configuration option of a Config. Collections by the Exporter and the
+// Readers of the Controller happening less than interval after the
+// asynchronous instrument callbacks last ran export the observations of
+// that run, instead of running the callbacks again.
+func WithMinimumObserveInterval(interval time.Duration) Option {
+	return minimumObserveIntervalOption(interval)
+}
+
+type minimumObserveIntervalOption time.Duration
+
+func (o minimumObserveIntervalOption) Apply(config *Config) {
+	config.MinimumObserveInterval = time.Duration(o)
+}
+
+// WithReader sets the Readers configuration option of a Config. Readers
+// passed by successive calls are added to the readers passed by previous
+// ones. A Reader can only be added to one Controller.
+func WithReader(readers ...Reader) Option {
+	return readersOption(readers)
+}
+
+type readersOption []Reader
+
+func (o readersOption) Apply(config *Config) {
+	config.Readers = append(config.Readers, o...)
+}
+
+// WithProducer sets the Producers configuration option of a Config.
+// Producers passed by successive calls are added to the producers
+// passed by previous ones.
+func WithProducer(producers ...export.Producer) Option {
+	return producersOption(producers)
+}
+
+type producersOption []export.Producer
+
+func (o producersOption) Apply(config *Config) {
+	config.Producers = append(config.Producers, o...)
+}
+
+// WithSelfMeterProvider sets the SelfMeterProvider configuration option
+// of a Config.
+func WithSelfMeterProvider(mp metric.MeterProvider) Option {
+	return selfMeterProviderOption{mp}
+}
+
+type selfMeterProviderOption struct{ metric.MeterProvider }
+
+func (o selfMeterProviderOption) Apply(config *Config) {
+	config.SelfMeterProvider = o.MeterProvider
+}
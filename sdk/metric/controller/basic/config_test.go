@@ -16,10 +16,13 @@ package basic
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"go.opentelemetry.io/otel/attribute"
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -35,3 +38,47 @@ func TestWithResource(t *testing.T) {
 	WithResource(r).Apply(c)
 	assert.Equal(t, r.Equivalent(), c.Resource.Equivalent())
 }
+
+func TestWithDeniedAttributeKeys(t *testing.T) {
+	c := &Config{}
+	WithDeniedAttributeKeys("A", "B").Apply(c)
+	assert.Equal(t, []attribute.Key{"A", "B"}, c.DeniedAttributeKeys)
+
+	// Ensure successive options extend the keys.
+	WithDeniedAttributeKeys("C").Apply(c)
+	assert.Equal(t, []attribute.Key{"A", "B", "C"}, c.DeniedAttributeKeys)
+}
+
+func TestEnvDefaults(t *testing.T) {
+	envStore, err := ottest.SetEnvVariables(map[string]string{
+		EnvExportInterval: "2500",
+		EnvExportTimeout:  "750",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, envStore.Restore()) }()
+
+	c := New(nil)
+	assert.Equal(t, 2500*time.Millisecond, c.collectPeriod)
+	assert.Equal(t, 750*time.Millisecond, c.pushTimeout)
+
+	r := NewPeriodicReader(nil, 0, 0)
+	assert.Equal(t, 2500*time.Millisecond, r.interval)
+
+	// Options take precedence over the environment.
+	c = New(nil, WithCollectPeriod(time.Second), WithPushTimeout(time.Second))
+	assert.Equal(t, time.Second, c.collectPeriod)
+	assert.Equal(t, time.Second, c.pushTimeout)
+}
+
+func TestInvalidEnvDefaults(t *testing.T) {
+	envStore, err := ottest.SetEnvVariables(map[string]string{
+		EnvExportInterval: "-1",
+		EnvExportTimeout:  "soon",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, envStore.Restore()) }()
+
+	c := New(nil)
+	assert.Equal(t, DefaultPeriod, c.collectPeriod)
+	assert.Equal(t, DefaultPeriod, c.pushTimeout)
+}
@@ -17,6 +17,7 @@ package basic // import "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 import (
 	"context"
 	"fmt"
+	"math/rand"
 	"sync"
 	"time"
 
@@ -24,6 +25,8 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/metric/registry"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/internal"
+	"go.opentelemetry.io/otel/sdk/internal/env"
 	sdk "go.opentelemetry.io/otel/sdk/metric"
 	controllerTime "go.opentelemetry.io/otel/sdk/metric/controller/time"
 	"go.opentelemetry.io/otel/sdk/resource"
@@ -40,6 +43,10 @@ const DefaultPeriod = 10 * time.Second
 // than once.
 var ErrControllerStarted = fmt.Errorf("controller already started")
 
+// ErrControllerShutdown indicates that a controller was started after
+// it was shut down.
+var ErrControllerShutdown = fmt.Errorf("controller is shut down")
+
 // Controller organizes and synchronizes collection of metric data in
 // both "pull" and "push" configurations.  This supports two distinct
 // modes:
@@ -54,6 +61,9 @@ var ErrControllerStarted = fmt.Errorf("controller already started")
 // The controller supports mixing push and pull access to metric data
 // using the export.CheckpointSet RWLock interface.  Collection will
 // be blocked by a pull request in the basic controller.
+//
+// Additional exporters, each with their own export kinds and schedule,
+// are attached with Readers, see WithReader.
 type Controller struct {
 	lock         sync.Mutex
 	accumulator  *sdk.Accumulator
@@ -62,16 +72,36 @@ type Controller struct {
 	exporter     export.Exporter
 	wg           sync.WaitGroup
 	stopCh       chan struct{}
+	shutdown     bool
 	clock        controllerTime.Clock
 	ticker       controllerTime.Ticker
 
 	collectPeriod  time.Duration
+	collectJitter  time.Duration
 	collectTimeout time.Duration
 	pushTimeout    time.Duration
 
+	// collectLock serializes the collections of all pipelines.
+	collectLock sync.Mutex
+	// pipeline is the pipeline of the checkpointer, pipelines
+	// contains it followed by the pipelines of the readers.
+	pipeline  *pipeline
+	pipelines fanout
+	readers   []Reader
+	producers []export.Producer
+
 	// collectedTime is used only in configurations with no
 	// exporter, when ticker != nil.
 	collectedTime time.Time
+
+	// selfMetrics records the collections of the Controller. It is nil
+	// if the Controller is not instrumented.
+	selfMetrics *selfMetrics
+
+	// releaseResource unregisters the resource from the process wide
+	// registry of service instances, on Shutdown or when the resource is
+	// merged with another one.
+	releaseResource func()
 }
 
 // New constructs a Controller using the provided checkpointer and
@@ -79,9 +109,9 @@ type Controller struct {
 // export pipeline.
 func New(checkpointer export.Checkpointer, opts ...Option) *Controller {
 	c := &Config{
-		CollectPeriod:  DefaultPeriod,
+		CollectPeriod:  env.Duration(otel.MetricsSignal, EnvExportInterval, DefaultPeriod),
 		CollectTimeout: DefaultPeriod,
-		PushTimeout:    DefaultPeriod,
+		PushTimeout:    env.Duration(otel.MetricsSignal, EnvExportTimeout, DefaultPeriod),
 	}
 	for _, opt := range opts {
 		opt.Apply(c)
@@ -89,23 +119,54 @@ func New(checkpointer export.Checkpointer, opts ...Option) *Controller {
 	if c.Resource == nil {
 		c.Resource = resource.Default()
 	}
+	releaseResource, err := internal.RegisterResource("metric", c.Resource)
+	if err != nil {
+		otel.HandleSignal(otel.MetricsSignal, err)
+	}
 
-	impl := sdk.NewAccumulator(
-		checkpointer,
-		c.Resource,
-	)
-	return &Controller{
-		provider:     registry.NewMeterProvider(impl),
-		accumulator:  impl,
+	main := &pipeline{checkpointer: checkpointer}
+	cont := &Controller{
 		checkpointer: checkpointer,
 		exporter:     c.Exporter,
 		stopCh:       nil,
 		clock:        controllerTime.RealClock{},
 
 		collectPeriod:  c.CollectPeriod,
+		collectJitter:  c.CollectJitter,
 		collectTimeout: c.CollectTimeout,
 		pushTimeout:    c.PushTimeout,
+
+		releaseResource: releaseResource,
+
+		pipeline:  main,
+		producers: c.Producers,
+		pipelines: fanout{
+			AggregatorSelector: checkpointer,
+			pipelines:          []*pipeline{main},
+		},
+	}
+	for _, r := range c.Readers {
+		p, err := r.bind(cont)
+		if err != nil {
+			otel.HandleSignal(otel.MetricsSignal, err)
+			continue
+		}
+		cont.pipelines.pipelines = append(cont.pipelines.pipelines, p)
+		cont.readers = append(cont.readers, r)
 	}
+
+	cont.accumulator = sdk.NewAccumulator(
+		&cont.pipelines,
+		c.Resource,
+		sdk.WithDeniedAttributeKeys(c.DeniedAttributeKeys...),
+		sdk.WithViews(c.Views...),
+		sdk.WithMinimumObserveInterval(c.MinimumObserveInterval),
+	)
+	cont.provider = registry.NewMeterProvider(cont.accumulator)
+	if c.SelfMeterProvider != nil {
+		cont.selfMetrics = newSelfMetrics(c.SelfMeterProvider)
+	}
+	return cont
 }
 
 // SetClock supports setting a mock clock for testing.  This must be
@@ -116,6 +177,51 @@ func (c *Controller) SetClock(clock controllerTime.Clock) {
 	c.clock = clock
 }
 
+// Resource returns the Resource associated with the metrics of this
+// controller.
+func (c *Controller) Resource() *resource.Resource {
+	return c.accumulator.Resource()
+}
+
+// MergeResource merges res into the Resource associated with the metrics of
+// this controller, values of res taking precedence.  This allows resource
+// information that is only known after instrumentation has been set up,
+// e.g. retrieved from a slow cloud metadata service, to be added.
+//
+// The Resource can only be changed until metrics are first collected,
+// after which sdk.ErrResourceAfterCollect is returned.  The Resource is
+// not changed if res has a schema URL conflicting with it, the
+// resource.ErrSchemaURLConflict error is returned instead.
+func (c *Controller) MergeResource(res *resource.Resource) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	merged, err := resource.Merge(c.accumulator.Resource(), res)
+	if err != nil {
+		return err
+	}
+	if err := c.accumulator.SetResource(merged); err != nil {
+		return err
+	}
+	c.releaseResource()
+	if c.releaseResource, err = internal.RegisterResource("metric", merged); err != nil {
+		otel.HandleSignal(otel.MetricsSignal, err)
+	}
+	return nil
+}
+
+// DisableInstruments stops recording measurements for the instruments
+// whose name matches pattern, see sdk.Accumulator.DisableInstruments.
+func (c *Controller) DisableInstruments(pattern string) error {
+	return c.accumulator.DisableInstruments(pattern)
+}
+
+// EnableInstruments resumes recording measurements for the instruments
+// disabled with pattern, see sdk.Accumulator.EnableInstruments.
+func (c *Controller) EnableInstruments(pattern string) {
+	c.accumulator.EnableInstruments(pattern)
+}
+
 // MeterProvider returns a MeterProvider instance for this controller.
 func (c *Controller) MeterProvider() metric.MeterProvider {
 	return c.provider
@@ -124,11 +230,12 @@ func (c *Controller) MeterProvider() metric.MeterProvider {
 // Start begins a ticker that periodically collects and exports
 // metrics with the configured interval.  This is required for calling
 // a configured Exporter (see WithExporter) and is otherwise optional
-// when only pulling metric data.
+// when only pulling metric data.  Start also starts the
+// PeriodicReaders of the controller.
 //
 // The passed context is passed to Collect() and subsequently to
 // asynchronous instrument callbacks.  Returns an error when the
-// controller was already started.
+// controller was already started or was shut down.
 //
 // Note that it is not necessary to Start a controller when only
 // pulling data; use the Collect() and ForEach() methods directly in
@@ -137,6 +244,9 @@ func (c *Controller) Start(ctx context.Context) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
+	if c.shutdown {
+		return ErrControllerShutdown
+	}
 	if c.stopCh != nil {
 		return ErrControllerStarted
 	}
@@ -145,6 +255,9 @@ func (c *Controller) Start(ctx context.Context) error {
 	c.stopCh = make(chan struct{})
 	c.ticker = c.clock.Ticker(c.collectPeriod)
 	go c.runTicker(ctx, c.stopCh)
+	for _, r := range c.readers {
+		r.start(ctx, c.clock)
+	}
 	return nil
 }
 
@@ -154,24 +267,94 @@ func (c *Controller) Start(ctx context.Context) error {
 // final asynchronous instruments.
 //
 // Note that Stop() will not cancel an ongoing collection or export.
+// The PeriodicReaders of the controller are stopped the same way.
 func (c *Controller) Stop(ctx context.Context) error {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	if c.stopCh == nil {
+	if !c.halt() {
 		return nil
 	}
 
+	err := c.collect(ctx)
+	for _, r := range c.readers {
+		err = combineErrors(err, r.stop(ctx))
+	}
+	return err
+}
+
+// ForceFlush collects the metric data and exports it to the configured
+// Exporter, if any, and to the exporters of the PeriodicReaders of the
+// controller, whether the controller is started or not.  The errors of
+// all exports are returned.
+func (c *Controller) ForceFlush(ctx context.Context) error {
+	var err error
+	if c.exporter != nil {
+		err = c.collect(ctx)
+	}
+	for _, r := range c.readers {
+		err = combineErrors(err, r.forceFlush(ctx))
+	}
+	return err
+}
+
+// Shutdown stops the background goroutines of the controller and of its
+// PeriodicReaders, then collects and exports the metric data one last
+// time, like ForceFlush, whether the controller was started or not.  The
+// controller cannot be started again once shut down, calling Shutdown
+// more than once has no effect.
+//
+// The exporters are not shut down, they may be shared with other
+// providers, e.g. a TracerProvider.
+func (c *Controller) Shutdown(ctx context.Context) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.shutdown {
+		return nil
+	}
+	c.shutdown = true
+	c.releaseResource()
+	c.halt()
+
+	var err error
+	if c.exporter != nil {
+		err = c.collect(ctx)
+	}
+	for _, r := range c.readers {
+		err = combineErrors(err, r.shutdown(ctx))
+	}
+	return err
+}
+
+// halt stops the background goroutine, it returns false if the
+// controller was not started.  This is called with c.lock held.
+func (c *Controller) halt() bool {
+	if c.stopCh == nil {
+		return false
+	}
 	close(c.stopCh)
 	c.stopCh = nil
 	c.wg.Wait()
 	c.ticker.Stop()
 	c.ticker = nil
+	return true
+}
 
-	return c.collect(ctx)
+// combineErrors returns the combination of err and next, either of which
+// may be nil.
+func combineErrors(err, next error) error {
+	if next == nil {
+		return err
+	}
+	if err == nil {
+		return next
+	}
+	return fmt.Errorf("%s: %w", next.Error(), err)
 }
 
 // runTicker collection on ticker events until the stop channel is closed.
+// Each collection is delayed by a random duration below collectJitter.
 func (c *Controller) runTicker(ctx context.Context, stopCh chan struct{}) {
 	defer c.wg.Done()
 	for {
@@ -179,13 +362,37 @@ func (c *Controller) runTicker(ctx context.Context, stopCh chan struct{}) {
 		case <-stopCh:
 			return
 		case <-c.ticker.C():
+			if !waitJitter(c.clock, c.collectJitter, stopCh) {
+				return
+			}
 			if err := c.collect(ctx); err != nil {
-				otel.Handle(err)
+				otel.HandleSignal(otel.MetricsSignal, err)
 			}
 		}
 	}
 }
 
+// waitJitter waits for a random duration below jitter, as measured by a
+// one-shot Ticker of clock. It returns false if the stop channel is closed
+// first.
+func waitJitter(clock controllerTime.Clock, jitter time.Duration, stopCh chan struct{}) bool {
+	if jitter <= 0 {
+		return true
+	}
+	delay := time.Duration(rand.Int63n(int64(jitter)))
+	if delay <= 0 {
+		return true
+	}
+	ticker := clock.Ticker(delay)
+	defer ticker.Stop()
+	select {
+	case <-stopCh:
+		return false
+	case <-ticker.C():
+		return true
+	}
+}
+
 // collect computes a checkpoint and optionally exports it.
 func (c *Controller) collect(ctx context.Context) error {
 	if err := c.checkpoint(ctx, func() bool {
@@ -209,14 +416,43 @@ func (c *Controller) collect(ctx context.Context) error {
 // timeout.  Note that this does not try to cancel a Collect or Export
 // when Stop() is called.
 func (c *Controller) checkpoint(ctx context.Context, cond func() bool) error {
-	ckpt := c.checkpointer.CheckpointSet()
+	return c.collectPipeline(ctx, c.pipeline, cond)
+}
+
+// collectPipeline collects the Accumulator for the checkpointer of p.
+// The other pipelines only keep the synchronous instrument data of the
+// collection, which is passed to their checkpointer in their own next
+// collection.
+func (c *Controller) collectPipeline(ctx context.Context, p *pipeline, cond func() bool) error {
+	start := time.Now()
+	collected, err := c.lockAndCollectPipeline(ctx, p, cond)
+	if collected {
+		c.selfMetrics.recordCollect(ctx, time.Since(start), err)
+	}
+	return err
+}
+
+// lockAndCollectPipeline collects the Accumulator for p if cond returns
+// true once the locks are held, and reports whether it did.
+func (c *Controller) lockAndCollectPipeline(ctx context.Context, p *pipeline, cond func() bool) (bool, error) {
+	c.collectLock.Lock()
+	defer c.collectLock.Unlock()
+
+	ckpt := p.checkpointer.CheckpointSet()
 	ckpt.Lock()
 	defer ckpt.Unlock()
 
 	if !cond() {
-		return nil
+		return false, nil
 	}
-	c.checkpointer.StartCollection()
+	p.checkpointer.StartCollection()
+
+	// Like the Accumulator, handle the errors of processing.
+	if err := p.processPending(); err != nil {
+		otel.HandleSignal(otel.MetricsSignal, err)
+	}
+	c.pipelines.active = p
+	defer func() { c.pipelines.active = nil }()
 
 	if c.collectTimeout > 0 {
 		var cancel context.CancelFunc
@@ -225,6 +461,7 @@ func (c *Controller) checkpoint(ctx context.Context, cond func() bool) error {
 	}
 
 	_ = c.accumulator.Collect(ctx)
+	p.produced = c.produce(ctx, p.produced)
 
 	var err error
 	select {
@@ -235,21 +472,13 @@ func (c *Controller) checkpoint(ctx context.Context, cond func() bool) error {
 	}
 
 	// Finish the checkpoint whether the accumulator timed out or not.
-	if cerr := c.checkpointer.FinishCollection(); cerr != nil {
-		if err == nil {
-			err = cerr
-		} else {
-			err = fmt.Errorf("%s: %w", cerr.Error(), err)
-		}
-	}
-
-	return err
+	return true, combineErrors(err, p.checkpointer.FinishCollection())
 }
 
 // export calls the exporter with a read lock on the CheckpointSet,
 // applying the configured export timeout.
 func (c *Controller) export(ctx context.Context) error {
-	ckpt := c.checkpointer.CheckpointSet()
+	ckpt := c.checkpointSet(c.pipeline)
 	ckpt.RLock()
 	defer ckpt.RUnlock()
 
@@ -265,7 +494,7 @@ func (c *Controller) export(ctx context.Context) error {
 // Foreach gives the caller read-locked access to the current
 // export.CheckpointSet.
 func (c *Controller) ForEach(ks export.ExportKindSelector, f func(export.Record) error) error {
-	ckpt := c.checkpointer.CheckpointSet()
+	ckpt := c.checkpointSet(c.pipeline)
 	ckpt.RLock()
 	defer ckpt.RUnlock()
 
@@ -27,10 +27,12 @@ import (
 	"go.opentelemetry.io/otel/metric"
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	sdk "go.opentelemetry.io/otel/sdk/metric"
 	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
 	"go.opentelemetry.io/otel/sdk/metric/controller/controllertest"
 	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
 	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/selector/simple"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -109,6 +111,48 @@ func TestControllerUsesResource(t *testing.T) {
 	}
 }
 
+func TestControllerMergeResource(t *testing.T) {
+	cont := controller.New(
+		processor.New(
+			processortest.AggregatorSelector(),
+			export.CumulativeExportKindSelector(),
+		),
+		controller.WithResource(resource.NewWithAttributes(attribute.String("R", "S"), attribute.String("T", "U"))),
+	)
+	prov := cont.MeterProvider()
+
+	// Instruments created before the resource is known use it.
+	ctr := metric.Must(prov.Meter("named")).NewFloat64Counter("calls.sum")
+	ctr.Add(context.Background(), 1.)
+
+	require.NoError(t, cont.MergeResource(resource.NewWithAttributes(attribute.String("T", "V"), attribute.String("W", "X"))))
+	require.Equal(t, "R=S,T=V,W=X", cont.Resource().Encoded(attribute.DefaultEncoder()))
+
+	require.NoError(t, cont.Collect(context.Background()))
+	require.EqualValues(t, map[string]float64{
+		"calls.sum//R=S,T=V,W=X": 1.,
+	}, getMap(t, cont))
+
+	err := cont.MergeResource(resource.NewWithAttributes(attribute.String("Y", "Z")))
+	require.True(t, errors.Is(err, sdk.ErrResourceAfterCollect))
+	require.Equal(t, "R=S,T=V,W=X", cont.Resource().Encoded(attribute.DefaultEncoder()))
+}
+
+func TestControllerMergeResourceSchemaURLConflict(t *testing.T) {
+	cont := controller.New(
+		processor.New(
+			processortest.AggregatorSelector(),
+			export.CumulativeExportKindSelector(),
+		),
+		controller.WithResource(resource.NewWithSchemaURL("https://opentelemetry.io/schemas/1.3.0", attribute.String("R", "S"))),
+	)
+
+	err := cont.MergeResource(resource.NewWithSchemaURL("https://opentelemetry.io/schemas/1.4.0", attribute.String("T", "U")))
+	require.True(t, errors.Is(err, resource.ErrSchemaURLConflict))
+	require.Equal(t, "R=S", cont.Resource().Encoded(attribute.DefaultEncoder()))
+	require.Equal(t, "https://opentelemetry.io/schemas/1.3.0", cont.Resource().SchemaURL())
+}
+
 func TestStartNoExporter(t *testing.T) {
 	cont := controller.New(
 		processor.New(
@@ -409,3 +453,55 @@ func TestCollectAfterStopThenStartAgain(t *testing.T) {
 		"one.lastvalue//": 6,
 	}, exp.Values())
 }
+
+func TestSelfMeterProvider(t *testing.T) {
+	self := controller.New(
+		processor.New(
+			simple.NewWithInexpensiveDistribution(),
+			export.CumulativeExportKindSelector(),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+	cont := controller.New(
+		processor.New(
+			processortest.AggregatorSelector(),
+			export.CumulativeExportKindSelector(),
+		),
+		controller.WithCollectPeriod(time.Second),
+		controller.WithResource(resource.Empty()),
+		controller.WithSelfMeterProvider(self.MeterProvider()),
+	)
+	mock := controllertest.NewMockClock()
+	cont.SetClock(mock)
+
+	ctx := context.Background()
+	mock.Add(time.Second)
+	require.NoError(t, cont.Collect(ctx))
+	// Skipped, the collection period has not elapsed.
+	require.NoError(t, cont.Collect(ctx))
+	mock.Add(time.Second)
+	require.NoError(t, cont.Collect(ctx))
+
+	require.NoError(t, self.Collect(ctx))
+	got := map[string]int64{}
+	require.NoError(t, self.ForEach(export.CumulativeExportKindSelector(), func(record export.Record) error {
+		success, _ := record.Labels().Value(controller.CollectSuccessKey)
+		require.True(t, success.AsBool())
+		switch agg := record.Aggregation().(type) {
+		case aggregation.Count:
+			count, err := agg.Count()
+			got[record.Descriptor().Name()] = int64(count)
+			return err
+		case aggregation.Sum:
+			sum, err := agg.Sum()
+			got[record.Descriptor().Name()] = sum.AsInt64()
+			return err
+		}
+		return fmt.Errorf("unexpected aggregation %T", record.Aggregation())
+	}))
+	require.Equal(t, map[string]int64{
+		"sdk.metric.controller.collects":         2,
+		"sdk.metric.controller.collect.duration": 2,
+	}, got)
+}
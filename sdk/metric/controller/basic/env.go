@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basic // import "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+
+// Environment variables used to configure the Controller defaults that
+// are not set with options.
+const (
+	// EnvExportInterval is the environment variable for the default
+	// CollectPeriod of a Controller, and the default interval of its
+	// PeriodicReaders, in milliseconds.
+	EnvExportInterval = "OTEL_METRIC_EXPORT_INTERVAL"
+
+	// EnvExportTimeout is the environment variable for the default
+	// PushTimeout of a Controller, in milliseconds.
+	EnvExportTimeout = "OTEL_METRIC_EXPORT_TIMEOUT"
+)
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basic_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// TestGlobalDelegation checks that the instruments and callbacks registered
+// with the global MeterProvider before the SDK is installed, e.g. in init(),
+// report to the SDK once it is.
+func TestGlobalDelegation(t *testing.T) {
+	defer global.SwapMeterProvider(nil)
+
+	ctx := context.Background()
+	meter := global.Meter("lib")
+	counter := metric.Must(meter).NewInt64Counter("counter.sum")
+	bound := counter.Bind(attribute.String("A", "B"))
+	_ = metric.Must(meter).NewInt64ValueObserver("obs.lastvalue", func(_ context.Context, r metric.Int64ObserverResult) {
+		r.Observe(3, attribute.String("A", "B"))
+	})
+	var batchObs metric.Int64ValueObserver
+	batch := metric.Must(meter).NewBatchObserver(func(_ context.Context, r metric.BatchObserverResult) {
+		r.Observe([]attribute.KeyValue{attribute.String("A", "B")}, batchObs.Observation(4))
+	})
+	batchObs = batch.NewInt64ValueObserver("batch.lastvalue")
+	cb := metric.Must(meter).NewBatchObserver(nil)
+	cbObs := cb.NewInt64ValueObserver("cb.lastvalue")
+	_, err := meter.RegisterCallback([]metric.Asynchronous{cbObs}, func(_ context.Context, r metric.BatchObserverResult) {
+		r.Observe([]attribute.KeyValue{attribute.String("A", "B")}, cbObs.Observation(5))
+	})
+	require.NoError(t, err)
+
+	// Nothing is recorded before the SDK is installed.
+	counter.Add(ctx, 100, attribute.String("A", "B"))
+
+	c := controller.New(
+		processor.New(processortest.AggregatorSelector(), export.CumulativeExportKindSelector(), processor.WithMemory(true)),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(resource.Empty()),
+	)
+	global.SetMeterProvider(c.MeterProvider())
+	counter.Add(ctx, 1, attribute.String("A", "B"))
+	bound.Add(ctx, 2)
+	meter.RecordBatch(ctx, []attribute.KeyValue{attribute.String("A", "B")}, counter.Measurement(10))
+
+	require.NoError(t, c.Collect(ctx))
+	records := processortest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, c.ForEach(export.CumulativeExportKindSelector(), records.AddRecord))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/A=B/":     13,
+		"obs.lastvalue/A=B/":   3,
+		"batch.lastvalue/A=B/": 4,
+		"cb.lastvalue/A=B/":    5,
+	}, records.Map())
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basic // import "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+)
+
+// produce returns the Records of the Producers of the Controller,
+// reusing the records slice.  The Records go through the denied
+// attribute keys and the views of the Accumulator, see
+// sdk.Accumulator.ApplyViews.
+func (c *Controller) produce(ctx context.Context, records []export.Record) []export.Record {
+	records = records[:0]
+	if len(c.producers) == 0 {
+		return records
+	}
+	for _, p := range c.producers {
+		produced, err := p.Produce(ctx)
+		if err != nil {
+			// Like the Accumulator, handle the errors of producing.
+			otel.HandleSignal(otel.MetricsSignal, err)
+		}
+		for _, r := range produced {
+			if r, ok := c.accumulator.ApplyViews(r); ok {
+				records = append(records, r)
+			}
+		}
+	}
+	return records
+}
+
+// checkpointSet returns the CheckpointSet of p, including the Records
+// of the Producers read by its last collection.
+func (c *Controller) checkpointSet(p *pipeline) export.CheckpointSet {
+	ckpt := p.checkpointer.CheckpointSet()
+	if len(c.producers) == 0 {
+		return ckpt
+	}
+	return producedCheckpointSet{CheckpointSet: ckpt, pipeline: p}
+}
+
+// producedCheckpointSet is the CheckpointSet of a pipeline followed by
+// the Records produced during its collection.  The Records are read
+// with the lock of the CheckpointSet held, as they are written.
+type producedCheckpointSet struct {
+	export.CheckpointSet
+	pipeline *pipeline
+}
+
+// ForEach implements export.CheckpointSet.
+func (s producedCheckpointSet) ForEach(ks export.ExportKindSelector, f func(export.Record) error) error {
+	if err := s.CheckpointSet.ForEach(ks, f); err != nil {
+		return err
+	}
+	for _, r := range s.pipeline.produced {
+		if err := f(r); err != nil && !errors.Is(err, aggregation.ErrNoData) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basic_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	sdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// testProducer produces a single sum of value.
+type testProducer struct {
+	value int64
+	err   error
+}
+
+func (p *testProducer) Produce(ctx context.Context) ([]export.Record, error) {
+	desc := metric.NewDescriptor("external.sum", metric.SumObserverInstrumentKind, number.Int64Kind)
+	agg := &sum.New(1)[0]
+	if err := agg.Update(ctx, number.NewInt64Number(p.value), &desc); err != nil {
+		return nil, err
+	}
+	labels := attribute.NewSet(attribute.String("E", "F"))
+	res := resource.NewWithAttributes(attribute.String("external", "true"))
+	now := time.Now()
+	return []export.Record{
+		export.NewRecord(&desc, &labels, res, agg, now, now),
+	}, p.err
+}
+
+// recordsProducer produces records.
+type recordsProducer []export.Record
+
+func (p recordsProducer) Produce(context.Context) ([]export.Record, error) {
+	return p, nil
+}
+
+func TestProducer(t *testing.T) {
+	ctx := context.Background()
+	producer := &testProducer{value: 5}
+	reader := controller.NewManualReader(export.CumulativeExportKindSelector())
+	exporter := newExporter()
+	cont := controller.New(
+		processor.New(
+			processortest.AggregatorSelector(),
+			export.CumulativeExportKindSelector(),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(testResource),
+		controller.WithExporter(exporter),
+		controller.WithReader(reader),
+		controller.WithProducer(producer),
+	)
+	counter := metric.Must(cont.MeterProvider().Meter("name")).NewInt64Counter("counter.sum")
+	counter.Add(ctx, 1)
+
+	// The Records produced are exported with the Resource of the
+	// controller.
+	require.NoError(t, cont.ForceFlush(ctx))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V":     1,
+		"external.sum/E=F/R=V": 5,
+	}, exporter.Values())
+
+	producer.value = 7
+	require.NoError(t, reader.Collect(ctx))
+	out := processortest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, reader.ForEach(out.AddRecord))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V":     1,
+		"external.sum/E=F/R=V": 7,
+	}, out.Map())
+
+	// The controller keeps the Records of its last collection.
+	out = processortest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, cont.ForEach(export.CumulativeExportKindSelector(), out.AddRecord))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V":     1,
+		"external.sum/E=F/R=V": 5,
+	}, out.Map())
+}
+
+func TestProducerError(t *testing.T) {
+	ctx := context.Background()
+	producerErr := errors.New("producer failed")
+	cont := controller.New(
+		newCheckpointer(),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(testResource),
+		controller.WithProducer(&testProducer{value: 1, err: producerErr}),
+	)
+
+	require.NoError(t, cont.Collect(ctx))
+	require.True(t, errors.Is(testHandler.Flush(), producerErr))
+
+	out := processortest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, cont.ForEach(export.CumulativeExportKindSelector(), out.AddRecord))
+	require.EqualValues(t, map[string]float64{
+		"external.sum/E=F/R=V": 1,
+	}, out.Map())
+}
+
+func TestProducerViews(t *testing.T) {
+	ctx := context.Background()
+	newRecord := func(name string, value int64, kvs ...attribute.KeyValue) export.Record {
+		desc := metric.NewDescriptor(name, metric.SumObserverInstrumentKind, number.Int64Kind)
+		agg := &sum.New(1)[0]
+		require.NoError(t, agg.Update(ctx, number.NewInt64Number(value), &desc))
+		labels := attribute.NewSet(kvs...)
+		now := time.Now()
+		return export.NewRecord(&desc, &labels, resource.Empty(), agg, now, now)
+	}
+	producer := recordsProducer{
+		newRecord("external.sum", 1, attribute.String("E", "F"), attribute.String("G", "H")),
+		newRecord("external.dropped.sum", 2),
+		newRecord("external.disabled.sum", 3),
+		newRecord("external.kept.sum", 4, attribute.String("E", "F"), attribute.String("I", "J")),
+	}
+	cont := controller.New(
+		newCheckpointer(),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(testResource),
+		controller.WithDeniedAttributeKeys("G"),
+		controller.WithViews(
+			sdk.View{InstrumentName: "external.sum", Name: "renamed.sum"},
+			sdk.View{InstrumentName: "external.dropped.*", Drop: true},
+			sdk.View{InstrumentName: "external.kept.*", AttributeKeys: []attribute.Key{"I"}},
+		),
+		controller.WithProducer(producer),
+	)
+	require.NoError(t, cont.DisableInstruments("external.disabled.*"))
+
+	require.NoError(t, cont.Collect(ctx))
+	out := processortest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, cont.ForEach(export.CumulativeExportKindSelector(), out.AddRecord))
+	require.EqualValues(t, map[string]float64{
+		"renamed.sum/E=F/R=V":       1,
+		"external.kept.sum/I=J/R=V": 4,
+	}, out.Map())
+}
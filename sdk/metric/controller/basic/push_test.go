@@ -103,6 +103,86 @@ func TestPushDoubleStart(t *testing.T) {
 	require.NoError(t, p.Stop(ctx))
 }
 
+func TestPushShutdown(t *testing.T) {
+	ctx := context.Background()
+	exporter := newExporter()
+	readerExporter := newExporter()
+	p := controller.New(
+		newCheckpointer(),
+		controller.WithExporter(exporter),
+		controller.WithCollectPeriod(time.Hour),
+		controller.WithResource(testResource),
+		controller.WithReader(controller.NewPeriodicReader(readerExporter, time.Hour, 0)),
+	)
+	p.SetClock(controllertest.NewMockClock())
+	counter := metric.Must(p.MeterProvider().Meter("name")).NewInt64Counter("counter.sum")
+
+	require.NoError(t, p.Start(ctx))
+	counter.Add(ctx, 3)
+
+	require.NoError(t, p.Shutdown(ctx))
+	require.False(t, p.IsRunning())
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 3,
+	}, exporter.Values())
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 3,
+	}, readerExporter.Values())
+	require.Equal(t, 1, exporter.ExportCount())
+	require.Equal(t, 1, readerExporter.ExportCount())
+
+	// Shutting down again has no effect, starting again fails.
+	require.NoError(t, p.Shutdown(ctx))
+	require.Equal(t, 1, exporter.ExportCount())
+	require.True(t, errors.Is(p.Start(ctx), controller.ErrControllerShutdown))
+}
+
+func TestPushShutdownNotStarted(t *testing.T) {
+	ctx := context.Background()
+	exporter := newExporter()
+	p := controller.New(
+		newCheckpointer(),
+		controller.WithExporter(exporter),
+		controller.WithResource(testResource),
+	)
+	counter := metric.Must(p.MeterProvider().Meter("name")).NewInt64Counter("counter.sum")
+	counter.Add(ctx, 3)
+
+	require.NoError(t, p.Shutdown(ctx))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 3,
+	}, exporter.Values())
+}
+
+func TestPushForceFlush(t *testing.T) {
+	ctx := context.Background()
+	exporter := newExporter()
+	readerExporter := newExporter()
+	p := controller.New(
+		newCheckpointer(),
+		controller.WithExporter(exporter),
+		controller.WithCollectPeriod(time.Hour),
+		controller.WithResource(testResource),
+		controller.WithReader(controller.NewPeriodicReader(readerExporter, time.Hour, 0)),
+	)
+	p.SetClock(controllertest.NewMockClock())
+	counter := metric.Must(p.MeterProvider().Meter("name")).NewInt64Counter("counter.sum")
+
+	require.NoError(t, p.Start(ctx))
+	counter.Add(ctx, 3)
+
+	require.NoError(t, p.ForceFlush(ctx))
+	require.True(t, p.IsRunning())
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 3,
+	}, exporter.Values())
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 3,
+	}, readerExporter.Values())
+
+	require.NoError(t, p.Stop(ctx))
+}
+
 func TestPushTicker(t *testing.T) {
 	exporter := newExporter()
 	checkpointer := newCheckpointer()
@@ -152,6 +232,48 @@ func TestPushTicker(t *testing.T) {
 	require.NoError(t, p.Stop(ctx))
 }
 
+func TestPushJitter(t *testing.T) {
+	exporter := newExporter()
+	checkpointer := newCheckpointer()
+	p := controller.New(
+		checkpointer,
+		controller.WithExporter(exporter),
+		controller.WithCollectPeriod(10*time.Second),
+		controller.WithCollectJitter(5*time.Second),
+		controller.WithResource(testResource),
+	)
+	meter := p.MeterProvider().Meter("name")
+
+	mock := controllertest.NewMockClock()
+	p.SetClock(mock)
+
+	ctx := context.Background()
+
+	counter := metric.Must(meter).NewInt64Counter("counter.sum")
+
+	require.NoError(t, p.Start(ctx))
+
+	counter.Add(ctx, 3)
+
+	mock.Add(10 * time.Second)
+	runtime.Gosched()
+
+	// The export is delayed by less than the jitter.
+	var waited time.Duration
+	for exporter.ExportCount() == 0 && waited < 5*time.Second {
+		mock.Add(100 * time.Millisecond)
+		runtime.Gosched()
+		waited += 100 * time.Millisecond
+	}
+
+	require.Equal(t, 1, exporter.ExportCount())
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 3,
+	}, exporter.Values())
+
+	require.NoError(t, p.Stop(ctx))
+}
+
 func TestPushExportError(t *testing.T) {
 	injector := func(name string, e error) func(r export.Record) error {
 		return func(r export.Record) error {
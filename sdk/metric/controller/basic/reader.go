@@ -0,0 +1,398 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basic // import "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/internal/env"
+	controllerTime "go.opentelemetry.io/otel/sdk/metric/controller/time"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+)
+
+var (
+	// ErrReaderBound indicates that a Reader was added to more than
+	// one Controller.
+	ErrReaderBound = fmt.Errorf("reader already added to a controller")
+
+	// ErrReaderNotBound indicates that a Reader was used before it was
+	// added to a Controller.
+	ErrReaderNotBound = fmt.Errorf("reader not added to a controller")
+)
+
+// Reader reads the metric data of a Controller independently of the
+// other readers of the Controller, with its own export kinds and its own
+// schedule.  Readers are added to a Controller with WithReader.
+//
+// The Readers are PeriodicReader, which pushes metric data to an
+// exporter, and ManualReader, which collects metric data on demand.
+type Reader interface {
+	// ExportKindSelector selects the export kinds of the metric data
+	// read by the Reader.
+	export.ExportKindSelector
+
+	// bind returns the pipeline of the Reader in c.
+	bind(c *Controller) (*pipeline, error)
+	// start starts periodic reading, if any.
+	start(ctx context.Context, clock controllerTime.Clock)
+	// stop stops periodic reading, if any, after a final read.
+	stop(ctx context.Context) error
+	// forceFlush reads and exports the metric data, if the Reader
+	// exports.
+	forceFlush(ctx context.Context) error
+	// shutdown stops periodic reading, if any, and makes a final
+	// forceFlush.
+	shutdown(ctx context.Context) error
+}
+
+// pipeline is a checkpointer receiving the collected metric data of a
+// Controller.
+type pipeline struct {
+	checkpointer export.Checkpointer
+
+	// pending holds the accumulations of synchronous instruments
+	// collected for the other pipelines, until the next collection of
+	// this pipeline.
+	pending map[pendingKey]export.Accumulation
+
+	// produced holds the Records of the Producers of the Controller
+	// read by the last collection of this pipeline.
+	produced []export.Record
+}
+
+type pendingKey struct {
+	descriptor *metric.Descriptor
+	distinct   attribute.Distinct
+	resource   attribute.Distinct
+}
+
+// addPending merges a copy of accum in the pending accumulations.
+func (p *pipeline) addPending(aselector export.AggregatorSelector, accum export.Accumulation) error {
+	desc := accum.Descriptor()
+	key := pendingKey{
+		descriptor: desc,
+		distinct:   accum.Labels().Equivalent(),
+		resource:   accum.Resource().Equivalent(),
+	}
+	pending, ok := p.pending[key]
+	if !ok {
+		if selector := accum.AggregatorSelector(); selector != nil {
+			aselector = selector
+		}
+		var agg export.Aggregator
+		aselector.AggregatorFor(desc, &agg)
+		if agg == nil {
+			return nil
+		}
+		pending = export.NewAccumulationWithSelector(desc, accum.Labels(), accum.Resource(), agg, accum.AggregatorSelector())
+		if p.pending == nil {
+			p.pending = map[pendingKey]export.Accumulation{}
+		}
+		p.pending[key] = pending
+	}
+	return pending.Aggregator().Merge(accum.Aggregator(), desc)
+}
+
+// processPending passes the pending accumulations to the checkpointer.
+func (p *pipeline) processPending() error {
+	var err error
+	for key, accum := range p.pending {
+		if perr := p.checkpointer.Process(accum); perr != nil && err == nil {
+			err = perr
+		}
+		delete(p.pending, key)
+	}
+	return err
+}
+
+// fanout is the Processor of the Accumulator of a Controller, it passes
+// the accumulations to the checkpointer of the pipeline being collected
+// and keeps them pending for the other pipelines.
+type fanout struct {
+	export.AggregatorSelector
+
+	pipelines []*pipeline
+	// active is the pipeline being collected.
+	active *pipeline
+}
+
+var _ export.Processor = &fanout{}
+
+// Process implements export.Processor.
+func (f *fanout) Process(accum export.Accumulation) error {
+	if len(f.pipelines) == 1 {
+		return f.pipelines[0].checkpointer.Process(accum)
+	}
+
+	var err error
+	if accum.Descriptor().InstrumentKind().Synchronous() {
+		for _, p := range f.pipelines {
+			if p == f.active {
+				continue
+			}
+			if perr := p.addPending(f.AggregatorSelector, accum); perr != nil && err == nil {
+				err = perr
+			}
+		}
+	}
+	// Asynchronous instruments are observed again when the other
+	// pipelines are collected.  The checkpointer may keep the
+	// Aggregator until its next collection, while the other
+	// collections reuse it, pass it a copy.
+	var aselector export.AggregatorSelector = f
+	if selector := accum.AggregatorSelector(); selector != nil {
+		aselector = selector
+	}
+	var agg export.Aggregator
+	aselector.AggregatorFor(accum.Descriptor(), &agg)
+	if agg == nil {
+		return err
+	}
+	perr := agg.Merge(accum.Aggregator(), accum.Descriptor())
+	if perr == nil {
+		perr = f.active.checkpointer.Process(export.NewAccumulationWithSelector(accum.Descriptor(), accum.Labels(), accum.Resource(), agg, accum.AggregatorSelector()))
+	}
+	if perr != nil && err == nil {
+		err = perr
+	}
+	return err
+}
+
+// readerBinding is the state shared by the Readers once they are added
+// to a Controller.
+type readerBinding struct {
+	lock       sync.Mutex
+	controller *Controller
+	pipeline   *pipeline
+}
+
+// bindPipeline binds the Reader to c using a Processor with eselector.
+func (b *readerBinding) bindPipeline(c *Controller, eselector export.ExportKindSelector, opts ...processor.Option) (*pipeline, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.controller != nil {
+		return nil, ErrReaderBound
+	}
+	b.controller = c
+	b.pipeline = &pipeline{
+		checkpointer: processor.New(c.checkpointer, eselector, opts...),
+	}
+	return b.pipeline, nil
+}
+
+// bound returns the Controller and the pipeline of the Reader.
+func (b *readerBinding) bound() (*Controller, *pipeline, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.controller == nil {
+		return nil, nil, ErrReaderNotBound
+	}
+	return b.controller, b.pipeline, nil
+}
+
+// collect collects the pipeline of the Reader.
+func (b *readerBinding) collect(ctx context.Context) error {
+	c, p, err := b.bound()
+	if err != nil {
+		return err
+	}
+	return c.collectPipeline(ctx, p, func() bool { return true })
+}
+
+// PeriodicReader is a Reader that collects and exports the metric data
+// of a Controller periodically once the Controller is started.
+type PeriodicReader struct {
+	readerBinding
+
+	exporter export.Exporter
+	interval time.Duration
+	timeout  time.Duration
+
+	wg     sync.WaitGroup
+	stopCh chan struct{}
+	ticker controllerTime.Ticker
+}
+
+var _ Reader = &PeriodicReader{}
+
+// NewPeriodicReader returns a PeriodicReader exporting metric data to
+// exporter every interval.  Each collection and export is subject to
+// timeout.  The EnvExportInterval environment variable, or else
+// DefaultPeriod, is used if interval is not positive, no timeout
+// is applied if timeout is zero.
+func NewPeriodicReader(exporter export.Exporter, interval, timeout time.Duration) *PeriodicReader {
+	if interval <= 0 {
+		interval = env.Duration(otel.MetricsSignal, EnvExportInterval, DefaultPeriod)
+	}
+	return &PeriodicReader{
+		exporter: exporter,
+		interval: interval,
+		timeout:  timeout,
+	}
+}
+
+// ExportKindFor implements export.ExportKindSelector.
+func (r *PeriodicReader) ExportKindFor(desc *metric.Descriptor, kind aggregation.Kind) export.ExportKind {
+	return r.exporter.ExportKindFor(desc, kind)
+}
+
+func (r *PeriodicReader) bind(c *Controller) (*pipeline, error) {
+	return r.bindPipeline(c, r.exporter)
+}
+
+func (r *PeriodicReader) start(ctx context.Context, clock controllerTime.Clock) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.stopCh != nil {
+		return
+	}
+	r.wg.Add(1)
+	r.stopCh = make(chan struct{})
+	r.ticker = clock.Ticker(r.interval)
+	go r.run(ctx, r.stopCh, r.ticker, clock, r.controller.collectJitter)
+}
+
+func (r *PeriodicReader) stop(ctx context.Context) error {
+	if !r.halt() {
+		return nil
+	}
+	return r.export(ctx)
+}
+
+func (r *PeriodicReader) forceFlush(ctx context.Context) error {
+	return r.export(ctx)
+}
+
+func (r *PeriodicReader) shutdown(ctx context.Context) error {
+	r.halt()
+	return r.export(ctx)
+}
+
+// halt stops the periodic export, it returns false if it was not
+// started.
+func (r *PeriodicReader) halt() bool {
+	r.lock.Lock()
+	if r.stopCh == nil {
+		r.lock.Unlock()
+		return false
+	}
+	close(r.stopCh)
+	r.stopCh = nil
+	r.ticker.Stop()
+	r.ticker = nil
+	r.lock.Unlock()
+
+	r.wg.Wait()
+	return true
+}
+
+// run collects and exports on ticker events until the stop channel is
+// closed. Each collection is delayed by a random duration below jitter.
+func (r *PeriodicReader) run(ctx context.Context, stopCh chan struct{}, ticker controllerTime.Ticker, clock controllerTime.Clock, jitter time.Duration) {
+	defer r.wg.Done()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case <-ticker.C():
+			if !waitJitter(clock, jitter, stopCh) {
+				return
+			}
+			if err := r.export(ctx); err != nil {
+				otel.HandleSignal(otel.MetricsSignal, err)
+			}
+		}
+	}
+}
+
+// export collects the metric data and exports it, applying the timeout.
+func (r *PeriodicReader) export(ctx context.Context) error {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+	if err := r.collect(ctx); err != nil {
+		return err
+	}
+
+	ckpt := r.controller.checkpointSet(r.pipeline)
+	ckpt.RLock()
+	defer ckpt.RUnlock()
+	return r.exporter.Export(ctx, ckpt)
+}
+
+// ManualReader is a Reader that collects the metric data of a Controller
+// when its Collect method is called, for pull-based exporters and tests.
+// The metric data of all instruments and label sets collected so far is
+// kept, as pull-based exporters require.
+type ManualReader struct {
+	readerBinding
+
+	eselector export.ExportKindSelector
+}
+
+var _ Reader = &ManualReader{}
+
+// NewManualReader returns a ManualReader of metric data with the export
+// kinds selected by eselector.
+func NewManualReader(eselector export.ExportKindSelector) *ManualReader {
+	return &ManualReader{eselector: eselector}
+}
+
+// ExportKindFor implements export.ExportKindSelector.
+func (r *ManualReader) ExportKindFor(desc *metric.Descriptor, kind aggregation.Kind) export.ExportKind {
+	return r.eselector.ExportKindFor(desc, kind)
+}
+
+func (r *ManualReader) bind(c *Controller) (*pipeline, error) {
+	return r.bindPipeline(c, r.eselector, processor.WithMemory(true))
+}
+
+func (r *ManualReader) start(context.Context, controllerTime.Clock) {}
+
+func (r *ManualReader) stop(context.Context) error { return nil }
+
+func (r *ManualReader) forceFlush(context.Context) error { return nil }
+
+func (r *ManualReader) shutdown(context.Context) error { return nil }
+
+// Collect collects the metric data recorded since the previous call.
+// Collect returns ErrReaderNotBound if the reader was not added to a
+// Controller.
+func (r *ManualReader) Collect(ctx context.Context) error {
+	return r.collect(ctx)
+}
+
+// ForEach gives the caller read-locked access to the metric data of the
+// last collection, see export.CheckpointSet.
+func (r *ManualReader) ForEach(f func(export.Record) error) error {
+	c, p, err := r.bound()
+	if err != nil {
+		return err
+	}
+	ckpt := c.checkpointSet(p)
+	ckpt.RLock()
+	defer ckpt.RUnlock()
+	return ckpt.ForEach(r.eselector, f)
+}
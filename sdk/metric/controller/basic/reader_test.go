@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basic_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	controller "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+	"go.opentelemetry.io/otel/sdk/metric/controller/controllertest"
+	processor "go.opentelemetry.io/otel/sdk/metric/processor/basic"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+)
+
+func TestPeriodicReaders(t *testing.T) {
+	ctx := context.Background()
+	deltas := processortest.NewExporter(export.DeltaExportKindSelector(), attribute.DefaultEncoder())
+	cumulatives := processortest.NewExporter(export.CumulativeExportKindSelector(), attribute.DefaultEncoder())
+
+	cont := controller.New(
+		newCheckpointer(),
+		controller.WithCollectPeriod(time.Hour),
+		controller.WithResource(testResource),
+		controller.WithReader(
+			controller.NewPeriodicReader(deltas, time.Second, 0),
+			controller.NewPeriodicReader(cumulatives, 3*time.Second, time.Second),
+		),
+	)
+	mock := controllertest.NewMockClock()
+	cont.SetClock(mock)
+	meter := cont.MeterProvider().Meter("name")
+	counter := metric.Must(meter).NewInt64Counter("counter.sum")
+
+	require.NoError(t, cont.Start(ctx))
+
+	for i := 1; i <= 3; i++ {
+		counter.Add(ctx, int64(i))
+		mock.Add(time.Second)
+		runtime.Gosched()
+
+		require.EqualValues(t, map[string]float64{
+			"counter.sum//R=V": float64(i),
+		}, deltas.Values())
+		require.Equal(t, 1, deltas.ExportCount())
+		deltas.Reset()
+	}
+
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 6,
+	}, cumulatives.Values())
+	require.Equal(t, 1, cumulatives.ExportCount())
+	cumulatives.Reset()
+
+	// Stopping the controller exports a last time.
+	counter.Add(ctx, 4)
+	require.NoError(t, cont.Stop(ctx))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 4,
+	}, deltas.Values())
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 10,
+	}, cumulatives.Values())
+}
+
+func TestPeriodicReaderJitter(t *testing.T) {
+	ctx := context.Background()
+	exporter := processortest.NewExporter(export.DeltaExportKindSelector(), attribute.DefaultEncoder())
+
+	cont := controller.New(
+		newCheckpointer(),
+		controller.WithCollectPeriod(time.Hour),
+		controller.WithCollectJitter(5*time.Second),
+		controller.WithResource(testResource),
+		controller.WithReader(controller.NewPeriodicReader(exporter, 10*time.Second, 0)),
+	)
+	mock := controllertest.NewMockClock()
+	cont.SetClock(mock)
+	meter := cont.MeterProvider().Meter("name")
+	counter := metric.Must(meter).NewInt64Counter("counter.sum")
+
+	require.NoError(t, cont.Start(ctx))
+
+	counter.Add(ctx, 3)
+	mock.Add(10 * time.Second)
+	runtime.Gosched()
+
+	// The export is delayed by less than the jitter.
+	var waited time.Duration
+	for exporter.ExportCount() == 0 && waited < 5*time.Second {
+		mock.Add(100 * time.Millisecond)
+		runtime.Gosched()
+		waited += 100 * time.Millisecond
+	}
+
+	require.Equal(t, 1, exporter.ExportCount())
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V": 3,
+	}, exporter.Values())
+
+	require.NoError(t, cont.Stop(ctx))
+}
+
+func TestManualReader(t *testing.T) {
+	ctx := context.Background()
+	reader := controller.NewManualReader(export.CumulativeExportKindSelector())
+	cont := controller.New(
+		processor.New(
+			processortest.AggregatorSelector(),
+			export.CumulativeExportKindSelector(),
+		),
+		controller.WithCollectPeriod(0),
+		controller.WithResource(testResource),
+		controller.WithReader(reader),
+	)
+	meter := cont.MeterProvider().Meter("name")
+	counter := metric.Must(meter).NewInt64Counter("counter.sum")
+	var observed int64
+	_ = metric.Must(meter).NewInt64SumObserver("observer.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		observed += 10
+		result.Observe(observed)
+	})
+
+	values := func() map[string]float64 {
+		out := processortest.NewOutput(attribute.DefaultEncoder())
+		require.NoError(t, reader.ForEach(out.AddRecord))
+		return out.Map()
+	}
+
+	counter.Add(ctx, 1)
+	require.NoError(t, reader.Collect(ctx))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V":  1,
+		"observer.sum//R=V": 10,
+	}, values())
+
+	// The collections of the controller and of the reader see all
+	// the synchronous measurements.
+	counter.Add(ctx, 2)
+	require.NoError(t, cont.Collect(ctx))
+	counter.Add(ctx, 3)
+	require.NoError(t, cont.Collect(ctx))
+	require.NoError(t, reader.Collect(ctx))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V":  6,
+		"observer.sum//R=V": 40,
+	}, values())
+
+	out := processortest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, cont.ForEach(export.CumulativeExportKindSelector(), out.AddRecord))
+	require.EqualValues(t, map[string]float64{
+		"counter.sum//R=V":  6,
+		"observer.sum//R=V": 30,
+	}, out.Map())
+}
+
+func TestManualReaderNotBound(t *testing.T) {
+	reader := controller.NewManualReader(export.CumulativeExportKindSelector())
+	err := reader.Collect(context.Background())
+	require.True(t, errors.Is(err, controller.ErrReaderNotBound))
+	err = reader.ForEach(func(export.Record) error { return nil })
+	require.True(t, errors.Is(err, controller.ErrReaderNotBound))
+
+	_ = controller.New(newCheckpointer(), controller.WithReader(reader))
+	require.NoError(t, testHandler.Flush())
+	_ = controller.New(newCheckpointer(), controller.WithReader(reader))
+	require.True(t, errors.Is(testHandler.Flush(), controller.ErrReaderBound))
+}
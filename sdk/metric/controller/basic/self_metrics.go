@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package basic // import "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/unit"
+)
+
+const selfInstrumentationName = "go.opentelemetry.io/otel/sdk/metric/controller/basic"
+
+// CollectSuccessKey is the attribute key of the outcome of a collection
+// recorded by a Controller configured with WithSelfMeterProvider.
+const CollectSuccessKey = attribute.Key("sdk.metric.collect.success")
+
+// selfMetrics are the instruments a Controller records its own
+// collections with. All methods are no-ops on a nil *selfMetrics.
+type selfMetrics struct {
+	collects metric.Int64Counter
+	duration metric.Float64ValueRecorder
+}
+
+func newSelfMetrics(mp metric.MeterProvider) *selfMetrics {
+	m := metric.Must(mp.Meter(selfInstrumentationName))
+	return &selfMetrics{
+		collects: m.NewInt64Counter(
+			"sdk.metric.controller.collects",
+			metric.WithDescription("Number of collections of the Accumulator"),
+			metric.WithUnit(unit.Dimensionless),
+		),
+		duration: m.NewFloat64ValueRecorder(
+			"sdk.metric.controller.collect.duration",
+			metric.WithDescription("Duration of the collections of the Accumulator, including the Producers"),
+			metric.WithUnit(unit.Milliseconds),
+		),
+	}
+}
+
+// recordCollect records a collection that took d and returned err.
+func (sm *selfMetrics) recordCollect(ctx context.Context, d time.Duration, err error) {
+	if sm == nil {
+		return
+	}
+	success := CollectSuccessKey.Bool(err == nil)
+	sm.collects.Add(ctx, 1, success)
+	sm.duration.Record(ctx, float64(d)/float64(time.Millisecond), success)
+}
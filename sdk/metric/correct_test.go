@@ -20,6 +20,7 @@ import (
 	"math"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -89,7 +90,7 @@ func (ts *testSelector) AggregatorFor(desc *metric.Descriptor, aggPtrs ...*expor
 	processortest.AggregatorSelector().AggregatorFor(desc, aggPtrs...)
 }
 
-func newSDK(t *testing.T) (metric.Meter, *metricsdk.Accumulator, *correctnessProcessor) {
+func newSDK(t *testing.T, opts ...metricsdk.Option) (metric.Meter, *metricsdk.Accumulator, *correctnessProcessor) {
 	testHandler.Reset()
 	processor := &correctnessProcessor{
 		t:            t,
@@ -98,6 +99,7 @@ func newSDK(t *testing.T) (metric.Meter, *metricsdk.Accumulator, *correctnessPro
 	accum := metricsdk.NewAccumulator(
 		processor,
 		testResource,
+		opts...,
 	)
 	meter := metric.WrapMeterImpl(accum, "test")
 	return meter, accum, processor
@@ -263,6 +265,90 @@ func TestSDKLabelsDeduplication(t *testing.T) {
 	require.ElementsMatch(t, allExpect, actual)
 }
 
+func TestSDKDeniedAttributeKeys(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t,
+		metricsdk.WithDeniedAttributeKeys("user.email"),
+		metricsdk.WithDeniedAttributeKeys("http.url"),
+	)
+
+	counter := Must(meter).NewInt64Counter("name.sum")
+	recorder := Must(meter).NewInt64ValueRecorder("name.exact")
+	_ = Must(meter).NewInt64SumObserver("name.sumobserver.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1, attribute.String("user.email", "a@example.com"), attribute.String("A", "B"))
+	})
+
+	counter.Add(ctx, 1, attribute.String("user.email", "a@example.com"), attribute.String("A", "B"))
+	counter.Add(ctx, 1, attribute.String("user.email", "b@example.com"), attribute.String("A", "B"))
+	meter.RecordBatch(ctx, []attribute.KeyValue{
+		attribute.String("http.url", "/?token=secret"),
+		attribute.String("A", "B"),
+	}, recorder.Measurement(1))
+
+	sdk.Collect(ctx)
+
+	out := processortest.NewOutput(attribute.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"name.sum/A=B/R=V":             2,
+		"name.exact/A=B/R=V":           1,
+		"name.sumobserver.sum/A=B/R=V": 1,
+	}, out.Map())
+}
+
+func TestSDKDisableInstruments(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	counter := Must(meter).NewInt64Counter("http.requests.sum")
+	recorder := Must(meter).NewInt64ValueRecorder("http.latency.exact")
+	other := Must(meter).NewInt64Counter("db.calls.sum")
+	bound := counter.Bind(attribute.String("A", "B"))
+	defer bound.Unbind()
+	_ = Must(meter).NewInt64SumObserver("http.open.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1, attribute.String("A", "B"))
+	})
+
+	collect := func() map[string]float64 {
+		processor.accumulations = nil
+		sdk.Collect(ctx)
+		out := processortest.NewOutput(attribute.DefaultEncoder())
+		for _, rec := range processor.accumulations {
+			require.NoError(t, out.AddAccumulation(rec))
+		}
+		return out.Map()
+	}
+	record := func() {
+		counter.Add(ctx, 1, attribute.String("A", "B"))
+		bound.Add(ctx, 1)
+		meter.RecordBatch(ctx, []attribute.KeyValue{attribute.String("A", "B")},
+			recorder.Measurement(1), other.Measurement(1))
+	}
+
+	require.Error(t, sdk.DisableInstruments(""))
+	require.NoError(t, sdk.DisableInstruments("http.*"))
+	require.NoError(t, sdk.DisableInstruments("http.*"))
+	require.Equal(t, []string{"http.*"}, sdk.DisabledInstruments())
+
+	record()
+	require.EqualValues(t, map[string]float64{
+		"db.calls.sum/A=B/R=V": 1,
+	}, collect())
+
+	sdk.EnableInstruments("http.*")
+	require.Empty(t, sdk.DisabledInstruments())
+
+	record()
+	require.EqualValues(t, map[string]float64{
+		"http.requests.sum/A=B/R=V":  2,
+		"http.latency.exact/A=B/R=V": 1,
+		"http.open.sum/A=B/R=V":      1,
+		"db.calls.sum/A=B/R=V":       1,
+	}, collect())
+}
+
 func newSetIter(kvs ...attribute.KeyValue) attribute.Iterator {
 	labels := attribute.NewSet(kvs...)
 	return labels.Iter()
@@ -509,6 +595,29 @@ func TestRecordBatch(t *testing.T) {
 	}, out.Map())
 }
 
+func TestGauge(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	gauge := Must(meter).NewInt64Gauge("queue.lastvalue")
+	bound := gauge.Bind(attribute.String("Q", "b"))
+	defer bound.Unbind()
+
+	gauge.Set(ctx, 3, attribute.String("Q", "a"))
+	gauge.Set(ctx, 1, attribute.String("Q", "a"))
+	bound.Set(ctx, -2)
+	sdk.Collect(ctx)
+
+	out := processortest.NewOutput(attribute.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"queue.lastvalue/Q=a/R=V": 1,
+		"queue.lastvalue/Q=b/R=V": -2,
+	}, out.Map())
+}
+
 // TestRecordPersistence ensures that a direct-called instrument that
 // is repeatedly used each interval results in a persistent record, so
 // that its encoded labels will be cached across collection intervals.
@@ -588,3 +697,237 @@ func TestSyncInAsync(t *testing.T) {
 		"observer.lastvalue//R=V": 10,
 	}, out.Map())
 }
+
+func TestUnregisterObserver(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	kept := Must(meter).NewInt64ValueObserver("kept.lastvalue",
+		func(_ context.Context, result metric.Int64ObserverResult) {
+			result.Observe(1)
+		},
+	)
+	removed := Must(meter).NewInt64ValueObserver("removed.lastvalue",
+		func(_ context.Context, result metric.Int64ObserverResult) {
+			result.Observe(2)
+		},
+	)
+	var once metric.Int64ValueObserver
+	once = Must(meter).NewInt64ValueObserver("once.lastvalue",
+		func(_ context.Context, result metric.Int64ObserverResult) {
+			result.Observe(3)
+			// Callbacks can unregister themselves, the
+			// observations of the last run are kept.
+			require.NoError(t, once.Unregister())
+		},
+	)
+
+	require.Equal(t, 3, sdk.Collect(ctx))
+	require.NoError(t, removed.Unregister())
+
+	processor.accumulations = nil
+	require.Equal(t, 1, sdk.Collect(ctx))
+	require.Len(t, processor.accumulations, 1)
+	require.Equal(t, kept.AsyncImpl().Descriptor(), *processor.accumulations[0].Descriptor())
+}
+
+func TestMinimumObserveInterval(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t,
+		metricsdk.WithMinimumObserveInterval(50*time.Millisecond),
+	)
+
+	calls := 0
+	_ = Must(meter).NewInt64SumObserver("observer.sum",
+		func(_ context.Context, result metric.Int64ObserverResult) {
+			calls++
+			result.Observe(int64(calls), attribute.String("A", "B"))
+		},
+	)
+
+	collect := func() map[string]float64 {
+		processor.accumulations = nil
+		require.Equal(t, 1, sdk.Collect(ctx))
+		out := processortest.NewOutput(attribute.DefaultEncoder())
+		for _, rec := range processor.accumulations {
+			require.NoError(t, out.AddAccumulation(rec))
+		}
+		return out.Map()
+	}
+
+	// The collections within the interval export the observations of
+	// the first one.
+	for i := 0; i < 3; i++ {
+		require.EqualValues(t, map[string]float64{
+			"observer.sum/A=B/R=V": 1,
+		}, collect())
+	}
+	require.Equal(t, 1, calls)
+
+	time.Sleep(60 * time.Millisecond)
+	require.EqualValues(t, map[string]float64{
+		"observer.sum/A=B/R=V": 2,
+	}, collect())
+	require.Equal(t, 2, calls)
+}
+
+func TestRegisterCallback(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	batch := Must(meter).NewBatchObserver(nil)
+	cpu := batch.NewFloat64ValueObserver("cpu.lastvalue")
+	memory := batch.NewInt64ValueObserver("memory.lastvalue")
+	_, err := meter.RegisterCallback(
+		[]metric.Asynchronous{cpu, memory},
+		func(_ context.Context, result metric.BatchObserverResult) {
+			result.Observe([]attribute.KeyValue{attribute.String("A", "B")},
+				cpu.Observation(0.5),
+				memory.Observation(1024),
+			)
+		},
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, sdk.Collect(ctx))
+	out := processortest.NewOutput(attribute.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"cpu.lastvalue/A=B/R=V":    0.5,
+		"memory.lastvalue/A=B/R=V": 1024,
+	}, out.Map())
+
+	// Instruments of another SDK cannot be registered.
+	other, _, _ := newSDK(t)
+	foreign := Must(other).NewBatchObserver(nil).NewInt64ValueObserver("foreign.lastvalue")
+	_, err = meter.RegisterCallback([]metric.Asynchronous{foreign}, func(context.Context, metric.BatchObserverResult) {})
+	require.ErrorIs(t, err, metricsdk.ErrUninitializedInstrument)
+}
+
+func TestCardinalityLimit(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t,
+		metricsdk.WithCardinalityLimit(2),
+		metricsdk.WithViews(metricsdk.View{
+			InstrumentName:   "unlimited.sum",
+			CardinalityLimit: -1,
+		}),
+	)
+
+	counter := Must(meter).NewInt64Counter("counter.sum")
+	other := Must(meter).NewInt64Counter("other.sum")
+	unlimited := Must(meter).NewInt64Counter("unlimited.sum")
+	_ = Must(meter).NewInt64SumObserver("observer.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		for i := 0; i < 4; i++ {
+			result.Observe(1, attribute.Int("user", i))
+		}
+	})
+
+	for i := 0; i < 4; i++ {
+		counter.Add(ctx, 1, attribute.Int("user", i))
+		unlimited.Add(ctx, 1, attribute.Int("user", i))
+	}
+	// The labels of a batch are not shared with the overflow series.
+	meter.RecordBatch(ctx, []attribute.KeyValue{attribute.Int("user", 5)},
+		counter.Measurement(1), other.Measurement(1))
+
+	sdk.Collect(ctx)
+	out := processortest.NewOutput(attribute.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		require.NoError(t, out.AddAccumulation(rec))
+	}
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/user=0/R=V":                     1,
+		"counter.sum/user=1/R=V":                     1,
+		"counter.sum/otel.metric.overflow=true/R=V":  3,
+		"other.sum/user=5/R=V":                       1,
+		"unlimited.sum/user=0/R=V":                   1,
+		"unlimited.sum/user=1/R=V":                   1,
+		"unlimited.sum/user=2/R=V":                   1,
+		"unlimited.sum/user=3/R=V":                   1,
+		"observer.sum/user=0/R=V":                    1,
+		"observer.sum/user=1/R=V":                    1,
+		"observer.sum/otel.metric.overflow=true/R=V": 2,
+	}, out.Map())
+
+	// The label sets are released once collected without updates.
+	sdk.Collect(ctx)
+	processor.accumulations = nil
+	counter.Add(ctx, 1, attribute.Int("user", 2))
+	counter.Add(ctx, 1, attribute.Int("user", 3))
+	counter.Add(ctx, 1, attribute.Int("user", 4))
+	sdk.Collect(ctx)
+	out = processortest.NewOutput(attribute.DefaultEncoder())
+	for _, rec := range processor.accumulations {
+		if rec.Descriptor().Name() == "counter.sum" {
+			require.NoError(t, out.AddAccumulation(rec))
+		}
+	}
+	require.EqualValues(t, map[string]float64{
+		"counter.sum/user=2/R=V":                    1,
+		"counter.sum/user=3/R=V":                    1,
+		"counter.sum/otel.metric.overflow=true/R=V": 1,
+	}, out.Map())
+}
+
+func TestBoundHistogramRecordNoAllocs(t *testing.T) {
+	ctx := context.Background()
+	meter, _, _ := newSDK(t)
+
+	fh := metric.Must(meter).NewFloat64ValueRecorder("float64.histogram").Bind(attribute.String("A", "B"))
+	defer fh.Unbind()
+	ih := metric.Must(meter).NewInt64ValueRecorder("int64.histogram").Bind(attribute.String("A", "B"))
+	defer ih.Unbind()
+
+	require.Zero(t, testing.AllocsPerRun(100, func() { fh.Record(ctx, 1.5) }))
+	require.Zero(t, testing.AllocsPerRun(100, func() { ih.Record(ctx, 15) }))
+}
+
+func TestRecordRepeatedLabelsNoAllocs(t *testing.T) {
+	ctx := context.Background()
+	meter, _, _ := newSDK(t)
+
+	counter := metric.Must(meter).NewInt64Counter("int64.sum")
+	labels := []attribute.KeyValue{attribute.String("A", "B"), attribute.Int64("C", 1)}
+	counter.Add(ctx, 1, labels...)
+
+	require.Zero(t, testing.AllocsPerRun(100, func() { counter.Add(ctx, 1, labels...) }))
+}
+
+func TestRecordCachedLabels(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	counter := metric.Must(meter).NewInt64Counter("int64.sum")
+	values := func() map[string]float64 {
+		out := processortest.NewOutput(attribute.DefaultEncoder())
+		for _, rec := range processor.accumulations {
+			require.NoError(t, out.AddAccumulation(rec))
+		}
+		processor.accumulations = nil
+		return out.Map()
+	}
+
+	// The labels in any order are the same label set.
+	counter.Add(ctx, 1, attribute.String("A", "B"), attribute.String("C", "D"))
+	counter.Add(ctx, 2, attribute.String("C", "D"), attribute.String("A", "B"))
+	counter.Add(ctx, 3, attribute.String("A", "B"), attribute.String("C", "D"))
+	counter.Add(ctx, 4, attribute.String("A", "B"), attribute.String("C", "E"))
+	sdk.Collect(ctx)
+	require.EqualValues(t, map[string]float64{
+		"int64.sum/A=B,C=D/R=V": 6,
+		"int64.sum/A=B,C=E/R=V": 4,
+	}, values())
+
+	// Without updates, the records are deleted by the second
+	// collection.  Measurements with the same labels create new ones.
+	sdk.Collect(ctx)
+	sdk.Collect(ctx)
+	counter.Add(ctx, 5, attribute.String("A", "B"), attribute.String("C", "D"))
+	sdk.Collect(ctx)
+	require.EqualValues(t, map[string]float64{
+		"int64.sum/A=B,C=D/R=V": 5,
+	}, values())
+}
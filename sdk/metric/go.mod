@@ -44,6 +44,8 @@ replace go.opentelemetry.io/otel/sdk/export/metric => ../export/metric
 
 replace go.opentelemetry.io/otel/sdk/metric => ./
 
+replace go.opentelemetry.io/otel/sdk/trace/selfmetrics => ../trace/selfmetrics
+
 replace go.opentelemetry.io/otel/trace => ../../trace
 
 require (
@@ -53,4 +55,5 @@ require (
 	go.opentelemetry.io/otel/metric v0.19.0
 	go.opentelemetry.io/otel/sdk v0.19.0
 	go.opentelemetry.io/otel/sdk/export/metric v0.19.0
+	go.opentelemetry.io/otel/trace v0.19.0
 )
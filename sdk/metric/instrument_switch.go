@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric // import "go.opentelemetry.io/otel/sdk/metric"
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/internal/glob"
+)
+
+// errEmptyPattern is returned when disabling instruments with an empty
+// pattern, which would not match any instrument.
+var errEmptyPattern = errors.New("empty instrument name pattern")
+
+// instrumentSwitch holds the instrument name patterns for which recording
+// is disabled. Patterns are matched with glob.Match.
+type instrumentSwitch struct {
+	lock     sync.Mutex
+	patterns []string
+
+	// version is incremented every time patterns changes. Instruments
+	// cache whether they are disabled together with the version the
+	// decision was made for.
+	version int64
+}
+
+// instrumentState is the cached enabled state of an instrument. It packs
+// the instrumentSwitch version the state was computed for, offset by one
+// so that the zero value is never current, and a disabled bit in its
+// lowest bit so both are updated atomically.
+type instrumentState int64
+
+func (sw *instrumentSwitch) disable(pattern string) error {
+	if pattern == "" {
+		return errEmptyPattern
+	}
+	sw.lock.Lock()
+	defer sw.lock.Unlock()
+	for _, p := range sw.patterns {
+		if p == pattern {
+			return nil
+		}
+	}
+	sw.patterns = append(sw.patterns[:len(sw.patterns):len(sw.patterns)], pattern)
+	atomic.AddInt64(&sw.version, 1)
+	return nil
+}
+
+func (sw *instrumentSwitch) enable(pattern string) {
+	sw.lock.Lock()
+	defer sw.lock.Unlock()
+	for i, p := range sw.patterns {
+		if p == pattern {
+			patterns := make([]string, 0, len(sw.patterns)-1)
+			patterns = append(patterns, sw.patterns[:i]...)
+			sw.patterns = append(patterns, sw.patterns[i+1:]...)
+			atomic.AddInt64(&sw.version, 1)
+			return
+		}
+	}
+}
+
+func (sw *instrumentSwitch) disabledPatterns() []string {
+	sw.lock.Lock()
+	defer sw.lock.Unlock()
+	return append([]string(nil), sw.patterns...)
+}
+
+// isDisabled returns whether recording is disabled for the instrument
+// named name, using and updating the decision cached in state.
+func (sw *instrumentSwitch) isDisabled(name string, state *instrumentState) bool {
+	version := atomic.LoadInt64(&sw.version) + 1
+	if cached := atomic.LoadInt64((*int64)(state)); cached>>1 == version {
+		return cached&1 != 0
+	}
+
+	sw.lock.Lock()
+	version = sw.version + 1
+	disabled := false
+	for _, p := range sw.patterns {
+		if glob.Match(p, name) {
+			disabled = true
+			break
+		}
+	}
+	sw.lock.Unlock()
+
+	cached := version << 1
+	if disabled {
+		cached |= 1
+	}
+	atomic.StoreInt64((*int64)(state), cached)
+	return disabled
+}
+
+// DisableInstruments stops recording measurements for all instruments
+// whose name matches pattern, until EnableInstruments is called with the
+// same pattern. A "*" in pattern matches any sequence of characters, for
+// example "http.server.*". This can be used at runtime, e.g. to shed an
+// instrument whose cardinality grew unexpectedly: measurements of
+// disabled instruments are discarded without being aggregated, and their
+// records are released after the next collection. An error is returned
+// if pattern is empty.
+func (m *Accumulator) DisableInstruments(pattern string) error {
+	return m.instrumentSwitch.disable(pattern)
+}
+
+// EnableInstruments removes pattern from the patterns passed to
+// DisableInstruments. Instruments matching another disabled pattern stay
+// disabled.
+func (m *Accumulator) EnableInstruments(pattern string) {
+	m.instrumentSwitch.enable(pattern)
+}
+
+// DisabledInstruments returns the patterns passed to DisableInstruments
+// and not enabled again.
+func (m *Accumulator) DisabledInstruments() []string {
+	return m.instrumentSwitch.disabledPatterns()
+}
+
+// disabled returns whether recording is currently disabled for inst,
+// either because it is dropped by a View or with DisableInstruments.
+func (inst *instrument) disabled() bool {
+	if inst.dropped {
+		return true
+	}
+	return inst.meter.instrumentSwitch.isDisabled(inst.descriptor.Name(), &inst.state)
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric // import "go.opentelemetry.io/otel/sdk/metric"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxCachedLabelSets is the maximum number of labels cached by each
+// synchronous instrument.
+const maxCachedLabelSets = 1024
+
+// labelCache maps the labels of the measurements of a synchronous
+// instrument, as passed by the caller, to the record of their label
+// set.  This spares repeated measurements with the same labels from
+// computing their label set, which allocates, to find their record.
+type labelCache struct {
+	lock    sync.RWMutex
+	entries map[uint64]*cachedLabels
+}
+
+// cachedLabels is a copy of the labels of a measurement and the record
+// of their label set.
+type cachedLabels struct {
+	kvs []attribute.KeyValue
+	rec *record
+}
+
+// equalLabels returns whether a and b are the same labels in the same
+// order.
+func equalLabels(a, b []attribute.KeyValue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// lookup returns a reference to the record cached for kvs, or nil if
+// there is none or if it is no longer mapped.
+func (c *labelCache) lookup(hash uint64, kvs []attribute.KeyValue) *record {
+	c.lock.RLock()
+	entry := c.entries[hash]
+	c.lock.RUnlock()
+
+	if entry == nil || !equalLabels(entry.kvs, kvs) {
+		return nil
+	}
+	if !entry.rec.refMapped.ref() {
+		// The record was unmapped by a collection, it is evicted
+		// when deleted.
+		return nil
+	}
+	return entry.rec
+}
+
+// store caches rec as the record of kvs, a copy of the labels passed by
+// the caller, unless the cache is full or another set of labels with the
+// same hash is cached.
+func (c *labelCache) store(hash uint64, kvs []attribute.KeyValue, rec *record) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if entry, ok := c.entries[hash]; ok {
+		if !equalLabels(entry.kvs, kvs) {
+			return
+		}
+		// Replace the record of the cached labels, which was
+		// unmapped.
+	} else if len(c.entries) >= maxCachedLabelSets {
+		return
+	}
+	if c.entries == nil {
+		c.entries = map[uint64]*cachedLabels{}
+	}
+	c.entries[hash] = &cachedLabels{kvs: kvs, rec: rec}
+	rec.cacheHashes = append(rec.cacheHashes, hash)
+}
+
+// evict removes the cached labels of rec, which is deleted.
+func (c *labelCache) evict(rec *record) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, hash := range rec.cacheHashes {
+		if entry, ok := c.entries[hash]; ok && entry.rec == rec {
+			delete(c.entries, hash)
+		}
+	}
+	rec.cacheHashes = nil
+}
@@ -47,18 +47,28 @@ type (
 		// can be fixed by using the instrument name and kind
 		// instead of the descriptor pointer.  See
 		// https://github.com/open-telemetry/opentelemetry-go/issues/862.
+		//
+		// The labels and resource are keyed by their precomputed
+		// hashes, which spares hashing their Distinct values on
+		// every lookup.  Values of different labels or resources
+		// with the same hashes are chained, see stateValue.next.
 		descriptor *metric.Descriptor
-		distinct   attribute.Distinct
-		resource   attribute.Distinct
+		labels     uint64
+		resource   uint64
 	}
 
 	stateValue struct {
-		// labels corresponds to the stateKey.distinct field.
+		// labels corresponds to the stateKey.labels field.
 		labels *attribute.Set
 
 		// resource corresponds to the stateKey.resource field.
 		resource *resource.Resource
 
+		// next is the next value with the same stateKey, on the
+		// unlikely collision of the hashes of distinct labels or
+		// resources.
+		next *stateValue
+
 		// updated indicates the last sequence number when this value had
 		// Process() called by an accumulator.
 		updated int64
@@ -122,11 +132,18 @@ var _ export.CheckpointSet = &state{}
 var ErrInconsistentState = fmt.Errorf("inconsistent processor state")
 var ErrInvalidExportKind = fmt.Errorf("invalid export kind")
 
+// ErrUnpreparedExportKind is returned by ForEach if the export kind of an
+// instrument requires memory that was not kept because the ExportKindSelector
+// of the Processor did not include that export kind.
+var ErrUnpreparedExportKind = fmt.Errorf("export kind not included by the processor export kind selector")
+
 // New returns a basic Processor that is also a Checkpointer using the provided
 // AggregatorSelector to select Aggregators.  The ExportKindSelector
 // is consulted to determine the kind(s) of exporter that will consume
 // data, so that this Processor can prepare to compute Delta or
-// Cumulative Aggregations as needed.
+// Cumulative Aggregations as needed.  If the CheckpointSet is consumed by
+// several exporters, use an export.CombinedExportKindSelector of all of
+// them; each exporter then selects its own kind in ForEach.
 func New(aselector export.AggregatorSelector, eselector export.ExportKindSelector, opts ...Option) *Processor {
 	now := time.Now()
 	p := &Processor{
@@ -144,6 +161,16 @@ func New(aselector export.AggregatorSelector, eselector export.ExportKindSelecto
 	return p
 }
 
+// aggregatorFor allocates Aggregators holding copies of the Aggregator
+// of accum, selected like it.
+func (b *Processor) aggregatorFor(accum export.Accumulation, aggPtrs ...*export.Aggregator) {
+	if selector := accum.AggregatorSelector(); selector != nil {
+		selector.AggregatorFor(accum.Descriptor(), aggPtrs...)
+		return
+	}
+	b.AggregatorSelector.AggregatorFor(accum.Descriptor(), aggPtrs...)
+}
+
 // Process implements export.Processor.
 func (b *Processor) Process(accum export.Accumulation) error {
 	if b.startedCollection != b.finishedCollection+1 {
@@ -152,14 +179,14 @@ func (b *Processor) Process(accum export.Accumulation) error {
 	desc := accum.Descriptor()
 	key := stateKey{
 		descriptor: desc,
-		distinct:   accum.Labels().Equivalent(),
-		resource:   accum.Resource().Equivalent(),
+		labels:     accum.Labels().Hash(),
+		resource:   accum.Resource().Set().Hash(),
 	}
 	agg := accum.Aggregator()
 
 	// Check if there is an existing value.
-	value, ok := b.state.values[key]
-	if !ok {
+	value := b.state.lookup(key, accum.Labels(), accum.Resource())
+	if value == nil {
 		stateful := b.ExportKindFor(desc, agg.Aggregation().Kind()).MemoryRequired(desc.InstrumentKind())
 
 		newValue := &stateValue{
@@ -168,15 +195,16 @@ func (b *Processor) Process(accum export.Accumulation) error {
 			updated:  b.state.finishedCollection,
 			stateful: stateful,
 			current:  agg,
+			next:     b.state.values[key],
 		}
 		if stateful {
 			if desc.InstrumentKind().PrecomputedSum() {
 				// If we know we need to compute deltas, allocate two aggregators.
-				b.AggregatorFor(desc, &newValue.cumulative, &newValue.delta)
+				b.aggregatorFor(accum, &newValue.cumulative, &newValue.delta)
 			} else {
 				// In this case we are certain not to need a delta, only allocate
 				// a cumulative aggregator.
-				b.AggregatorFor(desc, &newValue.cumulative)
+				b.aggregatorFor(accum, &newValue.cumulative)
 			}
 		}
 		b.state.values[key] = newValue
@@ -233,7 +261,7 @@ func (b *Processor) Process(accum export.Accumulation) error {
 	// before merging below.
 	if !value.currentOwned {
 		tmp := value.current
-		b.AggregatorSelector.AggregatorFor(desc, &value.current)
+		b.aggregatorFor(accum, &value.current)
 		value.currentOwned = true
 		if err := tmp.SynchronizedMove(value.current, desc); err != nil {
 			return err
@@ -244,6 +272,17 @@ func (b *Processor) Process(accum export.Accumulation) error {
 	return value.current.Merge(agg, desc)
 }
 
+// lookup returns the value of key for labels and res, or nil if there is
+// none.
+func (b *state) lookup(key stateKey, labels *attribute.Set, res *resource.Resource) *stateValue {
+	for value := b.values[key]; value != nil; value = value.next {
+		if value.labels.Equals(labels) && value.resource.Equal(res) {
+			return value
+		}
+	}
+	return nil
+}
+
 // CheckpointSet returns the associated CheckpointSet.  Use the
 // CheckpointSet Locker interface to synchronize access to this
 // object.  The CheckpointSet.ForEach() method cannot be called
@@ -271,8 +310,27 @@ func (b *Processor) FinishCollection() error {
 	}
 	defer func() { b.finishedCollection++ }()
 
-	for key, value := range b.values {
-		mkind := key.descriptor.InstrumentKind()
+	for key, head := range b.values {
+		if err := b.finishChain(key, head); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finishChain updates the stateful aggregators of the chain of values of
+// key, starting at head, and removes its expired values.
+func (b *Processor) finishChain(key stateKey, head *stateValue) (err error) {
+	link := &head
+	defer func() {
+		if head == nil {
+			delete(b.values, key)
+		} else {
+			b.values[key] = head
+		}
+	}()
+
+	for value := head; value != nil; value = value.next {
 		stale := value.updated != b.finishedCollection
 		stateless := !value.stateful
 
@@ -285,15 +343,17 @@ func (b *Processor) FinishCollection() error {
 			// This implies that they were not updated
 			// over the previous full collection interval.
 			if stale && stateless && !b.config.Memory {
-				delete(b.values, key)
+				*link = value.next
+				continue
 			}
+			link = &value.next
 			continue
 		}
+		link = &value.next
 
 		// Update Aggregator state to support exporting either a
 		// delta or a cumulative aggregation.
-		var err error
-		if mkind.PrecomputedSum() {
+		if key.descriptor.InstrumentKind().PrecomputedSum() {
 			if currentSubtractor, ok := value.current.(export.Subtractor); ok {
 				// This line is equivalent to:
 				// value.delta = currentSubtractor - value.cumulative
@@ -324,53 +384,66 @@ func (b *state) ForEach(exporter export.ExportKindSelector, f func(export.Record
 	if b.startedCollection != b.finishedCollection {
 		return ErrInconsistentState
 	}
-	for key, value := range b.values {
-		mkind := key.descriptor.InstrumentKind()
-
-		var agg aggregation.Aggregation
-		var start time.Time
+	for key, head := range b.values {
+		for value := head; value != nil; value = value.next {
+			mkind := key.descriptor.InstrumentKind()
 
-		// If the processor does not have Config.Memory and it was not updated
-		// in the prior round, do not visit this value.
-		if !b.config.Memory && value.updated != (b.finishedCollection-1) {
-			continue
-		}
+			var agg aggregation.Aggregation
+			var start time.Time
 
-		ekind := exporter.ExportKindFor(key.descriptor, value.current.Aggregation().Kind())
-		switch ekind {
-		case export.CumulativeExportKind:
-			// If stateful, the sum has been computed.  If stateless, the
-			// input was already cumulative.  Either way, use the checkpointed
-			// value:
-			if value.stateful {
-				agg = value.cumulative.Aggregation()
-			} else {
-				agg = value.current.Aggregation()
+			// If the processor does not have Config.Memory and it was not updated
+			// in the prior round, do not visit this value.
+			if !b.config.Memory && value.updated != (b.finishedCollection-1) {
+				continue
 			}
-			start = b.processStart
 
-		case export.DeltaExportKind:
-			// Precomputed sums are a special case.
-			if mkind.PrecomputedSum() {
-				agg = value.delta.Aggregation()
-			} else {
-				agg = value.current.Aggregation()
+			akind := value.current.Aggregation().Kind()
+			ekind := exporter.ExportKindFor(key.descriptor, akind)
+			if !value.stateful && ekind.MemoryRequired(mkind) {
+				return fmt.Errorf("%v export of %s: %w", ekind, key.descriptor.Name(), ErrUnpreparedExportKind)
 			}
-			start = b.intervalStart
+			switch ekind {
+			case export.CumulativeExportKind:
+				// If stateful, the sum has been computed.  If stateless, the
+				// input was already cumulative.  Either way, use the checkpointed
+				// value:
+				if value.stateful {
+					agg = value.cumulative.Aggregation()
+				} else {
+					agg = value.current.Aggregation()
+				}
+				start = b.processStart
+
+			case export.DeltaExportKind:
+				// The delta of a value not updated in the prior
+				// round is empty, do not visit it even with
+				// Config.Memory.  Last values are not deltas, they
+				// are reported as is.
+				if value.updated != (b.finishedCollection-1) && akind != aggregation.LastValueKind {
+					continue
+				}
+				// Precomputed sums are a special case.
+				if mkind.PrecomputedSum() {
+					agg = value.delta.Aggregation()
+				} else {
+					agg = value.current.Aggregation()
+				}
+				start = b.intervalStart
 
-		default:
-			return fmt.Errorf("%v: %w", ekind, ErrInvalidExportKind)
-		}
+			default:
+				return fmt.Errorf("%v: %w", ekind, ErrInvalidExportKind)
+			}
 
-		if err := f(export.NewRecord(
-			key.descriptor,
-			value.labels,
-			value.resource,
-			agg,
-			start,
-			b.intervalEnd,
-		)); err != nil && !errors.Is(err, aggregation.ErrNoData) {
-			return err
+			if err := f(export.NewRecord(
+				key.descriptor,
+				value.labels,
+				value.resource,
+				agg,
+				start,
+				b.intervalEnd,
+			)); err != nil && !errors.Is(err, aggregation.ErrNoData) {
+				return err
+			}
 		}
 	}
 	return nil
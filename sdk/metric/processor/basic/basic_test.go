@@ -68,6 +68,7 @@ func TestProcessor(t *testing.T) {
 				{kind: metric.SumObserverInstrumentKind},
 				{kind: metric.UpDownSumObserverInstrumentKind},
 				{kind: metric.ValueObserverInstrumentKind},
+				{kind: metric.GaugeInstrumentKind},
 			} {
 				t.Run(ic.kind.String(), func(t *testing.T) {
 					for _, nc := range []numberCase{
@@ -231,8 +232,14 @@ func testProcessor(
 					}
 				}
 
+				// After an empty interval, the memory of
+				// cumulative and last value aggregations is
+				// reported, the deltas are empty.
+				reported := !repetitionAfterEmptyInterval ||
+					(hasMemory && (ekind == export.CumulativeExportKind || akind == aggregation.LastValueKind))
+
 				exp := map[string]float64{}
-				if hasMemory || !repetitionAfterEmptyInterval {
+				if reported {
 					exp = map[string]float64{
 						fmt.Sprintf("inst1%s/L1=V/R=V", instSuffix): float64(multiplier * 10), // labels1
 						fmt.Sprintf("inst2%s/L2=V/R=V", instSuffix): float64(multiplier * 10), // labels2
@@ -435,6 +442,93 @@ func TestStatefulNoMemoryDelta(t *testing.T) {
 	}
 }
 
+func TestCombinedExportKinds(t *testing.T) {
+	res := resource.NewWithAttributes(attribute.String("R", "V"))
+	counter := metric.NewDescriptor("counter.sum", metric.CounterInstrumentKind, number.Int64Kind)
+	observer := metric.NewDescriptor("observer.sum", metric.SumObserverInstrumentKind, number.Int64Kind)
+	selector := processorTest.AggregatorSelector()
+	cumulative := export.CumulativeExportKindSelector()
+	delta := export.DeltaExportKindSelector()
+
+	processor := basic.New(selector, export.CombinedExportKindSelector(cumulative, delta))
+	checkpointSet := processor.CheckpointSet()
+
+	for i := 1; i < 3; i++ {
+		processor.StartCollection()
+		// The counter adds 10 and the observer observes a
+		// cumulative sum growing by 10 every interval.
+		_ = processor.Process(updateFor(t, &counter, selector, res, 10, attribute.String("A", "B")))
+		_ = processor.Process(updateFor(t, &observer, selector, res, int64(i*10), attribute.String("A", "B")))
+		require.NoError(t, processor.FinishCollection())
+
+		records := processorTest.NewOutput(attribute.DefaultEncoder())
+		require.NoError(t, checkpointSet.ForEach(cumulative, records.AddRecord))
+		require.EqualValues(t, map[string]float64{
+			"counter.sum/A=B/R=V":  float64(i * 10),
+			"observer.sum/A=B/R=V": float64(i * 10),
+		}, records.Map())
+
+		records = processorTest.NewOutput(attribute.DefaultEncoder())
+		require.NoError(t, checkpointSet.ForEach(delta, records.AddRecord))
+		require.EqualValues(t, map[string]float64{
+			"counter.sum/A=B/R=V":  10,
+			"observer.sum/A=B/R=V": 10,
+		}, records.Map())
+	}
+}
+
+func TestUnpreparedExportKind(t *testing.T) {
+	res := resource.NewWithAttributes(attribute.String("R", "V"))
+	desc := metric.NewDescriptor("observer.sum", metric.SumObserverInstrumentKind, number.Int64Kind)
+	selector := processorTest.AggregatorSelector()
+
+	processor := basic.New(selector, export.CumulativeExportKindSelector())
+	processor.StartCollection()
+	_ = processor.Process(updateFor(t, &desc, selector, res, 10))
+	require.NoError(t, processor.FinishCollection())
+
+	err := processor.CheckpointSet().ForEach(export.DeltaExportKindSelector(), func(export.Record) error {
+		return nil
+	})
+	require.True(t, errors.Is(err, basic.ErrUnpreparedExportKind))
+}
+
+func TestDeltaMemorySkipsStale(t *testing.T) {
+	res := resource.NewWithAttributes(attribute.String("R", "V"))
+	desc := metric.NewDescriptor("observer.sum", metric.SumObserverInstrumentKind, number.Int64Kind)
+	selector := processorTest.AggregatorSelector()
+	delta := export.DeltaExportKindSelector()
+
+	processor := basic.New(selector, delta, basic.WithMemory(true))
+	checkpointSet := processor.CheckpointSet()
+
+	processor.StartCollection()
+	_ = processor.Process(updateFor(t, &desc, selector, res, 10))
+	require.NoError(t, processor.FinishCollection())
+
+	records := processorTest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, checkpointSet.ForEach(delta, records.AddRecord))
+	require.EqualValues(t, map[string]float64{"observer.sum//R=V": 10}, records.Map())
+
+	// The prior delta must not be reported again for an interval
+	// without updates.
+	processor.StartCollection()
+	require.NoError(t, processor.FinishCollection())
+
+	records = processorTest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, checkpointSet.ForEach(delta, records.AddRecord))
+	require.EqualValues(t, map[string]float64{}, records.Map())
+
+	// The remembered cumulative value is the base of the next delta.
+	processor.StartCollection()
+	_ = processor.Process(updateFor(t, &desc, selector, res, 25))
+	require.NoError(t, processor.FinishCollection())
+
+	records = processorTest.NewOutput(attribute.DefaultEncoder())
+	require.NoError(t, checkpointSet.ForEach(delta, records.AddRecord))
+	require.EqualValues(t, map[string]float64{"observer.sum//R=V": 15}, records.Map())
+}
+
 func TestMultiObserverSum(t *testing.T) {
 	for _, ekindSel := range []export.ExportKindSelector{
 		export.CumulativeExportKindSelector(),
@@ -523,3 +617,39 @@ func TestSumObserverEndToEnd(t *testing.T) {
 	requireNotAfter(t, endTime[0], endTime[1])
 	requireNotAfter(t, endTime[1], endTime[2])
 }
+
+// BenchmarkProcessHighCardinality measures a collection of a counter with
+// many distinct label sets, as keyed by the processor.
+func BenchmarkProcessHighCardinality(b *testing.B) {
+	const n = 10000
+	res := resource.NewWithAttributes(attribute.String("service.name", "frontend"))
+	desc := metric.NewDescriptor("inst.sum", metric.CounterInstrumentKind, number.Int64Kind)
+	selector := processorTest.AggregatorSelector()
+	accums := make([]export.Accumulation, n)
+	for i := range accums {
+		ls := attribute.NewSet(
+			attribute.String("http.method", "GET"),
+			attribute.String("http.route", fmt.Sprintf("/api/v1/items/%d", i)),
+			attribute.Int("http.status_code", 200+i%5),
+			attribute.String("net.host.name", "frontend.example.com"),
+		)
+		var agg export.Aggregator
+		selector.AggregatorFor(&desc, &agg)
+		accums[i] = export.NewAccumulation(&desc, &ls, res, agg)
+	}
+
+	processor := basic.New(selector, export.CumulativeExportKindSelector())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		processor.StartCollection()
+		for _, accum := range accums {
+			if err := processor.Process(accum); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if err := processor.FinishCollection(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
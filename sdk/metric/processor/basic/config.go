@@ -20,6 +20,8 @@ type Config struct {
 	// instruments and label sets that were previously reported.
 	// When Memory is true, CheckpointSet.ForEach() will visit
 	// metrics that were not updated in the most recent interval.
+	// Delta aggregations of such metrics are empty and are not
+	// visited, except for last values.
 	Memory bool
 }
 
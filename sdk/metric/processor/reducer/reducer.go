@@ -57,11 +57,12 @@ func (p *Processor) Process(accum export.Accumulation) error {
 		),
 	)
 	return p.Checkpointer.Process(
-		export.NewAccumulation(
+		export.NewAccumulationWithSelector(
 			accum.Descriptor(),
 			&reduced,
 			accum.Resource(),
 			accum.Aggregator(),
+			accum.AggregatorSelector(),
 		),
 	)
 }
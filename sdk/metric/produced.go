@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric // import "go.opentelemetry.io/otel/sdk/metric"
+
+import export "go.opentelemetry.io/otel/sdk/export/metric"
+
+// ApplyViews returns the Record r, produced by a source external to the
+// Accumulator, as the Accumulator records the measurements of its own
+// instruments: the labels with a denied attribute key are removed, the
+// first View selecting the instrument of r is applied, and r is associated
+// with the Resource of the Accumulator. False is returned if r is dropped
+// by a View or its instrument is disabled with DisableInstruments.
+//
+// The Aggregation and CardinalityLimit of the View are not applied, since
+// r is already aggregated. The Records left with equal labels once their
+// labels are filtered are returned separately.
+func (m *Accumulator) ApplyViews(r export.Record) (export.Record, bool) {
+	stream := m.resolveView(*r.Descriptor())
+	var state instrumentState
+	if stream.drop || m.instrumentSwitch.isDisabled(stream.descriptor.Name(), &state) {
+		return export.Record{}, false
+	}
+
+	labels := r.Labels()
+	if m.labelFilter != nil {
+		filtered, _ := labels.Filter(m.labelFilter)
+		labels = &filtered
+	}
+	if stream.labelFilter != nil {
+		filtered, _ := labels.Filter(stream.labelFilter)
+		labels = &filtered
+	}
+
+	m.resourceLock.Lock()
+	res := m.resource
+	m.resourceLock.Unlock()
+	return export.NewRecord(
+		&stream.descriptor,
+		labels,
+		res,
+		r.Aggregation(),
+		r.StartTime(),
+		r.EndTime(),
+	), true
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric // import "go.opentelemetry.io/otel/sdk/metric"
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/number"
+)
+
+// Int64TotalObserverFunc is a callback that observes the absolute totals of
+// an integer counter source that may reset, such as counters read from
+// /proc or from a process that can restart.
+type Int64TotalObserverFunc func(ctx context.Context, observe func(total int64, labels ...attribute.KeyValue))
+
+// Float64TotalObserverFunc is a callback that observes the absolute totals
+// of a floating point counter source that may reset.
+type Float64TotalObserverFunc func(ctx context.Context, observe func(total float64, labels ...attribute.KeyValue))
+
+// NewInt64ResetAwareObserverFunc returns an Int64ObserverFunc for use with
+// an Int64SumObserver that reports the totals observed by f as a monotonic
+// sum. Whenever a total observed for a label set is lower than the previous
+// one, the source is considered to have reset and the previous total is
+// carried over, so the sum keeps growing and the computed deltas stay
+// correct.
+//
+// The returned function keeps state for every observed label set, it must
+// only be used with a single instrument.
+func NewInt64ResetAwareObserverFunc(f Int64TotalObserverFunc) metric.Int64ObserverFunc {
+	t := newResetTracker(number.Int64Kind)
+	return func(ctx context.Context, result metric.Int64ObserverResult) {
+		f(ctx, func(total int64, labels ...attribute.KeyValue) {
+			n := t.adjust(number.NewInt64Number(total), labels)
+			result.Observe(n.AsInt64(), labels...)
+		})
+	}
+}
+
+// NewFloat64ResetAwareObserverFunc returns a Float64ObserverFunc for use
+// with a Float64SumObserver that reports the totals observed by f as a
+// monotonic sum. See NewInt64ResetAwareObserverFunc for details.
+func NewFloat64ResetAwareObserverFunc(f Float64TotalObserverFunc) metric.Float64ObserverFunc {
+	t := newResetTracker(number.Float64Kind)
+	return func(ctx context.Context, result metric.Float64ObserverResult) {
+		f(ctx, func(total float64, labels ...attribute.KeyValue) {
+			n := t.adjust(number.NewFloat64Number(total), labels)
+			result.Observe(n.AsFloat64(), labels...)
+		})
+	}
+}
+
+// resetState is the state of a single label set of a resetTracker.
+type resetState struct {
+	// last is the previously observed total.
+	last number.Number
+	// offset is the sum of all totals observed before a reset.
+	offset number.Number
+}
+
+// resetTracker converts absolute totals that may reset into monotonic sums.
+type resetTracker struct {
+	kind number.Kind
+
+	lock   sync.Mutex
+	states map[attribute.Distinct]*resetState
+}
+
+func newResetTracker(kind number.Kind) *resetTracker {
+	return &resetTracker{
+		kind:   kind,
+		states: make(map[attribute.Distinct]*resetState),
+	}
+}
+
+// adjust records total as the latest total observed for labels and returns
+// the monotonic sum for labels.
+func (t *resetTracker) adjust(total number.Number, labels []attribute.KeyValue) number.Number {
+	set := attribute.NewSet(labels...)
+	key := set.Equivalent()
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	state, ok := t.states[key]
+	if !ok {
+		state = &resetState{offset: t.kind.Zero()}
+		t.states[key] = state
+	} else if total.CompareNumber(t.kind, state.last) < 0 {
+		// The source reset, carry over what was counted before.
+		state.offset.AddNumber(t.kind, state.last)
+	}
+	state.last = total
+
+	sum := state.offset
+	sum.AddNumber(t.kind, total)
+	return sum
+}
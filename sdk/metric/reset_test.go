@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+)
+
+func TestResetAwareObserverFunc(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	var step int
+	intTotals := [][]int64{{5, 1}, {8, 2}, {3, 4}, {6, 0}}
+	floatTotals := []float64{1.5, 0.5, 2}
+
+	_ = Must(meter).NewInt64SumObserver("int.sumobserver.sum", metricsdk.NewInt64ResetAwareObserverFunc(
+		func(_ context.Context, observe func(int64, ...attribute.KeyValue)) {
+			observe(intTotals[step][0], attribute.String("A", "B"))
+			observe(intTotals[step][1])
+		},
+	))
+	_ = Must(meter).NewFloat64SumObserver("float.sumobserver.sum", metricsdk.NewFloat64ResetAwareObserverFunc(
+		func(_ context.Context, observe func(float64, ...attribute.KeyValue)) {
+			if step < len(floatTotals) {
+				observe(floatTotals[step])
+			}
+		},
+	))
+
+	collect := func() map[string]float64 {
+		processor.accumulations = nil
+		sdk.Collect(ctx)
+		out := processortest.NewOutput(attribute.DefaultEncoder())
+		for _, rec := range processor.accumulations {
+			require.NoError(t, out.AddAccumulation(rec))
+		}
+		return out.Map()
+	}
+
+	want := []map[string]float64{
+		{"int.sumobserver.sum/A=B/R=V": 5, "int.sumobserver.sum//R=V": 1, "float.sumobserver.sum//R=V": 1.5},
+		{"int.sumobserver.sum/A=B/R=V": 8, "int.sumobserver.sum//R=V": 2, "float.sumobserver.sum//R=V": 2},
+		// A=B reset from 8 to 3.
+		{"int.sumobserver.sum/A=B/R=V": 11, "int.sumobserver.sum//R=V": 4, "float.sumobserver.sum//R=V": 3.5},
+		// The unlabeled counter reset from 4 to 0.
+		{"int.sumobserver.sum/A=B/R=V": 14, "int.sumobserver.sum//R=V": 4},
+	}
+	for step = 0; step < len(want); step++ {
+		require.EqualValues(t, want[step], collect(), "step %d", step)
+	}
+}
+
+func TestResetAwareObserverFuncSameTotal(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newSDK(t)
+
+	f := metricsdk.NewInt64ResetAwareObserverFunc(func(_ context.Context, observe func(int64, ...attribute.KeyValue)) {
+		observe(7)
+	})
+	_ = Must(meter).NewInt64SumObserver("int.sumobserver.sum", f)
+
+	for i := 0; i < 3; i++ {
+		processor.accumulations = nil
+		sdk.Collect(ctx)
+		out := processortest.NewOutput(attribute.DefaultEncoder())
+		for _, rec := range processor.accumulations {
+			require.NoError(t, out.AddAccumulation(rec))
+		}
+		// An unchanged total is not a reset.
+		require.EqualValues(t, map[string]float64{"int.sumobserver.sum//R=V": 7}, out.Map())
+	}
+}
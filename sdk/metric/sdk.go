@@ -20,6 +20,7 @@ import (
 	"runtime"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -29,6 +30,7 @@ import (
 	export "go.opentelemetry.io/otel/sdk/export/metric"
 	"go.opentelemetry.io/otel/sdk/metric/aggregator"
 	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type (
@@ -67,10 +69,47 @@ type (
 
 		// resource is applied to all records in this Accumulator.
 		resource *resource.Resource
+		// resourceLock protects resource from SetResource while
+		// the views of new instruments are resolved, which may
+		// happen during Collect().
+		resourceLock sync.Mutex
+
+		// labelFilter removes the denied attribute keys from the
+		// labels of all measurements. It is nil if no keys are
+		// denied.
+		labelFilter attribute.Filter
+
+		// instrumentSwitch holds the patterns of the instruments
+		// for which recording is disabled.
+		instrumentSwitch instrumentSwitch
+
+		// views are applied to the instruments when they are
+		// created.
+		views []View
+
+		// cardinalityLimit is the maximum number of label sets
+		// of the instruments not selected by a View setting one.
+		cardinalityLimit int
+
+		// minimumObserveInterval is the minimum interval between
+		// two runs of the asynchronous instrument callbacks.
+		minimumObserveInterval time.Duration
+
+		// observedTime is the time the asynchronous instrument
+		// callbacks last ran, it is zero if they never ran.
+		observedTime time.Time
 	}
 
 	syncInstrument struct {
+		// cardinality is the number of label sets of the
+		// instrument counted towards its cardinality limit.
+		cardinality int64
+
 		instrument
+
+		// labelCache finds the records of repeated measurements
+		// without computing their label set.
+		labelCache labelCache
 	}
 
 	// mapkey uniquely describes a metric instrument in terms of
@@ -107,14 +146,18 @@ type (
 		// `RecordBatch`.
 		labels *attribute.Set
 
-		// sortSlice has a single purpose - as a temporary
-		// place for sorting during labels creation to avoid
-		// allocation.
-		sortSlice attribute.Sortable
+		// cacheHashes are the hashes of the labels cached for
+		// this record by the labelCache of inst.  It is
+		// protected by the lock of the labelCache.
+		cacheHashes []uint64
 
 		// inst is a pointer to the corresponding instrument.
 		inst *syncInstrument
 
+		// counted is set if the record is counted in the
+		// cardinality of inst.
+		counted bool
+
 		// current implements the actual RecordOne() API,
 		// depending on the type of aggregation.  If nil, the
 		// metric was disabled by the exporter.
@@ -123,8 +166,30 @@ type (
 	}
 
 	instrument struct {
-		meter      *Accumulator
+		meter *Accumulator
+		// descriptor describes the data produced by the
+		// instrument, after the View selecting it was applied.
 		descriptor metric.Descriptor
+
+		// dropped is set if the instrument is dropped by a View.
+		dropped bool
+		// aggregatorSelector selects the Aggregation of the View
+		// selecting the instrument, it is nil if the processor
+		// selects the aggregation.
+		aggregatorSelector export.AggregatorSelector
+		// viewFilter restricts the labels of the instrument to
+		// the keys of the View selecting it, it is nil if all
+		// labels are kept.
+		viewFilter attribute.Filter
+
+		// state caches whether recording is disabled for the
+		// instrument by the instrumentSwitch of meter.
+		state instrumentState
+
+		// cardinalityLimit is the maximum number of label sets
+		// of the instrument, there is no limit if it is not
+		// positive.
+		cardinalityLimit int
 	}
 
 	asyncInstrument struct {
@@ -142,14 +207,29 @@ type (
 )
 
 var (
-	_ metric.MeterImpl     = &Accumulator{}
-	_ metric.AsyncImpl     = &asyncInstrument{}
-	_ metric.SyncImpl      = &syncInstrument{}
-	_ metric.BoundSyncImpl = &record{}
+	_ metric.MeterImpl         = &Accumulator{}
+	_ metric.AsyncRegisterer   = &Accumulator{}
+	_ metric.AsyncUnregisterer = &Accumulator{}
+	_ metric.AsyncImpl         = &asyncInstrument{}
+	_ metric.SyncImpl          = &syncInstrument{}
+	_ metric.BoundSyncImpl     = &record{}
 
 	ErrUninitializedInstrument = fmt.Errorf("use of an uninitialized instrument")
+
+	// ErrResourceAfterCollect is returned when the Resource of an
+	// Accumulator is changed after it has been collected.
+	ErrResourceAfterCollect = fmt.Errorf("resource cannot be changed after the first collection")
+
+	// overflowLabels is the label set of the measurements of an
+	// instrument exceeding its cardinality limit.
+	overflowLabels = attribute.NewSet(attribute.Bool(string(OverflowAttributeKey), true))
 )
 
+// OverflowAttributeKey is the key of the label identifying the series
+// aggregating the measurements of an instrument with label sets beyond
+// its cardinality limit.
+const OverflowAttributeKey attribute.Key = "otel.metric.overflow"
+
 func (inst *instrument) Descriptor() metric.Descriptor {
 	return inst.descriptor
 }
@@ -163,10 +243,17 @@ func (s *syncInstrument) Implementation() interface{} {
 }
 
 func (a *asyncInstrument) observe(num number.Number, labels *attribute.Set) {
+	if a.disabled() {
+		return
+	}
 	if err := aggregator.RangeTest(num, &a.descriptor); err != nil {
-		otel.Handle(err)
+		otel.HandleSignal(otel.MetricsSignal, err)
 		return
 	}
+	if a.viewFilter != nil {
+		filtered, _ := labels.Filter(a.viewFilter)
+		labels = &filtered
+	}
 	recorder := a.getRecorder(labels)
 	if recorder == nil {
 		// The instrument is disabled according to the
@@ -174,22 +261,31 @@ func (a *asyncInstrument) observe(num number.Number, labels *attribute.Set) {
 		return
 	}
 	if err := recorder.Update(context.Background(), num, &a.descriptor); err != nil {
-		otel.Handle(err)
+		otel.HandleSignal(otel.MetricsSignal, err)
 		return
 	}
 }
 
 func (a *asyncInstrument) getRecorder(labels *attribute.Set) export.Aggregator {
 	lrec, ok := a.recorders[labels.Equivalent()]
+	if !ok && a.cardinalityLimit > 0 && a.cardinality() >= a.cardinalityLimit {
+		labels = &overflowLabels
+		lrec, ok = a.recorders[labels.Equivalent()]
+	}
 	if ok {
-		// Note: SynchronizedMove(nil) can't return an error
-		_ = lrec.observed.SynchronizedMove(nil, &a.descriptor)
+		// The last observation of a label set replaces the
+		// previous ones, the overflow recorder aggregates all the
+		// observations of a collection.
+		if lrec.labels != &overflowLabels || lrec.observedEpoch != a.meter.currentEpoch {
+			// Note: SynchronizedMove(nil) can't return an error
+			_ = lrec.observed.SynchronizedMove(nil, &a.descriptor)
+		}
 		lrec.observedEpoch = a.meter.currentEpoch
 		a.recorders[labels.Equivalent()] = lrec
 		return lrec.observed
 	}
 	var rec export.Aggregator
-	a.meter.processor.AggregatorFor(&a.descriptor, &rec)
+	a.aggregatorFor(&rec)
 	if a.recorders == nil {
 		a.recorders = make(map[attribute.Distinct]*labeledRecorder)
 	}
@@ -204,6 +300,53 @@ func (a *asyncInstrument) getRecorder(labels *attribute.Set) export.Aggregator {
 	return rec
 }
 
+// cardinality returns the number of label sets of a counted towards its
+// cardinality limit.
+func (a *asyncInstrument) cardinality() int {
+	n := len(a.recorders)
+	if _, ok := a.recorders[overflowLabels.Equivalent()]; ok {
+		n--
+	}
+	return n
+}
+
+// reserveLabelSet returns whether a new label set is within the
+// cardinality limit of s, counting it if so.
+func (s *syncInstrument) reserveLabelSet() bool {
+	if atomic.AddInt64(&s.cardinality, 1) <= int64(s.cardinalityLimit) {
+		return true
+	}
+	atomic.AddInt64(&s.cardinality, -1)
+	return false
+}
+
+// sortablePool holds the temporaries used for sorting during labels
+// creation, to avoid an allocation.
+var sortablePool = sync.Pool{
+	New: func() interface{} {
+		return new(attribute.Sortable)
+	},
+}
+
+// acquireCachedHandle gets or creates a `*record` corresponding to
+// `kvs`, the input labels, like acquireHandle.  The record of labels
+// already measured in the same order is found in the labelCache of
+// the instrument, which does not allocate.
+func (s *syncInstrument) acquireCachedHandle(kvs []attribute.KeyValue) *record {
+	hash := attribute.HashKeyValues(kvs)
+	if rec := s.labelCache.lookup(hash, kvs); rec != nil {
+		return rec
+	}
+	// Copy the labels before acquireHandle reorders them.
+	cached := make([]attribute.KeyValue, len(kvs))
+	copy(cached, kvs)
+	rec := s.acquireHandle(kvs, nil)
+	if rec.labels != &overflowLabels {
+		s.labelCache.store(hash, cached, rec)
+	}
+	return rec
+}
+
 // acquireHandle gets or creates a `*record` corresponding to `kvs`,
 // the input labels.  The second argument `labels` is passed in to
 // support re-use of the orderedLabels computed by a previous
@@ -215,10 +358,11 @@ func (s *syncInstrument) acquireHandle(kvs []attribute.KeyValue, labelPtr *attri
 
 	if labelPtr == nil {
 		// This memory allocation may not be used, but it's
-		// needed for the `sortSlice` field, to avoid an
-		// allocation while sorting.
+		// needed for the `storage` field.
 		rec = &record{}
-		rec.storage = attribute.NewSetWithSortable(kvs, &rec.sortSlice)
+		tmp := sortablePool.Get().(*attribute.Sortable)
+		s.newLabelSet(kvs, tmp, &rec.storage)
+		sortablePool.Put(tmp)
 		rec.labels = &rec.storage
 		equiv = rec.storage.Equivalent()
 	} else {
@@ -243,14 +387,25 @@ func (s *syncInstrument) acquireHandle(kvs []attribute.KeyValue, labelPtr *attri
 		// This entry is no longer mapped, try to add a new entry.
 	}
 
+	if s.cardinalityLimit > 0 && labelPtr != &overflowLabels {
+		if !s.reserveLabelSet() {
+			// Aggregate the measurements of new label sets
+			// into the overflow series beyond the limit.
+			return s.acquireHandle(nil, &overflowLabels)
+		}
+	}
+
 	if rec == nil {
 		rec = &record{}
 		rec.labels = labelPtr
 	}
+	rec.counted = s.cardinalityLimit > 0 && labelPtr != &overflowLabels
 	rec.refMapped = refcountMapped{value: 2}
 	rec.inst = s
 
-	s.meter.processor.AggregatorFor(&s.descriptor, &rec.current, &rec.checkpoint)
+	if !s.dropped {
+		s.aggregatorFor(&rec.current, &rec.checkpoint)
+	}
 
 	for {
 		// Load/Store: there's a memory allocation to place `mk` into
@@ -262,6 +417,9 @@ func (s *syncInstrument) acquireHandle(kvs []attribute.KeyValue, labelPtr *attri
 			if oldRec.refMapped.ref() {
 				// At this moment it is guaranteed that the entry is in
 				// the map and will not be removed.
+				if rec.counted {
+					atomic.AddInt64(&s.cardinality, -1)
+				}
 				return oldRec
 			}
 			// This loaded entry is marked as unmapped (so Collect will remove
@@ -287,9 +445,12 @@ func (s *syncInstrument) Bind(kvs []attribute.KeyValue) metric.BoundSyncImpl {
 }
 
 func (s *syncInstrument) RecordOne(ctx context.Context, num number.Number, kvs []attribute.KeyValue) {
-	h := s.acquireHandle(kvs, nil)
+	if s.disabled() {
+		return
+	}
+	h := s.acquireCachedHandle(kvs)
 	defer h.Unbind()
-	h.RecordOne(ctx, num)
+	h.RecordOne(s.withFilteredAttributes(ctx, kvs), num)
 }
 
 // NewAccumulator constructs a new Accumulator for the given
@@ -301,31 +462,139 @@ func (s *syncInstrument) RecordOne(ctx context.Context, num number.Number, kvs [
 // processor will call Collect() when it receives a request to scrape
 // current metric values.  A push-based processor should configure its
 // own periodic collection.
-func NewAccumulator(processor export.Processor, resource *resource.Resource) *Accumulator {
+//
+// Labels with a key denied by the WithDeniedAttributeKeys option are
+// removed from all measurements before they are aggregated.  The views
+// passed with the WithViews option are applied to every instrument when
+// it is created.  The asynchronous instrument callbacks run at most once
+// per interval set with the WithMinimumObserveInterval option.
+func NewAccumulator(processor export.Processor, resource *resource.Resource, opts ...Option) *Accumulator {
+	c := &Config{}
+	for _, opt := range opts {
+		opt.Apply(c)
+	}
+	// A nil filter keeps the labels without filtering them.
+	var labelFilter attribute.Filter
+	if len(c.DeniedAttributeKeys) > 0 {
+		labelFilter = attribute.NewDenyKeysFilter(c.DeniedAttributeKeys...)
+	}
 	return &Accumulator{
 		processor:        processor,
 		asyncInstruments: internal.NewAsyncInstrumentState(),
 		resource:         resource,
+		labelFilter:      labelFilter,
+		views:            validViews(c.Views),
+		cardinalityLimit: c.CardinalityLimit,
+
+		minimumObserveInterval: c.MinimumObserveInterval,
 	}
 }
 
+// newLabelSet stores the label set of a measurement with the labels kvs in
+// dst, using tmp to sort them.
+func (m *Accumulator) newLabelSet(kvs []attribute.KeyValue, tmp *attribute.Sortable, dst *attribute.Set) {
+	if m.labelFilter == nil {
+		*dst = attribute.NewSetWithSortable(kvs, tmp)
+		return
+	}
+	*dst, _ = attribute.NewSetWithSortableFiltered(kvs, tmp, m.labelFilter)
+}
+
+// newLabelSet stores the label set of a measurement of inst with the
+// labels kvs in dst, using tmp to sort them.
+func (inst *instrument) newLabelSet(kvs []attribute.KeyValue, tmp *attribute.Sortable, dst *attribute.Set) {
+	inst.meter.newLabelSet(kvs, tmp, dst)
+	if inst.viewFilter != nil {
+		*dst, _ = dst.Filter(inst.viewFilter)
+	}
+}
+
+// withFilteredAttributes returns ctx with the labels of kvs removed by the
+// filters of inst, to be retained by the exemplar of the measurement.  ctx
+// is returned as is if no label is removed or if the measurement cannot be
+// an exemplar.
+func (inst *instrument) withFilteredAttributes(ctx context.Context, kvs []attribute.KeyValue) context.Context {
+	denied, view := inst.meter.labelFilter, inst.viewFilter
+	if denied == nil && view == nil {
+		return ctx
+	}
+	if !trace.SpanContextFromContext(ctx).IsSampled() {
+		return ctx
+	}
+	var filtered []attribute.KeyValue
+	for _, kv := range kvs {
+		if (denied != nil && !denied(kv)) || (view != nil && !view(kv)) {
+			filtered = append(filtered, kv)
+		}
+	}
+	if len(filtered) == 0 {
+		return ctx
+	}
+	return aggregator.ContextWithFilteredAttributes(ctx, filtered)
+}
+
+// newInstrument returns the instrument of m described by descriptor,
+// applying the View selecting it.
+func (m *Accumulator) newInstrument(descriptor metric.Descriptor) instrument {
+	stream := m.resolveView(descriptor)
+	return instrument{
+		meter:              m,
+		descriptor:         stream.descriptor,
+		dropped:            stream.drop,
+		aggregatorSelector: stream.aggregatorSelector,
+		viewFilter:         stream.labelFilter,
+		cardinalityLimit:   stream.cardinalityLimit,
+	}
+}
+
+// aggregatorFor selects the Aggregators of the instrument, using the
+// Aggregation of its View if it has one.
+func (inst *instrument) aggregatorFor(aggPtrs ...*export.Aggregator) {
+	if inst.aggregatorSelector != nil {
+		inst.aggregatorSelector.AggregatorFor(&inst.descriptor, aggPtrs...)
+		return
+	}
+	inst.meter.processor.AggregatorFor(&inst.descriptor, aggPtrs...)
+}
+
+// Resource returns the Resource applied to all records of this Accumulator.
+func (m *Accumulator) Resource() *resource.Resource {
+	m.collectLock.Lock()
+	defer m.collectLock.Unlock()
+	return m.resource
+}
+
+// SetResource replaces the Resource applied to all records of this
+// Accumulator.  This allows the Resource to be determined asynchronously
+// after instrumentation has been set up.  Because the Resource identifies
+// the exported time series, it can only be changed until the first call
+// to Collect, after which ErrResourceAfterCollect is returned. The
+// ResourceAttributes of the views are matched against the Resource when
+// each instrument is created, so the views of the instruments created
+// earlier are not resolved again.
+func (m *Accumulator) SetResource(res *resource.Resource) error {
+	m.collectLock.Lock()
+	defer m.collectLock.Unlock()
+	if m.currentEpoch > 0 {
+		return ErrResourceAfterCollect
+	}
+	m.resourceLock.Lock()
+	m.resource = res
+	m.resourceLock.Unlock()
+	return nil
+}
+
 // NewSyncInstrument implements metric.MetricImpl.
 func (m *Accumulator) NewSyncInstrument(descriptor metric.Descriptor) (metric.SyncImpl, error) {
 	return &syncInstrument{
-		instrument: instrument{
-			descriptor: descriptor,
-			meter:      m,
-		},
+		instrument: m.newInstrument(descriptor),
 	}, nil
 }
 
 // NewAsyncInstrument implements metric.MetricImpl.
 func (m *Accumulator) NewAsyncInstrument(descriptor metric.Descriptor, runner metric.AsyncRunner) (metric.AsyncImpl, error) {
 	a := &asyncInstrument{
-		instrument: instrument{
-			descriptor: descriptor,
-			meter:      m,
-		},
+		instrument: m.newInstrument(descriptor),
 	}
 	m.asyncLock.Lock()
 	defer m.asyncLock.Unlock()
@@ -333,6 +602,26 @@ func (m *Accumulator) NewAsyncInstrument(descriptor metric.Descriptor, runner me
 	return a, nil
 }
 
+// RegisterAsync implements metric.AsyncRegisterer. ErrUninitializedInstrument
+// is returned if an instrument was not created by this Accumulator.
+func (m *Accumulator) RegisterAsync(runner metric.AsyncBatchRunner, instruments ...metric.AsyncImpl) error {
+	for _, inst := range instruments {
+		if a, ok := inst.Implementation().(*asyncInstrument); !ok || a.meter != m {
+			return ErrUninitializedInstrument
+		}
+	}
+	m.asyncInstruments.RegisterBatch(runner, instruments...)
+	return nil
+}
+
+// UnregisterAsync implements metric.AsyncUnregisterer.
+func (m *Accumulator) UnregisterAsync(runner metric.AsyncRunner) error {
+	// The asyncLock is held while callbacks run, the state has its
+	// own lock so that callbacks can unregister themselves.
+	m.asyncInstruments.Unregister(runner)
+	return nil
+}
+
 // Collect traverses the list of active records and observers and
 // exports data for each active instrument.  Collect() may not be
 // called concurrently.
@@ -381,6 +670,10 @@ func (m *Accumulator) collectSyncInstruments() int {
 		// entry in the map, they are busy calling Gosched() awaiting
 		// this deletion:
 		m.current.Delete(inuse.mapkey())
+		inuse.inst.labelCache.evict(inuse)
+		if inuse.counted {
+			atomic.AddInt64(&inuse.inst.cardinality, -1)
+		}
 
 		// There's a potential race between `LoadInt64` and
 		// `tryUnmap` in this function.  Since this is the
@@ -397,7 +690,8 @@ func (m *Accumulator) collectSyncInstruments() int {
 
 // CollectAsync implements internal.AsyncCollector.
 func (m *Accumulator) CollectAsync(kv []attribute.KeyValue, obs ...metric.Observation) {
-	labels := attribute.NewSetWithSortable(kv, &m.asyncSortSlice)
+	var labels attribute.Set
+	m.newLabelSet(kv, &m.asyncSortSlice, &labels)
 
 	for _, ob := range obs {
 		if a := m.fromAsync(ob.AsyncImpl()); a != nil {
@@ -412,9 +706,18 @@ func (m *Accumulator) observeAsyncInstruments(ctx context.Context) int {
 
 	asyncCollected := 0
 
-	m.asyncInstruments.Run(ctx, m)
+	// The instruments are listed before running the callbacks, the
+	// observations of a callback unregistering itself are kept.
+	instruments := m.asyncInstruments.Instruments()
+	now := time.Now()
+	if m.minimumObserveInterval > 0 && !m.observedTime.IsZero() && now.Sub(m.observedTime) < m.minimumObserveInterval {
+		m.reuseObservations(instruments)
+	} else {
+		m.asyncInstruments.Run(ctx, m)
+		m.observedTime = now
+	}
 
-	for _, inst := range m.asyncInstruments.Instruments() {
+	for _, inst := range instruments {
 		if a := m.fromAsync(inst); a != nil {
 			asyncCollected += m.checkpointAsync(a)
 		}
@@ -423,20 +726,36 @@ func (m *Accumulator) observeAsyncInstruments(ctx context.Context) int {
 	return asyncCollected
 }
 
+// reuseObservations carries the observations of the previous collection
+// over to the current one, in place of running the callbacks again.
+func (m *Accumulator) reuseObservations(instruments []metric.AsyncImpl) {
+	for _, inst := range instruments {
+		a := m.fromAsync(inst)
+		if a == nil {
+			continue
+		}
+		for _, lrec := range a.recorders {
+			if lrec.observedEpoch == m.currentEpoch-1 {
+				lrec.observedEpoch = m.currentEpoch
+			}
+		}
+	}
+}
+
 func (m *Accumulator) checkpointRecord(r *record) int {
 	if r.current == nil {
 		return 0
 	}
 	err := r.current.SynchronizedMove(r.checkpoint, &r.inst.descriptor)
 	if err != nil {
-		otel.Handle(err)
+		otel.HandleSignal(otel.MetricsSignal, err)
 		return 0
 	}
 
-	a := export.NewAccumulation(&r.inst.descriptor, r.labels, m.resource, r.checkpoint)
+	a := export.NewAccumulationWithSelector(&r.inst.descriptor, r.labels, m.resource, r.checkpoint, r.inst.aggregatorSelector)
 	err = m.processor.Process(a)
 	if err != nil {
-		otel.Handle(err)
+		otel.HandleSignal(otel.MetricsSignal, err)
 	}
 	return 1
 }
@@ -451,10 +770,10 @@ func (m *Accumulator) checkpointAsync(a *asyncInstrument) int {
 		epochDiff := m.currentEpoch - lrec.observedEpoch
 		if epochDiff == 0 {
 			if lrec.observed != nil {
-				a := export.NewAccumulation(&a.descriptor, lrec.labels, m.resource, lrec.observed)
+				a := export.NewAccumulationWithSelector(&a.descriptor, lrec.labels, m.resource, lrec.observed, a.aggregatorSelector)
 				err := m.processor.Process(a)
 				if err != nil {
-					otel.Handle(err)
+					otel.HandleSignal(otel.MetricsSignal, err)
 				}
 				checkpointed++
 			}
@@ -478,20 +797,29 @@ func (m *Accumulator) RecordBatch(ctx context.Context, kvs []attribute.KeyValue,
 	// previously computed value instead of recomputing the
 	// ordered labels.
 	var labelsPtr *attribute.Set
-	for i, meas := range measurements {
+	for _, meas := range measurements {
 		s := m.fromSync(meas.SyncImpl())
-		if s == nil {
+		if s == nil || s.disabled() {
+			continue
+		}
+		if s.viewFilter != nil {
+			// The labels of this instrument are restricted
+			// by a View, they cannot be shared.
+			h := s.acquireHandle(kvs, nil)
+			defer h.Unbind()
+			h.RecordOne(s.withFilteredAttributes(ctx, kvs), meas.Number())
 			continue
 		}
 		h := s.acquireHandle(kvs, labelsPtr)
 
-		// Re-use labels for the next measurement.
-		if i == 0 {
+		// Re-use labels for the next measurement, unless they
+		// were replaced because of the cardinality limit.
+		if labelsPtr == nil && h.labels != &overflowLabels {
 			labelsPtr = h.labels
 		}
 
 		defer h.Unbind()
-		h.RecordOne(ctx, meas.Number())
+		h.RecordOne(s.withFilteredAttributes(ctx, kvs), meas.Number())
 	}
 }
 
@@ -501,12 +829,15 @@ func (r *record) RecordOne(ctx context.Context, num number.Number) {
 		// The instrument is disabled according to the AggregatorSelector.
 		return
 	}
+	if r.inst.disabled() {
+		return
+	}
 	if err := aggregator.RangeTest(num, &r.inst.descriptor); err != nil {
-		otel.Handle(err)
+		otel.HandleSignal(otel.MetricsSignal, err)
 		return
 	}
 	if err := r.current.Update(ctx, num, &r.inst.descriptor); err != nil {
-		otel.Handle(err)
+		otel.HandleSignal(otel.MetricsSignal, err)
 		return
 	}
 	// Record was modified, inform the Collect() that things need
@@ -534,7 +865,7 @@ func (m *Accumulator) fromSync(sync metric.SyncImpl) *syncInstrument {
 			return inst
 		}
 	}
-	otel.Handle(ErrUninitializedInstrument)
+	otel.HandleSignal(otel.MetricsSignal, ErrUninitializedInstrument)
 	return nil
 }
 
@@ -546,6 +877,6 @@ func (m *Accumulator) fromAsync(async metric.AsyncImpl) *asyncInstrument {
 			return inst
 		}
 	}
-	otel.Handle(ErrUninitializedInstrument)
+	otel.HandleSignal(otel.MetricsSignal, ErrUninitializedInstrument)
 	return nil
 }
@@ -37,6 +37,7 @@ var (
 	testUpDownSumObserverDesc = metric.NewDescriptor("updownsumobserver", metric.UpDownSumObserverInstrumentKind, number.Int64Kind)
 	testValueRecorderDesc     = metric.NewDescriptor("valuerecorder", metric.ValueRecorderInstrumentKind, number.Int64Kind)
 	testValueObserverDesc     = metric.NewDescriptor("valueobserver", metric.ValueObserverInstrumentKind, number.Int64Kind)
+	testGaugeDesc             = metric.NewDescriptor("gauge", metric.GaugeInstrumentKind, number.Int64Kind)
 )
 
 func oneAgg(sel export.AggregatorSelector, desc *metric.Descriptor) export.Aggregator {
@@ -47,6 +48,7 @@ func oneAgg(sel export.AggregatorSelector, desc *metric.Descriptor) export.Aggre
 
 func testFixedSelectors(t *testing.T, sel export.AggregatorSelector) {
 	require.IsType(t, (*lastvalue.Aggregator)(nil), oneAgg(sel, &testValueObserverDesc))
+	require.IsType(t, (*lastvalue.Aggregator)(nil), oneAgg(sel, &testGaugeDesc))
 	require.IsType(t, (*sum.Aggregator)(nil), oneAgg(sel, &testCounterDesc))
 	require.IsType(t, (*sum.Aggregator)(nil), oneAgg(sel, &testUpDownCounterDesc))
 	require.IsType(t, (*sum.Aggregator)(nil), oneAgg(sel, &testSumObserverDesc))
@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric // import "go.opentelemetry.io/otel/sdk/metric"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal/glob"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/ddsketch"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exact"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exponential"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/histogram"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/lastvalue"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/minmaxsumcount"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/sum"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// View customizes the metric data produced for the instruments it
+// selects. An instrument is selected by a View if its name, the name
+// and version of the instrumentation library that created it, and the
+// Resource of the Accumulator all match. A View with only InstrumentationName and Drop set discards all
+// the measurements of a library.
+//
+// Only the first View selecting an instrument applies to it. The zero
+// value fields of a View keep the default behavior.
+type View struct {
+	// InstrumentName selects instruments by name, using a pattern
+	// where "*" matches any sequence of characters, e.g.
+	// "http.server.*". An empty value selects every instrument.
+	InstrumentName string
+	// InstrumentationName selects instruments created by the
+	// instrumentation libraries with a matching name, using a pattern
+	// where "*" matches any sequence of characters, including "/", e.g.
+	// "github.com/example/*". An empty value selects instruments of any
+	// instrumentation library.
+	InstrumentationName string
+	// InstrumentationVersion selects instruments created by the
+	// instrumentation library with this version. An empty value
+	// selects instruments of any version.
+	InstrumentationVersion string
+	// ResourceAttributes selects instruments only if the Resource of
+	// the Accumulator has all these attributes, e.g. to use expensive
+	// aggregations only where deployment.environment is "prod". The
+	// Resource is matched when the instrument is created. An empty
+	// value selects instruments with any Resource.
+	ResourceAttributes []attribute.KeyValue
+
+	// Name replaces the name of the selected instrument. It can only be
+	// set if InstrumentName selects a single instrument name.
+	Name string
+	// Description replaces the description of the selected
+	// instruments.
+	Description string
+	// Drop discards all measurements of the selected instruments.
+	Drop bool
+	// Aggregation selects the aggregation of the selected instruments:
+	// aggregation.SumKind, LastValueKind, HistogramKind,
+	// ExponentialHistogramKind, SummaryKind, MinMaxSumCountKind, or
+	// ExactKind. An empty value uses the AggregatorSelector of the
+	// processor.
+	//
+	// Precomputed sums, i.e. SumObserver and UpDownSumObserver
+	// instruments, can only use the SumKind or LastValueKind
+	// aggregations. Instruments recording non-additive values, i.e.
+	// ValueObserver and Gauge instruments, cannot use SumKind. The
+	// Aggregation of the View is not applied, and an error is handled,
+	// when an instrument it cannot be used for is created.
+	Aggregation aggregation.Kind
+	// HistogramBoundaries are the bucket boundaries used with the
+	// HistogramKind Aggregation. The histogram defaults apply if empty.
+	HistogramBoundaries []float64
+	// ExponentialHistogramMaxSize is the maximum number of buckets of
+	// each sign used with the ExponentialHistogramKind Aggregation.
+	// The exponential histogram default applies if zero.
+	ExponentialHistogramMaxSize int32
+	// SummaryQuantiles are the quantiles reported with the SummaryKind
+	// Aggregation. The summary defaults apply if empty.
+	SummaryQuantiles []float64
+	// SummaryRelativeAccuracy is the relative accuracy of the
+	// quantiles estimated by the SummaryKind Aggregation. The summary
+	// default applies if zero.
+	SummaryRelativeAccuracy float64
+	// ExemplarStrategy creates the Reservoirs of the exemplars of the
+	// SumKind and HistogramKind Aggregations. The aggregator defaults
+	// apply if nil.
+	ExemplarStrategy exemplar.Strategy
+	// DisableExemplars disables the exemplars of the SumKind and
+	// HistogramKind Aggregations.
+	DisableExemplars bool
+	// AttributeKeys are the only label keys kept for the measurements
+	// of the selected instruments. All labels are kept if nil.
+	AttributeKeys []attribute.Key
+	// CardinalityLimit is the maximum number of label sets of each
+	// of the selected instruments, see WithCardinalityLimit. The limit
+	// of the Accumulator applies if zero, a negative value removes it.
+	CardinalityLimit int
+}
+
+// validate returns an error if v cannot be applied.
+func (v *View) validate() error {
+	if v.Name != "" && (v.InstrumentName == "" || !glob.IsLiteral(v.InstrumentName)) {
+		return fmt.Errorf("invalid view instrument name %q: renaming requires a single instrument name", v.InstrumentName)
+	}
+	switch v.Aggregation {
+	case "", aggregation.SumKind, aggregation.LastValueKind, aggregation.HistogramKind,
+		aggregation.ExponentialHistogramKind, aggregation.SummaryKind, aggregation.MinMaxSumCountKind,
+		aggregation.ExactKind:
+	default:
+		return fmt.Errorf("invalid view aggregation %q for instrument name %q", v.Aggregation, v.InstrumentName)
+	}
+	for _, q := range v.SummaryQuantiles {
+		if !(q >= 0 && q <= 1) {
+			return fmt.Errorf("invalid view summary quantile %v for instrument name %q", q, v.InstrumentName)
+		}
+	}
+	if !(v.SummaryRelativeAccuracy >= 0 && v.SummaryRelativeAccuracy < 1) {
+		return fmt.Errorf("invalid view summary relative accuracy %v for instrument name %q", v.SummaryRelativeAccuracy, v.InstrumentName)
+	}
+	return nil
+}
+
+// selects returns whether v selects the instrument described by desc
+// created by an Accumulator with the Resource res.
+func (v *View) selects(desc *metric.Descriptor, res *resource.Resource) bool {
+	if !v.matchesLibrary(desc) || !v.matchesResource(res) {
+		return false
+	}
+	return v.matchesName(desc.Name())
+}
+
+// matchesResource returns whether res has all the ResourceAttributes of
+// v.
+func (v *View) matchesResource(res *resource.Resource) bool {
+	if len(v.ResourceAttributes) == 0 {
+		return true
+	}
+	set := res.Set()
+	for _, kv := range v.ResourceAttributes {
+		if value, ok := set.Value(kv.Key); !ok || value != kv.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesLibrary returns whether the instrumentation library of desc
+// matches v.
+func (v *View) matchesLibrary(desc *metric.Descriptor) bool {
+	if v.InstrumentationVersion != "" && v.InstrumentationVersion != desc.InstrumentationVersion() {
+		return false
+	}
+	if v.InstrumentationName == "" {
+		return true
+	}
+	return glob.Match(v.InstrumentationName, desc.InstrumentationName())
+}
+
+func (v *View) matchesName(name string) bool {
+	if v.InstrumentName == "" {
+		return true
+	}
+	return glob.Match(v.InstrumentName, name)
+}
+
+// apply returns the descriptor of the data produced by v for the
+// instrument described by desc.
+func (v *View) apply(desc metric.Descriptor) metric.Descriptor {
+	if v.Name == "" && v.Description == "" {
+		return desc
+	}
+	name, description := desc.Name(), desc.Description()
+	if v.Name != "" {
+		name = v.Name
+	}
+	if v.Description != "" {
+		description = v.Description
+	}
+	return metric.NewDescriptor(name, desc.InstrumentKind(), desc.NumberKind(),
+		metric.WithDescription(description),
+		metric.WithUnit(desc.Unit()),
+		metric.WithInstrumentationName(desc.InstrumentationName()),
+		metric.WithInstrumentationVersion(desc.InstrumentationVersion()),
+		metric.WithSchemaURL(desc.InstrumentationSchemaURL()),
+		metric.WithHistogramBoundaries(desc.HistogramBoundaries()...),
+	)
+}
+
+// validViews returns the views that can be applied, handling an error
+// for the others.
+func validViews(views []View) []View {
+	valid := make([]View, 0, len(views))
+	for _, v := range views {
+		if err := v.validate(); err != nil {
+			otel.HandleSignal(otel.MetricsSignal, err)
+			continue
+		}
+		valid = append(valid, v)
+	}
+	return valid
+}
+
+// checkAggregation returns an error if the Aggregation of v cannot be
+// used for the instrument described by desc.
+func (v *View) checkAggregation(desc *metric.Descriptor) error {
+	kind := desc.InstrumentKind()
+	switch {
+	case v.Aggregation == "", v.Aggregation == aggregation.LastValueKind:
+		return nil
+	case v.Aggregation == aggregation.SumKind:
+		if kind == metric.ValueObserverInstrumentKind || kind == metric.GaugeInstrumentKind {
+			break
+		}
+		return nil
+	case !kind.PrecomputedSum():
+		return nil
+	}
+	return fmt.Errorf("invalid view aggregation %q for %s instrument %q", v.Aggregation, kind, desc.Name())
+}
+
+// viewStream is the result of applying the first View selecting an
+// instrument.
+type viewStream struct {
+	descriptor metric.Descriptor
+	// aggregatorSelector selects the Aggregation of the View, it is
+	// nil if the processor selects the aggregation.
+	aggregatorSelector export.AggregatorSelector
+	drop               bool
+	// labelFilter restricts the labels to the keys of the View, it is
+	// nil if all labels are kept.
+	labelFilter attribute.Filter
+	// cardinalityLimit is the maximum number of label sets, there
+	// is no limit if it is not positive.
+	cardinalityLimit int
+}
+
+// resolveView applies the first of the views of m selecting the
+// instrument described by desc.
+func (m *Accumulator) resolveView(desc metric.Descriptor) viewStream {
+	m.resourceLock.Lock()
+	res := m.resource
+	m.resourceLock.Unlock()
+	for i := range m.views {
+		v := &m.views[i]
+		if !v.selects(&desc, res) {
+			continue
+		}
+		stream := viewStream{
+			descriptor:       v.apply(desc),
+			drop:             v.Drop,
+			cardinalityLimit: m.cardinalityLimit,
+		}
+		if v.AttributeKeys != nil {
+			stream.labelFilter = attribute.NewAllowKeysFilter(v.AttributeKeys...)
+		}
+		if v.CardinalityLimit != 0 {
+			stream.cardinalityLimit = v.CardinalityLimit
+		}
+		if err := v.checkAggregation(&desc); err != nil {
+			otel.HandleSignal(otel.MetricsSignal, err)
+		} else if v.Aggregation != "" {
+			stream.aggregatorSelector = viewAggregatorSelector{view: v}
+		}
+		return stream
+	}
+	return viewStream{descriptor: desc, cardinalityLimit: m.cardinalityLimit}
+}
+
+// viewAggregatorSelector selects the Aggregation of a View for the
+// instruments it selects.
+type viewAggregatorSelector struct {
+	view *View
+}
+
+var _ export.AggregatorSelector = viewAggregatorSelector{}
+
+// AggregatorFor implements export.AggregatorSelector.
+func (s viewAggregatorSelector) AggregatorFor(descriptor *metric.Descriptor, aggPtrs ...*export.Aggregator) {
+	s.view.aggregatorsFor(descriptor, aggPtrs)
+}
+
+// exemplarStrategy returns the exemplar Strategy of v and true, or false
+// if the aggregator default applies.
+func (v *View) exemplarStrategy() (exemplar.Strategy, bool) {
+	if v.DisableExemplars {
+		return nil, true
+	}
+	return v.ExemplarStrategy, v.ExemplarStrategy != nil
+}
+
+// aggregatorsFor stores Aggregators of the Aggregation of v in aggPtrs.
+func (v *View) aggregatorsFor(descriptor *metric.Descriptor, aggPtrs []*export.Aggregator) {
+	switch v.Aggregation {
+	case aggregation.SumKind:
+		var opts []sum.Option
+		if strategy, ok := v.exemplarStrategy(); ok {
+			opts = append(opts, sum.WithExemplarStrategy(strategy))
+		}
+		aggs := sum.New(len(aggPtrs), opts...)
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	case aggregation.LastValueKind:
+		aggs := lastvalue.New(len(aggPtrs))
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	case aggregation.HistogramKind:
+		var opts []histogram.Option
+		if len(v.HistogramBoundaries) > 0 {
+			opts = append(opts, histogram.WithExplicitBoundaries(v.HistogramBoundaries))
+		}
+		if strategy, ok := v.exemplarStrategy(); ok {
+			opts = append(opts, histogram.WithExemplarStrategy(strategy))
+		}
+		aggs := histogram.New(len(aggPtrs), descriptor, opts...)
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	case aggregation.ExponentialHistogramKind:
+		var opts []exponential.Option
+		if v.ExponentialHistogramMaxSize > 0 {
+			opts = append(opts, exponential.WithMaxSize(v.ExponentialHistogramMaxSize))
+		}
+		aggs := exponential.New(len(aggPtrs), descriptor, opts...)
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	case aggregation.SummaryKind:
+		var opts []ddsketch.Option
+		if len(v.SummaryQuantiles) > 0 {
+			opts = append(opts, ddsketch.WithQuantiles(v.SummaryQuantiles))
+		}
+		if v.SummaryRelativeAccuracy > 0 {
+			opts = append(opts, ddsketch.WithRelativeAccuracy(v.SummaryRelativeAccuracy))
+		}
+		aggs := ddsketch.New(len(aggPtrs), descriptor, opts...)
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	case aggregation.MinMaxSumCountKind:
+		aggs := minmaxsumcount.New(len(aggPtrs), descriptor)
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	case aggregation.ExactKind:
+		aggs := exact.New(len(aggPtrs))
+		for i := range aggPtrs {
+			*aggPtrs[i] = &aggs[i]
+		}
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metric_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	export "go.opentelemetry.io/otel/sdk/export/metric"
+	"go.opentelemetry.io/otel/sdk/export/metric/aggregation"
+	metricsdk "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/aggregator/exemplar"
+	"go.opentelemetry.io/otel/sdk/metric/processor/processortest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// viewProcessor keeps the accumulations it processes.
+type viewProcessor struct {
+	export.AggregatorSelector
+	accumulations map[string]export.Accumulation
+}
+
+func (p *viewProcessor) Process(accum export.Accumulation) error {
+	p.accumulations[accum.Descriptor().Name()] = accum
+	return nil
+}
+
+func newViewSDK(views ...metricsdk.View) (metric.Meter, *metricsdk.Accumulator, *viewProcessor) {
+	testHandler.Reset()
+	processor := &viewProcessor{
+		AggregatorSelector: processortest.AggregatorSelector(),
+		accumulations:      map[string]export.Accumulation{},
+	}
+	accum := metricsdk.NewAccumulator(processor, testResource, metricsdk.WithViews(views...))
+	return metric.WrapMeterImpl(accum, "test"), accum, processor
+}
+
+func TestViewRename(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newViewSDK(metricsdk.View{
+		InstrumentName: "requests.sum",
+		Name:           "http.requests.sum",
+		Description:    "Number of requests",
+	})
+
+	counter := Must(meter).NewInt64Counter("requests.sum", metric.WithDescription("requests"), metric.WithUnit("1"))
+	other := Must(meter).NewInt64Counter("other.sum")
+	counter.Add(ctx, 1)
+	other.Add(ctx, 1)
+	sdk.Collect(ctx)
+
+	require.Len(t, processor.accumulations, 2)
+	accum, ok := processor.accumulations["http.requests.sum"]
+	require.True(t, ok)
+	desc := accum.Descriptor()
+	assert.Equal(t, "Number of requests", desc.Description())
+	assert.Equal(t, "1", string(desc.Unit()))
+	assert.Equal(t, "test", desc.InstrumentationName())
+	assert.Equal(t, metric.CounterInstrumentKind, desc.InstrumentKind())
+	assert.Contains(t, processor.accumulations, "other.sum")
+}
+
+func TestViewDrop(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newViewSDK(metricsdk.View{
+		InstrumentName: "noisy.*",
+		Drop:           true,
+	})
+
+	counter := Must(meter).NewInt64Counter("noisy.sum")
+	bound := counter.Bind(attribute.String("A", "B"))
+	defer bound.Unbind()
+	_ = Must(meter).NewInt64SumObserver("noisy.observer.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1)
+	})
+	kept := Must(meter).NewInt64Counter("kept.sum")
+
+	counter.Add(ctx, 1)
+	bound.Add(ctx, 1)
+	meter.RecordBatch(ctx, nil, counter.Measurement(1), kept.Measurement(1))
+	sdk.Collect(ctx)
+
+	require.Len(t, processor.accumulations, 1)
+	assert.Contains(t, processor.accumulations, "kept.sum")
+}
+
+func TestViewDropLibrary(t *testing.T) {
+	ctx := context.Background()
+	_, sdk, processor := newViewSDK(
+		metricsdk.View{InstrumentationName: "github.com/noisy/*", InstrumentationVersion: "v1", Drop: true},
+	)
+
+	noisy := metric.WrapMeterImpl(sdk, "github.com/noisy/lib", metric.WithInstrumentationVersion("v1"))
+	newer := metric.WrapMeterImpl(sdk, "github.com/noisy/lib", metric.WithInstrumentationVersion("v2"))
+	other := metric.WrapMeterImpl(sdk, "github.com/other/lib", metric.WithInstrumentationVersion("v1"))
+
+	Must(noisy).NewInt64Counter("noisy.sum").Add(ctx, 1)
+	_ = Must(noisy).NewInt64SumObserver("noisy.observer.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1)
+	})
+	Must(newer).NewInt64Counter("newer.sum").Add(ctx, 1)
+	Must(other).NewInt64Counter("other.sum").Add(ctx, 1)
+	sdk.Collect(ctx)
+
+	require.Len(t, processor.accumulations, 2)
+	assert.Contains(t, processor.accumulations, "newer.sum")
+	assert.Contains(t, processor.accumulations, "other.sum")
+}
+
+func TestViewResourceAttributes(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newViewSDK(
+		metricsdk.View{ResourceAttributes: []attribute.KeyValue{attribute.String("R", "other")}, Drop: true},
+		metricsdk.View{
+			InstrumentName:     "kept.*",
+			ResourceAttributes: []attribute.KeyValue{attribute.String("R", "V")},
+			Aggregation:        aggregation.HistogramKind,
+		},
+	)
+
+	Must(meter).NewFloat64ValueRecorder("kept.minmaxsumcount").Record(ctx, 1)
+	Must(meter).NewFloat64ValueRecorder("other.minmaxsumcount").Record(ctx, 1)
+	sdk.Collect(ctx)
+
+	require.Len(t, processor.accumulations, 2)
+	kept := processor.accumulations["kept.minmaxsumcount"]
+	assert.Equal(t, aggregation.HistogramKind, kept.Aggregator().Aggregation().Kind())
+	other := processor.accumulations["other.minmaxsumcount"]
+	assert.Equal(t, aggregation.MinMaxSumCountKind, other.Aggregator().Aggregation().Kind())
+}
+
+func TestViewAggregation(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newViewSDK(
+		metricsdk.View{
+			InstrumentName:      "latency.sum",
+			Aggregation:         aggregation.HistogramKind,
+			HistogramBoundaries: []float64{10, 100},
+		},
+		metricsdk.View{
+			InstrumentName:              "size.sum",
+			Aggregation:                 aggregation.ExponentialHistogramKind,
+			ExponentialHistogramMaxSize: 4,
+		},
+		metricsdk.View{
+			InstrumentName:   "duration.sum",
+			Aggregation:      aggregation.SummaryKind,
+			SummaryQuantiles: []float64{0.5, 1},
+		},
+		metricsdk.View{
+			InstrumentName: "renamed.sum",
+			Name:           "queue.lastvalue.sum",
+			Aggregation:    aggregation.LastValueKind,
+		},
+	)
+
+	latency := Must(meter).NewFloat64ValueRecorder("latency.sum")
+	queue := Must(meter).NewInt64UpDownCounter("renamed.sum")
+	size := Must(meter).NewInt64ValueRecorder("size.sum")
+	duration := Must(meter).NewFloat64ValueRecorder("duration.sum")
+	for _, v := range []float64{1, 2, 3} {
+		duration.Record(ctx, v)
+	}
+	for _, v := range []int64{1, 10, 100, 1000, 10000} {
+		size.Record(ctx, v)
+	}
+	latency.Record(ctx, 5)
+	latency.Record(ctx, 50)
+	latency.Record(ctx, 500)
+	queue.Add(ctx, 3)
+	queue.Add(ctx, 4)
+	sdk.Collect(ctx)
+	require.NoError(t, testHandler.Flush())
+
+	require.Len(t, processor.accumulations, 4)
+	agg := processor.accumulations["latency.sum"].Aggregator().Aggregation()
+	require.Equal(t, aggregation.HistogramKind, agg.Kind())
+	buckets, err := agg.(aggregation.Histogram).Histogram()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{10, 100}, buckets.Boundaries)
+	assert.Equal(t, []uint64{1, 1, 1}, buckets.Counts)
+
+	agg = processor.accumulations["size.sum"].Aggregator().Aggregation()
+	require.Equal(t, aggregation.ExponentialHistogramKind, agg.Kind())
+	positive, err := agg.(aggregation.ExponentialHistogram).Positive()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(positive.Counts), 4)
+	count, err := agg.(aggregation.ExponentialHistogram).Count()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), count)
+
+	agg = processor.accumulations["duration.sum"].Aggregator().Aggregation()
+	require.Equal(t, aggregation.SummaryKind, agg.Kind())
+	assert.Equal(t, []float64{0.5, 1}, agg.(aggregation.Summary).Quantiles())
+	median, err := agg.(aggregation.Summary).Quantile(0.5)
+	require.NoError(t, err)
+	assert.InDelta(t, 2, median.AsFloat64(), 0.02)
+	max, err := agg.(aggregation.Summary).Quantile(1)
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, max.AsFloat64())
+
+	agg = processor.accumulations["queue.lastvalue.sum"].Aggregator().Aggregation()
+	require.Equal(t, aggregation.LastValueKind, agg.Kind())
+	last, _, err := agg.(aggregation.LastValue).LastValue()
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), last.AsInt64())
+}
+
+func TestViewAggregationOfFirstMatch(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newViewSDK(
+		metricsdk.View{InstrumentName: "a.sum", Name: "b.sum"},
+		metricsdk.View{InstrumentName: "b.sum", Aggregation: aggregation.HistogramKind},
+	)
+
+	Must(meter).NewInt64Counter("a.sum").Add(ctx, 1)
+	sdk.Collect(ctx)
+
+	// The second View applies to instruments named b.sum, not to the
+	// data renamed b.sum by the first View.
+	require.Contains(t, processor.accumulations, "b.sum")
+	accum := processor.accumulations["b.sum"]
+	assert.Equal(t, aggregation.SumKind, accum.Aggregator().Aggregation().Kind())
+	assert.Nil(t, accum.AggregatorSelector())
+}
+
+func TestViewInvalidAggregationForInstrument(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newViewSDK(
+		metricsdk.View{InstrumentName: "observer.sum", Aggregation: aggregation.HistogramKind},
+		metricsdk.View{InstrumentName: "gauge.lastvalue", Aggregation: aggregation.SumKind},
+		metricsdk.View{InstrumentName: "counter.sum", Aggregation: aggregation.HistogramKind},
+	)
+	require.NoError(t, testHandler.Flush())
+
+	_ = Must(meter).NewInt64SumObserver("observer.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1)
+	})
+	require.Error(t, testHandler.Flush())
+	gauge := Must(meter).NewInt64Gauge("gauge.lastvalue")
+	require.Error(t, testHandler.Flush())
+	counter := Must(meter).NewInt64Counter("counter.sum")
+	require.NoError(t, testHandler.Flush())
+
+	gauge.Set(ctx, 1)
+	counter.Add(ctx, 1)
+	sdk.Collect(ctx)
+
+	assert.Equal(t, aggregation.SumKind, processor.accumulations["observer.sum"].Aggregator().Aggregation().Kind())
+	assert.Equal(t, aggregation.LastValueKind, processor.accumulations["gauge.lastvalue"].Aggregator().Aggregation().Kind())
+	accum := processor.accumulations["counter.sum"]
+	assert.Equal(t, aggregation.HistogramKind, accum.Aggregator().Aggregation().Kind())
+	assert.NotNil(t, accum.AggregatorSelector())
+}
+
+func TestViewAttributeKeys(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newViewSDK(metricsdk.View{
+		InstrumentationName: "test",
+		InstrumentName:      "filtered.*",
+		AttributeKeys:       []attribute.Key{"A"},
+	})
+
+	filtered := Must(meter).NewInt64Counter("filtered.sum")
+	unfiltered := Must(meter).NewInt64Counter("unfiltered.sum")
+	_ = Must(meter).NewInt64SumObserver("filtered.observer.sum", func(_ context.Context, result metric.Int64ObserverResult) {
+		result.Observe(1, attribute.String("A", "B"), attribute.String("C", "D"))
+	})
+	labels := []attribute.KeyValue{attribute.String("A", "B"), attribute.String("C", "D")}
+	meter.RecordBatch(ctx, labels, unfiltered.Measurement(1), filtered.Measurement(1))
+	sdk.Collect(ctx)
+
+	require.Len(t, processor.accumulations, 3)
+	encoder := attribute.DefaultEncoder()
+	assert.Equal(t, "A=B", processor.accumulations["filtered.sum"].Labels().Encoded(encoder))
+	assert.Equal(t, "A=B", processor.accumulations["filtered.observer.sum"].Labels().Encoded(encoder))
+	assert.Equal(t, "A=B,C=D", processor.accumulations["unfiltered.sum"].Labels().Encoded(encoder))
+}
+
+func TestViewExemplars(t *testing.T) {
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	meter, sdk, processor := newViewSDK(
+		metricsdk.View{
+			InstrumentName:   "sampled.sum",
+			Aggregation:      aggregation.SumKind,
+			ExemplarStrategy: exemplar.FixedSize(2),
+			AttributeKeys:    []attribute.Key{"A"},
+		},
+		metricsdk.View{
+			InstrumentName:   "unsampled.histogram",
+			Aggregation:      aggregation.HistogramKind,
+			DisableExemplars: true,
+		},
+	)
+
+	sampled := Must(meter).NewInt64Counter("sampled.sum")
+	unsampled := Must(meter).NewFloat64ValueRecorder("unsampled.histogram")
+	for i := 0; i < 3; i++ {
+		sampled.Add(ctx, 1, attribute.String("A", "B"), attribute.String("C", "D"))
+	}
+	unsampled.Record(ctx, 1)
+	sdk.Collect(ctx)
+
+	require.Len(t, processor.accumulations, 2)
+	exemplars, err := processor.accumulations["sampled.sum"].Aggregator().Aggregation().(aggregation.Exemplars).Exemplars()
+	require.NoError(t, err)
+	require.Len(t, exemplars, 2)
+	for _, ex := range exemplars {
+		assert.Equal(t, trace.TraceID{0x01}, ex.SpanContext.TraceID())
+		assert.Equal(t, []attribute.KeyValue{attribute.String("C", "D")}, ex.FilteredAttributes)
+	}
+
+	exemplars, err = processor.accumulations["unsampled.histogram"].Aggregator().Aggregation().(aggregation.Exemplars).Exemplars()
+	require.NoError(t, err)
+	assert.Empty(t, exemplars)
+}
+
+func TestViewFirstMatchApplies(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newViewSDK(
+		metricsdk.View{InstrumentName: "a.*", InstrumentationName: "other", Drop: true},
+		metricsdk.View{InstrumentName: "a.*"},
+		metricsdk.View{InstrumentName: "a.*", Drop: true},
+	)
+
+	counter := Must(meter).NewInt64Counter("a.sum")
+	counter.Add(ctx, 1)
+	sdk.Collect(ctx)
+
+	assert.Contains(t, processor.accumulations, "a.sum")
+}
+
+func TestInvalidViews(t *testing.T) {
+	ctx := context.Background()
+	meter, sdk, processor := newViewSDK(
+		metricsdk.View{InstrumentName: "[", Drop: true},
+		metricsdk.View{InstrumentName: "*", Name: "renamed"},
+		metricsdk.View{InstrumentationName: "[", Drop: true},
+		metricsdk.View{InstrumentName: "a.sum", Aggregation: "Unknown"},
+		metricsdk.View{InstrumentName: "a.sum", Aggregation: aggregation.SummaryKind, SummaryQuantiles: []float64{2}},
+	)
+	require.Error(t, testHandler.Flush())
+
+	counter := Must(meter).NewInt64Counter("a.sum")
+	counter.Add(ctx, 1)
+	sdk.Collect(ctx)
+
+	assert.Contains(t, processor.accumulations, "a.sum")
+}
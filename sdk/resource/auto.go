@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 )
 
 var (
@@ -37,23 +38,79 @@ type Detector interface {
 	Detect(ctx context.Context) (*Resource, error)
 }
 
-// Detect calls all input detectors sequentially and merges each result with the previous one.
-// It returns the merged error too.
+// Detect calls all input detectors concurrently and merges their results
+// in the order of detectors, the attributes of a detector overwriting the
+// ones of the detectors before it. The errors of the detectors are
+// merged in the returned error, the detectors failing with an error not
+// wrapping ErrPartialResource do not contribute to the Resource.
 func Detect(ctx context.Context, detectors ...Detector) (*Resource, error) {
-	var autoDetectedRes *Resource
-	var errInfo []string
+	return detect(ctx, 0, detectors)
+}
+
+// detectResult is the outcome of a single detector.
+type detectResult struct {
+	res *Resource
+	err error
+}
+
+// detect runs detectors like Detect, each one being abandoned after
+// timeout if it is positive.
+func detect(ctx context.Context, timeout time.Duration, detectors []Detector) (*Resource, error) {
+	type pending struct {
+		detector Detector
+		ctx      context.Context
+		cancel   context.CancelFunc
+		result   chan detectResult
+	}
+	running := make([]pending, 0, len(detectors))
 	for _, detector := range detectors {
 		if detector == nil {
 			continue
 		}
-		res, err := detector.Detect(ctx)
-		if err != nil {
-			errInfo = append(errInfo, err.Error())
-			if !errors.Is(err, ErrPartialResource) {
+		p := pending{
+			detector: detector,
+			// The result is buffered so that an abandoned
+			// detector does not block forever.
+			result: make(chan detectResult, 1),
+		}
+		if timeout > 0 {
+			p.ctx, p.cancel = context.WithTimeout(ctx, timeout)
+		} else {
+			p.ctx, p.cancel = context.WithCancel(ctx)
+		}
+		go func(p pending) {
+			res, err := p.detector.Detect(p.ctx)
+			p.result <- detectResult{res: res, err: err}
+		}(p)
+		running = append(running, p)
+	}
+
+	var autoDetectedRes *Resource
+	var errInfo []string
+	for _, p := range running {
+		var r detectResult
+		select {
+		case r = <-p.result:
+		case <-p.ctx.Done():
+			select {
+			case r = <-p.result:
+			default:
+				r.err = p.ctx.Err()
+			}
+		}
+		p.cancel()
+
+		if r.err != nil {
+			errInfo = append(errInfo, fmt.Sprintf("%T: %v", p.detector, r.err))
+			if !errors.Is(r.err, ErrPartialResource) {
 				continue
 			}
 		}
-		autoDetectedRes = Merge(autoDetectedRes, res)
+		merged, err := Merge(autoDetectedRes, r.res)
+		if err != nil {
+			errInfo = append(errInfo, fmt.Sprintf("%T: %v", p.detector, err))
+		}
+		autoDetectedRes = merged
 	}
 
 	var aggregatedError error
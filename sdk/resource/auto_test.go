@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+type detectorFunc func(context.Context) (*resource.Resource, error)
+
+func (f detectorFunc) Detect(ctx context.Context) (*resource.Resource, error) {
+	return f(ctx)
+}
+
+func attributesDetector(kvs ...attribute.KeyValue) resource.Detector {
+	return detectorFunc(func(context.Context) (*resource.Resource, error) {
+		return resource.NewWithAttributes(kvs...), nil
+	})
+}
+
+// blockingDetector returns a Detector waiting for its context to be done,
+// and a channel closed once it returns.
+func blockingDetector() (resource.Detector, chan struct{}) {
+	done := make(chan struct{})
+	return detectorFunc(func(ctx context.Context) (*resource.Resource, error) {
+		defer close(done)
+		<-ctx.Done()
+		return resource.NewWithAttributes(attribute.String("blocked", "true")), ctx.Err()
+	}), done
+}
+
+func TestDetectMergeOrder(t *testing.T) {
+	res, err := resource.Detect(context.Background(),
+		attributesDetector(attribute.String("A", "1"), attribute.String("B", "1")),
+		nil,
+		attributesDetector(attribute.String("B", "2")),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(
+		attribute.String("A", "1"),
+		attribute.String("B", "2"),
+	).Equivalent(), res.Equivalent())
+}
+
+func TestDetectConcurrently(t *testing.T) {
+	// Each detector waits for the other to start, they would deadlock
+	// if they ran sequentially.
+	started := make(chan struct{}, 2)
+	waitOther := func(k string) resource.Detector {
+		return detectorFunc(func(ctx context.Context) (*resource.Resource, error) {
+			started <- struct{}{}
+			for len(started) < 2 {
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(time.Millisecond):
+				}
+			}
+			return resource.NewWithAttributes(attribute.String(k, "v")), nil
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	res, err := resource.Detect(ctx, waitOther("A"), waitOther("B"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, res.Len())
+}
+
+func TestDetectErrorAggregation(t *testing.T) {
+	failing := detectorFunc(func(context.Context) (*resource.Resource, error) {
+		return resource.NewWithAttributes(attribute.String("failed", "true")), errors.New("failing detector")
+	})
+	partial := detectorFunc(func(context.Context) (*resource.Resource, error) {
+		return resource.NewWithAttributes(attribute.String("partial", "true")), fmt.Errorf("%w: missing", resource.ErrPartialResource)
+	})
+
+	res, err := resource.Detect(context.Background(),
+		failing,
+		attributesDetector(attribute.String("A", "B")),
+		partial,
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "resource_test.detectorFunc: failing detector")
+	assert.Contains(t, err.Error(), "resource_test.detectorFunc: partial resource: missing")
+	assert.Equal(t, resource.NewWithAttributes(
+		attribute.String("A", "B"),
+		attribute.String("partial", "true"),
+	).Equivalent(), res.Equivalent())
+}
+
+func TestWithDetectorTimeout(t *testing.T) {
+	blocking, done := blockingDetector()
+	res, err := resource.New(context.Background(),
+		resource.WithDetectorTimeout(10*time.Millisecond),
+		resource.WithDetectors(blocking),
+		resource.WithAttributes(attribute.String("A", "B")),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+	assert.Equal(t, resource.NewWithAttributes(attribute.String("A", "B")).Equivalent(), res.Equivalent())
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("detector context was not canceled")
+	}
+}
+
+func TestDetectCanceledContext(t *testing.T) {
+	blocking, done := blockingDetector()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := resource.Detect(ctx, blocking)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), context.Canceled.Error())
+	<-done
+}
@@ -57,7 +57,7 @@ func benchmarkMergeResource(b *testing.B, size int) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		_ = resource.Merge(r1, r2)
+		_, _ = resource.Merge(r1, r2)
 	}
 }
 
@@ -19,25 +19,22 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/semconv"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
 type (
 	// TelemetrySDK is a Detector that provides information about
-	// the OpenTelemetry SDK used.  This Detector is included as a
-	// builtin. If these resource attributes are not wanted, use
-	// the WithTelemetrySDK(nil) or WithoutBuiltin() options to
-	// explicitly disable them.
+	// the OpenTelemetry SDK used.  It is added to a Resource created
+	// with New by the WithTelemetrySDK option.
 	TelemetrySDK struct{}
 
 	// Host is a Detector that provides information about the host
-	// being run on. This Detector is included as a builtin. If
-	// these resource attributes are not wanted, use the
-	// WithHost(nil) or WithoutBuiltin() options to explicitly
-	// disable them.
+	// being run on: its name and CPU architecture.  It is added to a
+	// Resource created with New by the WithHost option.
 	Host struct{}
 
 	stringDetector struct {
@@ -57,16 +54,43 @@ var (
 
 // Detect returns a *Resource that describes the OpenTelemetry SDK used.
 func (TelemetrySDK) Detect(context.Context) (*Resource, error) {
-	return NewWithAttributes(
+	return NewWithSchemaURL(
+		semconv.SchemaURL,
 		semconv.TelemetrySDKNameKey.String("opentelemetry"),
 		semconv.TelemetrySDKLanguageKey.String("go"),
 		semconv.TelemetrySDKVersionKey.String(otel.Version()),
 	), nil
 }
 
-// Detect returns a *Resource that describes the host being run on.
-func (Host) Detect(ctx context.Context) (*Resource, error) {
-	return StringDetector(semconv.HostNameKey, os.Hostname).Detect(ctx)
+// Detect returns a *Resource that describes the host being run on. If
+// the host name cannot be detected, an error wrapping ErrPartialResource
+// is returned along with the host architecture.
+func (Host) Detect(context.Context) (*Resource, error) {
+	name, err := os.Hostname()
+	if err != nil {
+		return NewWithSchemaURL(semconv.SchemaURL, hostArch()),
+			fmt.Errorf("%w: %s: %v", ErrPartialResource, semconv.HostNameKey, err)
+	}
+	return NewWithSchemaURL(semconv.SchemaURL, semconv.HostNameKey.String(name), hostArch()), nil
+}
+
+// hostArch returns the host.arch attribute of the architecture the
+// program was compiled for.
+func hostArch() attribute.KeyValue {
+	switch runtime.GOARCH {
+	case "amd64":
+		return semconv.HostArchAMD64
+	case "arm":
+		return semconv.HostArchARM32
+	case "arm64":
+		return semconv.HostArchARM64
+	case "386":
+		return semconv.HostArchX86
+	case "ppc64", "ppc64le":
+		return semconv.HostArchPPC64
+	default:
+		return semconv.HostArchKey.String(runtime.GOARCH)
+	}
 }
 
 // StringDetector returns a Detector that will produce a *Resource
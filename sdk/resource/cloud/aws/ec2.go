@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws // import "go.opentelemetry.io/otel/sdk/resource/cloud/aws"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/cloud"
+	"go.opentelemetry.io/otel/sdk/resource/cloud/internal/metadata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// ec2Endpoint is the address of the EC2 instance metadata service.
+var ec2Endpoint = "http://169.254.169.254"
+
+const (
+	tokenTTLHeader = "X-aws-ec2-metadata-token-ttl-seconds"
+	tokenHeader    = "X-aws-ec2-metadata-token"
+)
+
+// EC2 is the detector of the Amazon EC2 platform. It provides the cloud
+// and host attributes of the instance, read from the instance metadata
+// service with a session token (IMDSv2).
+type EC2 struct{}
+
+// EKS is the detector of the Amazon EKS platform, Kubernetes running on
+// EC2 instances. It provides the attributes of the EC2 detector with the
+// aws_eks cloud platform.
+type EKS struct{}
+
+var (
+	_ resource.Detector = EC2{}
+	_ resource.Detector = EKS{}
+)
+
+// identityDocument holds the fields used of the EC2 instance identity
+// document.
+type identityDocument struct {
+	AccountID        string `json:"accountId"`
+	AvailabilityZone string `json:"availabilityZone"`
+	Region           string `json:"region"`
+	InstanceID       string `json:"instanceId"`
+	InstanceType     string `json:"instanceType"`
+	ImageID          string `json:"imageId"`
+}
+
+// Detect returns a *Resource that describes the EC2 instance the process
+// runs on.
+func (EC2) Detect(ctx context.Context) (*resource.Resource, error) {
+	return detectEC2(ctx, semconv.CloudPlatformAWSEC2)
+}
+
+// Detect returns a *Resource that describes the EC2 instance of the EKS
+// cluster the process runs on.
+func (EKS) Detect(ctx context.Context) (*resource.Resource, error) {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" {
+		return nil, fmt.Errorf("%w: not running in Kubernetes", cloud.ErrNotDetected)
+	}
+	return detectEC2(ctx, semconv.CloudPlatformAWSEKS)
+}
+
+func detectEC2(ctx context.Context, platform attribute.KeyValue) (*resource.Resource, error) {
+	token, err := ec2Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{tokenHeader: []string{token}}
+
+	body, _, err := metadata.Fetch(ctx, http.MethodGet, ec2Endpoint+"/latest/dynamic/instance-identity/document", header)
+	if err != nil {
+		return nil, err
+	}
+	var doc identityDocument
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("decoding EC2 instance identity document: %w", err)
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		platform,
+		semconv.CloudRegionKey.String(doc.Region),
+		semconv.CloudAvailabilityZoneKey.String(doc.AvailabilityZone),
+		semconv.CloudAccountIDKey.String(doc.AccountID),
+		semconv.HostIDKey.String(doc.InstanceID),
+		semconv.HostTypeKey.String(doc.InstanceType),
+		semconv.HostImageIDKey.String(doc.ImageID),
+	}
+
+	hostname, _, err := metadata.Fetch(ctx, http.MethodGet, ec2Endpoint+"/latest/meta-data/hostname", header)
+	if err != nil {
+		return resource.NewWithSchemaURL(semconv.SchemaURL, attrs...),
+			fmt.Errorf("%w: %s: %v", resource.ErrPartialResource, semconv.HostNameKey, err)
+	}
+	attrs = append(attrs, semconv.HostNameKey.String(string(hostname)))
+	return resource.NewWithSchemaURL(semconv.SchemaURL, attrs...), nil
+}
+
+// ec2Token returns a session token of the instance metadata service. The
+// process does not run on EC2 if the service does not grant one.
+func ec2Token(ctx context.Context) (string, error) {
+	body, _, err := metadata.Fetch(ctx, http.MethodPut, ec2Endpoint+"/latest/api/token", http.Header{
+		tokenTTLHeader: []string{"60"},
+	})
+	var statusErr *metadata.StatusError
+	if errors.As(err, &statusErr) {
+		return "", fmt.Errorf("%w: %v", cloud.ErrNotDetected, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/cloud"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+const identityDocumentJSON = `{
+	"accountId": "123456789012",
+	"architecture": "x86_64",
+	"availabilityZone": "us-west-2b",
+	"imageId": "ami-5fb8c835",
+	"instanceId": "i-1234567890abcdef0",
+	"instanceType": "t2.micro",
+	"region": "us-west-2"
+}`
+
+func newIMDS(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/latest/api/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "60", r.Header.Get(tokenTTLHeader))
+		_, _ = w.Write([]byte("token"))
+	})
+	authorized := func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(tokenHeader) != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			h(w, r)
+		}
+	}
+	mux.HandleFunc("/latest/dynamic/instance-identity/document", authorized(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(identityDocumentJSON))
+	}))
+	mux.HandleFunc("/latest/meta-data/hostname", authorized(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ip-172-31-0-1.us-west-2.compute.internal"))
+	}))
+	return httptest.NewServer(mux)
+}
+
+func setEC2Endpoint(t *testing.T, endpoint string) {
+	orig := ec2Endpoint
+	ec2Endpoint = endpoint
+	t.Cleanup(func() { ec2Endpoint = orig })
+}
+
+func TestEC2Detect(t *testing.T) {
+	srv := newIMDS(t)
+	defer srv.Close()
+	setEC2Endpoint(t, srv.URL)
+
+	res, err := EC2{}.Detect(context.Background())
+	require.NoError(t, err)
+	expected := resource.NewWithSchemaURL(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSEC2,
+		semconv.CloudRegionKey.String("us-west-2"),
+		semconv.CloudAvailabilityZoneKey.String("us-west-2b"),
+		semconv.CloudAccountIDKey.String("123456789012"),
+		semconv.HostIDKey.String("i-1234567890abcdef0"),
+		semconv.HostTypeKey.String("t2.micro"),
+		semconv.HostImageIDKey.String("ami-5fb8c835"),
+		semconv.HostNameKey.String("ip-172-31-0-1.us-west-2.compute.internal"),
+	)
+	assert.Equal(t, expected.Equivalent(), res.Equivalent())
+	assert.Equal(t, semconv.SchemaURL, res.SchemaURL())
+}
+
+func TestEC2NotDetected(t *testing.T) {
+	// Another metadata server, e.g. the one of GCE, does not grant
+	// tokens.
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	setEC2Endpoint(t, srv.URL)
+
+	_, err := EC2{}.Detect(context.Background())
+	assert.True(t, errors.Is(err, cloud.ErrNotDetected))
+
+	// No metadata server at all.
+	srv.Close()
+	_, err = EC2{}.Detect(context.Background())
+	assert.True(t, errors.Is(err, cloud.ErrNotDetected))
+}
+
+func TestEKSDetect(t *testing.T) {
+	srv := newIMDS(t)
+	defer srv.Close()
+	setEC2Endpoint(t, srv.URL)
+
+	store, err := ottest.SetEnvVariables(map[string]string{"KUBERNETES_SERVICE_HOST": ""})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	_, err = EKS{}.Detect(context.Background())
+	assert.True(t, errors.Is(err, cloud.ErrNotDetected))
+
+	k8sStore, err := ottest.SetEnvVariables(map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1"})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, k8sStore.Restore()) }()
+
+	res, err := EKS{}.Detect(context.Background())
+	require.NoError(t, err)
+	platform, ok := res.Set().Value(semconv.CloudPlatformKey)
+	require.True(t, ok)
+	assert.Equal(t, "aws_eks", platform.AsString())
+}
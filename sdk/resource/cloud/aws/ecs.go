@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws // import "go.opentelemetry.io/otel/sdk/resource/cloud/aws"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/cloud"
+	"go.opentelemetry.io/otel/sdk/resource/cloud/internal/metadata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// The environment variables of the ECS task metadata endpoints, version 4
+// and version 3.
+const (
+	metadataURIV4Var = "ECS_CONTAINER_METADATA_URI_V4"
+	metadataURIVar   = "ECS_CONTAINER_METADATA_URI"
+)
+
+// ECS is the detector of the Amazon ECS platform. It provides the cloud,
+// ECS and container attributes of the task the process runs in, read from
+// the task metadata endpoint.
+type ECS struct{}
+
+var _ resource.Detector = ECS{}
+
+// containerMetadata holds the fields used of the ECS container metadata.
+type containerMetadata struct {
+	DockerID     string `json:"DockerId"`
+	ContainerARN string `json:"ContainerARN"`
+}
+
+// taskMetadata holds the fields used of the ECS task metadata.
+type taskMetadata struct {
+	Cluster          string `json:"Cluster"`
+	TaskARN          string `json:"TaskARN"`
+	Family           string `json:"Family"`
+	Revision         string `json:"Revision"`
+	AvailabilityZone string `json:"AvailabilityZone"`
+	LaunchType       string `json:"LaunchType"`
+}
+
+// Detect returns a *Resource that describes the ECS task the process runs
+// in.
+func (ECS) Detect(ctx context.Context) (*resource.Resource, error) {
+	uri := os.Getenv(metadataURIV4Var)
+	if uri == "" {
+		uri = os.Getenv(metadataURIVar)
+	}
+	if uri == "" {
+		return nil, fmt.Errorf("%w: no ECS task metadata endpoint", cloud.ErrNotDetected)
+	}
+
+	var container containerMetadata
+	if err := fetchJSON(ctx, uri, &container); err != nil {
+		return nil, err
+	}
+	var task taskMetadata
+	if err := fetchJSON(ctx, uri+"/task", &task); err != nil {
+		return nil, err
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSECS,
+		semconv.ContainerIDKey.String(container.DockerID),
+		semconv.AWSECSTaskARNKey.String(task.TaskARN),
+		semconv.AWSECSTaskFamilyKey.String(task.Family),
+		semconv.AWSECSTaskRevisionKey.String(task.Revision),
+	}
+	if container.ContainerARN != "" {
+		attrs = append(attrs, semconv.AWSECSContainerARNKey.String(container.ContainerARN))
+	}
+	if task.AvailabilityZone != "" {
+		attrs = append(attrs, semconv.CloudAvailabilityZoneKey.String(task.AvailabilityZone))
+	}
+	if task.LaunchType != "" {
+		attrs = append(attrs, semconv.AWSECSLaunchtypeKey.String(strings.ToLower(task.LaunchType)))
+	}
+
+	// The task ARN is arn:aws:ecs:<region>:<account>:task/..., the
+	// cluster may be a name or an ARN.
+	if arn := strings.SplitN(task.TaskARN, ":", 6); len(arn) == 6 {
+		region, account := arn[3], arn[4]
+		attrs = append(attrs,
+			semconv.CloudRegionKey.String(region),
+			semconv.CloudAccountIDKey.String(account),
+		)
+		cluster := task.Cluster
+		if cluster != "" && !strings.HasPrefix(cluster, "arn:") {
+			cluster = fmt.Sprintf("arn:%s:ecs:%s:%s:cluster/%s", arn[1], region, account, cluster)
+		}
+		if cluster != "" {
+			attrs = append(attrs, semconv.AWSECSClusterARNKey.String(cluster))
+		}
+	}
+	return resource.NewWithSchemaURL(semconv.SchemaURL, attrs...), nil
+}
+
+func fetchJSON(ctx context.Context, url string, v interface{}) error {
+	body, _, err := metadata.Fetch(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decoding ECS metadata of %s: %w", url, err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/cloud"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+const (
+	containerMetadataJSON = `{
+	"DockerId": "cd189a933e5849daa93386466019ab50-2495160603",
+	"Name": "curl",
+	"ContainerARN": "arn:aws:ecs:us-west-2:111122223333:container/05966557-f16c-49cb-9352-24b3a0dcd0e1"
+}`
+	taskMetadataJSON = `{
+	"Cluster": "default",
+	"TaskARN": "arn:aws:ecs:us-west-2:111122223333:task/default/e9028f8d5d8e4f258373e7b93ce9a3c3",
+	"Family": "curltest",
+	"Revision": "3",
+	"AvailabilityZone": "us-west-2d",
+	"LaunchType": "FARGATE"
+}`
+)
+
+func TestECSDetect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v4/container", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(containerMetadataJSON))
+	})
+	mux.HandleFunc("/v4/container/task", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(taskMetadataJSON))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	store, err := ottest.SetEnvVariables(map[string]string{
+		metadataURIV4Var: srv.URL + "/v4/container",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := ECS{}.Detect(context.Background())
+	require.NoError(t, err)
+	expected := resource.NewWithSchemaURL(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSECS,
+		semconv.CloudRegionKey.String("us-west-2"),
+		semconv.CloudAvailabilityZoneKey.String("us-west-2d"),
+		semconv.CloudAccountIDKey.String("111122223333"),
+		semconv.ContainerIDKey.String("cd189a933e5849daa93386466019ab50-2495160603"),
+		semconv.AWSECSContainerARNKey.String("arn:aws:ecs:us-west-2:111122223333:container/05966557-f16c-49cb-9352-24b3a0dcd0e1"),
+		semconv.AWSECSClusterARNKey.String("arn:aws:ecs:us-west-2:111122223333:cluster/default"),
+		semconv.AWSECSLaunchtypeKey.String("fargate"),
+		semconv.AWSECSTaskARNKey.String("arn:aws:ecs:us-west-2:111122223333:task/default/e9028f8d5d8e4f258373e7b93ce9a3c3"),
+		semconv.AWSECSTaskFamilyKey.String("curltest"),
+		semconv.AWSECSTaskRevisionKey.String("3"),
+	)
+	assert.Equal(t, expected.Equivalent(), res.Equivalent())
+}
+
+func TestECSNotDetected(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		metadataURIV4Var: "",
+		metadataURIVar:   "",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	_, err = ECS{}.Detect(context.Background())
+	assert.True(t, errors.Is(err, cloud.ErrNotDetected))
+}
+
+func TestECSMetadataError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	store, err := ottest.SetEnvVariables(map[string]string{
+		metadataURIV4Var: "",
+		metadataURIVar:   srv.URL,
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	_, err = ECS{}.Detect(context.Background())
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, cloud.ErrNotDetected))
+	assert.Contains(t, err.Error(), "500 Internal Server Error")
+}
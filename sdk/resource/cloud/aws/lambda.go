@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws // import "go.opentelemetry.io/otel/sdk/resource/cloud/aws"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/cloud"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// The environment variables of the AWS Lambda runtime.
+const (
+	lambdaFunctionNameVar    = "AWS_LAMBDA_FUNCTION_NAME"
+	lambdaFunctionVersionVar = "AWS_LAMBDA_FUNCTION_VERSION"
+	lambdaFunctionMemoryVar  = "AWS_LAMBDA_FUNCTION_MEMORY_SIZE"
+	lambdaLogStreamNameVar   = "AWS_LAMBDA_LOG_STREAM_NAME"
+	awsRegionVar             = "AWS_REGION"
+)
+
+// Lambda is the detector of the AWS Lambda platform. It provides the cloud
+// and FaaS attributes of the function the process runs, read from the
+// environment variables of the Lambda runtime.
+type Lambda struct{}
+
+var _ resource.Detector = Lambda{}
+
+// Detect returns a *Resource that describes the Lambda function the
+// process runs.
+func (Lambda) Detect(context.Context) (*resource.Resource, error) {
+	name := os.Getenv(lambdaFunctionNameVar)
+	if name == "" {
+		return nil, fmt.Errorf("%w: no AWS Lambda function name", cloud.ErrNotDetected)
+	}
+
+	faas := semconv.FaaS{
+		Name:    name,
+		Version: os.Getenv(lambdaFunctionVersionVar),
+		// The log stream name identifies the execution environment.
+		Instance: os.Getenv(lambdaLogStreamNameVar),
+	}
+	if v := os.Getenv(lambdaFunctionMemoryVar); v != "" {
+		memory, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", lambdaFunctionMemoryVar, err)
+		}
+		faas.MaxMemory = memory
+	}
+
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSLambda,
+	}
+	if region := os.Getenv(awsRegionVar); region != "" {
+		attrs = append(attrs, semconv.CloudRegionKey.String(region))
+	}
+	attrs = append(attrs, faas.Attributes()...)
+	return resource.NewWithSchemaURL(semconv.SchemaURL, attrs...), nil
+}
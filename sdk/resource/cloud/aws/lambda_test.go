@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/cloud"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+func TestLambdaDetect(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		lambdaFunctionNameVar:    "my-function",
+		lambdaFunctionVersionVar: "$LATEST",
+		lambdaFunctionMemoryVar:  "128",
+		lambdaLogStreamNameVar:   "2021/06/28/[$LATEST]2f0c1e4d5a6b7c8d9e0f1a2b3c4d5e6f",
+		awsRegionVar:             "us-east-1",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := Lambda{}.Detect(context.Background())
+	require.NoError(t, err)
+	expected := resource.NewWithSchemaURL(semconv.SchemaURL,
+		semconv.CloudProviderAWS,
+		semconv.CloudPlatformAWSLambda,
+		semconv.CloudRegionKey.String("us-east-1"),
+		semconv.FaaSNameKey.String("my-function"),
+		semconv.FaaSVersionKey.String("$LATEST"),
+		semconv.FaaSInstanceKey.String("2021/06/28/[$LATEST]2f0c1e4d5a6b7c8d9e0f1a2b3c4d5e6f"),
+		semconv.FaaSMaxMemoryKey.Int(128),
+	)
+	assert.Equal(t, expected.Equivalent(), res.Equivalent())
+}
+
+func TestLambdaNotDetected(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		lambdaFunctionNameVar: "",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	_, err = Lambda{}.Detect(context.Background())
+	assert.True(t, errors.Is(err, cloud.ErrNotDetected))
+}
+
+func TestLambdaInvalidMemory(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		lambdaFunctionNameVar:   "my-function",
+		lambdaFunctionMemoryVar: "lots",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	_, err = Lambda{}.Detect(context.Background())
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, cloud.ErrNotDetected))
+}
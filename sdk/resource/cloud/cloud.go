@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud // import "go.opentelemetry.io/otel/sdk/resource/cloud"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// DefaultTimeout is the default bound of the detection of the platforms
+// of a Detector.
+const DefaultTimeout = 5 * time.Second
+
+// ErrNotDetected is wrapped by the errors of the detectors of cloud
+// platforms when the process does not run on their platform.
+var ErrNotDetected = errors.New("cloud platform not detected")
+
+// Detector is a resource.Detector of the first of its Platforms the
+// process runs on. The Platforms are detected concurrently, their
+// detection is abandoned once Timeout has elapsed.
+type Detector struct {
+	// Platforms are the detectors of the supported platforms, in
+	// order of preference. The detector of a platform returns an
+	// error wrapping ErrNotDetected when the process does not run on
+	// it.
+	Platforms []resource.Detector
+
+	// Timeout bounds the detection of the Platforms, including the
+	// requests to their metadata endpoints. DefaultTimeout is used if
+	// it is not positive.
+	Timeout time.Duration
+}
+
+var _ resource.Detector = Detector{}
+
+type result struct {
+	res *resource.Resource
+	err error
+}
+
+// Detect returns the *Resource of the first of the Platforms the process
+// runs on, or an empty *Resource if it runs on none of them. The errors
+// of the Platforms are only returned if none of them is detected.
+func (d Detector) Detect(ctx context.Context) (*resource.Resource, error) {
+	timeout := d.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	results := make([]chan result, len(d.Platforms))
+	for i, p := range d.Platforms {
+		results[i] = make(chan result, 1)
+		go func(p resource.Detector, ch chan<- result) {
+			res, err := p.Detect(ctx)
+			ch <- result{res: res, err: err}
+		}(p, results[i])
+	}
+
+	var errs []string
+	for i, ch := range results {
+		var r result
+		select {
+		case r = <-ch:
+		case <-ctx.Done():
+			// Prefer the result of a platform detected before the
+			// timeout to the timeout.
+			select {
+			case r = <-ch:
+			default:
+				r.err = fmt.Errorf("%w: %v", ErrNotDetected, ctx.Err())
+			}
+		}
+		switch {
+		case r.err == nil, errors.Is(r.err, resource.ErrPartialResource):
+			return r.res, r.err
+		case !errors.Is(r.err, ErrNotDetected):
+			errs = append(errs, fmt.Sprintf("%T: %v", d.Platforms[i], r.err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("detecting cloud platform: %s", errs)
+	}
+	return resource.Empty(), nil
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloud_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/cloud"
+)
+
+type platform func(context.Context) (*resource.Resource, error)
+
+func (p platform) Detect(ctx context.Context) (*resource.Resource, error) {
+	return p(ctx)
+}
+
+func detected(name string) resource.Detector {
+	return platform(func(context.Context) (*resource.Resource, error) {
+		return resource.NewWithAttributes(attribute.String("platform", name)), nil
+	})
+}
+
+func notDetected() resource.Detector {
+	return platform(func(context.Context) (*resource.Resource, error) {
+		return nil, fmt.Errorf("%w: elsewhere", cloud.ErrNotDetected)
+	})
+}
+
+func failing() resource.Detector {
+	return platform(func(context.Context) (*resource.Resource, error) {
+		return nil, errors.New("metadata unavailable")
+	})
+}
+
+func blocking() resource.Detector {
+	return platform(func(ctx context.Context) (*resource.Resource, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+}
+
+func TestDetectorFirstDetected(t *testing.T) {
+	res, err := cloud.Detector{
+		Platforms: []resource.Detector{notDetected(), failing(), detected("a"), detected("b")},
+	}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(attribute.String("platform", "a")).Equivalent(), res.Equivalent())
+}
+
+func TestDetectorNoneDetected(t *testing.T) {
+	res, err := cloud.Detector{
+		Platforms: []resource.Detector{notDetected(), notDetected()},
+	}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Len())
+
+	res, err = cloud.Detector{}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Len())
+}
+
+func TestDetectorErrors(t *testing.T) {
+	res, err := cloud.Detector{
+		Platforms: []resource.Detector{notDetected(), failing()},
+	}.Detect(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "metadata unavailable")
+	assert.Nil(t, res)
+}
+
+func TestDetectorTimeout(t *testing.T) {
+	start := time.Now()
+	res, err := cloud.Detector{
+		Platforms: []resource.Detector{blocking(), detected("b")},
+		Timeout:   10 * time.Millisecond,
+	}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(attribute.String("platform", "b")).Equivalent(), res.Equivalent())
+	assert.Less(t, int64(time.Since(start)), int64(5*time.Second))
+}
@@ -0,0 +1,26 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloud provides a resource.Detector of the cloud platform the
+// process runs on, built from the detectors of the platforms it supports,
+// e.g. the ones of the aws and gcp packages:
+//
+//	res, err := resource.New(ctx, resource.WithDetectors(cloud.Detector{
+//		Platforms: []resource.Detector{aws.Lambda{}, aws.ECS{}, aws.EKS{}, aws.EC2{}, gcp.GKE{}, gcp.GCE{}},
+//	}))
+//
+// This package is currently in a pre-GA phase. Backwards incompatible changes
+// may be introduced in subsequent minor version releases as we work to track
+// the evolving OpenTelemetry specification and user feedback.
+package cloud // import "go.opentelemetry.io/otel/sdk/resource/cloud"
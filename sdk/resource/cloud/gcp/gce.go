@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp // import "go.opentelemetry.io/otel/sdk/resource/cloud/gcp"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/cloud"
+	"go.opentelemetry.io/otel/sdk/resource/cloud/internal/metadata"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// gceEndpoint is the address of the Compute Engine metadata server, the
+// GCE_METADATA_HOST environment variable overrides its host.
+var gceEndpoint = "http://169.254.169.254"
+
+const (
+	flavorHeader = "Metadata-Flavor"
+	flavor       = "Google"
+)
+
+// GCE is the detector of the Google Compute Engine platform. It provides
+// the cloud and host attributes of the instance, read from the metadata
+// server.
+type GCE struct{}
+
+// GKE is the detector of the Google Kubernetes Engine platform. It
+// provides the attributes of the GCE detector with the
+// gcp_kubernetes_engine cloud platform and the name of the cluster.
+type GKE struct{}
+
+var (
+	_ resource.Detector = GCE{}
+	_ resource.Detector = GKE{}
+)
+
+// Detect returns a *Resource that describes the Compute Engine instance
+// the process runs on.
+func (GCE) Detect(ctx context.Context) (*resource.Resource, error) {
+	attrs, err := detectGCE(ctx, semconv.CloudPlatformGCPComputeEngine)
+	if err != nil {
+		return nil, err
+	}
+	return resource.NewWithSchemaURL(semconv.SchemaURL, attrs...), nil
+}
+
+// Detect returns a *Resource that describes the Compute Engine instance
+// and the cluster of the GKE node the process runs on.
+func (GKE) Detect(ctx context.Context) (*resource.Resource, error) {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") == "" {
+		return nil, fmt.Errorf("%w: not running in Kubernetes", cloud.ErrNotDetected)
+	}
+	attrs, err := detectGCE(ctx, semconv.CloudPlatformGCPKubernetesEngine)
+	if err != nil {
+		return nil, err
+	}
+	cluster, err := get(ctx, "instance/attributes/cluster-name")
+	if err != nil {
+		return resource.NewWithSchemaURL(semconv.SchemaURL, attrs...),
+			fmt.Errorf("%w: %s: %v", resource.ErrPartialResource, semconv.K8SClusterNameKey, err)
+	}
+	attrs = append(attrs, semconv.K8SClusterNameKey.String(cluster))
+	return resource.NewWithSchemaURL(semconv.SchemaURL, attrs...), nil
+}
+
+func detectGCE(ctx context.Context, platform attribute.KeyValue) ([]attribute.KeyValue, error) {
+	// The first request tells whether the process runs on GCE.
+	project, err := get(ctx, "project/project-id")
+	if err != nil {
+		var statusErr *metadata.StatusError
+		if errors.As(err, &statusErr) {
+			return nil, fmt.Errorf("%w: %v", cloud.ErrNotDetected, err)
+		}
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	for _, path := range []string{"instance/id", "instance/name", "instance/zone", "instance/machine-type"} {
+		v, err := get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		values[path] = v
+	}
+
+	// The zone and the machine type are returned as
+	// projects/<number>/zones/<zone> and
+	// projects/<number>/machineTypes/<type>.
+	zone := lastSegment(values["instance/zone"])
+	attrs := []attribute.KeyValue{
+		semconv.CloudProviderGCP,
+		platform,
+		semconv.CloudAccountIDKey.String(project),
+		semconv.CloudAvailabilityZoneKey.String(zone),
+		semconv.HostIDKey.String(values["instance/id"]),
+		semconv.HostNameKey.String(values["instance/name"]),
+		semconv.HostTypeKey.String(lastSegment(values["instance/machine-type"])),
+	}
+	// The region is the zone without its suffix, e.g. us-central1 for
+	// us-central1-a.
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		attrs = append(attrs, semconv.CloudRegionKey.String(zone[:i]))
+	}
+	return attrs, nil
+}
+
+// get returns the metadata value of path. The responses not coming from
+// a Google metadata server are reported as ErrNotDetected, an EC2
+// instance metadata service is also reachable at the same address.
+func get(ctx context.Context, path string) (string, error) {
+	endpoint := gceEndpoint
+	if host := os.Getenv("GCE_METADATA_HOST"); host != "" {
+		endpoint = "http://" + host
+	}
+	body, header, err := metadata.Fetch(ctx, http.MethodGet, endpoint+"/computeMetadata/v1/"+path, http.Header{
+		flavorHeader: []string{flavor},
+	})
+	if header != nil && header.Get(flavorHeader) != flavor {
+		return "", fmt.Errorf("%w: not a Google metadata server", cloud.ErrNotDetected)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
+
+func lastSegment(s string) string {
+	return s[strings.LastIndex(s, "/")+1:]
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/resource/cloud"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+var testMetadata = map[string]string{
+	"project/project-id":               "my-project",
+	"instance/id":                      "4520031799277581759",
+	"instance/name":                    "instance-1",
+	"instance/zone":                    "projects/123456789/zones/us-central1-a",
+	"instance/machine-type":            "projects/123456789/machineTypes/e2-medium",
+	"instance/attributes/cluster-name": "my-cluster",
+}
+
+func newMetadataServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(flavorHeader, flavor)
+		assert.Equal(t, flavor, r.Header.Get(flavorHeader))
+		v, ok := testMetadata[r.URL.Path[len("/computeMetadata/v1/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(v))
+	}))
+}
+
+func setEndpoint(t *testing.T, endpoint string) {
+	orig := gceEndpoint
+	gceEndpoint = endpoint
+	t.Cleanup(func() { gceEndpoint = orig })
+}
+
+func TestGCEDetect(t *testing.T) {
+	srv := newMetadataServer(t)
+	defer srv.Close()
+	setEndpoint(t, srv.URL)
+
+	res, err := GCE{}.Detect(context.Background())
+	require.NoError(t, err)
+	expected := resource.NewWithSchemaURL(semconv.SchemaURL,
+		semconv.CloudProviderGCP,
+		semconv.CloudPlatformGCPComputeEngine,
+		semconv.CloudAccountIDKey.String("my-project"),
+		semconv.CloudRegionKey.String("us-central1"),
+		semconv.CloudAvailabilityZoneKey.String("us-central1-a"),
+		semconv.HostIDKey.String("4520031799277581759"),
+		semconv.HostNameKey.String("instance-1"),
+		semconv.HostTypeKey.String("e2-medium"),
+	)
+	assert.Equal(t, expected.Equivalent(), res.Equivalent())
+}
+
+func TestGKEDetect(t *testing.T) {
+	srv := newMetadataServer(t)
+	defer srv.Close()
+	setEndpoint(t, srv.URL)
+
+	store, err := ottest.SetEnvVariables(map[string]string{"KUBERNETES_SERVICE_HOST": "10.0.0.1"})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := GKE{}.Detect(context.Background())
+	require.NoError(t, err)
+	platform, _ := res.Set().Value(semconv.CloudPlatformKey)
+	assert.Equal(t, "gcp_kubernetes_engine", platform.AsString())
+	cluster, _ := res.Set().Value(semconv.K8SClusterNameKey)
+	assert.Equal(t, "my-cluster", cluster.AsString())
+}
+
+func TestGKENotInKubernetes(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{"KUBERNETES_SERVICE_HOST": ""})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	_, err = GKE{}.Detect(context.Background())
+	assert.True(t, errors.Is(err, cloud.ErrNotDetected))
+}
+
+func TestGCENotDetected(t *testing.T) {
+	// Another metadata server, e.g. the one of EC2, at the same address.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("not google"))
+	}))
+	defer srv.Close()
+	setEndpoint(t, srv.URL)
+
+	_, err := GCE{}.Detect(context.Background())
+	assert.True(t, errors.Is(err, cloud.ErrNotDetected))
+
+	srv.Close()
+	_, err = GCE{}.Detect(context.Background())
+	assert.True(t, errors.Is(err, cloud.ErrNotDetected))
+}
+
+func TestGCEMetadataHostEnv(t *testing.T) {
+	srv := newMetadataServer(t)
+	defer srv.Close()
+	setEndpoint(t, "http://invalid.test")
+
+	store, err := ottest.SetEnvVariables(map[string]string{"GCE_METADATA_HOST": srv.Listener.Addr().String()})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	_, err = GCE{}.Detect(context.Background())
+	require.NoError(t, err)
+}
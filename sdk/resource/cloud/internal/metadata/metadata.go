@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metadata fetches the metadata of cloud platforms from their
+// metadata endpoints.
+package metadata // import "go.opentelemetry.io/otel/sdk/resource/cloud/internal/metadata"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"go.opentelemetry.io/otel/sdk/resource/cloud"
+)
+
+// maxBodySize limits the size of the metadata read from an endpoint.
+const maxBodySize = 1 << 20
+
+// client does not use the proxies configured in the environment, the
+// metadata endpoints are only reachable from the instance itself.
+var client = &http.Client{
+	Transport: &http.Transport{Proxy: nil},
+}
+
+// Fetch performs a method request of url with header and returns the body
+// and the header of its response. The errors to reach the endpoint wrap
+// cloud.ErrNotDetected, the process not running on its platform.
+func Fetch(ctx context.Context, method, url string, header http.Header) ([]byte, http.Header, error) {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", cloud.ErrNotDetected, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s %s: %w", method, url, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp.Header, &StatusError{Method: method, URL: url, StatusCode: resp.StatusCode}
+	}
+	return body, resp.Header, nil
+}
+
+// StatusError is returned by Fetch when the status of the response is not
+// 200 OK.
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
@@ -16,26 +16,23 @@ package resource // import "go.opentelemetry.io/otel/sdk/resource"
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 )
 
 // config contains configuration for Resource creation.
 type config struct {
-	// detectors that will be evaluated.
+	// detectors that will be evaluated, in order.
 	detectors []Detector
 
-	// telemetrySDK is used to specify non-default
-	// `telemetry.sdk.*` attributes.
-	telemetrySDK Detector
+	// schemaURL is the schema URL of the created Resource, the one
+	// of the detectors is used if it is empty.
+	schemaURL string
 
-	// HostResource is used to specify non-default `host.*`
-	// attributes.
-	host Detector
-
-	// FromEnv is used to specify non-default OTEL_RESOURCE_ATTRIBUTES
-	// attributes.
-	fromEnv Detector
+	// detectorTimeout is the maximum duration of each detector, there
+	// is no limit if it is not positive.
+	detectorTimeout time.Duration
 }
 
 // Option is the interface that applies a configuration option.
@@ -81,85 +78,144 @@ func (o detectorsOption) Apply(cfg *config) {
 	cfg.detectors = append(cfg.detectors, o.detectors...)
 }
 
-// WithTelemetrySDK overrides the builtin `telemetry.sdk.*`
-// attributes.  Use nil to disable these attributes entirely.
-func WithTelemetrySDK(d Detector) Option {
-	return telemetrySDKOption{Detector: d}
+// WithFromEnv adds the FromEnv detector, which provides the attributes
+// of the OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME environment
+// variables, to the configured Resource.
+func WithFromEnv() Option {
+	return WithDetectors(FromEnv{})
 }
 
-type telemetrySDKOption struct {
-	option
-	Detector
+// WithDefaultServiceName adds a detector providing the
+// `unknown_service:<executable name>` service.name attribute to the
+// configured Resource. Add it before the detectors that provide the
+// service.name of the program, so that it is only used as a fallback.
+func WithDefaultServiceName() Option {
+	return WithDetectors(defaultServiceNameDetector{})
 }
 
-// Apply implements Option.
-func (o telemetrySDKOption) Apply(cfg *config) {
-	cfg.telemetrySDK = o.Detector
+// WithBuiltinDetectors adds the detectors of the Resource of the SDK, in
+// the order of their precedence: WithDefaultServiceName, WithTelemetrySDK,
+// WithHost, and WithFromEnv. The detectors added after it override
+// their attributes.
+func WithBuiltinDetectors() Option {
+	return WithDetectors(defaultServiceNameDetector{}, TelemetrySDK{}, Host{}, FromEnv{})
 }
 
-// WithHost overrides the builtin `host.*` attributes.  Use nil to
-// disable these attributes entirely.
-func WithHost(d Detector) Option {
-	return hostOption{Detector: d}
+// WithTelemetrySDK adds the TelemetrySDK detector, which provides the
+// `telemetry.sdk.*` attributes, to the configured Resource.
+func WithTelemetrySDK() Option {
+	return WithDetectors(TelemetrySDK{})
 }
 
-type hostOption struct {
-	option
-	Detector
+// WithHost adds the Host detector, which provides the `host.*`
+// attributes, to the configured Resource.
+func WithHost() Option {
+	return WithDetectors(Host{})
 }
 
-// Apply implements Option.
-func (o hostOption) Apply(cfg *config) {
-	cfg.host = o.Detector
+// WithProcess adds the Process detector, which provides the
+// `process.*` attributes, to the configured Resource.
+func WithProcess() Option {
+	return WithDetectors(Process{})
+}
+
+// WithOS adds the OS detector, which provides the `os.*` attributes, to
+// the configured Resource.
+func WithOS() Option {
+	return WithDetectors(OS{})
+}
+
+// WithContainer adds the Container detector, which provides the
+// `container.id` attribute, to the configured Resource.
+func WithContainer() Option {
+	return WithDetectors(Container{})
 }
 
-// WithFromEnv overrides the builtin detector for
-// OTEL_RESOURCE_ATTRIBUTES.  Use nil to disable environment checking.
-func WithFromEnv(d Detector) Option {
-	return fromEnvOption{Detector: d}
+// WithKubernetes adds the Kubernetes detector, which provides the `k8s.*`
+// attributes read from the environment variables described by Kubernetes,
+// to the configured Resource.
+func WithKubernetes() Option {
+	return WithDetectors(Kubernetes{})
 }
 
-type fromEnvOption struct {
+// WithSchemaURL sets the schema URL of the configured Resource,
+// replacing the one of its detectors.
+func WithSchemaURL(schemaURL string) Option {
+	return schemaURLOption{schemaURL: schemaURL}
+}
+
+type schemaURLOption struct {
 	option
-	Detector
+	schemaURL string
 }
 
 // Apply implements Option.
-func (o fromEnvOption) Apply(cfg *config) {
-	cfg.fromEnv = o.Detector
+func (o schemaURLOption) Apply(cfg *config) {
+	cfg.schemaURL = o.schemaURL
 }
 
-// WithoutBuiltin disables all the builtin detectors, including the
-// telemetry.sdk.*, host.*, and the environment detector.
-func WithoutBuiltin() Option {
-	return noBuiltinOption{}
+// WithDetectorTimeout limits the duration of each detector evaluated
+// for the configured Resource to timeout. The detectors still running
+// after timeout are reported as failed and do not contribute to the
+// Resource, the other detectors are not affected.
+func WithDetectorTimeout(timeout time.Duration) Option {
+	return detectorTimeoutOption{timeout: timeout}
 }
 
-type noBuiltinOption struct {
+type detectorTimeoutOption struct {
 	option
+	timeout time.Duration
 }
 
 // Apply implements Option.
-func (o noBuiltinOption) Apply(cfg *config) {
-	cfg.host = nil
-	cfg.telemetrySDK = nil
-	cfg.fromEnv = nil
+func (o detectorTimeoutOption) Apply(cfg *config) {
+	cfg.detectorTimeout = o.timeout
 }
 
-// New returns a Resource combined from the provided attributes,
-// user-provided detectors and builtin detectors.
+// WithoutBuiltin has no effect, New only evaluates the detectors added by
+// its options.
+//
+// Deprecated: New no longer evaluates builtin detectors by default, this
+// option will be removed in a future release. Use WithBuiltinDetectors to
+// add them.
+func WithoutBuiltin() Option {
+	return detectorsOption{}
+}
+
+// New returns a Resource combined from the detectors added by opts.  The
+// detectors are evaluated concurrently and their results are merged in
+// the order of opts, the attributes of a detector overwriting the ones
+// of the detectors before it, e.g.
+//
+//	resource.New(ctx,
+//		resource.WithTelemetrySDK(),
+//		resource.WithHost(),
+//		resource.WithAttributes(semconv.ServiceNameKey.String("checkout")),
+//		resource.WithFromEnv(),
+//	)
+//
+// returns a Resource whose service.name can be overridden with the
+// environment.  No detector is evaluated by default, add the detectors of
+// the Resource of the SDK with WithBuiltinDetectors, e.g.
+//
+//	resource.New(ctx,
+//		resource.WithBuiltinDetectors(),
+//		resource.WithAttributes(semconv.ServiceNameKey.String("checkout")),
+//	)
+//
+// returns a Resource whose service.name defaults to
+// `unknown_service:<executable name>` when it is not configured.
 func New(ctx context.Context, opts ...Option) (*Resource, error) {
-	cfg := config{
-		telemetrySDK: TelemetrySDK{},
-		host:         Host{},
-		fromEnv:      FromEnv{},
-	}
+	var cfg config
 	for _, opt := range opts {
 		opt.Apply(&cfg)
 	}
-	detectors := append(
-		[]Detector{cfg.telemetrySDK, cfg.host, cfg.fromEnv},
-		cfg.detectors...,
-	)
-	return Detect(ctx, detectors...)
+	res, err := detect(ctx, cfg.detectorTimeout, cfg.detectors)
+	if res == nil {
+		res = Empty()
+	}
+	if cfg.schemaURL != "" {
+		res = NewWithSchemaURL(cfg.schemaURL, res.Attributes()...)
+	}
+	return res, err
 }
@@ -18,6 +18,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -26,29 +28,24 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	ottest "go.opentelemetry.io/otel/internal/internaltest"
 	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
 const envVar = "OTEL_RESOURCE_ATTRIBUTES"
 
-func TestDefaultConfig(t *testing.T) {
+func TestNewWithoutOptions(t *testing.T) {
 	store, err := ottest.SetEnvVariables(map[string]string{
-		envVar: "",
+		envVar: "key=value",
 	})
 	require.NoError(t, err)
 	defer func() { require.NoError(t, store.Restore()) }()
 
-	ctx := context.Background()
-	res, err := resource.New(ctx)
+	res, err := resource.New(context.Background())
 	require.NoError(t, err)
-	require.EqualValues(t, map[string]string{
-		"host.name":              hostname(),
-		"telemetry.sdk.name":     "opentelemetry",
-		"telemetry.sdk.language": "go",
-		"telemetry.sdk.version":  otel.Version(),
-	}, toMap(res))
+	require.Equal(t, resource.Empty(), res)
 }
 
-func TestDefaultConfigNoHost(t *testing.T) {
+func TestNewBuiltinDetectors(t *testing.T) {
 	store, err := ottest.SetEnvVariables(map[string]string{
 		envVar: "",
 	})
@@ -56,54 +53,101 @@ func TestDefaultConfigNoHost(t *testing.T) {
 	defer func() { require.NoError(t, store.Restore()) }()
 
 	ctx := context.Background()
-	res, err := resource.New(ctx, resource.WithHost(nil))
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithFromEnv(),
+	)
 	require.NoError(t, err)
 	require.EqualValues(t, map[string]string{
+		"host.name":              hostname(),
+		"host.arch":              hostArch(),
 		"telemetry.sdk.name":     "opentelemetry",
 		"telemetry.sdk.language": "go",
 		"telemetry.sdk.version":  otel.Version(),
 	}, toMap(res))
+	require.Equal(t, semconv.SchemaURL, res.SchemaURL())
 }
 
-func TestDefaultConfigNoEnv(t *testing.T) {
+// defaultServiceName returns the service.name of the default service name
+// detector in the test binary.
+func defaultServiceName(t *testing.T) string {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	return "unknown_service:" + filepath.Base(executable)
+}
+
+func TestNewWithBuiltinDetectors(t *testing.T) {
 	store, err := ottest.SetEnvVariables(map[string]string{
-		envVar: "from=here",
+		envVar: "key=value",
 	})
 	require.NoError(t, err)
 	defer func() { require.NoError(t, store.Restore()) }()
 
 	ctx := context.Background()
-	res, err := resource.New(ctx, resource.WithFromEnv(nil))
+	res, err := resource.New(ctx, resource.WithBuiltinDetectors())
 	require.NoError(t, err)
 	require.EqualValues(t, map[string]string{
+		"key":                    "value",
 		"host.name":              hostname(),
+		"host.arch":              hostArch(),
+		"service.name":           defaultServiceName(t),
 		"telemetry.sdk.name":     "opentelemetry",
 		"telemetry.sdk.language": "go",
 		"telemetry.sdk.version":  otel.Version(),
 	}, toMap(res))
+
+	res, err = resource.New(ctx,
+		resource.WithBuiltinDetectors(),
+		resource.WithAttributes(semconv.ServiceNameKey.String("checkout")),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "checkout", toMap(res)["service.name"])
 }
 
-func TestDefaultConfigWithEnv(t *testing.T) {
+func TestNewWithDefaultServiceName(t *testing.T) {
+	ctx := context.Background()
+	res, err := resource.New(ctx, resource.WithDefaultServiceName())
+	require.NoError(t, err)
+	require.EqualValues(t, map[string]string{
+		"service.name": defaultServiceName(t),
+	}, toMap(res))
+
+	res, err = resource.New(ctx,
+		resource.WithDefaultServiceName(),
+		resource.WithAttributes(semconv.ServiceNameKey.String("checkout")),
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, map[string]string{"service.name": "checkout"}, toMap(res))
+}
+
+func TestNewWithoutBuiltin(t *testing.T) {
+	res, err := resource.New(context.Background(),
+		resource.WithoutBuiltin(),
+		resource.WithAttributes(attribute.String("A", "B")),
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, map[string]string{"A": "B"}, toMap(res))
+}
+
+func TestNewWithoutFromEnv(t *testing.T) {
 	store, err := ottest.SetEnvVariables(map[string]string{
-		envVar: "key=value,other=attr",
+		envVar: "from=here",
 	})
 	require.NoError(t, err)
 	defer func() { require.NoError(t, store.Restore()) }()
 
 	ctx := context.Background()
-	res, err := resource.New(ctx)
+	res, err := resource.New(ctx, resource.WithTelemetrySDK())
 	require.NoError(t, err)
 	require.EqualValues(t, map[string]string{
-		"key":                    "value",
-		"other":                  "attr",
-		"host.name":              hostname(),
 		"telemetry.sdk.name":     "opentelemetry",
 		"telemetry.sdk.language": "go",
 		"telemetry.sdk.version":  otel.Version(),
 	}, toMap(res))
 }
 
-func TestWithoutBuiltin(t *testing.T) {
+func TestNewWithFromEnv(t *testing.T) {
 	store, err := ottest.SetEnvVariables(map[string]string{
 		envVar: "key=value,other=attr",
 	})
@@ -111,13 +155,14 @@ func TestWithoutBuiltin(t *testing.T) {
 	defer func() { require.NoError(t, store.Restore()) }()
 
 	ctx := context.Background()
-	res, err := resource.New(
-		ctx,
-		resource.WithoutBuiltin(),
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(),
 		resource.WithAttributes(attribute.String("hello", "collector")),
 	)
 	require.NoError(t, err)
 	require.EqualValues(t, map[string]string{
+		"key":   "value",
+		"other": "attr",
 		"hello": "collector",
 	}, toMap(res))
 }
@@ -130,6 +175,71 @@ func toMap(res *resource.Resource) map[string]string {
 	return m
 }
 
+func TestServiceNameFromEnv(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		envVar:              "service.name=attributes,key=value",
+		"OTEL_SERVICE_NAME": "from-env",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := resource.New(context.Background(), resource.WithFromEnv())
+	require.NoError(t, err)
+	require.EqualValues(t, map[string]string{
+		"key":          "value",
+		"service.name": "from-env",
+	}, toMap(res))
+}
+
+func TestNewOverrideOrder(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		"OTEL_SERVICE_NAME": "from-env",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	attrs := resource.WithAttributes(attribute.String("service.name", "attribute"))
+
+	res, err := resource.New(context.Background(), attrs, resource.WithFromEnv())
+	require.NoError(t, err)
+	require.EqualValues(t, map[string]string{"service.name": "from-env"}, toMap(res))
+
+	res, err = resource.New(context.Background(), resource.WithFromEnv(), attrs)
+	require.NoError(t, err)
+	require.EqualValues(t, map[string]string{"service.name": "attribute"}, toMap(res))
+}
+
+func TestWithSchemaURL(t *testing.T) {
+	res, err := resource.New(context.Background(),
+		resource.WithTelemetrySDK(),
+		resource.WithSchemaURL("https://example.com/schemas/1.0.0"),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/schemas/1.0.0", res.SchemaURL())
+	require.Contains(t, res.Attributes(), semconv.TelemetrySDKLanguageGo)
+
+	res, err = resource.New(context.Background(), resource.WithSchemaURL("https://example.com/schemas/1.0.0"))
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/schemas/1.0.0", res.SchemaURL())
+	require.Equal(t, 0, res.Len())
+
+	res, err = resource.New(context.Background(), resource.WithAttributes(attribute.String("A", "B")))
+	require.NoError(t, err)
+	require.Equal(t, "", res.SchemaURL())
+}
+
+func hostArch() string {
+	arch, ok := map[string]string{
+		"386":     "x86",
+		"arm":     "arm32",
+		"ppc64le": "ppc64",
+	}[runtime.GOARCH]
+	if !ok {
+		return runtime.GOARCH
+	}
+	return arch
+}
+
 func hostname() string {
 	hn, err := os.Hostname()
 	if err != nil {
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// Container is a Detector that provides the identifier of the container
+// the process runs in, read from the cgroup files of the process. No
+// attributes are provided outside of a container. It is added to a
+// Resource created with New by the WithContainer option.
+type Container struct{}
+
+var _ Detector = Container{}
+
+const (
+	cgroupPath    = "/proc/self/cgroup"
+	mountinfoPath = "/proc/self/mountinfo"
+)
+
+var (
+	// cgroupContainerIDRe matches the container identifier at the end
+	// of a cgroup v1 path, e.g. /docker/<id>, /kubepods/.../<id> or
+	// /system.slice/docker-<id>.scope.
+	cgroupContainerIDRe = regexp.MustCompile(`([0-9a-f]{64})(?:\.scope)?$`)
+
+	// mountinfoContainerIDRe matches the container identifier in the
+	// source of a mount made by the container runtime, e.g.
+	// /var/lib/docker/containers/<id>/hostname, in cgroup v2 layouts.
+	mountinfoContainerIDRe = regexp.MustCompile(`/(?:containers|sandboxes)/([0-9a-f]{64})/`)
+)
+
+// Detect returns a *Resource that describes the container the process
+// runs in, or an empty *Resource if it does not run in a container.
+func (Container) Detect(context.Context) (*Resource, error) {
+	id, err := containerID()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", semconv.ContainerIDKey, err)
+	}
+	if id == "" {
+		return Empty(), nil
+	}
+	return NewWithSchemaURL(semconv.SchemaURL, semconv.ContainerIDKey.String(id)), nil
+}
+
+// containerID returns the identifier of the container found in the
+// cgroup file, with the cgroup v1 layout, or else in the mountinfo file,
+// with the cgroup v2 layout. It is empty if none is found.
+func containerID() (string, error) {
+	id, err := scanFile(cgroupPath, containerIDFromCgroup)
+	if id != "" || err != nil {
+		return id, err
+	}
+	return scanFile(mountinfoPath, containerIDFromMountinfo)
+}
+
+// scanFile returns the first non-empty result of match for the lines of
+// the file name. A file that does not exist produces no result.
+func scanFile(name string, match func(line string) string) (string, error) {
+	f, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return scanLines(f, match)
+}
+
+func scanLines(r io.Reader, match func(line string) string) (string, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if id := match(scanner.Text()); id != "" {
+			return id, nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// containerIDFromCgroup returns the container identifier of a line of a
+// cgroup file, `hierarchy-ID:controller-list:cgroup-path`.
+func containerIDFromCgroup(line string) string {
+	parts := strings.SplitN(strings.TrimSpace(line), ":", 3)
+	if len(parts) != 3 {
+		return ""
+	}
+	if m := cgroupContainerIDRe.FindStringSubmatch(parts[2]); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// containerIDFromMountinfo returns the container identifier of a line of
+// a mountinfo file.
+func containerIDFromMountinfo(line string) string {
+	if m := mountinfoContainerIDRe.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContainerID = "a6b2c1e4f38d9f0c7ac6e2f1d6b4b8e3f0f2a9c6d1e7b5a4c3d2e1f0a9b8c7d6"
+
+func TestContainerIDFromCgroup(t *testing.T) {
+	for _, tc := range []struct {
+		desc     string
+		content  string
+		expected string
+	}{
+		{
+			desc: "docker",
+			content: `12:pids:/docker/` + testContainerID + `
+11:cpuset:/docker/` + testContainerID + `
+`,
+			expected: testContainerID,
+		},
+		{
+			desc:     "kubernetes",
+			content:  `11:memory:/kubepods/besteffort/pod5e1c7e8a-8c43-4e5a-9f0e-1d4b5c6d7e8f/` + testContainerID + "\n",
+			expected: testContainerID,
+		},
+		{
+			desc:     "systemd",
+			content:  `1:name=systemd:/system.slice/docker-` + testContainerID + ".scope\n",
+			expected: testContainerID,
+		},
+		{
+			desc: "host",
+			content: `12:pids:/user.slice/user-1000.slice
+1:name=systemd:/init.scope
+`,
+		},
+		{
+			desc:    "cgroup v2",
+			content: "0::/\n",
+		},
+		{
+			desc:    "malformed",
+			content: testContainerID + "\n",
+		},
+	} {
+		id, err := scanLines(strings.NewReader(tc.content), containerIDFromCgroup)
+		require.NoError(t, err, tc.desc)
+		assert.Equal(t, tc.expected, id, tc.desc)
+	}
+}
+
+func TestContainerIDFromMountinfo(t *testing.T) {
+	id, err := scanLines(strings.NewReader(`1096 1088 0:104 / /sys/fs/cgroup ro,nosuid,nodev,noexec,relatime - cgroup2 cgroup rw
+1103 1084 254:1 /docker/containers/`+testContainerID+`/resolv.conf /etc/resolv.conf rw,relatime - ext4 /dev/vda1 rw
+1104 1084 254:1 /docker/containers/`+testContainerID+`/hostname /etc/hostname rw,relatime - ext4 /dev/vda1 rw
+`), containerIDFromMountinfo)
+	require.NoError(t, err)
+	assert.Equal(t, testContainerID, id)
+
+	id, err = scanLines(strings.NewReader(`22 1 8:1 / / rw,relatime shared:1 - ext4 /dev/sda1 rw
+`), containerIDFromMountinfo)
+	require.NoError(t, err)
+	assert.Empty(t, id)
+}
+
+func TestContainerDetector(t *testing.T) {
+	res, err := Container{}.Detect(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, res)
+}
+
+func TestScanFileNotExist(t *testing.T) {
+	id, err := scanFile("/does/not/exist", containerIDFromCgroup)
+	require.NoError(t, err)
+	assert.Empty(t, id)
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DefaultResolveTimeout is the default maximum time Resolve waits for all
+// ValueProviders of a Dynamic resource.
+const DefaultResolveTimeout = 100 * time.Millisecond
+
+// ValueProvider returns the current value of a dynamic resource attribute.
+// It may be called concurrently and should return promptly, the passed
+// context is canceled once the resolve timeout is reached.
+type ValueProvider func(ctx context.Context) (attribute.Value, error)
+
+// Dynamic describes resource attributes whose values are not known when a
+// TracerProvider or Controller is built and may change over its lifetime,
+// e.g. the leader status of a replica or the active configuration version.
+//
+// Their values are resolved by calling Resolve when telemetry is exported,
+// once per exported batch, and merged over the static Resource of the
+// exported telemetry. A dynamic attribute is therefore never frozen, but it
+// also does not describe the entity at the time the telemetry was recorded.
+type Dynamic struct {
+	keys      []attribute.Key
+	providers []ValueProvider
+	timeout   time.Duration
+}
+
+// DynamicOption configures a Dynamic resource.
+type DynamicOption func(*Dynamic)
+
+// WithDynamicAttribute adds an attribute with key k whose value is returned
+// by p when the Dynamic resource is resolved. If several providers are
+// added for the same key, the value of the last one that succeeds is used.
+func WithDynamicAttribute(k attribute.Key, p ValueProvider) DynamicOption {
+	return func(d *Dynamic) {
+		if p == nil {
+			return
+		}
+		d.keys = append(d.keys, k)
+		d.providers = append(d.providers, p)
+	}
+}
+
+// WithResolveTimeout sets the maximum time Resolve waits for all
+// ValueProviders. Attributes whose provider did not return in time are
+// omitted from the resolved Resource. A non-positive timeout is ignored and
+// DefaultResolveTimeout is used.
+func WithResolveTimeout(timeout time.Duration) DynamicOption {
+	return func(d *Dynamic) {
+		if timeout > 0 {
+			d.timeout = timeout
+		}
+	}
+}
+
+// NewDynamic returns a Dynamic resource configured with opts.
+func NewDynamic(opts ...DynamicOption) *Dynamic {
+	d := &Dynamic{timeout: DefaultResolveTimeout}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Resolve calls all ValueProviders concurrently and returns a Resource
+// holding the values they returned. Resolve returns once all providers
+// returned or the resolve timeout or ctx expired, whichever happens first.
+// Errors returned by providers, and providers that did not return in time,
+// are reported to the error handlers of the traces and metrics signals, see
+// otel.HandleSignals, and their attributes omitted.
+func (d *Dynamic) Resolve(ctx context.Context) *Resource {
+	if d == nil || len(d.providers) == 0 {
+		return Empty()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.timeout)
+	defer cancel()
+
+	type result struct {
+		idx int
+		val attribute.Value
+		err error
+	}
+	// Buffered so late providers do not block once Resolve returned.
+	results := make(chan result, len(d.providers))
+	for i, p := range d.providers {
+		go func(i int, p ValueProvider) {
+			v, err := p(ctx)
+			results <- result{idx: i, val: v, err: err}
+		}(i, p)
+	}
+
+	values := make([]*attribute.Value, len(d.providers))
+	pending := len(d.providers)
+	for pending > 0 {
+		select {
+		case r := <-results:
+			pending--
+			if r.err != nil {
+				otel.HandleSignals(fmt.Errorf("resolving dynamic resource attribute %q: %w", d.keys[r.idx], r.err), otel.TracesSignal, otel.MetricsSignal)
+				continue
+			}
+			v := r.val
+			values[r.idx] = &v
+		case <-ctx.Done():
+			otel.HandleSignals(fmt.Errorf("resolving dynamic resource attributes: %d of %d providers did not return: %w", pending, len(d.providers), ctx.Err()), otel.TracesSignal, otel.MetricsSignal)
+			pending = 0
+		}
+	}
+
+	attrs := make([]attribute.KeyValue, 0, len(values))
+	for i, v := range values {
+		if v != nil {
+			attrs = append(attrs, attribute.KeyValue{Key: d.keys[i], Value: *v})
+		}
+	}
+	return NewWithAttributes(attrs...)
+}
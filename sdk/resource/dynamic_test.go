@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+func TestDynamicResolve(t *testing.T) {
+	var version int64
+	d := resource.NewDynamic(
+		resource.WithDynamicAttribute("config.version", func(context.Context) (attribute.Value, error) {
+			return attribute.Int64Value(atomic.AddInt64(&version, 1)), nil
+		}),
+		resource.WithDynamicAttribute("leader", func(context.Context) (attribute.Value, error) {
+			return attribute.BoolValue(true), nil
+		}),
+	)
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int64("config.version", 1),
+		attribute.Bool("leader", true),
+	}, d.Resolve(context.Background()).Attributes())
+	// Values are resolved again on every call.
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int64("config.version", 2),
+		attribute.Bool("leader", true),
+	}, d.Resolve(context.Background()).Attributes())
+}
+
+func TestDynamicResolveEmpty(t *testing.T) {
+	var d *resource.Dynamic
+	assert.Equal(t, 0, d.Resolve(context.Background()).Len())
+	assert.Equal(t, 0, resource.NewDynamic(resource.WithDynamicAttribute("k", nil)).Resolve(context.Background()).Len())
+}
+
+func TestDynamicResolveOmitsFailedProviders(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	d := resource.NewDynamic(
+		resource.WithResolveTimeout(10*time.Millisecond),
+		resource.WithDynamicAttribute("ok", func(context.Context) (attribute.Value, error) {
+			return attribute.StringValue("v"), nil
+		}),
+		resource.WithDynamicAttribute("failed", func(context.Context) (attribute.Value, error) {
+			return attribute.Value{}, errors.New("unavailable")
+		}),
+		resource.WithDynamicAttribute("slow", func(context.Context) (attribute.Value, error) {
+			<-release
+			return attribute.StringValue("late"), nil
+		}),
+	)
+
+	start := time.Now()
+	r := d.Resolve(context.Background())
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+	assert.Equal(t, []attribute.KeyValue{attribute.String("ok", "v")}, r.Attributes())
+}
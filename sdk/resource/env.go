@@ -21,10 +21,18 @@ import (
 	"strings"
 
 	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
-// envVar is the environment variable name OpenTelemetry Resource information can be assigned to.
-const envVar = "OTEL_RESOURCE_ATTRIBUTES"
+const (
+	// envVar is the environment variable name OpenTelemetry Resource information can be assigned to.
+	envVar = "OTEL_RESOURCE_ATTRIBUTES"
+
+	// svcNameVar is the environment variable name the service.name
+	// attribute can be assigned to, it takes precedence over the one
+	// of envVar.
+	svcNameVar = "OTEL_SERVICE_NAME"
+)
 
 var (
 	// errMissingValue is returned when a resource value is missing.
@@ -32,10 +40,11 @@ var (
 )
 
 // FromEnv is a Detector that implements the Detector and collects
-// resources from environment.  This Detector is included as a
-// builtin.  If these resource attributes are not wanted, use the
-// WithFromEnv(nil) or WithoutBuiltin() options to explicitly disable
-// them.
+// resources from environment: the attributes of the
+// OTEL_RESOURCE_ATTRIBUTES environment variable and the service.name
+// attribute of the OTEL_SERVICE_NAME environment variable, which takes
+// precedence over the former.  It is added to a Resource created with
+// New by the WithFromEnv option.
 type FromEnv struct{}
 
 // compile time assertion that FromEnv implements Detector interface
@@ -44,11 +53,21 @@ var _ Detector = FromEnv{}
 // Detect collects resources from environment
 func (FromEnv) Detect(context.Context) (*Resource, error) {
 	attrs := strings.TrimSpace(os.Getenv(envVar))
+	svcName := strings.TrimSpace(os.Getenv(svcNameVar))
 
-	if attrs == "" {
+	if attrs == "" && svcName == "" {
 		return Empty(), nil
 	}
-	return constructOTResources(attrs)
+
+	res, err := Empty(), error(nil)
+	if attrs != "" {
+		res, err = constructOTResources(attrs)
+	}
+	if svcName != "" {
+		// The service name cannot conflict, it has no schema URL.
+		res, _ = Merge(res, NewWithAttributes(semconv.ServiceNameKey.String(svcName)))
+	}
+	return res, err
 }
 
 func constructOTResources(s string) (*Resource, error) {
@@ -24,6 +24,7 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
 func TestDetectOnePair(t *testing.T) {
@@ -71,6 +72,36 @@ func TestEmpty(t *testing.T) {
 	assert.Equal(t, Empty(), res)
 }
 
+func TestDetectServiceNameFromEnv(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		envVar:     "key=value,service.name=bar",
+		svcNameVar: "foo",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	detector := &FromEnv{}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, NewWithAttributes(
+		attribute.String("key", "value"),
+		semconv.ServiceNameKey.String("foo"),
+	).Equivalent(), res.Equivalent())
+}
+
+func TestDetectServiceNameOnly(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		svcNameVar: "foo",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	detector := &FromEnv{}
+	res, err := detector.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, NewWithAttributes(semconv.ServiceNameKey.String("foo")).Equivalent(), res.Equivalent())
+}
+
 func TestMissingKeyError(t *testing.T) {
 	store, err := ottest.SetEnvVariables(map[string]string{
 		envVar: "key=value,key",
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"context"
+	"os"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// The environment variables read by the Kubernetes detector, see its
+// documentation. The comments are the downward API field paths of the ones
+// Kubernetes can provide.
+const (
+	k8sClusterNameVar    = "K8S_CLUSTER_NAME"
+	k8sNodeNameVar       = "K8S_NODE_NAME"      // spec.nodeName
+	k8sNamespaceNameVar  = "K8S_NAMESPACE_NAME" // metadata.namespace
+	k8sPodNameVar        = "K8S_POD_NAME"       // metadata.name
+	k8sPodUIDVar         = "K8S_POD_UID"        // metadata.uid
+	k8sContainerNameVar  = "K8S_CONTAINER_NAME"
+	k8sDeploymentNameVar = "K8S_DEPLOYMENT_NAME"
+)
+
+// Kubernetes is a Detector that provides the `k8s.*` attributes of the pod
+// the process runs in, read from environment variables. It is added to a
+// Resource created with New by the WithKubernetes option.
+//
+// The variables are a convention of this package, they are neither set by
+// Kubernetes nor specified by OpenTelemetry. They need to be set in the pod
+// specification, using the downward API for the ones Kubernetes knows:
+//
+//	env:
+//	- name: K8S_NODE_NAME
+//	  valueFrom:
+//	    fieldRef:
+//	      fieldPath: spec.nodeName
+//	- name: K8S_NAMESPACE_NAME
+//	  valueFrom:
+//	    fieldRef:
+//	      fieldPath: metadata.namespace
+//	- name: K8S_POD_NAME
+//	  valueFrom:
+//	    fieldRef:
+//	      fieldPath: metadata.name
+//	- name: K8S_POD_UID
+//	  valueFrom:
+//	    fieldRef:
+//	      fieldPath: metadata.uid
+//	- name: K8S_CLUSTER_NAME
+//	  value: production
+//	- name: K8S_CONTAINER_NAME
+//	  value: app
+//	- name: K8S_DEPLOYMENT_NAME
+//	  value: app
+//
+// No attributes are provided for the variables that are not set. The same
+// attributes can be set without this detector with the standard
+// OTEL_RESOURCE_ATTRIBUTES variable read by FromEnv, e.g. set to
+// "k8s.pod.name=$(K8S_POD_NAME)" after the variables above.
+type Kubernetes struct{}
+
+var _ Detector = Kubernetes{}
+
+// Detect returns a *Resource that describes the Kubernetes pod the process
+// runs in, or an empty *Resource if none of the environment variables is
+// set.
+func (Kubernetes) Detect(context.Context) (*Resource, error) {
+	attrs := semconv.K8S{
+		ClusterName:    os.Getenv(k8sClusterNameVar),
+		NodeName:       os.Getenv(k8sNodeNameVar),
+		NamespaceName:  os.Getenv(k8sNamespaceNameVar),
+		PodName:        os.Getenv(k8sPodNameVar),
+		PodUID:         os.Getenv(k8sPodUIDVar),
+		ContainerName:  os.Getenv(k8sContainerNameVar),
+		DeploymentName: os.Getenv(k8sDeploymentNameVar),
+	}.Attributes()
+	if len(attrs) == 0 {
+		return Empty(), nil
+	}
+	return NewWithSchemaURL(semconv.SchemaURL, attrs...), nil
+}
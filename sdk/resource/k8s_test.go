@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ottest "go.opentelemetry.io/otel/internal/internaltest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+func TestKubernetesDetect(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		k8sClusterNameVar:    "",
+		k8sNodeNameVar:       "node-1",
+		k8sNamespaceNameVar:  "default",
+		k8sPodNameVar:        "app-5d8f7",
+		k8sPodUIDVar:         "1e9f3a4c-7d2b-4c8e-9f6a-5b3d2c1e0f9a",
+		k8sContainerNameVar:  "",
+		k8sDeploymentNameVar: "app",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := Kubernetes{}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, semconv.SchemaURL, res.SchemaURL())
+	assert.Equal(t, NewWithSchemaURL(semconv.SchemaURL,
+		semconv.K8SNodeNameKey.String("node-1"),
+		semconv.K8SNamespaceNameKey.String("default"),
+		semconv.K8SPodNameKey.String("app-5d8f7"),
+		semconv.K8SPodUIDKey.String("1e9f3a4c-7d2b-4c8e-9f6a-5b3d2c1e0f9a"),
+		semconv.K8SDeploymentNameKey.String("app"),
+	).Equivalent(), res.Equivalent())
+}
+
+func TestKubernetesNotDetected(t *testing.T) {
+	store, err := ottest.SetEnvVariables(map[string]string{
+		k8sClusterNameVar:    "",
+		k8sNodeNameVar:       "",
+		k8sNamespaceNameVar:  "",
+		k8sPodNameVar:        "",
+		k8sPodUIDVar:         "",
+		k8sContainerNameVar:  "",
+		k8sDeploymentNameVar: "",
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, store.Restore()) }()
+
+	res, err := Kubernetes{}.Detect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Empty(), res)
+}
@@ -0,0 +1,58 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// OS is a Detector that provides information about the operating system
+// being run on: its type and a human readable description of its
+// version. The description is read from the os-release file on Linux and
+// from the system version file on macOS, it is omitted on the other
+// platforms. It is added to a Resource created with New by the WithOS
+// option.
+type OS struct{}
+
+var _ Detector = OS{}
+
+// Detect returns a *Resource that describes the operating system being
+// run on. If the description cannot be read, an error wrapping
+// ErrPartialResource is returned along with the type.
+func (OS) Detect(context.Context) (*Resource, error) {
+	attrs := []attribute.KeyValue{osType()}
+	description, err := osDescription()
+	if err != nil {
+		return NewWithSchemaURL(semconv.SchemaURL, attrs...), fmt.Errorf("%w: %s: %v", ErrPartialResource, semconv.OSDescriptionKey, err)
+	}
+	if description != "" {
+		attrs = append(attrs, semconv.OSDescriptionKey.String(description))
+	}
+	return NewWithSchemaURL(semconv.SchemaURL, attrs...), nil
+}
+
+// osType returns the os.type attribute of the operating system the
+// program was compiled for.
+func osType() attribute.KeyValue {
+	if runtime.GOOS == "dragonfly" {
+		return semconv.OSTypeDragonflyBSD
+	}
+	return semconv.OSTypeKey.String(runtime.GOOS)
+}
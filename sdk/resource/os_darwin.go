@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+)
+
+const systemVersionPath = "/System/Library/CoreServices/SystemVersion.plist"
+
+func osDescription() (string, error) {
+	f, err := os.Open(systemVersionPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	values, err := parsePlistStrings(f)
+	if err != nil {
+		return "", err
+	}
+	description := strings.TrimSpace(values["ProductName"] + " " + values["ProductVersion"])
+	if build := values["ProductBuildVersion"]; build != "" {
+		description += " (" + build + ")"
+	}
+	return description, nil
+}
+
+// parsePlistStrings returns the string values of the keys of the
+// property list read from r.
+func parsePlistStrings(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	decoder := xml.NewDecoder(r)
+	var key, element string
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			element = t.Name.Local
+		case xml.EndElement:
+			element = ""
+		case xml.CharData:
+			switch element {
+			case "key":
+				key = string(t)
+			case "string":
+				values[key] = string(t)
+			}
+		}
+	}
+}
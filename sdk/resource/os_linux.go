@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+func osDescription() (string, error) {
+	return osRelease()
+}
+
+// osRelease returns the description of the Linux distribution in the
+// first of the os-release files found.
+func osRelease() (string, error) {
+	f, err := os.Open("/etc/os-release")
+	if err != nil {
+		if f, err = os.Open("/usr/lib/os-release"); err != nil {
+			return "", err
+		}
+	}
+	defer f.Close()
+	return osReleaseDescription(parseOSRelease(f)), nil
+}
+
+// parseOSRelease returns the variables of the os-release file read from
+// r, see os-release(5).
+func parseOSRelease(r io.Reader) map[string]string {
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.Index(line, "=")
+		if idx <= 0 {
+			continue
+		}
+		key, value := line[:idx], line[idx+1:]
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		} else {
+			value = strings.Trim(value, `'"`)
+		}
+		values[key] = value
+	}
+	return values
+}
+
+// osReleaseDescription returns the pretty name of the distribution
+// described by the os-release values, or its name and version.
+func osReleaseDescription(values map[string]string) string {
+	if name, ok := values["PRETTY_NAME"]; ok && name != "" {
+		return name
+	}
+	name := values["NAME"]
+	if name == "" {
+		name = "Linux"
+	}
+	if version := values["VERSION"]; version != "" {
+		return name + " " + version
+	}
+	return name
+}
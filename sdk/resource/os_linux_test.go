@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOSRelease(t *testing.T) {
+	values := parseOSRelease(strings.NewReader(`# Comment
+NAME="Ubuntu"
+VERSION="20.04.2 LTS (Focal Fossa)"
+ID=ubuntu
+
+PRETTY_NAME="Ubuntu 20.04.2 LTS"
+HOME_URL='https://www.ubuntu.com/'
+invalid
+`))
+	assert.Equal(t, map[string]string{
+		"NAME":        "Ubuntu",
+		"VERSION":     "20.04.2 LTS (Focal Fossa)",
+		"ID":          "ubuntu",
+		"PRETTY_NAME": "Ubuntu 20.04.2 LTS",
+		"HOME_URL":    "https://www.ubuntu.com/",
+	}, values)
+}
+
+func TestOSReleaseDescription(t *testing.T) {
+	for _, tc := range []struct {
+		values   map[string]string
+		expected string
+	}{
+		{map[string]string{"PRETTY_NAME": "Debian GNU/Linux 10 (buster)", "NAME": "Debian GNU/Linux"}, "Debian GNU/Linux 10 (buster)"},
+		{map[string]string{"NAME": "Fedora", "VERSION": "33 (Container Image)"}, "Fedora 33 (Container Image)"},
+		{map[string]string{"NAME": "Alpine Linux"}, "Alpine Linux"},
+		{map[string]string{}, "Linux"},
+	} {
+		assert.Equal(t, tc.expected, osReleaseDescription(tc.values))
+	}
+}
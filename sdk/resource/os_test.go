@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+func TestOSDetector(t *testing.T) {
+	res, err := resource.OS{}.Detect(context.Background())
+	require.NoError(t, err)
+
+	osType, ok := res.Set().Value(semconv.OSTypeKey)
+	require.True(t, ok)
+	if runtime.GOOS != "dragonfly" {
+		assert.Equal(t, runtime.GOOS, osType.AsString())
+	}
+	if runtime.GOOS == "linux" || runtime.GOOS == "darwin" {
+		description, ok := res.Set().Value(semconv.OSDescriptionKey)
+		require.True(t, ok)
+		assert.NotEmpty(t, description.AsString())
+	}
+}
+
+func TestWithOS(t *testing.T) {
+	res, err := resource.New(context.Background(),
+		resource.WithOS(),
+	)
+	require.NoError(t, err)
+	assert.True(t, res.Set().HasValue(semconv.OSTypeKey))
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource // import "go.opentelemetry.io/otel/sdk/resource"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// Process is a Detector that provides information about the process
+// being run: its identifier, executable, command arguments and owner,
+// and the Go runtime it runs on. It is added to a Resource created with
+// New by the WithProcess option.
+type Process struct{}
+
+var _ Detector = Process{}
+
+// Detect returns a *Resource that describes the process being run. The
+// attributes that cannot be detected are omitted and an error wrapping
+// ErrPartialResource is returned along with the others.
+func (Process) Detect(context.Context) (*Resource, error) {
+	attrs := []attribute.KeyValue{
+		semconv.ProcessPIDKey.Int(os.Getpid()),
+		semconv.ProcessCommandArgsKey.StringSlice(os.Args),
+		semconv.ProcessRuntimeNameKey.String(runtime.Compiler),
+		semconv.ProcessRuntimeVersionKey.String(runtime.Version()),
+		semconv.ProcessRuntimeDescriptionKey.String(runtimeDescription()),
+	}
+
+	var errs []string
+	if executable, err := os.Executable(); err != nil {
+		errs = append(errs, fmt.Sprintf("%s: %v", semconv.ProcessExecutablePathKey, err))
+	} else {
+		attrs = append(attrs,
+			semconv.ProcessExecutableNameKey.String(filepath.Base(executable)),
+			semconv.ProcessExecutablePathKey.String(executable),
+		)
+	}
+	if owner, err := user.Current(); err != nil {
+		errs = append(errs, fmt.Sprintf("%s: %v", semconv.ProcessOwnerKey, err))
+	} else {
+		attrs = append(attrs, semconv.ProcessOwnerKey.String(owner.Username))
+	}
+
+	res := NewWithSchemaURL(semconv.SchemaURL, attrs...)
+	if len(errs) > 0 {
+		return res, fmt.Errorf("%w: %s", ErrPartialResource, errs)
+	}
+	return res, nil
+}
+
+// runtimeDescription returns a description of the Go runtime in the
+// format of the `go version` command.
+func runtimeDescription() string {
+	return fmt.Sprintf("go version %s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+func TestProcessDetector(t *testing.T) {
+	executable, err := os.Executable()
+	require.NoError(t, err)
+	owner, err := user.Current()
+	require.NoError(t, err)
+
+	res, err := resource.Process{}.Detect(context.Background())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(
+		semconv.ProcessPIDKey.Int(os.Getpid()),
+		semconv.ProcessExecutableNameKey.String(filepath.Base(executable)),
+		semconv.ProcessExecutablePathKey.String(executable),
+		semconv.ProcessCommandArgsKey.StringSlice(os.Args),
+		semconv.ProcessOwnerKey.String(owner.Username),
+		semconv.ProcessRuntimeNameKey.String(runtime.Compiler),
+		semconv.ProcessRuntimeVersionKey.String(runtime.Version()),
+		semconv.ProcessRuntimeDescriptionKey.String(
+			fmt.Sprintf("go version %s %s/%s", runtime.Version(), runtime.GOOS, runtime.GOARCH),
+		),
+	)
+	assert.Equal(t, expected.Equivalent(), res.Equivalent())
+}
+
+func TestWithProcess(t *testing.T) {
+	res, err := resource.New(context.Background(),
+		resource.WithProcess(),
+	)
+	require.NoError(t, err)
+
+	keys := map[attribute.Key]bool{}
+	for _, kv := range res.Attributes() {
+		keys[kv.Key] = true
+	}
+	assert.True(t, keys[semconv.ProcessPIDKey])
+	assert.True(t, keys[semconv.ProcessRuntimeNameKey])
+}
@@ -16,6 +16,10 @@ package resource // import "go.opentelemetry.io/otel/sdk/resource"
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -28,27 +32,59 @@ import (
 // Resources should be passed and stored as pointers
 // (`*resource.Resource`).  The `nil` value is equivalent to an empty
 // Resource.
+//
+// A Resource may identify the schema of its attributes by a schema URL,
+// e.g. semconv.SchemaURL for the semantic conventions of the semconv
+// package.
 type Resource struct {
-	attrs attribute.Set
+	attrs     attribute.Set
+	schemaURL string
+	key       Key
+}
+
+// Key is a comparable identity of a Resource, computed once when the
+// Resource is created.  Resources have the same Key if and only if they
+// have the same attributes and schema URL, it is a cheap key in a map of
+// resources, e.g. to group telemetry by Resource.
+type Key struct {
+	encoded   string
+	schemaURL string
 }
 
 var (
 	emptyResource Resource
 
+	// ErrSchemaURLConflict is returned by Merge when the resources
+	// have different, non-empty, schema URLs.
+	ErrSchemaURLConflict = errors.New("cannot merge resources with different schema URLs")
+
 	defaultResource *Resource = func(r *Resource, err error) *Resource {
 		if err != nil {
-			otel.Handle(err)
+			otel.HandleSignals(err, otel.TracesSignal, otel.MetricsSignal)
 		}
 		return r
-	}(Detect(context.Background(), defaultServiceNameDetector{}, TelemetrySDK{}))
+	}(Detect(context.Background(), defaultServiceNameDetector{}, FromEnv{}, TelemetrySDK{}))
 )
 
 // NewWithAttributes creates a resource from attrs. If attrs contains
 // duplicate keys, the last value will be used. If attrs contains any invalid
-// items those items will be dropped.
+// items those items will be dropped. The resource has no schema URL.
 func NewWithAttributes(attrs ...attribute.KeyValue) *Resource {
+	return newResource("", attrs)
+}
+
+// NewWithSchemaURL creates a resource from attrs, like NewWithAttributes,
+// identifying the schema of attrs by schemaURL.
+func NewWithSchemaURL(schemaURL string, attrs ...attribute.KeyValue) *Resource {
+	return newResource(schemaURL, attrs)
+}
+
+func newResource(schemaURL string, attrs []attribute.KeyValue) *Resource {
 	if len(attrs) == 0 {
-		return &emptyResource
+		if schemaURL == "" {
+			return &emptyResource
+		}
+		return &Resource{schemaURL: schemaURL, key: Key{schemaURL: schemaURL}}
 	}
 
 	// Ensure attributes comply with the specification:
@@ -58,11 +94,38 @@ func NewWithAttributes(attrs ...attribute.KeyValue) *Resource {
 	})
 
 	// If attrs only contains invalid entries do not allocate a new resource.
-	if s.Len() == 0 {
+	if s.Len() == 0 && schemaURL == "" {
 		return &emptyResource
 	}
 
-	return &Resource{s} //nolint
+	return &Resource{attrs: s, schemaURL: schemaURL, key: Key{encoded: keyEncoding(&s), schemaURL: schemaURL}} //nolint
+}
+
+// keyEncoding returns an unambiguous encoding of the attributes of s: the
+// keys and the values are quoted, slice values are encoded in JSON, and
+// the type of each value is included.
+func keyEncoding(s *attribute.Set) string {
+	var b strings.Builder
+	iter := s.Iter()
+	for iter.Next() {
+		kv := iter.Label()
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.Quote(string(kv.Key)))
+		b.WriteByte(':')
+		b.WriteString(kv.Value.Type().String())
+		b.WriteByte(':')
+		switch kv.Value.Type() {
+		case attribute.BOOLSLICE, attribute.INT64SLICE, attribute.FLOAT64SLICE, attribute.STRINGSLICE:
+			// Slices of these types always encode.
+			encoded, _ := json.Marshal(kv.Value.AsInterface())
+			b.Write(encoded)
+		default:
+			b.WriteString(strconv.Quote(kv.Value.Emit()))
+		}
+	}
+	return b.String()
 }
 
 // String implements the Stringer interface and provides a
@@ -95,7 +158,17 @@ func (r *Resource) Iter() attribute.Iterator {
 	return r.attrs.Iter()
 }
 
+// SchemaURL returns the schema URL of the Resource attributes, it is empty
+// if the Resource has no schema URL.
+func (r *Resource) SchemaURL() string {
+	if r == nil {
+		return ""
+	}
+	return r.schemaURL
+}
+
 // Equal returns true when a Resource is equivalent to this Resource.
+// The schema URLs of the resources are not compared.
 func (r *Resource) Equal(eq *Resource) bool {
 	if r == nil {
 		r = Empty()
@@ -103,7 +176,7 @@ func (r *Resource) Equal(eq *Resource) bool {
 	if eq == nil {
 		eq = Empty()
 	}
-	return r.Equivalent() == eq.Equivalent()
+	return r.Set().Equals(eq.Set())
 }
 
 // Merge creates a new resource by combining resource a and b.
@@ -111,15 +184,31 @@ func (r *Resource) Equal(eq *Resource) bool {
 // If there are common keys between resource a and b, then the value
 // from resource b will overwrite the value from resource a, even
 // if resource b's value is empty.
-func Merge(a, b *Resource) *Resource {
+//
+// The merged resource has the schema URL of a and b if only one of them
+// has a schema URL or if they have the same one. If a and b have
+// different schema URLs, the merged resource has no schema URL and
+// ErrSchemaURLConflict is returned along with it.
+func Merge(a, b *Resource) (*Resource, error) {
 	if a == nil && b == nil {
-		return Empty()
+		return Empty(), nil
 	}
 	if a == nil {
-		return b
+		return b, nil
 	}
 	if b == nil {
-		return a
+		return a, nil
+	}
+
+	var schemaURL string
+	var err error
+	switch {
+	case a.schemaURL == "":
+		schemaURL = b.schemaURL
+	case b.schemaURL == "", a.schemaURL == b.schemaURL:
+		schemaURL = a.schemaURL
+	default:
+		err = ErrSchemaURLConflict
 	}
 
 	// Note: 'b' attributes will overwrite 'a' with last-value-wins in attribute.Key()
@@ -129,7 +218,7 @@ func Merge(a, b *Resource) *Resource {
 	for mi.Next() {
 		combine = append(combine, mi.Label())
 	}
-	return NewWithAttributes(combine...)
+	return newResource(schemaURL, combine), err
 }
 
 // Empty returns an instance of Resource with no attributes.  It is
@@ -139,11 +228,23 @@ func Empty() *Resource {
 }
 
 // Default returns an instance of Resource with a default
-// "service.name" and OpenTelemetrySDK attributes
+// "service.name", the attributes of the environment, see FromEnv, and
+// OpenTelemetrySDK attributes.  The environment is read once, when the
+// package is initialized.
 func Default() *Resource {
 	return defaultResource
 }
 
+// Key returns the comparable identity of the Resource, see Key.  It is
+// cheaper than Equivalent as a key in a map, and it also distinguishes
+// resources with different schema URLs.
+func (r *Resource) Key() Key {
+	if r == nil {
+		return Key{}
+	}
+	return r.key
+}
+
 // Equivalent returns an object that can be compared for equality
 // between two resources.  This value is suitable for use as a key in
 // a map.
@@ -26,7 +26,7 @@ import (
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/resource"
-	"go.opentelemetry.io/otel/semconv"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
 var (
@@ -154,7 +154,8 @@ func TestMerge(t *testing.T) {
 	}
 	for _, c := range cases {
 		t.Run(fmt.Sprintf("case-%s", c.name), func(t *testing.T) {
-			res := resource.Merge(c.a, c.b)
+			res, err := resource.Merge(c.a, c.b)
+			require.NoError(t, err)
 			if diff := cmp.Diff(
 				res.Attributes(),
 				c.want,
@@ -165,6 +166,97 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestMergeSchemaURL(t *testing.T) {
+	const (
+		schema1 = "https://opentelemetry.io/schemas/1.3.0"
+		schema2 = "https://opentelemetry.io/schemas/1.4.0"
+	)
+	cases := []struct {
+		name      string
+		a, b      *resource.Resource
+		schemaURL string
+		err       error
+	}{
+		{
+			name: "no schema URL",
+			a:    resource.NewWithAttributes(kv11),
+			b:    resource.NewWithAttributes(kv21),
+		},
+		{
+			name:      "first schema URL",
+			a:         resource.NewWithSchemaURL(schema1, kv11),
+			b:         resource.NewWithAttributes(kv21),
+			schemaURL: schema1,
+		},
+		{
+			name:      "second schema URL",
+			a:         resource.NewWithAttributes(kv11),
+			b:         resource.NewWithSchemaURL(schema2, kv21),
+			schemaURL: schema2,
+		},
+		{
+			name:      "same schema URL",
+			a:         resource.NewWithSchemaURL(schema1, kv11),
+			b:         resource.NewWithSchemaURL(schema1, kv21),
+			schemaURL: schema1,
+		},
+		{
+			name: "conflicting schema URLs",
+			a:    resource.NewWithSchemaURL(schema1, kv11),
+			b:    resource.NewWithSchemaURL(schema2, kv21),
+			err:  resource.ErrSchemaURLConflict,
+		},
+		{
+			name:      "empty resource with schema URL",
+			a:         resource.NewWithSchemaURL(schema1),
+			b:         resource.NewWithAttributes(kv11, kv21),
+			schemaURL: schema1,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res, err := resource.Merge(c.a, c.b)
+			require.Equal(t, c.err, err)
+			require.Equal(t, c.schemaURL, res.SchemaURL())
+			require.Equal(t, []attribute.KeyValue{kv11, kv21}, res.Attributes())
+		})
+	}
+}
+
+func TestNewWithSchemaURL(t *testing.T) {
+	res := resource.NewWithSchemaURL(semconv.SchemaURL, kv11)
+	require.Equal(t, semconv.SchemaURL, res.SchemaURL())
+	require.True(t, res.Equal(resource.NewWithAttributes(kv11)))
+
+	require.Equal(t, "", resource.NewWithAttributes(kv11).SchemaURL())
+	require.Equal(t, "", (*resource.Resource)(nil).SchemaURL())
+	require.Equal(t, semconv.SchemaURL, resource.Default().SchemaURL())
+}
+
+func TestKey(t *testing.T) {
+	require.Equal(t, resource.NewWithAttributes(kv11, kv21).Key(), resource.NewWithAttributes(kv21, kv11).Key())
+	require.NotEqual(t, resource.NewWithAttributes(kv11).Key(), resource.NewWithAttributes(kv12).Key())
+	require.NotEqual(t, resource.NewWithAttributes(kv11).Key(), resource.NewWithSchemaURL(semconv.SchemaURL, kv11).Key())
+	require.NotEqual(t,
+		resource.NewWithAttributes(attribute.String("k1", "[1,2]")).Key(),
+		resource.NewWithAttributes(attribute.IntSlice("k1", []int{1, 2})).Key(),
+	)
+	require.NotEqual(t,
+		resource.NewWithAttributes(attribute.String("k1", "1")).Key(),
+		resource.NewWithAttributes(attribute.Int("k1", 1)).Key(),
+	)
+	require.NotEqual(t,
+		resource.NewWithAttributes(attribute.String("k1", `a","k2":"b`)).Key(),
+		resource.NewWithAttributes(attribute.String("k1", "a"), attribute.String("k2", "b")).Key(),
+	)
+	require.Equal(t, resource.Empty().Key(), (*resource.Resource)(nil).Key())
+	require.Equal(t, resource.Empty().Key(), resource.NewWithAttributes().Key())
+
+	merged, err := resource.Merge(resource.NewWithAttributes(kv11), resource.NewWithAttributes(kv21))
+	require.NoError(t, err)
+	require.Equal(t, resource.NewWithAttributes(kv11, kv21).Key(), merged.Key())
+}
+
 func TestDefault(t *testing.T) {
 	res := resource.Default()
 	require.False(t, res.Equal(resource.Empty()))
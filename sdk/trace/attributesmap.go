@@ -15,7 +15,7 @@
 package trace // import "go.opentelemetry.io/otel/sdk/trace"
 
 import (
-	"container/list"
+	"sync"
 
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -24,68 +24,114 @@ import (
 // Eviction is done via a LRU method, the oldest entry is removed to create room for a new entry.
 // Updates are allowed and they refresh the usage of the key.
 //
-// This is based from https://github.com/hashicorp/golang-lru/blob/master/simplelru/lru.go
-// With a subset of the its operations and specific for holding attribute.KeyValue
+// The attributes are stored in a slice, from the least to the most recently
+// used, indexed by a map. Both are taken from attributesPool when the first
+// attribute is added and returned to it by release once the span has ended,
+// so that spans do not allocate them. The zero value is ready to use.
 type attributesMap struct {
-	attributes   map[attribute.Key]*list.Element
-	evictList    *list.List
+	attributes   map[attribute.Key]int
+	kvs          []attribute.KeyValue
+	buf          *attributesBuffer
 	droppedCount int
 	capacity     int
 }
 
-func newAttributesMap(capacity int) *attributesMap {
-	lm := &attributesMap{
-		attributes: make(map[attribute.Key]*list.Element),
-		evictList:  list.New(),
-		capacity:   capacity,
-	}
-	return lm
+// attributesBuffer holds the storage of an attributesMap while it is pooled.
+type attributesBuffer struct {
+	attributes map[attribute.Key]int
+	kvs        []attribute.KeyValue
+}
+
+var attributesPool = sync.Pool{
+	New: func() interface{} {
+		return &attributesBuffer{attributes: make(map[attribute.Key]int)}
+	},
+}
+
+func newAttributesMap(capacity int) attributesMap {
+	return attributesMap{capacity: capacity}
+}
+
+func (am *attributesMap) len() int {
+	return len(am.kvs)
 }
 
 func (am *attributesMap) add(kv attribute.KeyValue) {
+	if am.attributes == nil {
+		am.buf = attributesPool.Get().(*attributesBuffer)
+		am.attributes = am.buf.attributes
+		am.kvs = append(am.buf.kvs[:0], am.kvs...)
+		for i, kv := range am.kvs {
+			am.attributes[kv.Key] = i
+		}
+	}
+
 	// Check for existing item
-	if ent, ok := am.attributes[kv.Key]; ok {
-		am.evictList.MoveToFront(ent)
-		ent.Value = &kv
+	if i, ok := am.attributes[kv.Key]; ok {
+		am.remove(i)
+		am.attributes[kv.Key] = len(am.kvs)
+		am.kvs = append(am.kvs, kv)
 		return
 	}
 
 	// Add new item
-	entry := am.evictList.PushFront(&kv)
-	am.attributes[kv.Key] = entry
+	am.attributes[kv.Key] = len(am.kvs)
+	am.kvs = append(am.kvs, kv)
 
 	// Verify size not exceeded
-	if am.evictList.Len() > am.capacity {
+	if len(am.kvs) > am.capacity {
 		am.removeOldest()
 		am.droppedCount++
 	}
 }
 
+// remove removes the attribute at index i of kvs, its key stays indexed.
+func (am *attributesMap) remove(i int) {
+	copy(am.kvs[i:], am.kvs[i+1:])
+	am.kvs[len(am.kvs)-1] = attribute.KeyValue{}
+	am.kvs = am.kvs[:len(am.kvs)-1]
+	for ; i < len(am.kvs); i++ {
+		am.attributes[am.kvs[i].Key] = i
+	}
+}
+
 // toKeyValue copies the attributesMap into a slice of attribute.KeyValue and
 // returns it. If the map is empty, a nil is returned.
-// TODO: Is it more efficient to return a pointer to the slice?
 func (am *attributesMap) toKeyValue() []attribute.KeyValue {
-	len := am.evictList.Len()
-	if len == 0 {
+	if len(am.kvs) == 0 {
 		return nil
 	}
 
-	attributes := make([]attribute.KeyValue, 0, len)
-	for ent := am.evictList.Back(); ent != nil; ent = ent.Prev() {
-		if value, ok := ent.Value.(*attribute.KeyValue); ok {
-			attributes = append(attributes, *value)
-		}
-	}
-
+	attributes := make([]attribute.KeyValue, len(am.kvs))
+	copy(attributes, am.kvs)
 	return attributes
 }
 
 // removeOldest removes the oldest item from the cache.
 func (am *attributesMap) removeOldest() {
-	ent := am.evictList.Back()
-	if ent != nil {
-		am.evictList.Remove(ent)
-		kv := ent.Value.(*attribute.KeyValue)
-		delete(am.attributes, kv.Key)
+	if len(am.kvs) == 0 {
+		return
+	}
+	delete(am.attributes, am.kvs[0].Key)
+	am.remove(0)
+}
+
+// release returns the storage of the attributes to attributesPool. The
+// attributes are replaced by kvs, a copy of them that is not modified
+// anymore, and are copied again if more attributes are added.
+func (am *attributesMap) release(kvs []attribute.KeyValue) {
+	if am.buf == nil {
+		return
+	}
+	for k := range am.attributes {
+		delete(am.attributes, k)
+	}
+	for i := range am.kvs {
+		am.kvs[i] = attribute.KeyValue{}
 	}
+	am.buf.kvs = am.kvs[:0]
+	attributesPool.Put(am.buf)
+	am.buf = nil
+	am.attributes = nil
+	am.kvs = kvs[:len(kvs):len(kvs)]
 }
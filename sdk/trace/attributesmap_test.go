@@ -16,6 +16,7 @@ package trace
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -90,6 +91,49 @@ func TestAttributesMapToKeyValue(t *testing.T) {
 	}
 }
 
+func TestAttributesMapUpdate(t *testing.T) {
+	attrMap := newAttributesMap(2)
+	attrMap.add(attribute.Int("a", 1))
+	attrMap.add(attribute.Int("b", 2))
+	attrMap.add(attribute.Int("a", 3))
+	// The update made "b" the oldest attribute.
+	attrMap.add(attribute.Int("c", 4))
+
+	want := []attribute.KeyValue{attribute.Int("a", 3), attribute.Int("c", 4)}
+	if got := attrMap.toKeyValue(); !reflect.DeepEqual(got, want) {
+		t.Errorf("attrMap.toKeyValue(): got %v; want %v", got, want)
+	}
+	if attrMap.droppedCount != 1 {
+		t.Errorf("attrMap.droppedCount: got '%d'; want '%d'", attrMap.droppedCount, 1)
+	}
+}
+
+func TestAttributesMapRelease(t *testing.T) {
+	attrMap := newAttributesMap(128)
+	attrMap.add(attribute.Int("a", 1))
+	attrMap.add(attribute.Int("b", 2))
+
+	frozen := attrMap.toKeyValue()
+	attrMap.release(frozen)
+	if attrMap.buf != nil || attrMap.attributes != nil {
+		t.Fatal("attrMap storage not released")
+	}
+	if got := attrMap.toKeyValue(); !reflect.DeepEqual(got, frozen) {
+		t.Errorf("attrMap.toKeyValue(): got %v; want %v", got, frozen)
+	}
+
+	// Adding attributes after release does not modify the released copy.
+	attrMap.add(attribute.Int("a", 3))
+	want := []attribute.KeyValue{attribute.Int("a", 1), attribute.Int("b", 2)}
+	if !reflect.DeepEqual(frozen, want) {
+		t.Errorf("released attributes: got %v; want %v", frozen, want)
+	}
+	want = []attribute.KeyValue{attribute.Int("b", 2), attribute.Int("a", 3)}
+	if got := attrMap.toKeyValue(); !reflect.DeepEqual(got, want) {
+		t.Errorf("attrMap.toKeyValue(): got %v; want %v", got, want)
+	}
+}
+
 func BenchmarkAttributesMapToKeyValue(b *testing.B) {
 	attrMap := newAttributesMap(128)
 
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// baggageSpanProcessor is a SpanProcessor that copies baggage entries of the
+// parent context onto started spans.
+type baggageSpanProcessor struct {
+	filter attribute.Filter
+}
+
+var _ SpanProcessor = (*baggageSpanProcessor)(nil)
+
+// NewBaggageSpanProcessor returns a SpanProcessor that, when a span is
+// started, sets the baggage entries of the context it is started with,
+// e.g. tenant or request IDs propagated by upstream services, as attributes
+// of the span. Only the entries filter returns true for are set, all entries
+// are set if filter is nil. Use AllowBaggageKeys to set the entries with
+// specific keys.
+//
+// Baggage entries that may not be propagated, set with
+// baggage.ContextWithLocalValues or with no hop left, are never set as they
+// are not meant to leave the process.
+//
+// The attributes are set after the sampling decision is made and are only
+// seen by the SpanProcessors registered after this one when a span starts.
+// Use a ContextEnricher for attributes the Sampler needs to consider.
+func NewBaggageSpanProcessor(filter attribute.Filter) SpanProcessor {
+	return &baggageSpanProcessor{filter: filter}
+}
+
+// AllowBaggageKeys returns an attribute.Filter for NewBaggageSpanProcessor
+// that only keeps the baggage entries with one of keys.
+func AllowBaggageKeys(keys ...attribute.Key) attribute.Filter {
+	return attribute.NewAllowKeysFilter(keys...)
+}
+
+// OnStart sets the selected baggage entries of parent as attributes of s.
+func (p *baggageSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	set := baggage.Set(parent)
+	if set.Len() == 0 {
+		return
+	}
+	attrs := make([]attribute.KeyValue, 0, set.Len())
+	for iter := set.Iter(); iter.Next(); {
+		kv := iter.Attribute()
+		if hops, limited := baggage.HopLimit(parent, kv.Key); limited && hops <= 0 {
+			continue
+		}
+		if p.filter == nil || p.filter(kv) {
+			attrs = append(attrs, kv)
+		}
+	}
+	s.SetAttributes(attrs...)
+}
+
+// OnEnding does nothing.
+func (p *baggageSpanProcessor) OnEnding(ReadWriteSpan) {}
+
+// OnEnd does nothing.
+func (p *baggageSpanProcessor) OnEnd(ReadOnlySpan) {}
+
+// Shutdown does nothing.
+func (p *baggageSpanProcessor) Shutdown(context.Context) error { return nil }
+
+// ForceFlush does nothing.
+func (p *baggageSpanProcessor) ForceFlush(context.Context) error { return nil }
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestBaggageSpanProcessor(t *testing.T) {
+	ctx := baggage.ContextWithValues(context.Background(),
+		attribute.String("tenant.id", "t1"),
+		attribute.String("request.id", "r1"),
+		attribute.String("other", "o"),
+	)
+	ctx = baggage.ContextWithLocalValues(ctx, attribute.String("secret", "s"))
+
+	tests := []struct {
+		name   string
+		filter attribute.Filter
+		want   []attribute.KeyValue
+	}{
+		{
+			name: "all",
+			want: []attribute.KeyValue{
+				attribute.String("other", "o"),
+				attribute.String("request.id", "r1"),
+				attribute.String("tenant.id", "t1"),
+			},
+		},
+		{
+			name:   "allowed keys",
+			filter: sdktrace.AllowBaggageKeys("tenant.id", "request.id", "secret"),
+			want: []attribute.KeyValue{
+				attribute.String("request.id", "r1"),
+				attribute.String("tenant.id", "t1"),
+			},
+		},
+		{
+			name: "predicate",
+			filter: func(kv attribute.KeyValue) bool {
+				return kv.Key == "other"
+			},
+			want: []attribute.KeyValue{attribute.String("other", "o")},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			exp := tracetest.NewInMemoryExporter()
+			tp := sdktrace.NewTracerProvider(
+				sdktrace.WithSpanProcessor(sdktrace.NewBaggageSpanProcessor(tc.filter)),
+				sdktrace.WithSyncer(exp),
+			)
+			_, span := tp.Tracer("TestBaggageSpanProcessor").Start(ctx, "span")
+			span.End()
+
+			spans := exp.GetSpans()
+			require.Len(t, spans, 1)
+			assert.ElementsMatch(t, tc.want, spans[0].Attributes)
+		})
+	}
+}
+
+func TestBaggageSpanProcessorWithoutBaggage(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(sdktrace.NewBaggageSpanProcessor(nil)),
+		sdktrace.WithSyncer(exp),
+	)
+	_, span := tp.Tracer("TestBaggageSpanProcessorWithoutBaggage").Start(context.Background(), "span")
+	span.End()
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Empty(t, spans[0].Attributes)
+}
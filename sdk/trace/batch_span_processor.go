@@ -16,19 +16,20 @@ package trace // import "go.opentelemetry.io/otel/sdk/trace"
 
 import (
 	"context"
-	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel"
-	export "go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/internal/global"
+	"go.opentelemetry.io/otel/sdk/internal/env"
 )
 
 const (
 	DefaultMaxQueueSize       = 2048
 	DefaultBatchTimeout       = 5000 * time.Millisecond
 	DefaultMaxExportBatchSize = 512
+	DefaultExportTimeout      = 30000 * time.Millisecond
 )
 
 type BatchSpanProcessorOption func(o *BatchSpanProcessorOptions)
@@ -36,37 +37,108 @@ type BatchSpanProcessorOption func(o *BatchSpanProcessorOptions)
 type BatchSpanProcessorOptions struct {
 	// MaxQueueSize is the maximum queue size to buffer spans for delayed processing. If the
 	// queue gets full it drops the spans. Use BlockOnQueueFull to change this behavior.
-	// The default value of MaxQueueSize is 2048.
+	// The default value of MaxQueueSize is 2048, or the value of the
+	// EnvBatchSpanProcessorMaxQueueSize environment variable.
 	MaxQueueSize int
 
 	// BatchTimeout is the maximum duration for constructing a batch. Processor
 	// forcefully sends available spans when timeout is reached.
-	// The default value of BatchTimeout is 5000 msec.
+	// The default value of BatchTimeout is 5000 msec, or the value of the
+	// EnvBatchSpanProcessorScheduleDelay environment variable.
 	BatchTimeout time.Duration
 
 	// MaxExportBatchSize is the maximum number of spans to process in a single batch.
 	// If there are more than one batch worth of spans then it processes multiple batches
 	// of spans one batch after the other without any delay.
-	// The default value of MaxExportBatchSize is 512.
+	// The default value of MaxExportBatchSize is 512, or the value of the
+	// EnvBatchSpanProcessorMaxExportBatchSize environment variable.
 	MaxExportBatchSize int
 
+	// ExportTimeout specifies the maximum duration for exporting spans. If the timeout
+	// is reached, the export will be cancelled.
+	// The default value of ExportTimeout is 30000 msec, or the value of
+	// the EnvBatchSpanProcessorExportTimeout environment variable.
+	ExportTimeout time.Duration
+
 	// BlockOnQueueFull blocks onEnd() and onStart() method if the queue is full
 	// AND if BlockOnQueueFull is set to true.
 	// Blocking option should be used carefully as it can severely affect the performance of an
 	// application.
 	BlockOnQueueFull bool
+
+	// OnBatchExported, if set, is called with the BatchExportStats of each
+	// batch after it has been passed to the exporter.
+	OnBatchExported func(BatchExportStats)
+
+	// OnSpanDropped, if set, is called with every span that is dropped
+	// because the queue is full.
+	OnSpanDropped func(ReadOnlySpan)
+}
+
+// BatchExportStats describes the export of a single batch of spans by a
+// batch span processor. It can be used to record self-observability
+// metrics and tune the queue size, batch size and timeout of the
+// processor.
+type BatchExportStats struct {
+	// SpanCount is the number of spans in the batch.
+	SpanCount int
+
+	// ExportDuration is the time the exporter took to export the batch.
+	ExportDuration time.Duration
+
+	// MaxLatency is the longest time a span of the batch took from
+	// ending to being exported, including the time spent queued.
+	MaxLatency time.Duration
+
+	// MeanLatency is the mean time the spans of the batch took from
+	// ending to being exported, including the time spent queued.
+	MeanLatency time.Duration
+
+	// Err is the error returned by the exporter, if any.
+	Err error
+
+	// Enqueued is the total number of spans added to the queue of the
+	// processor since it was created.
+	Enqueued uint64
+
+	// Exported is the total number of spans successfully exported by the
+	// processor since it was created, including this batch.
+	Exported uint64
+
+	// Dropped is the total number of spans dropped by the processor
+	// since it was created because its queue was full.
+	Dropped uint64
+
+	// Batch is the metadata of the batch, also passed to the SpanExporter
+	// in the export context.
+	Batch ExportBatchInfo
 }
 
 // batchSpanProcessor is a SpanProcessor that batches asynchronously-received
-// SpanSnapshots and sends them to a trace.Exporter when complete.
+// ReadOnlySpans and sends them to a SpanExporter when complete.
 type batchSpanProcessor struct {
-	e export.SpanExporter
+	// Ensure counters are 64-bit aligned for atomic operations on both 32
+	// and 64 bit machines.
+	enqueued uint64
+	exported uint64
+	dropped  uint64
+
+	e SpanExporter
 	o BatchSpanProcessorOptions
 
-	queue   chan *export.SpanSnapshot
-	dropped uint32
+	dryRunValue
+	selfMetricsValue
+
+	// queue holds the ended spans until they are moved to the batch.
+	queue *spanQueue
+	// notify signals the processing goroutine that a batch of spans is
+	// queued, or that the queue is full.
+	notify chan struct{}
+	// space signals blocked producers that spans were dequeued.
+	space chan struct{}
 
-	batch      []*export.SpanSnapshot
+	batch      []ReadOnlySpan
+	batchID    uint64
 	batchMutex sync.Mutex
 	timer      *time.Timer
 	stopWait   sync.WaitGroup
@@ -80,21 +152,27 @@ var _ SpanProcessor = (*batchSpanProcessor)(nil)
 // span batches to the exporter with the supplied options.
 //
 // If the exporter is nil, the span processor will preform no action.
-func NewBatchSpanProcessor(exporter export.SpanExporter, options ...BatchSpanProcessorOption) SpanProcessor {
+func NewBatchSpanProcessor(exporter SpanExporter, options ...BatchSpanProcessorOption) SpanProcessor {
 	o := BatchSpanProcessorOptions{
-		BatchTimeout:       DefaultBatchTimeout,
-		MaxQueueSize:       DefaultMaxQueueSize,
-		MaxExportBatchSize: DefaultMaxExportBatchSize,
+		BatchTimeout:       env.Duration(otel.TracesSignal, EnvBatchSpanProcessorScheduleDelay, DefaultBatchTimeout),
+		MaxQueueSize:       env.Int(otel.TracesSignal, EnvBatchSpanProcessorMaxQueueSize, DefaultMaxQueueSize),
+		MaxExportBatchSize: env.Int(otel.TracesSignal, EnvBatchSpanProcessorMaxExportBatchSize, DefaultMaxExportBatchSize),
+		ExportTimeout:      env.Duration(otel.TracesSignal, EnvBatchSpanProcessorExportTimeout, DefaultExportTimeout),
 	}
 	for _, opt := range options {
 		opt(&o)
 	}
+	if o.MaxExportBatchSize <= 0 {
+		o.MaxExportBatchSize = DefaultMaxExportBatchSize
+	}
 	bsp := &batchSpanProcessor{
 		e:      exporter,
 		o:      o,
-		batch:  make([]*export.SpanSnapshot, 0, o.MaxExportBatchSize),
+		batch:  make([]ReadOnlySpan, 0, o.MaxExportBatchSize),
 		timer:  time.NewTimer(o.BatchTimeout),
-		queue:  make(chan *export.SpanSnapshot, o.MaxQueueSize),
+		queue:  newSpanQueue(o.MaxQueueSize),
+		notify: make(chan struct{}, 1),
+		space:  make(chan struct{}, 1),
 		stopCh: make(chan struct{}),
 	}
 
@@ -111,13 +189,16 @@ func NewBatchSpanProcessor(exporter export.SpanExporter, options ...BatchSpanPro
 // OnStart method does nothing.
 func (bsp *batchSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {}
 
+// OnEnding method does nothing.
+func (bsp *batchSpanProcessor) OnEnding(s ReadWriteSpan) {}
+
 // OnEnd method enqueues a ReadOnlySpan for later processing.
 func (bsp *batchSpanProcessor) OnEnd(s ReadOnlySpan) {
 	// Do not enqueue spans if we are just going to drop them.
 	if bsp.e == nil {
 		return
 	}
-	bsp.enqueue(s.Snapshot())
+	bsp.enqueue(s)
 }
 
 // Shutdown flushes the queue and waits until all spans are processed.
@@ -129,9 +210,10 @@ func (bsp *batchSpanProcessor) Shutdown(ctx context.Context) error {
 		go func() {
 			close(bsp.stopCh)
 			bsp.stopWait.Wait()
+			bsp.selfMetrics().removeQueue(bsp)
 			if bsp.e != nil {
 				if err := bsp.e.Shutdown(ctx); err != nil {
-					otel.Handle(err)
+					otel.HandleSignal(otel.TracesSignal, err)
 				}
 			}
 			close(wait)
@@ -152,8 +234,9 @@ func (bsp *batchSpanProcessor) ForceFlush(ctx context.Context) error {
 	if bsp.e != nil {
 		wait := make(chan struct{})
 		go func() {
-			if err := bsp.exportSpans(ctx); err != nil {
-				otel.Handle(err)
+			bsp.collect(ctx, false)
+			if err := bsp.exportSpans(ctx, FlushReasonForced); err != nil {
+				otel.HandleSignal(otel.TracesSignal, err)
 			}
 			close(wait)
 		}()
@@ -185,21 +268,92 @@ func WithBatchTimeout(delay time.Duration) BatchSpanProcessorOption {
 	}
 }
 
+// WithExportTimeout returns a BatchSpanProcessorOption that configures the
+// maximum duration of each call to the exporter. A timeout of zero or less
+// means exports are not bounded.
+func WithExportTimeout(timeout time.Duration) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.ExportTimeout = timeout
+	}
+}
+
+// WithBlocking returns a BatchSpanProcessorOption that configures OnEnd to
+// block until there is room in the queue instead of dropping the span when
+// the queue is full. A blocked OnEnd returns, dropping the span, once the
+// processor is shut down.
 func WithBlocking() BatchSpanProcessorOption {
 	return func(o *BatchSpanProcessorOptions) {
 		o.BlockOnQueueFull = true
 	}
 }
 
-// exportSpans is a subroutine of processing and draining the queue.
-func (bsp *batchSpanProcessor) exportSpans(ctx context.Context) error {
+// WithDropCallback returns a BatchSpanProcessorOption that configures cb to
+// be called with every span dropped because the queue is full.
+//
+// The callback is called synchronously from OnEnd, it should return quickly.
+func WithDropCallback(cb func(ReadOnlySpan)) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.OnSpanDropped = cb
+	}
+}
+
+// WithBatchExportCallback returns a BatchSpanProcessorOption that configures
+// cb to be called with the BatchExportStats of each exported batch.
+//
+// The callback is called synchronously by the processor, it should return
+// quickly so it does not delay the export of subsequent batches.
+func WithBatchExportCallback(cb func(BatchExportStats)) BatchSpanProcessorOption {
+	return func(o *BatchSpanProcessorOptions) {
+		o.OnBatchExported = cb
+	}
+}
+
+// setSelfMetrics sets the self-observability metrics of bsp and observes
+// the length of its queue with them.
+func (bsp *batchSpanProcessor) setSelfMetrics(sm *selfMetrics) {
+	bsp.selfMetricsValue.setSelfMetrics(sm)
+	sm.addQueue(bsp)
+}
+
+// exportSpans is a subroutine of processing and draining the queue. The
+// batch is exported with an ExportBatchInfo for reason in its context.
+func (bsp *batchSpanProcessor) exportSpans(ctx context.Context, reason FlushReason) error {
 	bsp.timer.Reset(bsp.o.BatchTimeout)
 
 	bsp.batchMutex.Lock()
 	defer bsp.batchMutex.Unlock()
 
 	if len(bsp.batch) > 0 {
-		if err := bsp.e.ExportSpans(ctx, bsp.batch); err != nil {
+		if bsp.o.ExportTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, bsp.o.ExportTimeout)
+			defer cancel()
+		}
+		bsp.batchID++
+		info := ExportBatchInfo{ID: bsp.batchID, Reason: reason}
+		ctx = ContextWithExportBatchInfo(ctx, info)
+
+		global.Debug("exporting spans", "count", len(bsp.batch), "batch_id", info.ID, "reason", reason)
+		start := time.Now()
+		var err error
+		if c := bsp.counter(); c != nil {
+			c.count(bsp.batch)
+		} else {
+			err = bsp.e.ExportSpans(ctx, bsp.batch)
+			bsp.selfMetrics().recordExport(ctx, batchProcessor, len(bsp.batch), time.Since(start), err)
+		}
+		if err == nil {
+			atomic.AddUint64(&bsp.exported, uint64(len(bsp.batch)))
+		}
+		if bsp.o.OnBatchExported != nil {
+			stats := batchExportStats(bsp.batch, start, time.Now(), err)
+			stats.Enqueued = atomic.LoadUint64(&bsp.enqueued)
+			stats.Exported = atomic.LoadUint64(&bsp.exported)
+			stats.Dropped = atomic.LoadUint64(&bsp.dropped)
+			stats.Batch = info
+			bsp.o.OnBatchExported(stats)
+		}
+		if err != nil {
 			return err
 		}
 		bsp.batch = bsp.batch[:0]
@@ -207,7 +361,29 @@ func (bsp *batchSpanProcessor) exportSpans(ctx context.Context) error {
 	return nil
 }
 
-// processQueue removes spans from the `queue` channel until processor
+// batchExportStats returns the BatchExportStats of exporting batch from
+// start until end with the resulting err.
+func batchExportStats(batch []ReadOnlySpan, start, end time.Time, err error) BatchExportStats {
+	stats := BatchExportStats{
+		SpanCount:      len(batch),
+		ExportDuration: end.Sub(start),
+		Err:            err,
+	}
+	var total time.Duration
+	for _, sd := range batch {
+		latency := end.Sub(sd.EndTime())
+		if latency > stats.MaxLatency {
+			stats.MaxLatency = latency
+		}
+		total += latency
+	}
+	if len(batch) > 0 {
+		stats.MeanLatency = total / time.Duration(len(batch))
+	}
+	return stats
+}
+
+// processQueue moves spans from the queue to the batch until the processor
 // is shut down. It calls the exporter in batches of up to MaxExportBatchSize
 // waiting up to BatchTimeout to form a batch.
 func (bsp *batchSpanProcessor) processQueue() {
@@ -220,91 +396,111 @@ func (bsp *batchSpanProcessor) processQueue() {
 		case <-bsp.stopCh:
 			return
 		case <-bsp.timer.C:
-			if err := bsp.exportSpans(ctx); err != nil {
-				otel.Handle(err)
-			}
-		case sd := <-bsp.queue:
-			bsp.batchMutex.Lock()
-			bsp.batch = append(bsp.batch, sd)
-			shouldExport := len(bsp.batch) == bsp.o.MaxExportBatchSize
-			bsp.batchMutex.Unlock()
-			if shouldExport {
-				if !bsp.timer.Stop() {
-					<-bsp.timer.C
-				}
-				if err := bsp.exportSpans(ctx); err != nil {
-					otel.Handle(err)
-				}
+			bsp.collect(ctx, false)
+			if err := bsp.exportSpans(ctx, FlushReasonTimeout); err != nil {
+				otel.HandleSignal(otel.TracesSignal, err)
 			}
+		case <-bsp.notify:
+			bsp.collect(ctx, true)
 		}
 	}
 }
 
-// drainQueue awaits the any caller that had added to bsp.stopWait
-// to finish the enqueue, then exports the final batch.
+// drainQueue exports all spans left in the queue once the processor is shut
+// down.
 func (bsp *batchSpanProcessor) drainQueue() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	bsp.collect(ctx, false)
+	if err := bsp.exportSpans(ctx, FlushReasonShutdown); err != nil {
+		otel.HandleSignal(otel.TracesSignal, err)
+	}
+}
+
+// collect moves the queued spans to the batch, exporting the batch every
+// time it reaches MaxExportBatchSize. If stopTimer is true the batch timer is
+// stopped before these exports. Collecting stops if an export fails, leaving
+// the remaining spans queued until the next export.
+func (bsp *batchSpanProcessor) collect(ctx context.Context, stopTimer bool) {
 	for {
-		select {
-		case sd := <-bsp.queue:
-			if sd == nil {
-				if err := bsp.exportSpans(ctx); err != nil {
-					otel.Handle(err)
-				}
-				return
+		bsp.batchMutex.Lock()
+		n := 0
+		for len(bsp.batch) < bsp.o.MaxExportBatchSize {
+			sd, ok := bsp.queue.dequeue()
+			if !ok {
+				break
 			}
-
-			bsp.batchMutex.Lock()
 			bsp.batch = append(bsp.batch, sd)
-			shouldExport := len(bsp.batch) == bsp.o.MaxExportBatchSize
-			bsp.batchMutex.Unlock()
+			n++
+		}
+		full := len(bsp.batch) >= bsp.o.MaxExportBatchSize
+		bsp.batchMutex.Unlock()
 
-			if shouldExport {
-				if err := bsp.exportSpans(ctx); err != nil {
-					otel.Handle(err)
-				}
-			}
-		default:
-			close(bsp.queue)
+		if n > 0 && bsp.o.BlockOnQueueFull {
+			signal(bsp.space)
+		}
+		if !full {
+			return
+		}
+		if stopTimer && !bsp.timer.Stop() {
+			<-bsp.timer.C
+		}
+		if err := bsp.exportSpans(ctx, FlushReasonSize); err != nil {
+			otel.HandleSignal(otel.TracesSignal, err)
+			return
 		}
 	}
 }
 
-func (bsp *batchSpanProcessor) enqueue(sd *export.SpanSnapshot) {
-	if !sd.SpanContext.IsSampled() {
+// enqueue adds sd to the queue. If the queue is full, sd is dropped or, if
+// the processor is configured with BlockOnQueueFull, enqueue waits until
+// there is room in the queue or the processor is shut down.
+func (bsp *batchSpanProcessor) enqueue(sd ReadOnlySpan) {
+	if !sd.SpanContext().IsSampled() {
 		return
 	}
 
-	// This ensures the bsp.queue<- below does not panic as the
-	// processor shuts down.
-	defer func() {
-		x := recover()
-		switch err := x.(type) {
-		case nil:
-			return
-		case runtime.Error:
-			if err.Error() == "send on closed channel" {
-				return
-			}
-		}
-		panic(x)
-	}()
-
 	select {
 	case <-bsp.stopCh:
 		return
 	default:
 	}
 
-	if bsp.o.BlockOnQueueFull {
-		bsp.queue <- sd
-		return
+	blocked := false
+	for !bsp.queue.tryEnqueue(sd) {
+		// Ensure the full queue is drained.
+		signal(bsp.notify)
+		if !bsp.o.BlockOnQueueFull {
+			atomic.AddUint64(&bsp.dropped, 1)
+			bsp.selfMetrics().recordDropped(context.Background())
+			global.Debug("dropping span: queue is full", "span", sd.Name(), "queue_size", bsp.queue.cap())
+			if bsp.o.OnSpanDropped != nil {
+				bsp.o.OnSpanDropped(sd)
+			}
+			return
+		}
+		select {
+		case <-bsp.space:
+			blocked = true
+		case <-bsp.stopCh:
+			return
+		}
+	}
+	atomic.AddUint64(&bsp.enqueued, 1)
+	if blocked {
+		// Pass the wake-up on to the next blocked producer.
+		signal(bsp.space)
 	}
+	if n := bsp.queue.len(); n >= bsp.o.MaxExportBatchSize || n == bsp.queue.cap() {
+		signal(bsp.notify)
+	}
+}
 
+// signal sends on ch without blocking. A signal already pending on ch is not
+// duplicated.
+func signal(ch chan struct{}) {
 	select {
-	case bsp.queue <- sd:
+	case ch <- struct{}{}:
 	default:
-		atomic.AddUint32(&bsp.dropped, 1)
 	}
 }
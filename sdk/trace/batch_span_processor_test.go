@@ -26,19 +26,18 @@ import (
 
 	"go.opentelemetry.io/otel/trace"
 
-	export "go.opentelemetry.io/otel/sdk/export/trace"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type testBatchExporter struct {
 	mu            sync.Mutex
-	spans         []*export.SpanSnapshot
+	spans         []sdktrace.ReadOnlySpan
 	sizes         []int
 	batchCount    int
 	shutdownCount int
 }
 
-func (t *testBatchExporter) ExportSpans(ctx context.Context, ss []*export.SpanSnapshot) error {
+func (t *testBatchExporter) ExportSpans(ctx context.Context, ss []sdktrace.ReadOnlySpan) error {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -65,7 +64,7 @@ func (t *testBatchExporter) getBatchCount() int {
 	return t.batchCount
 }
 
-var _ export.SpanExporter = (*testBatchExporter)(nil)
+var _ sdktrace.SpanExporter = (*testBatchExporter)(nil)
 
 func TestNewBatchSpanProcessorWithNilExporter(t *testing.T) {
 	tp := basicTracerProvider(t)
@@ -326,3 +325,275 @@ func TestBatchSpanProcessorForceFlushCancellation(t *testing.T) {
 		t.Errorf("expected context canceled error, got %v", err)
 	}
 }
+
+func TestBatchSpanProcessorExportCallback(t *testing.T) {
+	te := testBatchExporter{}
+	var stats []sdktrace.BatchExportStats
+	bsp := sdktrace.NewBatchSpanProcessor(&te, sdktrace.WithBatchExportCallback(func(s sdktrace.BatchExportStats) {
+		stats = append(stats, s)
+	}))
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+	tr := tp.Tracer("BatchSpanProcessorExportCallback")
+
+	ended := time.Now()
+	for i := 0; i < 3; i++ {
+		_, span := tr.Start(context.Background(), "span", trace.WithTimestamp(ended.Add(-time.Minute)))
+		span.End(trace.WithTimestamp(ended.Add(-time.Duration(i) * time.Second)))
+	}
+	assert.NoError(t, bsp.Shutdown(context.Background()))
+
+	if assert.Len(t, stats, 1) {
+		s := stats[0]
+		assert.Equal(t, 3, s.SpanCount)
+		assert.NoError(t, s.Err)
+		assert.GreaterOrEqual(t, int64(s.MaxLatency), int64(2*time.Second))
+		assert.Less(t, int64(s.MeanLatency), int64(s.MaxLatency))
+		assert.GreaterOrEqual(t, int64(s.MaxLatency), int64(s.ExportDuration))
+	}
+}
+
+type stuckExporter struct {
+	testBatchExporter
+	release chan struct{}
+}
+
+func (e *stuckExporter) ExportSpans(ctx context.Context, ss []sdktrace.ReadOnlySpan) error {
+	<-e.release
+	return e.testBatchExporter.ExportSpans(ctx, ss)
+}
+
+func TestBatchSpanProcessorBlockingUnblocksOnShutdown(t *testing.T) {
+	exp := &stuckExporter{release: make(chan struct{})}
+	bsp := sdktrace.NewBatchSpanProcessor(
+		exp,
+		sdktrace.WithMaxQueueSize(1),
+		sdktrace.WithMaxExportBatchSize(1),
+		sdktrace.WithBlocking(),
+	)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+	tr := tp.Tracer("BlockingBSP")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// The first span is held by the stuck exporter, the second fills
+		// the queue, and the third blocks.
+		for i := 0; i < 3; i++ {
+			_, span := tr.Start(context.Background(), "span")
+			span.End()
+		}
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("OnEnd did not block on a full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, bsp.Shutdown(ctx))
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("OnEnd still blocked after shutdown")
+	}
+	close(exp.release)
+}
+
+type ctxBlockingExporter struct {
+	testBatchExporter
+}
+
+func (e *ctxBlockingExporter) ExportSpans(ctx context.Context, ss []sdktrace.ReadOnlySpan) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestBatchSpanProcessorExportTimeout(t *testing.T) {
+	statsCh := make(chan sdktrace.BatchExportStats, 1)
+	bsp := sdktrace.NewBatchSpanProcessor(
+		&ctxBlockingExporter{},
+		sdktrace.WithMaxExportBatchSize(1),
+		sdktrace.WithExportTimeout(10*time.Millisecond),
+		sdktrace.WithBatchExportCallback(func(s sdktrace.BatchExportStats) {
+			select {
+			case statsCh <- s:
+			default:
+			}
+		}),
+	)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+	_, span := tp.Tracer("ExportTimeout").Start(context.Background(), "span")
+	span.End()
+
+	select {
+	case stats := <-statsCh:
+		assert.Equal(t, context.DeadlineExceeded, stats.Err)
+		assert.Equal(t, uint64(1), stats.Enqueued)
+		assert.Equal(t, uint64(0), stats.Exported)
+	case <-time.After(time.Second):
+		t.Fatal("export was not timed out")
+	}
+	assert.NoError(t, bsp.Shutdown(context.Background()))
+}
+
+func TestBatchSpanProcessorDropCounts(t *testing.T) {
+	exp := &stuckExporter{release: make(chan struct{})}
+	var dropped []string
+	statsCh := make(chan sdktrace.BatchExportStats, 10)
+	bsp := sdktrace.NewBatchSpanProcessor(
+		exp,
+		sdktrace.WithMaxQueueSize(1),
+		sdktrace.WithMaxExportBatchSize(1),
+		sdktrace.WithDropCallback(func(s sdktrace.ReadOnlySpan) {
+			dropped = append(dropped, s.Name())
+		}),
+		sdktrace.WithBatchExportCallback(func(s sdktrace.BatchExportStats) {
+			statsCh <- s
+		}),
+	)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+	tr := tp.Tracer("DropCounts")
+
+	// Wait for the first span to be held by the stuck exporter so the
+	// queue has room for exactly one more span.
+	_, span := tr.Start(context.Background(), "exporting")
+	span.End()
+	assert.Eventually(t, func() bool {
+		_, span := tr.Start(context.Background(), "queued")
+		span.End()
+		return len(dropped) > 0
+	}, time.Second, time.Millisecond)
+
+	_, span = tr.Start(context.Background(), "dropped")
+	span.End()
+	assert.Equal(t, "dropped", dropped[len(dropped)-1])
+
+	close(exp.release)
+	stats := <-statsCh
+	assert.Equal(t, uint64(1), stats.Exported)
+	assert.Equal(t, uint64(len(dropped)), stats.Dropped)
+	assert.Equal(t, stats.Enqueued+stats.Dropped, uint64(len(dropped)+2))
+	assert.NoError(t, bsp.Shutdown(context.Background()))
+}
+
+type batchInfoExporter struct {
+	testBatchExporter
+	infos []sdktrace.ExportBatchInfo
+}
+
+func (e *batchInfoExporter) ExportSpans(ctx context.Context, ss []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	if info, ok := sdktrace.ExportBatchInfoFromContext(ctx); ok {
+		e.infos = append(e.infos, info)
+	}
+	e.mu.Unlock()
+	return e.testBatchExporter.ExportSpans(ctx, ss)
+}
+
+func (e *batchInfoExporter) getInfos() []sdktrace.ExportBatchInfo {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]sdktrace.ExportBatchInfo(nil), e.infos...)
+}
+
+func TestBatchSpanProcessorExportBatchInfo(t *testing.T) {
+	exp := &batchInfoExporter{}
+	var stats []sdktrace.BatchExportStats
+	var statsMu sync.Mutex
+	bsp := sdktrace.NewBatchSpanProcessor(
+		exp,
+		sdktrace.WithMaxExportBatchSize(2),
+		sdktrace.WithBatchTimeout(time.Hour),
+		sdktrace.WithBatchExportCallback(func(s sdktrace.BatchExportStats) {
+			statsMu.Lock()
+			stats = append(stats, s)
+			statsMu.Unlock()
+		}),
+	)
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+	tr := tp.Tracer("ExportBatchInfo")
+
+	generateSpan(t, false, tr, testOption{genNumSpans: 2})
+	assert.Eventually(t, func() bool { return exp.getBatchCount() == 1 }, time.Second, time.Millisecond)
+
+	// The span might not have been added to the batch yet, in which case
+	// ForceFlush has nothing to export.
+	generateSpan(t, false, tr, testOption{genNumSpans: 1})
+	assert.Eventually(t, func() bool {
+		assert.NoError(t, bsp.ForceFlush(context.Background()))
+		return exp.getBatchCount() == 2
+	}, time.Second, time.Millisecond)
+
+	generateSpan(t, false, tr, testOption{genNumSpans: 1})
+	assert.NoError(t, bsp.Shutdown(context.Background()))
+
+	want := []sdktrace.ExportBatchInfo{
+		{ID: 1, Reason: sdktrace.FlushReasonSize},
+		{ID: 2, Reason: sdktrace.FlushReasonForced},
+		{ID: 3, Reason: sdktrace.FlushReasonShutdown},
+	}
+	assert.Equal(t, want, exp.getInfos())
+
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	if assert.Len(t, stats, len(want)) {
+		for i, s := range stats {
+			assert.Equal(t, want[i], s.Batch)
+		}
+	}
+}
+
+func TestBatchSpanProcessorExportBatchInfoTimeout(t *testing.T) {
+	exp := &batchInfoExporter{}
+	bsp := sdktrace.NewBatchSpanProcessor(exp, sdktrace.WithBatchTimeout(time.Millisecond))
+	tp := basicTracerProvider(t)
+	tp.RegisterSpanProcessor(bsp)
+
+	generateSpan(t, false, tp.Tracer("ExportBatchInfoTimeout"), testOption{genNumSpans: 1})
+	assert.Eventually(t, func() bool { return exp.getBatchCount() == 1 }, time.Second, time.Millisecond)
+	assert.NoError(t, bsp.Shutdown(context.Background()))
+
+	assert.Equal(t, []sdktrace.ExportBatchInfo{{ID: 1, Reason: sdktrace.FlushReasonTimeout}}, exp.getInfos())
+}
+
+// discardExporter is a SpanExporter dropping all spans.
+type discardExporter struct{}
+
+func (discardExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error { return nil }
+func (discardExporter) Shutdown(context.Context) error                             { return nil }
+
+func BenchmarkBatchSpanProcessorOnEnd(b *testing.B) {
+	tp := sdktrace.NewTracerProvider()
+	_, s := tp.Tracer("BenchmarkBatchSpanProcessorOnEnd").Start(context.Background(), "span")
+	s.End()
+	span := s.(sdktrace.ReadOnlySpan)
+
+	for _, bc := range []struct {
+		name string
+		opts []sdktrace.BatchSpanProcessorOption
+	}{
+		{name: "Dropping"},
+		{name: "Blocking", opts: []sdktrace.BatchSpanProcessorOption{sdktrace.WithBlocking()}},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			bsp := sdktrace.NewBatchSpanProcessor(discardExporter{}, bc.opts...)
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					bsp.OnEnd(span)
+				}
+			})
+			b.StopTimer()
+			_ = bsp.Shutdown(context.Background())
+		})
+	}
+}
@@ -18,10 +18,13 @@ import (
 	"context"
 	"testing"
 
+	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal/global"
 	"go.opentelemetry.io/otel/trace"
 
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func BenchmarkStartEndSpan(b *testing.B) {
@@ -35,6 +38,33 @@ func BenchmarkStartEndSpan(b *testing.B) {
 	})
 }
 
+func BenchmarkTracer(b *testing.B) {
+	tp := sdktrace.NewTracerProvider()
+	opt := trace.WithInstrumentationVersion("v1.0.0")
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = tp.Tracer("Benchmark Tracer", opt)
+		}
+	})
+}
+
+func BenchmarkGlobalTracerWithSDK(b *testing.B) {
+	// Compare with BenchmarkTracer() to see the overhead of the global
+	// TracerProvider delegating to the SDK.
+	global.ResetForTest()
+	b.Cleanup(global.ResetForTest)
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = otel.Tracer("Benchmark Tracer")
+		}
+	})
+}
+
 func BenchmarkSpanWithAttributes_4(b *testing.B) {
 	traceBenchmark(b, "Benchmark Start With 4 Attributes", func(b *testing.B, t trace.Tracer) {
 		ctx := context.Background()
@@ -72,6 +102,29 @@ func BenchmarkSpanWithAttributes_8(b *testing.B) {
 	})
 }
 
+func BenchmarkSpanWithAttributes_4_processor(b *testing.B) {
+	// Compare with BenchmarkSpanWithAttributes_4() to see the cost of the
+	// snapshot of ended spans, whose attributes storage is then reused.
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(tracetest.NewNoopExporter()),
+	)
+	t := tp.Tracer("Benchmark Start With 4 Attributes and a processor")
+	ctx := context.Background()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, span := t.Start(ctx, "/foo")
+		span.SetAttributes(
+			attribute.Bool("key1", false),
+			attribute.String("key2", "hello"),
+			attribute.Int64("key3", 123),
+			attribute.Float64("key4", 123.456),
+		)
+		span.End()
+	}
+}
+
 func BenchmarkSpanWithAttributes_all(b *testing.B) {
 	traceBenchmark(b, "Benchmark Start With all Attribute types", func(b *testing.B, t trace.Tracer) {
 		ctx := context.Background()
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/internal/global"
+)
+
+const (
+	DefaultCircuitBreakerFailureThreshold = 5
+	DefaultCircuitBreakerProbeInterval    = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned by the ExportSpans method of a circuit breaker
+// SpanExporter without a fallback while its circuit is open.
+var ErrCircuitOpen = errors.New("span exporter circuit breaker is open")
+
+type CircuitBreakerOption func(o *CircuitBreakerOptions)
+
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failed exports that
+	// open the circuit.
+	// The default value of FailureThreshold is 5.
+	FailureThreshold int
+
+	// ProbeInterval is the time the circuit stays open before a single
+	// export is passed to the wrapped exporter to probe the backend.
+	// The default value of ProbeInterval is 30 seconds.
+	ProbeInterval time.Duration
+
+	// Fallback, if set, exports the spans while the circuit is open
+	// instead of failing fast.
+	Fallback SpanExporter
+}
+
+// circuitState is the state of the circuit of a circuitBreakerExporter.
+type circuitState int
+
+const (
+	// circuitClosed passes all exports to the wrapped exporter.
+	circuitClosed circuitState = iota
+	// circuitOpen fails all exports fast until the probe interval elapsed.
+	circuitOpen
+	// circuitHalfOpen passes a single probing export to the wrapped
+	// exporter and fails the others fast until it returns.
+	circuitHalfOpen
+)
+
+// circuitBreakerExporter is a SpanExporter that stops calling the wrapped
+// SpanExporter after consecutive failures.
+type circuitBreakerExporter struct {
+	next  SpanExporter
+	o     CircuitBreakerOptions
+	clock Clock
+
+	mu       sync.Mutex
+	state    circuitState
+	failures int
+	openedAt time.Time
+}
+
+var _ SpanExporter = (*circuitBreakerExporter)(nil)
+
+// NewCircuitBreakerExporter returns a SpanExporter that exports spans to
+// next until FailureThreshold consecutive exports failed. The circuit is
+// then open: exports fail fast with ErrCircuitOpen, or are passed to the
+// Fallback exporter if one is configured, without calling next. Once the
+// ProbeInterval elapsed a single export is passed to next again, closing
+// the circuit if it succeeds or opening it for another ProbeInterval if it
+// fails.
+//
+// This prevents an unreachable backend from consuming resources with
+// exports bound to fail and from delaying the shutdown of the span
+// processor exporting to it.
+func NewCircuitBreakerExporter(next SpanExporter, options ...CircuitBreakerOption) SpanExporter {
+	o := CircuitBreakerOptions{
+		FailureThreshold: DefaultCircuitBreakerFailureThreshold,
+		ProbeInterval:    DefaultCircuitBreakerProbeInterval,
+	}
+	for _, opt := range options {
+		opt(&o)
+	}
+	if o.FailureThreshold <= 0 {
+		o.FailureThreshold = DefaultCircuitBreakerFailureThreshold
+	}
+	if o.ProbeInterval <= 0 {
+		o.ProbeInterval = DefaultCircuitBreakerProbeInterval
+	}
+	return &circuitBreakerExporter{
+		next:  next,
+		o:     o,
+		clock: defaultClock{},
+	}
+}
+
+// WithFailureThreshold returns a CircuitBreakerOption that sets the number
+// of consecutive failed exports that open the circuit.
+func WithFailureThreshold(n int) CircuitBreakerOption {
+	return func(o *CircuitBreakerOptions) {
+		o.FailureThreshold = n
+	}
+}
+
+// WithProbeInterval returns a CircuitBreakerOption that sets the time the
+// circuit stays open before the backend is probed.
+func WithProbeInterval(d time.Duration) CircuitBreakerOption {
+	return func(o *CircuitBreakerOptions) {
+		o.ProbeInterval = d
+	}
+}
+
+// WithFallbackExporter returns a CircuitBreakerOption that sets the
+// exporter the spans are exported to while the circuit is open.
+func WithFallbackExporter(e SpanExporter) CircuitBreakerOption {
+	return func(o *CircuitBreakerOptions) {
+		o.Fallback = e
+	}
+}
+
+// ExportSpans exports spans to the wrapped exporter if the circuit is
+// closed or if this export probes the backend, and to the fallback exporter
+// otherwise.
+func (e *circuitBreakerExporter) ExportSpans(ctx context.Context, spans []ReadOnlySpan) error {
+	if !e.allow() {
+		if e.o.Fallback != nil {
+			return e.o.Fallback.ExportSpans(ctx, spans)
+		}
+		return ErrCircuitOpen
+	}
+	err := e.next.ExportSpans(ctx, spans)
+	e.done(err)
+	return err
+}
+
+// allow returns whether an export is passed to the wrapped exporter. It
+// moves an open circuit to half-open once the probe interval elapsed.
+func (e *circuitBreakerExporter) allow() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch e.state {
+	case circuitClosed:
+		return true
+	case circuitOpen:
+		if e.clock.Since(e.openedAt) < e.o.ProbeInterval {
+			return false
+		}
+		e.state = circuitHalfOpen
+		global.Info("probing span exporter")
+		return true
+	default:
+		// A probing export is in flight.
+		return false
+	}
+}
+
+// done updates the circuit with the result of an export passed to the
+// wrapped exporter.
+func (e *circuitBreakerExporter) done(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err == nil {
+		if e.state != circuitClosed {
+			global.Info("closing span exporter circuit breaker")
+		}
+		e.state = circuitClosed
+		e.failures = 0
+		return
+	}
+
+	e.failures++
+	if e.state == circuitHalfOpen || e.failures >= e.o.FailureThreshold {
+		if e.state == circuitClosed {
+			global.Warn("opening span exporter circuit breaker", "failures", e.failures, "error", err)
+		}
+		e.state = circuitOpen
+		e.openedAt = e.clock.Now()
+	}
+}
+
+// Shutdown shuts down the wrapped and the fallback exporters.
+func (e *circuitBreakerExporter) Shutdown(ctx context.Context) error {
+	err := e.next.Shutdown(ctx)
+	if e.o.Fallback != nil {
+		if ferr := e.o.Fallback.Shutdown(ctx); err == nil {
+			err = ferr
+		}
+	}
+	return err
+}
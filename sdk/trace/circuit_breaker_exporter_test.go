@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stepClock is a Clock that only advances when told to.
+type stepClock struct{ now time.Time }
+
+func (c *stepClock) Now() time.Time                  { return c.now }
+func (c *stepClock) Since(t time.Time) time.Duration { return c.now.Sub(t) }
+
+// countingExporter is a SpanExporter returning err from every export.
+type countingExporter struct {
+	err       error
+	exports   int
+	shutdowns int
+}
+
+func (e *countingExporter) ExportSpans(context.Context, []ReadOnlySpan) error {
+	e.exports++
+	return e.err
+}
+
+func (e *countingExporter) Shutdown(context.Context) error {
+	e.shutdowns++
+	return nil
+}
+
+func newTestCircuitBreaker(next SpanExporter, options ...CircuitBreakerOption) (*circuitBreakerExporter, *stepClock) {
+	clock := &stepClock{now: time.Unix(1000, 0)}
+	e := NewCircuitBreakerExporter(next, options...).(*circuitBreakerExporter)
+	e.clock = clock
+	return e, clock
+}
+
+func TestCircuitBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	errExport := errors.New("export failed")
+	next := &countingExporter{err: errExport}
+	e, _ := newTestCircuitBreaker(next, WithFailureThreshold(3))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, errExport, e.ExportSpans(ctx, nil))
+	}
+	assert.Equal(t, ErrCircuitOpen, e.ExportSpans(ctx, nil))
+	assert.Equal(t, 3, next.exports)
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	next := &countingExporter{err: errors.New("export failed")}
+	e, _ := newTestCircuitBreaker(next, WithFailureThreshold(2))
+	ctx := context.Background()
+
+	assert.Error(t, e.ExportSpans(ctx, nil))
+	next.err = nil
+	assert.NoError(t, e.ExportSpans(ctx, nil))
+	next.err = errors.New("export failed")
+	assert.Error(t, e.ExportSpans(ctx, nil))
+	assert.NotEqual(t, ErrCircuitOpen, e.ExportSpans(ctx, nil), "the circuit opens on the second consecutive failure")
+	assert.Equal(t, ErrCircuitOpen, e.ExportSpans(ctx, nil))
+	assert.Equal(t, 4, next.exports)
+}
+
+func TestCircuitBreakerProbe(t *testing.T) {
+	next := &countingExporter{err: errors.New("export failed")}
+	e, clock := newTestCircuitBreaker(next, WithFailureThreshold(1), WithProbeInterval(time.Minute))
+	ctx := context.Background()
+
+	require.Error(t, e.ExportSpans(ctx, nil))
+	clock.now = clock.now.Add(59 * time.Second)
+	assert.Equal(t, ErrCircuitOpen, e.ExportSpans(ctx, nil))
+	assert.Equal(t, 1, next.exports)
+
+	// The failed probe opens the circuit for another interval.
+	clock.now = clock.now.Add(time.Second)
+	assert.NotEqual(t, ErrCircuitOpen, e.ExportSpans(ctx, nil))
+	assert.Equal(t, 2, next.exports)
+	clock.now = clock.now.Add(30 * time.Second)
+	assert.Equal(t, ErrCircuitOpen, e.ExportSpans(ctx, nil))
+
+	// The successful probe closes the circuit.
+	next.err = nil
+	clock.now = clock.now.Add(30 * time.Second)
+	assert.NoError(t, e.ExportSpans(ctx, nil))
+	assert.NoError(t, e.ExportSpans(ctx, nil))
+	assert.Equal(t, 4, next.exports)
+}
+
+func TestCircuitBreakerSingleProbe(t *testing.T) {
+	next := &countingExporter{err: errors.New("export failed")}
+	e, clock := newTestCircuitBreaker(next, WithFailureThreshold(1))
+	ctx := context.Background()
+
+	require.Error(t, e.ExportSpans(ctx, nil))
+	clock.now = clock.now.Add(DefaultCircuitBreakerProbeInterval)
+	require.True(t, e.allow(), "first export probes the backend")
+	assert.Equal(t, ErrCircuitOpen, e.ExportSpans(ctx, nil), "exports fail fast while probing")
+	e.done(nil)
+	next.err = nil
+	assert.NoError(t, e.ExportSpans(ctx, nil))
+}
+
+func TestCircuitBreakerFallback(t *testing.T) {
+	next := &countingExporter{err: errors.New("export failed")}
+	fallback := &countingExporter{}
+	e, _ := newTestCircuitBreaker(next, WithFailureThreshold(1), WithFallbackExporter(fallback))
+	ctx := context.Background()
+
+	assert.Error(t, e.ExportSpans(ctx, nil))
+	assert.NoError(t, e.ExportSpans(ctx, nil))
+	assert.NoError(t, e.ExportSpans(ctx, nil))
+	assert.Equal(t, 1, next.exports)
+	assert.Equal(t, 2, fallback.exports)
+
+	require.NoError(t, e.Shutdown(ctx))
+	assert.Equal(t, 1, next.shutdowns)
+	assert.Equal(t, 1, fallback.shutdowns)
+}
+
+func TestCircuitBreakerDefaults(t *testing.T) {
+	e := NewCircuitBreakerExporter(&countingExporter{}, WithFailureThreshold(0), WithProbeInterval(-time.Second)).(*circuitBreakerExporter)
+	assert.Equal(t, CircuitBreakerOptions{
+		FailureThreshold: DefaultCircuitBreakerFailureThreshold,
+		ProbeInterval:    DefaultCircuitBreakerProbeInterval,
+	}, e.o)
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import "time"
+
+// Clock provides the time of spans.
+type Clock interface {
+	// Now returns the current time. It is used as the start time
+	// of spans and as the time of span events.
+	Now() time.Time
+
+	// Since returns the time elapsed since t, the start time of a span. It
+	// is added to t to compute the end time of the span and should be
+	// measured with a monotonic clock so span durations are not affected
+	// by changes of the wall clock.
+	Since(t time.Time) time.Duration
+}
+
+// defaultClock is the system Clock.
+type defaultClock struct{}
+
+var _ Clock = defaultClock{}
+
+// Now returns the current wall clock time with a monotonic clock reading.
+func (defaultClock) Now() time.Time {
+	return time.Now()
+}
+
+// Since returns the time elapsed since t. If t holds a monotonic clock
+// reading, as returned by Now, the monotonic clock is used, so the duration
+// is never negative even if the wall clock is set backwards.
+// See https://golang.org/pkg/time/#hdr-Monotonic_Clocks
+func (defaultClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// fakeClock is a Clock whose wall clock and monotonic clock are advanced
+// independently, to simulate wall clock corrections. It counts the reads of
+// the wall clock.
+type fakeClock struct {
+	wall    time.Time
+	elapsed time.Duration
+	nows    int
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.nows++
+	return c.wall
+}
+
+func (c *fakeClock) Since(time.Time) time.Duration { return c.elapsed }
+
+func TestWithClock(t *testing.T) {
+	start := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	clock := &fakeClock{wall: start}
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp), sdktrace.WithClock(clock))
+
+	_, span := tp.Tracer("TestWithClock").Start(context.Background(), "span")
+	clock.wall = start.Add(time.Second)
+	span.AddEvent("event")
+	explicit := start.Add(time.Minute)
+	nows := clock.nows
+	span.AddEvent("explicit", trace.WithTimestamp(explicit))
+	assert.Equal(t, nows, clock.nows, "clock read for an event with a timestamp")
+	// The wall clock is set backwards while 2 seconds pass.
+	clock.wall = start.Add(-time.Hour)
+	clock.elapsed = 2 * time.Second
+	span.End()
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 1)
+	got := spans[0]
+	assert.Equal(t, start, got.StartTime)
+	assert.Equal(t, start.Add(2*time.Second), got.EndTime)
+	require.Len(t, got.MessageEvents, 2)
+	assert.Equal(t, start.Add(time.Second), got.MessageEvents[0].Time)
+	assert.Equal(t, explicit, got.MessageEvents[1].Time)
+}
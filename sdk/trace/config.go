@@ -14,6 +14,11 @@
 
 package trace // import "go.opentelemetry.io/otel/sdk/trace"
 
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/internal/env"
+)
+
 // SpanLimits represents the limits of a span.
 type SpanLimits struct {
 	// AttributeCountLimit is the maximum allowed span attribute count.
@@ -30,26 +35,66 @@ type SpanLimits struct {
 
 	// AttributePerLinkCountLimit is the maximum allowed attribute per span link count.
 	AttributePerLinkCountLimit int
+
+	// AttributeValueLengthLimit is the maximum allowed attribute value
+	// length in characters. Longer string values, and string elements of
+	// array values, are truncated to this length. Unlike the other limits,
+	// a negative value means no limit and only zero is considered unset.
+	AttributeValueLengthLimit int
 }
 
+// ensureDefault sets each unset (non-positive) limit of sl to the value of
+// its environment variable, or to the default limit if the environment
+// variable is not set or is invalid.
 func (sl *SpanLimits) ensureDefault() {
 	if sl.EventCountLimit <= 0 {
-		sl.EventCountLimit = DefaultEventCountLimit
+		sl.EventCountLimit = env.Int(otel.TracesSignal, EnvSpanEventCountLimit, DefaultEventCountLimit)
 	}
 	if sl.AttributeCountLimit <= 0 {
-		sl.AttributeCountLimit = DefaultAttributeCountLimit
+		sl.AttributeCountLimit = env.Int(otel.TracesSignal, EnvSpanAttributeCountLimit, DefaultAttributeCountLimit)
 	}
 	if sl.LinkCountLimit <= 0 {
-		sl.LinkCountLimit = DefaultLinkCountLimit
+		sl.LinkCountLimit = env.Int(otel.TracesSignal, EnvSpanLinkCountLimit, DefaultLinkCountLimit)
 	}
 	if sl.AttributePerEventCountLimit <= 0 {
-		sl.AttributePerEventCountLimit = DefaultAttributePerEventCountLimit
+		sl.AttributePerEventCountLimit = env.Int(otel.TracesSignal, EnvEventAttributeCountLimit, DefaultAttributePerEventCountLimit)
 	}
 	if sl.AttributePerLinkCountLimit <= 0 {
-		sl.AttributePerLinkCountLimit = DefaultAttributePerLinkCountLimit
+		sl.AttributePerLinkCountLimit = env.Int(otel.TracesSignal, EnvLinkAttributeCountLimit, DefaultAttributePerLinkCountLimit)
+	}
+	if sl.AttributeValueLengthLimit == 0 {
+		sl.AttributeValueLengthLimit = env.Int(otel.TracesSignal, EnvAttributeValueLengthLimit, DefaultAttributeValueLengthLimit)
 	}
 }
 
+// Environment variables used to configure SpanLimits that are not set with
+// the WithSpanLimits option.
+const (
+	// EnvSpanAttributeCountLimit is the environment variable for the
+	// maximum allowed span attribute count.
+	EnvSpanAttributeCountLimit = "OTEL_SPAN_ATTRIBUTE_COUNT_LIMIT"
+
+	// EnvSpanEventCountLimit is the environment variable for the maximum
+	// allowed span event count.
+	EnvSpanEventCountLimit = "OTEL_SPAN_EVENT_COUNT_LIMIT"
+
+	// EnvSpanLinkCountLimit is the environment variable for the maximum
+	// allowed span link count.
+	EnvSpanLinkCountLimit = "OTEL_SPAN_LINK_COUNT_LIMIT"
+
+	// EnvEventAttributeCountLimit is the environment variable for the
+	// maximum allowed attribute per span event count.
+	EnvEventAttributeCountLimit = "OTEL_EVENT_ATTRIBUTE_COUNT_LIMIT"
+
+	// EnvLinkAttributeCountLimit is the environment variable for the
+	// maximum allowed attribute per span link count.
+	EnvLinkAttributeCountLimit = "OTEL_LINK_ATTRIBUTE_COUNT_LIMIT"
+
+	// EnvAttributeValueLengthLimit is the environment variable for the
+	// maximum allowed attribute value length.
+	EnvAttributeValueLengthLimit = "OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT"
+)
+
 const (
 	// DefaultAttributeCountLimit is the default maximum allowed span attribute count.
 	DefaultAttributeCountLimit = 128
@@ -65,4 +110,8 @@ const (
 
 	// DefaultAttributePerLinkCountLimit is the default maximum allowed attribute per span link count.
 	DefaultAttributePerLinkCountLimit = 128
+
+	// DefaultAttributeValueLengthLimit is the default maximum allowed
+	// attribute value length, unlimited.
+	DefaultAttributeValueLengthLimit = -1
 )
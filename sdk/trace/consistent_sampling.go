@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// otelTraceStateKey is the key of the OpenTelemetry entry in the W3C
+	// tracestate that holds the p-value and r-value of a trace.
+	otelTraceStateKey = attribute.Key("ot")
+
+	// maxRValue is the largest valid r-value.
+	maxRValue = 62
+	// zeroProbabilityPValue is the p-value of a zero sampling probability.
+	zeroProbabilityPValue = 63
+)
+
+// otelTraceState is the parsed value of the OpenTelemetry tracestate entry.
+type otelTraceState struct {
+	p, r       uint
+	hasP, hasR bool
+	// rest are the unknown fields of the entry, in their original order.
+	rest []string
+}
+
+// parseOTelTraceState parses the OpenTelemetry tracestate entry value v.
+// Invalid p-values and r-values are ignored.
+func parseOTelTraceState(v string) otelTraceState {
+	var ots otelTraceState
+	if v == "" {
+		return ots
+	}
+	for _, field := range strings.Split(v, ";") {
+		kv := strings.SplitN(field, ":", 2)
+		if len(kv) != 2 {
+			ots.rest = append(ots.rest, field)
+			continue
+		}
+		switch kv[0] {
+		case "p":
+			if p, err := strconv.ParseUint(kv[1], 10, 8); err == nil && p <= zeroProbabilityPValue {
+				ots.p, ots.hasP = uint(p), true
+			}
+		case "r":
+			if r, err := strconv.ParseUint(kv[1], 10, 8); err == nil && r <= maxRValue {
+				ots.r, ots.hasR = uint(r), true
+			}
+		default:
+			ots.rest = append(ots.rest, field)
+		}
+	}
+	return ots
+}
+
+// String encodes ots as an OpenTelemetry tracestate entry value.
+func (ots otelTraceState) String() string {
+	fields := make([]string, 0, len(ots.rest)+2)
+	if ots.hasP {
+		fields = append(fields, "p:"+strconv.FormatUint(uint64(ots.p), 10))
+	}
+	if ots.hasR {
+		fields = append(fields, "r:"+strconv.FormatUint(uint64(ots.r), 10))
+	}
+	fields = append(fields, ots.rest...)
+	return strings.Join(fields, ";")
+}
+
+// pValueProbability returns the sampling probability of p-value p.
+func pValueProbability(p uint) float64 {
+	if p >= zeroProbabilityPValue {
+		return 0
+	}
+	return math.Ldexp(1, -int(p))
+}
+
+type consistentProbabilitySampler struct {
+	// lowerP is the p-value used with probability lowerProbability and
+	// lowerP+1 is used otherwise. This interpolates between the power of
+	// two probabilities that can be expressed by a p-value.
+	lowerP           uint
+	lowerProbability float64
+
+	mu  sync.Mutex
+	rnd *rand.Rand
+
+	description string
+}
+
+func (cs *consistentProbabilitySampler) ShouldSample(p SamplingParameters) SamplingResult {
+	ts := trace.SpanContextFromContext(p.ParentContext).TraceState()
+	ots := parseOTelTraceState(ts.Get(otelTraceStateKey).AsString())
+
+	cs.mu.Lock()
+	if !ots.hasR {
+		ots.r, ots.hasR = cs.newRValue(), true
+	}
+	pValue := cs.pValue()
+	cs.mu.Unlock()
+
+	decision := Drop
+	if pValue <= ots.r {
+		decision = RecordAndSample
+		ots.p, ots.hasP = pValue, true
+	} else {
+		// The p-value is only meaningful for sampled spans.
+		ots.hasP = false
+	}
+
+	if updated, err := ts.Insert(otelTraceStateKey.String(ots.String())); err != nil {
+		otel.HandleSignal(otel.TracesSignal, err)
+	} else {
+		ts = updated
+	}
+
+	return SamplingResult{
+		Decision:   decision,
+		Tracestate: ts,
+	}
+}
+
+// newRValue returns a random r-value. The probability of the r-value being
+// greater or equal to n is 2^-n.
+//
+// The caller needs to hold cs.mu.
+func (cs *consistentProbabilitySampler) newRValue() uint {
+	r := uint(bits.LeadingZeros64(cs.rnd.Uint64()))
+	if r > maxRValue {
+		r = maxRValue
+	}
+	return r
+}
+
+// pValue returns the p-value to sample a span with.
+//
+// The caller needs to hold cs.mu.
+func (cs *consistentProbabilitySampler) pValue() uint {
+	if cs.lowerProbability >= 1 || cs.rnd.Float64() < cs.lowerProbability {
+		return cs.lowerP
+	}
+	return cs.lowerP + 1
+}
+
+func (cs *consistentProbabilitySampler) Description() string {
+	return cs.description
+}
+
+// ConsistentProbabilityBased samples a given fraction of traces, making
+// sampling decisions consistent with other ConsistentProbabilityBased
+// samplers of the same trace, even if they use different fractions. The
+// randomness used to make the decision (r-value) and the sampling
+// probability (p-value) are propagated in the OpenTelemetry tracestate entry
+// so backends can compute accurate span counts from the sampled spans.
+//
+// Fractions >= 1 will always sample. Fractions <= 0 never sample. To respect
+// the parent trace's `SampledFlag`, the `ConsistentProbabilityBased` sampler
+// should be used as a delegate of a `Parent` sampler.
+func ConsistentProbabilityBased(fraction float64) Sampler {
+	var rngSeed int64
+	_ = binary.Read(crand.Reader, binary.LittleEndian, &rngSeed)
+	return newConsistentProbabilitySampler(fraction, rand.NewSource(rngSeed))
+}
+
+func newConsistentProbabilitySampler(fraction float64, src rand.Source) *consistentProbabilitySampler {
+	cs := &consistentProbabilitySampler{
+		rnd:         rand.New(src),
+		description: fmt.Sprintf("ConsistentProbabilityBased{%g}", fraction),
+	}
+	switch {
+	case fraction >= 1:
+		cs.lowerP, cs.lowerProbability = 0, 1
+	case fraction <= pValueProbability(maxRValue+1):
+		cs.lowerP, cs.lowerProbability = zeroProbabilityPValue, 1
+	default:
+		cs.lowerP = uint(math.Floor(-math.Log2(fraction)))
+		upper, lower := pValueProbability(cs.lowerP), pValueProbability(cs.lowerP+1)
+		cs.lowerProbability = (fraction - lower) / (upper - lower)
+	}
+	return cs
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func parentWithOTelTraceState(t *testing.T, value string) context.Context {
+	ts, err := trace.TraceStateFromKeyValues(
+		otelTraceStateKey.String(value),
+		attribute.String("vendor", "value"),
+	)
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceState: ts,
+	})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+func TestParseOTelTraceState(t *testing.T) {
+	tests := []struct {
+		value string
+		want  otelTraceState
+	}{
+		{value: "", want: otelTraceState{}},
+		{value: "p:2;r:5", want: otelTraceState{p: 2, hasP: true, r: 5, hasR: true}},
+		{value: "r:62", want: otelTraceState{r: 62, hasR: true}},
+		{value: "p:63", want: otelTraceState{p: 63, hasP: true}},
+		{value: "p:64;r:63", want: otelTraceState{}},
+		{value: "r:x;p:-1", want: otelTraceState{}},
+		{value: "r:1;v:abc;flag", want: otelTraceState{r: 1, hasR: true, rest: []string{"v:abc", "flag"}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			assert.Equal(t, tc.want, parseOTelTraceState(tc.value))
+		})
+	}
+}
+
+func TestOTelTraceStateString(t *testing.T) {
+	assert.Equal(t, "", otelTraceState{}.String())
+	assert.Equal(t, "p:2;r:5", otelTraceState{p: 2, hasP: true, r: 5, hasR: true}.String())
+	assert.Equal(t, "r:5;v:abc", otelTraceState{r: 5, hasR: true, rest: []string{"v:abc"}}.String())
+}
+
+func TestConsistentProbabilityBasedUsesParentRValue(t *testing.T) {
+	ctx := parentWithOTelTraceState(t, "r:5;v:abc")
+	src := rand.NewSource(1)
+
+	sampled := newConsistentProbabilitySampler(0.25, src).ShouldSample(SamplingParameters{ParentContext: ctx})
+	assert.Equal(t, RecordAndSample, sampled.Decision)
+	assert.Equal(t, "ot=p:2;r:5;v:abc,vendor=value", sampled.Tracestate.String())
+
+	dropped := newConsistentProbabilitySampler(1.0/64, src).ShouldSample(SamplingParameters{ParentContext: ctx})
+	assert.Equal(t, Drop, dropped.Decision)
+	assert.Equal(t, "ot=r:5;v:abc,vendor=value", dropped.Tracestate.String())
+}
+
+func TestConsistentProbabilityBasedAddsRValue(t *testing.T) {
+	params := SamplingParameters{ParentContext: context.Background()}
+
+	always := newConsistentProbabilitySampler(1, rand.NewSource(1)).ShouldSample(params)
+	assert.Equal(t, RecordAndSample, always.Decision)
+	ots := parseOTelTraceState(always.Tracestate.Get(otelTraceStateKey).AsString())
+	assert.True(t, ots.hasR)
+	assert.Equal(t, otelTraceState{p: 0, hasP: true, r: ots.r, hasR: true}, ots)
+
+	never := newConsistentProbabilitySampler(0, rand.NewSource(1)).ShouldSample(params)
+	assert.Equal(t, Drop, never.Decision)
+	ots = parseOTelTraceState(never.Tracestate.Get(otelTraceStateKey).AsString())
+	assert.True(t, ots.hasR)
+	assert.False(t, ots.hasP)
+}
+
+func TestConsistentProbabilityBasedIsConsistent(t *testing.T) {
+	src := rand.NewSource(1)
+	rnd := rand.New(src)
+	high := newConsistentProbabilitySampler(0.5, src)
+	low := newConsistentProbabilitySampler(0.125, src)
+
+	for i := 0; i < 1000; i++ {
+		r := otelTraceState{r: uint(rnd.Intn(maxRValue + 1)), hasR: true}
+		params := SamplingParameters{ParentContext: parentWithOTelTraceState(t, r.String())}
+		if low.ShouldSample(params).Decision == RecordAndSample {
+			require.Equal(t, RecordAndSample, high.ShouldSample(params).Decision)
+		}
+	}
+}
+
+func TestConsistentProbabilityBasedFraction(t *testing.T) {
+	const total = 20000
+	for _, fraction := range []float64{0.5, 0.3, 0.01} {
+		cs := newConsistentProbabilitySampler(fraction, rand.NewSource(1))
+		params := SamplingParameters{ParentContext: context.Background()}
+
+		var sampled int
+		for i := 0; i < total; i++ {
+			if cs.ShouldSample(params).Decision == RecordAndSample {
+				sampled++
+			}
+		}
+		assert.InDelta(t, fraction, float64(sampled)/total, 0.02, "fraction %g", fraction)
+	}
+}
+
+func TestConsistentProbabilityBasedDescription(t *testing.T) {
+	assert.Equal(t, "ConsistentProbabilityBased{0.25}", ConsistentProbabilityBased(0.25).Description())
+}
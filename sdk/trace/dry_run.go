@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import "sync/atomic"
+
+// DryRunStats are the counts of what the span processors of a TracerProvider
+// in dry-run mode would have exported.
+type DryRunStats struct {
+	// Batches is the number of batches that would have been exported.
+	Batches uint64
+
+	// Spans is the number of spans that would have been exported.
+	Spans uint64
+}
+
+// dryRunCounter counts the spans span processors would have exported.
+type dryRunCounter struct {
+	// Ensure counters are 64-bit aligned for atomic operations on both 32
+	// and 64 bit machines.
+	batches uint64
+	spans   uint64
+}
+
+// count records the export of batch.
+func (c *dryRunCounter) count(batch []ReadOnlySpan) {
+	atomic.AddUint64(&c.batches, 1)
+	atomic.AddUint64(&c.spans, uint64(len(batch)))
+}
+
+func (c *dryRunCounter) stats() DryRunStats {
+	return DryRunStats{
+		Batches: atomic.LoadUint64(&c.batches),
+		Spans:   atomic.LoadUint64(&c.spans),
+	}
+}
+
+// dryRunner is implemented by the span processors of this package that
+// export spans. Once its dry-run counter is set, a dryRunner counts the spans
+// it would export instead of passing them to its SpanExporter.
+type dryRunner interface {
+	setDryRun(*dryRunCounter)
+}
+
+// dryRunValue holds the dry-run counter of a span processor.
+type dryRunValue struct {
+	v atomic.Value
+}
+
+func (d *dryRunValue) setDryRun(c *dryRunCounter) {
+	d.v.Store(c)
+}
+
+// counter returns the dry-run counter, or nil if not in dry-run mode.
+func (d *dryRunValue) counter() *dryRunCounter {
+	c, _ := d.v.Load().(*dryRunCounter)
+	return c
+}
+
+// WithDryRun returns a TracerProviderOption that puts the TracerProvider in
+// dry-run mode. Spans are sampled and processed as usual, but the span
+// processors of this package registered with the TracerProvider count the
+// spans they would export instead of passing them to their SpanExporter. The
+// counts are returned by the DryRunStats method of the TracerProvider.
+//
+// This allows measuring the overhead of instrumentation, for example in load
+// tests, in isolation from the export backend and network. Span processors
+// not from this package are not affected.
+func WithDryRun() TracerProviderOption {
+	return func(cfg *TracerProviderConfig) {
+		cfg.dryRun = true
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDryRun(t *testing.T) {
+	syncExp := tracetest.NewInMemoryExporter()
+	batchExp := &testBatchExporter{}
+	filteredExp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithDryRun(),
+		sdktrace.WithSyncer(syncExp),
+		sdktrace.WithBatcher(batchExp, sdktrace.WithMaxExportBatchSize(2)),
+		sdktrace.WithSpanProcessor(sdktrace.NewFilterProcessor(sdktrace.NewSimpleSpanProcessor(filteredExp))),
+	)
+	tr := tp.Tracer("TestDryRun")
+
+	for i := 0; i < 4; i++ {
+		_, span := tr.Start(context.Background(), "span")
+		span.End()
+	}
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	assert.Len(t, syncExp.GetSpans(), 0)
+	assert.Len(t, filteredExp.GetSpans(), 0)
+	assert.Equal(t, 0, batchExp.getBatchCount())
+	assert.Equal(t, 1, batchExp.shutdownCount, "exporters are still shut down")
+
+	stats := tp.DryRunStats()
+	// 4 spans for each SimpleSpanProcessor and 4 spans in batches of at
+	// most 2 for the BatchSpanProcessor.
+	assert.Equal(t, uint64(12), stats.Spans)
+	assert.GreaterOrEqual(t, stats.Batches, uint64(8+2))
+}
+
+func TestDryRunRegisterSpanProcessor(t *testing.T) {
+	tp := sdktrace.NewTracerProvider(sdktrace.WithDryRun())
+	exp := tracetest.NewInMemoryExporter()
+	tp.RegisterSpanProcessor(sdktrace.NewSimpleSpanProcessor(exp))
+
+	_, span := tp.Tracer("TestDryRunRegisterSpanProcessor").Start(context.Background(), "span")
+	span.End()
+
+	assert.Len(t, exp.GetSpans(), 0)
+	assert.Equal(t, sdktrace.DryRunStats{Batches: 1, Spans: 1}, tp.DryRunStats())
+}
+
+func TestWithoutDryRun(t *testing.T) {
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+
+	_, span := tp.Tracer("TestWithoutDryRun").Start(context.Background(), "span")
+	span.End()
+
+	assert.Len(t, exp.GetSpans(), 1)
+	assert.Equal(t, sdktrace.DryRunStats{}, tp.DryRunStats())
+}
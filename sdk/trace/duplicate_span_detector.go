@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultDuplicateSpanDetectorMaxSpans is the default number of span IDs
+// remembered by a DuplicateSpanDetector.
+const DefaultDuplicateSpanDetectorMaxSpans = 4096
+
+// SpanSummary identifies an ended span in a DuplicateSpanError.
+type SpanSummary struct {
+	TraceID                trace.TraceID
+	ParentSpanID           trace.SpanID
+	Name                   string
+	InstrumentationLibrary instrumentation.Library
+	StartTime              time.Time
+	EndTime                time.Time
+}
+
+func (s SpanSummary) String() string {
+	return fmt.Sprintf("%q (trace %s, parent %s, tracer %q, ended %s)",
+		s.Name, s.TraceID, s.ParentSpanID, s.InstrumentationLibrary.Name,
+		s.EndTime.Format(time.RFC3339Nano))
+}
+
+// DuplicateSpanError is reported to the global error handler by a
+// DuplicateSpanDetector when a span ends with the span ID of a previously
+// ended span.
+type DuplicateSpanError struct {
+	// SpanID is the span ID shared by both spans.
+	SpanID trace.SpanID
+	// First is the span that ended first with SpanID.
+	First SpanSummary
+	// Duplicate is the span that ended later with SpanID.
+	Duplicate SpanSummary
+}
+
+func (e *DuplicateSpanError) Error() string {
+	return fmt.Sprintf("duplicate span ID %s: ended by %s and by %s", e.SpanID, e.First, e.Duplicate)
+}
+
+// DuplicateSpanDetector is a debugging SpanProcessor that detects ended
+// spans sharing the same span ID, usually caused by the misuse of a
+// SpanContext or by a custom IDGenerator. Every duplicate is reported to the
+// global error handler as a *DuplicateSpanError describing both spans.
+//
+// Only the span IDs of the most recently ended spans are remembered, the
+// oldest are forgotten once the configured maximum is reached. Spans
+// dropped by the Sampler are not seen by SpanProcessors and are therefore
+// not checked.
+type DuplicateSpanDetector struct {
+	mu         sync.Mutex
+	seen       map[trace.SpanID]SpanSummary
+	order      []trace.SpanID
+	head       int
+	duplicates int
+}
+
+var _ SpanProcessor = (*DuplicateSpanDetector)(nil)
+
+// NewDuplicateSpanDetector returns a DuplicateSpanDetector remembering the
+// span IDs of the last maxSpans ended spans. If maxSpans is zero or less
+// DefaultDuplicateSpanDetectorMaxSpans is used.
+func NewDuplicateSpanDetector(maxSpans int) *DuplicateSpanDetector {
+	if maxSpans <= 0 {
+		maxSpans = DefaultDuplicateSpanDetectorMaxSpans
+	}
+	return &DuplicateSpanDetector{
+		seen:  make(map[trace.SpanID]SpanSummary, maxSpans),
+		order: make([]trace.SpanID, 0, maxSpans),
+	}
+}
+
+// OnStart method does nothing.
+func (d *DuplicateSpanDetector) OnStart(context.Context, ReadWriteSpan) {}
+
+// OnEnding method does nothing.
+func (d *DuplicateSpanDetector) OnEnding(ReadWriteSpan) {}
+
+// OnEnd checks whether a span with the span ID of s already ended, and
+// reports it if so.
+func (d *DuplicateSpanDetector) OnEnd(s ReadOnlySpan) {
+	sc := s.SpanContext()
+	summary := SpanSummary{
+		TraceID:                sc.TraceID(),
+		ParentSpanID:           s.Parent().SpanID(),
+		Name:                   s.Name(),
+		InstrumentationLibrary: s.InstrumentationLibrary(),
+		StartTime:              s.StartTime(),
+		EndTime:                s.EndTime(),
+	}
+
+	d.mu.Lock()
+	first, ok := d.seen[sc.SpanID()]
+	if ok {
+		d.duplicates++
+	} else {
+		d.remember(sc.SpanID(), summary)
+	}
+	d.mu.Unlock()
+
+	if ok {
+		otel.HandleSignal(otel.TracesSignal, &DuplicateSpanError{
+			SpanID:    sc.SpanID(),
+			First:     first,
+			Duplicate: summary,
+		})
+	}
+}
+
+// remember adds id to the seen span IDs, forgetting the oldest one if the
+// maximum is reached. The lock must be held.
+func (d *DuplicateSpanDetector) remember(id trace.SpanID, s SpanSummary) {
+	if len(d.order) < cap(d.order) {
+		d.order = append(d.order, id)
+	} else {
+		delete(d.seen, d.order[d.head])
+		d.order[d.head] = id
+		d.head = (d.head + 1) % len(d.order)
+	}
+	d.seen[id] = s
+}
+
+// Duplicates returns the number of duplicate spans detected.
+func (d *DuplicateSpanDetector) Duplicates() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.duplicates
+}
+
+// Shutdown forgets all remembered span IDs.
+func (d *DuplicateSpanDetector) Shutdown(context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.seen = make(map[trace.SpanID]SpanSummary)
+	d.order = d.order[:0]
+	d.head = 0
+	return nil
+}
+
+// ForceFlush does nothing as there is no data to flush.
+func (d *DuplicateSpanDetector) ForceFlush(context.Context) error {
+	return nil
+}
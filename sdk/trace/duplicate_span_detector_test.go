@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// constantIDGenerator generates new trace IDs but always the same span ID.
+type constantIDGenerator struct {
+	IDGenerator
+}
+
+func (g constantIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	tid, _ := g.IDGenerator.NewIDs(ctx)
+	return tid, sid
+}
+
+func (g constantIDGenerator) NewSpanID(context.Context, trace.TraceID) trace.SpanID {
+	return sid
+}
+
+func TestDuplicateSpanDetector(t *testing.T) {
+	handler.Reset()
+	d := NewDuplicateSpanDetector(0)
+	tp := NewTracerProvider(
+		WithSpanProcessor(d),
+		WithIDGenerator(constantIDGenerator{defaultIDGenerator()}),
+	)
+	tracer := tp.Tracer("TestDuplicateSpanDetector")
+
+	_, first := tracer.Start(context.Background(), "first")
+	first.End()
+	assert.Equal(t, 0, d.Duplicates())
+	_, second := tracer.Start(context.Background(), "second")
+	second.End()
+	assert.Equal(t, 1, d.Duplicates())
+
+	require.Len(t, handler.errs, 1)
+	var dupErr *DuplicateSpanError
+	require.True(t, errors.As(handler.errs[0], &dupErr))
+	assert.Equal(t, sid, dupErr.SpanID)
+	assert.Equal(t, "first", dupErr.First.Name)
+	assert.Equal(t, first.SpanContext().TraceID(), dupErr.First.TraceID)
+	assert.Equal(t, "second", dupErr.Duplicate.Name)
+	assert.Equal(t, second.SpanContext().TraceID(), dupErr.Duplicate.TraceID)
+	assert.Equal(t, "TestDuplicateSpanDetector", dupErr.Duplicate.InstrumentationLibrary.Name)
+	assert.Contains(t, dupErr.Error(), sid.String())
+	handler.Reset()
+}
+
+func TestDuplicateSpanDetectorUniqueSpans(t *testing.T) {
+	handler.Reset()
+	d := NewDuplicateSpanDetector(0)
+	tracer := NewTracerProvider(WithSpanProcessor(d)).Tracer("TestDuplicateSpanDetectorUniqueSpans")
+	for i := 0; i < 10; i++ {
+		_, span := tracer.Start(context.Background(), "span")
+		span.End()
+	}
+	assert.Equal(t, 0, d.Duplicates())
+	assert.Empty(t, handler.errs)
+}
+
+func TestDuplicateSpanDetectorForgetsOldest(t *testing.T) {
+	d := NewDuplicateSpanDetector(2)
+	ids := []trace.SpanID{{1}, {2}, {3}}
+	for _, id := range ids {
+		d.remember(id, SpanSummary{})
+	}
+	assert.Len(t, d.seen, 2)
+	assert.NotContains(t, d.seen, ids[0])
+	assert.Contains(t, d.seen, ids[1])
+	assert.Contains(t, d.seen, ids[2])
+
+	d.remember(trace.SpanID{4}, SpanSummary{})
+	assert.NotContains(t, d.seen, ids[1])
+}
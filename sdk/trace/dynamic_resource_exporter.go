@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// dynamicResourceExporter is a SpanExporter that merges the attributes of a
+// resource.Dynamic into the Resource of every exported span.
+type dynamicResourceExporter struct {
+	dynamic *resource.Dynamic
+	next    SpanExporter
+}
+
+var _ SpanExporter = (*dynamicResourceExporter)(nil)
+
+// NewDynamicResourceExporter returns a SpanExporter that resolves d once for
+// every exported batch and exports the spans to next with the resolved
+// attributes merged over their Resource. The dynamic attributes take
+// precedence over the static Resource attributes with the same key.
+func NewDynamicResourceExporter(d *resource.Dynamic, next SpanExporter) SpanExporter {
+	return &dynamicResourceExporter{dynamic: d, next: next}
+}
+
+// ExportSpans resolves the dynamic resource and exports spans to the wrapped
+// exporter.
+func (e *dynamicResourceExporter) ExportSpans(ctx context.Context, spans []ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return e.next.ExportSpans(ctx, spans)
+	}
+
+	dynamic := e.dynamic.Resolve(ctx)
+	if dynamic.Len() == 0 {
+		return e.next.ExportSpans(ctx, spans)
+	}
+
+	// Spans of a batch almost always share the same few Resources, only
+	// merge each of them once.
+	merged := make(map[*resource.Resource]*resource.Resource)
+	out := make([]ReadOnlySpan, len(spans))
+	for i, s := range spans {
+		static := s.Resource()
+		r, ok := merged[static]
+		if !ok {
+			// The resolved attributes have no schema URL, they
+			// cannot conflict with the one of static.
+			r, _ = resource.Merge(static, dynamic)
+			merged[static] = r
+		}
+		out[i] = resourceSpan{ReadOnlySpan: s, resource: r}
+	}
+	return e.next.ExportSpans(ctx, out)
+}
+
+// Shutdown shuts down the wrapped exporter.
+func (e *dynamicResourceExporter) Shutdown(ctx context.Context) error {
+	return e.next.Shutdown(ctx)
+}
+
+// resourceSpan is a ReadOnlySpan with a replaced Resource.
+type resourceSpan struct {
+	ReadOnlySpan
+	resource *resource.Resource
+}
+
+// Resource returns the replaced Resource of the span.
+func (s resourceSpan) Resource() *resource.Resource {
+	return s.resource
+}
@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestDynamicResourceExporter(t *testing.T) {
+	leader := attribute.BoolValue(false)
+	d := resource.NewDynamic(resource.WithDynamicAttribute("leader", func(context.Context) (attribute.Value, error) {
+		return leader, nil
+	}))
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSyncer(sdktrace.NewDynamicResourceExporter(d, exp)),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			attribute.String("service.name", "svc"),
+			attribute.Bool("leader", true),
+		)),
+	)
+	tracer := tp.Tracer("TestDynamicResourceExporter")
+
+	_, span := tracer.Start(context.Background(), "follower")
+	span.End()
+	leader = attribute.BoolValue(true)
+	_, span = tracer.Start(context.Background(), "leader")
+	span.End()
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Bool("leader", false),
+		attribute.String("service.name", "svc"),
+	}, spans[0].Resource.Attributes())
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Bool("leader", true),
+		attribute.String("service.name", "svc"),
+	}, spans[1].Resource.Attributes())
+	require.NoError(t, tp.Shutdown(context.Background()))
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ContextEnricher provides additional attributes and links for a span from
+// the context it is started with, e.g. from a security principal stored in
+// the context by a framework.
+//
+// ContextEnrichers are called, in the order they are registered with a
+// TracerProvider, before the sampling decision is made for a span. The
+// returned attributes and links are added to the span as if they were
+// passed as options when it was started, and are therefore available to
+// the Sampler.
+type ContextEnricher interface {
+	// Enrich returns the attributes and links to add to a span started
+	// with the parent context ctx. It is called synchronously when the
+	// span is started and must not block.
+	Enrich(ctx context.Context) ([]attribute.KeyValue, []trace.Link)
+}
+
+// ContextEnricherFunc is an adapter to allow the use of ordinary functions
+// as ContextEnrichers.
+type ContextEnricherFunc func(ctx context.Context) ([]attribute.KeyValue, []trace.Link)
+
+var _ ContextEnricher = ContextEnricherFunc(nil)
+
+// Enrich calls f(ctx).
+func (f ContextEnricherFunc) Enrich(ctx context.Context) ([]attribute.KeyValue, []trace.Link) {
+	return f(ctx)
+}
+
+// enrich adds the attributes and links returned by enrichers for ctx to
+// config.
+func enrich(ctx context.Context, enrichers []ContextEnricher, config *trace.SpanConfig) {
+	for _, e := range enrichers {
+		attrs, links := e.Enrich(ctx)
+		config.Attributes = append(config.Attributes, attrs...)
+		config.Links = append(config.Links, links...)
+	}
+}
+
+// WithContextEnricher returns a TracerProviderOption that will register the
+// ContextEnricher e with the TracerProvider. The attributes and links e
+// provides are added to all spans the Tracers of the TracerProvider start.
+//
+// This option can be used multiple times, the ContextEnrichers are called in
+// the order they are registered.
+func WithContextEnricher(e ContextEnricher) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		if e != nil {
+			opts.enrichers = append(opts.enrichers, e)
+		}
+	}
+}
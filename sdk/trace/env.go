@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+)
+
+// Environment variables used to configure the Sampler of a TracerProvider
+// not set with the WithSampler option, and the BatchSpanProcessorOptions
+// not set with options.
+const (
+	// EnvTracesSampler is the environment variable for the Sampler of a
+	// TracerProvider: "always_on", "always_off", "traceidratio",
+	// "parentbased_always_on", "parentbased_always_off", or
+	// "parentbased_traceidratio".
+	EnvTracesSampler = "OTEL_TRACES_SAMPLER"
+
+	// EnvTracesSamplerArg is the environment variable for the argument of
+	// the EnvTracesSampler Sampler, the sampling probability of the
+	// "traceidratio" and "parentbased_traceidratio" Samplers. It defaults
+	// to 1.0.
+	EnvTracesSamplerArg = "OTEL_TRACES_SAMPLER_ARG"
+
+	// EnvBatchSpanProcessorScheduleDelay is the environment variable for
+	// the BatchTimeout of a batch span processor, in milliseconds.
+	EnvBatchSpanProcessorScheduleDelay = "OTEL_BSP_SCHEDULE_DELAY"
+
+	// EnvBatchSpanProcessorExportTimeout is the environment variable for
+	// the ExportTimeout of a batch span processor, in milliseconds.
+	EnvBatchSpanProcessorExportTimeout = "OTEL_BSP_EXPORT_TIMEOUT"
+
+	// EnvBatchSpanProcessorMaxQueueSize is the environment variable for
+	// the MaxQueueSize of a batch span processor.
+	EnvBatchSpanProcessorMaxQueueSize = "OTEL_BSP_MAX_QUEUE_SIZE"
+
+	// EnvBatchSpanProcessorMaxExportBatchSize is the environment variable
+	// for the MaxExportBatchSize of a batch span processor.
+	EnvBatchSpanProcessorMaxExportBatchSize = "OTEL_BSP_MAX_EXPORT_BATCH_SIZE"
+)
+
+// samplerFromEnv returns the Sampler configured by the EnvTracesSampler and
+// EnvTracesSamplerArg environment variables, or nil if EnvTracesSampler is
+// unset. Invalid values are reported to the global error handler and nil,
+// or the Sampler with the default argument, is returned.
+func samplerFromEnv() Sampler {
+	name, ok := os.LookupEnv(EnvTracesSampler)
+	if !ok || name == "" {
+		return nil
+	}
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	switch name {
+	case "always_on":
+		return AlwaysSample()
+	case "always_off":
+		return NeverSample()
+	case "traceidratio":
+		return TraceIDRatioBased(samplerRatioFromEnv())
+	case "parentbased_always_on":
+		return ParentBased(AlwaysSample())
+	case "parentbased_always_off":
+		return ParentBased(NeverSample())
+	case "parentbased_traceidratio":
+		return ParentBased(TraceIDRatioBased(samplerRatioFromEnv()))
+	}
+	otel.HandleSignal(otel.TracesSignal, fmt.Errorf("unsupported %s value %q", EnvTracesSampler, name))
+	return nil
+}
+
+// samplerRatioFromEnv returns the sampling probability of the
+// EnvTracesSamplerArg environment variable, or 1.0 if it is unset or
+// invalid.
+func samplerRatioFromEnv() float64 {
+	v, ok := os.LookupEnv(EnvTracesSamplerArg)
+	if !ok || v == "" {
+		return 1.0
+	}
+	ratio, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		otel.HandleSignal(otel.TracesSignal, fmt.Errorf("invalid %s value %q: must be a probability between 0 and 1", EnvTracesSamplerArg, v))
+		return 1.0
+	}
+	return ratio
+}
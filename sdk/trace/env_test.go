@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSamplerFromEnv(t *testing.T) {
+	tests := []struct {
+		sampler, arg string
+		want         string
+	}{
+		{"", "", ParentBased(AlwaysSample()).Description()},
+		{"always_on", "", AlwaysSample().Description()},
+		{"ALWAYS_OFF", "", NeverSample().Description()},
+		{"traceidratio", "0.25", TraceIDRatioBased(0.25).Description()},
+		{"traceidratio", "", TraceIDRatioBased(1).Description()},
+		{"traceidratio", "2", TraceIDRatioBased(1).Description()},
+		{"parentbased_always_on", "", ParentBased(AlwaysSample()).Description()},
+		{"parentbased_always_off", "", ParentBased(NeverSample()).Description()},
+		{"parentbased_traceidratio", "0.5", ParentBased(TraceIDRatioBased(0.5)).Description()},
+		{"unknown", "", ParentBased(AlwaysSample()).Description()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sampler+"/"+tt.arg, func(t *testing.T) {
+			setEnv(t, EnvTracesSampler, tt.sampler)
+			setEnv(t, EnvTracesSamplerArg, tt.arg)
+			tp := NewTracerProvider()
+			assert.Equal(t, tt.want, tp.sampler.Load().(samplerHolder).sampler.Description())
+		})
+	}
+}
+
+func TestSamplerFromEnvOverridden(t *testing.T) {
+	setEnv(t, EnvTracesSampler, "always_off")
+	tp := NewTracerProvider(WithSampler(AlwaysSample()))
+	assert.Equal(t, AlwaysSample().Description(), tp.sampler.Load().(samplerHolder).sampler.Description())
+}
+
+func TestBatchSpanProcessorOptionsFromEnv(t *testing.T) {
+	setEnv(t, EnvBatchSpanProcessorScheduleDelay, "100")
+	setEnv(t, EnvBatchSpanProcessorExportTimeout, "invalid")
+	setEnv(t, EnvBatchSpanProcessorMaxQueueSize, "10")
+	setEnv(t, EnvBatchSpanProcessorMaxExportBatchSize, "5")
+
+	bsp := NewBatchSpanProcessor(nil, WithMaxExportBatchSize(2)).(*batchSpanProcessor)
+	defer func() { require.NoError(t, bsp.Shutdown(context.Background())) }()
+	assert.Equal(t, 100*time.Millisecond, bsp.o.BatchTimeout)
+	assert.Equal(t, DefaultExportTimeout, bsp.o.ExportTimeout)
+	assert.Equal(t, 10, bsp.o.MaxQueueSize)
+	assert.Equal(t, 2, bsp.o.MaxExportBatchSize)
+}
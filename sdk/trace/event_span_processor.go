@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// eventSpanProcessor is a SpanProcessor that converts span events into
+// child spans before passing ended spans to another SpanProcessor.
+type eventSpanProcessor struct {
+	next SpanProcessor
+
+	// names are the names of the converted events. All events are
+	// converted if it is empty.
+	names map[string]struct{}
+
+	// idGenerator generates the span IDs of spans created by a Tracer
+	// unknown to this SDK.
+	idGenerator IDGenerator
+}
+
+var _ SpanProcessor = (*eventSpanProcessor)(nil)
+
+// NewEventSpanProcessor returns a new SpanProcessor that wraps next,
+// converting the events with one of eventNames of every ended span into
+// zero-duration child spans of that span. All events are converted if no
+// eventNames are passed. This is useful for backends that visualize spans
+// far better than span events.
+//
+// The child spans are named after the event, start and end at the time of
+// the event, and have the attributes of the event. They are passed to the
+// OnEnd method of next before the span they were converted from, which no
+// longer contains the converted events and counts the child spans in its
+// ChildSpanCount. OnStart and OnEnding are passed through to next.
+func NewEventSpanProcessor(next SpanProcessor, eventNames ...string) SpanProcessor {
+	esp := &eventSpanProcessor{
+		next:        next,
+		idGenerator: defaultIDGenerator(),
+	}
+	if len(eventNames) > 0 {
+		esp.names = make(map[string]struct{}, len(eventNames))
+		for _, n := range eventNames {
+			esp.names[n] = struct{}{}
+		}
+	}
+	return esp
+}
+
+// OnStart passes s to the wrapped SpanProcessor.
+func (esp *eventSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	esp.next.OnStart(parent, s)
+}
+
+// OnEnding passes s to the wrapped SpanProcessor.
+func (esp *eventSpanProcessor) OnEnding(s ReadWriteSpan) {
+	esp.next.OnEnding(s)
+}
+
+// OnEnd passes the spans converted from the events of s, and then s, to the
+// wrapped SpanProcessor.
+func (esp *eventSpanProcessor) OnEnd(s ReadOnlySpan) {
+	events := s.Events()
+	var kept []trace.Event
+	var converted int
+	for _, e := range events {
+		if !esp.converts(e.Name) {
+			kept = append(kept, e)
+			continue
+		}
+		esp.next.OnEnd(esp.eventSpan(s, e))
+		converted++
+	}
+	if converted > 0 {
+		s = eventlessSpan{ReadOnlySpan: s, events: kept, converted: converted}
+	}
+	esp.next.OnEnd(s)
+}
+
+// converts returns if events named name are converted to spans.
+func (esp *eventSpanProcessor) converts(name string) bool {
+	if esp.names == nil {
+		return true
+	}
+	_, ok := esp.names[name]
+	return ok
+}
+
+// eventSpan returns the zero-duration child span of parent for e.
+func (esp *eventSpanProcessor) eventSpan(parent ReadOnlySpan, e trace.Event) ReadOnlySpan {
+	psc := parent.SpanContext()
+	gen := esp.idGenerator
+	tr, _ := parent.Tracer().(*tracer)
+	if tr != nil && tr.provider != nil {
+		gen = tr.provider.idGenerator
+	}
+
+	return &snapshot{
+		name: e.Name,
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    psc.TraceID(),
+			SpanID:     gen.NewSpanID(context.Background(), psc.TraceID()),
+			TraceFlags: psc.TraceFlags(),
+			TraceState: psc.TraceState(),
+		}),
+		parent:                 psc,
+		spanKind:               trace.SpanKindInternal,
+		startTime:              e.Time,
+		endTime:                e.Time,
+		attributes:             e.Attributes,
+		resource:               parent.Resource(),
+		instrumentationLibrary: parent.InstrumentationLibrary(),
+		tracer:                 tr,
+	}
+}
+
+// setDryRun sets the dry-run counter of the wrapped SpanProcessor.
+func (esp *eventSpanProcessor) setDryRun(c *dryRunCounter) {
+	if dr, ok := esp.next.(dryRunner); ok {
+		dr.setDryRun(c)
+	}
+}
+
+// setSelfMetrics sets the self-observability metrics of the wrapped
+// SpanProcessor.
+func (esp *eventSpanProcessor) setSelfMetrics(sm *selfMetrics) {
+	if si, ok := esp.next.(selfInstrumented); ok {
+		si.setSelfMetrics(sm)
+	}
+}
+
+// Shutdown shuts down the wrapped SpanProcessor.
+func (esp *eventSpanProcessor) Shutdown(ctx context.Context) error {
+	return esp.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the wrapped SpanProcessor.
+func (esp *eventSpanProcessor) ForceFlush(ctx context.Context) error {
+	return esp.next.ForceFlush(ctx)
+}
+
+// eventlessSpan is a ReadOnlySpan without the events converted to spans.
+type eventlessSpan struct {
+	ReadOnlySpan
+
+	events    []trace.Event
+	converted int
+}
+
+// Events returns the events of the span that were not converted.
+func (s eventlessSpan) Events() []trace.Event {
+	return s.events
+}
+
+// ChildSpanCount returns the count of spans that consider the span a direct
+// parent, including those converted from its events.
+func (s eventlessSpan) ChildSpanCount() int {
+	return s.ReadOnlySpan.ChildSpanCount() + s.converted
+}
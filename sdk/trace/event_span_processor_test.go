@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newEventSpanProvider(eventNames ...string) (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exp := tracetest.NewInMemoryExporter()
+	esp := sdktrace.NewEventSpanProcessor(sdktrace.NewSimpleSpanProcessor(exp), eventNames...)
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(esp)), exp
+}
+
+func TestEventSpanProcessor(t *testing.T) {
+	tp, exp := newEventSpanProvider("cache.miss")
+	_, span := tp.Tracer("TestEventSpanProcessor").Start(context.Background(), "parent")
+	eventTime := time.Now()
+	span.AddEvent("cache.miss", trace.WithTimestamp(eventTime), trace.WithAttributes(attribute.String("key", "user:1")))
+	span.AddEvent("retry")
+	span.End()
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 2)
+	child, parent := spans[0], spans[1]
+
+	assert.Equal(t, "parent", parent.Name)
+	require.Len(t, parent.MessageEvents, 1)
+	assert.Equal(t, "retry", parent.MessageEvents[0].Name)
+	assert.Equal(t, 1, parent.ChildSpanCount)
+
+	assert.Equal(t, "cache.miss", child.Name)
+	assert.Equal(t, parent.SpanContext.TraceID(), child.SpanContext.TraceID())
+	assert.True(t, child.SpanContext.SpanID().IsValid())
+	assert.NotEqual(t, parent.SpanContext.SpanID(), child.SpanContext.SpanID())
+	assert.Equal(t, parent.SpanContext.TraceFlags(), child.SpanContext.TraceFlags())
+	assert.Equal(t, parent.SpanContext, child.Parent)
+	assert.Equal(t, trace.SpanKindInternal, child.SpanKind)
+	assert.Equal(t, eventTime, child.StartTime)
+	assert.Equal(t, eventTime, child.EndTime)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("key", "user:1")}, child.Attributes)
+	assert.Equal(t, parent.InstrumentationLibrary, child.InstrumentationLibrary)
+	assert.Equal(t, parent.Resource, child.Resource)
+}
+
+func TestEventSpanProcessorAllEvents(t *testing.T) {
+	tp, exp := newEventSpanProvider()
+	_, span := tp.Tracer("TestEventSpanProcessorAllEvents").Start(context.Background(), "parent")
+	span.AddEvent("a")
+	span.AddEvent("b")
+	span.End()
+
+	assert.Equal(t, []string{"a", "b", "parent"}, spanNames(exp.GetSpans()))
+	assert.Empty(t, exp.GetSpans()[2].MessageEvents)
+}
+
+func TestEventSpanProcessorNoEvents(t *testing.T) {
+	tp, exp := newEventSpanProvider("a")
+	_, span := tp.Tracer("TestEventSpanProcessorNoEvents").Start(context.Background(), "parent")
+	span.AddEvent("b")
+	span.End()
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Len(t, spans[0].MessageEvents, 1)
+	assert.Equal(t, 0, spans[0].ChildSpanCount)
+}
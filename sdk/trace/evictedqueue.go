@@ -20,13 +20,10 @@ type evictedQueue struct {
 	droppedCount int
 }
 
-func newEvictedQueue(capacity int) *evictedQueue {
-	eq := &evictedQueue{
-		capacity: capacity,
-		queue:    make([]interface{}, 0),
-	}
-
-	return eq
+// newEvictedQueue returns an empty evictedQueue. The queue is only
+// allocated once the first value is added.
+func newEvictedQueue(capacity int) evictedQueue {
+	return evictedQueue{capacity: capacity}
 }
 
 func (eq *evictedQueue) add(value interface{}) {
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import "context"
+
+// FlushReason is the reason a span processor exported a batch of spans.
+type FlushReason int
+
+const (
+	// FlushReasonUnknown is the reason of a batch exported for an
+	// unspecified reason.
+	FlushReasonUnknown FlushReason = iota
+	// FlushReasonSize is the reason of a batch exported because it reached
+	// its maximum size.
+	FlushReasonSize
+	// FlushReasonTimeout is the reason of a batch exported because its
+	// batch timeout expired.
+	FlushReasonTimeout
+	// FlushReasonForced is the reason of a batch exported by ForceFlush.
+	FlushReasonForced
+	// FlushReasonShutdown is the reason of the last batch exported when the
+	// span processor is shut down.
+	FlushReasonShutdown
+)
+
+// String returns the lower case name of r.
+func (r FlushReason) String() string {
+	switch r {
+	case FlushReasonSize:
+		return "size"
+	case FlushReasonTimeout:
+		return "timeout"
+	case FlushReasonForced:
+		return "forced"
+	case FlushReasonShutdown:
+		return "shutdown"
+	default:
+		return "unknown"
+	}
+}
+
+// ExportBatchInfo is the batch-scoped metadata of a batch of spans passed to
+// a SpanExporter. Exporters can expose it to help debugging the export
+// cadence of a span processor.
+type ExportBatchInfo struct {
+	// ID identifies the batch among the batches exported by the same span
+	// processor. IDs start at 1 and increase with each export.
+	ID uint64
+
+	// Reason is why the batch was exported.
+	Reason FlushReason
+}
+
+type exportBatchInfoKeyType int
+
+const exportBatchInfoKey exportBatchInfoKeyType = iota
+
+// ContextWithExportBatchInfo returns a copy of parent with info set as the
+// metadata of the exported batch of spans.
+func ContextWithExportBatchInfo(parent context.Context, info ExportBatchInfo) context.Context {
+	return context.WithValue(parent, exportBatchInfoKey, info)
+}
+
+// ExportBatchInfoFromContext returns the metadata of the exported batch of
+// spans in ctx, if any.
+func ExportBatchInfoFromContext(ctx context.Context) (ExportBatchInfo, bool) {
+	info, ok := ctx.Value(exportBatchInfoKey).(ExportBatchInfo)
+	return info, ok
+}
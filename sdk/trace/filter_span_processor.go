@@ -0,0 +1,295 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"regexp"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SpanPredicate reports whether an ended span matches a condition.
+type SpanPredicate func(ReadOnlySpan) bool
+
+// SpanNameMatches returns a SpanPredicate matching the spans whose name
+// matches re.
+func SpanNameMatches(re *regexp.Regexp) SpanPredicate {
+	return func(s ReadOnlySpan) bool {
+		return re.MatchString(s.Name())
+	}
+}
+
+// SpanHasAttribute returns a SpanPredicate matching the spans having the
+// attribute kv, with an equal value.
+func SpanHasAttribute(kv attribute.KeyValue) SpanPredicate {
+	return func(s ReadOnlySpan) bool {
+		for _, a := range s.Attributes() {
+			if a.Key == kv.Key && a.Value == kv.Value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// SpanStatusIs returns a SpanPredicate matching the spans with the status
+// code.
+func SpanStatusIs(code codes.Code) SpanPredicate {
+	return func(s ReadOnlySpan) bool {
+		return s.StatusCode() == code
+	}
+}
+
+// SpanDurationBelow returns a SpanPredicate matching the spans that lasted
+// less than threshold.
+func SpanDurationBelow(threshold time.Duration) SpanPredicate {
+	return func(s ReadOnlySpan) bool {
+		return s.EndTime().Sub(s.StartTime()) < threshold
+	}
+}
+
+// AllSpanPredicates returns a SpanPredicate matching the spans matched by
+// all of predicates, e.g. the spans of a health check endpoint that lasted
+// less than a millisecond.
+func AllSpanPredicates(predicates ...SpanPredicate) SpanPredicate {
+	return func(s ReadOnlySpan) bool {
+		for _, p := range predicates {
+			if !p(s) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// SpanRedaction removes attributes from the spans matching a SpanPredicate.
+type SpanRedaction struct {
+	// Match selects the spans to redact. If nil, all spans are redacted.
+	Match SpanPredicate
+
+	// Keys are the keys of the attributes removed from the span and its
+	// events.
+	Keys []attribute.Key
+}
+
+// FilterProcessorOptions is configuration settings for a filtering span
+// processor.
+type FilterProcessorOptions struct {
+	// Drop are the predicates of the spans that are not passed to the
+	// wrapped SpanProcessor when they end. A span is dropped if it matches
+	// any of them.
+	Drop []SpanPredicate
+
+	// Redactions are applied, in order, to the spans passed to the wrapped
+	// SpanProcessor when they end.
+	Redactions []SpanRedaction
+
+	// DropCounter, if set, counts the spans that are dropped.
+	DropCounter *SpanDropCounter
+
+	// OnSpanDropped, if set, is called with every span that is dropped.
+	OnSpanDropped func(ReadOnlySpan)
+}
+
+// SpanDropCounter counts the spans dropped by filtering span processors. It
+// can be shared by several processors. The zero value is ready to use.
+type SpanDropCounter struct {
+	dropped uint64
+}
+
+// Dropped returns the number of spans dropped since c was first used.
+func (c *SpanDropCounter) Dropped() uint64 {
+	return atomic.LoadUint64(&c.dropped)
+}
+
+// FilterProcessorOption configures a filtering span processor.
+type FilterProcessorOption func(o *FilterProcessorOptions)
+
+// WithDropFilter returns a FilterProcessorOption that drops the spans
+// matching p.
+func WithDropFilter(p SpanPredicate) FilterProcessorOption {
+	return func(o *FilterProcessorOptions) {
+		o.Drop = append(o.Drop, p)
+	}
+}
+
+// WithSpanDropCounter returns a FilterProcessorOption that counts the
+// dropped spans with c.
+func WithSpanDropCounter(c *SpanDropCounter) FilterProcessorOption {
+	return func(o *FilterProcessorOptions) {
+		o.DropCounter = c
+	}
+}
+
+// WithFilterDropCallback returns a FilterProcessorOption that configures cb
+// to be called with every dropped span, e.g. to count them by name.
+//
+// The callback is called synchronously from OnEnd, it should return quickly.
+func WithFilterDropCallback(cb func(ReadOnlySpan)) FilterProcessorOption {
+	return func(o *FilterProcessorOptions) {
+		o.OnSpanDropped = cb
+	}
+}
+
+// WithRedaction returns a FilterProcessorOption that removes the attributes
+// with keys from the spans matching p, and from their events. If p is nil,
+// the attributes are removed from all spans.
+func WithRedaction(p SpanPredicate, keys ...attribute.Key) FilterProcessorOption {
+	return func(o *FilterProcessorOptions) {
+		o.Redactions = append(o.Redactions, SpanRedaction{Match: p, Keys: keys})
+	}
+}
+
+// filterSpanProcessor is a SpanProcessor that drops or redacts ended spans
+// before passing them to another SpanProcessor.
+type filterSpanProcessor struct {
+	next SpanProcessor
+	o    FilterProcessorOptions
+}
+
+var _ SpanProcessor = (*filterSpanProcessor)(nil)
+
+// NewFilterProcessor returns a new SpanProcessor that wraps next, dropping or
+// redacting the ended spans as configured by the options before next
+// receives them in OnEnd. This ensures dropped spans and redacted attributes
+// never reach the exporters of next. Dropping noisy spans, e.g. short health
+// checks, this way is cheaper than tail sampling as the decision is made for
+// each span on its own, without buffering its trace.
+//
+// The predicates are evaluated once a span has ended, as its name,
+// attributes, and status are only final then. OnStart and OnEnding are
+// passed through to next for all spans.
+func NewFilterProcessor(next SpanProcessor, options ...FilterProcessorOption) SpanProcessor {
+	fsp := &filterSpanProcessor{next: next}
+	for _, opt := range options {
+		opt(&fsp.o)
+	}
+	return fsp
+}
+
+// OnStart passes s to the wrapped SpanProcessor.
+func (fsp *filterSpanProcessor) OnStart(parent context.Context, s ReadWriteSpan) {
+	fsp.next.OnStart(parent, s)
+}
+
+// OnEnding passes s to the wrapped SpanProcessor.
+func (fsp *filterSpanProcessor) OnEnding(s ReadWriteSpan) {
+	fsp.next.OnEnding(s)
+}
+
+// OnEnd passes s, redacted as configured, to the wrapped SpanProcessor unless
+// it matches a drop predicate.
+func (fsp *filterSpanProcessor) OnEnd(s ReadOnlySpan) {
+	for _, drop := range fsp.o.Drop {
+		if drop(s) {
+			fsp.dropped(s)
+			return
+		}
+	}
+
+	var denied map[attribute.Key]struct{}
+	for _, r := range fsp.o.Redactions {
+		if r.Match != nil && !r.Match(s) {
+			continue
+		}
+		if denied == nil {
+			denied = make(map[attribute.Key]struct{}, len(r.Keys))
+		}
+		for _, k := range r.Keys {
+			denied[k] = struct{}{}
+		}
+	}
+	if len(denied) > 0 {
+		s = redactedSpan{ReadOnlySpan: s, denied: denied}
+	}
+
+	fsp.next.OnEnd(s)
+}
+
+// dropped records that s was dropped.
+func (fsp *filterSpanProcessor) dropped(s ReadOnlySpan) {
+	if fsp.o.DropCounter != nil {
+		atomic.AddUint64(&fsp.o.DropCounter.dropped, 1)
+	}
+	if fsp.o.OnSpanDropped != nil {
+		fsp.o.OnSpanDropped(s)
+	}
+}
+
+// setDryRun sets the dry-run counter of the wrapped SpanProcessor.
+func (fsp *filterSpanProcessor) setDryRun(c *dryRunCounter) {
+	if dr, ok := fsp.next.(dryRunner); ok {
+		dr.setDryRun(c)
+	}
+}
+
+// setSelfMetrics sets the self-observability metrics of the wrapped
+// SpanProcessor.
+func (fsp *filterSpanProcessor) setSelfMetrics(sm *selfMetrics) {
+	if si, ok := fsp.next.(selfInstrumented); ok {
+		si.setSelfMetrics(sm)
+	}
+}
+
+// Shutdown shuts down the wrapped SpanProcessor.
+func (fsp *filterSpanProcessor) Shutdown(ctx context.Context) error {
+	return fsp.next.Shutdown(ctx)
+}
+
+// ForceFlush flushes the wrapped SpanProcessor.
+func (fsp *filterSpanProcessor) ForceFlush(ctx context.Context) error {
+	return fsp.next.ForceFlush(ctx)
+}
+
+// redactedSpan is a ReadOnlySpan without the attributes with denied keys.
+type redactedSpan struct {
+	ReadOnlySpan
+
+	denied map[attribute.Key]struct{}
+}
+
+// Attributes returns the attributes of the span that are not denied.
+func (s redactedSpan) Attributes() []attribute.KeyValue {
+	return s.redact(s.ReadOnlySpan.Attributes())
+}
+
+// Events returns the events of the span without their denied attributes.
+func (s redactedSpan) Events() []trace.Event {
+	events := s.ReadOnlySpan.Events()
+	if len(events) == 0 {
+		return events
+	}
+	redacted := make([]trace.Event, len(events))
+	for i, e := range events {
+		e.Attributes = s.redact(e.Attributes)
+		redacted[i] = e
+	}
+	return redacted
+}
+
+func (s redactedSpan) redact(attrs []attribute.KeyValue) []attribute.KeyValue {
+	var kept []attribute.KeyValue
+	for _, kv := range attrs {
+		if _, ok := s.denied[kv.Key]; !ok {
+			kept = append(kept, kv)
+		}
+	}
+	return kept
+}
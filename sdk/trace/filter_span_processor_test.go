@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newFilteredProvider(opts ...sdktrace.FilterProcessorOption) (*sdktrace.TracerProvider, *tracetest.InMemoryExporter) {
+	exp := tracetest.NewInMemoryExporter()
+	fsp := sdktrace.NewFilterProcessor(sdktrace.NewSimpleSpanProcessor(exp), opts...)
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(fsp)), exp
+}
+
+func TestFilterProcessorDrop(t *testing.T) {
+	tp, exp := newFilteredProvider(
+		sdktrace.WithDropFilter(sdktrace.SpanNameMatches(regexp.MustCompile(`^/health`))),
+		sdktrace.WithDropFilter(sdktrace.SpanHasAttribute(attribute.String("synthetic", "true"))),
+	)
+	tr := tp.Tracer("TestFilterProcessorDrop")
+	ctx := context.Background()
+
+	for _, name := range []string{"/healthz", "/api", "/health/ready"} {
+		_, span := tr.Start(ctx, name)
+		span.End()
+	}
+	_, span := tr.Start(ctx, "/synthetic", trace.WithAttributes(attribute.String("synthetic", "true")))
+	span.End()
+	_, span = tr.Start(ctx, "/real", trace.WithAttributes(attribute.String("synthetic", "false")))
+	span.End()
+
+	assert.Equal(t, []string{"/api", "/real"}, spanNames(exp.GetSpans()))
+}
+
+func TestFilterProcessorDropStatus(t *testing.T) {
+	tp, exp := newFilteredProvider(sdktrace.WithDropFilter(sdktrace.SpanStatusIs(codes.Ok)))
+	tr := tp.Tracer("TestFilterProcessorDropStatus")
+
+	_, ok := tr.Start(context.Background(), "ok")
+	ok.SetStatus(codes.Ok, "")
+	ok.End()
+	_, failed := tr.Start(context.Background(), "failed")
+	failed.SetStatus(codes.Error, "boom")
+	failed.End()
+
+	assert.Equal(t, []string{"failed"}, spanNames(exp.GetSpans()))
+}
+
+func TestFilterProcessorDropShortSpans(t *testing.T) {
+	var counter sdktrace.SpanDropCounter
+	var dropped []string
+	tp, exp := newFilteredProvider(
+		sdktrace.WithDropFilter(sdktrace.AllSpanPredicates(
+			sdktrace.SpanNameMatches(regexp.MustCompile(`^/health`)),
+			sdktrace.SpanDurationBelow(time.Millisecond),
+		)),
+		sdktrace.WithSpanDropCounter(&counter),
+		sdktrace.WithFilterDropCallback(func(s sdktrace.ReadOnlySpan) {
+			dropped = append(dropped, s.Name())
+		}),
+	)
+	tr := tp.Tracer("TestFilterProcessorDropShortSpans")
+	start := time.Now()
+
+	for _, tc := range []struct {
+		name     string
+		duration time.Duration
+	}{
+		{"/health", 100 * time.Microsecond},
+		{"/health", 2 * time.Millisecond},
+		{"/api", 100 * time.Microsecond},
+		{"/healthz", 0},
+	} {
+		_, span := tr.Start(context.Background(), tc.name, trace.WithTimestamp(start))
+		span.End(trace.WithTimestamp(start.Add(tc.duration)))
+	}
+
+	assert.Equal(t, []string{"/health", "/api"}, spanNames(exp.GetSpans()))
+	assert.Equal(t, uint64(2), counter.Dropped())
+	assert.Equal(t, []string{"/health", "/healthz"}, dropped)
+}
+
+func TestFilterProcessorRedaction(t *testing.T) {
+	tp, exp := newFilteredProvider(
+		sdktrace.WithRedaction(nil, "user.email"),
+		sdktrace.WithRedaction(sdktrace.SpanNameMatches(regexp.MustCompile(`^http`)), "http.url"),
+	)
+	tr := tp.Tracer("TestFilterProcessorRedaction")
+	attrs := trace.WithAttributes(
+		attribute.String("user.email", "a@example.com"),
+		attribute.String("http.url", "/?token=secret"),
+		attribute.Int("kept", 1),
+	)
+
+	_, span := tr.Start(context.Background(), "http.request", attrs)
+	span.AddEvent("login", trace.WithAttributes(attribute.String("user.email", "a@example.com"), attribute.Int("kept", 2)))
+	span.End()
+	_, span = tr.Start(context.Background(), "db.query", attrs)
+	span.End()
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 2)
+	assert.ElementsMatch(t, []attribute.KeyValue{attribute.Int("kept", 1)}, spans[0].Attributes)
+	require.Len(t, spans[0].MessageEvents, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("kept", 2)}, spans[0].MessageEvents[0].Attributes)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("http.url", "/?token=secret"),
+		attribute.Int("kept", 1),
+	}, spans[1].Attributes)
+}
+
+type countingSpanProcessor struct {
+	starts, endings, ends, flushes, shutdowns int
+}
+
+func (p *countingSpanProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) { p.starts++ }
+func (p *countingSpanProcessor) OnEnding(sdktrace.ReadWriteSpan)                 { p.endings++ }
+func (p *countingSpanProcessor) OnEnd(sdktrace.ReadOnlySpan)                     { p.ends++ }
+func (p *countingSpanProcessor) ForceFlush(context.Context) error {
+	p.flushes++
+	return nil
+}
+func (p *countingSpanProcessor) Shutdown(context.Context) error {
+	p.shutdowns++
+	return nil
+}
+
+func TestFilterProcessorPassesThrough(t *testing.T) {
+	next := &countingSpanProcessor{}
+	fsp := sdktrace.NewFilterProcessor(next, sdktrace.WithDropFilter(func(sdktrace.ReadOnlySpan) bool { return true }))
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(fsp))
+
+	_, span := tp.Tracer("TestFilterProcessorPassesThrough").Start(context.Background(), "span")
+	span.End()
+	require.NoError(t, tp.ForceFlush(context.Background()))
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	assert.Equal(t, &countingSpanProcessor{starts: 1, endings: 1, ends: 0, flushes: 1, shutdowns: 1}, next)
+}
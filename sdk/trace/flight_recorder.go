@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultFlightRecorderMaxSpans = 2048
+)
+
+type FlightRecorderOption func(o *FlightRecorderOptions)
+
+type FlightRecorderOptions struct {
+	// MaxSpans is the maximum number of ended spans held in memory. Once
+	// it is reached the oldest span is discarded for every new span.
+	// The default value of MaxSpans is 2048.
+	MaxSpans int
+
+	// MaxAge is the maximum time since a span ended for it to be included
+	// in a dump. Spans older than this are discarded. A value of zero or
+	// less means spans are only discarded once MaxSpans is reached.
+	// The default value of MaxAge is zero.
+	MaxAge time.Duration
+}
+
+// FlightRecorder is a SpanProcessor that keeps the most recently ended
+// spans in a bounded in-memory ring buffer, regardless of whether they were
+// sampled, until they are dumped on demand. This allows traces for the
+// period leading up to an incident to be exported retroactively.
+//
+// Spans that are dropped by the Sampler are never seen by a SpanProcessor.
+// Use RecordUnsampled to wrap the configured Sampler so these spans are
+// recorded (but not sampled) and kept by the FlightRecorder.
+type FlightRecorder struct {
+	o FlightRecorderOptions
+
+	mu      sync.Mutex
+	spans   []ReadOnlySpan
+	head    int
+	count   int
+	stopped bool
+}
+
+var _ SpanProcessor = (*FlightRecorder)(nil)
+
+// NewFlightRecorder returns a new FlightRecorder configured with options.
+func NewFlightRecorder(options ...FlightRecorderOption) *FlightRecorder {
+	o := FlightRecorderOptions{
+		MaxSpans: DefaultFlightRecorderMaxSpans,
+	}
+	for _, opt := range options {
+		opt(&o)
+	}
+	if o.MaxSpans <= 0 {
+		o.MaxSpans = DefaultFlightRecorderMaxSpans
+	}
+	return &FlightRecorder{
+		o:     o,
+		spans: make([]ReadOnlySpan, o.MaxSpans),
+	}
+}
+
+// WithMaxRecordedSpans returns a FlightRecorderOption that sets the
+// maximum number of spans held in memory.
+func WithMaxRecordedSpans(size int) FlightRecorderOption {
+	return func(o *FlightRecorderOptions) {
+		o.MaxSpans = size
+	}
+}
+
+// WithMaxRecordedAge returns a FlightRecorderOption that sets the maximum
+// time since a span ended for it to be included in a dump.
+func WithMaxRecordedAge(age time.Duration) FlightRecorderOption {
+	return func(o *FlightRecorderOptions) {
+		o.MaxAge = age
+	}
+}
+
+// OnStart method does nothing.
+func (fr *FlightRecorder) OnStart(context.Context, ReadWriteSpan) {}
+
+// OnEnding method does nothing.
+func (fr *FlightRecorder) OnEnding(ReadWriteSpan) {}
+
+// OnEnd records s, replacing the oldest recorded span if the buffer is full.
+func (fr *FlightRecorder) OnEnd(s ReadOnlySpan) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	if fr.stopped {
+		return
+	}
+	idx := (fr.head + fr.count) % len(fr.spans)
+	fr.spans[idx] = s
+	if fr.count < len(fr.spans) {
+		fr.count++
+	} else {
+		fr.head = (fr.head + 1) % len(fr.spans)
+	}
+}
+
+// Dump exports all recorded spans, oldest first, to exporter and removes
+// them from the FlightRecorder. Spans that ended longer than MaxAge ago are
+// discarded without being exported. The exporter is not shut down.
+func (fr *FlightRecorder) Dump(ctx context.Context, exporter SpanExporter) error {
+	spans := fr.drain(time.Now())
+	if len(spans) == 0 || exporter == nil {
+		return nil
+	}
+	return exporter.ExportSpans(ctx, spans)
+}
+
+// drain returns the recorded spans that ended within MaxAge of now and
+// resets the buffer.
+func (fr *FlightRecorder) drain(now time.Time) []ReadOnlySpan {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	spans := make([]ReadOnlySpan, 0, fr.count)
+	for i := 0; i < fr.count; i++ {
+		idx := (fr.head + i) % len(fr.spans)
+		s := fr.spans[idx]
+		fr.spans[idx] = nil
+		if fr.o.MaxAge > 0 && now.Sub(s.EndTime()) > fr.o.MaxAge {
+			continue
+		}
+		spans = append(spans, s)
+	}
+	fr.head, fr.count = 0, 0
+	return spans
+}
+
+// Shutdown discards all recorded spans. Spans that end after Shutdown has
+// been called are ignored.
+func (fr *FlightRecorder) Shutdown(context.Context) error {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+
+	fr.stopped = true
+	fr.spans = nil
+	fr.head, fr.count = 0, 0
+	return nil
+}
+
+// ForceFlush does nothing. Recorded spans are only exported by Dump.
+func (fr *FlightRecorder) ForceFlush(context.Context) error {
+	return nil
+}
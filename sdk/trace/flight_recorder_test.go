@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func flightRecorderProvider(fr *sdktrace.FlightRecorder) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.RecordUnsampled(sdktrace.NeverSample())),
+		sdktrace.WithSpanProcessor(fr),
+	)
+}
+
+func spanNames(spans tracetest.SpanStubs) []string {
+	names := make([]string, 0, len(spans))
+	for _, s := range spans {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestFlightRecorderKeepsUnsampledSpans(t *testing.T) {
+	fr := sdktrace.NewFlightRecorder(sdktrace.WithMaxRecordedSpans(3))
+	tr := flightRecorderProvider(fr).Tracer("FlightRecorder")
+	for i := 0; i < 5; i++ {
+		_, span := tr.Start(context.Background(), fmt.Sprintf("span%d", i))
+		assert.True(t, span.IsRecording())
+		assert.False(t, span.SpanContext().IsSampled())
+		span.End()
+	}
+
+	exp := tracetest.NewInMemoryExporter()
+	require.NoError(t, fr.Dump(context.Background(), exp))
+	assert.Equal(t, []string{"span2", "span3", "span4"}, spanNames(exp.GetSpans()))
+
+	// Dumped spans are removed.
+	exp.Reset()
+	require.NoError(t, fr.Dump(context.Background(), exp))
+	assert.Len(t, exp.GetSpans(), 0)
+}
+
+func TestFlightRecorderMaxAge(t *testing.T) {
+	fr := sdktrace.NewFlightRecorder(sdktrace.WithMaxRecordedAge(time.Minute))
+	tr := flightRecorderProvider(fr).Tracer("FlightRecorder")
+
+	_, span := tr.Start(context.Background(), "old", trace.WithTimestamp(time.Now().Add(-time.Hour)))
+	span.End(trace.WithTimestamp(time.Now().Add(-2 * time.Minute)))
+	_, span = tr.Start(context.Background(), "new")
+	span.End()
+
+	exp := tracetest.NewInMemoryExporter()
+	require.NoError(t, fr.Dump(context.Background(), exp))
+	assert.Equal(t, []string{"new"}, spanNames(exp.GetSpans()))
+}
+
+func TestFlightRecorderShutdown(t *testing.T) {
+	fr := sdktrace.NewFlightRecorder()
+	tr := flightRecorderProvider(fr).Tracer("FlightRecorder")
+
+	_, span := tr.Start(context.Background(), "before")
+	span.End()
+	require.NoError(t, fr.Shutdown(context.Background()))
+	_, span = tr.Start(context.Background(), "after")
+	span.End()
+
+	exp := tracetest.NewInMemoryExporter()
+	require.NoError(t, fr.Dump(context.Background(), exp))
+	assert.Len(t, exp.GetSpans(), 0)
+}
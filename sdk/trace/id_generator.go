@@ -20,6 +20,7 @@ import (
 	"encoding/binary"
 	"math/rand"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel/trace"
 )
@@ -30,12 +31,32 @@ type IDGenerator interface {
 	NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID
 }
 
+// RandomTraceIDGenerator is an IDGenerator that can guarantee at least the
+// right-most 7 bytes of every trace ID it generates are random, as required
+// by W3C Trace Context Level 2. Root spans started with such a generator
+// have the trace.FlagsRandom bit set in their SpanContext if
+// RandomTraceIDs returns true.
+//
+// The default IDGenerator and the one returned by NewXRayIDGenerator
+// implement it.
+type RandomTraceIDGenerator interface {
+	IDGenerator
+
+	// RandomTraceIDs returns if the generated trace IDs are random.
+	RandomTraceIDs() bool
+}
+
 type randomIDGenerator struct {
 	sync.Mutex
 	randSource *rand.Rand
 }
 
 var _ IDGenerator = &randomIDGenerator{}
+var _ RandomTraceIDGenerator = &randomIDGenerator{}
+
+// RandomTraceIDs returns true, every byte of the generated trace IDs is
+// random.
+func (gen *randomIDGenerator) RandomTraceIDs() bool { return true }
 
 // NewSpanID returns a non-zero span ID from a randomly-chosen sequence.
 func (gen *randomIDGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
@@ -65,3 +86,56 @@ func defaultIDGenerator() IDGenerator {
 	gen.randSource = rand.New(rand.NewSource(rngSeed))
 	return gen
 }
+
+// xrayIDGenerator generates AWS X-Ray compatible IDs. The first 4 bytes of
+// a trace ID are the big-endian Unix epoch time, in seconds, of the trace
+// start and the remaining bytes are random.
+type xrayIDGenerator struct {
+	sync.Mutex
+	randSource *rand.Rand
+	now        func() time.Time
+}
+
+var _ IDGenerator = &xrayIDGenerator{}
+var _ RandomTraceIDGenerator = &xrayIDGenerator{}
+
+// RandomTraceIDs returns true, the right-most 12 bytes of the generated
+// trace IDs are random.
+func (gen *xrayIDGenerator) RandomTraceIDs() bool { return true }
+
+// NewSpanID returns a non-zero span ID from a randomly-chosen sequence.
+func (gen *xrayIDGenerator) NewSpanID(ctx context.Context, traceID trace.TraceID) trace.SpanID {
+	gen.Lock()
+	defer gen.Unlock()
+	sid := trace.SpanID{}
+	gen.randSource.Read(sid[:])
+	return sid
+}
+
+// NewIDs returns a timestamp-prefixed trace ID and a non-zero span ID from
+// a randomly-chosen sequence.
+func (gen *xrayIDGenerator) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+	gen.Lock()
+	defer gen.Unlock()
+	tid := trace.TraceID{}
+	binary.BigEndian.PutUint32(tid[0:4], uint32(gen.now().Unix()))
+	gen.randSource.Read(tid[4:])
+	sid := trace.SpanID{}
+	gen.randSource.Read(sid[:])
+	return tid, sid
+}
+
+// NewXRayIDGenerator returns an IDGenerator producing trace IDs accepted by
+// AWS X-Ray: the high 4 bytes of each trace ID hold the time the trace was
+// started, in seconds since the Unix epoch, and the remaining 12 bytes are
+// random. Span IDs are random.
+//
+// Use it with the WithIDGenerator TracerProviderOption so spans exported to
+// X-Ray are accepted without remapping their trace IDs.
+func NewXRayIDGenerator() IDGenerator {
+	gen := &xrayIDGenerator{now: time.Now}
+	var rngSeed int64
+	_ = binary.Read(crand.Reader, binary.LittleEndian, &rngSeed)
+	gen.randSource = rand.New(rand.NewSource(rngSeed))
+	return gen
+}
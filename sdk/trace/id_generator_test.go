@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestXRayIDGenerator(t *testing.T) {
+	start := time.Unix(1617000000, 0)
+	gen := &xrayIDGenerator{
+		randSource: rand.New(rand.NewSource(1)),
+		now:        func() time.Time { return start },
+	}
+
+	tid, sid := gen.NewIDs(context.Background())
+	assert.Equal(t, uint32(start.Unix()), binary.BigEndian.Uint32(tid[0:4]))
+	assert.True(t, tid.IsValid())
+	assert.True(t, sid.IsValid())
+
+	other, _ := gen.NewIDs(context.Background())
+	assert.Equal(t, tid[0:4], other[0:4], "trace IDs started in the same second share the prefix")
+	assert.NotEqual(t, tid[4:], other[4:])
+
+	assert.NotEqual(t, sid, gen.NewSpanID(context.Background(), tid))
+}
+
+func TestXRayIDGeneratorWithTracerProvider(t *testing.T) {
+	before := time.Now().Unix()
+	tp := NewTracerProvider(WithIDGenerator(NewXRayIDGenerator()))
+	_, span := tp.Tracer("TestXRayIDGenerator").Start(context.Background(), "span")
+	after := time.Now().Unix()
+
+	tid := span.SpanContext().TraceID()
+	ts := int64(binary.BigEndian.Uint32(tid[0:4]))
+	assert.GreaterOrEqual(t, ts, before)
+	assert.LessOrEqual(t, ts, after)
+}
+
+func TestRandomTraceIDFlag(t *testing.T) {
+	ctx := context.Background()
+
+	tp := NewTracerProvider()
+	ctx, root := tp.Tracer("TestRandomTraceIDFlag").Start(ctx, "root")
+	assert.True(t, root.SpanContext().IsRandom(), "default IDGenerator asserts randomness")
+	_, child := tp.Tracer("TestRandomTraceIDFlag").Start(ctx, "child")
+	assert.True(t, child.SpanContext().IsRandom(), "children inherit the flag")
+
+	tp = NewTracerProvider(WithIDGenerator(NewXRayIDGenerator()))
+	_, root = tp.Tracer("TestRandomTraceIDFlag").Start(context.Background(), "root")
+	assert.True(t, root.SpanContext().IsRandom(), "X-Ray IDGenerator asserts randomness")
+
+	tp = NewTracerProvider(WithIDGenerator(&testIDGenerator{traceID: 1, spanID: 1}))
+	_, root = tp.Tracer("TestRandomTraceIDFlag").Start(context.Background(), "root")
+	assert.False(t, root.SpanContext().IsRandom(), "custom IDGenerator does not assert randomness")
+
+	for _, random := range []bool{true, false} {
+		tp = NewTracerProvider(WithIDGenerator(randomTestIDGenerator{
+			IDGenerator: defaultIDGenerator(),
+			random:      random,
+		}))
+		_, root = tp.Tracer("TestRandomTraceIDFlag").Start(context.Background(), "root")
+		assert.Equal(t, random, root.SpanContext().IsRandom(), "custom RandomTraceIDGenerator")
+	}
+}
+
+type randomTestIDGenerator struct {
+	IDGenerator
+	random bool
+}
+
+func (gen randomTestIDGenerator) RandomTraceIDs() bool { return gen.random }
+
+func TestRandomTraceIDFlagFromRemoteParent(t *testing.T) {
+	tp := NewTracerProvider()
+	for _, flags := range []byte{0, trace.FlagsRandom} {
+		psc := trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{1},
+			SpanID:     trace.SpanID{1},
+			TraceFlags: flags | trace.FlagsSampled,
+			Remote:     true,
+		})
+		ctx := trace.ContextWithRemoteSpanContext(context.Background(), psc)
+		_, span := tp.Tracer("TestRandomTraceIDFlagFromRemoteParent").Start(ctx, "span")
+		assert.Equal(t, psc.IsRandom(), span.SpanContext().IsRandom())
+	}
+}
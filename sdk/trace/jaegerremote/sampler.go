@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package jaegerremote provides a Sampler that applies the sampling
+// strategies served by a Jaeger agent or collector.
+package jaegerremote // import "go.opentelemetry.io/otel/sdk/trace/jaegerremote"
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// DefaultSamplingServerURL is the URL of the sampling endpoint of a
+	// Jaeger agent running on the local host.
+	DefaultSamplingServerURL = "http://localhost:5778/sampling"
+	// DefaultRefreshInterval is the interval between two fetches of the
+	// sampling strategy.
+	DefaultRefreshInterval = time.Minute
+	// DefaultMaxOperations is the maximum number of operations the
+	// per-operation sampling strategy is applied to.
+	DefaultMaxOperations = 2000
+)
+
+// Option applies an option to the configuration of a Sampler.
+type Option func(*config)
+
+type config struct {
+	serverURL       string
+	refreshInterval time.Duration
+	initialSampler  sdktrace.Sampler
+	maxOperations   int
+	httpClient      *http.Client
+}
+
+// WithSamplingServerURL sets the URL of the endpoint serving the sampling
+// strategies. The default is DefaultSamplingServerURL.
+func WithSamplingServerURL(serverURL string) Option {
+	return func(c *config) {
+		c.serverURL = serverURL
+	}
+}
+
+// WithRefreshInterval sets the interval between two fetches of the
+// sampling strategy. The default, also used for a non-positive interval,
+// is DefaultRefreshInterval.
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.refreshInterval = interval
+	}
+}
+
+// WithInitialSampler sets the Sampler used until a sampling strategy has
+// been fetched. The default samples 0.1% of traces.
+func WithInitialSampler(s sdktrace.Sampler) Option {
+	return func(c *config) {
+		c.initialSampler = s
+	}
+}
+
+// WithMaxOperations sets the maximum number of operations the
+// per-operation sampling strategy is applied to. Spans of other operations
+// are sampled with the default strategy. The default, also used for a
+// non-positive n, is DefaultMaxOperations.
+func WithMaxOperations(n int) Option {
+	return func(c *config) {
+		c.maxOperations = n
+	}
+}
+
+// WithHTTPClient sets the client used to fetch the sampling strategy. The
+// default is http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *config) {
+		c.httpClient = client
+	}
+}
+
+// Sampler is a Sampler that periodically fetches the sampling strategy of
+// a service from a Jaeger agent or collector and applies it.
+//
+// Probabilistic, rate limiting and per-operation strategies are supported.
+// Per-operation strategies are matched against span names. The fetched
+// strategy applies to all spans, to respect the sampling decision of the
+// parent of a span use the Sampler as the root of a ParentBased sampler.
+type Sampler struct {
+	serviceName string
+	cfg         config
+
+	mu      sync.RWMutex
+	sampler sdktrace.Sampler
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	doneCh   chan struct{}
+}
+
+var _ sdktrace.Sampler = (*Sampler)(nil)
+
+// New returns a Sampler applying the sampling strategy of serviceName. The
+// strategy is fetched in the background, until it is first fetched the
+// initial sampler is used. Close must be called to stop fetching.
+func New(serviceName string, opts ...Option) *Sampler {
+	s := newSampler(serviceName, opts...)
+	go s.poll()
+	return s
+}
+
+func newSampler(serviceName string, opts ...Option) *Sampler {
+	cfg := config{
+		serverURL:       DefaultSamplingServerURL,
+		refreshInterval: DefaultRefreshInterval,
+		initialSampler:  sdktrace.TraceIDRatioBased(0.001),
+		maxOperations:   DefaultMaxOperations,
+		httpClient:      http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.refreshInterval <= 0 {
+		cfg.refreshInterval = DefaultRefreshInterval
+	}
+	if cfg.maxOperations <= 0 {
+		cfg.maxOperations = DefaultMaxOperations
+	}
+	return &Sampler{
+		serviceName: serviceName,
+		cfg:         cfg,
+		sampler:     cfg.initialSampler,
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+}
+
+// ShouldSample returns the sampling decision of the current sampling
+// strategy.
+func (s *Sampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	s.mu.RLock()
+	sampler := s.sampler
+	s.mu.RUnlock()
+	return sampler.ShouldSample(p)
+}
+
+// Description returns the description of the Sampler.
+func (s *Sampler) Description() string {
+	s.mu.RLock()
+	sampler := s.sampler
+	s.mu.RUnlock()
+	return fmt.Sprintf("JaegerRemoteSampler{%s}", sampler.Description())
+}
+
+// Close stops fetching the sampling strategy. The last fetched strategy
+// continues to be applied.
+func (s *Sampler) Close() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+		<-s.doneCh
+	})
+}
+
+// poll updates the sampling strategy every refresh interval until the
+// Sampler is closed. Failures to update are reported to the global error
+// handler and the current strategy is kept.
+func (s *Sampler) poll() {
+	defer close(s.doneCh)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-s.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(s.cfg.refreshInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.update(ctx); err != nil && ctx.Err() == nil {
+			otel.HandleSignal(otel.TracesSignal, err)
+		}
+		select {
+		case <-s.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// update fetches the sampling strategy and replaces the current sampler.
+func (s *Sampler) update(ctx context.Context) error {
+	strategy, err := s.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	sampler, err := newSamplerFromStrategy(strategy, s.cfg.maxOperations)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.sampler = sampler
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Sampler) fetch(ctx context.Context) (*samplingStrategyResponse, error) {
+	u, err := url.Parse(s.cfg.serverURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sampling server URL: %w", err)
+	}
+	q := u.Query()
+	q.Set("service", s.serviceName)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.cfg.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sampling strategy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sampling strategy: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch sampling strategy: %s: %s", resp.Status, body)
+	}
+	return parseStrategy(body)
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type strategyServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	strategy string
+	status   int
+	services []string
+}
+
+func newStrategyServer(strategy string) *strategyServer {
+	ss := &strategyServer{strategy: strategy, status: http.StatusOK}
+	ss.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ss.mu.Lock()
+		defer ss.mu.Unlock()
+		ss.services = append(ss.services, r.URL.Query().Get("service"))
+		w.WriteHeader(ss.status)
+		_, _ = w.Write([]byte(ss.strategy))
+	}))
+	return ss
+}
+
+func (ss *strategyServer) set(status int, strategy string) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	ss.status, ss.strategy = status, strategy
+}
+
+func TestSamplerUpdate(t *testing.T) {
+	srv := newStrategyServer(`{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":0.5}}`)
+	defer srv.Close()
+
+	s := newSampler("my-service", WithSamplingServerURL(srv.URL), WithInitialSampler(sdktrace.NeverSample()))
+	assert.Equal(t, "JaegerRemoteSampler{AlwaysOffSampler}", s.Description())
+
+	require.NoError(t, s.update(context.Background()))
+	assert.Equal(t, "JaegerRemoteSampler{TraceIDRatioBased{0.5}}", s.Description())
+	assert.Equal(t, []string{"my-service"}, srv.services)
+
+	// Failures keep the current strategy.
+	srv.set(http.StatusInternalServerError, "failure")
+	assert.Error(t, s.update(context.Background()))
+	srv.set(http.StatusOK, `{"strategyType":"RATE_LIMITING"}`)
+	assert.Error(t, s.update(context.Background()))
+	assert.Equal(t, "JaegerRemoteSampler{TraceIDRatioBased{0.5}}", s.Description())
+
+	srv.set(http.StatusOK, `{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":1}}`)
+	require.NoError(t, s.update(context.Background()))
+	assert.True(t, sampled(s, "op"))
+}
+
+func TestSamplerInitialSampler(t *testing.T) {
+	s := newSampler("my-service")
+	assert.Equal(t, "JaegerRemoteSampler{TraceIDRatioBased{0.001}}", s.Description())
+}
+
+func TestSamplerInvalidOptions(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		s := newSampler(
+			"my-service",
+			WithRefreshInterval(time.Duration(n)),
+			WithMaxOperations(n),
+		)
+		assert.Equal(t, DefaultRefreshInterval, s.cfg.refreshInterval)
+		assert.Equal(t, DefaultMaxOperations, s.cfg.maxOperations)
+	}
+
+	// A non-positive refresh interval must not panic the poller.
+	s := New("my-service", WithRefreshInterval(0), WithSamplingServerURL("http://localhost:0"))
+	s.Close()
+}
+
+func TestSamplerPolls(t *testing.T) {
+	srv := newStrategyServer(`{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":1}}`)
+	defer srv.Close()
+
+	s := New(
+		"my-service",
+		WithSamplingServerURL(srv.URL),
+		WithRefreshInterval(time.Millisecond),
+		WithInitialSampler(sdktrace.NeverSample()),
+	)
+	defer s.Close()
+
+	assert.Eventually(t, func() bool {
+		return sampled(s, "op")
+	}, time.Second, time.Millisecond)
+
+	srv.set(http.StatusOK, `{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":0}}`)
+	assert.Eventually(t, func() bool {
+		return !sampled(s, "op")
+	}, time.Second, time.Millisecond)
+
+	s.Close()
+	// Closing more than once does not block.
+	s.Close()
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote // import "go.opentelemetry.io/otel/sdk/trace/jaegerremote"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// strategyType is the type of sampling strategy returned by the sampling
+// server. It is encoded either as a name or, by older servers, as the
+// value of the Thrift enumeration.
+type strategyType int
+
+const (
+	probabilisticStrategy strategyType = iota
+	rateLimitingStrategy
+)
+
+func (t *strategyType) UnmarshalJSON(data []byte) error {
+	data = bytes.Trim(data, `"`)
+	switch string(data) {
+	case "PROBABILISTIC", "0":
+		*t = probabilisticStrategy
+	case "RATE_LIMITING", "1":
+		*t = rateLimitingStrategy
+	default:
+		return fmt.Errorf("unknown sampling strategy type: %s", data)
+	}
+	return nil
+}
+
+// samplingStrategyResponse is the sampling strategy of a service as
+// described by the Jaeger sampling API.
+type samplingStrategyResponse struct {
+	StrategyType          strategyType                    `json:"strategyType"`
+	ProbabilisticSampling *probabilisticSamplingStrategy  `json:"probabilisticSampling,omitempty"`
+	RateLimitingSampling  *rateLimitingSamplingStrategy   `json:"rateLimitingSampling,omitempty"`
+	OperationSampling     *perOperationSamplingStrategies `json:"operationSampling,omitempty"`
+}
+
+type probabilisticSamplingStrategy struct {
+	SamplingRate float64 `json:"samplingRate"`
+}
+
+type rateLimitingSamplingStrategy struct {
+	MaxTracesPerSecond float64 `json:"maxTracesPerSecond"`
+}
+
+type perOperationSamplingStrategies struct {
+	DefaultSamplingProbability       float64                     `json:"defaultSamplingProbability"`
+	DefaultLowerBoundTracesPerSecond float64                     `json:"defaultLowerBoundTracesPerSecond"`
+	PerOperationStrategies           []operationSamplingStrategy `json:"perOperationStrategies"`
+}
+
+type operationSamplingStrategy struct {
+	Operation             string                         `json:"operation"`
+	ProbabilisticSampling *probabilisticSamplingStrategy `json:"probabilisticSampling"`
+}
+
+// parseStrategy decodes a sampling strategy response.
+func parseStrategy(data []byte) (*samplingStrategyResponse, error) {
+	var s samplingStrategyResponse
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid sampling strategy: %w", err)
+	}
+	return &s, nil
+}
+
+// newSamplerFromStrategy returns the Sampler applying the sampling strategy
+// s. At most maxOperations per-operation samplers are created.
+func newSamplerFromStrategy(s *samplingStrategyResponse, maxOperations int) (sdktrace.Sampler, error) {
+	if s.OperationSampling != nil {
+		return newPerOperationSampler(s.OperationSampling, maxOperations), nil
+	}
+	switch s.StrategyType {
+	case probabilisticStrategy:
+		if s.ProbabilisticSampling == nil {
+			return nil, fmt.Errorf("probabilistic sampling strategy without sampling rate")
+		}
+		return sdktrace.TraceIDRatioBased(s.ProbabilisticSampling.SamplingRate), nil
+	case rateLimitingStrategy:
+		if s.RateLimitingSampling == nil {
+			return nil, fmt.Errorf("rate limiting sampling strategy without rate")
+		}
+		return sdktrace.RateLimited(s.RateLimitingSampling.MaxTracesPerSecond), nil
+	}
+	return nil, fmt.Errorf("unknown sampling strategy type: %d", s.StrategyType)
+}
+
+// guaranteedThroughputSampler samples a fraction of traces and, regardless
+// of the fraction, at least lowerBound traces per second.
+type guaranteedThroughputSampler struct {
+	probabilistic sdktrace.Sampler
+	lowerBound    sdktrace.Sampler
+}
+
+func newGuaranteedThroughputSampler(rate, lowerBound float64) *guaranteedThroughputSampler {
+	return &guaranteedThroughputSampler{
+		probabilistic: sdktrace.TraceIDRatioBased(rate),
+		lowerBound:    sdktrace.RateLimited(lowerBound),
+	}
+}
+
+func (gs *guaranteedThroughputSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	result := gs.probabilistic.ShouldSample(p)
+	if result.Decision == sdktrace.RecordAndSample {
+		// Keep the lower bound balance in sync so bursts of probabilistic
+		// samples do not add up with the guaranteed throughput.
+		gs.lowerBound.ShouldSample(p)
+		return result
+	}
+	return gs.lowerBound.ShouldSample(p)
+}
+
+func (gs *guaranteedThroughputSampler) Description() string {
+	return fmt.Sprintf(
+		"GuaranteedThroughputSampler{%s,%s}",
+		gs.probabilistic.Description(),
+		gs.lowerBound.Description(),
+	)
+}
+
+// perOperationSampler applies a different sampler to the spans of each
+// operation, identified by the span name.
+type perOperationSampler struct {
+	defaultSampler sdktrace.Sampler
+	operations     map[string]sdktrace.Sampler
+}
+
+func newPerOperationSampler(s *perOperationSamplingStrategies, maxOperations int) *perOperationSampler {
+	ps := &perOperationSampler{
+		defaultSampler: newGuaranteedThroughputSampler(
+			s.DefaultSamplingProbability,
+			s.DefaultLowerBoundTracesPerSecond,
+		),
+		operations: make(map[string]sdktrace.Sampler),
+	}
+	for _, op := range s.PerOperationStrategies {
+		if len(ps.operations) >= maxOperations {
+			break
+		}
+		if op.ProbabilisticSampling == nil {
+			continue
+		}
+		ps.operations[op.Operation] = newGuaranteedThroughputSampler(
+			op.ProbabilisticSampling.SamplingRate,
+			s.DefaultLowerBoundTracesPerSecond,
+		)
+	}
+	return ps
+}
+
+func (ps *perOperationSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if s, ok := ps.operations[p.Name]; ok {
+		return s.ShouldSample(p)
+	}
+	return ps.defaultSampler.ShouldSample(p)
+}
+
+func (ps *perOperationSampler) Description() string {
+	return fmt.Sprintf("PerOperationSampler{default:%s,operations:%d}", ps.defaultSampler.Description(), len(ps.operations))
+}
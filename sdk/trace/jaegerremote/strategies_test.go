@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func sampled(s sdktrace.Sampler, name string) bool {
+	p := sdktrace.SamplingParameters{Name: name}
+	return s.ShouldSample(p).Decision == sdktrace.RecordAndSample
+}
+
+func TestParseStrategy(t *testing.T) {
+	for _, data := range []string{
+		`{"strategyType":"PROBABILISTIC","probabilisticSampling":{"samplingRate":0.5}}`,
+		`{"strategyType":0,"probabilisticSampling":{"samplingRate":0.5}}`,
+	} {
+		s, err := parseStrategy([]byte(data))
+		require.NoError(t, err, data)
+		assert.Equal(t, probabilisticStrategy, s.StrategyType)
+		assert.Equal(t, 0.5, s.ProbabilisticSampling.SamplingRate)
+	}
+
+	s, err := parseStrategy([]byte(`{"strategyType":"RATE_LIMITING","rateLimitingSampling":{"maxTracesPerSecond":10}}`))
+	require.NoError(t, err)
+	assert.Equal(t, rateLimitingStrategy, s.StrategyType)
+	assert.Equal(t, 10.0, s.RateLimitingSampling.MaxTracesPerSecond)
+
+	_, err = parseStrategy([]byte(`{"strategyType":"UNKNOWN"}`))
+	assert.Error(t, err)
+	_, err = parseStrategy([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestProbabilisticStrategy(t *testing.T) {
+	s, err := newSamplerFromStrategy(&samplingStrategyResponse{
+		StrategyType:          probabilisticStrategy,
+		ProbabilisticSampling: &probabilisticSamplingStrategy{SamplingRate: 0.25},
+	}, DefaultMaxOperations)
+	require.NoError(t, err)
+	assert.Equal(t, "TraceIDRatioBased{0.25}", s.Description())
+
+	_, err = newSamplerFromStrategy(&samplingStrategyResponse{
+		StrategyType: probabilisticStrategy,
+	}, DefaultMaxOperations)
+	assert.Error(t, err)
+}
+
+func TestRateLimitingStrategy(t *testing.T) {
+	s, err := newSamplerFromStrategy(&samplingStrategyResponse{
+		StrategyType:         rateLimitingStrategy,
+		RateLimitingSampling: &rateLimitingSamplingStrategy{MaxTracesPerSecond: 2},
+	}, DefaultMaxOperations)
+	require.NoError(t, err)
+	assert.Equal(t, sdktrace.RateLimited(2).Description(), s.Description())
+
+	// The balance starts with one second worth of traces.
+	assert.True(t, sampled(s, "op"))
+	assert.True(t, sampled(s, "op"))
+	assert.False(t, sampled(s, "op"))
+
+	_, err = newSamplerFromStrategy(&samplingStrategyResponse{
+		StrategyType: rateLimitingStrategy,
+	}, DefaultMaxOperations)
+	assert.Error(t, err)
+}
+
+func TestZeroRateLimitingStrategy(t *testing.T) {
+	s, err := newSamplerFromStrategy(&samplingStrategyResponse{
+		StrategyType:         rateLimitingStrategy,
+		RateLimitingSampling: &rateLimitingSamplingStrategy{MaxTracesPerSecond: 0},
+	}, DefaultMaxOperations)
+	require.NoError(t, err)
+	assert.False(t, sampled(s, "op"))
+}
+
+func TestPerOperationStrategy(t *testing.T) {
+	strategy := &samplingStrategyResponse{
+		StrategyType: probabilisticStrategy,
+		OperationSampling: &perOperationSamplingStrategies{
+			DefaultSamplingProbability:       0,
+			DefaultLowerBoundTracesPerSecond: 1,
+			PerOperationStrategies: []operationSamplingStrategy{
+				{
+					Operation:             "always",
+					ProbabilisticSampling: &probabilisticSamplingStrategy{SamplingRate: 1},
+				},
+				{
+					Operation:             "ignored",
+					ProbabilisticSampling: &probabilisticSamplingStrategy{SamplingRate: 1},
+				},
+			},
+		},
+	}
+	s, err := newSamplerFromStrategy(strategy, 1)
+	require.NoError(t, err)
+
+	for i := 0; i < 10; i++ {
+		assert.True(t, sampled(s, "always"))
+	}
+
+	// Other operations, including the one exceeding the maximum number
+	// of operations, are only sampled at the lower bound rate.
+	assert.True(t, sampled(s, "ignored"))
+	assert.False(t, sampled(s, "ignored"))
+	assert.False(t, sampled(s, "other"))
+}
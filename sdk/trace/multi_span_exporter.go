@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// multiSpanExporter is a SpanExporter that exports every batch to multiple
+// SpanExporters concurrently.
+type multiSpanExporter struct {
+	exporters []SpanExporter
+}
+
+var _ SpanExporter = (*multiSpanExporter)(nil)
+
+// NewMultiSpanExporter returns a SpanExporter that exports every batch of
+// spans to all exporters concurrently. This allows the spans of a single
+// SpanProcessor, and its memory, to be shared by several exporters, e.g. to
+// write to two backends during a migration.
+//
+// The exporters are independent: a failing or slow exporter does not
+// prevent the others from exporting. ExportSpans and Shutdown return once all
+// exporters have returned, with an error describing every exporter that
+// failed, if any. The passed spans are shared by all exporters and must not
+// be modified by them.
+func NewMultiSpanExporter(exporters ...SpanExporter) SpanExporter {
+	var exps []SpanExporter
+	for _, e := range exporters {
+		if e != nil {
+			exps = append(exps, e)
+		}
+	}
+	return &multiSpanExporter{exporters: exps}
+}
+
+// ExportSpans exports spans to all exporters concurrently.
+func (m *multiSpanExporter) ExportSpans(ctx context.Context, spans []ReadOnlySpan) error {
+	return m.each(func(e SpanExporter) error {
+		return e.ExportSpans(ctx, spans)
+	})
+}
+
+// Shutdown shuts down all exporters concurrently.
+func (m *multiSpanExporter) Shutdown(ctx context.Context) error {
+	return m.each(func(e SpanExporter) error {
+		return e.Shutdown(ctx)
+	})
+}
+
+// each calls f for every exporter concurrently and returns the combined
+// errors of all calls.
+func (m *multiSpanExporter) each(f func(SpanExporter) error) error {
+	if len(m.exporters) == 1 {
+		return f(m.exporters[0])
+	}
+
+	errs := make([]error, len(m.exporters))
+	var wg sync.WaitGroup
+	wg.Add(len(m.exporters))
+	for i, e := range m.exporters {
+		go func(i int, e SpanExporter) {
+			defer wg.Done()
+			errs[i] = f(e)
+		}(i, e)
+	}
+	wg.Wait()
+
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("exporter %d: %v", i, err))
+		}
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d span exporters failed: %s", len(failed), len(m.exporters), strings.Join(failed, "; "))
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type failingExporter struct {
+	err      error
+	release  chan struct{}
+	shutdown bool
+}
+
+func (e *failingExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	if e.release != nil {
+		<-e.release
+	}
+	return e.err
+}
+
+func (e *failingExporter) Shutdown(context.Context) error {
+	e.shutdown = true
+	return e.err
+}
+
+func TestMultiSpanExporter(t *testing.T) {
+	a, b := tracetest.NewInMemoryExporter(), tracetest.NewInMemoryExporter()
+	exp := sdktrace.NewMultiSpanExporter(a, nil, b)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+
+	_, span := tp.Tracer("TestMultiSpanExporter").Start(context.Background(), "span")
+	span.End()
+
+	assert.Equal(t, []string{"span"}, spanNames(a.GetSpans()))
+	assert.Equal(t, []string{"span"}, spanNames(b.GetSpans()))
+	require.NoError(t, tp.Shutdown(context.Background()))
+}
+
+func TestMultiSpanExporterIndependentErrors(t *testing.T) {
+	mem := tracetest.NewInMemoryExporter()
+	failing := &failingExporter{err: errors.New("unavailable")}
+	exp := sdktrace.NewMultiSpanExporter(failing, mem)
+
+	spans := tracetest.SpanStubs{{Name: "span"}}.Snapshots()
+	err := exp.ExportSpans(context.Background(), spans)
+	require.Error(t, err)
+	assert.Equal(t, "1 of 2 span exporters failed: exporter 0: unavailable", err.Error())
+	// The failing exporter does not prevent the others from exporting.
+	assert.Equal(t, []string{"span"}, spanNames(mem.GetSpans()))
+
+	assert.Error(t, exp.Shutdown(context.Background()))
+	assert.True(t, failing.shutdown)
+}
+
+func TestMultiSpanExporterConcurrent(t *testing.T) {
+	release := make(chan struct{})
+	slow := &failingExporter{release: release}
+	mem := tracetest.NewInMemoryExporter()
+	exp := sdktrace.NewMultiSpanExporter(slow, mem)
+
+	done := make(chan error)
+	go func() {
+		done <- exp.ExportSpans(context.Background(), tracetest.SpanStubs{{Name: "span"}}.Snapshots())
+	}()
+
+	// The fast exporter receives the spans while the slow one is blocked.
+	require.Eventually(t, func() bool { return len(mem.GetSpans()) == 1 }, time.Second, time.Millisecond)
+	close(release)
+	assert.NoError(t, <-done)
+}
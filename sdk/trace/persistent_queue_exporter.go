@@ -0,0 +1,377 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/internal/global"
+)
+
+const (
+	DefaultPersistentQueueMaxBytes      = 64 << 20
+	DefaultPersistentQueueRetryInterval = 5 * time.Second
+	DefaultPersistentQueueExportTimeout = 30 * time.Second
+)
+
+const (
+	persistedBatchExt = ".batch"
+	tempBatchExt      = ".tmp"
+)
+
+type PersistentQueueOption func(o *PersistentQueueOptions)
+
+type PersistentQueueOptions struct {
+	// MaxBytes is the maximum total size of the batches stored on disk.
+	// Once it is exceeded the oldest batches are discarded.
+	// The default value of MaxBytes is 64 MiB.
+	MaxBytes int64
+
+	// RetryInterval is the interval between two attempts to export the
+	// stored batches while no new batch is passed to the queue.
+	// The default value of RetryInterval is 5 seconds.
+	RetryInterval time.Duration
+
+	// ExportTimeout is the timeout of every attempt to export the stored
+	// batches made after the RetryInterval.
+	// The default value of ExportTimeout is 30 seconds.
+	ExportTimeout time.Duration
+}
+
+// queuedBatch is a batch of spans stored in a file of a persistent queue.
+type queuedBatch struct {
+	seq  uint64
+	size int64
+}
+
+// persistentQueueExporter is a SpanExporter storing batches of spans on
+// disk until they are exported to the SpanExporter it wraps.
+type persistentQueueExporter struct {
+	dir  string
+	next SpanExporter
+	o    PersistentQueueOptions
+
+	mu      sync.Mutex
+	batches []queuedBatch
+	size    int64
+	seq     uint64
+
+	// exportSem serializes the exports of the stored batches, it holds
+	// a value while a goroutine exports them.
+	exportSem chan struct{}
+
+	cancel   context.CancelFunc
+	stopWait sync.WaitGroup
+	stopOnce sync.Once
+}
+
+var _ SpanExporter = (*persistentQueueExporter)(nil)
+
+// NewPersistentQueueExporter returns a SpanExporter that writes every batch
+// of spans to a file in dir before exporting the stored batches to next, in
+// order, removing each file once its batch is exported. If next fails, the
+// batches are kept and exported again with the next batch, or after the
+// RetryInterval. The batches left in dir by a previous process, e.g. one
+// stopped during a backend outage, are exported once the queue starts.
+//
+// ExportSpans returns once the batch is stored: failed exports to next are
+// not returned, so the span processor calling it does not export the batch
+// again. Once the stored batches exceed MaxBytes, the oldest ones are
+// discarded and reported to the global error handler.
+//
+// The directory is created if it does not exist and must not be used by
+// another persistent queue.
+func NewPersistentQueueExporter(dir string, next SpanExporter, options ...PersistentQueueOption) (SpanExporter, error) {
+	o := PersistentQueueOptions{
+		MaxBytes:      DefaultPersistentQueueMaxBytes,
+		RetryInterval: DefaultPersistentQueueRetryInterval,
+		ExportTimeout: DefaultPersistentQueueExportTimeout,
+	}
+	for _, opt := range options {
+		opt(&o)
+	}
+	if o.MaxBytes <= 0 {
+		o.MaxBytes = DefaultPersistentQueueMaxBytes
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = DefaultPersistentQueueRetryInterval
+	}
+	if o.ExportTimeout <= 0 {
+		o.ExportTimeout = DefaultPersistentQueueExportTimeout
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	e := &persistentQueueExporter{
+		dir:       dir,
+		next:      next,
+		o:         o,
+		exportSem: make(chan struct{}, 1),
+	}
+	if err := e.load(); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.stopWait.Add(1)
+	go func() {
+		defer e.stopWait.Done()
+		e.retry(ctx)
+	}()
+	return e, nil
+}
+
+// WithMaxQueueBytes returns a PersistentQueueOption that sets the maximum
+// total size of the batches stored on disk.
+func WithMaxQueueBytes(n int64) PersistentQueueOption {
+	return func(o *PersistentQueueOptions) {
+		o.MaxBytes = n
+	}
+}
+
+// WithRetryInterval returns a PersistentQueueOption that sets the interval
+// between two attempts to export the stored batches.
+func WithRetryInterval(d time.Duration) PersistentQueueOption {
+	return func(o *PersistentQueueOptions) {
+		o.RetryInterval = d
+	}
+}
+
+// WithQueueExportTimeout returns a PersistentQueueOption that sets the
+// timeout of every attempt to export the stored batches after the retry
+// interval.
+func WithQueueExportTimeout(d time.Duration) PersistentQueueOption {
+	return func(o *PersistentQueueOptions) {
+		o.ExportTimeout = d
+	}
+}
+
+// load reads the batches stored in the directory of e, removing the
+// batches the previous process did not finish writing.
+func (e *persistentQueueExporter) load() error {
+	infos, err := ioutil.ReadDir(e.dir)
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		name := info.Name()
+		switch filepath.Ext(name) {
+		case tempBatchExt:
+			_ = os.Remove(filepath.Join(e.dir, name))
+		case persistedBatchExt:
+			seq, err := strconv.ParseUint(strings.TrimSuffix(name, persistedBatchExt), 10, 64)
+			if err != nil {
+				continue
+			}
+			e.batches = append(e.batches, queuedBatch{seq: seq, size: info.Size()})
+			e.size += info.Size()
+			if seq >= e.seq {
+				e.seq = seq + 1
+			}
+		}
+	}
+	sort.Slice(e.batches, func(i, j int) bool {
+		return e.batches[i].seq < e.batches[j].seq
+	})
+	return nil
+}
+
+func (e *persistentQueueExporter) path(seq uint64) string {
+	return filepath.Join(e.dir, fmt.Sprintf("%020d%s", seq, persistedBatchExt))
+}
+
+// ExportSpans stores spans and exports the stored batches. If ctx is done
+// while another export of the stored batches is in progress, the batch is
+// only stored.
+func (e *persistentQueueExporter) ExportSpans(ctx context.Context, spans []ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	data, err := encodeBatch(spans)
+	if err != nil {
+		return err
+	}
+	if err := e.store(data); err != nil {
+		return err
+	}
+	if err := e.exportStored(ctx); err != nil {
+		global.Info("persistent queue export failed", "error", err, "batches", e.len())
+	}
+	return nil
+}
+
+// store writes data to a new file and discards the oldest batches if the
+// stored batches exceed the maximum size.
+func (e *persistentQueueExporter) store(data []byte) error {
+	e.mu.Lock()
+	seq := e.seq
+	e.seq++
+	e.mu.Unlock()
+
+	// Write to a temporary file first, so a partially written batch is
+	// never exported.
+	tmp := strings.TrimSuffix(e.path(seq), persistedBatchExt) + tempBatchExt
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, e.path(seq))
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.batches = append(e.batches, queuedBatch{seq: seq, size: int64(len(data))})
+	e.size += int64(len(data))
+	var dropped int
+	for e.size > e.o.MaxBytes && len(e.batches) > 0 {
+		_ = os.Remove(e.path(e.batches[0].seq))
+		e.size -= e.batches[0].size
+		e.batches = e.batches[1:]
+		dropped++
+	}
+	if dropped > 0 {
+		otel.HandleSignal(otel.TracesSignal, fmt.Errorf("persistent queue full: %d batches discarded", dropped))
+	}
+	return nil
+}
+
+func (e *persistentQueueExporter) len() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.batches)
+}
+
+// oldest returns the oldest stored batch.
+func (e *persistentQueueExporter) oldest() (queuedBatch, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.batches) == 0 {
+		return queuedBatch{}, false
+	}
+	return e.batches[0], true
+}
+
+// remove removes b from the stored batches, unless it was discarded.
+func (e *persistentQueueExporter) remove(b queuedBatch) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.batches) == 0 || e.batches[0].seq != b.seq {
+		return
+	}
+	_ = os.Remove(e.path(b.seq))
+	e.size -= b.size
+	e.batches = e.batches[1:]
+}
+
+// exportStored exports the stored batches to the wrapped exporter, oldest
+// first, until they are all exported or an export fails. It waits for any
+// other export of the stored batches to return first, unless ctx is done.
+func (e *persistentQueueExporter) exportStored(ctx context.Context) error {
+	select {
+	case e.exportSem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-e.exportSem }()
+
+	for {
+		b, ok := e.oldest()
+		if !ok {
+			return nil
+		}
+		data, err := ioutil.ReadFile(e.path(b.seq))
+		if os.IsNotExist(err) {
+			// Discarded since it was selected, or removed by
+			// someone else.
+			e.remove(b)
+			continue
+		}
+		var spans []ReadOnlySpan
+		if err == nil {
+			spans, err = decodeBatch(data)
+		}
+		if err != nil {
+			// A corrupted batch cannot be exported.
+			otel.HandleSignal(otel.TracesSignal, fmt.Errorf("persistent queue: discarding batch %d: %w", b.seq, err))
+			e.remove(b)
+			continue
+		}
+		if err := e.next.ExportSpans(ctx, spans); err != nil {
+			return err
+		}
+		e.remove(b)
+	}
+}
+
+// retry exports the stored batches every retry interval until ctx is
+// canceled. Every attempt is subject to the export timeout.
+func (e *persistentQueueExporter) retry(ctx context.Context) {
+	ticker := time.NewTicker(e.o.RetryInterval)
+	defer ticker.Stop()
+	for {
+		if e.len() > 0 {
+			actx, cancel := context.WithTimeout(ctx, e.o.ExportTimeout)
+			if err := e.exportStored(actx); err != nil && ctx.Err() == nil {
+				global.Info("persistent queue export failed", "error", err, "batches", e.len())
+			}
+			cancel()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown makes a last attempt to export the stored batches and shuts
+// down the wrapped exporter. The batches that are not exported are kept
+// on disk for the next process.
+func (e *persistentQueueExporter) Shutdown(ctx context.Context) error {
+	var err error
+	e.stopOnce.Do(func() {
+		e.cancel()
+		e.stopWait.Wait()
+		if eerr := e.exportStored(ctx); eerr != nil {
+			global.Info("persistent queue export failed", "error", eerr, "batches", e.len())
+		}
+		err = e.next.Shutdown(ctx)
+	})
+	return err
+}
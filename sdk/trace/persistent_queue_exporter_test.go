@@ -0,0 +1,305 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// outageExporter is an InMemoryExporter failing all exports while down.
+type outageExporter struct {
+	*tracetest.InMemoryExporter
+
+	mu   sync.Mutex
+	down bool
+}
+
+func newOutageExporter(down bool) *outageExporter {
+	return &outageExporter{InMemoryExporter: tracetest.NewInMemoryExporter(), down: down}
+}
+
+func (e *outageExporter) setDown(down bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.down = down
+}
+
+func (e *outageExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.down {
+		return errors.New("collector unavailable")
+	}
+	return e.InMemoryExporter.ExportSpans(ctx, spans)
+}
+
+// Shutdown keeps the exported spans.
+func (e *outageExporter) Shutdown(context.Context) error { return nil }
+
+func tempQueueDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "persistent-queue")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return dir
+}
+
+func queuedFiles(t *testing.T, dir string) []string {
+	files, err := filepath.Glob(filepath.Join(dir, "*.batch"))
+	require.NoError(t, err)
+	return files
+}
+
+func namedStubs(names ...string) []sdktrace.ReadOnlySpan {
+	stubs := make(tracetest.SpanStubs, len(names))
+	for i, name := range names {
+		stubs[i] = tracetest.SpanStub{
+			Name:     name,
+			Resource: resource.Empty(),
+		}
+	}
+	return stubs.Snapshots()
+}
+
+func exportedNames(e *outageExporter) []string {
+	var names []string
+	for _, s := range e.GetSpans() {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestPersistentQueueExporterRoundTrip(t *testing.T) {
+	next := newOutageExporter(false)
+	exp, err := sdktrace.NewPersistentQueueExporter(tempQueueDir(t), next)
+	require.NoError(t, err)
+
+	ts, err := trace.TraceStateFromKeyValues(attribute.String("vendor", "value"))
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+		TraceState: ts,
+	})
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x03},
+		Remote:  true,
+	})
+	start := time.Date(2021, time.June, 1, 12, 0, 0, 123, time.UTC)
+	attrs := []attribute.KeyValue{
+		attribute.Bool("bool", true),
+		attribute.Int64("int", -42),
+		attribute.Float64("float", 1.5),
+		attribute.String("string", "value"),
+		attribute.BoolSlice("bools", []bool{true, false}),
+		attribute.Int64Slice("ints", []int64{1 << 60, 2}),
+		attribute.Float64Slice("floats", []float64{0.25}),
+		attribute.StringSlice("strings", []string{"a", "b"}),
+	}
+	want := tracetest.SpanStub{
+		SpanContext:              sc,
+		Parent:                   parent,
+		SpanKind:                 trace.SpanKindServer,
+		Name:                     "span",
+		StartTime:                start,
+		EndTime:                  start.Add(time.Second),
+		Attributes:               attrs,
+		MessageEvents:            []trace.Event{{Name: "event", Time: start.Add(time.Millisecond), Attributes: attrs[:1]}},
+		Links:                    []trace.Link{{SpanContext: parent, Attributes: attrs[1:2]}},
+		StatusCode:               codes.Error,
+		StatusMessage:            "failed",
+		DroppedAttributeCount:    1,
+		DroppedMessageEventCount: 2,
+		DroppedLinkCount:         3,
+		ChildSpanCount:           4,
+		Resource:                 resource.NewWithSchemaURL("https://example.com/schema", attribute.String("service.name", "test")),
+		InstrumentationLibrary:   instrumentation.Library{Name: "lib", Version: "v1"},
+	}
+	require.NoError(t, exp.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{want.Snapshot()}))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	spans := next.GetSpans()
+	require.Len(t, spans, 1)
+	got := spans[0]
+	assert.True(t, want.Resource.Equal(got.Resource))
+	assert.Equal(t, want.Resource.SchemaURL(), got.Resource.SchemaURL())
+	want.Resource, got.Resource = nil, nil
+	assert.Equal(t, want, got)
+}
+
+func TestPersistentQueueExporterOutage(t *testing.T) {
+	dir := tempQueueDir(t)
+	next := newOutageExporter(true)
+	exp, err := sdktrace.NewPersistentQueueExporter(dir, next, sdktrace.WithRetryInterval(time.Hour))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	assert.NoError(t, exp.ExportSpans(ctx, namedStubs("a", "b")), "the batch is stored")
+	assert.NoError(t, exp.ExportSpans(ctx, namedStubs("c")))
+	assert.Len(t, queuedFiles(t, dir), 2)
+	assert.Len(t, next.GetSpans(), 0)
+
+	next.setDown(false)
+	assert.NoError(t, exp.ExportSpans(ctx, namedStubs("d")))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, exportedNames(next))
+	assert.Len(t, queuedFiles(t, dir), 0)
+	assert.NoError(t, exp.Shutdown(ctx))
+}
+
+func TestPersistentQueueExporterReplay(t *testing.T) {
+	dir := tempQueueDir(t)
+	ctx := context.Background()
+
+	down := newOutageExporter(true)
+	exp, err := sdktrace.NewPersistentQueueExporter(dir, down)
+	require.NoError(t, err)
+	require.NoError(t, exp.ExportSpans(ctx, namedStubs("a")))
+	require.NoError(t, exp.ExportSpans(ctx, namedStubs("b")))
+	require.NoError(t, exp.Shutdown(ctx))
+	require.Len(t, queuedFiles(t, dir), 2, "batches are kept for the next process")
+	// A batch the process did not finish writing is discarded.
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "00000000000000000002.tmp"), []byte("{"), 0600))
+
+	up := newOutageExporter(false)
+	exp, err = sdktrace.NewPersistentQueueExporter(dir, up)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(up.GetSpans()) == 2
+	}, time.Second, 10*time.Millisecond, "stored batches are exported on start")
+	require.NoError(t, exp.ExportSpans(ctx, namedStubs("c")))
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Equal(t, []string{"a", "b", "c"}, exportedNames(up))
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 0)
+}
+
+// hangingExporter blocks every export until its context is done.
+type hangingExporter struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (e *hangingExporter) ExportSpans(ctx context.Context, _ []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	e.calls++
+	e.mu.Unlock()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (e *hangingExporter) Shutdown(context.Context) error { return nil }
+
+func (e *hangingExporter) callCount() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.calls
+}
+
+func TestPersistentQueueExporterHangingExport(t *testing.T) {
+	dir := tempQueueDir(t)
+	next := &hangingExporter{}
+	exp, err := sdktrace.NewPersistentQueueExporter(dir, next,
+		sdktrace.WithRetryInterval(10*time.Millisecond),
+		sdktrace.WithQueueExportTimeout(50*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	require.NoError(t, exp.ExportSpans(ctx, namedStubs("a")))
+
+	// The retries are not stuck in the first hanging export.
+	assert.Eventually(t, func() bool {
+		return next.callCount() >= 3
+	}, time.Second, 10*time.Millisecond)
+
+	// An export waiting for the retries returns once its context is done.
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- exp.ExportSpans(ctx, namedStubs("b")) }()
+	select {
+	case err := <-done:
+		assert.NoError(t, err, "the batch is stored")
+	case <-time.After(time.Second):
+		t.Fatal("ExportSpans did not return once its context was done")
+	}
+	assert.Len(t, queuedFiles(t, dir), 2)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.NoError(t, exp.Shutdown(ctx))
+}
+
+func TestPersistentQueueExporterMaxBytes(t *testing.T) {
+	dir := tempQueueDir(t)
+	next := newOutageExporter(true)
+	exp, err := sdktrace.NewPersistentQueueExporter(dir, next, sdktrace.WithRetryInterval(time.Hour))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, exp.ExportSpans(ctx, namedStubs("a")))
+	files := queuedFiles(t, dir)
+	require.Len(t, files, 1)
+	info, err := os.Stat(files[0])
+	require.NoError(t, err)
+	require.NoError(t, exp.Shutdown(ctx))
+
+	// Room for two batches.
+	exp, err = sdktrace.NewPersistentQueueExporter(dir, next, sdktrace.WithRetryInterval(time.Hour), sdktrace.WithMaxQueueBytes(2*info.Size()))
+	require.NoError(t, err)
+	require.NoError(t, exp.ExportSpans(ctx, namedStubs("b")))
+	require.NoError(t, exp.ExportSpans(ctx, namedStubs("c")))
+	assert.Len(t, queuedFiles(t, dir), 2)
+
+	next.setDown(false)
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Equal(t, []string{"b", "c"}, exportedNames(next), "the oldest batch is discarded")
+}
+
+func TestPersistentQueueExporterCorruptedBatch(t *testing.T) {
+	dir := tempQueueDir(t)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "00000000000000000007.batch"), []byte("not json"), 0600))
+
+	next := newOutageExporter(false)
+	exp, err := sdktrace.NewPersistentQueueExporter(dir, next, sdktrace.WithRetryInterval(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, exp.ExportSpans(context.Background(), namedStubs("a")))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	assert.Equal(t, []string{"a"}, exportedNames(next))
+	assert.Len(t, queuedFiles(t, dir), 0)
+}
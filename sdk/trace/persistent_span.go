@@ -0,0 +1,337 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// persistedBatch is the JSON encoding of a batch of spans stored by a
+// persistent queue. The Resources shared by the spans are only stored
+// once.
+type persistedBatch struct {
+	Resources []persistedResource `json:"resources"`
+	Spans     []persistedSpan     `json:"spans"`
+}
+
+type persistedResource struct {
+	SchemaURL  string               `json:"schema_url,omitempty"`
+	Attributes []persistedAttribute `json:"attributes,omitempty"`
+}
+
+type persistedSpan struct {
+	Name                   string                  `json:"name"`
+	SpanContext            persistedSpanContext    `json:"span_context"`
+	Parent                 persistedSpanContext    `json:"parent"`
+	SpanKind               trace.SpanKind          `json:"kind"`
+	StartTime              time.Time               `json:"start_time"`
+	EndTime                time.Time               `json:"end_time"`
+	Attributes             []persistedAttribute    `json:"attributes,omitempty"`
+	Events                 []persistedEvent        `json:"events,omitempty"`
+	Links                  []persistedLink         `json:"links,omitempty"`
+	StatusCode             uint32                  `json:"status_code"`
+	StatusMessage          string                  `json:"status_message,omitempty"`
+	ChildSpanCount         int                     `json:"child_span_count,omitempty"`
+	DroppedAttributes      int                     `json:"dropped_attributes,omitempty"`
+	DroppedEvents          int                     `json:"dropped_events,omitempty"`
+	DroppedLinks           int                     `json:"dropped_links,omitempty"`
+	Resource               int                     `json:"resource"`
+	InstrumentationLibrary instrumentation.Library `json:"instrumentation_library"`
+}
+
+type persistedSpanContext struct {
+	TraceID    string `json:"trace_id,omitempty"`
+	SpanID     string `json:"span_id,omitempty"`
+	TraceFlags byte   `json:"trace_flags,omitempty"`
+	TraceState string `json:"trace_state,omitempty"`
+	Remote     bool   `json:"remote,omitempty"`
+}
+
+type persistedEvent struct {
+	Name       string               `json:"name"`
+	Time       time.Time            `json:"time"`
+	Attributes []persistedAttribute `json:"attributes,omitempty"`
+}
+
+type persistedLink struct {
+	SpanContext persistedSpanContext `json:"span_context"`
+	Attributes  []persistedAttribute `json:"attributes,omitempty"`
+}
+
+type persistedAttribute struct {
+	Key   attribute.Key   `json:"key"`
+	Type  attribute.Type  `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// persistedSnapshot is a ReadOnlySpan decoded from a persistedSpan. It
+// has no Tracer of the TracerProvider that created the span.
+type persistedSnapshot struct {
+	*snapshot
+}
+
+// Tracer returns a no-op Tracer.
+func (s persistedSnapshot) Tracer() trace.Tracer {
+	return trace.NewNoopTracerProvider().Tracer(s.instrumentationLibrary.Name)
+}
+
+// encodeBatch returns the JSON encoding of spans.
+func encodeBatch(spans []ReadOnlySpan) ([]byte, error) {
+	var b persistedBatch
+	resources := make(map[*resource.Resource]int)
+	for _, s := range spans {
+		r := s.Resource()
+		idx, ok := resources[r]
+		if !ok {
+			idx = len(b.Resources)
+			resources[r] = idx
+			pr := persistedResource{}
+			if r != nil {
+				pr.SchemaURL = r.SchemaURL()
+				pr.Attributes = encodeAttributes(r.Attributes())
+			}
+			b.Resources = append(b.Resources, pr)
+		}
+
+		ps := persistedSpan{
+			Name:                   s.Name(),
+			SpanContext:            encodeSpanContext(s.SpanContext()),
+			Parent:                 encodeSpanContext(s.Parent()),
+			SpanKind:               s.SpanKind(),
+			StartTime:              s.StartTime(),
+			EndTime:                s.EndTime(),
+			Attributes:             encodeAttributes(s.Attributes()),
+			StatusCode:             uint32(s.StatusCode()),
+			StatusMessage:          s.StatusMessage(),
+			ChildSpanCount:         s.ChildSpanCount(),
+			DroppedAttributes:      s.DroppedAttributes(),
+			DroppedEvents:          s.DroppedEvents(),
+			DroppedLinks:           s.DroppedLinks(),
+			Resource:               idx,
+			InstrumentationLibrary: s.InstrumentationLibrary(),
+		}
+		for _, e := range s.Events() {
+			ps.Events = append(ps.Events, persistedEvent{
+				Name:       e.Name,
+				Time:       e.Time,
+				Attributes: encodeAttributes(e.Attributes),
+			})
+		}
+		for _, l := range s.Links() {
+			ps.Links = append(ps.Links, persistedLink{
+				SpanContext: encodeSpanContext(l.SpanContext),
+				Attributes:  encodeAttributes(l.Attributes),
+			})
+		}
+		b.Spans = append(b.Spans, ps)
+	}
+	return json.Marshal(b)
+}
+
+func encodeSpanContext(sc trace.SpanContext) persistedSpanContext {
+	if !sc.IsValid() {
+		return persistedSpanContext{}
+	}
+	return persistedSpanContext{
+		TraceID:    sc.TraceID().String(),
+		SpanID:     sc.SpanID().String(),
+		TraceFlags: sc.TraceFlags(),
+		TraceState: sc.TraceState().String(),
+		Remote:     sc.IsRemote(),
+	}
+}
+
+func encodeAttributes(attrs []attribute.KeyValue) []persistedAttribute {
+	if len(attrs) == 0 {
+		return nil
+	}
+	out := make([]persistedAttribute, 0, len(attrs))
+	for _, kv := range attrs {
+		v, err := json.Marshal(kv.Value.AsInterface())
+		if err != nil {
+			// Only non-finite floats fail to encode, they are
+			// dropped.
+			continue
+		}
+		out = append(out, persistedAttribute{Key: kv.Key, Type: kv.Value.Type(), Value: v})
+	}
+	return out
+}
+
+// decodeBatch returns the spans of the JSON encoding data.
+func decodeBatch(data []byte) ([]ReadOnlySpan, error) {
+	var b persistedBatch
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, err
+	}
+
+	resources := make([]*resource.Resource, len(b.Resources))
+	for i, pr := range b.Resources {
+		attrs, err := decodeAttributes(pr.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		resources[i] = resource.NewWithSchemaURL(pr.SchemaURL, attrs...)
+	}
+
+	spans := make([]ReadOnlySpan, 0, len(b.Spans))
+	for _, ps := range b.Spans {
+		if ps.Resource < 0 || ps.Resource >= len(resources) {
+			return nil, fmt.Errorf("span %q: invalid resource index %d", ps.Name, ps.Resource)
+		}
+		s := &snapshot{
+			name:                   ps.Name,
+			spanKind:               ps.SpanKind,
+			startTime:              ps.StartTime,
+			endTime:                ps.EndTime,
+			statusCode:             codes.Code(ps.StatusCode),
+			statusMessage:          ps.StatusMessage,
+			childSpanCount:         ps.ChildSpanCount,
+			droppedAttributeCount:  ps.DroppedAttributes,
+			droppedEventCount:      ps.DroppedEvents,
+			droppedLinkCount:       ps.DroppedLinks,
+			resource:               resources[ps.Resource],
+			instrumentationLibrary: ps.InstrumentationLibrary,
+		}
+		var err error
+		if s.spanContext, err = decodeSpanContext(ps.SpanContext); err != nil {
+			return nil, err
+		}
+		if s.parent, err = decodeSpanContext(ps.Parent); err != nil {
+			return nil, err
+		}
+		if s.attributes, err = decodeAttributes(ps.Attributes); err != nil {
+			return nil, err
+		}
+		for _, pe := range ps.Events {
+			e := trace.Event{Name: pe.Name, Time: pe.Time}
+			if e.Attributes, err = decodeAttributes(pe.Attributes); err != nil {
+				return nil, err
+			}
+			s.events = append(s.events, e)
+		}
+		for _, pl := range ps.Links {
+			var l trace.Link
+			if l.SpanContext, err = decodeSpanContext(pl.SpanContext); err != nil {
+				return nil, err
+			}
+			if l.Attributes, err = decodeAttributes(pl.Attributes); err != nil {
+				return nil, err
+			}
+			s.links = append(s.links, l)
+		}
+		spans = append(spans, persistedSnapshot{s})
+	}
+	return spans, nil
+}
+
+func decodeSpanContext(psc persistedSpanContext) (trace.SpanContext, error) {
+	if psc.TraceID == "" && psc.SpanID == "" {
+		return trace.SpanContext{}, nil
+	}
+	tid, err := trace.TraceIDFromHex(psc.TraceID)
+	if err != nil {
+		return trace.SpanContext{}, err
+	}
+	sid, err := trace.SpanIDFromHex(psc.SpanID)
+	if err != nil {
+		return trace.SpanContext{}, err
+	}
+	var entries []attribute.KeyValue
+	if psc.TraceState != "" {
+		for _, member := range strings.Split(psc.TraceState, ",") {
+			kv := strings.SplitN(member, "=", 2)
+			if len(kv) != 2 {
+				return trace.SpanContext{}, fmt.Errorf("invalid trace state member %q", member)
+			}
+			entries = append(entries, attribute.String(kv[0], kv[1]))
+		}
+	}
+	ts, err := trace.TraceStateFromKeyValues(entries...)
+	if err != nil {
+		return trace.SpanContext{}, err
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    tid,
+		SpanID:     sid,
+		TraceFlags: psc.TraceFlags,
+		TraceState: ts,
+		Remote:     psc.Remote,
+	}), nil
+}
+
+func decodeAttributes(pas []persistedAttribute) ([]attribute.KeyValue, error) {
+	if len(pas) == 0 {
+		return nil, nil
+	}
+	out := make([]attribute.KeyValue, 0, len(pas))
+	for _, pa := range pas {
+		v, err := decodeValue(pa.Type, pa.Value)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", pa.Key, err)
+		}
+		out = append(out, attribute.KeyValue{Key: pa.Key, Value: v})
+	}
+	return out, nil
+}
+
+func decodeValue(t attribute.Type, raw json.RawMessage) (attribute.Value, error) {
+	var err error
+	switch t {
+	case attribute.BOOL:
+		var v bool
+		err = json.Unmarshal(raw, &v)
+		return attribute.BoolValue(v), err
+	case attribute.INT64:
+		var v int64
+		err = json.Unmarshal(raw, &v)
+		return attribute.Int64Value(v), err
+	case attribute.FLOAT64:
+		var v float64
+		err = json.Unmarshal(raw, &v)
+		return attribute.Float64Value(v), err
+	case attribute.STRING:
+		var v string
+		err = json.Unmarshal(raw, &v)
+		return attribute.StringValue(v), err
+	case attribute.BOOLSLICE:
+		var v []bool
+		err = json.Unmarshal(raw, &v)
+		return attribute.BoolSliceValue(v), err
+	case attribute.INT64SLICE:
+		var v []int64
+		err = json.Unmarshal(raw, &v)
+		return attribute.Int64SliceValue(v), err
+	case attribute.FLOAT64SLICE:
+		var v []float64
+		err = json.Unmarshal(raw, &v)
+		return attribute.Float64SliceValue(v), err
+	case attribute.STRINGSLICE:
+		var v []string
+		err = json.Unmarshal(raw, &v)
+		return attribute.StringSliceValue(v), err
+	}
+	return attribute.Value{}, fmt.Errorf("unsupported attribute type %d", t)
+}
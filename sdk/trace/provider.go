@@ -21,10 +21,11 @@ import (
 	"sync/atomic"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
-	export "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/internal"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -50,18 +51,66 @@ type TracerProviderConfig struct {
 
 	// resource contains attributes representing an entity that produces telemetry.
 	resource *resource.Resource
+
+	// tracerOverrides replace the sampler and spanLimits for the Tracers
+	// with a matching name.
+	tracerOverrides []*tracerOverride
+
+	// localRootAttribute, if defined, is the key of the attribute set on
+	// local root spans.
+	localRootAttribute attribute.Key
+
+	// enrichers provide additional attributes and links for spans when
+	// they are started.
+	enrichers []ContextEnricher
+
+	// dryRun replaces the export of spans by counting them.
+	dryRun bool
+
+	// clock provides the start, end, and event times of spans.
+	clock Clock
+
+	// spanNameFormatter, if defined, rewrites the names of started spans.
+	spanNameFormatter SpanNameFormatter
+
+	// selfObserver, if set, observes the operation of the
+	// TracerProvider.
+	selfObserver SelfObserver
+
+	// listeners are notified of the start and end of spans.
+	listeners []SpanListener
 }
 
 type TracerProviderOption func(*TracerProviderConfig)
 
 type TracerProvider struct {
 	mu             sync.Mutex
-	namedTracer    map[instrumentation.Library]*tracer
+	namedTracer    sync.Map // instrumentation.Library -> *tracer
 	spanProcessors atomic.Value
-	sampler        Sampler
+	spanListeners  atomic.Value
+	sampler        atomic.Value
 	idGenerator    IDGenerator
 	spanLimits     SpanLimits
 	resource       *resource.Resource
+
+	tracerOverrides []*tracerOverride
+
+	localRootAttribute attribute.Key
+	enrichers          []ContextEnricher
+	clock              Clock
+	spanNameFormatter  SpanNameFormatter
+
+	// dryRun counts the spans exported in dry-run mode. It is nil if the
+	// TracerProvider is not in dry-run mode.
+	dryRun *dryRunCounter
+
+	// selfMetrics reports the operation of the TracerProvider. It is nil
+	// if the TracerProvider is not observed.
+	selfMetrics *selfMetrics
+
+	// releaseResource unregisters the resource from the process wide
+	// registry of service instances, on Shutdown.
+	releaseResource func()
 }
 
 var _ trace.TracerProvider = &TracerProvider{}
@@ -69,10 +118,12 @@ var _ trace.TracerProvider = &TracerProvider{}
 // NewTracerProvider returns a new and configured TracerProvider.
 //
 // By default the returned TracerProvider is configured with:
-//  - a ParentBased(AlwaysSample) Sampler
+//  - the Sampler configured by the environment or a ParentBased(AlwaysSample) Sampler
 //  - a random number IDGenerator
 //  - the resource.Default() Resource
-//  - the default SpanLimits.
+//  - the SpanLimits configured by the environment or the default SpanLimits.
+//  - no Tracer specific Sampler or SpanLimits, and all Tracers enabled.
+//  - a Clock using the system wall and monotonic clocks.
 //
 // The passed opts are used to override these default values and configure the
 // returned TracerProvider appropriately.
@@ -85,47 +136,86 @@ func NewTracerProvider(opts ...TracerProviderOption) *TracerProvider {
 
 	ensureValidTracerProviderConfig(o)
 
+	releaseResource, err := internal.RegisterResource("trace", o.resource)
+	if err != nil {
+		otel.HandleSignal(otel.TracesSignal, err)
+	}
+
 	tp := &TracerProvider{
-		namedTracer: make(map[instrumentation.Library]*tracer),
-		sampler:     o.sampler,
 		idGenerator: o.idGenerator,
 		spanLimits:  o.spanLimits,
 		resource:    o.resource,
+
+		releaseResource: releaseResource,
+
+		tracerOverrides:    o.tracerOverrides,
+		localRootAttribute: o.localRootAttribute,
+		enrichers:          o.enrichers,
+		clock:              o.clock,
+		spanNameFormatter:  o.spanNameFormatter,
+	}
+	tp.sampler.Store(samplerHolder{o.sampler})
+	if o.dryRun {
+		tp.dryRun = &dryRunCounter{}
+	}
+	if o.selfObserver != nil {
+		tp.selfMetrics = newSelfMetrics(o.selfObserver)
 	}
 
 	for _, sp := range o.processors {
 		tp.RegisterSpanProcessor(sp)
 	}
+	for _, l := range o.listeners {
+		tp.AddSpanListener(l)
+	}
 
 	return tp
 }
 
 // Tracer returns a Tracer with the given name and options. If a Tracer for
-// the given name and options does not exist it is created, otherwise the
-// existing Tracer is returned.
+// the given name, instrumentation version and schema URL does not exist it
+// is created, otherwise the existing Tracer is returned: repeated calls with
+// identical parameters return the same instance. Looking up an existing
+// Tracer does not acquire any lock, so it is cheap enough to be done for
+// every request.
 //
 // If name is empty, DefaultTracerName is used instead.
 //
+// If the WithSpanStartOptionsDefaults option is passed, a new Tracer applying
+// the default options to the spans it starts is returned on every call.
+//
 // This method is safe to be called concurrently.
 func (p *TracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
 	c := trace.NewTracerConfig(opts...)
 
-	p.mu.Lock()
-	defer p.mu.Unlock()
 	if name == "" {
 		name = defaultTracerName
 	}
 	il := instrumentation.Library{
-		Name:    name,
-		Version: c.InstrumentationVersion,
+		Name:      name,
+		Version:   c.InstrumentationVersion,
+		SchemaURL: c.SchemaURL,
 	}
-	t, ok := p.namedTracer[il]
-	if !ok {
+	var t *tracer
+	if v, ok := p.namedTracer.Load(il); ok {
+		t = v.(*tracer)
+	} else {
 		t = &tracer{
 			provider:               p,
 			instrumentationLibrary: il,
 		}
-		p.namedTracer[il] = t
+		t.sampler, t.spanLimits, t.disabled = p.tracerOverridesFor(il)
+		// Another goroutine may have created the same tracer
+		// concurrently, only one of them is ever returned.
+		v, _ := p.namedTracer.LoadOrStore(il, t)
+		t = v.(*tracer)
+	}
+	if len(c.SpanStartOptions) > 0 {
+		// Tracers with default span start options share the configuration
+		// of the named Tracer but are not cached.
+		tc := *t
+		tc.spanStartOptions = c.SpanStartOptions
+		return &tc
 	}
 	return t
 }
@@ -134,6 +224,12 @@ func (p *TracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.T
 func (p *TracerProvider) RegisterSpanProcessor(s SpanProcessor) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
+	if dr, ok := s.(dryRunner); ok && p.dryRun != nil {
+		dr.setDryRun(p.dryRun)
+	}
+	if si, ok := s.(selfInstrumented); ok && p.selfMetrics != nil {
+		si.setSelfMetrics(p.selfMetrics)
+	}
 	new := spanProcessorStates{}
 	if old, ok := p.spanProcessors.Load().(spanProcessorStates); ok {
 		new = append(new, old...)
@@ -146,6 +242,30 @@ func (p *TracerProvider) RegisterSpanProcessor(s SpanProcessor) {
 	p.spanProcessors.Store(new)
 }
 
+// samplerHolder holds the Sampler of a TracerProvider. It allows different
+// Sampler implementations to be stored in the same atomic.Value.
+type samplerHolder struct {
+	sampler Sampler
+}
+
+// SetSampler replaces the Sampler of the TracerProvider with s. The sampling
+// decisions of all spans started after SetSampler returns, including those
+// of already created Tracers, are made by s. This allows the sampling to be
+// changed at runtime, e.g. raised during an incident, without restarting the
+// application.
+//
+// Tracer specific Samplers configured with WithTracerSampler are not
+// replaced and still take precedence. If s is nil the Sampler is not
+// replaced.
+//
+// This method is safe to be called concurrently.
+func (p *TracerProvider) SetSampler(s Sampler) {
+	if s == nil {
+		return
+	}
+	p.sampler.Store(samplerHolder{s})
+}
+
 // UnregisterSpanProcessor removes the given SpanProcessor from the list of SpanProcessors
 func (p *TracerProvider) UnregisterSpanProcessor(s SpanProcessor) {
 	p.mu.Lock()
@@ -169,7 +289,7 @@ func (p *TracerProvider) UnregisterSpanProcessor(s SpanProcessor) {
 	if stopOnce != nil {
 		stopOnce.state.Do(func() {
 			if err := s.Shutdown(context.Background()); err != nil {
-				otel.Handle(err)
+				otel.HandleSignal(otel.TracesSignal, err)
 			}
 		})
 	}
@@ -184,6 +304,11 @@ func (p *TracerProvider) UnregisterSpanProcessor(s SpanProcessor) {
 
 // ForceFlush immediately exports all spans that have not yet been exported for
 // all the registered span processors.
+//
+// All span processors are flushed, in the order they were registered, even if
+// flushing one of them fails. The first error encountered is returned. If ctx
+// is done before all span processors have been flushed, the remaining ones are
+// not flushed and the context error is returned.
 func (p *TracerProvider) ForceFlush(ctx context.Context) error {
 	spss, ok := p.spanProcessors.Load().(spanProcessorStates)
 	if !ok {
@@ -193,6 +318,7 @@ func (p *TracerProvider) ForceFlush(ctx context.Context) error {
 		return nil
 	}
 
+	var firstErr error
 	for _, sps := range spss {
 		select {
 		case <-ctx.Done():
@@ -200,15 +326,29 @@ func (p *TracerProvider) ForceFlush(ctx context.Context) error {
 		default:
 		}
 
-		if err := sps.sp.ForceFlush(ctx); err != nil {
-			return err
+		if err := sps.sp.ForceFlush(ctx); err != nil && firstErr == nil {
+			firstErr = err
 		}
 	}
-	return nil
+	return firstErr
+}
+
+// DryRunStats returns the counts of the spans the span processors would have
+// exported if the TracerProvider was configured with WithDryRun. Zero counts
+// are returned otherwise.
+func (p *TracerProvider) DryRunStats() DryRunStats {
+	if p.dryRun == nil {
+		return DryRunStats{}
+	}
+	return p.dryRun.stats()
 }
 
 // Shutdown shuts down the span processors in the order they were registered.
 func (p *TracerProvider) Shutdown(ctx context.Context) error {
+	if p.releaseResource != nil {
+		p.releaseResource()
+	}
+
 	spss, ok := p.spanProcessors.Load().(spanProcessorStates)
 	if !ok {
 		return fmt.Errorf("failed to load span processors")
@@ -237,13 +377,13 @@ func (p *TracerProvider) Shutdown(ctx context.Context) error {
 
 // WithSyncer registers the exporter with the TracerProvider using a
 // SimpleSpanProcessor.
-func WithSyncer(e export.SpanExporter) TracerProviderOption {
+func WithSyncer(e SpanExporter) TracerProviderOption {
 	return WithSpanProcessor(NewSimpleSpanProcessor(e))
 }
 
 // WithBatcher registers the exporter with the TracerProvider using a
 // BatchSpanProcessor configured with the passed opts.
-func WithBatcher(e export.SpanExporter, opts ...BatchSpanProcessorOption) TracerProviderOption {
+func WithBatcher(e SpanExporter, opts ...BatchSpanProcessorOption) TracerProviderOption {
 	return WithSpanProcessor(NewBatchSpanProcessor(e, opts...))
 }
 
@@ -275,7 +415,8 @@ func WithResource(r *resource.Resource) TracerProviderOption {
 // Trace IDs.
 //
 // If this option is not used, the TracerProvider will use a random number
-// IDGenerator by default.
+// IDGenerator by default. Use the IDGenerator returned by NewXRayIDGenerator
+// to generate trace IDs accepted by AWS X-Ray.
 func WithIDGenerator(g IDGenerator) TracerProviderOption {
 	return func(opts *TracerProviderConfig) {
 		if g != nil {
@@ -284,6 +425,21 @@ func WithIDGenerator(g IDGenerator) TracerProviderOption {
 	}
 }
 
+// WithClock returns a TracerProviderOption that will configure the Clock c
+// as a TracerProvider's Clock. The configured Clock provides the start, end,
+// and event times of the spans created by the TracerProvider's Tracers that
+// are not explicitly set with the WithTimestamp option.
+//
+// If this option is not used, the TracerProvider will use the system clock.
+// Use this option to control time in tests.
+func WithClock(c Clock) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		if c != nil {
+			opts.clock = c
+		}
+	}
+}
+
 // WithSampler returns a TracerProviderOption that will configure the Sampler
 // s as a TracerProvider's Sampler. The configured Sampler is used by the
 // Tracers the TracerProvider creates to make their sampling decisions for the
@@ -304,22 +460,49 @@ func WithSampler(s Sampler) TracerProviderOption {
 // are used used by the Tracers the TracerProvider and the Spans they create
 // to limit tracing resources used.
 //
-// If this option is not used, the TracerProvider will use the default
-// SpanLimits.
+// Limits of sl that are not set (zero or negative) are read from their
+// OTEL_SPAN_ATTRIBUTE_COUNT_LIMIT, OTEL_SPAN_EVENT_COUNT_LIMIT,
+// OTEL_SPAN_LINK_COUNT_LIMIT, OTEL_EVENT_ATTRIBUTE_COUNT_LIMIT,
+// OTEL_LINK_ATTRIBUTE_COUNT_LIMIT and OTEL_ATTRIBUTE_VALUE_LENGTH_LIMIT
+// environment variables if set, otherwise the default limits are used. The
+// attribute value length limit is only considered unset if it is zero.
+//
+// If this option is not used, the TracerProvider will use the SpanLimits
+// configured by these environment variables or the default SpanLimits.
 func WithSpanLimits(sl SpanLimits) TracerProviderOption {
 	return func(opts *TracerProviderConfig) {
 		opts.spanLimits = sl
 	}
 }
 
+// WithLocalRootAttribute returns a TracerProviderOption that will configure
+// the TracerProvider to set a boolean attribute with key k to true on every
+// local root span, a span without a parent or with a remote parent, when it
+// is started. This allows SpanProcessors and SpanExporters, possibly in a
+// different process, to identify local root spans without inspecting their
+// parent.
+//
+// If this option is not used, no attribute is set.
+func WithLocalRootAttribute(k attribute.Key) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		opts.localRootAttribute = k
+	}
+}
+
 // ensureValidTracerProviderConfig ensures that given TracerProviderConfig is valid.
 func ensureValidTracerProviderConfig(cfg *TracerProviderConfig) {
+	if cfg.sampler == nil {
+		cfg.sampler = samplerFromEnv()
+	}
 	if cfg.sampler == nil {
 		cfg.sampler = ParentBased(AlwaysSample())
 	}
 	if cfg.idGenerator == nil {
 		cfg.idGenerator = defaultIDGenerator()
 	}
+	if cfg.clock == nil {
+		cfg.clock = defaultClock{}
+	}
 	cfg.spanLimits.ensureDefault()
 	if cfg.resource == nil {
 		cfg.resource = resource.Default()
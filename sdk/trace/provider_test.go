@@ -17,14 +17,22 @@ package trace
 import (
 	"context"
 	"errors"
+	"os"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type basicSpanProcesor struct {
 	running             bool
 	injectShutdownError error
+	flushCount          int
+	injectFlushError    error
 }
 
 func (t *basicSpanProcesor) Shutdown(context.Context) error {
@@ -33,9 +41,11 @@ func (t *basicSpanProcesor) Shutdown(context.Context) error {
 }
 
 func (t *basicSpanProcesor) OnStart(context.Context, ReadWriteSpan) {}
+func (t *basicSpanProcesor) OnEnding(ReadWriteSpan)                 {}
 func (t *basicSpanProcesor) OnEnd(ReadOnlySpan)                     {}
 func (t *basicSpanProcesor) ForceFlush(context.Context) error {
-	return nil
+	t.flushCount++
+	return t.injectFlushError
 }
 
 func TestShutdownTraceProvider(t *testing.T) {
@@ -66,6 +76,43 @@ func TestFailedProcessorShutdown(t *testing.T) {
 	assert.Equal(t, err, spErr)
 }
 
+func TestForceFlushTraceProvider(t *testing.T) {
+	stp := NewTracerProvider()
+	sp1, sp2 := &basicSpanProcesor{}, &basicSpanProcesor{}
+	stp.RegisterSpanProcessor(sp1)
+	stp.RegisterSpanProcessor(sp2)
+
+	assert.NoError(t, stp.ForceFlush(context.Background()))
+	assert.Equal(t, 1, sp1.flushCount)
+	assert.Equal(t, 1, sp2.flushCount)
+}
+
+func TestFailedProcessorForceFlushFlushesAll(t *testing.T) {
+	stp := NewTracerProvider()
+	spErr := errors.New("basic span processor flush failure")
+	sp1 := &basicSpanProcesor{injectFlushError: spErr}
+	sp2 := &basicSpanProcesor{injectFlushError: errors.New("second failure")}
+	sp3 := &basicSpanProcesor{}
+	stp.RegisterSpanProcessor(sp1)
+	stp.RegisterSpanProcessor(sp2)
+	stp.RegisterSpanProcessor(sp3)
+
+	assert.Equal(t, spErr, stp.ForceFlush(context.Background()))
+	assert.Equal(t, 1, sp2.flushCount)
+	assert.Equal(t, 1, sp3.flushCount)
+}
+
+func TestForceFlushTraceProviderCanceled(t *testing.T) {
+	stp := NewTracerProvider()
+	sp := &basicSpanProcesor{}
+	stp.RegisterSpanProcessor(sp)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, stp.ForceFlush(ctx), context.Canceled)
+	assert.Equal(t, 0, sp.flushCount)
+}
+
 func TestFailedProcessorShutdownInUnregister(t *testing.T) {
 	handler.Reset()
 	stp := NewTracerProvider()
@@ -82,3 +129,301 @@ func TestFailedProcessorShutdownInUnregister(t *testing.T) {
 	err := stp.Shutdown(context.Background())
 	assert.NoError(t, err)
 }
+
+func TestTracerOverrides(t *testing.T) {
+	limits := SpanLimits{AttributeCountLimit: 1}
+	tp := NewTracerProvider(
+		WithSampler(AlwaysSample()),
+		WithTracerSampler("github.com/legacy/*", NeverSample()),
+		WithTracerSampler("github.com/legacy/kept", AlwaysSample()),
+		WithTracerSampler("github.com/legacy/verbose/*", TraceIDRatioBased(0.5)),
+		WithTracerSpanLimits("github.com/legacy/*", limits),
+	)
+	limits.ensureDefault()
+	defaults := SpanLimits{}
+	defaults.ensureDefault()
+
+	tests := []struct {
+		name    string
+		sampler Sampler
+		limits  SpanLimits
+	}{
+		{"github.com/other", AlwaysSample(), defaults},
+		{"github.com/legacy", AlwaysSample(), defaults},
+		{"github.com/legacy/noisy", NeverSample(), limits},
+		{"github.com/legacy/kept", AlwaysSample(), limits},
+		{"github.com/legacy/verbose/client", TraceIDRatioBased(0.5), limits},
+	}
+	for _, test := range tests {
+		tr := tp.Tracer(test.name).(*tracer)
+		assert.Equal(t, test.sampler.Description(), tr.getSampler().Description(), test.name)
+		assert.Equal(t, test.limits, tr.getSpanLimits(), test.name)
+	}
+
+	_, span := tp.Tracer("github.com/legacy/noisy").Start(context.Background(), "span")
+	assert.False(t, span.IsRecording())
+	_, span = tp.Tracer("github.com/other").Start(context.Background(), "span")
+	assert.True(t, span.IsRecording())
+}
+
+func TestTracerVersionOverrides(t *testing.T) {
+	tp := NewTracerProvider(
+		WithSampler(AlwaysSample()),
+		WithTracerSampler("github.com/legacy/*", NeverSample()),
+		WithTracerSampler("github.com/legacy/*@v1.0.0", AlwaysSample()),
+		WithTracerSampler("github.com/legacy/exact@v2.0.0", TraceIDRatioBased(0.5)),
+	)
+
+	tests := []struct {
+		name, version string
+		sampler       Sampler
+	}{
+		{"github.com/legacy/lib", "", NeverSample()},
+		{"github.com/legacy/lib", "v0.9.0", NeverSample()},
+		{"github.com/legacy/lib", "v1.0.0", AlwaysSample()},
+		{"github.com/legacy/exact", "v2.0.0", TraceIDRatioBased(0.5)},
+		{"github.com/legacy/exact", "v1.0.0", AlwaysSample()},
+	}
+	for _, test := range tests {
+		tr := tp.Tracer(test.name, trace.WithInstrumentationVersion(test.version)).(*tracer)
+		assert.Equal(t, test.sampler.Description(), tr.getSampler().Description(), test.name+"@"+test.version)
+	}
+}
+
+func TestTracerEnabled(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(
+		WithSyncer(te),
+		WithTracerEnabled("github.com/noisy/*", false),
+		WithTracerEnabled("github.com/noisy/kept", true),
+	)
+
+	ctx, parent := tp.Tracer("app").Start(context.Background(), "parent")
+	noisyCtx, noisy := tp.Tracer("github.com/noisy/client").Start(ctx, "noisy")
+	assert.False(t, noisy.IsRecording())
+	// The span context of the parent is propagated through the disabled tracer.
+	assert.Equal(t, parent.SpanContext(), noisy.SpanContext())
+
+	_, child := tp.Tracer("app").Start(noisyCtx, "child")
+	_, kept := tp.Tracer("github.com/noisy/kept").Start(ctx, "kept")
+	assert.True(t, kept.IsRecording())
+
+	kept.End()
+	child.End()
+	noisy.End()
+	parent.End()
+
+	assert.Equal(t, 3, te.Len())
+	got, ok := te.GetSpan("child")
+	require.True(t, ok)
+	assert.Equal(t, parent.SpanContext().SpanID(), got.Parent().SpanID())
+	_, ok = te.GetSpan("noisy")
+	assert.False(t, ok)
+
+	_, root := tp.Tracer("github.com/noisy/client").Start(context.Background(), "root")
+	assert.False(t, root.IsRecording())
+	assert.False(t, root.SpanContext().IsValid())
+}
+
+func setEnv(t *testing.T, key, value string) {
+	orig, ok := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if ok {
+			_ = os.Setenv(key, orig)
+		} else {
+			_ = os.Unsetenv(key)
+		}
+	})
+}
+
+func TestSpanLimitsFromEnv(t *testing.T) {
+	setEnv(t, EnvSpanAttributeCountLimit, "1")
+	setEnv(t, EnvSpanEventCountLimit, "2")
+	setEnv(t, EnvSpanLinkCountLimit, "invalid")
+	setEnv(t, EnvEventAttributeCountLimit, "-1")
+	setEnv(t, EnvLinkAttributeCountLimit, "4")
+	setEnv(t, EnvAttributeValueLengthLimit, "6")
+
+	tp := NewTracerProvider(WithSpanLimits(SpanLimits{LinkCountLimit: 10, AttributePerLinkCountLimit: 5}))
+	assert.Equal(t, SpanLimits{
+		AttributeCountLimit:         1,
+		EventCountLimit:             2,
+		LinkCountLimit:              10,
+		AttributePerEventCountLimit: DefaultAttributePerEventCountLimit,
+		AttributePerLinkCountLimit:  5,
+		AttributeValueLengthLimit:   6,
+	}, tp.spanLimits)
+
+	tp = NewTracerProvider(WithSpanLimits(SpanLimits{AttributeValueLengthLimit: -1}))
+	assert.Equal(t, -1, tp.spanLimits.AttributeValueLengthLimit)
+
+	tp = NewTracerProvider()
+	assert.Equal(t, DefaultLinkCountLimit, tp.spanLimits.LinkCountLimit)
+	assert.Equal(t, 4, tp.spanLimits.AttributePerLinkCountLimit)
+
+	_, span := tp.Tracer("limited").Start(context.Background(), "span")
+	span.SetAttributes(attribute.Int("a", 1), attribute.Int("b", 2))
+	for i := 0; i < 3; i++ {
+		span.AddEvent("event")
+	}
+	snap := takeSnapshot(span)
+	assert.Len(t, snap.attributes, 1)
+	assert.Equal(t, 1, snap.droppedAttributeCount)
+	assert.Len(t, snap.events, 2)
+	assert.Equal(t, 1, snap.droppedEventCount)
+}
+
+type principalKey struct{}
+
+type samplerFunc func(SamplingParameters) SamplingResult
+
+func (f samplerFunc) ShouldSample(p SamplingParameters) SamplingResult { return f(p) }
+func (f samplerFunc) Description() string                              { return "samplerFunc" }
+
+func TestContextEnricher(t *testing.T) {
+	link := trace.Link{SpanContext: sc}
+	var sampled []attribute.KeyValue
+	sampler := samplerFunc(func(p SamplingParameters) SamplingResult {
+		sampled = p.Attributes
+		return SamplingResult{Decision: RecordAndSample}
+	})
+	tp := NewTracerProvider(
+		WithSampler(sampler),
+		WithContextEnricher(ContextEnricherFunc(func(ctx context.Context) ([]attribute.KeyValue, []trace.Link) {
+			if p, ok := ctx.Value(principalKey{}).(string); ok {
+				return []attribute.KeyValue{attribute.String("enduser.id", p)}, nil
+			}
+			return nil, nil
+		})),
+		WithContextEnricher(ContextEnricherFunc(func(context.Context) ([]attribute.KeyValue, []trace.Link) {
+			return nil, []trace.Link{link}
+		})),
+	)
+
+	ctx := context.WithValue(context.Background(), principalKey{}, "alice")
+	_, span := tp.Tracer("enricher").Start(ctx, "span", trace.WithAttributes(attribute.String("A", "B")))
+	ro := span.(ReadOnlySpan)
+
+	want := []attribute.KeyValue{attribute.String("A", "B"), attribute.String("enduser.id", "alice")}
+	assert.Equal(t, want, sampled)
+	assert.ElementsMatch(t, want, ro.Attributes())
+	require.Len(t, ro.Links(), 1)
+	assert.Equal(t, sc, ro.Links()[0].SpanContext)
+}
+
+func TestSetSampler(t *testing.T) {
+	tp := NewTracerProvider(
+		WithSampler(NeverSample()),
+		WithTracerSampler("pinned", NeverSample()),
+	)
+	tracer := tp.Tracer("TestSetSampler")
+	pinned := tp.Tracer("pinned")
+
+	_, span := tracer.Start(context.Background(), "before")
+	assert.False(t, span.SpanContext().IsSampled())
+
+	tp.SetSampler(AlwaysSample())
+	_, span = tracer.Start(context.Background(), "after")
+	assert.True(t, span.SpanContext().IsSampled())
+	_, span = tp.Tracer("new").Start(context.Background(), "after")
+	assert.True(t, span.SpanContext().IsSampled())
+	_, span = pinned.Start(context.Background(), "after")
+	assert.False(t, span.SpanContext().IsSampled(), "tracer specific sampler replaced")
+
+	tp.SetSampler(nil)
+	_, span = tracer.Start(context.Background(), "nil")
+	assert.True(t, span.SpanContext().IsSampled(), "nil sampler not ignored")
+}
+
+func TestSetSamplerConcurrentSafe(t *testing.T) {
+	tp := NewTracerProvider()
+	tracer := tp.Tracer("TestSetSamplerConcurrentSafe")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			tp.SetSampler(TraceIDRatioBased(float64(i) / 100))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			_, span := tracer.Start(context.Background(), "span")
+			span.End()
+		}
+	}()
+	wg.Wait()
+}
+
+func TestTracerSameInstance(t *testing.T) {
+	tp := NewTracerProvider()
+
+	t1 := tp.Tracer("lib", trace.WithInstrumentationVersion("v1"), trace.WithSchemaURL("https://example.com/1"))
+	assert.Same(t, t1, tp.Tracer("lib", trace.WithInstrumentationVersion("v1"), trace.WithSchemaURL("https://example.com/1")))
+	assert.NotSame(t, t1, tp.Tracer("lib", trace.WithInstrumentationVersion("v2"), trace.WithSchemaURL("https://example.com/1")))
+	assert.NotSame(t, t1, tp.Tracer("lib", trace.WithInstrumentationVersion("v1"), trace.WithSchemaURL("https://example.com/2")))
+	assert.NotSame(t, t1, tp.Tracer("other", trace.WithInstrumentationVersion("v1"), trace.WithSchemaURL("https://example.com/1")))
+	assert.Same(t, tp.Tracer(""), tp.Tracer(defaultTracerName))
+}
+
+func TestTracerSameInstanceConcurrentSafe(t *testing.T) {
+	tp := NewTracerProvider()
+
+	const n = 10
+	tracers := make([]trace.Tracer, n)
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			tracers[i] = tp.Tracer("lib")
+		}(i)
+	}
+	wg.Wait()
+	for _, tracer := range tracers {
+		assert.Same(t, tracers[0], tracer)
+	}
+}
+
+func TestTracerSpanStartOptionsDefaults(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithTracerSampler("consumer", NeverSample()))
+	defaults := []trace.SpanOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.String("messaging.system", "kafka")),
+	}
+	tracer := tp.Tracer("consumer", trace.WithSpanStartOptionsDefaults(defaults...))
+	// The configuration of the named Tracer is kept.
+	tp.SetSampler(AlwaysSample())
+	_, span := tracer.Start(context.Background(), "unsampled")
+	assert.False(t, span.IsRecording())
+
+	tracer = tp.Tracer("app", trace.WithSpanStartOptionsDefaults(defaults...))
+	_, span = tracer.Start(context.Background(), "default")
+	span.End()
+	_, span = tracer.Start(context.Background(), "overridden",
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attribute.String("messaging.system", "rabbitmq")),
+	)
+	span.End()
+	_, span = tp.Tracer("app").Start(context.Background(), "plain")
+	span.End()
+
+	got, ok := te.GetSpan("default")
+	require.True(t, ok)
+	assert.Equal(t, trace.SpanKindConsumer, got.SpanKind())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("messaging.system", "kafka")}, got.Attributes())
+
+	got, ok = te.GetSpan("overridden")
+	require.True(t, ok)
+	assert.Equal(t, trace.SpanKindInternal, got.SpanKind())
+	assert.Equal(t, []attribute.KeyValue{attribute.String("messaging.system", "rabbitmq")}, got.Attributes())
+
+	got, ok = te.GetSpan("plain")
+	require.True(t, ok)
+	assert.Equal(t, trace.SpanKindInternal, got.SpanKind())
+	assert.Empty(t, got.Attributes())
+}
@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal/glob"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// SamplingRule selects the Sampler used for the spans it matches. A span
+// matches a rule if it matches all of the non-zero criteria of the rule.
+type SamplingRule struct {
+	// SpanName is the pattern a span name needs to match. A "*" in the
+	// pattern matches any sequence of characters, including "/" (e.g.
+	// "/api/*"), a pattern without "*" only matches a name exactly. If
+	// empty, all span names match.
+	SpanName string
+
+	// SpanKind is the kind a span needs to be. If unspecified, all span
+	// kinds match.
+	SpanKind trace.SpanKind
+
+	// Attributes are the attributes a span needs to be started with. A span
+	// matches if all of these attributes are present with an equal value.
+	Attributes []attribute.KeyValue
+
+	// Sampler makes the sampling decision for the matching spans. If nil,
+	// matching spans are dropped.
+	Sampler Sampler
+}
+
+func (r SamplingRule) matches(p SamplingParameters) bool {
+	if r.SpanName != "" {
+		if !glob.Match(r.SpanName, p.Name) {
+			return false
+		}
+	}
+	if r.SpanKind != trace.SpanKindUnspecified && r.SpanKind != p.Kind {
+		return false
+	}
+	for _, want := range r.Attributes {
+		if !hasAttribute(p.Attributes, want) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r SamplingRule) String() string {
+	var criteria []string
+	if r.SpanName != "" {
+		criteria = append(criteria, "name:"+r.SpanName)
+	}
+	if r.SpanKind != trace.SpanKindUnspecified {
+		criteria = append(criteria, "kind:"+r.SpanKind.String())
+	}
+	for _, kv := range r.Attributes {
+		criteria = append(criteria, "attribute:"+kv.String())
+	}
+	return fmt.Sprintf("{%s,sampler:%s}", strings.Join(criteria, ","), r.Sampler.Description())
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if kv.Key == want.Key && kv.Value == want.Value {
+			return true
+		}
+	}
+	return false
+}
+
+type ruleBased struct {
+	rules    []SamplingRule
+	fallback Sampler
+}
+
+func (rb ruleBased) ShouldSample(p SamplingParameters) SamplingResult {
+	for _, r := range rb.rules {
+		if r.matches(p) {
+			return r.Sampler.ShouldSample(p)
+		}
+	}
+	return rb.fallback.ShouldSample(p)
+}
+
+func (rb ruleBased) Description() string {
+	rules := make([]string, len(rb.rules))
+	for i, r := range rb.rules {
+		rules[i] = r.String()
+	}
+	return fmt.Sprintf("RuleBased{rules:[%s],fallback:%s}",
+		strings.Join(rules, ","),
+		rb.fallback.Description(),
+	)
+}
+
+// RuleBased returns a Sampler that evaluates rules in order and delegates
+// the sampling decision of a span to the Sampler of the first rule it
+// matches. The fallback Sampler is used for spans that match no rule. A nil
+// fallback drops those spans.
+//
+// For example, to drop health checks, always sample errors-prone routes, and
+// sample 1% of all other traces:
+//
+//	sampler := RuleBased(TraceIDRatioBased(0.01),
+//		SamplingRule{SpanName: "/healthz"},
+//		SamplingRule{SpanName: "/checkout/*", Sampler: AlwaysSample()},
+//	)
+//
+// To respect the parent trace's `SampledFlag`, the `RuleBased` sampler
+// should be used as a delegate of a `Parent` sampler.
+func RuleBased(fallback Sampler, rules ...SamplingRule) Sampler {
+	rb := ruleBased{
+		rules:    make([]SamplingRule, len(rules)),
+		fallback: fallback,
+	}
+	if rb.fallback == nil {
+		rb.fallback = NeverSample()
+	}
+	for i, r := range rules {
+		if r.Sampler == nil {
+			r.Sampler = NeverSample()
+		}
+		r.Attributes = append([]attribute.KeyValue(nil), r.Attributes...)
+		rb.rules[i] = r
+	}
+	return rb
+}
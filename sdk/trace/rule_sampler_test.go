@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRuleBasedSampler(t *testing.T) {
+	sampler := RuleBased(AlwaysSample(),
+		SamplingRule{SpanName: "/healthz"},
+		SamplingRule{SpanName: "/api/*", SpanKind: trace.SpanKindServer, Sampler: RecordUnsampled(NeverSample())},
+		SamplingRule{Attributes: []attribute.KeyValue{attribute.String("tenant", "noisy")}, Sampler: NeverSample()},
+		SamplingRule{SpanName: "/api/*", Sampler: AlwaysSample()},
+	)
+
+	tests := []struct {
+		name   string
+		params SamplingParameters
+		want   SamplingDecision
+	}{
+		{
+			name:   "exact name",
+			params: SamplingParameters{Name: "/healthz"},
+			want:   Drop,
+		},
+		{
+			name:   "name prefix and kind",
+			params: SamplingParameters{Name: "/api/users", Kind: trace.SpanKindServer},
+			want:   RecordOnly,
+		},
+		{
+			name:   "kind mismatch falls through",
+			params: SamplingParameters{Name: "/api/users", Kind: trace.SpanKindClient},
+			want:   RecordAndSample,
+		},
+		{
+			name: "attribute",
+			params: SamplingParameters{
+				Name:       "/api/users",
+				Attributes: []attribute.KeyValue{attribute.Int("id", 1), attribute.String("tenant", "noisy")},
+			},
+			want: Drop,
+		},
+		{
+			name: "attribute value mismatch",
+			params: SamplingParameters{
+				Name:       "/other",
+				Attributes: []attribute.KeyValue{attribute.String("tenant", "quiet")},
+			},
+			want: RecordAndSample,
+		},
+		{
+			name:   "fallback",
+			params: SamplingParameters{Name: "/healthz/live"},
+			want:   RecordAndSample,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.params.ParentContext = context.Background()
+			assert.Equal(t, tc.want, sampler.ShouldSample(tc.params).Decision)
+		})
+	}
+}
+
+func TestRuleBasedSamplerNilFallback(t *testing.T) {
+	sampler := RuleBased(nil, SamplingRule{SpanName: "sampled", Sampler: AlwaysSample()})
+	ctx := context.Background()
+
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(SamplingParameters{ParentContext: ctx, Name: "sampled"}).Decision)
+	assert.Equal(t, Drop, sampler.ShouldSample(SamplingParameters{ParentContext: ctx, Name: "other"}).Decision)
+}
+
+func TestRuleBasedSamplerRulesAreCopied(t *testing.T) {
+	attrs := []attribute.KeyValue{attribute.String("k", "v")}
+	rules := []SamplingRule{{Attributes: attrs, Sampler: NeverSample()}}
+	sampler := RuleBased(AlwaysSample(), rules...)
+
+	rules[0].Sampler = AlwaysSample()
+	attrs[0] = attribute.String("k", "changed")
+
+	params := SamplingParameters{
+		ParentContext: context.Background(),
+		Attributes:    []attribute.KeyValue{attribute.String("k", "v")},
+	}
+	assert.Equal(t, Drop, sampler.ShouldSample(params).Decision)
+}
+
+func TestRuleBasedSamplerDescription(t *testing.T) {
+	sampler := RuleBased(TraceIDRatioBased(0.01),
+		SamplingRule{SpanName: "/healthz"},
+		SamplingRule{
+			SpanKind:   trace.SpanKindServer,
+			Attributes: []attribute.KeyValue{attribute.String("k", "v")},
+			Sampler:    AlwaysSample(),
+		},
+	)
+	assert.Equal(t,
+		"RuleBased{rules:[{name:/healthz,sampler:AlwaysOffSampler},{kind:server,attribute:k=v,sampler:AlwaysOnSampler}],fallback:TraceIDRatioBased{0.01}}",
+		sampler.Description(),
+	)
+}
@@ -18,8 +18,10 @@ import (
 	"context"
 	"encoding/binary"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/internal/ratelimit"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -107,6 +109,45 @@ func TraceIDRatioBased(fraction float64) Sampler {
 	}
 }
 
+type rateLimitingSampler struct {
+	tracesPerSecond float64
+	limiter         *ratelimit.Limiter
+}
+
+func (rs *rateLimitingSampler) ShouldSample(p SamplingParameters) SamplingResult {
+	decision := Drop
+	if rs.limiter.Take() {
+		decision = RecordAndSample
+	}
+	return SamplingResult{
+		Decision:   decision,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+func (rs *rateLimitingSampler) Description() string {
+	return fmt.Sprintf("RateLimited{%g}", rs.tracesPerSecond)
+}
+
+// RateLimited samples at most tracesPerSecond traces per second using a
+// token bucket. Bursts of up to one second worth of traces, and at least
+// one trace, are sampled. A rate <= 0 samples no traces. To respect the
+// parent trace's `SampledFlag`, the `RateLimited` sampler should be used as
+// a delegate of a `Parent` sampler.
+func RateLimited(tracesPerSecond float64) Sampler {
+	if tracesPerSecond <= 0 {
+		return NeverSample()
+	}
+	return newRateLimitingSampler(tracesPerSecond, time.Now)
+}
+
+func newRateLimitingSampler(tracesPerSecond float64, now func() time.Time) *rateLimitingSampler {
+	return &rateLimitingSampler{
+		tracesPerSecond: tracesPerSecond,
+		limiter:         ratelimit.NewLimiter(tracesPerSecond, tracesPerSecond, now),
+	}
+}
+
 type alwaysOnSampler struct{}
 
 func (as alwaysOnSampler) ShouldSample(p SamplingParameters) SamplingResult {
@@ -146,6 +187,31 @@ func NeverSample() Sampler {
 	return alwaysOffSampler{}
 }
 
+// RecordUnsampled returns a Sampler that makes the same sampling decision
+// as delegate, except spans delegate would drop are still recorded. These
+// spans are not sampled, and therefore not exported by the batch or simple
+// span processors, but are passed to all registered SpanProcessors when
+// they end. This is intended to be used with a FlightRecorder.
+func RecordUnsampled(delegate Sampler) Sampler {
+	return recordUnsampled{delegate: delegate}
+}
+
+type recordUnsampled struct {
+	delegate Sampler
+}
+
+func (rs recordUnsampled) ShouldSample(p SamplingParameters) SamplingResult {
+	result := rs.delegate.ShouldSample(p)
+	if result.Decision == Drop {
+		result.Decision = RecordOnly
+	}
+	return result
+}
+
+func (rs recordUnsampled) Description() string {
+	return fmt.Sprintf("RecordUnsampled{%s}", rs.delegate.Description())
+}
+
 // ParentBased returns a composite sampler which behaves differently,
 // based on the parent of the span. If the span has no parent,
 // the root(Sampler) is used to make sampling decision. If the span has
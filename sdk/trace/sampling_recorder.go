@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/internal/global"
+)
+
+const (
+	DefaultSamplingRecorderMaxSpanNames = 1000
+
+	// OtherSpanNames is the span name the decisions for spans are recorded
+	// under once a SamplingRecorder recorded the maximum number of span
+	// names.
+	OtherSpanNames = "(other)"
+)
+
+type SamplingRecorderOption func(o *SamplingRecorderOptions)
+
+type SamplingRecorderOptions struct {
+	// MaxSpanNames is the maximum number of span names decisions are
+	// recorded for. The decisions for the spans with other names are
+	// recorded under OtherSpanNames.
+	// The default value of MaxSpanNames is 1000.
+	MaxSpanNames int
+
+	// Log, if true, logs every sampling decision at the debug level of
+	// the Logger set with otel.SetLogger.
+	// The default value of Log is false.
+	Log bool
+}
+
+// SamplingStats are the sampling decisions a SamplingRecorder recorded for
+// spans with the same name.
+type SamplingStats struct {
+	// SpanName is the name of the spans.
+	SpanName string
+
+	// Drop, RecordOnly, and RecordAndSample are the number of times each
+	// SamplingDecision was made.
+	Drop            uint64
+	RecordOnly      uint64
+	RecordAndSample uint64
+
+	// LastDecision and LastAttributes are the SamplingDecision and the
+	// attributes of the SamplingResult of the last decision.
+	LastDecision   SamplingDecision
+	LastAttributes []attribute.KeyValue
+}
+
+// SamplingRecorder is a Sampler that records the decisions of the Sampler
+// it wraps by span name, to find out why spans are, or are not, sampled in
+// a running process. The recorded decisions are returned by Stats, the
+// zpages package serves them on a debug page. The sampling decisions of a
+// TracerProvider are also reported to the SelfObserver set with
+// WithSelfObserver.
+type SamplingRecorder struct {
+	delegate Sampler
+	o        SamplingRecorderOptions
+
+	// stats maps the span names to their *samplingEntry. Decisions for
+	// the names already recorded only use atomic operations, mu is held
+	// to add a name.
+	stats sync.Map
+	mu    sync.Mutex
+	names int
+}
+
+// samplingEntry holds the decisions recorded for a span name.
+type samplingEntry struct {
+	// The counters are first to be aligned for 64-bit atomic
+	// operations.
+	drop            uint64
+	recordOnly      uint64
+	recordAndSample uint64
+
+	// last holds the lastDecision.
+	last atomic.Value
+}
+
+// lastDecision is the last decision recorded for a span name.
+type lastDecision struct {
+	decision   SamplingDecision
+	attributes []attribute.KeyValue
+}
+
+var _ Sampler = (*SamplingRecorder)(nil)
+
+// NewSamplingRecorder returns a SamplingRecorder making the sampling
+// decisions of delegate, configured with options.
+func NewSamplingRecorder(delegate Sampler, options ...SamplingRecorderOption) *SamplingRecorder {
+	o := SamplingRecorderOptions{
+		MaxSpanNames: DefaultSamplingRecorderMaxSpanNames,
+	}
+	for _, opt := range options {
+		opt(&o)
+	}
+	if o.MaxSpanNames <= 0 {
+		o.MaxSpanNames = DefaultSamplingRecorderMaxSpanNames
+	}
+	return &SamplingRecorder{
+		delegate: delegate,
+		o:        o,
+	}
+}
+
+// WithMaxSampledSpanNames returns a SamplingRecorderOption that sets the
+// maximum number of span names decisions are recorded for.
+func WithMaxSampledSpanNames(n int) SamplingRecorderOption {
+	return func(o *SamplingRecorderOptions) {
+		o.MaxSpanNames = n
+	}
+}
+
+// WithSamplingLog returns a SamplingRecorderOption that logs every sampling
+// decision at the debug level.
+func WithSamplingLog() SamplingRecorderOption {
+	return func(o *SamplingRecorderOptions) {
+		o.Log = true
+	}
+}
+
+// ShouldSample returns the sampling decision of the wrapped Sampler and
+// records it.
+func (sr *SamplingRecorder) ShouldSample(p SamplingParameters) SamplingResult {
+	result := sr.delegate.ShouldSample(p)
+	sr.record(p.Name, result)
+	if sr.o.Log {
+		global.Debug("sampling decision",
+			"span", p.Name,
+			"trace_id", p.TraceID,
+			"sampler", sr.delegate.Description(),
+			"decision", decisionName(result.Decision),
+			"attributes", result.Attributes,
+		)
+	}
+	return result
+}
+
+func (sr *SamplingRecorder) record(name string, result SamplingResult) {
+	e := sr.entry(name)
+	switch result.Decision {
+	case Drop:
+		atomic.AddUint64(&e.drop, 1)
+	case RecordOnly:
+		atomic.AddUint64(&e.recordOnly, 1)
+	case RecordAndSample:
+		atomic.AddUint64(&e.recordAndSample, 1)
+	}
+	e.last.Store(lastDecision{decision: result.Decision, attributes: result.Attributes})
+}
+
+// entry returns the entry the decisions for the spans named name are
+// recorded in, adding it if needed.
+func (sr *SamplingRecorder) entry(name string) *samplingEntry {
+	if e, ok := sr.stats.Load(name); ok {
+		return e.(*samplingEntry)
+	}
+
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	if e, ok := sr.stats.Load(name); ok {
+		return e.(*samplingEntry)
+	}
+	if sr.names >= sr.o.MaxSpanNames {
+		name = OtherSpanNames
+		if e, ok := sr.stats.Load(name); ok {
+			return e.(*samplingEntry)
+		}
+	}
+	e := new(samplingEntry)
+	sr.stats.Store(name, e)
+	sr.names++
+	return e
+}
+
+// Stats returns the recorded decisions sorted by span name.
+func (sr *SamplingRecorder) Stats() []SamplingStats {
+	var stats []SamplingStats
+	sr.stats.Range(func(key, value interface{}) bool {
+		e := value.(*samplingEntry)
+		s := SamplingStats{
+			SpanName:        key.(string),
+			Drop:            atomic.LoadUint64(&e.drop),
+			RecordOnly:      atomic.LoadUint64(&e.recordOnly),
+			RecordAndSample: atomic.LoadUint64(&e.recordAndSample),
+		}
+		if last, ok := e.last.Load().(lastDecision); ok {
+			s.LastDecision = last.decision
+			s.LastAttributes = last.attributes
+		}
+		stats = append(stats, s)
+		return true
+	})
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].SpanName < stats[j].SpanName
+	})
+	return stats
+}
+
+// Reset discards the recorded decisions. Decisions recorded concurrently
+// may be discarded as well.
+func (sr *SamplingRecorder) Reset() {
+	sr.mu.Lock()
+	defer sr.mu.Unlock()
+	sr.stats.Range(func(key, _ interface{}) bool {
+		sr.stats.Delete(key)
+		return true
+	})
+	sr.names = 0
+}
+
+// Description returns the description of the wrapped Sampler.
+func (sr *SamplingRecorder) Description() string {
+	return fmt.Sprintf("SamplingRecorder{%s}", sr.delegate.Description())
+}
+
+func decisionName(d SamplingDecision) string {
+	switch d {
+	case Drop:
+		return "drop"
+	case RecordOnly:
+		return "record_only"
+	case RecordAndSample:
+		return "record_and_sample"
+	}
+	return fmt.Sprintf("SamplingDecision(%d)", d)
+}
@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// attributeSampler samples the spans named "sampled" with an attribute.
+type attributeSampler struct{}
+
+func (attributeSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if p.Name != "sampled" {
+		return sdktrace.SamplingResult{Decision: sdktrace.Drop}
+	}
+	return sdktrace.SamplingResult{
+		Decision:   sdktrace.RecordAndSample,
+		Attributes: []attribute.KeyValue{attribute.String("reason", "name")},
+	}
+}
+
+func (attributeSampler) Description() string { return "attributeSampler" }
+
+func TestSamplingRecorder(t *testing.T) {
+	sr := sdktrace.NewSamplingRecorder(attributeSampler{}, sdktrace.WithSamplingLog())
+	assert.Equal(t, "SamplingRecorder{attributeSampler}", sr.Description())
+	tr := sdktrace.NewTracerProvider(sdktrace.WithSampler(sr)).Tracer("TestSamplingRecorder")
+
+	for _, name := range []string{"sampled", "dropped", "sampled"} {
+		_, span := tr.Start(context.Background(), name)
+		span.End()
+	}
+
+	assert.Equal(t, []sdktrace.SamplingStats{
+		{
+			SpanName:     "dropped",
+			Drop:         1,
+			LastDecision: sdktrace.Drop,
+		},
+		{
+			SpanName:        "sampled",
+			RecordAndSample: 2,
+			LastDecision:    sdktrace.RecordAndSample,
+			LastAttributes:  []attribute.KeyValue{attribute.String("reason", "name")},
+		},
+	}, sr.Stats())
+
+	sr.Reset()
+	assert.Len(t, sr.Stats(), 0)
+}
+
+func TestSamplingRecorderMaxSpanNames(t *testing.T) {
+	sr := sdktrace.NewSamplingRecorder(sdktrace.AlwaysSample(), sdktrace.WithMaxSampledSpanNames(2))
+	for _, name := range []string{"a", "b", "c", "a", "d"} {
+		sr.ShouldSample(sdktrace.SamplingParameters{
+			ParentContext: context.Background(),
+			TraceID:       trace.TraceID{0x01},
+			Name:          name,
+		})
+	}
+
+	stats := sr.Stats()
+	require.Len(t, stats, 3)
+	assert.Equal(t, sdktrace.OtherSpanNames, stats[0].SpanName)
+	assert.Equal(t, uint64(2), stats[0].RecordAndSample)
+	assert.Equal(t, "a", stats[1].SpanName)
+	assert.Equal(t, uint64(2), stats[1].RecordAndSample)
+	assert.Equal(t, "b", stats[2].SpanName)
+}
+
+func TestSamplingRecorderConcurrent(t *testing.T) {
+	sr := sdktrace.NewSamplingRecorder(sdktrace.AlwaysSample(), sdktrace.WithMaxSampledSpanNames(4))
+	const goroutines, decisions = 8, 100
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < decisions; j++ {
+				sr.ShouldSample(sdktrace.SamplingParameters{
+					ParentContext: context.Background(),
+					TraceID:       trace.TraceID{0x01},
+					Name:          fmt.Sprintf("span-%d", (i+j)%8),
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	stats := sr.Stats()
+	assert.Len(t, stats, 5)
+	var total uint64
+	for _, s := range stats {
+		total += s.RecordAndSample
+	}
+	assert.Equal(t, uint64(goroutines*decisions), total)
+}
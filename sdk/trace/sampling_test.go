@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -236,6 +237,18 @@ func TestTracestateIsPassed(t *testing.T) {
 			"traceIDRatioSampler",
 			TraceIDRatioBased(.5),
 		},
+		{
+			"recordUnsampled",
+			RecordUnsampled(NeverSample()),
+		},
+		{
+			"rateLimited",
+			RateLimited(1),
+		},
+		{
+			"ruleBased",
+			RuleBased(AlwaysSample(), SamplingRule{SpanName: "*"}),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -258,3 +271,82 @@ func TestTracestateIsPassed(t *testing.T) {
 		})
 	}
 }
+
+func TestRecordUnsampled(t *testing.T) {
+	params := SamplingParameters{ParentContext: context.Background()}
+
+	assert.Equal(t, RecordOnly, RecordUnsampled(NeverSample()).ShouldSample(params).Decision)
+	assert.Equal(t, RecordAndSample, RecordUnsampled(AlwaysSample()).ShouldSample(params).Decision)
+	assert.Equal(t, "RecordUnsampled{AlwaysOffSampler}", RecordUnsampled(NeverSample()).Description())
+}
+
+func TestRateLimitedSampler(t *testing.T) {
+	now := time.Unix(0, 0)
+	clock := func() time.Time { return now }
+	sampler := newRateLimitingSampler(2, clock)
+	params := SamplingParameters{ParentContext: context.Background()}
+
+	// The bucket starts full.
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, Drop, sampler.ShouldSample(params).Decision)
+
+	now = now.Add(250 * time.Millisecond)
+	assert.Equal(t, Drop, sampler.ShouldSample(params).Decision)
+	now = now.Add(250 * time.Millisecond)
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, Drop, sampler.ShouldSample(params).Decision)
+
+	// The balance never exceeds one second worth of traces.
+	now = now.Add(time.Minute)
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision)
+	assert.Equal(t, Drop, sampler.ShouldSample(params).Decision)
+}
+
+func TestRateLimitedSamplerFractionalRate(t *testing.T) {
+	now := time.Unix(0, 0)
+	sampler := newRateLimitingSampler(0.5, func() time.Time { return now })
+	params := SamplingParameters{ParentContext: context.Background()}
+
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision)
+	now = now.Add(time.Second)
+	assert.Equal(t, Drop, sampler.ShouldSample(params).Decision)
+	now = now.Add(time.Second)
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(params).Decision)
+}
+
+func TestRateLimitedSamplerNonPositiveRate(t *testing.T) {
+	params := SamplingParameters{ParentContext: context.Background()}
+	for _, rate := range []float64{0, -1} {
+		assert.Equal(t, Drop, RateLimited(rate).ShouldSample(params).Decision)
+	}
+}
+
+func TestRateLimitedSamplerDescription(t *testing.T) {
+	assert.Equal(t, "RateLimited{2.5}", RateLimited(2.5).Description())
+	assert.Equal(t,
+		"ParentBased{root:RateLimited{10},remoteParentSampled:AlwaysOnSampler,remoteParentNotSampled:AlwaysOffSampler,localParentSampled:AlwaysOnSampler,localParentNotSampled:AlwaysOffSampler}",
+		ParentBased(RateLimited(10)).Description(),
+	)
+}
+
+func TestRateLimitedSamplerParentBased(t *testing.T) {
+	now := time.Unix(0, 0)
+	sampler := ParentBased(newRateLimitingSampler(1, func() time.Time { return now }))
+
+	root := SamplingParameters{ParentContext: context.Background()}
+	assert.Equal(t, RecordAndSample, sampler.ShouldSample(root).Decision)
+	assert.Equal(t, Drop, sampler.ShouldSample(root).Decision)
+
+	// Children of sampled parents are not subject to the rate limit.
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x01},
+		TraceFlags: trace.FlagsSampled,
+	})
+	child := SamplingParameters{ParentContext: trace.ContextWithSpanContext(context.Background(), parent)}
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, RecordAndSample, sampler.ShouldSample(child).Decision)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SelfObserver observes the operation of a TracerProvider configured with
+// WithSelfObserver, e.g. to record it with metrics. Its methods are called
+// synchronously by the TracerProvider and its span processors, they must be
+// safe for concurrent use and should return quickly.
+type SelfObserver interface {
+	// ObserveSamplingDecision is called with the decision of the
+	// Sampler for each started span.
+	ObserveSamplingDecision(ctx context.Context, d SamplingDecision)
+	// ObserveDroppedSpan is called for each span dropped because the
+	// queue of a batch span processor was full.
+	ObserveDroppedSpan(ctx context.Context)
+	// ObserveExport is called after each export of spanCount spans
+	// by a span processor, "batch" or "simple", that took d and
+	// returned err.
+	ObserveExport(ctx context.Context, processor string, spanCount int, d time.Duration, err error)
+	// ObserveQueueLength is called once, when the TracerProvider is
+	// created, with a function returning the number of spans waiting
+	// in the queues of its batch span processors.
+	ObserveQueueLength(length func() int)
+}
+
+const (
+	batchProcessor  = "batch"
+	simpleProcessor = "simple"
+)
+
+// selfMetrics reports the operation of a TracerProvider to its
+// SelfObserver. All methods are no-ops on a nil *selfMetrics.
+type selfMetrics struct {
+	observer SelfObserver
+
+	queuesMu sync.Mutex
+	// queues are the batch span processors whose queue length is
+	// observed.
+	queues map[*batchSpanProcessor]struct{}
+}
+
+func newSelfMetrics(o SelfObserver) *selfMetrics {
+	sm := &selfMetrics{observer: o, queues: map[*batchSpanProcessor]struct{}{}}
+	o.ObserveQueueLength(sm.queueLength)
+	return sm
+}
+
+func (sm *selfMetrics) recordSamplingDecision(ctx context.Context, d SamplingDecision) {
+	if sm == nil {
+		return
+	}
+	sm.observer.ObserveSamplingDecision(ctx, d)
+}
+
+func (sm *selfMetrics) recordDropped(ctx context.Context) {
+	if sm == nil {
+		return
+	}
+	sm.observer.ObserveDroppedSpan(ctx)
+}
+
+// recordExport records the export of spanCount spans by processor that
+// took d and returned err.
+func (sm *selfMetrics) recordExport(ctx context.Context, processor string, spanCount int, d time.Duration, err error) {
+	if sm == nil {
+		return
+	}
+	sm.observer.ObserveExport(ctx, processor, spanCount, d, err)
+}
+
+func (sm *selfMetrics) addQueue(bsp *batchSpanProcessor) {
+	sm.queuesMu.Lock()
+	defer sm.queuesMu.Unlock()
+	sm.queues[bsp] = struct{}{}
+}
+
+func (sm *selfMetrics) removeQueue(bsp *batchSpanProcessor) {
+	if sm == nil {
+		return
+	}
+	sm.queuesMu.Lock()
+	defer sm.queuesMu.Unlock()
+	delete(sm.queues, bsp)
+}
+
+func (sm *selfMetrics) queueLength() int {
+	sm.queuesMu.Lock()
+	defer sm.queuesMu.Unlock()
+	var n int
+	for bsp := range sm.queues {
+		n += bsp.queue.len()
+	}
+	return n
+}
+
+// selfInstrumented is implemented by the span processors of this package
+// that export spans. Once its metrics are set, a selfInstrumented records
+// its exports with them.
+type selfInstrumented interface {
+	setSelfMetrics(*selfMetrics)
+}
+
+// selfMetricsValue holds the self-observability metrics of a span
+// processor.
+type selfMetricsValue struct {
+	v atomic.Value
+}
+
+func (s *selfMetricsValue) setSelfMetrics(sm *selfMetrics) {
+	s.v.Store(sm)
+}
+
+// selfMetrics returns the metrics, or nil if none are set.
+func (s *selfMetricsValue) selfMetrics() *selfMetrics {
+	sm, _ := s.v.Load().(*selfMetrics)
+	return sm
+}
+
+// WithSelfObserver returns a TracerProviderOption that reports the
+// operation of the TracerProvider to o: the sampling decisions of started
+// spans and, for the span processors of this package registered with it,
+// their exports and, for the batch span processor, the length of their
+// queue and the spans they dropped. The
+// go.opentelemetry.io/otel/sdk/trace/selfmetrics module records them with
+// metrics.
+//
+// By default, or if o is nil, the operation of the TracerProvider is not
+// observed.
+func WithSelfObserver(o SelfObserver) TracerProviderOption {
+	return func(cfg *TracerProviderConfig) {
+		cfg.selfObserver = o
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type observedExport struct {
+	processor string
+	spanCount int
+	err       error
+}
+
+// recordingObserver is a SelfObserver keeping what it observes.
+type recordingObserver struct {
+	mu          sync.Mutex
+	decisions   []sdktrace.SamplingDecision
+	dropped     int
+	exports     []observedExport
+	queueLength func() int
+}
+
+func (o *recordingObserver) ObserveSamplingDecision(_ context.Context, d sdktrace.SamplingDecision) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.decisions = append(o.decisions, d)
+}
+
+func (o *recordingObserver) ObserveDroppedSpan(context.Context) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.dropped++
+}
+
+func (o *recordingObserver) ObserveExport(_ context.Context, processor string, spanCount int, _ time.Duration, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.exports = append(o.exports, observedExport{processor: processor, spanCount: spanCount, err: err})
+}
+
+func (o *recordingObserver) ObserveQueueLength(length func() int) {
+	o.queueLength = length
+}
+
+func TestSelfObserver(t *testing.T) {
+	errExport := errors.New("export failed")
+	observer := &recordingObserver{}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSelfObserver(observer),
+		sdktrace.WithBatcher(discardExporter{}),
+		sdktrace.WithSyncer(tracetest.NewInMemoryExporter()),
+		sdktrace.WithSpanProcessor(sdktrace.NewFilterProcessor(
+			sdktrace.NewSimpleSpanProcessor(&failingExporter{err: errExport}),
+		)),
+	)
+	tr := tp.Tracer("TestSelfObserver")
+
+	ctx := context.Background()
+	_, span := tr.Start(ctx, "sampled")
+	span.End()
+	unsampled := trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	}))
+	_, span = tr.Start(unsampled, "dropped")
+	span.End()
+	_ = tp.Shutdown(ctx)
+
+	assert.Equal(t, []sdktrace.SamplingDecision{sdktrace.RecordAndSample, sdktrace.Drop}, observer.decisions)
+	// The simple span processors export in OnEnd, the batch span processor
+	// when it is shut down.
+	assert.Equal(t, []observedExport{
+		{processor: "simple", spanCount: 1},
+		{processor: "simple", spanCount: 1, err: errExport},
+		{processor: "batch", spanCount: 1},
+	}, observer.exports)
+	require.NotNil(t, observer.queueLength)
+	assert.Equal(t, 0, observer.queueLength(), "the queue of a shut down processor is not observed")
+	assert.Equal(t, 0, observer.dropped)
+}
+
+func TestSelfObserverDroppedSpans(t *testing.T) {
+	observer := &recordingObserver{}
+	exp := &failingExporter{release: make(chan struct{})}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSelfObserver(observer),
+		sdktrace.WithBatcher(exp, sdktrace.WithMaxQueueSize(1), sdktrace.WithMaxExportBatchSize(1)),
+	)
+	tr := tp.Tracer("TestSelfObserverDroppedSpans")
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		_, span := tr.Start(ctx, "span")
+		span.End()
+	}
+	queued := observer.queueLength()
+	close(exp.release)
+	require.NoError(t, tp.Shutdown(ctx))
+
+	// The exporter blocks on the first span it is passed, at most one more
+	// span is queued and one in the batch to export.
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	assert.GreaterOrEqual(t, observer.dropped, 7)
+	assert.LessOrEqual(t, queued, 1)
+}
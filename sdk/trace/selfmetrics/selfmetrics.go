@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package selfmetrics records the operation of the TracerProvider of
+// go.opentelemetry.io/otel/sdk/trace with metrics:
+//
+//	tp := sdktrace.NewTracerProvider(
+//		sdktrace.WithSelfObserver(selfmetrics.NewObserver(mp)),
+//		sdktrace.WithBatcher(exporter),
+//	)
+//
+// It is a separate module so that the trace SDK does not depend on the
+// metric API.
+package selfmetrics // import "go.opentelemetry.io/otel/sdk/trace/selfmetrics"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/unit"
+)
+
+const instrumentationName = "go.opentelemetry.io/otel/sdk/trace"
+
+// SamplingDecisionKey, SpanProcessorKey, and ExportSuccessKey are the
+// attribute keys of the metrics recorded by an Observer. They hold the
+// SamplingDecision of a sampled span, "batch" or "simple" for the span
+// processor that exported spans, and the outcome of an export.
+const (
+	SamplingDecisionKey = attribute.Key("sdk.trace.sampling.decision")
+	SpanProcessorKey    = attribute.Key("sdk.trace.span_processor")
+	ExportSuccessKey    = attribute.Key("sdk.trace.export.success")
+)
+
+var samplingDecisions = map[sdktrace.SamplingDecision]attribute.KeyValue{
+	sdktrace.Drop:            SamplingDecisionKey.String("drop"),
+	sdktrace.RecordOnly:      SamplingDecisionKey.String("record_only"),
+	sdktrace.RecordAndSample: SamplingDecisionKey.String("record_and_sample"),
+}
+
+// Observer is a SelfObserver of the trace SDK recording what it observes
+// with metrics.
+type Observer struct {
+	sampled        metric.Int64Counter
+	dropped        metric.Int64Counter
+	exports        metric.Int64Counter
+	exportedSpans  metric.Int64Counter
+	exportDuration metric.Float64ValueRecorder
+
+	queuesMu sync.Mutex
+	// queues return the queue length of the observed TracerProviders.
+	queues []func() int
+}
+
+var _ sdktrace.SelfObserver = (*Observer)(nil)
+
+// NewObserver returns an Observer recording its metrics with a Meter of
+// mp.
+func NewObserver(mp metric.MeterProvider) *Observer {
+	o := &Observer{}
+	m := metric.Must(mp.Meter(instrumentationName))
+	o.sampled = m.NewInt64Counter(
+		"sdk.trace.sampler.decisions",
+		metric.WithDescription("Number of sampling decisions made for started spans"),
+		metric.WithUnit(unit.Dimensionless),
+	)
+	o.dropped = m.NewInt64Counter(
+		"sdk.trace.batch_span_processor.dropped",
+		metric.WithDescription("Number of spans dropped because the queue of a batch span processor was full"),
+		metric.WithUnit(unit.Dimensionless),
+	)
+	m.NewInt64ValueObserver(
+		"sdk.trace.batch_span_processor.queue.length",
+		func(_ context.Context, result metric.Int64ObserverResult) {
+			result.Observe(int64(o.queueLength()))
+		},
+		metric.WithDescription("Number of spans waiting in the queues of the batch span processors"),
+		metric.WithUnit(unit.Dimensionless),
+	)
+	o.exports = m.NewInt64Counter(
+		"sdk.trace.exporter.exports",
+		metric.WithDescription("Number of calls to the SpanExporters of the span processors"),
+		metric.WithUnit(unit.Dimensionless),
+	)
+	o.exportedSpans = m.NewInt64Counter(
+		"sdk.trace.exporter.spans",
+		metric.WithDescription("Number of spans passed to the SpanExporters of the span processors"),
+		metric.WithUnit(unit.Dimensionless),
+	)
+	o.exportDuration = m.NewFloat64ValueRecorder(
+		"sdk.trace.exporter.export.duration",
+		metric.WithDescription("Duration of the calls to the SpanExporters of the span processors"),
+		metric.WithUnit(unit.Milliseconds),
+	)
+	return o
+}
+
+// ObserveSamplingDecision implements sdktrace.SelfObserver.
+func (o *Observer) ObserveSamplingDecision(ctx context.Context, d sdktrace.SamplingDecision) {
+	o.sampled.Add(ctx, 1, samplingDecisions[d])
+}
+
+// ObserveDroppedSpan implements sdktrace.SelfObserver.
+func (o *Observer) ObserveDroppedSpan(ctx context.Context) {
+	o.dropped.Add(ctx, 1)
+}
+
+// ObserveExport implements sdktrace.SelfObserver.
+func (o *Observer) ObserveExport(ctx context.Context, processor string, spanCount int, d time.Duration, err error) {
+	attrs := []attribute.KeyValue{SpanProcessorKey.String(processor), ExportSuccessKey.Bool(err == nil)}
+	o.exports.Add(ctx, 1, attrs...)
+	o.exportedSpans.Add(ctx, int64(spanCount), attrs...)
+	o.exportDuration.Record(ctx, float64(d)/float64(time.Millisecond), attrs...)
+}
+
+// ObserveQueueLength implements sdktrace.SelfObserver.
+func (o *Observer) ObserveQueueLength(length func() int) {
+	o.queuesMu.Lock()
+	defer o.queuesMu.Unlock()
+	o.queues = append(o.queues, length)
+}
+
+func (o *Observer) queueLength() int {
+	o.queuesMu.Lock()
+	defer o.queuesMu.Unlock()
+	var n int
+	for _, length := range o.queues {
+		n += length()
+	}
+	return n
+}
@@ -0,0 +1,145 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfmetrics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/oteltest"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/selfmetrics"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestObserver(t *testing.T) {
+	meter, mp := oteltest.NewMeterProvider()
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSelfObserver(selfmetrics.NewObserver(mp)),
+		sdktrace.WithBatcher(discardExporter{}),
+		sdktrace.WithSyncer(tracetest.NewInMemoryExporter()),
+		sdktrace.WithSpanProcessor(sdktrace.NewFilterProcessor(
+			sdktrace.NewSimpleSpanProcessor(&failingExporter{err: errors.New("export failed")}),
+		)),
+	)
+	tr := tp.Tracer("TestObserver")
+
+	ctx := context.Background()
+	_, span := tr.Start(ctx, "sampled")
+	span.End()
+	unsampled := trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	}))
+	_, span = tr.Start(unsampled, "dropped")
+	span.End()
+	_ = tp.Shutdown(ctx)
+	meter.RunAsyncInstruments()
+
+	got := map[string][]oteltest.Measured{}
+	for _, m := range oteltest.AsStructs(meter.MeasurementBatches) {
+		assert.Equal(t, "go.opentelemetry.io/otel/sdk/trace", m.InstrumentationName)
+		got[m.Name] = append(got[m.Name], m)
+	}
+
+	decisions := got["sdk.trace.sampler.decisions"]
+	require.Len(t, decisions, 2)
+	assert.Equal(t, attribute.StringValue("record_and_sample"), decisions[0].Labels[selfmetrics.SamplingDecisionKey])
+	assert.Equal(t, attribute.StringValue("drop"), decisions[1].Labels[selfmetrics.SamplingDecisionKey])
+
+	// The simple span processors export in OnEnd, the batch span processor
+	// when it is shut down.
+	exports := got["sdk.trace.exporter.exports"]
+	require.Len(t, exports, 3)
+	simple := attribute.StringValue("simple")
+	assert.Equal(t, simple, exports[0].Labels[selfmetrics.SpanProcessorKey])
+	assert.Equal(t, attribute.BoolValue(true), exports[0].Labels[selfmetrics.ExportSuccessKey])
+	assert.Equal(t, simple, exports[1].Labels[selfmetrics.SpanProcessorKey])
+	assert.Equal(t, attribute.BoolValue(false), exports[1].Labels[selfmetrics.ExportSuccessKey])
+	assert.Equal(t, attribute.StringValue("batch"), exports[2].Labels[selfmetrics.SpanProcessorKey])
+	assert.Equal(t, attribute.BoolValue(true), exports[2].Labels[selfmetrics.ExportSuccessKey])
+
+	spans := got["sdk.trace.exporter.spans"]
+	require.Len(t, spans, 3)
+	for _, s := range spans {
+		assert.Equal(t, int64(1), s.Number.AsInt64())
+	}
+	assert.Len(t, got["sdk.trace.exporter.export.duration"], 3)
+
+	queue := got["sdk.trace.batch_span_processor.queue.length"]
+	require.Len(t, queue, 1)
+	assert.Equal(t, int64(0), queue[0].Number.AsInt64(), "the queue of a shut down processor is not observed")
+	assert.Len(t, got["sdk.trace.batch_span_processor.dropped"], 0)
+}
+
+func TestObserverDroppedSpans(t *testing.T) {
+	meter, mp := oteltest.NewMeterProvider()
+	exp := &failingExporter{release: make(chan struct{})}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSelfObserver(selfmetrics.NewObserver(mp)),
+		sdktrace.WithBatcher(exp, sdktrace.WithMaxQueueSize(1), sdktrace.WithMaxExportBatchSize(1)),
+	)
+	tr := tp.Tracer("TestObserverDroppedSpans")
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		_, span := tr.Start(ctx, "span")
+		span.End()
+	}
+	meter.RunAsyncInstruments()
+	close(exp.release)
+	require.NoError(t, tp.Shutdown(ctx))
+
+	var dropped, queued int64
+	for _, m := range oteltest.AsStructs(meter.MeasurementBatches) {
+		switch m.Name {
+		case "sdk.trace.batch_span_processor.dropped":
+			dropped += m.Number.AsInt64()
+		case "sdk.trace.batch_span_processor.queue.length":
+			queued = m.Number.AsInt64()
+		}
+	}
+	// The exporter blocks on the first span it is passed, at most one more
+	// span is queued and one in the batch to export.
+	assert.GreaterOrEqual(t, dropped, int64(7))
+	assert.LessOrEqual(t, queued, int64(1))
+}
+
+type discardExporter struct{}
+
+func (discardExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error { return nil }
+func (discardExporter) Shutdown(context.Context) error                             { return nil }
+
+// failingExporter returns err from ExportSpans, after release is closed
+// if it is not nil.
+type failingExporter struct {
+	err     error
+	release chan struct{}
+}
+
+func (e *failingExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	if e.release != nil {
+		<-e.release
+	}
+	return e.err
+}
+
+func (e *failingExporter) Shutdown(context.Context) error { return nil }
@@ -17,24 +17,27 @@ package trace // import "go.opentelemetry.io/otel/sdk/trace"
 import (
 	"context"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/otel"
-	export "go.opentelemetry.io/otel/sdk/export/trace"
 )
 
 // simpleSpanProcessor is a SpanProcessor that synchronously sends all
 // completed Spans to a trace.Exporter immediately.
 type simpleSpanProcessor struct {
 	exporterMu sync.RWMutex
-	exporter   export.SpanExporter
+	exporter   SpanExporter
 	stopOnce   sync.Once
+
+	dryRunValue
+	selfMetricsValue
 }
 
 var _ SpanProcessor = (*simpleSpanProcessor)(nil)
 
 // NewSimpleSpanProcessor returns a new SpanProcessor that will synchronously
 // send completed spans to the exporter immediately.
-func NewSimpleSpanProcessor(exporter export.SpanExporter) SpanProcessor {
+func NewSimpleSpanProcessor(exporter SpanExporter) SpanProcessor {
 	ssp := &simpleSpanProcessor{
 		exporter: exporter,
 	}
@@ -44,15 +47,25 @@ func NewSimpleSpanProcessor(exporter export.SpanExporter) SpanProcessor {
 // OnStart does nothing.
 func (ssp *simpleSpanProcessor) OnStart(context.Context, ReadWriteSpan) {}
 
+// OnEnding does nothing.
+func (ssp *simpleSpanProcessor) OnEnding(ReadWriteSpan) {}
+
 // OnEnd immediately exports a ReadOnlySpan.
 func (ssp *simpleSpanProcessor) OnEnd(s ReadOnlySpan) {
 	ssp.exporterMu.RLock()
 	defer ssp.exporterMu.RUnlock()
 
 	if ssp.exporter != nil && s.SpanContext().IsSampled() {
-		ss := s.Snapshot()
-		if err := ssp.exporter.ExportSpans(context.Background(), []*export.SpanSnapshot{ss}); err != nil {
-			otel.Handle(err)
+		if c := ssp.counter(); c != nil {
+			c.count([]ReadOnlySpan{s})
+			return
+		}
+		ctx := context.Background()
+		start := time.Now()
+		err := ssp.exporter.ExportSpans(ctx, []ReadOnlySpan{s})
+		ssp.selfMetrics().recordExport(ctx, simpleProcessor, 1, time.Since(start), err)
+		if err != nil {
+			otel.HandleSignal(otel.TracesSignal, err)
 		}
 	}
 }
@@ -24,12 +24,11 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/internal"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 
-	export "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
-	"go.opentelemetry.io/otel/sdk/internal"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
 
@@ -42,6 +41,9 @@ type ReadOnlySpan interface {
 	Name() string
 	SpanContext() trace.SpanContext
 	Parent() trace.SpanContext
+	// IsLocalRoot returns true if the span has no parent or its parent is
+	// remote, meaning it is the first span of the trace in this process.
+	IsLocalRoot() bool
 	SpanKind() trace.SpanKind
 	StartTime() time.Time
 	EndTime() time.Time
@@ -54,7 +56,18 @@ type ReadOnlySpan interface {
 	IsRecording() bool
 	InstrumentationLibrary() instrumentation.Library
 	Resource() *resource.Resource
-	Snapshot() *export.SpanSnapshot
+	// DroppedAttributes returns the number of attributes dropped by the span
+	// due to limits being reached, including those of its events and links.
+	DroppedAttributes() int
+	// DroppedLinks returns the number of links dropped by the span due to
+	// limits being reached.
+	DroppedLinks() int
+	// DroppedEvents returns the number of events dropped by the span due to
+	// limits being reached.
+	DroppedEvents() int
+	// ChildSpanCount returns the count of spans that consider the span a
+	// direct parent.
+	ChildSpanCount() int
 
 	// A private method to prevent users implementing the
 	// interface and so future additions to it will not
@@ -97,6 +110,11 @@ type span struct {
 	// value of time.Time until the span is ended.
 	endTime time.Time
 
+	// ending is true while the registered SpanProcessors are being passed
+	// the span in their OnEnding method. The span remains mutable during
+	// this time even though its endTime has been set.
+	ending bool
+
 	// statusCode represents the status of this span as a codes.Code value.
 	statusCode codes.Code
 
@@ -119,13 +137,13 @@ type span struct {
 
 	// attributes are capped at configured limit. When the capacity is reached
 	// an oldest entry is removed to create room for a new entry.
-	attributes *attributesMap
+	attributes attributesMap
 
 	// messageEvents are stored in FIFO queue capped by configured limit.
-	messageEvents *evictedQueue
+	messageEvents evictedQueue
 
 	// links are stored in FIFO queue capped by configured limit.
-	links *evictedQueue
+	links evictedQueue
 
 	// executionTracerTaskEnd ends the execution tracer span.
 	executionTracerTaskEnd func()
@@ -139,6 +157,11 @@ type span struct {
 
 var _ trace.Span = &span{}
 
+// codeStacktraceKey is the attribute key of the stack trace recorded for
+// events other than exceptions. It is not defined by the semantic
+// conventions.
+const codeStacktraceKey = attribute.Key("code.stacktrace")
+
 // SpanContext returns the SpanContext of this span.
 func (s *span) SpanContext() trace.SpanContext {
 	if s == nil {
@@ -156,7 +179,7 @@ func (s *span) IsRecording() bool {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	return !s.startTime.IsZero() && s.endTime.IsZero()
+	return !s.startTime.IsZero() && (s.endTime.IsZero() || s.ending)
 }
 
 // SetStatus sets the status of this span in the form of a code and a
@@ -188,6 +211,27 @@ func (s *span) SetAttributes(attributes ...attribute.KeyValue) {
 	s.copyToCappedAttributes(attributes...)
 }
 
+// SetAttributeSet sets the attributes contained in set as attributes of this
+// span.
+//
+// The attributes of set are already unique, they are added without being
+// copied into an intermediate slice.
+//
+// If this span is not being recorded than this method does nothing.
+func (s *span) SetAttributeSet(set *attribute.Set) {
+	if set == nil || !s.IsRecording() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	limit := s.spanLimits.AttributeValueLengthLimit
+	for iter := set.Iter(); iter.Next(); {
+		if a := iter.Attribute(); a.Valid() {
+			s.attributes.add(truncateAttr(limit, a))
+		}
+	}
+}
+
 // End ends the span. This method does nothing if the span is already ended or
 // is not being recorded.
 //
@@ -205,7 +249,7 @@ func (s *span) End(options ...trace.SpanOption) {
 
 	// Store the end time as soon as possible to avoid artificially increasing
 	// the span's duration in case some operation below takes a while.
-	et := internal.MonotonicEndTime(s.startTime)
+	et := s.startTime.Add(s.tracer.provider.clock.Since(s.startTime))
 
 	// Do relative expensive check now that we have an end time and see if we
 	// need to do any more processing.
@@ -231,22 +275,59 @@ func (s *span) End(options ...trace.SpanOption) {
 
 	config := trace.NewSpanConfig(options...)
 
+	sps, ok := s.tracer.provider.spanProcessors.Load().(spanProcessorStates)
+	mustExportOrProcess := ok && len(sps) > 0
+	listeners := s.tracer.provider.listeners()
+
 	s.mu.Lock()
+	if s.ending {
+		// End was called from within an OnEnding hook.
+		s.mu.Unlock()
+		return
+	}
 	// Setting endTime to non-zero marks the span as ended and not recording.
 	if config.Timestamp.IsZero() {
 		s.endTime = et
 	} else {
 		s.endTime = config.Timestamp
 	}
+	s.ending = mustExportOrProcess
 	s.mu.Unlock()
 
-	sps, ok := s.tracer.provider.spanProcessors.Load().(spanProcessorStates)
-	mustExportOrProcess := ok && len(sps) > 0
 	if mustExportOrProcess {
+		// The span stays mutable while processors are notified it is
+		// ending so they can add final attributes, events, or status.
+		for _, sp := range sps {
+			sp.sp.OnEnding(s)
+		}
+		s.mu.Lock()
+		s.ending = false
+		s.mu.Unlock()
+	}
+
+	var attrs []attribute.KeyValue
+	if mustExportOrProcess || len(listeners) > 0 {
+		// All processors and listeners share the same immutable
+		// snapshot of the ended span instead of each one copying it.
+		ro := s.snapshot()
 		for _, sp := range sps {
-			sp.sp.OnEnd(s)
+			sp.sp.OnEnd(ro)
 		}
+		for _, l := range listeners {
+			l.l.OnSpanEnd(ro)
+		}
+		attrs = ro.Attributes()
 	}
+
+	// The span is no longer recording, its attributes storage is reused
+	// by other spans. The attributes of the snapshot, which are never
+	// modified, are kept instead.
+	s.mu.Lock()
+	if attrs == nil {
+		attrs = s.attributes.toKeyValue()
+	}
+	s.attributes.release(attrs)
+	s.mu.Unlock()
 }
 
 // RecordError will record err as a span event for this span. An additional call to
@@ -262,6 +343,9 @@ func (s *span) RecordError(err error, opts ...trace.EventOption) {
 		semconv.ExceptionTypeKey.String(typeStr(err)),
 		semconv.ExceptionMessageKey.String(err.Error()),
 	))
+	if reason, _, ok := codes.ErrorReason(err); ok {
+		opts = append(opts, trace.WithAttributes(codes.ReasonKey.String(reason)))
+	}
 	s.addEvent(semconv.ExceptionEventName, opts...)
 }
 
@@ -281,6 +365,10 @@ func (s *span) Tracer() trace.Tracer {
 
 // AddEvent adds an event with the provided name and options. If this span is
 // not being recorded than this method does nothing.
+//
+// With the WithStackTrace option, the stack trace of the calling goroutine
+// is recorded as the code.stacktrace attribute of the event, or as its
+// exception.stacktrace attribute for exception events.
 func (s *span) AddEvent(name string, o ...trace.EventOption) {
 	if !s.IsRecording() {
 		return
@@ -289,13 +377,29 @@ func (s *span) AddEvent(name string, o ...trace.EventOption) {
 }
 
 func (s *span) addEvent(name string, o ...trace.EventOption) {
-	c := trace.NewEventConfig(o...)
+	// The options are applied directly, NewEventConfig would read the
+	// system clock when no timestamp is set.
+	c := new(trace.SpanConfig)
+	for _, opt := range o {
+		opt.ApplyEvent(c)
+	}
+	if c.Timestamp.IsZero() {
+		c.Timestamp = s.tracer.provider.clock.Now()
+	}
+	if c.StackTrace {
+		key := codeStacktraceKey
+		if name == semconv.ExceptionEventName {
+			key = semconv.ExceptionStacktraceKey
+		}
+		c.Attributes = append(c.Attributes, key.String(internal.StackTrace()))
+	}
 
 	// Discard over limited attributes
 	if len(c.Attributes) > s.spanLimits.AttributePerEventCountLimit {
 		s.addDroppedAttributeCount(len(c.Attributes) - s.spanLimits.AttributePerEventCountLimit)
 		c.Attributes = c.Attributes[:s.spanLimits.AttributePerEventCountLimit]
 	}
+	c.Attributes = truncateAttrs(s.spanLimits.AttributeValueLengthLimit, c.Attributes)
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -332,6 +436,19 @@ func (s *span) Parent() trace.SpanContext {
 	return s.parent
 }
 
+// IsLocalRoot returns true if the span has no parent or its parent is
+// remote.
+func (s *span) IsLocalRoot() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return isLocalRoot(s.parent)
+}
+
+// isLocalRoot returns if a span with parent is a local root span.
+func isLocalRoot(parent trace.SpanContext) bool {
+	return !parent.IsValid() || parent.IsRemote()
+}
+
 // SpanKind returns the SpanKind of this span.
 func (s *span) SpanKind() trace.SpanKind {
 	s.mu.Lock()
@@ -358,7 +475,7 @@ func (s *span) EndTime() time.Time {
 func (s *span) Attributes() []attribute.KeyValue {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if s.attributes.evictList.Len() == 0 {
+	if s.attributes.len() == 0 {
 		return []attribute.KeyValue{}
 	}
 	return s.attributes.toKeyValue()
@@ -414,7 +531,10 @@ func (s *span) Resource() *resource.Resource {
 	return s.resource
 }
 
-func (s *span) addLink(link trace.Link) {
+// AddLink adds link to the links of this span, subject to the link count
+// limit: once reached, the oldest links are dropped. If this span is not
+// being recorded than this method does nothing.
+func (s *span) AddLink(link trace.Link) {
 	if !s.IsRecording() {
 		return
 	}
@@ -426,47 +546,80 @@ func (s *span) addLink(link trace.Link) {
 		s.addDroppedAttributeCount(len(link.Attributes) - s.spanLimits.AttributePerLinkCountLimit)
 		link.Attributes = link.Attributes[:s.spanLimits.AttributePerLinkCountLimit]
 	}
+	link.Attributes = truncateAttrs(s.spanLimits.AttributeValueLengthLimit, link.Attributes)
 
 	s.links.add(link)
 }
 
-// Snapshot creates a snapshot representing the current state of the span as an
-// export.SpanSnapshot and returns a pointer to it.
-func (s *span) Snapshot() *export.SpanSnapshot {
-	var sd export.SpanSnapshot
+// DroppedAttributes returns the number of attributes dropped by the span
+// due to limits being reached, including those of its events and links.
+func (s *span) DroppedAttributes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return int(atomic.LoadInt64(&s.droppedAttributeCount)) + s.attributes.droppedCount
+}
+
+// DroppedLinks returns the number of links dropped by the span due to limits
+// being reached.
+func (s *span) DroppedLinks() int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	return s.links.droppedCount
+}
+
+// DroppedEvents returns the number of events dropped by the span due to
+// limits being reached.
+func (s *span) DroppedEvents() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.messageEvents.droppedCount
+}
 
-	sd.ChildSpanCount = s.childSpanCount
-	sd.EndTime = s.endTime
-	sd.InstrumentationLibrary = s.instrumentationLibrary
-	sd.Name = s.name
-	sd.Parent = s.parent
-	sd.Resource = s.resource
-	sd.SpanContext = s.spanContext
-	sd.SpanKind = s.spanKind
-	sd.StartTime = s.startTime
-	sd.StatusCode = s.statusCode
-	sd.StatusMessage = s.statusMessage
-
-	sd.DroppedAttributeCount = int(s.droppedAttributeCount)
-	if s.attributes.evictList.Len() > 0 {
-		sd.Attributes = s.attributes.toKeyValue()
-		sd.DroppedAttributeCount += s.attributes.droppedCount
+// ChildSpanCount returns the count of spans that consider the span a direct
+// parent.
+func (s *span) ChildSpanCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.childSpanCount
+}
+
+// snapshot creates a read-only copy of the current state of the span.
+func (s *span) snapshot() ReadOnlySpan {
+	var sd snapshot
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sd.childSpanCount = s.childSpanCount
+	sd.endTime = s.endTime
+	sd.instrumentationLibrary = s.instrumentationLibrary
+	sd.name = s.name
+	sd.parent = s.parent
+	sd.resource = s.resource
+	sd.spanContext = s.spanContext
+	sd.spanKind = s.spanKind
+	sd.startTime = s.startTime
+	sd.statusCode = s.statusCode
+	sd.statusMessage = s.statusMessage
+	sd.tracer = s.tracer
+
+	sd.droppedAttributeCount = int(atomic.LoadInt64(&s.droppedAttributeCount))
+	if s.attributes.len() > 0 {
+		sd.attributes = s.attributes.toKeyValue()
+		sd.droppedAttributeCount += s.attributes.droppedCount
 	}
 	if len(s.messageEvents.queue) > 0 {
-		sd.MessageEvents = s.interfaceArrayToMessageEventArray()
-		sd.DroppedMessageEventCount = s.messageEvents.droppedCount
+		sd.events = s.interfaceArrayToMessageEventArray()
+		sd.droppedEventCount = s.messageEvents.droppedCount
 	}
 	if len(s.links.queue) > 0 {
-		sd.Links = s.interfaceArrayToLinksArray()
-		sd.DroppedLinkCount = s.links.droppedCount
+		sd.links = s.interfaceArrayToLinksArray()
+		sd.droppedLinkCount = s.links.droppedCount
 	}
 	return &sd
 }
 
 func (s *span) interfaceArrayToLinksArray() []trace.Link {
-	linkArr := make([]trace.Link, 0)
+	linkArr := make([]trace.Link, 0, len(s.links.queue))
 	for _, value := range s.links.queue {
 		linkArr = append(linkArr, value.(trace.Link))
 	}
@@ -474,7 +627,7 @@ func (s *span) interfaceArrayToLinksArray() []trace.Link {
 }
 
 func (s *span) interfaceArrayToMessageEventArray() []trace.Event {
-	messageEventArr := make([]trace.Event, 0)
+	messageEventArr := make([]trace.Event, 0, len(s.messageEvents.queue))
 	for _, value := range s.messageEvents.queue {
 		messageEventArr = append(messageEventArr, value.(trace.Event))
 	}
@@ -488,9 +641,77 @@ func (s *span) copyToCappedAttributes(attributes ...attribute.KeyValue) {
 		// Ensure attributes conform to the specification:
 		// https://github.com/open-telemetry/opentelemetry-specification/blob/v1.0.1/specification/common/common.md#attributes
 		if a.Valid() {
-			s.attributes.add(a)
+			s.attributes.add(truncateAttr(s.spanLimits.AttributeValueLengthLimit, a))
+		}
+	}
+}
+
+// truncateAttrs returns attrs with all values truncated by truncateAttr. The
+// passed slice is not modified, a copy is returned if any value is
+// truncated.
+func truncateAttrs(limit int, attrs []attribute.KeyValue) []attribute.KeyValue {
+	if limit <= 0 {
+		return attrs
+	}
+	var out []attribute.KeyValue
+	for i, a := range attrs {
+		t := truncateAttr(limit, a)
+		if out == nil && t != a {
+			out = make([]attribute.KeyValue, len(attrs))
+			copy(out, attrs[:i])
 		}
+		if out != nil {
+			out[i] = t
+		}
+	}
+	if out == nil {
+		return attrs
 	}
+	return out
+}
+
+// truncateAttr returns attr with its string value, or the string elements
+// of its string slice value, truncated to at most limit characters. A limit <= 0
+// means no limit.
+func truncateAttr(limit int, attr attribute.KeyValue) attribute.KeyValue {
+	if limit <= 0 {
+		return attr
+	}
+	switch attr.Value.Type() {
+	case attribute.STRING:
+		if v, ok := truncate(limit, attr.Value.AsString()); ok {
+			return attr.Key.String(v)
+		}
+	case attribute.STRINGSLICE:
+		vals := attr.Value.AsStringSlice()
+		var truncated bool
+		for i := range vals {
+			var ok bool
+			vals[i], ok = truncate(limit, vals[i])
+			truncated = truncated || ok
+		}
+		if truncated {
+			return attr.Key.StringSlice(vals)
+		}
+	}
+	return attr
+}
+
+// truncate returns s truncated to at most limit characters and whether it
+// was truncated.
+func truncate(limit int, s string) (string, bool) {
+	if len(s) <= limit {
+		// A string with at most limit bytes has at most limit characters.
+		return s, false
+	}
+	var n int
+	for i := range s {
+		if n == limit {
+			return s[:i], true
+		}
+		n++
+	}
+	return s, false
 }
 
 func (s *span) addChild() {
@@ -525,20 +746,27 @@ func startSpanInternal(ctx context.Context, tr *tracer, name string, o *trace.Sp
 	// on a unique span ID, even if the Span is non-recording.
 	var tid trace.TraceID
 	var sid trace.SpanID
+	flags := psc.TraceFlags()
 	if !psc.TraceID().IsValid() {
 		tid, sid = provider.idGenerator.NewIDs(ctx)
+		// A new trace ID is only known to be random if the generator
+		// asserts it.
+		flags &^= trace.FlagsRandom
+		if gen, ok := provider.idGenerator.(RandomTraceIDGenerator); ok && gen.RandomTraceIDs() {
+			flags |= trace.FlagsRandom
+		}
 	} else {
 		tid = psc.TraceID()
 		sid = provider.idGenerator.NewSpanID(ctx, tid)
 	}
 
-	spanLimits := provider.spanLimits
+	spanLimits := tr.getSpanLimits()
 	span.attributes = newAttributesMap(spanLimits.AttributeCountLimit)
 	span.messageEvents = newEvictedQueue(spanLimits.EventCountLimit)
 	span.links = newEvictedQueue(spanLimits.LinkCountLimit)
 	span.spanLimits = spanLimits
 
-	samplingResult := provider.sampler.ShouldSample(SamplingParameters{
+	samplingResult := tr.getSampler().ShouldSample(SamplingParameters{
 		ParentContext: ctx,
 		TraceID:       tid,
 		Name:          name,
@@ -546,6 +774,7 @@ func startSpanInternal(ctx context.Context, tr *tracer, name string, o *trace.Sp
 		Attributes:    o.Attributes,
 		Links:         o.Links,
 	})
+	provider.selfMetrics.recordSamplingDecision(ctx, samplingResult.Decision)
 
 	scc := trace.SpanContextConfig{
 		TraceID:    tid,
@@ -553,9 +782,9 @@ func startSpanInternal(ctx context.Context, tr *tracer, name string, o *trace.Sp
 		TraceState: samplingResult.Tracestate,
 	}
 	if isSampled(samplingResult) {
-		scc.TraceFlags = psc.TraceFlags() | trace.FlagsSampled
+		scc.TraceFlags = flags | trace.FlagsSampled
 	} else {
-		scc.TraceFlags = psc.TraceFlags() &^ trace.FlagsSampled
+		scc.TraceFlags = flags &^ trace.FlagsSampled
 	}
 	span.spanContext = trace.NewSpanContext(scc)
 
@@ -565,7 +794,7 @@ func startSpanInternal(ctx context.Context, tr *tracer, name string, o *trace.Sp
 
 	startTime := o.Timestamp
 	if startTime.IsZero() {
-		startTime = time.Now()
+		startTime = provider.clock.Now()
 	}
 	span.startTime = startTime
 
@@ -576,6 +805,9 @@ func startSpanInternal(ctx context.Context, tr *tracer, name string, o *trace.Sp
 	span.instrumentationLibrary = tr.instrumentationLibrary
 
 	span.SetAttributes(samplingResult.Attributes...)
+	if k := provider.localRootAttribute; k.Defined() && isLocalRoot(psc) {
+		span.SetAttributes(k.Bool(true))
+	}
 
 	return span
 }
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import "context"
+
+// SpanListener is notified when the recording spans of a TracerProvider
+// start and end. Unlike a SpanProcessor, a SpanListener only has a
+// read-only view of spans, takes no part in their export, and is not shut
+// down with the TracerProvider. This suits cross-cutting concerns like
+// enriching request-scoped logs with the current span or counting live
+// spans.
+//
+// The methods of a SpanListener are called synchronously by the goroutine
+// starting or ending the span, after the SpanProcessors. They should be
+// fast and must be safe for concurrent use.
+type SpanListener interface {
+	// OnSpanStart is called with the context and the span when a span is
+	// started.
+	OnSpanStart(ctx context.Context, s ReadOnlySpan)
+
+	// OnSpanEnd is called with the ended span when a span ends.
+	OnSpanEnd(s ReadOnlySpan)
+}
+
+// SpanListenerFuncs is a SpanListener calling its functions, if set.
+type SpanListenerFuncs struct {
+	Start func(ctx context.Context, s ReadOnlySpan)
+	End   func(s ReadOnlySpan)
+}
+
+var _ SpanListener = SpanListenerFuncs{}
+
+// OnSpanStart calls the Start function.
+func (f SpanListenerFuncs) OnSpanStart(ctx context.Context, s ReadOnlySpan) {
+	if f.Start != nil {
+		f.Start(ctx, s)
+	}
+}
+
+// OnSpanEnd calls the End function.
+func (f SpanListenerFuncs) OnSpanEnd(s ReadOnlySpan) {
+	if f.End != nil {
+		f.End(s)
+	}
+}
+
+// spanListenerState is a SpanListener added to a TracerProvider. Its
+// address identifies it, so the same SpanListener can be added and removed
+// more than once.
+type spanListenerState struct {
+	l SpanListener
+}
+
+type spanListenerStates []*spanListenerState
+
+// AddSpanListener adds l to the SpanListeners notified of the spans of p.
+// The returned function removes it.
+func (p *TracerProvider) AddSpanListener(l SpanListener) (remove func()) {
+	state := &spanListenerState{l: l}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	old, _ := p.spanListeners.Load().(spanListenerStates)
+	p.spanListeners.Store(append(old[:len(old):len(old)], state))
+
+	return func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		old, _ := p.spanListeners.Load().(spanListenerStates)
+		var states spanListenerStates
+		for _, s := range old {
+			if s != state {
+				states = append(states, s)
+			}
+		}
+		p.spanListeners.Store(states)
+	}
+}
+
+// listeners returns the SpanListeners of p.
+func (p *TracerProvider) listeners() spanListenerStates {
+	ls, _ := p.spanListeners.Load().(spanListenerStates)
+	return ls
+}
+
+// WithSpanListener returns a TracerProviderOption that adds l to the
+// SpanListeners of a TracerProvider.
+func WithSpanListener(l SpanListener) TracerProviderOption {
+	return func(cfg *TracerProviderConfig) {
+		cfg.listeners = append(cfg.listeners, l)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// liveSpans counts the started spans that have not ended.
+type liveSpans struct {
+	n int64
+}
+
+func (l *liveSpans) OnSpanStart(context.Context, sdktrace.ReadOnlySpan) { atomic.AddInt64(&l.n, 1) }
+func (l *liveSpans) OnSpanEnd(sdktrace.ReadOnlySpan)                    { atomic.AddInt64(&l.n, -1) }
+func (l *liveSpans) count() int64                                       { return atomic.LoadInt64(&l.n) }
+
+func TestSpanListener(t *testing.T) {
+	live := &liveSpans{}
+	var ended []string
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSpanListener(live),
+		sdktrace.WithSpanListener(sdktrace.SpanListenerFuncs{
+			End: func(s sdktrace.ReadOnlySpan) { ended = append(ended, s.Name()) },
+		}),
+	)
+	tr := tp.Tracer("TestSpanListener")
+
+	ctx, parent := tr.Start(context.Background(), "parent")
+	_, child := tr.Start(ctx, "child")
+	assert.Equal(t, int64(2), live.count())
+	child.End()
+	assert.Equal(t, int64(1), live.count())
+	parent.End()
+	parent.End()
+	assert.Equal(t, int64(0), live.count())
+	assert.Equal(t, []string{"child", "parent"}, ended)
+}
+
+func TestSpanListenerStart(t *testing.T) {
+	type ctxKey struct{}
+	var got []string
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(tracetest.NewInMemoryExporter()))
+	tp.AddSpanListener(sdktrace.SpanListenerFuncs{
+		Start: func(ctx context.Context, s sdktrace.ReadOnlySpan) {
+			got = append(got, ctx.Value(ctxKey{}).(string), s.Name())
+			assert.True(t, s.SpanContext().IsValid())
+		},
+	})
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+	_, span := tp.Tracer("TestSpanListenerStart").Start(ctx, "span")
+	span.End()
+	assert.Equal(t, []string{"request", "span"}, got)
+}
+
+func TestSpanListenerRemove(t *testing.T) {
+	live := &liveSpans{}
+	tp := sdktrace.NewTracerProvider()
+	tr := tp.Tracer("TestSpanListenerRemove")
+	remove := tp.AddSpanListener(live)
+	// The same listener can be added twice.
+	removeOther := tp.AddSpanListener(live)
+
+	_, span := tr.Start(context.Background(), "span")
+	require.Equal(t, int64(2), live.count())
+	remove()
+	span.End()
+	assert.Equal(t, int64(1), live.count())
+
+	removeOther()
+	_, span = tr.Start(context.Background(), "span")
+	span.End()
+	assert.Equal(t, int64(1), live.count())
+}
+
+func TestSpanListenerNotRecording(t *testing.T) {
+	live := &liveSpans{}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.NeverSample()), sdktrace.WithSpanListener(live))
+	_, span := tp.Tracer("TestSpanListenerNotRecording").Start(context.Background(), "span")
+	require.False(t, span.IsRecording())
+	assert.Equal(t, int64(0), live.count())
+	span.End()
+	assert.Equal(t, int64(0), live.count())
+}
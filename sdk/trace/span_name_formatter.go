@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import "context"
+
+// SpanNameFormatter returns the name of a span started with the name name
+// and the parent context ctx. It can be used to reduce the cardinality of
+// span names, e.g. by replacing the IDs in a URL path with placeholders.
+//
+// A SpanNameFormatter is called synchronously when a span is started, before
+// the sampling decision is made, and must not block.
+type SpanNameFormatter func(ctx context.Context, name string) string
+
+// WithSpanNameFormatter returns a TracerProviderOption that will configure f
+// to rewrite the name of all spans the Tracers of the TracerProvider start.
+// Samplers, SpanProcessors and SpanExporters only see the rewritten name.
+// Names set with the SetName method of a span are not rewritten.
+//
+// If this option is used multiple times, the last SpanNameFormatter is used.
+// If this option is not used, span names are not rewritten.
+func WithSpanNameFormatter(f SpanNameFormatter) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		opts.spanNameFormatter = f
+	}
+}
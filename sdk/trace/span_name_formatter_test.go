@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// nameRecordingSampler records the names of the spans it samples.
+type nameRecordingSampler struct {
+	names []string
+}
+
+func (s *nameRecordingSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	s.names = append(s.names, p.Name)
+	return sdktrace.AlwaysSample().ShouldSample(p)
+}
+
+func (s *nameRecordingSampler) Description() string { return "nameRecordingSampler" }
+
+func TestWithSpanNameFormatter(t *testing.T) {
+	ids := regexp.MustCompile(`/[0-9]+`)
+	exp := tracetest.NewInMemoryExporter()
+	sampler := &nameRecordingSampler{}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sampler),
+		sdktrace.WithSyncer(exp),
+		sdktrace.WithSpanNameFormatter(func(_ context.Context, name string) string {
+			return ids.ReplaceAllString(name, "/{id}")
+		}),
+	)
+	tracer := tp.Tracer("TestWithSpanNameFormatter")
+
+	_, span := tracer.Start(context.Background(), "GET /users/123/orders/4")
+	span.End()
+	_, span = tracer.Start(context.Background(), "GET /users")
+	span.SetName("GET /users/5")
+	span.End()
+
+	assert.Equal(t, []string{"GET /users/{id}/orders/{id}", "GET /users"}, sampler.names)
+	spans := exp.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "GET /users/{id}/orders/{id}", spans[0].Name)
+	assert.Equal(t, "GET /users/5", spans[1].Name, "SetName rewritten")
+}
@@ -28,14 +28,30 @@ type SpanProcessor interface {
 	// and should not block.
 	OnStart(parent context.Context, s ReadWriteSpan)
 
+	// OnEnding is called when span is ending. The end time of the span has
+	// been set, but the span is still mutable and any changes made to it
+	// will be visible to the OnEnd method of all SpanProcessors. It is
+	// called synchronously and should not block.
+	OnEnding(s ReadWriteSpan)
+
 	// OnEnd is called when span is finished. It is called synchronously and
 	// hence not block.
+	//
+	// The passed ReadOnlySpan is an immutable snapshot of the ended span
+	// that is shared by all registered SpanProcessors. It can be passed on
+	// to a SpanExporter without being copied.
+	//
+	// The span cannot be modified in OnEnd: OnEnding is the place to
+	// modify it, e.g. to add final attributes or redact it, before it is
+	// passed to the OnEnd method of all SpanProcessors. A SpanProcessor
+	// changing only what it passes on, like the one returned by
+	// NewFilterProcessor, wraps the ReadOnlySpan instead.
 	OnEnd(s ReadOnlySpan)
 
 	// Shutdown is called when the SDK shuts down. Any cleanup or release of
 	// resources held by the processor should be done in this call.
 	//
-	// Calls to OnStart, OnEnd, or ForceFlush after this has been called
+	// Calls to OnStart, OnEnding, OnEnd, or ForceFlush after this has been called
 	// should be ignored.
 	//
 	// All timeouts and cancellations contained in ctx must be honored, this
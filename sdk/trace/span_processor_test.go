@@ -18,6 +18,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"go.opentelemetry.io/otel/attribute"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
@@ -26,6 +29,7 @@ import (
 type testSpanProcessor struct {
 	name          string
 	spansStarted  []sdktrace.ReadWriteSpan
+	spansEnding   []sdktrace.ReadWriteSpan
 	spansEnded    []sdktrace.ReadOnlySpan
 	shutdownCount int
 }
@@ -54,6 +58,11 @@ func (t *testSpanProcessor) OnStart(parent context.Context, s sdktrace.ReadWrite
 	t.spansStarted = append(t.spansStarted, s)
 }
 
+func (t *testSpanProcessor) OnEnding(s sdktrace.ReadWriteSpan) {
+	s.SetAttributes(attribute.String("OnEnding", t.name))
+	t.spansEnding = append(t.spansEnding, s)
+}
+
 func (t *testSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
 	t.spansEnded = append(t.spansEnded, s)
 }
@@ -141,6 +150,56 @@ func TestRegisterSpanProcessor(t *testing.T) {
 	}
 }
 
+func TestSpanProcessorOnEnding(t *testing.T) {
+	tp := basicTracerProvider(t)
+	spNames := []string{"sp1", "sp2"}
+	sps := NewNamedTestSpanProcessors(spNames)
+	for _, sp := range sps {
+		tp.RegisterSpanProcessor(sp)
+	}
+
+	tr := tp.Tracer("SpanProcessor")
+	_, span := tr.Start(context.Background(), "OnEnding")
+	span.End()
+
+	for _, sp := range sps {
+		require.Len(t, sp.spansEnding, 1)
+		require.Len(t, sp.spansEnded, 1)
+		assert.False(t, sp.spansEnding[0].EndTime().IsZero(), "end time not set before OnEnding")
+
+		// Attributes set by all processors' OnEnding are visible in OnEnd,
+		// the last registered processor wins.
+		assert.Contains(t, sp.spansEnded[0].Attributes(), attribute.String("OnEnding", "sp2"))
+	}
+
+	// The span is immutable once all OnEnding methods have returned.
+	assert.False(t, span.IsRecording())
+	span.SetAttributes(attribute.String("after", "end"))
+	assert.NotContains(t, sps[0].spansEnding[0].Attributes(), attribute.String("after", "end"))
+}
+
+type endingSpanProcessor struct {
+	testSpanProcessor
+}
+
+func (t *endingSpanProcessor) OnEnding(s sdktrace.ReadWriteSpan) {
+	t.testSpanProcessor.OnEnding(s)
+	s.End()
+}
+
+func TestSpanProcessorOnEndingEndIsIgnored(t *testing.T) {
+	tp := basicTracerProvider(t)
+	sp := &endingSpanProcessor{testSpanProcessor{name: "sp"}}
+	tp.RegisterSpanProcessor(sp)
+
+	tr := tp.Tracer("SpanProcessor")
+	_, span := tr.Start(context.Background(), "OnEnding")
+	span.End()
+
+	assert.Len(t, sp.spansEnding, 1)
+	assert.Len(t, sp.spansEnded, 1)
+}
+
 func TestUnregisterSpanProcessor(t *testing.T) {
 	name := "Start span after unregistering span processor"
 	tp := basicTracerProvider(t)
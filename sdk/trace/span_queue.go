@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import "sync/atomic"
+
+// cacheLinePad prevents false sharing of the positions of a spanQueue.
+type cacheLinePad struct {
+	_ [64]byte
+}
+
+// spanQueueSlot is an element of the ring buffer of a spanQueue.
+type spanQueueSlot struct {
+	// seq is twice the position the slot can be written at when it is
+	// empty, and twice the position it can be read at plus one when it
+	// holds a span. Using even and odd values allows queues of size one.
+	seq  uint64
+	span ReadOnlySpan
+}
+
+// spanQueue is a bounded lock-free multi-producer single-consumer queue of
+// spans, based on Dmitry Vyukov's bounded MPMC queue. Any number of
+// goroutines can enqueue concurrently, dequeue must not be called
+// concurrently.
+type spanQueue struct {
+	// Ensure positions are 64-bit aligned for atomic operations on both 32
+	// and 64 bit machines.
+	enqueuePos uint64
+	_          cacheLinePad
+	dequeuePos uint64
+	_          cacheLinePad
+
+	size  uint64
+	slots []spanQueueSlot
+}
+
+// newSpanQueue returns an empty spanQueue holding up to size spans.
+func newSpanQueue(size int) *spanQueue {
+	if size < 1 {
+		size = 1
+	}
+	q := &spanQueue{
+		size:  uint64(size),
+		slots: make([]spanQueueSlot, size),
+	}
+	for i := range q.slots {
+		q.slots[i].seq = 2 * uint64(i)
+	}
+	return q
+}
+
+// tryEnqueue adds s to the queue, returning false without adding it if the
+// queue is full.
+func (q *spanQueue) tryEnqueue(s ReadOnlySpan) bool {
+	pos := atomic.LoadUint64(&q.enqueuePos)
+	for {
+		slot := &q.slots[pos%q.size]
+		seq := atomic.LoadUint64(&slot.seq)
+		switch diff := int64(seq - 2*pos); {
+		case diff == 0:
+			if atomic.CompareAndSwapUint64(&q.enqueuePos, pos, pos+1) {
+				slot.span = s
+				// Publish the span to the consumer.
+				atomic.StoreUint64(&slot.seq, 2*pos+1)
+				return true
+			}
+			pos = atomic.LoadUint64(&q.enqueuePos)
+		case diff < 0:
+			// The slot still holds a span enqueued a lap ago.
+			return false
+		default:
+			// Another producer claimed the slot first.
+			pos = atomic.LoadUint64(&q.enqueuePos)
+		}
+	}
+}
+
+// dequeue removes and returns the oldest span of the queue, or returns false
+// if the queue is empty.
+func (q *spanQueue) dequeue() (ReadOnlySpan, bool) {
+	pos := atomic.LoadUint64(&q.dequeuePos)
+	slot := &q.slots[pos%q.size]
+	if atomic.LoadUint64(&slot.seq) != 2*pos+1 {
+		// Empty, or the producer of the slot has not published it yet.
+		return nil, false
+	}
+	s := slot.span
+	slot.span = nil
+	atomic.StoreUint64(&q.dequeuePos, pos+1)
+	// Release the slot to the producers of the next lap.
+	atomic.StoreUint64(&slot.seq, 2*(pos+q.size))
+	return s, true
+}
+
+// cap returns the maximum number of spans in the queue.
+func (q *spanQueue) cap() int {
+	return int(q.size)
+}
+
+// len returns the approximate number of spans in the queue.
+func (q *spanQueue) len() int {
+	deq := atomic.LoadUint64(&q.dequeuePos)
+	enq := atomic.LoadUint64(&q.enqueuePos)
+	if enq < deq {
+		return 0
+	}
+	return int(enq - deq)
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// queuedSpan is a ReadOnlySpan identified by its name.
+type queuedSpan struct {
+	ReadOnlySpan
+	name string
+}
+
+func (s queuedSpan) Name() string { return s.name }
+
+func TestSpanQueue(t *testing.T) {
+	for _, size := range []int{1, 2, 3} {
+		q := newSpanQueue(size)
+		assert.Equal(t, size, q.cap())
+
+		// Fill and empty the queue several times to wrap around the ring.
+		for lap := 0; lap < 3; lap++ {
+			_, ok := q.dequeue()
+			assert.False(t, ok, "dequeued from an empty queue")
+			for i := 0; i < size; i++ {
+				require.True(t, q.tryEnqueue(queuedSpan{name: string(rune('a' + i))}))
+			}
+			assert.False(t, q.tryEnqueue(queuedSpan{name: "full"}), "enqueued in a full queue of size %d", size)
+			assert.Equal(t, size, q.len())
+			for i := 0; i < size; i++ {
+				s, ok := q.dequeue()
+				require.True(t, ok)
+				assert.Equal(t, string(rune('a'+i)), s.Name())
+			}
+			assert.Equal(t, 0, q.len())
+		}
+	}
+}
+
+func TestSpanQueueConcurrentProducers(t *testing.T) {
+	const producers, perProducer = 8, 1000
+	q := newSpanQueue(16)
+
+	var wg sync.WaitGroup
+	wg.Add(producers)
+	for p := 0; p < producers; p++ {
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				s := queuedSpan{name: string(rune('a' + p))}
+				for !q.tryEnqueue(s) {
+					runtime.Gosched()
+				}
+			}
+		}(p)
+	}
+
+	counts := map[string]int{}
+	for n := 0; n < producers*perProducer; {
+		if s, ok := q.dequeue(); ok {
+			counts[s.Name()]++
+			n++
+		} else {
+			runtime.Gosched()
+		}
+	}
+	wg.Wait()
+
+	for p := 0; p < producers; p++ {
+		assert.Equal(t, perProducer, counts[string(rune('a'+p))])
+	}
+	_, ok := q.dequeue()
+	assert.False(t, ok)
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/internal/ratelimit"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// defaultLimiterMaxKeys is the number of spans or traces a TelemetryLimiter
+// tracks before it evicts the least recently used one.
+const defaultLimiterMaxKeys = 1024
+
+// LimiterScope determines what a TelemetryLimiter keys its budgets on.
+type LimiterScope int
+
+const (
+	// LimitPerTrace shares one budget between all spans of a trace.
+	LimitPerTrace LimiterScope = iota
+	// LimitPerSpan gives every span its own budget.
+	LimitPerSpan
+)
+
+// TelemetryLimiter caps the rate at which instrumentation emits telemetry,
+// such as events or links, for a single span or trace. Every span or trace
+// has its own token bucket that holds up to burst tokens and is refilled at
+// perSecond tokens per second.
+//
+// A TelemetryLimiter is safe for concurrent use.
+type TelemetryLimiter struct {
+	scope     LimiterScope
+	perSecond float64
+	burst     float64
+	maxKeys   int
+	now       func() time.Time
+
+	mu      sync.Mutex
+	buckets map[limiterKey]*list.Element
+	lru     *list.List
+}
+
+type limiterKey struct {
+	traceID trace.TraceID
+	spanID  trace.SpanID
+}
+
+type limiterBucket struct {
+	key limiterKey
+	ratelimit.Bucket
+}
+
+// NewTelemetryLimiter returns a TelemetryLimiter that allows bursts of up to
+// burst emissions per span or trace, as determined by scope, and refills at
+// perSecond emissions per second. A burst < 1 is treated as 1. A perSecond
+// <= 0 never refills the bucket, capping the total number of emissions at
+// burst.
+func NewTelemetryLimiter(scope LimiterScope, perSecond float64, burst int) *TelemetryLimiter {
+	return newTelemetryLimiter(scope, perSecond, burst, defaultLimiterMaxKeys, time.Now)
+}
+
+func newTelemetryLimiter(scope LimiterScope, perSecond float64, burst, maxKeys int, now func() time.Time) *TelemetryLimiter {
+	return &TelemetryLimiter{
+		scope:     scope,
+		perSecond: perSecond,
+		burst:     float64(burst),
+		maxKeys:   maxKeys,
+		now:       now,
+		buckets:   make(map[limiterKey]*list.Element),
+		lru:       list.New(),
+	}
+}
+
+// Allow reports whether telemetry may be emitted for the span contained in
+// ctx, consuming one token from its budget if so. Telemetry is always
+// allowed if ctx does not contain a valid span.
+func (l *TelemetryLimiter) Allow(ctx context.Context) bool {
+	return l.AllowSpanContext(trace.SpanContextFromContext(ctx))
+}
+
+// AllowSpanContext reports whether telemetry may be emitted for the span
+// identified by sc, consuming one token from its budget if so. Telemetry is
+// always allowed for an invalid sc.
+func (l *TelemetryLimiter) AllowSpanContext(sc trace.SpanContext) bool {
+	if !sc.IsValid() {
+		return true
+	}
+
+	key := limiterKey{traceID: sc.TraceID()}
+	if l.scope == LimitPerSpan {
+		key.spanID = sc.SpanID()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	return l.bucket(key, now).Take(now)
+}
+
+// bucket returns the bucket for key, creating a full one and evicting the
+// least recently used bucket if needed. It must be called with l.mu held.
+func (l *TelemetryLimiter) bucket(key limiterKey, now time.Time) *limiterBucket {
+	if e, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(e)
+		return e.Value.(*limiterBucket)
+	}
+
+	if l.lru.Len() >= l.maxKeys {
+		if oldest := l.lru.Back(); oldest != nil {
+			l.lru.Remove(oldest)
+			delete(l.buckets, oldest.Value.(*limiterBucket).key)
+		}
+	}
+
+	b := &limiterBucket{key: key, Bucket: ratelimit.NewBucket(l.perSecond, l.burst, now)}
+	l.buckets[key] = l.lru.PushFront(b)
+	return b
+}
+
+// Forget discards the budget held for the span identified by sc, or its
+// trace when the limiter is scoped per trace. It can be called once a span
+// or trace has ended to release its state early.
+func (l *TelemetryLimiter) Forget(sc trace.SpanContext) {
+	key := limiterKey{traceID: sc.TraceID()}
+	if l.scope == LimitPerSpan {
+		key.spanID = sc.SpanID()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.buckets[key]; ok {
+		l.lru.Remove(e)
+		delete(l.buckets, key)
+	}
+}
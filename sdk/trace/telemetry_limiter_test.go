@@ -0,0 +1,128 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type limiterClock struct {
+	t time.Time
+}
+
+func (c *limiterClock) now() time.Time { return c.t }
+
+func (c *limiterClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func limiterSpanContext(traceID, spanID byte) trace.SpanContext {
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{traceID},
+		SpanID:  trace.SpanID{spanID},
+	})
+}
+
+func TestTelemetryLimiterPerTrace(t *testing.T) {
+	clock := &limiterClock{t: time.Unix(0, 0)}
+	l := newTelemetryLimiter(LimitPerTrace, 1, 2, defaultLimiterMaxKeys, clock.now)
+
+	a, b := limiterSpanContext(1, 1), limiterSpanContext(1, 2)
+	other := limiterSpanContext(2, 1)
+
+	// Spans of the same trace share a budget.
+	assert.True(t, l.AllowSpanContext(a))
+	assert.True(t, l.AllowSpanContext(b))
+	assert.False(t, l.AllowSpanContext(a))
+	assert.False(t, l.AllowSpanContext(b))
+
+	// Other traces are unaffected.
+	assert.True(t, l.AllowSpanContext(other))
+
+	clock.advance(time.Second)
+	assert.True(t, l.AllowSpanContext(b))
+	assert.False(t, l.AllowSpanContext(a))
+}
+
+func TestTelemetryLimiterPerSpan(t *testing.T) {
+	clock := &limiterClock{t: time.Unix(0, 0)}
+	l := newTelemetryLimiter(LimitPerSpan, 0, 1, defaultLimiterMaxKeys, clock.now)
+
+	a, b := limiterSpanContext(1, 1), limiterSpanContext(1, 2)
+	assert.True(t, l.AllowSpanContext(a))
+	assert.False(t, l.AllowSpanContext(a))
+	assert.True(t, l.AllowSpanContext(b))
+
+	// A rate of zero never refills.
+	clock.advance(time.Hour)
+	assert.False(t, l.AllowSpanContext(a))
+}
+
+func TestTelemetryLimiterBurstCap(t *testing.T) {
+	clock := &limiterClock{t: time.Unix(0, 0)}
+	l := newTelemetryLimiter(LimitPerSpan, 10, 3, defaultLimiterMaxKeys, clock.now)
+	sc := limiterSpanContext(1, 1)
+
+	clock.advance(time.Minute)
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.AllowSpanContext(sc))
+	}
+	assert.False(t, l.AllowSpanContext(sc))
+}
+
+func TestTelemetryLimiterInvalidSpanContext(t *testing.T) {
+	l := NewTelemetryLimiter(LimitPerTrace, 0, 1)
+	for i := 0; i < 5; i++ {
+		assert.True(t, l.Allow(context.Background()))
+	}
+}
+
+func TestTelemetryLimiterAllowContext(t *testing.T) {
+	l := NewTelemetryLimiter(LimitPerSpan, 0, 1)
+	ctx := trace.ContextWithSpanContext(context.Background(), limiterSpanContext(1, 1))
+	assert.True(t, l.Allow(ctx))
+	assert.False(t, l.Allow(ctx))
+}
+
+func TestTelemetryLimiterForget(t *testing.T) {
+	l := NewTelemetryLimiter(LimitPerTrace, 0, 1)
+	sc := limiterSpanContext(1, 1)
+	assert.True(t, l.AllowSpanContext(sc))
+	assert.False(t, l.AllowSpanContext(sc))
+
+	l.Forget(sc)
+	assert.True(t, l.AllowSpanContext(sc))
+}
+
+func TestTelemetryLimiterEviction(t *testing.T) {
+	clock := &limiterClock{t: time.Unix(0, 0)}
+	l := newTelemetryLimiter(LimitPerTrace, 0, 1, 2, clock.now)
+
+	a, b, c := limiterSpanContext(1, 1), limiterSpanContext(2, 1), limiterSpanContext(3, 1)
+	assert.True(t, l.AllowSpanContext(a))
+	assert.True(t, l.AllowSpanContext(b))
+	// Touch a so b becomes the least recently used.
+	assert.False(t, l.AllowSpanContext(a))
+	assert.True(t, l.AllowSpanContext(c))
+
+	assert.Len(t, l.buckets, 2)
+	assert.False(t, l.AllowSpanContext(a))
+	// b was evicted and starts with a fresh budget.
+	assert.True(t, l.AllowSpanContext(b))
+}
@@ -30,7 +30,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/oteltest"
-	"go.opentelemetry.io/otel/semconv"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/google/go-cmp/cmp"
@@ -39,7 +39,6 @@ import (
 
 	ottest "go.opentelemetry.io/otel/internal/internaltest"
 
-	export "go.opentelemetry.io/otel/sdk/export/trace"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/resource"
 )
@@ -102,36 +101,36 @@ func TestTracerFollowsExpectedAPIBehaviour(t *testing.T) {
 type testExporter struct {
 	mu    sync.RWMutex
 	idx   map[string]int
-	spans []*export.SpanSnapshot
+	spans []*snapshot
 }
 
 func NewTestExporter() *testExporter {
 	return &testExporter{idx: make(map[string]int)}
 }
 
-func (te *testExporter) ExportSpans(_ context.Context, ss []*export.SpanSnapshot) error {
+func (te *testExporter) ExportSpans(_ context.Context, ss []ReadOnlySpan) error {
 	te.mu.Lock()
 	defer te.mu.Unlock()
 
 	i := len(te.spans)
 	for _, s := range ss {
-		te.idx[s.Name] = i
-		te.spans = append(te.spans, s)
+		te.idx[s.Name()] = i
+		te.spans = append(te.spans, s.(*snapshot))
 		i++
 	}
 	return nil
 }
 
-func (te *testExporter) Spans() []*export.SpanSnapshot {
+func (te *testExporter) Spans() []*snapshot {
 	te.mu.RLock()
 	defer te.mu.RUnlock()
 
-	cp := make([]*export.SpanSnapshot, len(te.spans))
+	cp := make([]*snapshot, len(te.spans))
 	copy(cp, te.spans)
 	return cp
 }
 
-func (te *testExporter) GetSpan(name string) (*export.SpanSnapshot, bool) {
+func (te *testExporter) GetSpan(name string) (*snapshot, bool) {
 	te.mu.RLock()
 	defer te.mu.RUnlock()
 	i, ok := te.idx[name]
@@ -375,6 +374,38 @@ func TestStartSpanWithParent(t *testing.T) {
 	}
 }
 
+func TestLocalRootSpan(t *testing.T) {
+	const key = attribute.Key("local.root")
+	tp := NewTracerProvider(WithLocalRootAttribute(key))
+	tr := tp.Tracer("LocalRootSpan")
+	ctx := context.Background()
+
+	ctx1, root := tr.Start(ctx, "root")
+	_, child := tr.Start(ctx1, "child")
+	_, remoteChild := tr.Start(trace.ContextWithRemoteSpanContext(ctx, sc), "remote-child")
+
+	tests := []struct {
+		span trace.Span
+		want bool
+	}{
+		{root, true},
+		{child, false},
+		{remoteChild, true},
+	}
+	for _, test := range tests {
+		ro := test.span.(ReadOnlySpan)
+		assert.Equal(t, test.want, ro.IsLocalRoot(), ro.Name())
+
+		var got bool
+		for _, kv := range ro.Attributes() {
+			if kv.Key == key {
+				got = kv.Value.AsBool()
+			}
+		}
+		assert.Equal(t, test.want, got, ro.Name())
+	}
+}
+
 func TestSetSpanAttributesOnStart(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -388,19 +419,19 @@ func TestSetSpanAttributesOnStart(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent: sc.WithRemote(true),
-		Name:   "span0",
-		Attributes: []attribute.KeyValue{
+		parent: sc.WithRemote(true),
+		name:   "span0",
+		attributes: []attribute.KeyValue{
 			attribute.String("key1", "value1"),
 			attribute.String("key2", "value2"),
 		},
-		SpanKind:               trace.SpanKindInternal,
-		InstrumentationLibrary: instrumentation.Library{Name: "StartSpanAttribute"},
+		spanKind:               trace.SpanKindInternal,
+		instrumentationLibrary: instrumentation.Library{Name: "StartSpanAttribute"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("SetSpanAttributesOnStart: -got +want %s", diff)
@@ -417,24 +448,59 @@ func TestSetSpanAttributes(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent: sc.WithRemote(true),
-		Name:   "span0",
-		Attributes: []attribute.KeyValue{
+		parent: sc.WithRemote(true),
+		name:   "span0",
+		attributes: []attribute.KeyValue{
 			attribute.String("key1", "value1"),
 		},
-		SpanKind:               trace.SpanKindInternal,
-		InstrumentationLibrary: instrumentation.Library{Name: "SpanAttribute"},
+		spanKind:               trace.SpanKindInternal,
+		instrumentationLibrary: instrumentation.Library{Name: "SpanAttribute"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("SetSpanAttributes: -got +want %s", diff)
 	}
 }
 
+func TestSetSpanAttributeSet(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+	span := startSpan(tp, "SpanAttributeSet")
+	span.SetAttributes(attribute.String("key1", "value0"))
+	set := attribute.NewSet(
+		attribute.String("key2", "value2"),
+		attribute.String("key1", "value1"),
+	)
+	span.SetAttributeSet(&set)
+	span.SetAttributeSet(nil)
+	got, err := endSpan(te, span)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    tid,
+			TraceFlags: 0x1,
+		}),
+		parent: sc.WithRemote(true),
+		name:   "span0",
+		attributes: []attribute.KeyValue{
+			attribute.String("key1", "value1"),
+			attribute.String("key2", "value2"),
+		},
+		spanKind:               trace.SpanKindInternal,
+		instrumentationLibrary: instrumentation.Library{Name: "SpanAttributeSet"},
+	}
+	if diff := cmpDiff(got, want); diff != "" {
+		t.Errorf("SetSpanAttributeSet: -got +want %s", diff)
+	}
+}
+
 func TestSamplerAttributesLocalChildSpan(t *testing.T) {
 	sampler := &testSampler{prefix: "span", t: t}
 	te := NewTestExporter()
@@ -453,8 +519,55 @@ func TestSamplerAttributesLocalChildSpan(t *testing.T) {
 	gotSpan0, gotSpan1 := got[0], got[1]
 	// Ensure sampler is called for local child spans by verifying the
 	// attributes set by the sampler are set on the child span.
-	assert.Equal(t, []attribute.KeyValue{attribute.Int("callCount", 2)}, gotSpan0.Attributes)
-	assert.Equal(t, []attribute.KeyValue{attribute.Int("callCount", 1)}, gotSpan1.Attributes)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("callCount", 2)}, gotSpan0.attributes)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("callCount", 1)}, gotSpan1.attributes)
+}
+
+func TestSpanAttributeValueLengthLimit(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSpanLimits(SpanLimits{AttributeValueLengthLimit: 3}), WithSyncer(te), WithResource(resource.Empty()))
+
+	links := []attribute.KeyValue{attribute.String("link", "abcdef")}
+	span := startSpan(tp, "AttributeValueLengthLimit", trace.WithLinks(trace.Link{SpanContext: sc, Attributes: links}))
+	span.SetAttributes(
+		attribute.String("short", "ab"),
+		attribute.String("long", "abcdef"),
+		attribute.String("multibyte", "ąęśćż"),
+		attribute.StringSlice("slice", []string{"a", "abcd"}),
+		attribute.IntSlice("ints", []int{12345}),
+		attribute.Int64("int", 12345),
+	)
+	span.AddEvent("event", trace.WithAttributes(attribute.String("event", "abcdef")))
+
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("short", "ab"),
+		attribute.String("long", "abc"),
+		attribute.String("multibyte", "ąęś"),
+		attribute.StringSlice("slice", []string{"a", "abc"}),
+		attribute.IntSlice("ints", []int{12345}),
+		attribute.Int64("int", 12345),
+	}, got.attributes)
+	require.Len(t, got.events, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("event", "abc")}, got.events[0].Attributes)
+	require.Len(t, got.links, 1)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("link", "abc")}, got.links[0].Attributes)
+	// The attributes passed by the user are not modified.
+	assert.Equal(t, attribute.String("link", "abcdef"), links[0])
+}
+
+func TestSpanAttributeValueLengthUnlimited(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+
+	long := strings.Repeat("a", 10000)
+	span := startSpan(tp, "AttributeValueLengthUnlimited")
+	span.SetAttributes(attribute.String("long", long))
+
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("long", long)}, got.attributes)
 }
 
 func TestSetSpanAttributesOverLimit(t *testing.T) {
@@ -473,20 +586,20 @@ func TestSetSpanAttributesOverLimit(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent: sc.WithRemote(true),
-		Name:   "span0",
-		Attributes: []attribute.KeyValue{
+		parent: sc.WithRemote(true),
+		name:   "span0",
+		attributes: []attribute.KeyValue{
 			attribute.Bool("key1", false),
 			attribute.Int64("key4", 4),
 		},
-		SpanKind:               trace.SpanKindInternal,
-		DroppedAttributeCount:  1,
-		InstrumentationLibrary: instrumentation.Library{Name: "SpanAttributesOverLimit"},
+		spanKind:               trace.SpanKindInternal,
+		droppedAttributeCount:  1,
+		instrumentationLibrary: instrumentation.Library{Name: "SpanAttributesOverLimit"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("SetSpanAttributesOverLimit: -got +want %s", diff)
@@ -507,19 +620,19 @@ func TestSetSpanAttributesWithInvalidKey(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent: sc.WithRemote(true),
-		Name:   "span0",
-		Attributes: []attribute.KeyValue{
+		parent: sc.WithRemote(true),
+		name:   "span0",
+		attributes: []attribute.KeyValue{
 			attribute.Bool("key1", false),
 		},
-		SpanKind:               trace.SpanKindInternal,
-		DroppedAttributeCount:  0,
-		InstrumentationLibrary: instrumentation.Library{Name: "SpanToSetInvalidKeyOrValue"},
+		spanKind:               trace.SpanKindInternal,
+		droppedAttributeCount:  0,
+		instrumentationLibrary: instrumentation.Library{Name: "SpanToSetInvalidKeyOrValue"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("SetSpanAttributesWithInvalidKey: -got +want %s", diff)
@@ -545,25 +658,25 @@ func TestEvents(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	for i := range got.MessageEvents {
-		if !checkTime(&got.MessageEvents[i].Time) {
+	for i := range got.events {
+		if !checkTime(&got.events[i].Time) {
 			t.Error("exporting span: expected nonzero Event Time")
 		}
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent: sc.WithRemote(true),
-		Name:   "span0",
-		MessageEvents: []trace.Event{
+		parent: sc.WithRemote(true),
+		name:   "span0",
+		events: []trace.Event{
 			{Name: "foo", Attributes: []attribute.KeyValue{k1v1}},
 			{Name: "bar", Attributes: []attribute.KeyValue{k2v2, k3v3}},
 		},
-		SpanKind:               trace.SpanKindInternal,
-		InstrumentationLibrary: instrumentation.Library{Name: "Events"},
+		spanKind:               trace.SpanKindInternal,
+		instrumentationLibrary: instrumentation.Library{Name: "Events"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("Message Events: -got +want %s", diff)
@@ -594,26 +707,26 @@ func TestEventsOverLimit(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	for i := range got.MessageEvents {
-		if !checkTime(&got.MessageEvents[i].Time) {
+	for i := range got.events {
+		if !checkTime(&got.events[i].Time) {
 			t.Error("exporting span: expected nonzero Event Time")
 		}
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent: sc.WithRemote(true),
-		Name:   "span0",
-		MessageEvents: []trace.Event{
+		parent: sc.WithRemote(true),
+		name:   "span0",
+		events: []trace.Event{
 			{Name: "foo", Attributes: []attribute.KeyValue{k1v1}},
 			{Name: "bar", Attributes: []attribute.KeyValue{k2v2, k3v3}},
 		},
-		DroppedMessageEventCount: 2,
-		SpanKind:                 trace.SpanKindInternal,
-		InstrumentationLibrary:   instrumentation.Library{Name: "EventsOverLimit"},
+		droppedEventCount:      2,
+		spanKind:               trace.SpanKindInternal,
+		instrumentationLibrary: instrumentation.Library{Name: "EventsOverLimit"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("Message Event over limit: -got +want %s", diff)
@@ -642,16 +755,16 @@ func TestLinks(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent:                 sc.WithRemote(true),
-		Name:                   "span0",
-		Links:                  links,
-		SpanKind:               trace.SpanKindInternal,
-		InstrumentationLibrary: instrumentation.Library{Name: "Links"},
+		parent:                 sc.WithRemote(true),
+		name:                   "span0",
+		links:                  links,
+		spanKind:               trace.SpanKindInternal,
+		instrumentationLibrary: instrumentation.Library{Name: "Links"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("Link: -got +want %s", diff)
@@ -683,26 +796,77 @@ func TestLinksOverLimit(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent: sc.WithRemote(true),
-		Name:   "span0",
-		Links: []trace.Link{
+		parent: sc.WithRemote(true),
+		name:   "span0",
+		links: []trace.Link{
 			{SpanContext: sc2, Attributes: []attribute.KeyValue{k2v2}},
 			{SpanContext: sc3, Attributes: []attribute.KeyValue{k3v3}},
 		},
-		DroppedLinkCount:       1,
-		SpanKind:               trace.SpanKindInternal,
-		InstrumentationLibrary: instrumentation.Library{Name: "LinksOverLimit"},
+		droppedLinkCount:       1,
+		spanKind:               trace.SpanKindInternal,
+		instrumentationLibrary: instrumentation.Library{Name: "LinksOverLimit"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("Link over limit: -got +want %s", diff)
 	}
 }
 
+func TestAddLink(t *testing.T) {
+	te := NewTestExporter()
+
+	sc1 := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID([16]byte{1, 1}), SpanID: trace.SpanID{3}})
+	sc2 := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID([16]byte{1, 1}), SpanID: trace.SpanID{4}})
+	sc3 := trace.NewSpanContext(trace.SpanContextConfig{TraceID: trace.TraceID([16]byte{1, 1}), SpanID: trace.SpanID{5}})
+
+	tp := NewTracerProvider(WithSpanLimits(SpanLimits{LinkCountLimit: 2, AttributePerLinkCountLimit: 1}), WithSyncer(te), WithResource(resource.Empty()))
+
+	k1v1 := attribute.String("key1", "value1")
+	span := startSpan(tp, "AddLink", trace.WithLinks(trace.Link{SpanContext: sc1, Attributes: []attribute.KeyValue{k1v1}}))
+
+	k2v2 := attribute.String("key2", "value2")
+	k3v3 := attribute.String("key3", "value3")
+	span.AddLink(trace.Link{SpanContext: sc2, Attributes: []attribute.KeyValue{k2v2, k3v3}})
+	span.AddLink(trace.Link{SpanContext: sc3})
+
+	got, err := endSpan(te, span)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Links added after End are ignored.
+	span.AddLink(trace.Link{SpanContext: sc1})
+
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    tid,
+			TraceFlags: 0x1,
+		}),
+		parent: sc.WithRemote(true),
+		name:   "span0",
+		links: []trace.Link{
+			{SpanContext: sc2, Attributes: []attribute.KeyValue{k2v2}},
+			{SpanContext: sc3},
+		},
+		droppedLinkCount:       1,
+		droppedAttributeCount:  1,
+		spanKind:               trace.SpanKindInternal,
+		instrumentationLibrary: instrumentation.Library{Name: "AddLink"},
+	}
+	if diff := cmpDiff(got, want); diff != "" {
+		t.Errorf("AddLink: -got +want %s", diff)
+	}
+	if n := len(te.Spans()); n != 1 {
+		t.Fatalf("%d spans exported, want 1", n)
+	}
+	if links := te.Spans()[0].Links(); len(links) != 2 {
+		t.Errorf("link added after End: %v", links)
+	}
+}
+
 func TestSetSpanName(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -716,8 +880,8 @@ func TestSetSpanName(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if got.Name != want {
-		t.Errorf("span.Name: got %q; want %q", got.Name, want)
+	if got.name != want {
+		t.Errorf("span.Name: got %q; want %q", got.name, want)
 	}
 }
 
@@ -732,17 +896,17 @@ func TestSetSpanStatus(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent:                 sc.WithRemote(true),
-		Name:                   "span0",
-		SpanKind:               trace.SpanKindInternal,
-		StatusCode:             codes.Error,
-		StatusMessage:          "Error",
-		InstrumentationLibrary: instrumentation.Library{Name: "SpanStatus"},
+		parent:                 sc.WithRemote(true),
+		name:                   "span0",
+		spanKind:               trace.SpanKindInternal,
+		statusCode:             codes.Error,
+		statusMessage:          "Error",
+		instrumentationLibrary: instrumentation.Library{Name: "SpanStatus"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("SetSpanStatus: -got +want %s", diff)
@@ -760,17 +924,17 @@ func TestSetSpanStatusWithoutMessageWhenStatusIsNotError(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent:                 sc.WithRemote(true),
-		Name:                   "span0",
-		SpanKind:               trace.SpanKindInternal,
-		StatusCode:             codes.Ok,
-		StatusMessage:          "",
-		InstrumentationLibrary: instrumentation.Library{Name: "SpanStatus"},
+		parent:                 sc.WithRemote(true),
+		name:                   "span0",
+		spanKind:               trace.SpanKindInternal,
+		statusCode:             codes.Ok,
+		statusMessage:          "",
+		instrumentationLibrary: instrumentation.Library{Name: "SpanStatus"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("SetSpanStatus: -got +want %s", diff)
@@ -779,6 +943,7 @@ func TestSetSpanStatusWithoutMessageWhenStatusIsNotError(t *testing.T) {
 
 func cmpDiff(x, y interface{}) string {
 	return cmp.Diff(x, y,
+		cmp.AllowUnexported(snapshot{}),
 		cmp.AllowUnexported(attribute.Value{}),
 		cmp.AllowUnexported(trace.Event{}),
 		cmp.AllowUnexported(trace.TraceState{}))
@@ -838,16 +1003,16 @@ func startLocalSpan(tp *TracerProvider, ctx context.Context, trName, name string
 }
 
 // endSpan is a test utility function that ends the span in the context and
-// returns the exported export.SpanSnapshot.
+// returns the exported span snapshot.
 // It requires that span be sampled using one of these methods
 //  1. Passing parent span context in context
 //  2. Use WithSampler(AlwaysSample())
 //  3. Configuring AlwaysSample() as default sampler
 //
 // It also does some basic tests on the span.
-// It also clears spanID in the export.SpanSnapshot to make the comparison
+// It also clears spanID and the tracer in the snapshot to make the comparison
 // easier.
-func endSpan(te *testExporter, span trace.Span) (*export.SpanSnapshot, error) {
+func endSpan(te *testExporter, span trace.Span) (*snapshot, error) {
 	if !span.IsRecording() {
 		return nil, fmt.Errorf("IsRecording: got false, want true")
 	}
@@ -859,19 +1024,25 @@ func endSpan(te *testExporter, span trace.Span) (*export.SpanSnapshot, error) {
 		return nil, fmt.Errorf("got %d exported spans, want one span", te.Len())
 	}
 	got := te.Spans()[0]
-	if !got.SpanContext.SpanID().IsValid() {
+	if !got.spanContext.SpanID().IsValid() {
 		return nil, fmt.Errorf("exporting span: expected nonzero SpanID")
 	}
-	got.SpanContext = got.SpanContext.WithSpanID(trace.SpanID{})
-	if !checkTime(&got.StartTime) {
+	got.spanContext = got.spanContext.WithSpanID(trace.SpanID{})
+	got.tracer = nil
+	if !checkTime(&got.startTime) {
 		return nil, fmt.Errorf("exporting span: expected nonzero StartTime")
 	}
-	if !checkTime(&got.EndTime) {
+	if !checkTime(&got.endTime) {
 		return nil, fmt.Errorf("exporting span: expected nonzero EndTime")
 	}
 	return got, nil
 }
 
+// takeSnapshot returns a snapshot of the current state of s.
+func takeSnapshot(s trace.Span) *snapshot {
+	return s.(*span).snapshot().(*snapshot)
+}
+
 // checkTime checks that a nonzero time was set in x, then clears it.
 func checkTime(x *time.Time) bool {
 	if x.IsZero() {
@@ -934,16 +1105,16 @@ func TestStartSpanAfterEnd(t *testing.T) {
 		t.Fatal("span-2 not recorded")
 	}
 
-	if got, want := gotSpan1.SpanContext.TraceID(), gotParent.SpanContext.TraceID(); got != want {
+	if got, want := gotSpan1.spanContext.TraceID(), gotParent.spanContext.TraceID(); got != want {
 		t.Errorf("span-1.TraceID=%q; want %q", got, want)
 	}
-	if got, want := gotSpan2.SpanContext.TraceID(), gotParent.SpanContext.TraceID(); got != want {
+	if got, want := gotSpan2.spanContext.TraceID(), gotParent.spanContext.TraceID(); got != want {
 		t.Errorf("span-2.TraceID=%q; want %q", got, want)
 	}
-	if got, want := gotSpan1.Parent.SpanID(), gotParent.SpanContext.SpanID(); got != want {
+	if got, want := gotSpan1.parent.SpanID(), gotParent.spanContext.SpanID(); got != want {
 		t.Errorf("span-1.ParentSpanID=%q; want %q (parent.SpanID)", got, want)
 	}
-	if got, want := gotSpan2.Parent.SpanID(), gotSpan1.SpanContext.SpanID(); got != want {
+	if got, want := gotSpan2.parent.SpanID(), gotSpan1.spanContext.SpanID(); got != want {
 		t.Errorf("span-2.ParentSpanID=%q; want %q (span1.SpanID)", got, want)
 	}
 }
@@ -983,16 +1154,16 @@ func TestChildSpanCount(t *testing.T) {
 		t.Fatal("span-3 not recorded")
 	}
 
-	if got, want := gotSpan3.ChildSpanCount, 0; got != want {
+	if got, want := gotSpan3.childSpanCount, 0; got != want {
 		t.Errorf("span-3.ChildSpanCount=%d; want %d", got, want)
 	}
-	if got, want := gotSpan2.ChildSpanCount, 0; got != want {
+	if got, want := gotSpan2.childSpanCount, 0; got != want {
 		t.Errorf("span-2.ChildSpanCount=%d; want %d", got, want)
 	}
-	if got, want := gotSpan1.ChildSpanCount, 1; got != want {
+	if got, want := gotSpan1.childSpanCount, 1; got != want {
 		t.Errorf("span-1.ChildSpanCount=%d; want %d", got, want)
 	}
-	if got, want := gotParent.ChildSpanCount, 2; got != want {
+	if got, want := gotParent.childSpanCount, 2; got != want {
 		t.Errorf("parent.ChildSpanCount=%d; want %d", got, want)
 	}
 }
@@ -1037,7 +1208,7 @@ func TestExecutionTracerTaskEnd(t *testing.T) {
 	s.executionTracerTaskEnd = executionTracerTaskEnd
 	spans = append(spans, s) // parent not sampled
 
-	tp.sampler = AlwaysSample()
+	tp.SetSampler(AlwaysSample())
 	_, apiSpan = tr.Start(context.Background(), "foo")
 	s = apiSpan.(*span)
 	s.executionTracerTaskEnd = executionTracerTaskEnd
@@ -1070,11 +1241,11 @@ func TestCustomStartEndTime(t *testing.T) {
 		t.Fatalf("got %d exported spans, want one span", te.Len())
 	}
 	got := te.Spans()[0]
-	if got.StartTime != startTime {
-		t.Errorf("expected start time to be %s, got %s", startTime, got.StartTime)
+	if got.startTime != startTime {
+		t.Errorf("expected start time to be %s, got %s", startTime, got.startTime)
 	}
-	if got.EndTime != endTime {
-		t.Errorf("expected end time to be %s, got %s", endTime, got.EndTime)
+	if got.endTime != endTime {
+		t.Errorf("expected end time to be %s, got %s", endTime, got.endTime)
 	}
 }
 
@@ -1109,16 +1280,16 @@ func TestRecordError(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		want := &export.SpanSnapshot{
-			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+		want := &snapshot{
+			spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 				TraceID:    tid,
 				TraceFlags: 0x1,
 			}),
-			Parent:     sc.WithRemote(true),
-			Name:       "span0",
-			StatusCode: codes.Unset,
-			SpanKind:   trace.SpanKindInternal,
-			MessageEvents: []trace.Event{
+			parent:     sc.WithRemote(true),
+			name:       "span0",
+			statusCode: codes.Unset,
+			spanKind:   trace.SpanKindInternal,
+			events: []trace.Event{
 				{
 					Name: semconv.ExceptionEventName,
 					Time: errTime,
@@ -1128,7 +1299,7 @@ func TestRecordError(t *testing.T) {
 					},
 				},
 			},
-			InstrumentationLibrary: instrumentation.Library{Name: "RecordError"},
+			instrumentationLibrary: instrumentation.Library{Name: "RecordError"},
 		}
 		if diff := cmpDiff(got, want); diff != "" {
 			t.Errorf("SpanErrorOptions: -got +want %s", diff)
@@ -1136,6 +1307,78 @@ func TestRecordError(t *testing.T) {
 	}
 }
 
+func TestRecordErrorWithReason(t *testing.T) {
+	require.NoError(t, codes.RegisterReason("throttled", codes.Error))
+
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+	span := startSpan(tp, "RecordErrorWithReason")
+
+	span.RecordError(fmt.Errorf("request: %w", codes.WithReason(errors.New("rate limited"), "throttled")))
+
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+	require.Len(t, got.events, 1)
+	assert.Contains(t, got.events[0].Attributes, codes.ReasonKey.String("throttled"))
+}
+
+func TestRecordErrorWithStackTrace(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+	span := startSpan(tp, "RecordErrorWithStackTrace")
+
+	span.RecordError(errors.New("test error"), trace.WithStackTrace(true))
+
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+	require.Len(t, got.events, 1)
+	var stack string
+	for _, kv := range got.events[0].Attributes {
+		if kv.Key == semconv.ExceptionStacktraceKey {
+			stack = kv.Value.AsString()
+		}
+	}
+	assert.Contains(t, stack, "TestRecordErrorWithStackTrace")
+}
+
+func TestRecordErrorWithoutStackTrace(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+	span := startSpan(tp, "RecordErrorWithoutStackTrace")
+
+	span.RecordError(errors.New("test error"), trace.WithStackTrace(false))
+
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+	require.Len(t, got.events, 1)
+	for _, kv := range got.events[0].Attributes {
+		assert.NotEqual(t, semconv.ExceptionStacktraceKey, kv.Key)
+	}
+}
+
+func TestAddEventWithStackTrace(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+	span := startSpan(tp, "AddEventWithStackTrace")
+
+	span.AddEvent("slow path", trace.WithStackTrace(true), trace.WithAttributes(attribute.Int("attempt", 2)))
+	span.AddEvent("fast path")
+
+	got, err := endSpan(te, span)
+	require.NoError(t, err)
+	require.Len(t, got.events, 2)
+	var stack string
+	for _, kv := range got.events[0].Attributes {
+		assert.NotEqual(t, semconv.ExceptionStacktraceKey, kv.Key)
+		if kv.Key == codeStacktraceKey {
+			stack = kv.Value.AsString()
+		}
+	}
+	assert.Contains(t, stack, "TestAddEventWithStackTrace")
+	assert.Contains(t, got.events[0].Attributes, attribute.Int("attempt", 2))
+	assert.Empty(t, got.events[1].Attributes)
+}
+
 func TestRecordErrorNil(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -1148,17 +1391,17 @@ func TestRecordErrorNil(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent:                 sc.WithRemote(true),
-		Name:                   "span0",
-		SpanKind:               trace.SpanKindInternal,
-		StatusCode:             codes.Unset,
-		StatusMessage:          "",
-		InstrumentationLibrary: instrumentation.Library{Name: "RecordErrorNil"},
+		parent:                 sc.WithRemote(true),
+		name:                   "span0",
+		spanKind:               trace.SpanKindInternal,
+		statusCode:             codes.Unset,
+		statusMessage:          "",
+		instrumentationLibrary: instrumentation.Library{Name: "RecordErrorNil"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("SpanErrorOptions: -got +want %s", diff)
@@ -1176,8 +1419,8 @@ func TestWithSpanKind(t *testing.T) {
 		t.Error(err.Error())
 	}
 
-	if spanData.SpanKind != trace.SpanKindInternal {
-		t.Errorf("Default value of Spankind should be Internal: got %+v, want %+v\n", spanData.SpanKind, trace.SpanKindInternal)
+	if spanData.spanKind != trace.SpanKindInternal {
+		t.Errorf("Default value of Spankind should be Internal: got %+v, want %+v\n", spanData.spanKind, trace.SpanKindInternal)
 	}
 
 	sks := []trace.SpanKind{
@@ -1197,8 +1440,8 @@ func TestWithSpanKind(t *testing.T) {
 			t.Error(err.Error())
 		}
 
-		if spanData.SpanKind != sk {
-			t.Errorf("WithSpanKind check: got %+v, want %+v\n", spanData.SpanKind, sks)
+		if spanData.spanKind != sk {
+			t.Errorf("WithSpanKind check: got %+v, want %+v\n", spanData.spanKind, sks)
 		}
 	}
 }
@@ -1245,19 +1488,19 @@ func TestWithResource(t *testing.T) {
 			if err != nil {
 				t.Error(err.Error())
 			}
-			want := &export.SpanSnapshot{
-				SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			want := &snapshot{
+				spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 					TraceID:    tid,
 					TraceFlags: 0x1,
 				}),
-				Parent: sc.WithRemote(true),
-				Name:   "span0",
-				Attributes: []attribute.KeyValue{
+				parent: sc.WithRemote(true),
+				name:   "span0",
+				attributes: []attribute.KeyValue{
 					attribute.String("key1", "value1"),
 				},
-				SpanKind:               trace.SpanKindInternal,
-				Resource:               tc.want,
-				InstrumentationLibrary: instrumentation.Library{Name: "WithResource"},
+				spanKind:               trace.SpanKindInternal,
+				resource:               tc.want,
+				instrumentationLibrary: instrumentation.Library{Name: "WithResource"},
 			}
 			if diff := cmpDiff(got, want); diff != "" {
 				t.Errorf("WithResource:\n  -got +want %s", diff)
@@ -1281,15 +1524,15 @@ func TestWithInstrumentationVersion(t *testing.T) {
 		t.Error(err.Error())
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent:   sc.WithRemote(true),
-		Name:     "span0",
-		SpanKind: trace.SpanKindInternal,
-		InstrumentationLibrary: instrumentation.Library{
+		parent:   sc.WithRemote(true),
+		name:     "span0",
+		spanKind: trace.SpanKindInternal,
+		instrumentationLibrary: instrumentation.Library{
 			Name:    "WithInstrumentationVersion",
 			Version: "v0.1.0",
 		},
@@ -1299,6 +1542,36 @@ func TestWithInstrumentationVersion(t *testing.T) {
 	}
 }
 
+func TestWithSchemaURL(t *testing.T) {
+	te := NewTestExporter()
+	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
+
+	tracer := tp.Tracer(
+		"WithSchemaURL",
+		trace.WithInstrumentationVersion("v0.1.0"),
+		trace.WithSchemaURL(semconv.SchemaURL),
+	)
+	_, span := tracer.Start(context.Background(), "span0")
+	got, err := endSpan(te, span)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	want := instrumentation.Library{
+		Name:      "WithSchemaURL",
+		Version:   "v0.1.0",
+		SchemaURL: semconv.SchemaURL,
+	}
+	if got.instrumentationLibrary != want {
+		t.Errorf("instrumentation library: got %v, want %v", got.instrumentationLibrary, want)
+	}
+
+	// Tracers of different schema URLs are distinct.
+	if other := tp.Tracer("WithSchemaURL", trace.WithInstrumentationVersion("v0.1.0")); other == tracer {
+		t.Error("tracer without a schema URL is the tracer with one")
+	}
+}
+
 func TestSpanCapturesPanic(t *testing.T) {
 	te := NewTestExporter()
 	tp := NewTracerProvider(WithSyncer(te), WithResource(resource.Empty()))
@@ -1314,9 +1587,9 @@ func TestSpanCapturesPanic(t *testing.T) {
 	require.PanicsWithError(t, "error message", f)
 	spans := te.Spans()
 	require.Len(t, spans, 1)
-	require.Len(t, spans[0].MessageEvents, 1)
-	assert.Equal(t, spans[0].MessageEvents[0].Name, semconv.ExceptionEventName)
-	assert.Equal(t, spans[0].MessageEvents[0].Attributes, []attribute.KeyValue{
+	require.Len(t, spans[0].events, 1)
+	assert.Equal(t, spans[0].events[0].Name, semconv.ExceptionEventName)
+	assert.Equal(t, spans[0].events[0].Attributes, []attribute.KeyValue{
 		semconv.ExceptionTypeKey.String("*errors.errorString"),
 		semconv.ExceptionMessageKey.String("error message"),
 	})
@@ -1379,18 +1652,18 @@ func TestReadOnlySpan(t *testing.T) {
 	span.SetName("bar")
 	assert.Equal(t, "bar", ro.Name())
 
-	// Verify Snapshot() returns snapshots that are independent from the
+	// Verify snapshot() returns snapshots that are independent from the
 	// original span and from one another.
-	d1 := ro.Snapshot()
+	d1 := takeSnapshot(span)
 	span.AddEvent("baz")
-	d2 := ro.Snapshot()
-	for _, e := range d1.MessageEvents {
+	d2 := takeSnapshot(span)
+	for _, e := range d1.events {
 		if e.Name == "baz" {
 			t.Errorf("Didn't expect to find 'baz' event")
 		}
 	}
 	var exists bool
-	for _, e := range d2.MessageEvents {
+	for _, e := range d2.events {
 		if e.Name == "baz" {
 			exists = true
 		}
@@ -1463,21 +1736,21 @@ func TestAddEventsWithMoreAttributesThanLimit(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	for i := range got.MessageEvents {
-		if !checkTime(&got.MessageEvents[i].Time) {
+	for i := range got.events {
+		if !checkTime(&got.events[i].Time) {
 			t.Error("exporting span: expected nonzero Event Time")
 		}
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent:     sc.WithRemote(true),
-		Name:       "span0",
-		Attributes: nil,
-		MessageEvents: []trace.Event{
+		parent:     sc.WithRemote(true),
+		name:       "span0",
+		attributes: nil,
+		events: []trace.Event{
 			{
 				Name: "test1",
 				Attributes: []attribute.KeyValue{
@@ -1493,9 +1766,9 @@ func TestAddEventsWithMoreAttributesThanLimit(t *testing.T) {
 				},
 			},
 		},
-		SpanKind:               trace.SpanKindInternal,
-		DroppedAttributeCount:  2,
-		InstrumentationLibrary: instrumentation.Library{Name: "AddSpanEventWithOverLimitedAttributes"},
+		spanKind:               trace.SpanKindInternal,
+		droppedAttributeCount:  2,
+		instrumentationLibrary: instrumentation.Library{Name: "AddSpanEventWithOverLimitedAttributes"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("SetSpanAttributesOverLimit: -got +want %s", diff)
@@ -1528,20 +1801,20 @@ func TestAddLinksWithMoreAttributesThanLimit(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	want := &export.SpanSnapshot{
-		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+	want := &snapshot{
+		spanContext: trace.NewSpanContext(trace.SpanContextConfig{
 			TraceID:    tid,
 			TraceFlags: 0x1,
 		}),
-		Parent: sc.WithRemote(true),
-		Name:   "span0",
-		Links: []trace.Link{
+		parent: sc.WithRemote(true),
+		name:   "span0",
+		links: []trace.Link{
 			{SpanContext: sc1, Attributes: []attribute.KeyValue{k1v1}},
 			{SpanContext: sc2, Attributes: []attribute.KeyValue{k2v2}},
 		},
-		DroppedAttributeCount:  3,
-		SpanKind:               trace.SpanKindInternal,
-		InstrumentationLibrary: instrumentation.Library{Name: "Links"},
+		droppedAttributeCount:  3,
+		spanKind:               trace.SpanKindInternal,
+		instrumentationLibrary: instrumentation.Library{Name: "Links"},
 	}
 	if diff := cmpDiff(got, want); diff != "" {
 		t.Errorf("Link: -got +want %s", diff)
@@ -1681,7 +1954,7 @@ func TestSamplerTraceState(t *testing.T) {
 				return
 			}
 
-			receivedState := got[0].SpanContext.TraceState()
+			receivedState := got[0].spanContext.TraceState()
 
 			if diff := cmpDiff(receivedState, ts.want); diff != "" {
 				t.Errorf("TraceState not propagated: -got +want %s", diff)
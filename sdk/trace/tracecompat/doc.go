@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracecompat provides adapters between the SpanSnapshot based
+// exporter interface of earlier releases and the ReadOnlySpan based
+// SpanExporter of the SDK.
+//
+// Exporters written against the removed
+// go.opentelemetry.io/otel/sdk/export/trace package can be registered with
+// the SDK by wrapping them with WrapSnapshotExporter. Conversely, a current
+// SpanExporter can be handed to code that still expects the old interface
+// by wrapping it with WrapSpanExporter.
+//
+// This package is intended as a migration aid only. New exporters should
+// implement the SpanExporter interface from go.opentelemetry.io/otel/sdk/trace
+// directly.
+package tracecompat // import "go.opentelemetry.io/otel/sdk/trace/tracecompat"
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracecompat // import "go.opentelemetry.io/otel/sdk/trace/tracecompat"
+
+import (
+	"context"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SnapshotExporter handles the delivery of SpanSnapshots to external
+// receivers. It is the exporter interface of the removed
+// go.opentelemetry.io/otel/sdk/export/trace package.
+type SnapshotExporter interface {
+	// ExportSpans exports a batch of SpanSnapshots.
+	ExportSpans(ctx context.Context, ss []*SpanSnapshot) error
+	// Shutdown notifies the exporter of a pending halt to operations.
+	Shutdown(ctx context.Context) error
+}
+
+// WrapSnapshotExporter returns a SpanExporter that converts exported spans
+// to SpanSnapshots and passes them to exp. Wrapping an exporter returned by
+// WrapSpanExporter returns the original SpanExporter.
+func WrapSnapshotExporter(exp SnapshotExporter) sdktrace.SpanExporter {
+	if w, ok := exp.(*snapshotToSpanExporter); ok {
+		return w.exp
+	}
+	return &spanToSnapshotExporter{exp: exp}
+}
+
+// WrapSpanExporter returns a SnapshotExporter that converts exported
+// SpanSnapshots to ReadOnlySpans and passes them to exp. Wrapping an
+// exporter returned by WrapSnapshotExporter returns the original
+// SnapshotExporter.
+func WrapSpanExporter(exp sdktrace.SpanExporter) SnapshotExporter {
+	if w, ok := exp.(*spanToSnapshotExporter); ok {
+		return w.exp
+	}
+	return &snapshotToSpanExporter{exp: exp}
+}
+
+// spanToSnapshotExporter is a SpanExporter backed by a SnapshotExporter.
+type spanToSnapshotExporter struct {
+	exp SnapshotExporter
+}
+
+var _ sdktrace.SpanExporter = (*spanToSnapshotExporter)(nil)
+
+// ExportSpans converts spans to SpanSnapshots and exports them.
+func (e *spanToSnapshotExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	return e.exp.ExportSpans(ctx, SnapshotsFromReadOnlySpans(spans))
+}
+
+// Shutdown shuts down the wrapped exporter.
+func (e *spanToSnapshotExporter) Shutdown(ctx context.Context) error {
+	return e.exp.Shutdown(ctx)
+}
+
+// snapshotToSpanExporter is a SnapshotExporter backed by a SpanExporter.
+type snapshotToSpanExporter struct {
+	exp sdktrace.SpanExporter
+}
+
+var _ SnapshotExporter = (*snapshotToSpanExporter)(nil)
+
+// ExportSpans converts ss to ReadOnlySpans and exports them.
+func (e *snapshotToSpanExporter) ExportSpans(ctx context.Context, ss []*SpanSnapshot) error {
+	return e.exp.ExportSpans(ctx, ReadOnlySpans(ss))
+}
+
+// Shutdown shuts down the wrapped exporter.
+func (e *snapshotToSpanExporter) Shutdown(ctx context.Context) error {
+	return e.exp.Shutdown(ctx)
+}
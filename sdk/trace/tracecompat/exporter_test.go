@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracecompat
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type recordingSnapshotExporter struct {
+	spans    []*SpanSnapshot
+	err      error
+	shutdown bool
+}
+
+func (e *recordingSnapshotExporter) ExportSpans(_ context.Context, ss []*SpanSnapshot) error {
+	e.spans = append(e.spans, ss...)
+	return e.err
+}
+
+func (e *recordingSnapshotExporter) Shutdown(context.Context) error {
+	e.shutdown = true
+	return e.err
+}
+
+func testSnapshot() *SpanSnapshot {
+	start := time.Unix(1600000000, 0)
+	return &SpanSnapshot{
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{0x01},
+			SpanID:     trace.SpanID{0x02},
+			TraceFlags: trace.FlagsSampled,
+		}),
+		Parent: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: trace.TraceID{0x01},
+			SpanID:  trace.SpanID{0x03},
+			Remote:  true,
+		}),
+		SpanKind:                 trace.SpanKindServer,
+		Name:                     "span",
+		StartTime:                start,
+		EndTime:                  start.Add(time.Second),
+		Attributes:               []attribute.KeyValue{attribute.String("key", "value")},
+		MessageEvents:            []trace.Event{{Name: "event", Time: start}},
+		Links:                    []trace.Link{{SpanContext: trace.SpanContext{}}},
+		StatusCode:               codes.Error,
+		StatusMessage:            "failed",
+		DroppedAttributeCount:    1,
+		DroppedMessageEventCount: 2,
+		DroppedLinkCount:         3,
+		ChildSpanCount:           4,
+		Resource:                 resource.NewWithAttributes(attribute.String("service.name", "test")),
+		InstrumentationLibrary:   instrumentation.Library{Name: "lib", Version: "v1"},
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	want := testSnapshot()
+	ro := want.ReadOnlySpan()
+	require.NotNil(t, ro)
+	assert.True(t, ro.IsLocalRoot())
+	assert.False(t, ro.IsRecording())
+	assert.Equal(t, want, SnapshotFromReadOnlySpan(ro))
+}
+
+func TestNilConversions(t *testing.T) {
+	var s *SpanSnapshot
+	assert.Nil(t, s.ReadOnlySpan())
+	assert.Nil(t, SnapshotFromReadOnlySpan(nil))
+	assert.Nil(t, SnapshotsFromReadOnlySpans(nil))
+	assert.Nil(t, ReadOnlySpans(nil))
+	assert.Len(t, ReadOnlySpans([]*SpanSnapshot{nil, testSnapshot()}), 1)
+}
+
+func TestWrapSnapshotExporter(t *testing.T) {
+	exp := &recordingSnapshotExporter{}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(WrapSnapshotExporter(exp)))
+
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	span.SetAttributes(attribute.Int("int", 1))
+	span.End()
+
+	require.Len(t, exp.spans, 1)
+	assert.Equal(t, "span", exp.spans[0].Name)
+	assert.Equal(t, "test", exp.spans[0].InstrumentationLibrary.Name)
+	assert.Equal(t, []attribute.KeyValue{attribute.Int("int", 1)}, exp.spans[0].Attributes)
+
+	require.NoError(t, tp.Shutdown(context.Background()))
+	assert.True(t, exp.shutdown)
+}
+
+func TestWrapSpanExporter(t *testing.T) {
+	mem := tracetest.NewInMemoryExporter()
+	exp := WrapSpanExporter(mem)
+
+	ss := testSnapshot()
+	require.NoError(t, exp.ExportSpans(context.Background(), []*SpanSnapshot{ss}))
+
+	got := mem.GetSpans()
+	require.Len(t, got, 1)
+	assert.Equal(t, ss.Name, got[0].Name)
+	assert.Equal(t, ss.SpanContext, got[0].SpanContext)
+	assert.Equal(t, ss.MessageEvents, got[0].MessageEvents)
+	assert.Equal(t, ss.DroppedLinkCount, got[0].DroppedLinkCount)
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+	assert.Empty(t, mem.GetSpans())
+}
+
+func TestWrapPropagatesErrors(t *testing.T) {
+	errExport := errors.New("export failed")
+	exp := WrapSnapshotExporter(&recordingSnapshotExporter{err: errExport})
+
+	ctx := context.Background()
+	assert.ErrorIs(t, exp.ExportSpans(ctx, []sdktrace.ReadOnlySpan{testSnapshot().ReadOnlySpan()}), errExport)
+	assert.ErrorIs(t, exp.Shutdown(ctx), errExport)
+}
+
+func TestWrapUnwraps(t *testing.T) {
+	old := &recordingSnapshotExporter{}
+	assert.Same(t, old, WrapSpanExporter(WrapSnapshotExporter(old)))
+
+	mem := tracetest.NewInMemoryExporter()
+	assert.Same(t, mem, WrapSnapshotExporter(WrapSpanExporter(mem)))
+}
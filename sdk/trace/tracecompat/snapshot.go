@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracecompat // import "go.opentelemetry.io/otel/sdk/trace/tracecompat"
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// SpanSnapshot is a snapshot of a span which contains all the information
+// collected by the span. It has the same layout as the SpanSnapshot type of
+// the removed go.opentelemetry.io/otel/sdk/export/trace package, and as
+// tracetest.SpanStub.
+//
+// Although SpanSnapshot fields can be accessed and potentially modified,
+// SpanSnapshot should be treated as immutable.
+type SpanSnapshot tracetest.SpanStub
+
+// SnapshotFromReadOnlySpan returns a SpanSnapshot populated from ro. It
+// returns nil if ro is nil.
+func SnapshotFromReadOnlySpan(ro sdktrace.ReadOnlySpan) *SpanSnapshot {
+	if ro == nil {
+		return nil
+	}
+	s := SpanSnapshot(tracetest.SpanStubFromReadOnlySpan(ro))
+	return &s
+}
+
+// SnapshotsFromReadOnlySpans returns the SpanSnapshots of all spans in ro.
+func SnapshotsFromReadOnlySpans(ro []sdktrace.ReadOnlySpan) []*SpanSnapshot {
+	if len(ro) == 0 {
+		return nil
+	}
+
+	ss := make([]*SpanSnapshot, 0, len(ro))
+	for _, r := range ro {
+		if s := SnapshotFromReadOnlySpan(r); s != nil {
+			ss = append(ss, s)
+		}
+	}
+	return ss
+}
+
+// ReadOnlySpan returns s as a ReadOnlySpan. It returns nil if s is nil.
+func (s *SpanSnapshot) ReadOnlySpan() sdktrace.ReadOnlySpan {
+	if s == nil {
+		return nil
+	}
+	return tracetest.SpanStub(*s).Snapshot()
+}
+
+// ReadOnlySpans returns all SpanSnapshots in ss as ReadOnlySpans.
+func ReadOnlySpans(ss []*SpanSnapshot) []sdktrace.ReadOnlySpan {
+	if len(ss) == 0 {
+		return nil
+	}
+
+	ro := make([]sdktrace.ReadOnlySpan, 0, len(ss))
+	for _, s := range ss {
+		if s != nil {
+			ro = append(ro, s.ReadOnlySpan())
+		}
+	}
+	return ro
+}
@@ -26,6 +26,18 @@ import (
 type tracer struct {
 	provider               *TracerProvider
 	instrumentationLibrary instrumentation.Library
+
+	// sampler and spanLimits, if non-nil, override the Sampler and
+	// SpanLimits of provider for this tracer.
+	sampler    Sampler
+	spanLimits *SpanLimits
+
+	// disabled tracers do not create spans.
+	disabled bool
+
+	// spanStartOptions are applied to every span before the options passed
+	// to Start.
+	spanStartOptions []trace.SpanOption
 }
 
 var _ trace.Tracer = &tracer{}
@@ -37,7 +49,22 @@ var _ trace.Tracer = &tracer{}
 // configured appropriately by any SpanOption passed. Any Timestamp option
 // passed will be used as the start time of the Span's life-cycle.
 func (tr *tracer) Start(ctx context.Context, name string, options ...trace.SpanOption) (context.Context, trace.Span) {
+	if tr.disabled {
+		// Pass the parent through so spans of other tracers are still
+		// correctly parented.
+		ctx = trace.ContextWithSpanContext(ctx, trace.SpanContextFromContext(ctx))
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	if len(tr.spanStartOptions) > 0 {
+		// Limit the capacity so the defaults are copied, not appended to.
+		options = append(tr.spanStartOptions[:len(tr.spanStartOptions):len(tr.spanStartOptions)], options...)
+	}
 	config := trace.NewSpanConfig(options...)
+	enrich(ctx, tr.provider.enrichers, config)
+	if f := tr.provider.spanNameFormatter; f != nil {
+		name = f(ctx, name)
+	}
 
 	// For local spans created by this SDK, track child span count.
 	if p := trace.SpanFromContext(ctx); p != nil {
@@ -48,7 +75,7 @@ func (tr *tracer) Start(ctx context.Context, name string, options ...trace.SpanO
 
 	span := startSpanInternal(ctx, tr, name, config)
 	for _, l := range config.Links {
-		span.addLink(l)
+		span.AddLink(l)
 	}
 	span.SetAttributes(config.Attributes...)
 
@@ -59,6 +86,9 @@ func (tr *tracer) Start(ctx context.Context, name string, options ...trace.SpanO
 		for _, sp := range sps {
 			sp.sp.OnStart(ctx, span)
 		}
+		for _, l := range tr.provider.listeners() {
+			l.l.OnSpanStart(ctx, span)
+		}
 	}
 
 	ctx, span.executionTracerTaskEnd = func(ctx context.Context) (context.Context, func()) {
@@ -73,3 +103,19 @@ func (tr *tracer) Start(ctx context.Context, name string, options ...trace.SpanO
 
 	return trace.ContextWithSpan(ctx, span), span
 }
+
+// getSampler returns the Sampler used to sample the spans tr creates.
+func (tr *tracer) getSampler() Sampler {
+	if tr.sampler != nil {
+		return tr.sampler
+	}
+	return tr.provider.sampler.Load().(samplerHolder).sampler
+}
+
+// getSpanLimits returns the SpanLimits of the spans tr creates.
+func (tr *tracer) getSpanLimits() SpanLimits {
+	if tr.spanLimits != nil {
+		return *tr.spanLimits
+	}
+	return tr.provider.spanLimits
+}
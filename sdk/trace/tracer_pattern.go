@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/internal/glob"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+)
+
+// tracerPatternVersionSeparator separates the optional instrumentation
+// version a tracer pattern matches from the name pattern.
+const tracerPatternVersionSeparator = "@"
+
+// tracerOverride is the configuration applied to the Tracers with a name
+// matching pattern instead of the TracerProvider defaults.
+type tracerOverride struct {
+	pattern string
+
+	// sampler, if non-nil, replaces the TracerProvider Sampler.
+	sampler Sampler
+
+	// spanLimits, if non-nil, replaces the TracerProvider SpanLimits.
+	spanLimits *SpanLimits
+
+	// enabled, if non-nil, determines if the Tracers create spans.
+	enabled *bool
+}
+
+// match returns if il matches the pattern of o and, if it does, how
+// specific that match is. Exact matches are more specific than any wildcard
+// match, and wildcard matches with more literal characters are more
+// specific than ones with fewer. A pattern that also matches the version is more specific
+// than the same name pattern without a version.
+func (o *tracerOverride) match(il instrumentation.Library) (int, bool) {
+	pattern, version := o.pattern, ""
+	if i := strings.LastIndex(pattern, tracerPatternVersionSeparator); i >= 0 {
+		pattern, version = pattern[:i], pattern[i+1:]
+	}
+	if !glob.Match(pattern, il.Name) || (version != "" && version != il.Version) {
+		return 0, false
+	}
+	s := glob.Specificity(pattern) * 2
+	if version != "" {
+		s++
+	}
+	return s, true
+}
+
+// overrideFor returns the override for il from the TracerProviderConfig
+// overrides that has the most specific matching pattern and for which
+// include returns true. Nil is returned if none match.
+func overrideFor(overrides []*tracerOverride, il instrumentation.Library, include func(*tracerOverride) bool) *tracerOverride {
+	var (
+		best        *tracerOverride
+		specificity = -1
+	)
+	for _, o := range overrides {
+		if !include(o) {
+			continue
+		}
+		if s, ok := o.match(il); ok && s > specificity {
+			best, specificity = o, s
+		}
+	}
+	return best
+}
+
+// tracerOverridesFor returns the Sampler and SpanLimits overriding the
+// TracerProvider defaults for a Tracer of il, and whether that Tracer is
+// disabled. Nil is returned for the Sampler or SpanLimits if the default is
+// not overridden.
+func (p *TracerProvider) tracerOverridesFor(il instrumentation.Library) (Sampler, *SpanLimits, bool) {
+	var (
+		sampler  Sampler
+		limits   *SpanLimits
+		disabled bool
+	)
+	hasSampler := func(o *tracerOverride) bool { return o.sampler != nil }
+	if o := overrideFor(p.tracerOverrides, il, hasSampler); o != nil {
+		sampler = o.sampler
+	}
+	hasLimits := func(o *tracerOverride) bool { return o.spanLimits != nil }
+	if o := overrideFor(p.tracerOverrides, il, hasLimits); o != nil {
+		limits = o.spanLimits
+	}
+	hasEnabled := func(o *tracerOverride) bool { return o.enabled != nil }
+	if o := overrideFor(p.tracerOverrides, il, hasEnabled); o != nil {
+		disabled = !*o.enabled
+	}
+	return sampler, limits, disabled
+}
+
+// tracerOverride returns the override for pattern stored in cfg, creating
+// it if it does not exist.
+func (cfg *TracerProviderConfig) tracerOverride(pattern string) *tracerOverride {
+	for _, o := range cfg.tracerOverrides {
+		if o.pattern == pattern {
+			return o
+		}
+	}
+	o := &tracerOverride{pattern: pattern}
+	cfg.tracerOverrides = append(cfg.tracerOverrides, o)
+	return o
+}
+
+// WithTracerSampler returns a TracerProviderOption that will configure the
+// Sampler s to be used by the Tracers the TracerProvider creates with a name
+// matching pattern instead of the TracerProvider's Sampler.
+//
+// Tracer names are hierarchical, commonly the import path of the
+// instrumentation library. A "*" in a pattern matches any sequence of
+// characters, including "/" (e.g. "github.com/legacy/*"), a pattern without
+// "*" only matches a name exactly. If multiple patterns match a name, an
+// exact match is used before the wildcard pattern with the most literal
+// characters.
+//
+// A pattern can be restricted to a single instrumentation version by
+// appending "@" and the version (e.g. "github.com/legacy/*@v0.1.0"). Such a
+// pattern is preferred over the same pattern without a version.
+func WithTracerSampler(pattern string, s Sampler) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		if s != nil {
+			opts.tracerOverride(pattern).sampler = s
+		}
+	}
+}
+
+// WithTracerSpanLimits returns a TracerProviderOption that will configure the
+// SpanLimits sl to be used by the Tracers the TracerProvider creates with a
+// name matching pattern instead of the TracerProvider's SpanLimits. Any
+// unset or invalid limit in sl is set to its default value.
+//
+// Patterns are matched against Tracer names as described by
+// WithTracerSampler.
+func WithTracerSpanLimits(pattern string, sl SpanLimits) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		sl.ensureDefault()
+		opts.tracerOverride(pattern).spanLimits = &sl
+	}
+}
+
+// WithTracerEnabled returns a TracerProviderOption that will configure
+// whether the Tracers the TracerProvider creates with a name matching
+// pattern create spans. The Tracers of a disabled pattern return
+// non-recording spans that propagate the span context of their parent, so
+// spans started by other Tracers are parented as if the disabled Tracers
+// did not exist. This can, for example, be used to silence a noisy
+// instrumentation library. A more specific pattern can re-enable Tracers of
+// a disabled pattern.
+//
+// Patterns are matched against Tracer names and versions as described by
+// WithTracerSampler.
+func WithTracerEnabled(pattern string, enabled bool) TracerProviderOption {
+	return func(opts *TracerProviderConfig) {
+		opts.tracerOverride(pattern).enabled = &enabled
+	}
+}
@@ -21,7 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"go.opentelemetry.io/otel/sdk/export/trace"
+	"go.opentelemetry.io/otel/sdk/trace"
 )
 
 // TestNoop tests only that the no-op does not crash in different scenarios.
@@ -29,8 +29,8 @@ func TestNoop(t *testing.T) {
 	nsb := NewNoopExporter()
 
 	require.NoError(t, nsb.ExportSpans(context.Background(), nil))
-	require.NoError(t, nsb.ExportSpans(context.Background(), make([]*trace.SpanSnapshot, 10)))
-	require.NoError(t, nsb.ExportSpans(context.Background(), make([]*trace.SpanSnapshot, 0, 10)))
+	require.NoError(t, nsb.ExportSpans(context.Background(), make([]trace.ReadOnlySpan, 10)))
+	require.NoError(t, nsb.ExportSpans(context.Background(), make([]trace.ReadOnlySpan, 0, 10)))
 }
 
 func TestNewInMemoryExporter(t *testing.T) {
@@ -39,23 +39,45 @@ func TestNewInMemoryExporter(t *testing.T) {
 	require.NoError(t, imsb.ExportSpans(context.Background(), nil))
 	assert.Len(t, imsb.GetSpans(), 0)
 
-	input := make([]*trace.SpanSnapshot, 10)
+	input := make(SpanStubs, 10)
 	for i := 0; i < 10; i++ {
-		input[i] = new(trace.SpanSnapshot)
+		input[i] = SpanStub{Name: "span", ChildSpanCount: i}
 	}
-	require.NoError(t, imsb.ExportSpans(context.Background(), input))
+	require.NoError(t, imsb.ExportSpans(context.Background(), input.Snapshots()))
 	sds := imsb.GetSpans()
 	assert.Len(t, sds, 10)
 	for i, sd := range sds {
-		assert.Same(t, input[i], sd)
+		assert.Equal(t, input[i], sd)
 	}
 	imsb.Reset()
 	// Ensure that operations on the internal storage does not change the previously returned value.
 	assert.Len(t, sds, 10)
 	assert.Len(t, imsb.GetSpans(), 0)
 
-	require.NoError(t, imsb.ExportSpans(context.Background(), input[0:1]))
+	require.NoError(t, imsb.ExportSpans(context.Background(), input.Snapshots()[0:1]))
 	sds = imsb.GetSpans()
 	assert.Len(t, sds, 1)
-	assert.Same(t, input[0], sds[0])
+	assert.Equal(t, input[0], sds[0])
+}
+
+func TestSpanStubRoundTrip(t *testing.T) {
+	tp := trace.NewTracerProvider(trace.WithSampler(trace.AlwaysSample()))
+	exp := NewInMemoryExporter()
+	tp.RegisterSpanProcessor(trace.NewSimpleSpanProcessor(exp))
+
+	_, span := tp.Tracer("tracetest").Start(context.Background(), "span")
+	span.AddEvent("event")
+	span.End()
+
+	spans := exp.GetSpans()
+	require.Len(t, spans, 1)
+	stub := spans[0]
+	assert.Equal(t, "span", stub.Name)
+	assert.Len(t, stub.MessageEvents, 1)
+	assert.Equal(t, "tracetest", stub.InstrumentationLibrary.Name)
+
+	ro := stub.Snapshot()
+	assert.Equal(t, stub, SpanStubFromReadOnlySpan(ro))
+	assert.True(t, ro.IsLocalRoot())
+	assert.False(t, ro.IsRecording())
 }
@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracetest
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSpanRecorder(t *testing.T) {
+	sr := NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	tracer := tp.Tracer("tracetest")
+
+	_, span := tracer.Start(context.Background(), "span")
+	require.Len(t, sr.Started(), 1)
+	assert.Equal(t, "span", sr.Started()[0].Name())
+	assert.Len(t, sr.Ended(), 0)
+
+	span.End()
+	require.Len(t, sr.Ended(), 1)
+	assert.Equal(t, "span", sr.Ended()[0].Name())
+
+	sr.Reset()
+	assert.Len(t, sr.Started(), 0)
+	assert.Len(t, sr.Ended(), 0)
+
+	assert.NoError(t, sr.ForceFlush(context.Background()))
+	assert.NoError(t, sr.Shutdown(context.Background()))
+}
+
+func TestSpanRecorderConcurrentSafe(t *testing.T) {
+	sr := NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)).Tracer("tracetest")
+
+	const n = 20
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			_, span := tracer.Start(context.Background(), "span")
+			_ = sr.Started()
+			span.End()
+			_ = sr.Ended()
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, sr.Started(), n)
+	assert.Len(t, sr.Ended(), n)
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace // import "go.opentelemetry.io/otel/sdk/trace"
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// DefaultTranslationCacheSize is the default maximum number of translations
+// of each kind held by a TranslationCache.
+const DefaultTranslationCacheSize = 64
+
+// TranslationCache memoizes the translation of the Resource and
+// instrumentation.Library of spans into the representation used by a
+// SpanExporter. These are the same for nearly all spans exported by a
+// process, caching them avoids translating them again for every batch.
+//
+// The translated values returned by a TranslationCache are shared, they
+// must not be modified. Implementations must be safe for concurrent use.
+type TranslationCache interface {
+	// Resource returns the translation of r. If no translation of r is
+	// cached, it is computed with translate and cached.
+	Resource(r *resource.Resource, translate func(*resource.Resource) interface{}) interface{}
+
+	// InstrumentationLibrary returns the translation of il. If no
+	// translation of il is cached, it is computed with translate and
+	// cached.
+	InstrumentationLibrary(il instrumentation.Library, translate func(instrumentation.Library) interface{}) interface{}
+}
+
+// NewTranslationCache returns a TranslationCache holding at most size
+// translations of each kind. Once a kind is full all its translations are
+// discarded. If size is zero or less, DefaultTranslationCacheSize is used.
+func NewTranslationCache(size int) TranslationCache {
+	if size <= 0 {
+		size = DefaultTranslationCacheSize
+	}
+	return &translationCache{
+		size:      size,
+		resources: make(map[resource.Key]interface{}),
+		libraries: make(map[instrumentation.Library]interface{}),
+	}
+}
+
+type translationCache struct {
+	size int
+
+	mu        sync.RWMutex
+	resources map[resource.Key]interface{}
+	libraries map[instrumentation.Library]interface{}
+}
+
+// Resource returns the cached translation of r.
+func (c *translationCache) Resource(r *resource.Resource, translate func(*resource.Resource) interface{}) interface{} {
+	key := r.Key()
+	c.mu.RLock()
+	v, ok := c.resources[key]
+	c.mu.RUnlock()
+	if ok {
+		return v
+	}
+
+	v = translate(r)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.resources) >= c.size {
+		c.resources = make(map[resource.Key]interface{})
+	}
+	c.resources[key] = v
+	return v
+}
+
+// InstrumentationLibrary returns the cached translation of il.
+func (c *translationCache) InstrumentationLibrary(il instrumentation.Library, translate func(instrumentation.Library) interface{}) interface{} {
+	c.mu.RLock()
+	v, ok := c.libraries[il]
+	c.mu.RUnlock()
+	if ok {
+		return v
+	}
+
+	v = translate(il)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.libraries) >= c.size {
+		c.libraries = make(map[instrumentation.Library]interface{})
+	}
+	c.libraries[il] = v
+	return v
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trace_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestTranslationCacheResource(t *testing.T) {
+	cache := sdktrace.NewTranslationCache(2)
+	var calls int
+	translate := func(r *resource.Resource) interface{} {
+		calls++
+		return r.Encoded(attribute.DefaultEncoder())
+	}
+
+	r1 := resource.NewWithAttributes(attribute.String("A", "1"))
+	assert.Equal(t, "A=1", cache.Resource(r1, translate))
+	assert.Equal(t, "A=1", cache.Resource(resource.NewWithAttributes(attribute.String("A", "1")), translate))
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, "", cache.Resource(nil, translate))
+	assert.Equal(t, "", cache.Resource(resource.Empty(), translate))
+	assert.Equal(t, 2, calls)
+
+	// The cache is full, adding a translation discards the others.
+	cache.Resource(resource.NewWithAttributes(attribute.String("B", "2")), translate)
+	assert.Equal(t, 3, calls)
+	cache.Resource(r1, translate)
+	assert.Equal(t, 4, calls)
+}
+
+func TestTranslationCacheInstrumentationLibrary(t *testing.T) {
+	cache := sdktrace.NewTranslationCache(0)
+	var calls int
+	translate := func(il instrumentation.Library) interface{} {
+		calls++
+		return il.Name + "@" + il.Version
+	}
+
+	il := instrumentation.Library{Name: "lib", Version: "v1"}
+	assert.Equal(t, "lib@v1", cache.InstrumentationLibrary(il, translate))
+	assert.Equal(t, "lib@v1", cache.InstrumentationLibrary(il, translate))
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, "lib@v2", cache.InstrumentationLibrary(instrumentation.Library{Name: "lib", Version: "v2"}, translate))
+	assert.Equal(t, 2, calls)
+}
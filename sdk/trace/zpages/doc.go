@@ -0,0 +1,34 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package zpages provides in-process debugging pages for the
+// OpenTelemetry SDK.
+//
+// The tracez page lists, for every span name, the spans that are currently
+// running, a sample of recently ended spans bucketed by latency, and a
+// sample of recent spans that ended with an error status. Register a
+// SpanProcessor with a TracerProvider and serve its data with a
+// TracezHandler:
+//
+//	zsp := zpages.NewSpanProcessor()
+//	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(zsp))
+//	http.Handle("/debug/tracez", zpages.NewTracezHandler(zsp))
+//
+// The samplingz page lists, for every span name, the sampling decisions
+// recorded by a SamplingRecorder wrapping the Sampler of a TracerProvider:
+//
+//	sr := sdktrace.NewSamplingRecorder(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0.1)))
+//	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sr))
+//	http.Handle("/debug/samplingz", zpages.NewSamplingzHandler(sr))
+package zpages // import "go.opentelemetry.io/otel/sdk/trace/zpages"
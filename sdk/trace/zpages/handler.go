@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages // import "go.opentelemetry.io/otel/sdk/trace/zpages"
+
+import (
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// Values of the ztype query parameter selecting which spans of a name to
+// display.
+const (
+	typeRunning = iota
+	typeLatency
+	typeError
+)
+
+var latencyBucketNames = []string{
+	">0s", ">10µs", ">100µs", ">1ms", ">10ms", ">100ms", ">1s", ">10s", ">100s",
+}
+
+var tracezTemplate = template.Must(template.New("tracez").Funcs(template.FuncMap{
+	"duration": func(s sdktrace.ReadOnlySpan) string {
+		end := s.EndTime()
+		if end.IsZero() {
+			end = time.Now()
+		}
+		return end.Sub(s.StartTime()).String()
+	},
+	"timestamp": func(t time.Time) string {
+		return t.Format("2006/01/02-15:04:05.000000")
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><title>tracez</title></head>
+<body>
+<h1>tracez</h1>
+<table border="1">
+<tr><th>Span Name</th><th>Running</th>{{range .Buckets}}<th>{{.}}</th>{{end}}<th>Errors</th></tr>
+{{range .Summaries}}{{$name := .Name}}<tr>
+<td>{{.Name}}</td>
+<td><a href="?zspanname={{.Name}}&amp;ztype=0">{{.Running}}</a></td>
+{{range $i, $n := .Latency}}<td><a href="?zspanname={{$name}}&amp;ztype=1&amp;zlatencybucket={{$i}}">{{$n}}</a></td>
+{{end}}<td><a href="?zspanname={{.Name}}&amp;ztype=2">{{.Errors}}</a></td>
+</tr>
+{{end}}</table>
+{{if .Selected}}
+<h2>{{.Selected}}: {{.Kind}}</h2>
+<table border="1">
+<tr><th>Start</th><th>Duration</th><th>Trace ID</th><th>Span ID</th><th>Parent Span ID</th><th>Status</th><th>Attributes</th><th>Events</th></tr>
+{{range .Spans}}<tr>
+<td>{{timestamp .StartTime}}</td>
+<td>{{duration .}}</td>
+<td>{{.SpanContext.TraceID}}</td>
+<td>{{.SpanContext.SpanID}}</td>
+<td>{{if .Parent.IsValid}}{{.Parent.SpanID}}{{end}}</td>
+<td>{{.StatusCode}} {{.StatusMessage}}</td>
+<td>{{range .Attributes}}{{.Key}}={{.Value.Emit}}<br>{{end}}</td>
+<td>{{range .Events}}{{timestamp .Time}} {{.Name}}<br>{{end}}</td>
+</tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+`))
+
+type tracezData struct {
+	Buckets   []string
+	Summaries []summary
+	Selected  string
+	Kind      string
+	Spans     []sdktrace.ReadOnlySpan
+}
+
+// tracezHandler serves the tracez page.
+type tracezHandler struct {
+	sp *SpanProcessor
+}
+
+// NewTracezHandler returns an http.Handler serving the tracez page for the
+// spans tracked by sp.
+func NewTracezHandler(sp *SpanProcessor) http.Handler {
+	return &tracezHandler{sp: sp}
+}
+
+func (h *tracezHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	data := tracezData{
+		Buckets:   latencyBucketNames,
+		Summaries: h.sp.summaries(),
+	}
+
+	if name := r.Form.Get("zspanname"); name != "" {
+		data.Selected = name
+		typ, _ := strconv.Atoi(r.Form.Get("ztype"))
+		switch typ {
+		case typeRunning:
+			data.Kind = "running"
+			data.Spans = h.sp.runningSpans(name)
+		case typeLatency:
+			bucket, _ := strconv.Atoi(r.Form.Get("zlatencybucket"))
+			if bucket >= 0 && bucket < len(latencyBucketNames) {
+				data.Kind = "latency " + latencyBucketNames[bucket]
+			}
+			data.Spans = h.sp.latencySpans(name, bucket)
+		case typeError:
+			data.Kind = "errors"
+			data.Spans = h.sp.errorSpans(name)
+		default:
+			http.Error(w, "unknown ztype "+strconv.Itoa(typ), http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tracezTemplate.Execute(w, data); err != nil {
+		otel.HandleSignal(otel.TracesSignal, err)
+	}
+}
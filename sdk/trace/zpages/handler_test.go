@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func serveTracez(sp *SpanProcessor, query string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	NewTracezHandler(sp).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tracez"+query, nil))
+	return rec
+}
+
+func TestTracezHandlerSummary(t *testing.T) {
+	sp := NewSpanProcessor()
+	tracer := newTestTracer(sp)
+	_, running := tracer.Start(context.Background(), "running-op")
+	defer running.End()
+	_, ended := tracer.Start(context.Background(), "ended-op")
+	ended.End()
+
+	rec := serveTracez(sp, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	body := rec.Body.String()
+	assert.Contains(t, body, "running-op")
+	assert.Contains(t, body, "ended-op")
+	assert.Contains(t, body, "&gt;10ms")
+}
+
+func TestTracezHandlerDetails(t *testing.T) {
+	sp := NewSpanProcessor()
+	tracer := newTestTracer(sp)
+
+	_, running := tracer.Start(context.Background(), "op")
+	running.SetAttributes(attribute.String("running-key", "v"))
+	defer running.End()
+
+	_, span := tracer.Start(context.Background(), "op")
+	span.SetAttributes(attribute.String("error-key", "<v>"))
+	span.SetStatus(codes.Error, "boom")
+	span.End()
+
+	body := serveTracez(sp, "?zspanname=op&ztype=0").Body.String()
+	assert.Contains(t, body, "op: running")
+	assert.Contains(t, body, running.SpanContext().TraceID().String())
+	assert.Contains(t, body, "running-key=v")
+
+	body = serveTracez(sp, "?zspanname=op&ztype=2").Body.String()
+	assert.Contains(t, body, "op: errors")
+	assert.Contains(t, body, span.SpanContext().SpanID().String())
+	assert.Contains(t, body, "boom")
+	// Attribute values are escaped.
+	assert.Contains(t, body, "error-key=&lt;v&gt;")
+
+	body = serveTracez(sp, "?zspanname=op&ztype=1&zlatencybucket=0").Body.String()
+	assert.Contains(t, body, "op: latency &gt;0s")
+}
+
+func TestTracezHandlerUnknownType(t *testing.T) {
+	rec := serveTracez(NewSpanProcessor(), "?zspanname=op&ztype=9")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,277 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages // import "go.opentelemetry.io/otel/sdk/trace/zpages"
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// defaultSampleSize is the number of ended spans kept for every
+	// latency bucket and for errors of a span name.
+	defaultSampleSize = 16
+
+	// maxNames is the number of distinct span names tracked. Spans with
+	// other names are ignored once it is reached.
+	maxNames = 1024
+)
+
+// latencyBucketBounds are the lower bounds of the latency buckets ended
+// spans are sorted into. The last bucket has no upper bound.
+var latencyBucketBounds = []time.Duration{
+	0,
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+	100 * time.Second,
+}
+
+// latencyBucket returns the index of the bucket d falls into.
+func latencyBucket(d time.Duration) int {
+	return sort.Search(len(latencyBucketBounds), func(i int) bool {
+		return latencyBucketBounds[i] > d
+	}) - 1
+}
+
+// spanKey identifies a running span.
+type spanKey struct {
+	traceID trace.TraceID
+	spanID  trace.SpanID
+}
+
+func keyOf(sc trace.SpanContext) spanKey {
+	return spanKey{traceID: sc.TraceID(), spanID: sc.SpanID()}
+}
+
+// sampleRing holds the most recently added spans up to its capacity.
+type sampleRing struct {
+	spans []sdktrace.ReadOnlySpan
+	next  int
+}
+
+func (r *sampleRing) add(s sdktrace.ReadOnlySpan) {
+	if len(r.spans) < defaultSampleSize {
+		r.spans = append(r.spans, s)
+		return
+	}
+	r.spans[r.next] = s
+	r.next = (r.next + 1) % defaultSampleSize
+}
+
+// list returns the held spans ordered from oldest to newest.
+func (r *sampleRing) list() []sdktrace.ReadOnlySpan {
+	out := make([]sdktrace.ReadOnlySpan, 0, len(r.spans))
+	out = append(out, r.spans[r.next:]...)
+	return append(out, r.spans[:r.next]...)
+}
+
+// nameData holds the tracked spans of a single span name.
+type nameData struct {
+	mu      sync.Mutex
+	running map[spanKey]sdktrace.ReadWriteSpan
+	latency []sampleRing
+	errors  sampleRing
+}
+
+func newNameData() *nameData {
+	return &nameData{
+		running: make(map[spanKey]sdktrace.ReadWriteSpan),
+		latency: make([]sampleRing, len(latencyBucketBounds)),
+	}
+}
+
+// SpanProcessor is a SpanProcessor that keeps track of running spans and
+// samples of ended spans to be displayed by a TracezHandler.
+type SpanProcessor struct {
+	mu    sync.RWMutex
+	names map[string]*nameData
+
+	// running maps the key of every running span to the data of the name
+	// it was started with.
+	running sync.Map
+}
+
+var _ sdktrace.SpanProcessor = (*SpanProcessor)(nil)
+
+// NewSpanProcessor returns a new SpanProcessor.
+func NewSpanProcessor() *SpanProcessor {
+	return &SpanProcessor{names: make(map[string]*nameData)}
+}
+
+// lookup returns the data of name, or nil if name is not tracked.
+func (sp *SpanProcessor) lookup(name string) *nameData {
+	sp.mu.RLock()
+	defer sp.mu.RUnlock()
+	return sp.names[name]
+}
+
+// data returns the data of name, creating it if needed. It returns nil if
+// name is not tracked and maxNames is reached.
+func (sp *SpanProcessor) data(name string) *nameData {
+	if d := sp.lookup(name); d != nil {
+		return d
+	}
+
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	d, ok := sp.names[name]
+	if !ok {
+		if len(sp.names) >= maxNames {
+			return nil
+		}
+		d = newNameData()
+		sp.names[name] = d
+	}
+	return d
+}
+
+// OnStart tracks s as a running span.
+func (sp *SpanProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	d := sp.data(s.Name())
+	if d == nil {
+		return
+	}
+	key := keyOf(s.SpanContext())
+	d.mu.Lock()
+	d.running[key] = s
+	d.mu.Unlock()
+	sp.running.Store(key, d)
+}
+
+// OnEnding does nothing.
+func (sp *SpanProcessor) OnEnding(sdktrace.ReadWriteSpan) {}
+
+// OnEnd stops tracking s as a running span and records it as a latency
+// sample, and as an error sample if it ended with an error status.
+func (sp *SpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	key := keyOf(s.SpanContext())
+	// The name may have changed while the span was running, it is
+	// tracked under the one it was started with.
+	if v, ok := sp.running.Load(key); ok {
+		sp.running.Delete(key)
+		started := v.(*nameData)
+		started.mu.Lock()
+		delete(started.running, key)
+		started.mu.Unlock()
+	}
+
+	d := sp.data(s.Name())
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.latency[latencyBucket(s.EndTime().Sub(s.StartTime()))].add(s)
+	if s.StatusCode() == codes.Error {
+		d.errors.add(s)
+	}
+}
+
+// Shutdown does nothing.
+func (sp *SpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush does nothing.
+func (sp *SpanProcessor) ForceFlush(context.Context) error {
+	return nil
+}
+
+// summary is the overview of the spans of a single name.
+type summary struct {
+	Name    string
+	Running int
+	Latency []int
+	Errors  int
+}
+
+// summaries returns the overview of all tracked span names ordered by
+// name.
+func (sp *SpanProcessor) summaries() []summary {
+	sp.mu.RLock()
+	names := make(map[string]*nameData, len(sp.names))
+	for name, d := range sp.names {
+		names[name] = d
+	}
+	sp.mu.RUnlock()
+
+	out := make([]summary, 0, len(names))
+	for name, d := range names {
+		d.mu.Lock()
+		s := summary{
+			Name:    name,
+			Running: len(d.running),
+			Latency: make([]int, len(d.latency)),
+			Errors:  len(d.errors.spans),
+		}
+		for i := range d.latency {
+			s.Latency[i] = len(d.latency[i].spans)
+		}
+		d.mu.Unlock()
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// runningSpans returns the running spans named name ordered by start time.
+func (sp *SpanProcessor) runningSpans(name string) []sdktrace.ReadOnlySpan {
+	d := sp.lookup(name)
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	out := make([]sdktrace.ReadOnlySpan, 0, len(d.running))
+	for _, s := range d.running {
+		out = append(out, s)
+	}
+	d.mu.Unlock()
+	sort.Slice(out, func(i, j int) bool { return out[i].StartTime().Before(out[j].StartTime()) })
+	return out
+}
+
+// latencySpans returns the latency samples of bucket for spans named name.
+func (sp *SpanProcessor) latencySpans(name string, bucket int) []sdktrace.ReadOnlySpan {
+	d := sp.lookup(name)
+	if d == nil || bucket < 0 || bucket >= len(d.latency) {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.latency[bucket].list()
+}
+
+// errorSpans returns the error samples for spans named name.
+func (sp *SpanProcessor) errorSpans(name string) []sdktrace.ReadOnlySpan {
+	d := sp.lookup(name)
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.errors.list()
+}
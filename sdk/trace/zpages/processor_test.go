@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func newTestTracer(sp *SpanProcessor) trace.Tracer {
+	return sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sp)).Tracer("zpages")
+}
+
+func TestLatencyBucket(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want int
+	}{
+		{0, 0},
+		{9 * time.Microsecond, 0},
+		{10 * time.Microsecond, 1},
+		{500 * time.Microsecond, 2},
+		{5 * time.Millisecond, 3},
+		{50 * time.Millisecond, 4},
+		{500 * time.Millisecond, 5},
+		{5 * time.Second, 6},
+		{50 * time.Second, 7},
+		{time.Hour, 8},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, latencyBucket(test.d), test.d.String())
+	}
+}
+
+func TestSpanProcessorRunning(t *testing.T) {
+	sp := NewSpanProcessor()
+	tracer := newTestTracer(sp)
+
+	_, span := tracer.Start(context.Background(), "op")
+	require.Len(t, sp.runningSpans("op"), 1)
+	assert.Equal(t, "op", sp.runningSpans("op")[0].Name())
+
+	span.End()
+	assert.Len(t, sp.runningSpans("op"), 0)
+	assert.Nil(t, sp.runningSpans("unknown"))
+}
+
+func TestSpanProcessorRenamedRunningSpan(t *testing.T) {
+	sp := NewSpanProcessor()
+	start := time.Unix(0, 0)
+	_, span := newTestTracer(sp).Start(context.Background(), "before", trace.WithTimestamp(start))
+	span.SetName("after")
+	span.End(trace.WithTimestamp(start))
+
+	assert.Len(t, sp.runningSpans("before"), 0)
+	assert.Len(t, sp.latencySpans("after", 0), 1)
+}
+
+func TestSpanProcessorLatencyAndErrors(t *testing.T) {
+	sp := NewSpanProcessor()
+	tracer := newTestTracer(sp)
+
+	start := time.Unix(0, 0)
+	_, fast := tracer.Start(context.Background(), "op", trace.WithTimestamp(start))
+	fast.End(trace.WithTimestamp(start.Add(5 * time.Microsecond)))
+
+	_, slow := tracer.Start(context.Background(), "op", trace.WithTimestamp(start))
+	slow.SetStatus(codes.Error, "failed")
+	slow.End(trace.WithTimestamp(start.Add(2 * time.Second)))
+
+	assert.Len(t, sp.latencySpans("op", 0), 1)
+	assert.Len(t, sp.latencySpans("op", 6), 1)
+	assert.Nil(t, sp.latencySpans("op", len(latencyBucketBounds)))
+	require.Len(t, sp.errorSpans("op"), 1)
+	assert.Equal(t, "failed", sp.errorSpans("op")[0].StatusMessage())
+
+	sums := sp.summaries()
+	require.Len(t, sums, 1)
+	assert.Equal(t, "op", sums[0].Name)
+	assert.Equal(t, 0, sums[0].Running)
+	assert.Equal(t, 1, sums[0].Latency[0])
+	assert.Equal(t, 1, sums[0].Latency[6])
+	assert.Equal(t, 1, sums[0].Errors)
+}
+
+func TestSpanProcessorSampleSize(t *testing.T) {
+	sp := NewSpanProcessor()
+	tracer := newTestTracer(sp)
+
+	start := time.Unix(0, 0)
+	for i := 0; i < defaultSampleSize+5; i++ {
+		_, span := tracer.Start(context.Background(), "op", trace.WithTimestamp(start.Add(time.Duration(i))))
+		span.End(trace.WithTimestamp(start.Add(time.Duration(i))))
+	}
+
+	spans := sp.latencySpans("op", 0)
+	require.Len(t, spans, defaultSampleSize)
+	// The oldest spans are evicted first and the rest stay in order.
+	assert.Equal(t, start.Add(5), spans[0].StartTime())
+	assert.Equal(t, start.Add(defaultSampleSize+4), spans[defaultSampleSize-1].StartTime())
+}
+
+func TestSpanProcessorNameLimit(t *testing.T) {
+	sp := NewSpanProcessor()
+	tracer := newTestTracer(sp)
+
+	start := time.Unix(0, 0)
+	for i := 0; i < maxNames; i++ {
+		_, span := tracer.Start(context.Background(), fmt.Sprintf("op-%d", i), trace.WithTimestamp(start))
+		span.End(trace.WithTimestamp(start))
+	}
+	_, span := tracer.Start(context.Background(), "over", trace.WithTimestamp(start))
+	assert.Nil(t, sp.runningSpans("over"))
+	span.End(trace.WithTimestamp(start))
+	assert.Nil(t, sp.latencySpans("over", 0))
+	assert.Len(t, sp.summaries(), maxNames)
+
+	// Names tracked before the limit is reached are still recorded.
+	_, span = tracer.Start(context.Background(), "op-0", trace.WithTimestamp(start))
+	span.End(trace.WithTimestamp(start))
+	assert.Len(t, sp.latencySpans("op-0", 0), 2)
+}
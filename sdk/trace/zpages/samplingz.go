@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages // import "go.opentelemetry.io/otel/sdk/trace/zpages"
+
+import (
+	"html/template"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+var samplingzTemplate = template.Must(template.New("samplingz").Funcs(template.FuncMap{
+	"decision": func(d sdktrace.SamplingDecision) string {
+		switch d {
+		case sdktrace.Drop:
+			return "Drop"
+		case sdktrace.RecordOnly:
+			return "RecordOnly"
+		case sdktrace.RecordAndSample:
+			return "RecordAndSample"
+		}
+		return "unknown"
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head><title>samplingz</title></head>
+<body>
+<h1>samplingz</h1>
+<p>Sampler: {{.Sampler}}</p>
+<table border="1">
+<tr><th>Span Name</th><th>Drop</th><th>RecordOnly</th><th>RecordAndSample</th><th>Last Decision</th><th>Last Attributes</th></tr>
+{{range .Stats}}<tr>
+<td>{{.SpanName}}</td>
+<td>{{.Drop}}</td>
+<td>{{.RecordOnly}}</td>
+<td>{{.RecordAndSample}}</td>
+<td>{{decision .LastDecision}}</td>
+<td>{{range .LastAttributes}}{{.Key}}={{.Value.Emit}}<br>{{end}}</td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+type samplingzData struct {
+	Sampler string
+	Stats   []sdktrace.SamplingStats
+}
+
+// samplingzHandler serves the samplingz page.
+type samplingzHandler struct {
+	sr *sdktrace.SamplingRecorder
+}
+
+// NewSamplingzHandler returns an http.Handler serving the samplingz page
+// for the sampling decisions recorded by sr.
+func NewSamplingzHandler(sr *sdktrace.SamplingRecorder) http.Handler {
+	return &samplingzHandler{sr: sr}
+}
+
+func (h *samplingzHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	data := samplingzData{
+		Sampler: h.sr.Description(),
+		Stats:   h.sr.Stats(),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := samplingzTemplate.Execute(w, data); err != nil {
+		otel.HandleSignal(otel.TracesSignal, err)
+	}
+}
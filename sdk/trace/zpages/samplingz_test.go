@@ -0,0 +1,43 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package zpages
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestSamplingzHandler(t *testing.T) {
+	sr := sdktrace.NewSamplingRecorder(sdktrace.NeverSample())
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSampler(sr)).Tracer("TestSamplingzHandler")
+	_, span := tracer.Start(context.Background(), "<op>")
+	span.End()
+
+	rec := httptest.NewRecorder()
+	NewSamplingzHandler(sr).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/samplingz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	body := rec.Body.String()
+	assert.Contains(t, body, "SamplingRecorder{AlwaysOffSampler}")
+	// Span names are escaped.
+	assert.Contains(t, body, "<td>&lt;op&gt;</td>\n<td>1</td>")
+	assert.Contains(t, body, "<td>Drop</td>")
+}
@@ -14,11 +14,10 @@
 
 // Package semconv implements OpenTelemetry semantic conventions.
 //
-// This package is currently in a pre-GA phase. Backwards incompatible changes
-// may be introduced in subsequent minor version releases as we work to track
-// the evolving OpenTelemetry specification and user feedback.
+// The semantic conventions are defined in versioned sub-packages, such as
+// go.opentelemetry.io/otel/semconv/v1.4.0, each exposing the SchemaURL of
+// its version. The declarations of this package forward to the ones of
+// go.opentelemetry.io/otel/semconv/v1.4.0.
 //
-// OpenTelemetry semantic conventions are agreed standardized naming
-// patterns for OpenTelemetry things. This package aims to be the
-// centralized place to interact with these conventions.
+// Deprecated: use go.opentelemetry.io/otel/semconv/v1.4.0 instead.
 package semconv // import "go.opentelemetry.io/otel/semconv"
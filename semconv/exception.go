@@ -4,36 +4,36 @@
 // you may not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//     http://www.apache.org/licenses/LICENSE-2.0
+//	http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing, software
 // distributed under the License is distributed on an "AS IS" BASIS,
 // WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 // See the License for the specific language governing permissions and
 // limitations under the License.
-package semconv
+package semconv // import "go.opentelemetry.io/otel/semconv"
 
-import "go.opentelemetry.io/otel/attribute"
+import semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 
 // Semantic conventions for exception attribute keys.
 const (
 	// The Go type containing the error or exception.
-	ExceptionTypeKey = attribute.Key("exception.type")
+	ExceptionTypeKey = semconv.ExceptionTypeKey
 
 	// The exception message.
-	ExceptionMessageKey = attribute.Key("exception.message")
+	ExceptionMessageKey = semconv.ExceptionMessageKey
 
 	// A stacktrace as a string. This most commonly will come from
 	// "runtime/debug".Stack.
-	ExceptionStacktraceKey = attribute.Key("exception.stacktrace")
+	ExceptionStacktraceKey = semconv.ExceptionStacktraceKey
 
 	// If the exception event is recorded at a point where it is known
 	// that the exception is escaping the scope of the span this
 	// attribute is set to true.
-	ExceptionEscapedKey = attribute.Key("exception.escaped")
+	ExceptionEscapedKey = semconv.ExceptionEscapedKey
 )
 
 const (
 	// ExceptionEventName is the name of the Span event representing an exception.
-	ExceptionEventName = "exception"
+	ExceptionEventName = semconv.ExceptionEventName
 )
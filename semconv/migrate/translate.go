@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package migrate translates the attribute keys of the OpenTelemetry
+// semantic conventions between the versions defined by the versioned
+// sub-packages of go.opentelemetry.io/otel/semconv, so that libraries
+// pinned to different versions can be used in one binary.
+package migrate // import "go.opentelemetry.io/otel/semconv/migrate"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	v140 "go.opentelemetry.io/otel/semconv/v1.4.0"
+	v150 "go.opentelemetry.io/otel/semconv/v1.5.0"
+)
+
+// version is a version of the semantic conventions along with the attribute
+// keys it renamed, mapping the key of the previous version to the new one.
+type version struct {
+	schemaURL string
+	renames   map[attribute.Key]attribute.Key
+}
+
+// history is every version of the semantic conventions that has a
+// versioned sub-package, oldest first.
+var history = []version{
+	{schemaURL: v140.SchemaURL},
+	// No attribute key was renamed in 1.5.0.
+	{schemaURL: v150.SchemaURL},
+}
+
+// Translate returns attrs with their keys translated from the version of
+// the semantic conventions identified by fromSchemaURL to the one
+// identified by toSchemaURL. The keys are translated version by version,
+// through every version between the two, in either direction.
+//
+// A key renamed from several keys cannot be mapped back to its original
+// key and is kept when translating to an older version. An error is
+// returned if either schema URL is unknown.
+func Translate(attrs []attribute.KeyValue, fromSchemaURL, toSchemaURL string) ([]attribute.KeyValue, error) {
+	from, err := versionIndex(fromSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+	to, err := versionIndex(toSchemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]attribute.KeyValue, len(attrs))
+	copy(out, attrs)
+	for i := from + 1; i <= to; i++ {
+		rename(out, history[i].renames)
+	}
+	for i := from; i > to; i-- {
+		rename(out, reverse(history[i].renames))
+	}
+	return out, nil
+}
+
+func versionIndex(schemaURL string) (int, error) {
+	for i, v := range history {
+		if v.schemaURL == schemaURL {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown semantic conventions schema URL: %q", schemaURL)
+}
+
+func rename(attrs []attribute.KeyValue, renames map[attribute.Key]attribute.Key) {
+	if len(renames) == 0 {
+		return
+	}
+	for i, kv := range attrs {
+		if k, ok := renames[kv.Key]; ok {
+			attrs[i].Key = k
+		}
+	}
+}
+
+// reverse returns the inverse of renames, leaving out the keys renamed from
+// more than one key.
+func reverse(renames map[attribute.Key]attribute.Key) map[attribute.Key]attribute.Key {
+	r := make(map[attribute.Key]attribute.Key, len(renames))
+	ambiguous := make(map[attribute.Key]bool)
+	for from, to := range renames {
+		if _, ok := r[to]; ok {
+			ambiguous[to] = true
+		}
+		r[to] = from
+	}
+	for k := range ambiguous {
+		delete(r, k)
+	}
+	return r
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	v140 "go.opentelemetry.io/otel/semconv/v1.4.0"
+	v150 "go.opentelemetry.io/otel/semconv/v1.5.0"
+)
+
+func TestHistoryContainsVersionedPackages(t *testing.T) {
+	i, err := versionIndex(v140.SchemaURL)
+	assert.NoError(t, err)
+	j, err := versionIndex(v150.SchemaURL)
+	assert.NoError(t, err)
+	assert.Less(t, i, j, "history not sorted")
+}
+
+func TestTranslateVersionedPackages(t *testing.T) {
+	attrs := []attribute.KeyValue{
+		v140.HTTPMethodKey.String("GET"),
+		v140.RPCGRPCStatusCodeKey.Int(0),
+	}
+	want := []attribute.KeyValue{
+		v150.HTTPMethodKey.String("GET"),
+		v150.RPCGRPCStatusCodeKey.Int(0),
+	}
+
+	got, err := Translate(attrs, v140.SchemaURL, v150.SchemaURL)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	got, err = Translate(want, v150.SchemaURL, v140.SchemaURL)
+	require.NoError(t, err)
+	assert.Equal(t, attrs, got)
+}
+
+func TestTranslate(t *testing.T) {
+	orig := history
+	defer func() { history = orig }()
+	history = []version{
+		{schemaURL: "v1"},
+		{schemaURL: "v2", renames: map[attribute.Key]attribute.Key{
+			"a":   "b",
+			"db1": "db",
+			"db2": "db",
+		}},
+		{schemaURL: "v3", renames: map[attribute.Key]attribute.Key{
+			"b": "c",
+		}},
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("a", "1"),
+		attribute.String("db1", "2"),
+		attribute.String("other", "3"),
+	}
+
+	got, err := Translate(attrs, "v1", "v3")
+	require.NoError(t, err)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("c", "1"),
+		attribute.String("db", "2"),
+		attribute.String("other", "3"),
+	}, got)
+	assert.Equal(t, attribute.Key("a"), attrs[0].Key, "input modified")
+
+	got, err = Translate(got, "v3", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("a", "1"),
+		attribute.String("db", "2"),
+		attribute.String("other", "3"),
+	}, got)
+
+	got, err = Translate(attrs, "v2", "v2")
+	require.NoError(t, err)
+	assert.Equal(t, attrs, got)
+}
+
+func TestTranslateUnknownSchemaURL(t *testing.T) {
+	_, err := Translate(nil, v140.SchemaURL, "https://opentelemetry.io/schemas/0.0.0")
+	assert.Error(t, err)
+	_, err = Translate(nil, "https://opentelemetry.io/schemas/0.0.0", v140.SchemaURL)
+	assert.Error(t, err)
+}
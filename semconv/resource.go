@@ -14,25 +14,25 @@
 
 package semconv // import "go.opentelemetry.io/otel/semconv"
 
-import "go.opentelemetry.io/otel/attribute"
+import semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 
 // Semantic conventions for service resource attribute keys.
 const (
 	// Name of the service.
-	ServiceNameKey = attribute.Key("service.name")
+	ServiceNameKey = semconv.ServiceNameKey
 
 	// A namespace for `service.name`. This needs to have meaning that helps
 	// to distinguish a group of services. For example, the team name that
 	// owns a group of services. `service.name` is expected to be unique
 	// within the same namespace.
-	ServiceNamespaceKey = attribute.Key("service.namespace")
+	ServiceNamespaceKey = semconv.ServiceNamespaceKey
 
 	// A unique identifier of the service instance. In conjunction with the
 	// `service.name` and `service.namespace` this must be unique.
-	ServiceInstanceIDKey = attribute.Key("service.instance.id")
+	ServiceInstanceIDKey = semconv.ServiceInstanceIDKey
 
 	// The version of the service API.
-	ServiceVersionKey = attribute.Key("service.version")
+	ServiceVersionKey = semconv.ServiceVersionKey
 )
 
 // Semantic conventions for telemetry SDK resource attribute keys.
@@ -47,77 +47,77 @@ const (
 	//
 	// The value `opentelemetry` is reserved and MUST NOT be used by
 	// non-OpenTelemetry SDKs.
-	TelemetrySDKNameKey = attribute.Key("telemetry.sdk.name")
+	TelemetrySDKNameKey = semconv.TelemetrySDKNameKey
 
 	// The language of the telemetry SDK.
-	TelemetrySDKLanguageKey = attribute.Key("telemetry.sdk.language")
+	TelemetrySDKLanguageKey = semconv.TelemetrySDKLanguageKey
 
 	// The version string of the telemetry SDK.
-	TelemetrySDKVersionKey = attribute.Key("telemetry.sdk.version")
+	TelemetrySDKVersionKey = semconv.TelemetrySDKVersionKey
 )
 
 // Semantic conventions for telemetry SDK resource attributes.
 var (
-	TelemetrySDKLanguageGo = TelemetrySDKLanguageKey.String("go")
+	TelemetrySDKLanguageGo = semconv.TelemetrySDKLanguageGo
 )
 
 // Semantic conventions for container resource attribute keys.
 const (
 	// A uniquely identifying name for the Container.
-	ContainerNameKey = attribute.Key("container.name")
+	ContainerNameKey = semconv.ContainerNameKey
 
 	// Container ID, usually a UUID, as for example used to
 	// identify Docker containers. The UUID might be abbreviated.
-	ContainerIDKey = attribute.Key("container.id")
+	ContainerIDKey = semconv.ContainerIDKey
 
 	// Name of the image the container was built on.
-	ContainerImageNameKey = attribute.Key("container.image.name")
+	ContainerImageNameKey = semconv.ContainerImageNameKey
 
 	// Container image tag.
-	ContainerImageTagKey = attribute.Key("container.image.tag")
+	ContainerImageTagKey = semconv.ContainerImageTagKey
 )
 
 // Semantic conventions for Function-as-a-Service resource attribute keys.
 const (
 	// A uniquely identifying name for the FaaS.
-	FaaSNameKey = attribute.Key("faas.name")
+	FaaSNameKey = semconv.FaaSNameKey
 
 	// The unique name of the function being executed.
-	FaaSIDKey = attribute.Key("faas.id")
+	FaaSIDKey = semconv.FaaSIDKey
 
 	// The version of the function being executed.
-	FaaSVersionKey = attribute.Key("faas.version")
+	FaaSVersionKey = semconv.FaaSVersionKey
 
 	// The execution environment identifier.
-	FaaSInstanceKey = attribute.Key("faas.instance")
+	FaaSInstanceKey = semconv.FaaSInstanceKey
 )
 
 // Semantic conventions for operating system process resource attribute keys.
 const (
 	// Process identifier (PID).
-	ProcessPIDKey = attribute.Key("process.pid")
+	ProcessPIDKey = semconv.ProcessPIDKey
 	// The name of the process executable. On Linux based systems, can be
 	// set to the `Name` in `proc/[pid]/status`. On Windows, can be set to
 	// the base name of `GetProcessImageFileNameW`.
-	ProcessExecutableNameKey = attribute.Key("process.executable.name")
+	ProcessExecutableNameKey = semconv.ProcessExecutableNameKey
 	// The full path to the process executable. On Linux based systems, can
 	// be set to the target of `proc/[pid]/exe`. On Windows, can be set to
 	// the result of `GetProcessImageFileNameW`.
-	ProcessExecutablePathKey = attribute.Key("process.executable.path")
+	ProcessExecutablePathKey = semconv.ProcessExecutablePathKey
 	// The command used to launch the process (i.e. the command name). On
 	// Linux based systems, can be set to the zeroth string in
 	// `proc/[pid]/cmdline`. On Windows, can be set to the first parameter
 	// extracted from `GetCommandLineW`.
-	ProcessCommandKey = attribute.Key("process.command")
+	ProcessCommandKey = semconv.ProcessCommandKey
 	// The full command used to launch the process. The value can be either
 	// a list of strings representing the ordered list of arguments, or a
 	// single string representing the full command. On Linux based systems,
 	// can be set to the list of null-delimited strings extracted from
 	// `proc/[pid]/cmdline`. On Windows, can be set to the result of
 	// `GetCommandLineW`.
-	ProcessCommandLineKey = attribute.Key("process.command_line")
+	ProcessCommandLineKey = semconv.ProcessCommandLineKey
 	// The username of the user that owns the process.
-	ProcessOwnerKey = attribute.Key("process.owner")
+	ProcessOwnerKey = semconv.ProcessOwnerKey
 )
 
 // Semantic conventions for Kubernetes resource attribute keys.
@@ -126,102 +126,102 @@ const (
 	// does not have cluster names as an internal concept so this may be
 	// set to any meaningful value within the environment. For example,
 	// GKE clusters have a name which can be used for this attribute.
-	K8SClusterNameKey = attribute.Key("k8s.cluster.name")
+	K8SClusterNameKey = semconv.K8SClusterNameKey
 
 	// The name of the namespace that the pod is running in.
-	K8SNamespaceNameKey = attribute.Key("k8s.namespace.name")
+	K8SNamespaceNameKey = semconv.K8SNamespaceNameKey
 
 	// The uid of the Pod.
-	K8SPodUIDKey = attribute.Key("k8s.pod.uid")
+	K8SPodUIDKey = semconv.K8SPodUIDKey
 
 	// The name of the pod.
-	K8SPodNameKey = attribute.Key("k8s.pod.name")
+	K8SPodNameKey = semconv.K8SPodNameKey
 
 	// The name of the Container in a Pod template.
-	K8SContainerNameKey = attribute.Key("k8s.container.name")
+	K8SContainerNameKey = semconv.K8SContainerNameKey
 
 	// The uid of the ReplicaSet.
-	K8SReplicaSetUIDKey = attribute.Key("k8s.replicaset.uid")
+	K8SReplicaSetUIDKey = semconv.K8SReplicaSetUIDKey
 
 	// The name of the ReplicaSet.
-	K8SReplicaSetNameKey = attribute.Key("k8s.replicaset.name")
+	K8SReplicaSetNameKey = semconv.K8SReplicaSetNameKey
 
 	// The uid of the Deployment.
-	K8SDeploymentUIDKey = attribute.Key("k8s.deployment.uid")
+	K8SDeploymentUIDKey = semconv.K8SDeploymentUIDKey
 
 	// The name of the deployment.
-	K8SDeploymentNameKey = attribute.Key("k8s.deployment.name")
+	K8SDeploymentNameKey = semconv.K8SDeploymentNameKey
 
 	// The uid of the StatefulSet.
-	K8SStatefulSetUIDKey = attribute.Key("k8s.statefulset.uid")
+	K8SStatefulSetUIDKey = semconv.K8SStatefulSetUIDKey
 
 	// The name of the StatefulSet.
-	K8SStatefulSetNameKey = attribute.Key("k8s.statefulset.name")
+	K8SStatefulSetNameKey = semconv.K8SStatefulSetNameKey
 
 	// The uid of the DaemonSet.
-	K8SDaemonSetUIDKey = attribute.Key("k8s.daemonset.uid")
+	K8SDaemonSetUIDKey = semconv.K8SDaemonSetUIDKey
 
 	// The name of the DaemonSet.
-	K8SDaemonSetNameKey = attribute.Key("k8s.daemonset.name")
+	K8SDaemonSetNameKey = semconv.K8SDaemonSetNameKey
 
 	// The uid of the Job.
-	K8SJobUIDKey = attribute.Key("k8s.job.uid")
+	K8SJobUIDKey = semconv.K8SJobUIDKey
 
 	// The name of the Job.
-	K8SJobNameKey = attribute.Key("k8s.job.name")
+	K8SJobNameKey = semconv.K8SJobNameKey
 
 	// The uid of the CronJob.
-	K8SCronJobUIDKey = attribute.Key("k8s.cronjob.uid")
+	K8SCronJobUIDKey = semconv.K8SCronJobUIDKey
 
 	// The name of the CronJob.
-	K8SCronJobNameKey = attribute.Key("k8s.cronjob.name")
+	K8SCronJobNameKey = semconv.K8SCronJobNameKey
 )
 
 // Semantic conventions for host resource attribute keys.
 const (
 	// A uniquely identifying name for the host: 'hostname', FQDN, or user specified name
-	HostNameKey = attribute.Key("host.name")
+	HostNameKey = semconv.HostNameKey
 
 	// Unique host ID. For cloud environments this will be the instance ID.
-	HostIDKey = attribute.Key("host.id")
+	HostIDKey = semconv.HostIDKey
 
 	// Type of host. For cloud environments this will be the machine type.
-	HostTypeKey = attribute.Key("host.type")
+	HostTypeKey = semconv.HostTypeKey
 
 	// Name of the OS or VM image the host is running.
-	HostImageNameKey = attribute.Key("host.image.name")
+	HostImageNameKey = semconv.HostImageNameKey
 
 	// Identifier of the image the host is running.
-	HostImageIDKey = attribute.Key("host.image.id")
+	HostImageIDKey = semconv.HostImageIDKey
 
 	// Version of the image the host is running.
-	HostImageVersionKey = attribute.Key("host.image.version")
+	HostImageVersionKey = semconv.HostImageVersionKey
 )
 
 // Semantic conventions for cloud environment resource attribute keys.
 const (
 	// Name of the cloud provider.
-	CloudProviderKey = attribute.Key("cloud.provider")
+	CloudProviderKey = semconv.CloudProviderKey
 
 	// The account ID from the cloud provider used for authorization.
-	CloudAccountIDKey = attribute.Key("cloud.account.id")
+	CloudAccountIDKey = semconv.CloudAccountIDKey
 
 	// Geographical region where this resource is.
-	CloudRegionKey = attribute.Key("cloud.region")
+	CloudRegionKey = semconv.CloudRegionKey
 
 	// Zone of the region where this resource is.
-	CloudZoneKey = attribute.Key("cloud.zone")
+	CloudZoneKey = semconv.CloudZoneKey
 )
 
 // Semantic conventions for common cloud provider resource attributes.
 var (
-	CloudProviderAWS   = CloudProviderKey.String("aws")
-	CloudProviderAzure = CloudProviderKey.String("azure")
-	CloudProviderGCP   = CloudProviderKey.String("gcp")
+	CloudProviderAWS   = semconv.CloudProviderAWS
+	CloudProviderAzure = semconv.CloudProviderAzure
+	CloudProviderGCP   = semconv.CloudProviderGCP
 )
 
 // Semantic conventions for deployment attributes.
 const (
 	// Name of the deployment environment (aka deployment tier); e.g. (staging, production).
-	DeploymentEnvironmentKey = attribute.Key("deployment.environment")
+	DeploymentEnvironmentKey = semconv.DeploymentEnvironmentKey
 )
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semconv // import "go.opentelemetry.io/otel/semconv/v1.4.0"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Semantic conventions for attribute keys for gRPC.
+const (
+	// The numeric status code of the gRPC request.
+	RPCGRPCStatusCodeKey = attribute.Key("rpc.grpc.status_code")
+)
+
+// gRPC status codes as defined by
+// https://github.com/grpc/grpc/blob/master/doc/statuscodes.md. They are
+// duplicated here so this package does not depend on the gRPC module.
+const (
+	grpcCodeOK uint32 = iota
+	grpcCodeCanceled
+	grpcCodeUnknown
+	grpcCodeInvalidArgument
+	grpcCodeDeadlineExceeded
+	grpcCodeNotFound
+	grpcCodeAlreadyExists
+	grpcCodePermissionDenied
+	grpcCodeResourceExhausted
+	grpcCodeFailedPrecondition
+	grpcCodeAborted
+	grpcCodeOutOfRange
+	grpcCodeUnimplemented
+	grpcCodeInternal
+	grpcCodeUnavailable
+	grpcCodeDataLoss
+	grpcCodeUnauthenticated
+)
+
+// grpcServerErrorCodes are the gRPC status codes that signal an error on
+// the server side. All other codes are the result of a client error and do
+// not mark a server span as failed.
+var grpcServerErrorCodes = map[uint32]bool{
+	grpcCodeUnknown:          true,
+	grpcCodeDeadlineExceeded: true,
+	grpcCodeUnimplemented:    true,
+	grpcCodeInternal:         true,
+	grpcCodeUnavailable:      true,
+	grpcCodeDataLoss:         true,
+}
+
+// GRPCAttributesFromGRPCStatusCode generates attributes of the rpc.grpc
+// namespace as specified by the OpenTelemetry specification for a span.
+// The code parameter is the numeric value of a gRPC status code (for
+// example, an uint32 conversion of a google.golang.org/grpc/codes.Code).
+func GRPCAttributesFromGRPCStatusCode(code uint32) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		RPCGRPCStatusCodeKey.Int64(int64(code)),
+	}
+}
+
+// SpanStatusFromGRPCStatusCodeAndSpanKind generates a status code and a
+// message as specified by the OpenTelemetry specification for a span with
+// the given kind. The code parameter is the numeric value of a gRPC status
+// code. For CLIENT spans every code other than OK is an error, for SERVER
+// spans only the codes that indicate a server failure are.
+func SpanStatusFromGRPCStatusCodeAndSpanKind(code uint32, spanKind trace.SpanKind) (codes.Code, string) {
+	if code > grpcCodeUnauthenticated {
+		return codes.Error, fmt.Sprintf("Invalid gRPC status code %d", code)
+	}
+	if code == grpcCodeOK {
+		return codes.Unset, ""
+	}
+	if spanKind == trace.SpanKindServer && !grpcServerErrorCodes[code] {
+		return codes.Unset, ""
+	}
+	return codes.Error, ""
+}
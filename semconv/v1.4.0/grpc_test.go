@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestGRPCAttributesFromGRPCStatusCode(t *testing.T) {
+	expected := []attribute.KeyValue{
+		attribute.Int64("rpc.grpc.status_code", 14),
+	}
+	got := GRPCAttributesFromGRPCStatusCode(grpcCodeUnavailable)
+	assertElementsMatch(t, expected, got, "with gRPC status code 14")
+}
+
+func TestSpanStatusFromGRPCStatusCodeAndSpanKind(t *testing.T) {
+	for code := grpcCodeOK; code <= grpcCodeUnauthenticated; code++ {
+		got, msg := SpanStatusFromGRPCStatusCodeAndSpanKind(code, trace.SpanKindClient)
+		expected := codes.Error
+		if code == grpcCodeOK {
+			expected = codes.Unset
+		}
+		assert.Equalf(t, expected, got, "client span with gRPC status code %d", code)
+		assert.Empty(t, msg)
+
+		got, msg = SpanStatusFromGRPCStatusCodeAndSpanKind(code, trace.SpanKindServer)
+		expected = codes.Unset
+		switch code {
+		case grpcCodeUnknown,
+			grpcCodeDeadlineExceeded,
+			grpcCodeUnimplemented,
+			grpcCodeInternal,
+			grpcCodeUnavailable,
+			grpcCodeDataLoss:
+			expected = codes.Error
+		}
+		assert.Equalf(t, expected, got, "server span with gRPC status code %d", code)
+		assert.Empty(t, msg)
+	}
+
+	for _, kind := range []trace.SpanKind{trace.SpanKindClient, trace.SpanKindServer} {
+		got, msg := SpanStatusFromGRPCStatusCodeAndSpanKind(grpcCodeUnauthenticated+1, kind)
+		assert.Equal(t, codes.Error, got)
+		assert.NotEmpty(t, msg, "message should be set for an invalid gRPC status code")
+	}
+}
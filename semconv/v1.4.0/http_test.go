@@ -24,6 +24,7 @@ import (
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type tlsOption int
@@ -708,6 +709,25 @@ func TestSpanStatusFromHTTPStatusCode(t *testing.T) {
 	}
 }
 
+func TestSpanStatusFromHTTPStatusCodeAndSpanKind(t *testing.T) {
+	for code := 0; code < 1000; code++ {
+		expected := getExpectedCodeForHTTPCode(code)
+		got, msg := SpanStatusFromHTTPStatusCodeAndSpanKind(code, trace.SpanKindClient)
+		assert.Equalf(t, expected, got, "%s vs %s", expected, got)
+
+		_, valid := validateHTTPStatusCode(code)
+		if !valid {
+			assert.NotEmpty(t, msg, "message should be set if error cannot be inferred from code")
+		} else {
+			assert.Empty(t, msg, "message should not be set if error can be inferred from code")
+		}
+	}
+	code, _ := SpanStatusFromHTTPStatusCodeAndSpanKind(400, trace.SpanKindServer)
+	assert.Equal(t, codes.Unset, code)
+	code, _ = SpanStatusFromHTTPStatusCodeAndSpanKind(500, trace.SpanKindServer)
+	assert.Equal(t, codes.Error, code)
+}
+
 func getExpectedCodeForHTTPCode(code int) codes.Code {
 	if http.StatusText(code) == "" {
 		return codes.Error
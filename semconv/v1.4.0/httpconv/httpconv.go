@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httpconv provides OpenTelemetry semantic conventions for the
+// net/http package.
+//
+// The functions in this package derive the attributes, span names, and span
+// status of HTTP client and server spans directly from *http.Request and
+// *http.Response values, so instrumentation of net/http based libraries
+// reports them consistently.
+//
+// This package is currently in a pre-GA phase. Backwards incompatible changes
+// may be introduced in subsequent minor version releases as we work to track
+// the evolving OpenTelemetry specification and user feedback.
+package httpconv // import "go.opentelemetry.io/otel/semconv/v1.4.0/httpconv"
+
+import (
+	"net"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/semconv/v1.4.0/netconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ClientRequest returns the attributes of a client span for req. These
+// are the HTTP method, URL, scheme, host, flavor, user agent, and request
+// content length, along with the net peer name and port of the requested
+// URL. The end user is not included, it is only recorded on server spans.
+func ClientRequest(req *http.Request) []attribute.KeyValue {
+	attrs := semconv.HTTPClientAttributesFromHTTPRequest(req)
+	if req.URL != nil && req.URL.Scheme == "https" {
+		// The TLS state of an outgoing request is never set, the scheme
+		// is only known from the requested URL.
+		for i := range attrs {
+			if attrs[i].Key == semconv.HTTPSchemeKey {
+				attrs[i] = semconv.HTTPSchemeHTTPS
+			}
+		}
+	}
+	return append(attrs, clientPeer(req)...)
+}
+
+// ClientResponse returns the attributes of a client span for resp. These
+// are the HTTP status code and response content length.
+func ClientResponse(resp *http.Response) []attribute.KeyValue {
+	return response(resp.StatusCode, resp.ContentLength)
+}
+
+// ClientSpanName returns the name of a client span for req, which is "HTTP"
+// followed by the request method.
+func ClientSpanName(req *http.Request) string {
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return "HTTP " + method
+}
+
+// ClientStatus returns the span status code and message of a client span
+// for the HTTP status code. All 4xx and 5xx codes are errors.
+func ClientStatus(code int) (codes.Code, string) {
+	return semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(code, trace.SpanKindClient)
+}
+
+// ServerRequest returns the attributes of a server span for req handled
+// by the server named serverName and matched by route, each omitted if
+// empty. These are the HTTP method, target, scheme, host, flavor, user
+// agent, request content length, and client IP, along with the net
+// attributes of the TCP connection and the end user of basic
+// authentication.
+func ServerRequest(serverName, route string, req *http.Request) []attribute.KeyValue {
+	attrs := semconv.HTTPServerAttributesFromHTTPRequest(serverName, route, req)
+	attrs = append(attrs, semconv.NetAttributesFromHTTPRequest("tcp", req)...)
+	return append(attrs, semconv.EndUserAttributesFromHTTPRequest(req)...)
+}
+
+// ServerResponse returns the attributes of a server span for a response
+// written with the HTTP status code and a body of contentLength bytes. A
+// non-positive contentLength is omitted.
+func ServerResponse(code int, contentLength int64) []attribute.KeyValue {
+	return response(code, contentLength)
+}
+
+// ServerSpanName returns the name of a server span for req matched by
+// route. If route is empty, the name is "HTTP" followed by the request
+// method, if any.
+func ServerSpanName(route string, req *http.Request) string {
+	if route != "" {
+		return route
+	}
+	if req.Method == "" {
+		return "HTTP"
+	}
+	return "HTTP " + req.Method
+}
+
+// ServerStatus returns the span status code and message of a server span
+// for the HTTP status code. Only 5xx codes are errors, a 4xx code leaves
+// the status unset.
+func ServerStatus(code int) (codes.Code, string) {
+	return semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(code, trace.SpanKindServer)
+}
+
+func response(code int, contentLength int64) []attribute.KeyValue {
+	attrs := semconv.HTTPAttributesFromHTTPStatusCode(code)
+	if contentLength > 0 {
+		attrs = append(attrs, semconv.HTTPResponseContentLengthKey.Int64(contentLength))
+	}
+	return attrs
+}
+
+// clientPeer returns the net peer attributes of the host requested by req.
+// The port is derived from the scheme if the URL does not contain one.
+func clientPeer(req *http.Request) []attribute.KeyValue {
+	if req.URL == nil || req.URL.Host == "" {
+		return nil
+	}
+
+	hostport := req.URL.Host
+	if req.URL.Port() == "" {
+		switch req.URL.Scheme {
+		case "http":
+			hostport = net.JoinHostPort(req.URL.Hostname(), "80")
+		case "https":
+			hostport = net.JoinHostPort(req.URL.Hostname(), "443")
+		}
+	}
+	return netconv.Peer(hostport)
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpconv
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+func TestClientRequest(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://example.com/path?q=1", strings.NewReader("body"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("User-Agent", "test-agent")
+	req.SetBasicAuth("user", "pass")
+
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		semconv.HTTPMethodKey.String("POST"),
+		semconv.HTTPURLKey.String("https://example.com/path?q=1"),
+		semconv.HTTPUserAgentKey.String("test-agent"),
+		semconv.HTTPRequestContentLengthKey.Int64(4),
+		semconv.HTTPSchemeHTTPS,
+		semconv.HTTPHostKey.String("example.com"),
+		semconv.HTTPFlavorKey.String("1.1"),
+		semconv.NetPeerNameKey.String("example.com"),
+		semconv.NetPeerPortKey.Int(443),
+	}, ClientRequest(req))
+}
+
+func TestClientRequestPeerIP(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://127.0.0.1:8080/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attrs := ClientRequest(req)
+	assert.Contains(t, attrs, semconv.HTTPSchemeHTTP)
+	assert.Contains(t, attrs, semconv.NetPeerIPKey.String("127.0.0.1"))
+	assert.Contains(t, attrs, semconv.NetPeerPortKey.Int(8080))
+}
+
+func TestClientResponse(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.HTTPStatusCodeKey.Int(200),
+		semconv.HTTPResponseContentLengthKey.Int64(10),
+	}, ClientResponse(&http.Response{StatusCode: 200, ContentLength: 10}))
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.HTTPStatusCodeKey.Int(404),
+	}, ClientResponse(&http.Response{StatusCode: 404, ContentLength: -1}))
+}
+
+func TestServerRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/users/42?x=y", nil)
+	req.RemoteAddr = "10.0.0.1:56789"
+	req.TLS = &tls.ConnectionState{}
+	req.Header.Set("X-Forwarded-For", "1.2.3.4")
+
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		semconv.HTTPMethodKey.String("GET"),
+		semconv.HTTPTargetKey.String("/users/42?x=y"),
+		semconv.HTTPServerNameKey.String("srv"),
+		semconv.HTTPRouteKey.String("/users/:id"),
+		semconv.HTTPClientIPKey.String("1.2.3.4"),
+		semconv.HTTPSchemeHTTPS,
+		semconv.HTTPHostKey.String("example.com"),
+		semconv.HTTPFlavorKey.String("1.1"),
+		semconv.NetTransportTCP,
+		semconv.NetPeerIPKey.String("10.0.0.1"),
+		semconv.NetPeerPortKey.Int(56789),
+		semconv.NetHostNameKey.String("example.com"),
+	}, ServerRequest("srv", "/users/:id", req))
+}
+
+func TestServerResponse(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.HTTPStatusCodeKey.Int(201),
+		semconv.HTTPResponseContentLengthKey.Int64(3),
+	}, ServerResponse(201, 3))
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.HTTPStatusCodeKey.Int(204),
+	}, ServerResponse(204, 0))
+}
+
+func TestSpanNames(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "/users/42", nil)
+	assert.Equal(t, "HTTP PUT", ClientSpanName(req))
+	assert.Equal(t, "HTTP GET", ClientSpanName(&http.Request{}))
+	assert.Equal(t, "HTTP PUT", ServerSpanName("", req))
+	assert.Equal(t, "HTTP", ServerSpanName("", &http.Request{}))
+	assert.Equal(t, "/users/:id", ServerSpanName("/users/:id", req))
+}
+
+func TestStatus(t *testing.T) {
+	for _, tc := range []struct {
+		code   int
+		client codes.Code
+		server codes.Code
+	}{
+		{200, codes.Unset, codes.Unset},
+		{302, codes.Unset, codes.Unset},
+		{404, codes.Error, codes.Unset},
+		{503, codes.Error, codes.Error},
+		{600, codes.Error, codes.Error},
+	} {
+		c, _ := ClientStatus(tc.code)
+		assert.Equal(t, tc.client, c, "client status for %d", tc.code)
+		s, _ := ServerStatus(tc.code)
+		assert.Equal(t, tc.server, s, "server status for %d", tc.code)
+	}
+}
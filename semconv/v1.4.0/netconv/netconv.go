@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package netconv provides OpenTelemetry semantic conventions for the net
+// package.
+//
+// The functions in this package convert net.Addr and net.Conn endpoints
+// into the net.transport, net.peer.*, and net.host.* attributes, so
+// instrumentation of HTTP, gRPC, database, and other network clients and
+// servers reports them consistently.
+//
+// This package is currently in a pre-GA phase. Backwards incompatible changes
+// may be introduced in subsequent minor version releases as we work to track
+// the evolving OpenTelemetry specification and user feedback.
+package netconv // import "go.opentelemetry.io/otel/semconv/v1.4.0/netconv"
+
+import (
+	"net"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+// Transport returns the net.transport attribute for network, a network
+// name understood by net.Dial such as "tcp6" or "unixgram".
+func Transport(network string) attribute.KeyValue {
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+		return semconv.NetTransportTCP
+	case "udp", "udp4", "udp6":
+		return semconv.NetTransportUDP
+	case "ip", "ip4", "ip6":
+		return semconv.NetTransportIP
+	case "unix", "unixgram", "unixpacket":
+		return semconv.NetTransportUnix
+	default:
+		return semconv.NetTransportOther
+	}
+}
+
+// Conn returns the net.transport, net.peer.*, and net.host.* attributes of
+// conn, derived from its remote and local addresses.
+func Conn(conn net.Conn) []attribute.KeyValue {
+	remote, local := conn.RemoteAddr(), conn.LocalAddr()
+	var attrs []attribute.KeyValue
+	if remote != nil {
+		attrs = append(attrs, Transport(remote.Network()))
+	} else if local != nil {
+		attrs = append(attrs, Transport(local.Network()))
+	}
+	attrs = append(attrs, PeerAddr(remote)...)
+	return append(attrs, HostAddr(local)...)
+}
+
+// PeerAddr returns the net.peer.ip or net.peer.name, and net.peer.port
+// attributes of addr. The path of a Unix socket is returned as the
+// net.peer.name. No attributes are returned for a nil addr.
+func PeerAddr(addr net.Addr) []attribute.KeyValue {
+	return endpoint(addr, semconv.NetPeerIPKey, semconv.NetPeerNameKey, semconv.NetPeerPortKey)
+}
+
+// HostAddr returns the net.host.ip or net.host.name, and net.host.port
+// attributes of addr. The path of a Unix socket is returned as the
+// net.host.name. No attributes are returned for a nil addr.
+func HostAddr(addr net.Addr) []attribute.KeyValue {
+	return endpoint(addr, semconv.NetHostIPKey, semconv.NetHostNameKey, semconv.NetHostPortKey)
+}
+
+// Peer returns the net.peer.ip or net.peer.name, and net.peer.port
+// attributes of hostport, an address of the form "host", "host:port", or
+// "[host]:port" as accepted by net.Dial.
+func Peer(hostport string) []attribute.KeyValue {
+	host, port := splitHostPort(hostport)
+	return hostAttrs(host, port, semconv.NetPeerIPKey, semconv.NetPeerNameKey, semconv.NetPeerPortKey)
+}
+
+// Host returns the net.host.ip or net.host.name, and net.host.port
+// attributes of hostport, an address of the form "host", "host:port", or
+// "[host]:port" as accepted by net.Listen.
+func Host(hostport string) []attribute.KeyValue {
+	host, port := splitHostPort(hostport)
+	return hostAttrs(host, port, semconv.NetHostIPKey, semconv.NetHostNameKey, semconv.NetHostPortKey)
+}
+
+func endpoint(addr net.Addr, ipKey, nameKey, portKey attribute.Key) []attribute.KeyValue {
+	switch a := addr.(type) {
+	case nil:
+		return nil
+	case *net.TCPAddr:
+		if a == nil {
+			return nil
+		}
+		return ipAttrs(a.IP, a.Zone, a.Port, ipKey, portKey)
+	case *net.UDPAddr:
+		if a == nil {
+			return nil
+		}
+		return ipAttrs(a.IP, a.Zone, a.Port, ipKey, portKey)
+	case *net.IPAddr:
+		if a == nil {
+			return nil
+		}
+		return ipAttrs(a.IP, a.Zone, 0, ipKey, portKey)
+	case *net.UnixAddr:
+		if a == nil || a.Name == "" {
+			return nil
+		}
+		return []attribute.KeyValue{nameKey.String(a.Name)}
+	}
+	host, port := splitHostPort(addr.String())
+	return hostAttrs(host, port, ipKey, nameKey, portKey)
+}
+
+func ipAttrs(ip net.IP, zone string, port int, ipKey, portKey attribute.Key) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 2)
+	if ip != nil {
+		s := ip.String()
+		if zone != "" {
+			s += "%" + zone
+		}
+		attrs = append(attrs, ipKey.String(s))
+	}
+	if port > 0 {
+		attrs = append(attrs, portKey.Int(port))
+	}
+	return attrs
+}
+
+func hostAttrs(host string, port int, ipKey, nameKey, portKey attribute.Key) []attribute.KeyValue {
+	if ip, zone := parseIP(host); ip != nil {
+		return ipAttrs(ip, zone, port, ipKey, portKey)
+	}
+	attrs := make([]attribute.KeyValue, 0, 2)
+	if host != "" {
+		attrs = append(attrs, nameKey.String(host))
+	}
+	if port > 0 {
+		attrs = append(attrs, portKey.Int(port))
+	}
+	return attrs
+}
+
+// splitHostPort splits hostport into its host and port. The port is 0 if
+// hostport does not contain a valid one, and the brackets around an IPv6
+// host are removed.
+func splitHostPort(hostport string) (string, int) {
+	host, portStr, err := net.SplitHostPort(hostport)
+	if err != nil {
+		// No port, possibly a bracketed IPv6 address.
+		if n := len(hostport); n > 1 && hostport[0] == '[' && hostport[n-1] == ']' {
+			return hostport[1 : n-1], 0
+		}
+		return hostport, 0
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return host, 0
+	}
+	return host, int(port)
+}
+
+// parseIP parses host as an IP address with an optional IPv6 zone.
+func parseIP(host string) (net.IP, string) {
+	zone := ""
+	for i := len(host) - 1; i >= 0; i-- {
+		if host[i] == '%' {
+			host, zone = host[:i], host[i+1:]
+			break
+		}
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, ""
+	}
+	return ip, zone
+}
@@ -0,0 +1,195 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package netconv
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+)
+
+func TestTransport(t *testing.T) {
+	for network, want := range map[string]attribute.KeyValue{
+		"tcp":        semconv.NetTransportTCP,
+		"tcp6":       semconv.NetTransportTCP,
+		"udp4":       semconv.NetTransportUDP,
+		"ip":         semconv.NetTransportIP,
+		"unixgram":   semconv.NetTransportUnix,
+		"unixpacket": semconv.NetTransportUnix,
+		"pipe":       semconv.NetTransportOther,
+	} {
+		assert.Equal(t, want, Transport(network), network)
+	}
+}
+
+func TestPeerAddr(t *testing.T) {
+	testcases := []struct {
+		name string
+		addr net.Addr
+		want []attribute.KeyValue
+	}{
+		{
+			name: "nil",
+			addr: nil,
+		},
+		{
+			name: "nil TCP",
+			addr: (*net.TCPAddr)(nil),
+		},
+		{
+			name: "TCP IPv4",
+			addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 8080},
+			want: []attribute.KeyValue{
+				semconv.NetPeerIPKey.String("10.0.0.1"),
+				semconv.NetPeerPortKey.Int(8080),
+			},
+		},
+		{
+			name: "UDP IPv6 with zone",
+			addr: &net.UDPAddr{IP: net.ParseIP("fe80::1"), Zone: "eth0", Port: 53},
+			want: []attribute.KeyValue{
+				semconv.NetPeerIPKey.String("fe80::1%eth0"),
+				semconv.NetPeerPortKey.Int(53),
+			},
+		},
+		{
+			name: "IP",
+			addr: &net.IPAddr{IP: net.IPv6loopback},
+			want: []attribute.KeyValue{
+				semconv.NetPeerIPKey.String("::1"),
+			},
+		},
+		{
+			name: "Unix",
+			addr: &net.UnixAddr{Name: "/var/run/app.sock", Net: "unix"},
+			want: []attribute.KeyValue{
+				semconv.NetPeerNameKey.String("/var/run/app.sock"),
+			},
+		},
+		{
+			name: "unnamed Unix",
+			addr: &net.UnixAddr{Net: "unix"},
+		},
+		{
+			name: "other",
+			addr: fakeAddr("example.com:443"),
+			want: []attribute.KeyValue{
+				semconv.NetPeerNameKey.String("example.com"),
+				semconv.NetPeerPortKey.Int(443),
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, PeerAddr(tc.addr))
+		})
+	}
+}
+
+func TestHostAddr(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.NetHostIPKey.String("127.0.0.1"),
+		semconv.NetHostPortKey.Int(80),
+	}, HostAddr(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 80}))
+}
+
+func TestPeer(t *testing.T) {
+	testcases := []struct {
+		hostport string
+		want     []attribute.KeyValue
+	}{
+		{
+			hostport: "example.com",
+			want:     []attribute.KeyValue{semconv.NetPeerNameKey.String("example.com")},
+		},
+		{
+			hostport: "example.com:8080",
+			want: []attribute.KeyValue{
+				semconv.NetPeerNameKey.String("example.com"),
+				semconv.NetPeerPortKey.Int(8080),
+			},
+		},
+		{
+			hostport: "example.com:http",
+			want:     []attribute.KeyValue{semconv.NetPeerNameKey.String("example.com")},
+		},
+		{
+			hostport: "[::1]:9090",
+			want: []attribute.KeyValue{
+				semconv.NetPeerIPKey.String("::1"),
+				semconv.NetPeerPortKey.Int(9090),
+			},
+		},
+		{
+			hostport: "[fe80::1%eth0]",
+			want:     []attribute.KeyValue{semconv.NetPeerIPKey.String("fe80::1%eth0")},
+		},
+		{
+			hostport: "::1",
+			want:     []attribute.KeyValue{semconv.NetPeerIPKey.String("::1")},
+		},
+		{
+			hostport: "",
+			want:     []attribute.KeyValue{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.hostport, func(t *testing.T) {
+			assert.Equal(t, tc.want, Peer(tc.hostport))
+		})
+	}
+}
+
+func TestHost(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.NetHostNameKey.String("localhost"),
+		semconv.NetHostPortKey.Int(4317),
+	}, Host("localhost:4317"))
+}
+
+func TestConn(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	local := conn.LocalAddr().(*net.TCPAddr)
+	remote := conn.RemoteAddr().(*net.TCPAddr)
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.NetTransportTCP,
+		semconv.NetPeerIPKey.String("127.0.0.1"),
+		semconv.NetPeerPortKey.Int(remote.Port),
+		semconv.NetHostIPKey.String("127.0.0.1"),
+		semconv.NetHostPortKey.Int(local.Port),
+	}, Conn(conn))
+}
+
+type fakeAddr string
+
+func (a fakeAddr) Network() string { return "fake" }
+func (a fakeAddr) String() string  { return string(a) }
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semconv // import "go.opentelemetry.io/otel/semconv/v1.4.0"
+
+import "go.opentelemetry.io/otel/attribute"
+
+// K8S holds the values of the Kubernetes resource attributes.
+type K8S struct {
+	ClusterName    string
+	NodeName       string
+	NodeUID        string
+	NamespaceName  string
+	PodName        string
+	PodUID         string
+	ContainerName  string
+	DeploymentName string
+	DeploymentUID  string
+}
+
+// Attributes returns the k8s.* attributes of the non-empty values of k.
+func (k K8S) Attributes() []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	for _, a := range []struct {
+		key   attribute.Key
+		value string
+	}{
+		{K8SClusterNameKey, k.ClusterName},
+		{K8SNodeNameKey, k.NodeName},
+		{K8SNodeUIDKey, k.NodeUID},
+		{K8SNamespaceNameKey, k.NamespaceName},
+		{K8SPodNameKey, k.PodName},
+		{K8SPodUIDKey, k.PodUID},
+		{K8SContainerNameKey, k.ContainerName},
+		{K8SDeploymentNameKey, k.DeploymentName},
+		{K8SDeploymentUIDKey, k.DeploymentUID},
+	} {
+		if a.value != "" {
+			attrs = append(attrs, a.key.String(a.value))
+		}
+	}
+	return attrs
+}
+
+// FaaS holds the values of the Function-as-a-Service resource attributes.
+type FaaS struct {
+	Name     string
+	ID       string
+	Version  string
+	Instance string
+	// MaxMemory is the amount of memory available to the function in
+	// MiB.
+	MaxMemory int
+}
+
+// Attributes returns the faas.* attributes of the non-empty values of f.
+// A non-positive MaxMemory is omitted.
+func (f FaaS) Attributes() []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	for _, a := range []struct {
+		key   attribute.Key
+		value string
+	}{
+		{FaaSNameKey, f.Name},
+		{FaaSIDKey, f.ID},
+		{FaaSVersionKey, f.Version},
+		{FaaSInstanceKey, f.Instance},
+	} {
+		if a.value != "" {
+			attrs = append(attrs, a.key.String(a.value))
+		}
+	}
+	if f.MaxMemory > 0 {
+		attrs = append(attrs, FaaSMaxMemoryKey.Int(f.MaxMemory))
+	}
+	return attrs
+}
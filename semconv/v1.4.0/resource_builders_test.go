@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package semconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func TestK8SAttributes(t *testing.T) {
+	assert.Nil(t, K8S{}.Attributes())
+	assert.Equal(t, []attribute.KeyValue{
+		K8SNodeNameKey.String("node-1"),
+		K8SNamespaceNameKey.String("default"),
+		K8SPodNameKey.String("app-5d8f7"),
+		K8SDeploymentNameKey.String("app"),
+	}, K8S{
+		NodeName:       "node-1",
+		NamespaceName:  "default",
+		PodName:        "app-5d8f7",
+		DeploymentName: "app",
+	}.Attributes())
+}
+
+func TestFaaSAttributes(t *testing.T) {
+	assert.Nil(t, FaaS{}.Attributes())
+	assert.Equal(t, []attribute.KeyValue{
+		FaaSNameKey.String("fn"),
+		FaaSVersionKey.String("$LATEST"),
+		FaaSMaxMemoryKey.Int(128),
+	}, FaaS{Name: "fn", Version: "$LATEST", MaxMemory: 128}.Attributes())
+}
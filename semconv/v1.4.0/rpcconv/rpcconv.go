@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rpcconv provides OpenTelemetry semantic conventions for RPC
+// systems, and gRPC in particular.
+//
+// The functions in this package build the rpc.* attributes, the gRPC status
+// attributes and span status, and the message span events of RPC client
+// and server spans, so RPC interceptors report them consistently.
+//
+// This package is currently in a pre-GA phase. Backwards incompatible changes
+// may be introduced in subsequent minor version releases as we work to track
+// the evolving OpenTelemetry specification and user feedback.
+package rpcconv // import "go.opentelemetry.io/otel/semconv/v1.4.0/rpcconv"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MessageEventName is the name of the span events recording the messages
+// sent and received during an RPC.
+const MessageEventName = "message"
+
+// RPC returns the rpc.system, rpc.service, and rpc.method attributes of a
+// call to method of service using the remoting system. Empty values are
+// omitted.
+func RPC(system, service, method string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, 3)
+	if system != "" {
+		attrs = append(attrs, semconv.RPCSystemKey.String(system))
+	}
+	if service != "" {
+		attrs = append(attrs, semconv.RPCServiceKey.String(service))
+	}
+	if method != "" {
+		attrs = append(attrs, semconv.RPCMethodKey.String(method))
+	}
+	return attrs
+}
+
+// ParseGRPCFullMethod splits a gRPC full method name of the form
+// "/package.Service/Method" into its service and method. If fullMethod is
+// not of that form, it is returned as the method with an empty service.
+func ParseGRPCFullMethod(fullMethod string) (service, method string) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	i := strings.LastIndex(name, "/")
+	if i < 0 {
+		return "", name
+	}
+	return name[:i], name[i+1:]
+}
+
+// GRPC returns the rpc.system, rpc.service, and rpc.method attributes of
+// a gRPC call to fullMethod, of the form "/package.Service/Method", as
+// passed to gRPC interceptors.
+func GRPC(fullMethod string) []attribute.KeyValue {
+	service, method := ParseGRPCFullMethod(fullMethod)
+	attrs := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	return append(attrs, RPC("", service, method)...)
+}
+
+// GRPCStatus returns the rpc.grpc.status_code attribute for the numeric
+// value of a gRPC status code.
+func GRPCStatus(code uint32) []attribute.KeyValue {
+	return semconv.GRPCAttributesFromGRPCStatusCode(code)
+}
+
+// GRPCClientStatus returns the span status code and message of a gRPC
+// client span for the numeric value of a gRPC status code. Every code other
+// than OK is an error.
+func GRPCClientStatus(code uint32) (codes.Code, string) {
+	return semconv.SpanStatusFromGRPCStatusCodeAndSpanKind(code, trace.SpanKindClient)
+}
+
+// GRPCServerStatus returns the span status code and message of a gRPC
+// server span for the numeric value of a gRPC status code. Only the codes
+// that indicate a server failure are errors.
+func GRPCServerStatus(code uint32) (codes.Code, string) {
+	return semconv.SpanStatusFromGRPCStatusCodeAndSpanKind(code, trace.SpanKindServer)
+}
+
+// SentMessage returns the attributes of the message event for the id-th
+// message sent during an RPC, starting at 1, with an uncompressed size of
+// uncompressedSize bytes. A negative uncompressedSize is omitted.
+func SentMessage(id int, uncompressedSize int) []attribute.KeyValue {
+	return message(semconv.RPCMessageTypeSent, id, uncompressedSize)
+}
+
+// ReceivedMessage returns the attributes of the message event for the
+// id-th message received during an RPC, starting at 1, with an uncompressed
+// size of uncompressedSize bytes. A negative uncompressedSize is omitted.
+func ReceivedMessage(id int, uncompressedSize int) []attribute.KeyValue {
+	return message(semconv.RPCMessageTypeReceived, id, uncompressedSize)
+}
+
+// AddSentMessageEvent adds the message event of a sent message to span.
+// See SentMessage for the meaning of the parameters.
+func AddSentMessageEvent(span trace.Span, id int, uncompressedSize int) {
+	span.AddEvent(MessageEventName, trace.WithAttributes(SentMessage(id, uncompressedSize)...))
+}
+
+// AddReceivedMessageEvent adds the message event of a received message to
+// span. See ReceivedMessage for the meaning of the parameters.
+func AddReceivedMessageEvent(span trace.Span, id int, uncompressedSize int) {
+	span.AddEvent(MessageEventName, trace.WithAttributes(ReceivedMessage(id, uncompressedSize)...))
+}
+
+func message(messageType attribute.KeyValue, id int, uncompressedSize int) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		messageType,
+		semconv.RPCMessageIDKey.Int(id),
+	}
+	if uncompressedSize >= 0 {
+		attrs = append(attrs, semconv.RPCMessageUncompressedSizeKey.Int(uncompressedSize))
+	}
+	return attrs
+}
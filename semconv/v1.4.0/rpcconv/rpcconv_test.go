@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpcconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRPC(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.RPCSystemKey.String("java_rmi"),
+		semconv.RPCServiceKey.String("myservice.EchoService"),
+		semconv.RPCMethodKey.String("exampleMethod"),
+	}, RPC("java_rmi", "myservice.EchoService", "exampleMethod"))
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.RPCMethodKey.String("exampleMethod"),
+	}, RPC("", "", "exampleMethod"))
+}
+
+func TestParseGRPCFullMethod(t *testing.T) {
+	for _, tc := range []struct {
+		fullMethod, service, method string
+	}{
+		{"/grpc.health.v1.Health/Check", "grpc.health.v1.Health", "Check"},
+		{"grpc.health.v1.Health/Check", "grpc.health.v1.Health", "Check"},
+		{"/Check", "", "Check"},
+		{"", "", ""},
+	} {
+		service, method := ParseGRPCFullMethod(tc.fullMethod)
+		assert.Equal(t, tc.service, service, tc.fullMethod)
+		assert.Equal(t, tc.method, method, tc.fullMethod)
+	}
+}
+
+func TestGRPC(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.RPCSystemGRPC,
+		semconv.RPCServiceKey.String("grpc.health.v1.Health"),
+		semconv.RPCMethodKey.String("Check"),
+	}, GRPC("/grpc.health.v1.Health/Check"))
+}
+
+func TestGRPCStatus(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.RPCGRPCStatusCodeKey.Int64(5),
+	}, GRPCStatus(5))
+
+	// NotFound is a client error.
+	c, _ := GRPCClientStatus(5)
+	assert.Equal(t, codes.Error, c)
+	c, _ = GRPCServerStatus(5)
+	assert.Equal(t, codes.Unset, c)
+
+	// Internal is a server error.
+	c, _ = GRPCServerStatus(13)
+	assert.Equal(t, codes.Error, c)
+}
+
+func TestMessages(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.RPCMessageTypeSent,
+		semconv.RPCMessageIDKey.Int(1),
+		semconv.RPCMessageUncompressedSizeKey.Int(42),
+	}, SentMessage(1, 42))
+	assert.Equal(t, []attribute.KeyValue{
+		semconv.RPCMessageTypeReceived,
+		semconv.RPCMessageIDKey.Int(2),
+	}, ReceivedMessage(2, -1))
+}
+
+type event struct {
+	name  string
+	attrs []attribute.KeyValue
+}
+
+type eventSpan struct {
+	trace.Span
+	events []event
+}
+
+func (s *eventSpan) AddEvent(name string, opts ...trace.EventOption) {
+	c := trace.NewEventConfig(opts...)
+	s.events = append(s.events, event{name: name, attrs: c.Attributes})
+}
+
+func TestAddMessageEvents(t *testing.T) {
+	span := &eventSpan{}
+	AddSentMessageEvent(span, 1, 10)
+	AddReceivedMessageEvent(span, 1, 20)
+
+	assert.Equal(t, []event{
+		{name: MessageEventName, attrs: SentMessage(1, 10)},
+		{name: MessageEventName, attrs: ReceivedMessage(1, 20)},
+	}, span.events)
+}
@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package semconv implements OpenTelemetry semantic conventions version
+// 1.5.0.
+//
+// This package is currently in a pre-GA phase. Backwards incompatible changes
+// may be introduced in subsequent minor version releases as we work to track
+// the evolving OpenTelemetry specification and user feedback.
+//
+// Only the attribute keys and values are defined here. The helpers building
+// attributes from HTTP requests, gRPC codes and resources remain in
+// go.opentelemetry.io/otel/semconv/v1.4.0, their attributes can be
+// translated to this version with the Translate function of
+// go.opentelemetry.io/otel/semconv/migrate.
+package semconv // import "go.opentelemetry.io/otel/semconv/v1.5.0"
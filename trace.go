@@ -42,3 +42,18 @@ func GetTracerProvider() trace.TracerProvider {
 func SetTracerProvider(tp trace.TracerProvider) {
 	global.SetTracerProvider(tp)
 }
+
+// SwapTracerProvider registers `tp` as the global trace provider and
+// returns the previously registered one. Unlike SetTracerProvider, the
+// previous provider is not set to delegate to `tp`, which makes it
+// suitable to install, and later restore, test fixtures:
+//     prev := otel.SwapTracerProvider(tp)
+//     defer otel.SwapTracerProvider(prev)
+// Passing nil installs a new default delegating provider that the next
+// call to SetTracerProvider delegates to.
+//
+// SwapTracerProvider must not be called concurrently with
+// SetTracerProvider.
+func SwapTracerProvider(tp trace.TracerProvider) trace.TracerProvider {
+	return global.SwapTracerProvider(tp)
+}
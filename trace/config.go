@@ -25,6 +25,11 @@ type TracerConfig struct {
 	// InstrumentationVersion is the version of the library providing
 	// instrumentation.
 	InstrumentationVersion string
+	// SchemaURL is the schema URL of the telemetry emitted by the Tracer.
+	SchemaURL string
+	// SpanStartOptions are the default options of all spans started by
+	// the Tracer. They are applied before the options passed to Start.
+	SpanStartOptions []SpanOption
 }
 
 // NewTracerConfig applies all the options to a returned TracerConfig.
@@ -60,6 +65,9 @@ type SpanConfig struct {
 	NewRoot bool
 	// SpanKind is the role a Span has in a trace.
 	SpanKind SpanKind
+	// StackTrace identifies that a stack trace of the calling goroutine
+	// should be recorded with an event.
+	StackTrace bool
 }
 
 // NewSpanConfig applies all the options to a returned SpanConfig.
@@ -139,6 +147,36 @@ func WithAttributes(attributes ...attribute.KeyValue) LifeCycleOption {
 	return attributeSpanOption(attributes)
 }
 
+type attributeSetSpanOption struct{ set *attribute.Set }
+
+func (o attributeSetSpanOption) ApplySpan(c *SpanConfig)  { o.apply(c) }
+func (o attributeSetSpanOption) ApplyEvent(c *SpanConfig) { o.apply(c) }
+func (attributeSetSpanOption) private()                   {}
+func (o attributeSetSpanOption) apply(c *SpanConfig) {
+	if o.set == nil || o.set.Len() == 0 {
+		return
+	}
+	if len(c.Attributes) == 0 {
+		// The attributes of a Set are unique and sorted, take them as-is.
+		c.Attributes = o.set.ToSlice()
+		return
+	}
+	for iter := o.set.Iter(); iter.Next(); {
+		c.Attributes = append(c.Attributes, iter.Attribute())
+	}
+}
+
+// WithAttributeSet adds the attributes contained in set to a span life-cycle
+// event. It is equivalent to WithAttributes(set.ToSlice()...), but allows
+// callers that already maintain a canonical attribute.Set to pass it
+// directly.
+//
+// Like WithAttributes, the attributes extend those of any other attribute
+// options passed.
+func WithAttributeSet(set *attribute.Set) LifeCycleOption {
+	return attributeSetSpanOption{set: set}
+}
+
 type timestampSpanOption time.Time
 
 func (o timestampSpanOption) ApplySpan(c *SpanConfig)  { o.apply(c) }
@@ -152,6 +190,18 @@ func WithTimestamp(t time.Time) LifeCycleOption {
 	return timestampSpanOption(t)
 }
 
+type stackTraceOption bool
+
+func (o stackTraceOption) ApplyEvent(c *SpanConfig) { c.StackTrace = bool(o) }
+func (stackTraceOption) private()                   {}
+
+// WithStackTrace sets whether a stack trace of the calling goroutine is
+// recorded as an attribute of an event, either added with AddEvent or
+// recorded for an error with RecordError.
+func WithStackTrace(b bool) EventOption {
+	return stackTraceOption(b)
+}
+
 type linksSpanOption []Link
 
 func (o linksSpanOption) ApplySpan(c *SpanConfig) { c.Links = append(c.Links, []Link(o)...) }
@@ -203,3 +253,36 @@ func (i instrumentationVersionOption) ApplyTracer(config *TracerConfig) {
 }
 
 func (instrumentationVersionOption) private() {}
+
+// WithSchemaURL sets the schema URL of the telemetry emitted by the
+// Tracer, i.e. the version of the semantic conventions its attributes
+// follow.
+func WithSchemaURL(schemaURL string) TracerOption {
+	return schemaURLOption(schemaURL)
+}
+
+type schemaURLOption string
+
+func (o schemaURLOption) ApplyTracer(config *TracerConfig) {
+	config.SchemaURL = string(o)
+}
+
+func (schemaURLOption) private() {}
+
+// WithSpanStartOptionsDefaults sets options as the default options of all
+// spans started by the Tracer, e.g. the SpanKind and attributes common to
+// the spans of a framework integration. The options passed to Start are
+// applied after these and take precedence.
+//
+// This option can be used multiple times, the default options are appended.
+func WithSpanStartOptionsDefaults(options ...SpanOption) TracerOption {
+	return spanStartOptionsDefaults(options)
+}
+
+type spanStartOptionsDefaults []SpanOption
+
+func (o spanStartOptionsDefaults) ApplyTracer(config *TracerConfig) {
+	config.SpanStartOptions = append(config.SpanStartOptions, o...)
+}
+
+func (spanStartOptionsDefaults) private() {}
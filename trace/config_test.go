@@ -78,6 +78,31 @@ func TestNewSpanConfig(t *testing.T) {
 				Attributes: []attribute.KeyValue{k1v1, k1v2, k2v2},
 			},
 		},
+		{
+			[]SpanOption{
+				WithAttributeSet(attributeSet(k2v2, k1v1)),
+			},
+			&SpanConfig{
+				// Set attributes are sorted.
+				Attributes: []attribute.KeyValue{k1v1, k2v2},
+			},
+		},
+		{
+			// Set attributes should extend other attributes.
+			[]SpanOption{
+				WithAttributes(k1v2),
+				WithAttributeSet(attributeSet(k1v1, k2v2)),
+			},
+			&SpanConfig{
+				Attributes: []attribute.KeyValue{k1v2, k1v1, k2v2},
+			},
+		},
+		{
+			[]SpanOption{
+				WithAttributeSet(nil),
+			},
+			new(SpanConfig),
+		},
 		{
 			[]SpanOption{
 				WithTimestamp(timestamp0),
@@ -174,6 +199,12 @@ func TestNewSpanConfig(t *testing.T) {
 	}
 }
 
+func TestWithStackTrace(t *testing.T) {
+	assert.False(t, NewEventConfig().StackTrace)
+	assert.True(t, NewEventConfig(WithStackTrace(true)).StackTrace)
+	assert.False(t, NewEventConfig(WithStackTrace(true), WithStackTrace(false)).StackTrace)
+}
+
 func TestTracerConfig(t *testing.T) {
 	v1 := "semver:0.0.1"
 	v2 := "semver:1.0.0"
@@ -204,9 +235,35 @@ func TestTracerConfig(t *testing.T) {
 				InstrumentationVersion: v2,
 			},
 		},
+		{
+			[]TracerOption{
+				WithSchemaURL("https://opentelemetry.io/schemas/1.2.0"),
+			},
+			&TracerConfig{
+				SchemaURL: "https://opentelemetry.io/schemas/1.2.0",
+			},
+		},
+		{
+			[]TracerOption{
+				// Multiple calls should append.
+				WithSpanStartOptionsDefaults(WithSpanKind(SpanKindConsumer)),
+				WithSpanStartOptionsDefaults(WithNewRoot()),
+			},
+			&TracerConfig{
+				SpanStartOptions: []SpanOption{
+					WithSpanKind(SpanKindConsumer),
+					WithNewRoot(),
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		config := NewTracerConfig(test.options...)
 		assert.Equal(t, test.expected, config)
 	}
 }
+
+func attributeSet(kvs ...attribute.KeyValue) *attribute.Set {
+	s := attribute.NewSet(kvs...)
+	return &s
+}
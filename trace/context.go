@@ -30,7 +30,7 @@ func ContextWithSpan(parent context.Context, span Span) context.Context {
 // no operations other than to return sc as the SpanContext from the
 // SpanContext method.
 func ContextWithSpanContext(parent context.Context, sc SpanContext) context.Context {
-	return ContextWithSpan(parent, nonRecordingSpan{sc: sc})
+	return ContextWithSpan(parent, NewNonRecordingSpan(sc))
 }
 
 // ContextWithRemoteSpanContext returns a copy of parent with rsc set explicly
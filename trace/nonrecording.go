@@ -14,6 +14,16 @@
 
 package trace // import "go.opentelemetry.io/otel/trace"
 
+// NewNonRecordingSpan returns a Span that wraps sc and is not recording.
+// It performs no operations other than to return sc from its SpanContext
+// method, e.g. to propagate an extracted SpanContext without starting a
+// span:
+//
+//	ctx = trace.ContextWithSpan(ctx, trace.NewNonRecordingSpan(sc))
+func NewNonRecordingSpan(sc SpanContext) Span {
+	return nonRecordingSpan{sc: sc}
+}
+
 // nonRecordingSpan is a minimal implementation of a Span that wraps a
 // SpanContext. It performs no operations other than to return the wrapped
 // SpanContext.
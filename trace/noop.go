@@ -42,9 +42,14 @@ type noopTracer struct{}
 
 var _ Tracer = noopTracer{}
 
-// Start starts a noop span.
+// Start starts a noop span. If ctx holds a valid SpanContext, the returned
+// span is a non-recording span wrapping it, so the returned context still
+// propagates it.
 func (t noopTracer) Start(ctx context.Context, name string, _ ...SpanOption) (context.Context, Span) {
-	span := noopSpan{}
+	var span Span = noopSpan{}
+	if sc := SpanContextFromContext(ctx); sc.IsValid() {
+		span = nonRecordingSpan{sc: sc}
+	}
 	return ContextWithSpan(ctx, span), span
 }
 
@@ -68,6 +73,9 @@ func (noopSpan) SetError(bool) {}
 // SetAttributes does nothing.
 func (noopSpan) SetAttributes(...attribute.KeyValue) {}
 
+// SetAttributeSet does nothing.
+func (noopSpan) SetAttributeSet(*attribute.Set) {}
+
 // End does nothing.
 func (noopSpan) End(...SpanOption) {}
 
@@ -80,5 +88,8 @@ func (noopSpan) Tracer() Tracer { return noopTracer{} }
 // AddEvent does nothing.
 func (noopSpan) AddEvent(string, ...EventOption) {}
 
+// AddLink does nothing.
+func (noopSpan) AddLink(Link) {}
+
 // SetName does nothing.
 func (noopSpan) SetName(string) {}
@@ -57,6 +57,50 @@ func TestNoopTracerStart(t *testing.T) {
 	}
 }
 
+func TestNoopTracerStartPreservesSpanContext(t *testing.T) {
+	sc := NewSpanContext(SpanContextConfig{
+		TraceID:    [16]byte{1},
+		SpanID:     [8]byte{1},
+		TraceFlags: FlagsSampled,
+		Remote:     true,
+	})
+	ctx := ContextWithSpanContext(context.Background(), sc)
+	tracer := NewNoopTracerProvider().Tracer("test instrumentation")
+
+	ctx, span := tracer.Start(ctx, "span name")
+	if span.IsRecording() {
+		t.Error("noopTracer.Start() returned a recording span")
+	}
+	if got := span.SpanContext(); !assertSpanContextEqual(got, sc) {
+		t.Errorf("noopTracer.Start() returned a span of %#v, want %#v", got, sc)
+	}
+	if got := SpanContextFromContext(ctx); !assertSpanContextEqual(got, sc) {
+		t.Errorf("noopTracer.Start() returned a context of %#v, want %#v", got, sc)
+	}
+}
+
+func TestNewNonRecordingSpan(t *testing.T) {
+	sc := NewSpanContext(SpanContextConfig{
+		TraceID: [16]byte{1},
+		SpanID:  [8]byte{1},
+	})
+	span := NewNonRecordingSpan(sc)
+	if span.IsRecording() {
+		t.Error("NewNonRecordingSpan() returned a recording span")
+	}
+	if got := span.SpanContext(); !assertSpanContextEqual(got, sc) {
+		t.Errorf("span.SpanContext() returned %#v, want %#v", got, sc)
+	}
+
+	// Operations on the span are noops.
+	span.SetName("name")
+	span.AddEvent("event")
+	span.End()
+	if got := SpanContextFromContext(ContextWithSpan(context.Background(), span)); !assertSpanContextEqual(got, sc) {
+		t.Errorf("SpanContextFromContext() returned %#v, want %#v", got, sc)
+	}
+}
+
 func TestNoopSpan(t *testing.T) {
 	tracer := NewNoopTracerProvider().Tracer("test instrumentation")
 	_, s := tracer.Start(context.Background(), "test span")
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -37,6 +38,12 @@ const (
 	FlagsDeferred = byte(0x02)
 	// FlagsDebug is a bitmask with the debug bit set.
 	FlagsDebug = byte(0x04)
+	// FlagsRandom is a bitmask with the random trace ID bit set. A
+	// SpanContext with the random bit set means at least the right-most 7
+	// bytes of its trace ID were generated randomly, as defined by W3C
+	// Trace Context Level 2. The W3C traceparent header carries it as its
+	// 0x02 flag.
+	FlagsRandom = byte(0x08)
 
 	errInvalidHexID errorConst = "trace-id and span-id can only contain [0-9a-f] characters, all lowercase"
 
@@ -52,11 +59,16 @@ const (
 	traceStateValueFormat                    = `[\x20-\x2b\x2d-\x3c\x3e-\x7e]{0,255}[\x21-\x2b\x2d-\x3c\x3e-\x7e]`
 
 	traceStateMaxListMembers = 32
+	traceStateMaxLength      = 512
+	// traceStateMaxEntryLength is the length above which entries are
+	// removed first when truncating a trace state.
+	traceStateMaxEntryLength = 128
 
 	errInvalidTraceStateKeyValue errorConst = "provided key or value is not valid according to the" +
 		" W3C Trace Context specification"
 	errInvalidTraceStateMembersNumber errorConst = "trace state would exceed the maximum limit of members (32)"
 	errInvalidTraceStateDuplicate     errorConst = "trace state key/value pairs with duplicate keys provided"
+	errInvalidTraceStateLength        errorConst = "trace state would exceed the maximum length (512)"
 )
 
 type errorConst string
@@ -174,7 +186,8 @@ func decodeHex(h string, b []byte) error {
 
 // TraceState provides additional vendor-specific trace identification information
 // across different distributed tracing systems. It represents an immutable list consisting
-// of key/value pairs. There can be a maximum of 32 entries in the list.
+// of key/value pairs. There can be a maximum of 32 entries in the list, and
+// its encoding, see String, can be at most 512 characters long.
 //
 // Key and value of each list member must be valid according to the W3C Trace Context specification
 // (see https://www.w3.org/TR/trace-context-1/#key and https://www.w3.org/TR/trace-context-1/#value
@@ -238,6 +251,8 @@ func (ts TraceState) Get(key attribute.Key) attribute.Value {
 // Insert adds a new key/value, if one doesn't exists; otherwise updates the existing entry.
 // The new or updated entry is always inserted at the beginning of the TraceState, i.e.
 // on the left side, as per the W3C Trace Context specification requirement.
+// The TraceState is returned unchanged with an error if the entry is invalid
+// or if the updated TraceState would exceed the limits of entries or length.
 func (ts TraceState) Insert(entry attribute.KeyValue) (TraceState, error) {
 	if !isTraceStateKeyValueValid(entry) {
 		return ts, errInvalidTraceStateKeyValue
@@ -252,6 +267,10 @@ func (ts TraceState) Insert(entry attribute.KeyValue) (TraceState, error) {
 	copy(ckvs[1:], ckvs)
 	ckvs[0] = entry
 
+	if traceStateLength(ckvs) > traceStateMaxLength {
+		return ts, errInvalidTraceStateLength
+	}
+
 	return TraceState{ckvs}, nil
 }
 
@@ -269,6 +288,46 @@ func (ts TraceState) IsEmpty() bool {
 	return len(ts.kvs) == 0
 }
 
+// Len returns the number of entries of the TraceState.
+func (ts TraceState) Len() int {
+	return len(ts.kvs)
+}
+
+// traceStateLength returns the length of the encoding of kvs, see
+// TraceState.String.
+func traceStateLength(kvs []attribute.KeyValue) int {
+	if len(kvs) == 0 {
+		return 0
+	}
+	// The commas between the entries.
+	n := len(kvs) - 1
+	for _, kv := range kvs {
+		n += traceStateEntryLength(kv)
+	}
+	return n
+}
+
+// traceStateEntryLength returns the length of the encoding of kv.
+func traceStateEntryLength(kv attribute.KeyValue) int {
+	return len(kv.Key) + len("=") + len(kv.Value.Emit())
+}
+
+// truncateTraceState removes entries of kvs until its encoding fits in the
+// maximum length, as recommended by the W3C Trace Context specification:
+// the entries longer than 128 characters are removed first, then the
+// entries at the end of kvs.
+func truncateTraceState(kvs []attribute.KeyValue) []attribute.KeyValue {
+	for i := len(kvs) - 1; i >= 0 && traceStateLength(kvs) > traceStateMaxLength; i-- {
+		if traceStateEntryLength(kvs[i]) > traceStateMaxEntryLength {
+			kvs = append(kvs[:i], kvs[i+1:]...)
+		}
+	}
+	for traceStateLength(kvs) > traceStateMaxLength {
+		kvs = kvs[:len(kvs)-1]
+	}
+	return kvs
+}
+
 func (ts TraceState) copyKVsAndDeleteEntry(key attribute.Key) []attribute.KeyValue {
 	ckvs := make([]attribute.KeyValue, len(ts.kvs))
 	copy(ckvs, ts.kvs)
@@ -283,7 +342,10 @@ func (ts TraceState) copyKVsAndDeleteEntry(key attribute.Key) []attribute.KeyVal
 }
 
 // TraceStateFromKeyValues is a convenience method to create a new TraceState from
-// provided key/value pairs.
+// provided key/value pairs. If their encoding is longer than 512 characters,
+// the entries longer than 128 characters are dropped first, starting from the
+// last one, then the last entries, until it fits, as recommended by the W3C
+// Trace Context specification.
 func TraceStateFromKeyValues(kvs ...attribute.KeyValue) (TraceState, error) { //nolint:golint
 	if len(kvs) == 0 {
 		return TraceState{}, nil
@@ -307,7 +369,7 @@ func TraceStateFromKeyValues(kvs ...attribute.KeyValue) (TraceState, error) { //
 
 	ckvs := make([]attribute.KeyValue, len(kvs))
 	copy(ckvs, kvs)
-	return TraceState{ckvs}, nil
+	return TraceState{truncateTraceState(ckvs)}, nil
 }
 
 func isTraceStateKeyValid(key attribute.Key) bool {
@@ -440,6 +502,11 @@ func (sc SpanContext) IsDebug() bool {
 	return sc.traceFlags&FlagsDebug == FlagsDebug
 }
 
+// IsRandom returns if the random trace ID bit is set in the trace flags.
+func (sc SpanContext) IsRandom() bool {
+	return sc.traceFlags&FlagsRandom == FlagsRandom
+}
+
 // IsSampled returns if the sampling bit is set in the trace flags.
 func (sc SpanContext) IsSampled() bool {
 	return sc.traceFlags&FlagsSampled == FlagsSampled
@@ -499,6 +566,11 @@ type Span interface {
 	// AddEvent adds an event with the provided name and options.
 	AddEvent(name string, options ...EventOption)
 
+	// AddLink adds a link to the Span, e.g. to a span whose SpanContext
+	// was only known after the Span started. Unlike the links passed with
+	// WithLinks, it is not available to samplers.
+	AddLink(link Link)
+
 	// IsRecording returns the recording state of the Span. It will return
 	// true if the Span is active and events can be recorded.
 	IsRecording() bool
@@ -525,6 +597,12 @@ type Span interface {
 	// already exists for an attribute of the Span it will be overwritten with
 	// the value contained in kv.
 	SetAttributes(kv ...attribute.KeyValue)
+
+	// SetAttributeSet sets the attributes contained in set as attributes of
+	// the Span. It behaves the same as SetAttributes, but since the
+	// attributes of a Set are already unique implementations can avoid
+	// deduplicating them again.
+	SetAttributeSet(set *attribute.Set)
 }
 
 // Event is a thing that happened during a Span's lifetime.
@@ -630,6 +708,38 @@ func (sk SpanKind) String() string {
 	}
 }
 
+// Valid returns true if sk is one of the SpanKind values defined by the
+// OpenTelemetry specification. SpanKindUnspecified is not valid.
+func (sk SpanKind) Valid() bool {
+	return ValidateSpanKind(sk) == sk && sk != SpanKindUnspecified
+}
+
+// ParseSpanKind returns the SpanKind named s, ParseSpanKind(sk.String())
+// returns sk for any valid SpanKind. It accepts the names returned by
+// SpanKind.String, in any case, and the OTLP enum names, e.g.
+// "SPAN_KIND_SERVER". An error and SpanKindUnspecified are returned if s does
+// not name a SpanKind.
+func ParseSpanKind(s string) (SpanKind, error) {
+	name := strings.ToLower(s)
+	name = strings.TrimPrefix(name, "span_kind_")
+	switch name {
+	case "unspecified":
+		return SpanKindUnspecified, nil
+	case "internal":
+		return SpanKindInternal, nil
+	case "server":
+		return SpanKindServer, nil
+	case "client":
+		return SpanKindClient, nil
+	case "producer":
+		return SpanKindProducer, nil
+	case "consumer":
+		return SpanKindConsumer, nil
+	default:
+		return SpanKindUnspecified, fmt.Errorf("invalid span kind %q", s)
+	}
+}
+
 // Tracer is the creator of Spans.
 type Tracer interface {
 	// Start creates a span.
@@ -16,6 +16,7 @@ package trace
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -206,6 +207,39 @@ func TestSpanContextIsSampled(t *testing.T) {
 	}
 }
 
+func TestSpanContextIsRandom(t *testing.T) {
+	for _, testcase := range []struct {
+		name string
+		sc   SpanContext
+		want bool
+	}{
+		{
+			name: "random",
+			sc:   SpanContext{traceFlags: FlagsRandom},
+			want: true,
+		}, {
+			name: "random and sampled",
+			sc:   SpanContext{traceFlags: FlagsRandom | FlagsSampled},
+			want: true,
+		}, {
+			name: "other bits are ignored, still not random",
+			sc:   SpanContext{traceFlags: ^FlagsRandom},
+			want: false,
+		}, {
+			name: "not random/default",
+			sc:   SpanContext{},
+			want: false,
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			have := testcase.sc.IsRandom()
+			if have != testcase.want {
+				t.Errorf("Want: %v, but have: %v", testcase.want, have)
+			}
+		})
+	}
+}
+
 func TestStringTraceID(t *testing.T) {
 	for _, testcase := range []struct {
 		name string
@@ -340,6 +374,54 @@ func TestSpanKindString(t *testing.T) {
 	}
 }
 
+func TestSpanKindValid(t *testing.T) {
+	for _, sk := range []SpanKind{SpanKindInternal, SpanKindServer, SpanKindClient, SpanKindProducer, SpanKindConsumer} {
+		if !sk.Valid() {
+			t.Errorf("%#v.Valid() = false, want true", sk)
+		}
+	}
+	for _, sk := range []SpanKind{SpanKindUnspecified, SpanKind(-1), SpanKind(6)} {
+		if sk.Valid() {
+			t.Errorf("%#v.Valid() = true, want false", sk)
+		}
+	}
+}
+
+func TestParseSpanKind(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    SpanKind
+		wantErr bool
+	}{
+		{"unspecified", SpanKindUnspecified, false},
+		{"internal", SpanKindInternal, false},
+		{"server", SpanKindServer, false},
+		{"Client", SpanKindClient, false},
+		{"PRODUCER", SpanKindProducer, false},
+		{"SPAN_KIND_CONSUMER", SpanKindConsumer, false},
+		{"span_kind_server", SpanKindServer, false},
+		{"", SpanKindUnspecified, true},
+		{"span_kind_", SpanKindUnspecified, true},
+		{"rpc", SpanKindUnspecified, true},
+	}
+	for _, test := range tests {
+		got, err := ParseSpanKind(test.in)
+		if (err != nil) != test.wantErr {
+			t.Errorf("ParseSpanKind(%q) error = %v, want error %t", test.in, err, test.wantErr)
+		}
+		if got != test.want {
+			t.Errorf("ParseSpanKind(%q) = %#v, want %#v", test.in, got, test.want)
+		}
+	}
+
+	// Round-trip the canonical names.
+	for sk := SpanKindUnspecified; sk <= SpanKindConsumer; sk++ {
+		if got, err := ParseSpanKind(sk.String()); err != nil || got != sk {
+			t.Errorf("ParseSpanKind(%q) = %#v, %v, want %#v", sk.String(), got, err, sk)
+		}
+	}
+}
+
 func TestTraceStateString(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -552,6 +634,31 @@ func TestTraceStateInsert(t *testing.T) {
 			expectedTraceState: TraceState{kvsWithMaxMembers},
 			expectedErr:        errInvalidTraceStateMembersNumber,
 		},
+		{
+			name: "OK case - replace with max entries",
+			traceState: TraceState{
+				kvs: kvsWithMaxMembers,
+			},
+			keyValue: kvsWithMaxMembers[31],
+			expectedTraceState: TraceState{
+				kvs: append([]attribute.KeyValue{kvsWithMaxMembers[31]}, kvsWithMaxMembers[:31]...),
+			},
+		},
+		{
+			name: "Too long",
+			traceState: TraceState{
+				kvs: []attribute.KeyValue{
+					attribute.String("key1", strings.Repeat("v", 256)),
+				},
+			},
+			keyValue: attribute.String("key2", strings.Repeat("v", 250)),
+			expectedTraceState: TraceState{
+				kvs: []attribute.KeyValue{
+					attribute.String("key1", strings.Repeat("v", 256)),
+				},
+			},
+			expectedErr: errInvalidTraceStateLength,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -569,6 +676,20 @@ func TestTraceStateInsert(t *testing.T) {
 	}
 }
 
+func TestTraceStateLen(t *testing.T) {
+	assert.Equal(t, 0, TraceState{}.Len())
+	ts, err := TraceState{}.Insert(attribute.String("key1", "val1"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, ts.Len())
+	ts, err = ts.Insert(attribute.String("key1", "val2"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, ts.Len())
+	ts, err = ts.Delete("key1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, ts.Len())
+	assert.Equal(t, 32, TraceState{kvsWithMaxMembers}.Len())
+}
+
 func TestTraceStateFromKeyValues(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -595,6 +716,55 @@ func TestTraceStateFromKeyValues(t *testing.T) {
 			expectedTraceState: TraceState{},
 			expectedErr:        errInvalidTraceStateMembersNumber,
 		},
+		{
+			name: "Too long",
+			kvs: []attribute.KeyValue{
+				attribute.String("key1", strings.Repeat("v", 256)),
+				attribute.String("key2", strings.Repeat("v", 250)),
+			},
+			expectedTraceState: TraceState{[]attribute.KeyValue{
+				attribute.String("key1", strings.Repeat("v", 256)),
+			}},
+		},
+		{
+			name: "Too long drops long entries first",
+			kvs: []attribute.KeyValue{
+				attribute.String("key1", strings.Repeat("v", 250)),
+				attribute.String("key2", strings.Repeat("v", 150)),
+				attribute.String("key3", strings.Repeat("v", 100)),
+			},
+			expectedTraceState: TraceState{[]attribute.KeyValue{
+				attribute.String("key1", strings.Repeat("v", 250)),
+				attribute.String("key3", strings.Repeat("v", 100)),
+			}},
+		},
+		{
+			name: "Too long drops last entries",
+			kvs: []attribute.KeyValue{
+				attribute.String("key1", strings.Repeat("v", 120)),
+				attribute.String("key2", strings.Repeat("v", 120)),
+				attribute.String("key3", strings.Repeat("v", 120)),
+				attribute.String("key4", strings.Repeat("v", 120)),
+				attribute.String("key5", strings.Repeat("v", 120)),
+			},
+			expectedTraceState: TraceState{[]attribute.KeyValue{
+				attribute.String("key1", strings.Repeat("v", 120)),
+				attribute.String("key2", strings.Repeat("v", 120)),
+				attribute.String("key3", strings.Repeat("v", 120)),
+				attribute.String("key4", strings.Repeat("v", 120)),
+			}},
+		},
+		{
+			name: "Max length",
+			kvs: []attribute.KeyValue{
+				attribute.String("key1", strings.Repeat("v", 256)),
+				attribute.String("key2", strings.Repeat("v", 245)),
+			},
+			expectedTraceState: TraceState{[]attribute.KeyValue{
+				attribute.String("key1", strings.Repeat("v", 256)),
+				attribute.String("key2", strings.Repeat("v", 245)),
+			}},
+		},
 		{
 			name: "Duplicate key",
 			kvs: []attribute.KeyValue{